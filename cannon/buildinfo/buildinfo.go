@@ -0,0 +1,95 @@
+// Package buildinfo identifies the build of cannon, to attest which exact VM build produced a state.
+package buildinfo
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// GitCommit is the commit that cannon is built from, set with -ldflags.
+// The VCS revision recorded by the go toolchain is used if it is not set.
+var GitCommit = ""
+
+var ErrBuildMismatch = errors.New("build does not match attestation")
+
+// Info identifies a build of cannon. Builds with the same Info are expected to be identical.
+type Info struct {
+	GitCommit string `json:"gitCommit"`
+	GoVersion string `json:"goVersion"`
+	// FlagsHash is the hash of the build settings, like the build tags, ldflags, GOOS and GOARCH.
+	FlagsHash common.Hash `json:"flagsHash"`
+}
+
+var current = sync.OnceValue(func() *Info {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return &Info{GitCommit: GitCommit}
+	}
+	return FromBuildInfo(bi, GitCommit)
+})
+
+// Current returns the Info of the running build.
+func Current() *Info {
+	return current()
+}
+
+// FromBuildInfo returns the Info of the build described by bi. gitCommit overrides the VCS revision of bi if set.
+func FromBuildInfo(bi *debug.BuildInfo, gitCommit string) *Info {
+	var revision string
+	var modified bool
+	var settings []string
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		default:
+			// The other VCS settings, like the commit time, are implied by the commit
+			if !strings.HasPrefix(s.Key, "vcs.") {
+				settings = append(settings, s.Key+"="+s.Value)
+			}
+		}
+	}
+	if gitCommit == "" {
+		gitCommit = revision
+		if modified {
+			gitCommit += "-dirty"
+		}
+	}
+	slices.Sort(settings)
+	return &Info{
+		GitCommit: gitCommit,
+		GoVersion: bi.GoVersion,
+		FlagsHash: crypto.Keccak256Hash([]byte(strings.Join(settings, "\n"))),
+	}
+}
+
+// Verify checks that the build matches the attested build.
+func (i *Info) Verify(attested *Info) error {
+	var mismatches []string
+	if i.GitCommit != attested.GitCommit {
+		mismatches = append(mismatches, fmt.Sprintf("git commit %q, attested %q", i.GitCommit, attested.GitCommit))
+	}
+	if i.GoVersion != attested.GoVersion {
+		mismatches = append(mismatches, fmt.Sprintf("go version %q, attested %q", i.GoVersion, attested.GoVersion))
+	}
+	if i.FlagsHash != attested.FlagsHash {
+		mismatches = append(mismatches, fmt.Sprintf("flags hash %s, attested %s", i.FlagsHash, attested.FlagsHash))
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: %s", ErrBuildMismatch, strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+func (i *Info) String() string {
+	return fmt.Sprintf("commit %s, %s, flags %s", i.GitCommit, i.GoVersion, i.FlagsHash)
+}
@@ -0,0 +1,55 @@
+package buildinfo
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromBuildInfo(t *testing.T) {
+	bi := &debug.BuildInfo{
+		GoVersion: "go1.23.8",
+		Settings: []debug.BuildSetting{
+			{Key: "-trimpath", Value: "true"},
+			{Key: "GOARCH", Value: "amd64"},
+			{Key: "vcs.revision", Value: "abcd"},
+			{Key: "vcs.time", Value: "2025-01-01T00:00:00Z"},
+			{Key: "vcs.modified", Value: "false"},
+		},
+	}
+	info := FromBuildInfo(bi, "")
+	require.Equal(t, "abcd", info.GitCommit)
+	require.Equal(t, "go1.23.8", info.GoVersion)
+
+	require.Equal(t, "1234", FromBuildInfo(bi, "1234").GitCommit, "commit of ldflags takes precedence")
+
+	reordered := *bi
+	reordered.Settings = []debug.BuildSetting{bi.Settings[1], bi.Settings[0], {Key: "vcs.time", Value: "2025-02-01T00:00:00Z"}}
+	require.Equal(t, info.FlagsHash, FromBuildInfo(&reordered, "").FlagsHash, "settings are sorted, vcs settings excluded")
+
+	modified := *bi
+	modified.Settings = append([]debug.BuildSetting{{Key: "-tags", Value: "debug"}}, bi.Settings[:4]...)
+	modified.Settings = append(modified.Settings, debug.BuildSetting{Key: "vcs.modified", Value: "true"})
+	other := FromBuildInfo(&modified, "")
+	require.Equal(t, "abcd-dirty", other.GitCommit)
+	require.NotEqual(t, info.FlagsHash, other.FlagsHash)
+}
+
+func TestVerify(t *testing.T) {
+	info := &Info{GitCommit: "abcd", GoVersion: "go1.23.8", FlagsHash: common.Hash{0xaa}}
+	same := *info
+	require.NoError(t, info.Verify(&same))
+
+	require.ErrorIs(t, info.Verify(&Info{GitCommit: "1234", GoVersion: "go1.23.8", FlagsHash: common.Hash{0xaa}}), ErrBuildMismatch)
+	require.ErrorIs(t, info.Verify(&Info{GitCommit: "abcd", GoVersion: "go1.23.9", FlagsHash: common.Hash{0xaa}}), ErrBuildMismatch)
+	err := info.Verify(&Info{GitCommit: "abcd", GoVersion: "go1.23.8", FlagsHash: common.Hash{0xbb}})
+	require.ErrorIs(t, err, ErrBuildMismatch)
+	require.ErrorContains(t, err, "flags hash")
+}
+
+func TestCurrent(t *testing.T) {
+	require.Same(t, Current(), Current())
+	require.NotEmpty(t, Current().GoVersion)
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	CompareStatesAFlag = &cli.PathFlag{
+		Name:      "a",
+		Usage:     "path of the first state. Use - to read the state from a stream of frames on stdin.",
+		TakesFile: true,
+		Required:  true,
+	}
+	CompareStatesBFlag = &cli.PathFlag{
+		Name:      "b",
+		Usage:     "path of the second state. Use - to read the state from a stream of frames on stdin.",
+		TakesFile: true,
+		Required:  true,
+	}
+	CompareStatesJSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "print the diff as JSON instead of text",
+	}
+	CompareStatesMaxWordsFlag = &cli.IntFlag{
+		Name:  "max-words",
+		Usage: "maximum number of differing words to print per memory page. 0 prints all of them.",
+		Value: 16,
+	}
+)
+
+// compareStatesOutput is the JSON output of compare-states.
+type compareStatesOutput struct {
+	// Version is the difference between the state versions, if they differ.
+	Version *multithreaded.FieldDiff `json:"version,omitempty"`
+	*multithreaded.StateDiff
+}
+
+func CompareStates(ctx *cli.Context) error {
+	pathA := ctx.Path(CompareStatesAFlag.Name)
+	pathB := ctx.Path(CompareStatesBFlag.Name)
+	if pathA == stream.Path && pathB == stream.Path {
+		return fmt.Errorf("only one of --%s and --%s can be read from stdin", CompareStatesAFlag.Name, CompareStatesBFlag.Name)
+	}
+	stA, err := loadMultithreadedState(pathA)
+	if err != nil {
+		return err
+	}
+	stB, err := loadMultithreadedState(pathB)
+	if err != nil {
+		return err
+	}
+
+	out := compareStatesOutput{StateDiff: multithreaded.DiffStates(stA.state, stB.state, ctx.Int(CompareStatesMaxWordsFlag.Name))}
+	if stA.version != stB.version {
+		out.Version = &multithreaded.FieldDiff{Name: "version", A: stA.version.String(), B: stB.version.String()}
+	}
+	if ctx.Bool(CompareStatesJSONFlag.Name) {
+		if err := jsonutil.WriteJSON(out, ioutil.ToStdOut()); err != nil {
+			return fmt.Errorf("failed to write diff: %w", err)
+		}
+		return nil
+	}
+	writeStatesDiff(ctx.App.Writer, out)
+	return nil
+}
+
+type loadedState struct {
+	version versions.StateVersion
+	state   *multithreaded.State
+}
+
+func loadMultithreadedState(path string) (loadedState, error) {
+	state, err := loadState(path)
+	if err != nil {
+		return loadedState{}, fmt.Errorf("invalid input state (%v): %w", path, err)
+	}
+	st, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return loadedState{}, fmt.Errorf("unsupported state type %T in %v", state.FPVMState, path)
+	}
+	return loadedState{version: state.Version, state: st}, nil
+}
+
+// writeStatesDiff writes the diff in a line-oriented text format, e.g.
+//
+//	heap: 0x4000 != 0x5000
+//	thread 0: $sp: 0x0 != 0x7fff0000
+//	page 0x1 (0x1000): 3 differing words
+//	  0x1000: 0x1 != 0x2
+func writeStatesDiff(w io.Writer, out compareStatesOutput) {
+	if out.Version == nil && out.Empty() {
+		_, _ = fmt.Fprintln(w, "states are equal")
+		return
+	}
+	if out.Version != nil {
+		_, _ = fmt.Fprintf(w, "version: %s != %s\n", out.Version.A, out.Version.B)
+	}
+	for _, f := range out.Fields {
+		_, _ = fmt.Fprintf(w, "%s: %s != %s\n", f.Name, f.A, f.B)
+	}
+	for _, t := range out.Threads {
+		if t.Only != "" {
+			_, _ = fmt.Fprintf(w, "thread %d: only in %s\n", t.ThreadId, t.Only)
+			continue
+		}
+		for _, f := range t.Fields {
+			_, _ = fmt.Fprintf(w, "thread %d: %s: %s != %s\n", t.ThreadId, f.Name, f.A, f.B)
+		}
+	}
+	for _, p := range out.Pages {
+		only := ""
+		if p.Only != "" {
+			only = ", only allocated in " + p.Only
+		}
+		_, _ = fmt.Fprintf(w, "page 0x%x (0x%x): %d differing words%s\n", p.Index, p.Address, p.DifferingWords, only)
+		for _, word := range p.Words {
+			_, _ = fmt.Fprintf(w, "  0x%x: 0x%x != 0x%x\n", word.Address, word.A, word.B)
+		}
+		if omitted := p.DifferingWords - len(p.Words); omitted > 0 {
+			_, _ = fmt.Fprintf(w, "  ... %d more\n", omitted)
+		}
+	}
+}
+
+var CompareStatesCommand = &cli.Command{
+	Name:        "compare-states",
+	Usage:       "Print the difference between two states",
+	Description: "Print the difference between two states, of the same or different versions: their scalar fields, the registers and positions of their threads, and their differing memory pages.",
+	Action:      CompareStates,
+	Flags: []cli.Flag{
+		CompareStatesAFlag,
+		CompareStatesBFlag,
+		CompareStatesJSONFlag,
+		CompareStatesMaxWordsFlag,
+	},
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// hintMetrics are the metrics of the asynchronous hint processing, served by the metrics server.
+type hintMetrics struct {
+	hints          prometheus.Counter
+	batches        prometheus.Counter
+	prefetches     prometheus.Counter
+	prefetchHits   prometheus.Counter
+	prefetchMisses prometheus.Counter
+}
+
+var _ mipsevm.AsyncHintMetrics = (*hintMetrics)(nil)
+
+func newHintMetrics(registry *prometheus.Registry) *hintMetrics {
+	factory := opmetrics.With(registry)
+	return &hintMetrics{
+		hints: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "hints_total",
+			Help:      "Hints of the guest sent to the pre-image server",
+		}),
+		batches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "hint_batches_total",
+			Help:      "Batches of queued hints sent to the pre-image server",
+		}),
+		prefetches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_prefetches_total",
+			Help:      "Pre-images prefetched after their hint",
+		}),
+		prefetchHits: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_prefetch_hits_total",
+			Help:      "Pre-image requests served from the prefetched pre-images",
+		}),
+		prefetchMisses: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_prefetch_misses_total",
+			Help:      "Pre-image requests that waited for the pre-image server",
+		}),
+	}
+}
+
+func (m *hintMetrics) RecordHintBatch(size int) {
+	m.hints.Add(float64(size))
+	m.batches.Inc()
+}
+
+func (m *hintMetrics) RecordPrefetch() {
+	m.prefetches.Inc()
+}
+
+func (m *hintMetrics) RecordPrefetchHit() {
+	m.prefetchHits.Inc()
+}
+
+func (m *hintMetrics) RecordPrefetchMiss() {
+	m.prefetchMisses.Inc()
+}
+
+// preimageCacheMetrics are the metrics of the pre-image cache, served by the metrics server.
+type preimageCacheMetrics struct {
+	hits      prometheus.Counter
+	misses    prometheus.Counter
+	evictions prometheus.Counter
+	entries   prometheus.Gauge
+	size      prometheus.Gauge
+}
+
+var _ mipsevm.PreimageCacheMetrics = (*preimageCacheMetrics)(nil)
+
+func newPreimageCacheMetrics(registry *prometheus.Registry) *preimageCacheMetrics {
+	factory := opmetrics.With(registry)
+	return &preimageCacheMetrics{
+		hits: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_cache_hits_total",
+			Help:      "Pre-image requests served from the pre-image cache",
+		}),
+		misses: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_cache_misses_total",
+			Help:      "Pre-image requests that were not in the pre-image cache",
+		}),
+		evictions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_cache_evictions_total",
+			Help:      "Pre-images evicted from the pre-image cache",
+		}),
+		entries: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_cache_entries",
+			Help:      "Pre-images in the pre-image cache",
+		}),
+		size: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "preimage_cache_size_bytes",
+			Help:      "Combined size of the pre-images in the pre-image cache",
+		}),
+	}
+}
+
+func (m *preimageCacheMetrics) RecordPreimageCacheHit() {
+	m.hits.Inc()
+}
+
+func (m *preimageCacheMetrics) RecordPreimageCacheMiss() {
+	m.misses.Inc()
+}
+
+func (m *preimageCacheMetrics) RecordPreimageCacheEviction() {
+	m.evictions.Inc()
+}
+
+func (m *preimageCacheMetrics) RecordPreimageCacheSize(entries int, size uint64) {
+	m.entries.Set(float64(entries))
+	m.size.Set(float64(size))
+}
@@ -6,14 +6,15 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
-	"github.com/ethereum-optimism/optimism/op-service/serialize"
 )
 
 var (
@@ -24,13 +25,23 @@ var (
 	}
 	LoadELFPathFlag = &cli.PathFlag{
 		Name:      "path",
-		Usage:     "Path to 32/64-bit big-endian MIPS ELF file",
+		Usage:     "Path to 32/64-bit big-endian MIPS ELF file. Either this or --manifest is required.",
 		TakesFile: true,
-		Required:  true,
+	}
+	LoadELFManifestFlag = &cli.PathFlag{
+		Name: "manifest",
+		Usage: "Path to a JSON manifest of multiple ELF and raw files to load into a single state, e.g. a loader plus a payload. " +
+			"Either this or --path is required.",
+		TakesFile: true,
+	}
+	LoadELFMuslFlag = &cli.BoolFlag{
+		Name: "musl",
+		Usage: "Add the program headers of the entry ELF to the auxiliary vector of the initial stack, for statically linked musl programs, " +
+			"e.g. Rust programs built for linux/mips64 musl. The program requires a VM type with support for the musl runtime.",
 	}
 	LoadELFOutFlag = &cli.PathFlag{
 		Name:     "out",
-		Usage:    "Output path to write state to. State is dumped to stdout if set to '-'. Not written if empty. Use file extension '.bin', '.bin.gz', or '.json' for binary, compressed binary, or JSON formats.",
+		Usage:    "Output path to write state to. State is written as a frame to stdout if set to '-', see the stream package. Not written if empty. Use file extension '.bin', '.bin.gz', or '.json' for binary, compressed binary, or JSON formats.",
 		Value:    "state.bin.gz",
 		Required: false,
 	}
@@ -43,31 +54,66 @@ var (
 )
 
 func LoadELF(ctx *cli.Context) error {
-	elfPath := ctx.Path(LoadELFPathFlag.Name)
-	elfProgram, err := elf.Open(elfPath)
-	if err != nil {
-		return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
-	}
-	if elfProgram.Machine != elf.EM_MIPS {
-		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
+	var images []program.Image
+	if ctx.IsSet(LoadELFManifestFlag.Name) == ctx.IsSet(LoadELFPathFlag.Name) {
+		return fmt.Errorf("either --%s or --%s must be set", LoadELFPathFlag.Name, LoadELFManifestFlag.Name)
+	} else if ctx.Path(LoadELFOutFlag.Name) == stream.Path && ctx.Path(LoadELFMetaFlag.Name) == stream.Path {
+		return fmt.Errorf("--%s and --%s cannot both be written to stdout", LoadELFOutFlag.Name, LoadELFMetaFlag.Name)
+	} else if ctx.IsSet(LoadELFManifestFlag.Name) {
+		manifest, err := program.ReadManifest(ctx.Path(LoadELFManifestFlag.Name))
+		if err != nil {
+			return err
+		}
+		var closeImages func() error
+		images, closeImages, err = manifest.Open()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = closeImages() }()
+	} else {
+		elfPath := ctx.Path(LoadELFPathFlag.Name)
+		elfProgram, err := elf.Open(elfPath)
+		if err != nil {
+			return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
+		}
+		if err := program.CheckELF(elfProgram); err != nil {
+			return fmt.Errorf("invalid ELF file %q: %w", elfPath, err)
+		}
+		images = []program.Image{{Name: elfPath, ELF: elfProgram, Entry: true}}
 	}
 
-	var createInitialState func(f *elf.File) (mipsevm.FPVMState, error)
+	var createInitialState func(images []program.Image) (mipsevm.FPVMState, error)
 
 	var patcher = program.PatchStack
+	if ctx.Bool(LoadELFMuslFlag.Name) {
+		var auxv []program.AuxvEntry
+		for _, img := range images {
+			if !img.Entry {
+				continue
+			}
+			entryAuxv, err := program.ProgramHeadersAuxv(img.ELF)
+			if err != nil {
+				return fmt.Errorf("invalid musl program %q: %w", img.Name, err)
+			}
+			auxv = entryAuxv
+		}
+		patcher = func(st mipsevm.FPVMState) error {
+			return program.PatchStackWithAuxv(st, auxv...)
+		}
+	}
 	ver, err := versions.ParseStateVersion(ctx.String(LoadELFVMTypeFlag.Name))
 	if err != nil {
 		return err
 	}
 	if versions.IsSupportedMultiThreaded64(ver) {
-		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
-			return program.LoadELF(f, multithreaded.CreateInitialState)
+		createInitialState = func(images []program.Image) (mipsevm.FPVMState, error) {
+			return program.LoadImages(images, multithreaded.CreateInitialState)
 		}
 	} else {
 		return fmt.Errorf("unsupported state version: %d (%s)", ver, ver.String())
 	}
 
-	state, err := createInitialState(elfProgram)
+	state, err := createInitialState(images)
 	if err != nil {
 		return fmt.Errorf("failed to load ELF data into VM state: %w", err)
 	}
@@ -75,7 +121,7 @@ func LoadELF(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to patch state: %w", err)
 	}
-	meta, err := program.MakeMetadata(elfProgram)
+	meta, err := program.MakeImagesMetadata(images)
 	if err != nil {
 		return fmt.Errorf("failed to compute program metadata: %w", err)
 	}
@@ -88,7 +134,8 @@ func LoadELF(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create versioned state: %w", err)
 	}
-	return serialize.Write(ctx.Path(LoadELFOutFlag.Name), versionedState, OutFilePerm)
+	versionedState.Build = buildinfo.Current()
+	return writeState(ctx.Path(LoadELFOutFlag.Name), versionedState)
 }
 
 func CreateLoadELFCommand(action cli.ActionFunc) *cli.Command {
@@ -100,6 +147,8 @@ func CreateLoadELFCommand(action cli.ActionFunc) *cli.Command {
 		Flags: []cli.Flag{
 			LoadELFVMTypeFlag,
 			LoadELFPathFlag,
+			LoadELFManifestFlag,
+			LoadELFMuslFlag,
 			LoadELFOutFlag,
 			LoadELFMetaFlag,
 		},
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -12,9 +13,14 @@ type VMState interface {
 
 type StepMatcher func(st VMState) bool
 
+// NextMatch returns the first step at or after the given step that a matcher matches,
+// or math.MaxUint64 if there is none.
+type NextMatch func(step uint64) uint64
+
 type StepMatcherFlag struct {
 	repr    string
 	matcher StepMatcher
+	next    NextMatch
 }
 
 func MustStepMatcherFlag(pattern string) *StepMatcherFlag {
@@ -31,10 +37,14 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return false
 		}
+		m.next = neverMatches
 	} else if value == "always" {
 		m.matcher = func(st VMState) bool {
 			return true
 		}
+		m.next = func(step uint64) uint64 {
+			return step
+		}
 	} else if strings.HasPrefix(value, "=") {
 		when, err := strconv.ParseUint(value[1:], 0, 64)
 		if err != nil {
@@ -43,6 +53,12 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return st.GetStep() == when
 		}
+		m.next = func(step uint64) uint64 {
+			if step > when {
+				return math.MaxUint64
+			}
+			return when
+		}
 	} else if strings.HasPrefix(value, "%") {
 		when, err := strconv.ParseUint(value[1:], 0, 64)
 		if err != nil {
@@ -51,6 +67,15 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return st.GetStep()%when == 0
 		}
+		m.next = func(step uint64) uint64 {
+			if rem := step % when; rem != 0 {
+				if next := step + (when - rem); next > step {
+					return next
+				}
+				return math.MaxUint64
+			}
+			return step
+		}
 	} else {
 		return fmt.Errorf("unrecognized step matcher: %q", value)
 	}
@@ -70,6 +95,18 @@ func (m *StepMatcherFlag) Matcher() StepMatcher {
 	return m.matcher
 }
 
+// NextMatch returns the function to find the next step the matcher matches, to skip ahead to it.
+func (m *StepMatcherFlag) NextMatch() NextMatch {
+	if m.next == nil {
+		return neverMatches
+	}
+	return m.next
+}
+
+func neverMatches(uint64) uint64 {
+	return math.MaxUint64
+}
+
 func (m *StepMatcherFlag) Clone() any {
 	var out StepMatcherFlag
 	if err := out.Set(m.repr); err != nil {
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/pprof/profile"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+// profileReportTop is the number of the hottest functions that are logged when a profiled run stops.
+const profileReportTop = 10
+
+var (
+	ProfileInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of a pprof profile written by `cannon run --profile`",
+		TakesFile: true,
+		Required:  true,
+	}
+	ProfileTopFlag = &cli.IntFlag{
+		Name:  "top",
+		Usage: "number of the hottest functions and instructions to report",
+		Value: 20,
+	}
+)
+
+// writeProfile writes the instruction-level profile of a run in the gzipped pprof format,
+// recording the state version so that multicannon can dispatch the profile to the matching cannon.
+func writeProfile(path string, prof *mipsevm.Profile, ver versions.StateVersion) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, OutFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+	defer f.Close()
+	pp := prof.PProf()
+	pp.Comments = append(pp.Comments, versions.ProfileComment(ver))
+	if err := pp.Write(f); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	return f.Close()
+}
+
+func Profile(ctx *cli.Context) error {
+	input := ctx.Path(ProfileInputFlag.Name)
+	f, err := os.Open(input)
+	if err != nil {
+		return fmt.Errorf("failed to open profile: %w", err)
+	}
+	defer f.Close()
+	pp, err := profile.Parse(f)
+	if err != nil {
+		return fmt.Errorf("failed to parse profile (%v): %w", input, err)
+	}
+	prof, err := mipsevm.ProfileFromPProf(pp)
+	if err != nil {
+		return fmt.Errorf("invalid profile (%v): %w", input, err)
+	}
+	return prof.WriteReport(os.Stdout, ctx.Int(ProfileTopFlag.Name))
+}
+
+func CreateProfileCommand(action cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "profile",
+		Usage: "Report the hot paths of an instruction-level profile of the guest program",
+		Description: "Report the functions and instructions of the guest program that most steps were spent in, " +
+			"from a profile written by `cannon run --profile`. The profile can also be inspected with `go tool pprof`.",
+		Action: action,
+		Flags: []cli.Flag{
+			ProfileInputFlag,
+			ProfileTopFlag,
+		},
+	}
+}
+
+var ProfileCommand = CreateProfileCommand(Profile)
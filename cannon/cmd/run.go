@@ -1,7 +1,11 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -16,30 +20,34 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/pkg/profile"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
-	mipsexec "github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/serialize"
 )
 
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input binary state. Stdin if left empty.",
+		Usage:     "path of input binary state. Use - to read the state from a stream of frames on stdin, see the stream package.",
 		TakesFile: true,
 		Value:     "state.bin.gz",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output binary state. Not written if empty, use - to write to Stdout.",
+		Usage:     "path of output binary state. Not written if empty, use - to write the state as a frame to stdout.",
 		TakesFile: true,
 		Value:     "out.bin.gz",
 		Required:  false,
@@ -53,10 +61,15 @@ var (
 	}
 	RunProofFmtFlag = &cli.StringFlag{
 		Name:     "proof-fmt",
-		Usage:    "format for proof data output file names. Proof data is written to stdout if -.",
+		Usage:    "format for proof data output file names. Use - to write the proof data as frames to stdout.",
 		Value:    "proof-%d.json",
 		Required: false,
 	}
+	RunProofCompactFlag = &cli.BoolFlag{
+		Name:     "proof-compact",
+		Usage:    "write proof data in the compact encoding, deduplicating memory proof nodes. The proof data must be expanded again before use on-chain.",
+		Required: false,
+	}
 	RunSnapshotAtFlag = &cli.GenericFlag{
 		Name:     "snapshot-at",
 		Usage:    "step pattern to output snapshots at: " + patternHelp,
@@ -69,6 +82,12 @@ var (
 		Value:    "state-%d.bin.gz",
 		Required: false,
 	}
+	RunSnapshotFullEveryFlag = &cli.Uint64Flag{
+		Name:     "snapshot-full-every",
+		Usage:    "write every n-th snapshot in full, and the snapshots in between as deltas against the last full snapshot. 1 writes all snapshots in full. See the snapshots command to restore deltas.",
+		Value:    1,
+		Required: false,
+	}
 	RunStopAtFlag = &cli.GenericFlag{
 		Name:     "stop-at",
 		Usage:    "step pattern to stop at: " + patternHelp,
@@ -96,12 +115,23 @@ var (
 		Value:    "meta.json",
 		Required: false,
 	}
+	RunELFFlag = &cli.PathFlag{
+		Name:      "elf",
+		Usage:     "path to the ELF of the guest program, to resolve the PC and return address of a VM fault to source lines with its DWARF info. Symbols are resolved with --meta, or with the ELF if there is no metadata.",
+		TakesFile: true,
+		Required:  false,
+	}
 	RunInfoAtFlag = &cli.GenericFlag{
 		Name:     "info-at",
 		Usage:    "step pattern to print info at: " + patternHelp,
 		Value:    MustStepMatcherFlag("%100000"),
 		Required: false,
 	}
+	RunHashWorkersFlag = &cli.IntFlag{
+		Name:  "hash-workers",
+		Usage: "number of workers that hash memory pages in parallel when computing state witnesses and proofs. The results are identical for any number of workers.",
+		Value: 1,
+	}
 	RunPProfCPU = &cli.BoolFlag{
 		Name:  "pprof.cpu",
 		Usage: "enable pprof cpu profiling",
@@ -110,12 +140,91 @@ var (
 		Name:  "debug",
 		Usage: "enable debug mode, which includes stack traces and other debug info in the output. Requires --meta.",
 	}
+	RunDebugStackGuardFlag = &cli.BoolFlag{
+		Name:  "debug-stack-guard",
+		Usage: "stop with a traceback when a guest thread overflows its stack. Only affects the run, not the state transition. Requires --debug.",
+	}
+	RunDebugMainStackSizeFlag = &cli.Uint64Flag{
+		Name:  "debug-main-stack-size",
+		Usage: "size of the stack of the initial guest thread, checked by --debug-stack-guard",
+		Value: uint64(mipsevm.DefaultStackGuardConfig().MainStackSize),
+	}
+	RunDebugThreadStackSizeFlag = &cli.Uint64Flag{
+		Name:  "debug-thread-stack-size",
+		Usage: "size of the stack of guest threads created with clone, checked by --debug-stack-guard",
+		Value: uint64(mipsevm.DefaultStackGuardConfig().ThreadStackSize),
+	}
 	RunDebugInfoFlag = &cli.PathFlag{
 		Name:      "debug-info",
 		Usage:     "path to write debug info to",
 		TakesFile: true,
 		Required:  false,
 	}
+	RunSyscallStatsFlag = &cli.PathFlag{
+		Name:      "syscall-stats",
+		Usage:     "path to write per-syscall frequency and latency statistics to",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunAccessStatsFlag = &cli.PathFlag{
+		Name:      "access-stats",
+		Usage:     "path to write the memory accesses and the size of their Merkle proofs per window of steps to",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunAccessStatsWindowFlag = &cli.Uint64Flag{
+		Name:  "access-stats-window",
+		Usage: "number of steps per window of --access-stats",
+		Value: 1_000_000,
+	}
+	RunPanicOutputFlag = &cli.PathFlag{
+		Name:      "panic-output",
+		Usage:     "path to write the panic message and goroutine backtraces to, if the guest program panics",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunPreimageManifestFlag = &cli.PathFlag{
+		Name:      "preimage-manifest",
+		Usage:     "path to write the manifest of every preimage requested during the run (type, key, size, first step) to",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunProfileFlag = &cli.PathFlag{
+		Name:      "profile",
+		Usage:     "path to write a pprof profile of the instructions the guest executed at each PC to, and report the hottest functions when the run stops",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunProfileIntervalFlag = &cli.Uint64Flag{
+		Name:  "profile-interval",
+		Usage: "number of steps per sample of --profile. Instructions are counted exactly with the default of 1.",
+		Value: 1,
+	}
+	RunSyncHintsFlag = &cli.BoolFlag{
+		Name:  "sync-hints",
+		Usage: "process the hints of the guest synchronously, stalling the VM until the pre-image server handled each hint, instead of queueing them and prefetching the hinted pre-images in the background",
+	}
+	RunPreimageCacheSizeFlag = &cli.Uint64Flag{
+		Name:  "preimage-cache-size",
+		Usage: "combined size in bytes of the pre-images of the pre-image server to cache in memory, to serve repeated requests of the same pre-image without the server. The least recently requested pre-images are evicted. 0 disables the cache.",
+	}
+	RunPreimageCacheEntriesFlag = &cli.IntFlag{
+		Name:  "preimage-cache-entries",
+		Usage: "maximum number of pre-images in the pre-image cache",
+		Value: 1 << 16,
+	}
+	RunRecordSyscallsFlag = &cli.PathFlag{
+		Name:      "record-syscalls",
+		Usage:     "path to record every syscall of the guest to, with its arguments, return values, and the hints and preimages of the oracle, as a line of JSON per syscall. Use with --replay-syscalls to reproduce the run without the pre-image server.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunReplaySyscallsFlag = &cli.PathFlag{
+		Name:      "replay-syscalls",
+		Usage:     "path of a recording of --record-syscalls to replay: the recorded preimages are served instead of a pre-image server, and the run fails at the first syscall that differs from the recording. The run must start from the same state as the recording.",
+		TakesFile: true,
+		Required:  false,
+	}
 
 	OutFilePerm = os.FileMode(0o755)
 )
@@ -128,10 +237,15 @@ type Proof struct {
 
 	StateData hexutil.Bytes `json:"state-data"`
 	ProofData hexutil.Bytes `json:"proof-data"`
+	// CompactProofData is the compact encoding of the proof data, set instead of ProofData if enabled.
+	CompactProofData hexutil.Bytes `json:"compact-proof-data,omitempty"`
 
 	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
 	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
 	OracleOffset arch.Word     `json:"oracle-offset,omitempty"`
+
+	// Build is the build of cannon that produced the proof.
+	Build *buildinfo.Info `json:"build,omitempty"`
 }
 
 type rawHint string
@@ -262,32 +376,80 @@ func (p *ProcessPreimageOracle) wait() {
 
 type StepFn func(proof bool) (*mipsevm.StepWitness, error)
 
+// RunStepsFn runs up to n steps without proofs, see mipsevm.FPVM.RunSteps.
+type RunStepsFn func(n uint64) (uint64, error)
+
 func Guard(proc *os.ProcessState, fn StepFn) StepFn {
 	return func(proof bool) (wit *mipsevm.StepWitness, err error) {
-		defer func() {
-			if r := recover(); r != nil {
-				const size = 64 << 10
-				buf := make([]byte, size)
-				buf = buf[:runtime.Stack(buf, false)]
-				if proc.Exited() {
-					err = fmt.Errorf("pre-image server exited with code %d, resulting in panic %s", proc.ExitCode(), string(buf))
-				} else {
-					err = fmt.Errorf("pre-image server resulted in panic %s", string(buf))
-				}
-			}
-		}()
+		defer recoverPreimageServerPanic(proc, &err)
 		wit, err = fn(proof)
 		if err != nil {
-			if proc.Exited() {
-				return nil, fmt.Errorf("pre-image server exited with code %d, resulting in err %w", proc.ExitCode(), err)
-			} else {
-				return nil, err
-			}
+			return nil, preimageServerErr(proc, err)
 		}
 		return wit, nil
 	}
 }
 
+// GuardRunSteps is Guard for runs of multiple steps.
+func GuardRunSteps(proc *os.ProcessState, fn RunStepsFn) RunStepsFn {
+	return func(n uint64) (executed uint64, err error) {
+		defer recoverPreimageServerPanic(proc, &err)
+		executed, err = fn(n)
+		if err != nil {
+			return executed, preimageServerErr(proc, err)
+		}
+		return executed, nil
+	}
+}
+
+// recoverPreimageServerPanic turns a panic of the step into an error: of the pre-image server if it exited,
+// or of the VM otherwise, e.g. an illegal instruction or misaligned PC of the guest program.
+// The process state is nil without a pre-image server.
+func recoverPreimageServerPanic(proc *os.ProcessState, err *error) {
+	if r := recover(); r != nil {
+		const size = 64 << 10
+		buf := make([]byte, size)
+		buf = buf[:runtime.Stack(buf, false)]
+		if proc != nil && proc.Exited() {
+			*err = fmt.Errorf("pre-image server exited with code %d, resulting in panic %s", proc.ExitCode(), string(buf))
+		} else {
+			*err = fmt.Errorf("VM panic: %v\n%s", r, string(buf))
+		}
+	}
+}
+
+func preimageServerErr(proc *os.ProcessState, err error) error {
+	if proc != nil && proc.Exited() {
+		return fmt.Errorf("pre-image server exited with code %d, resulting in err %w", proc.ExitCode(), err)
+	}
+	return err
+}
+
+// locateFault resolves the PC and the return address of a failed step to the nearest symbols,
+// and to source lines if the ELF of the guest program has DWARF info, and logs them.
+// It returns the locations, to include in the error of the step.
+func locateFault(l log.Logger, elfPath string, meta *program.Metadata, state mipsevm.FPVMState) string {
+	var elfProgram *elf.File
+	if elfPath != "" {
+		f, err := elf.Open(elfPath)
+		if err != nil {
+			l.Warn("Failed to open ELF to locate fault", "err", err)
+		} else {
+			defer f.Close()
+			elfProgram = f
+		}
+	}
+	symbolizer, err := program.NewSymbolizer(meta, elfProgram)
+	if err != nil {
+		l.Warn("Failed to load symbols of ELF to locate fault", "err", err)
+		symbolizer, _ = program.NewSymbolizer(meta, nil)
+	}
+	pc := symbolizer.Locate(state.GetPC())
+	ra := symbolizer.Locate(state.GetRegistersRef()[31])
+	l.Error("VM fault", "pc", pc.String(), "ra", ra.String())
+	return fmt.Sprintf("PC: %s, RA: %s", pc, ra)
+}
+
 var _ mipsevm.PreimageOracle = (*ProcessPreimageOracle)(nil)
 
 func Run(ctx *cli.Context) error {
@@ -299,8 +461,9 @@ func Run(ctx *cli.Context) error {
 	}
 
 	guestLogger := Logger(os.Stderr, log.LevelInfo)
-	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
-	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+	// Go panics are written to stderr, but guest programs may redirect them, so both streams are inspected.
+	outLog := mipsevm.NewPanicDetector(&mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")})
+	errLog := mipsevm.NewPanicDetector(&mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")})
 
 	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
 
@@ -363,7 +526,12 @@ func Run(ctx *cli.Context) error {
 		args = []string{""}
 	}
 
-	poOut := Logger(os.Stdout, log.LevelInfo).With("module", "host")
+	// The output of the pre-image server is logged to stderr if stdout is used for the stream of states and proofs
+	poOutStream := os.Stdout
+	if streamsToStdout(ctx) {
+		poOutStream = os.Stderr
+	}
+	poOut := Logger(poOutStream, log.LevelInfo).With("module", "host")
 	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
 	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
 	if err != nil {
@@ -395,12 +563,105 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	state, err := versions.LoadStateFromFile(ctx.Path(RunInputFlag.Name))
+	state, err := loadState(ctx.Path(RunInputFlag.Name))
 	if err != nil {
 		return fmt.Errorf("failed to load state: %w", err)
 	}
 	l.Info("Loaded input state", "version", state.Version)
-	vm := state.CreateVM(l, po, outLog, errLog, meta)
+	// The snapshots, proofs and output state are produced by this build
+	state.Build = buildinfo.Current()
+	state.GetMemory().SetHashWorkers(ctx.Int(RunHashWorkersFlag.Name))
+
+	var telemetry *Telemetry
+	telemetryInterval := ctx.Duration(RunTelemetryIntervalFlag.Name)
+	var registry *prometheus.Registry
+	if metricsCfg := opmetrics.ReadCLIConfig(ctx); metricsCfg.Enabled {
+		registry = opmetrics.NewRegistry()
+		l.Info("Starting metrics server", "addr", metricsCfg.ListenAddr, "port", metricsCfg.ListenPort)
+		metricsSrv, err := opmetrics.StartServer(registry, metricsCfg.ListenAddr, metricsCfg.ListenPort)
+		if err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		defer func() {
+			if err := metricsSrv.Stop(context.Background()); err != nil {
+				l.Error("Failed to stop metrics server", "err", err)
+			}
+		}()
+		if telemetryInterval == 0 {
+			telemetryInterval = defaultTelemetryInterval
+		}
+		telemetry = NewTelemetry(l, telemetryInterval, registry, state.GetStep())
+	} else if telemetryInterval > 0 {
+		telemetry = NewTelemetry(l, telemetryInterval, nil, state.GetStep())
+	}
+
+	var oracle mipsevm.PreimageOracle = po
+	if po.cmd != nil && !ctx.Bool(RunSyncHintsFlag.Name) {
+		var metrics mipsevm.AsyncHintMetrics
+		if registry != nil {
+			metrics = newHintMetrics(registry)
+		}
+		asyncHints := mipsevm.NewAsyncHintOracle(po, mipsevm.KeccakHintPrefetchKey(mipsevm.DefaultPrefetchHintTypes...), metrics)
+		// Runs before the pre-image server is closed.
+		defer func() {
+			asyncHints.Close()
+			stats := asyncHints.Stats()
+			l.Info("Hint stats", "hints", stats.Hints, "batches", stats.Batches, "prefetches", stats.Prefetches,
+				"prefetch_hits", stats.PrefetchHits, "prefetch_misses", stats.PrefetchMisses, "hit_rate", stats.HitRate())
+		}()
+		oracle = asyncHints
+	}
+	if cacheSize := ctx.Uint64(RunPreimageCacheSizeFlag.Name); po.cmd != nil && cacheSize > 0 {
+		var metrics mipsevm.PreimageCacheMetrics
+		if registry != nil {
+			metrics = newPreimageCacheMetrics(registry)
+		}
+		cache, err := mipsevm.NewPreimageCache(oracle, ctx.Int(RunPreimageCacheEntriesFlag.Name), cacheSize, metrics)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", RunPreimageCacheEntriesFlag.Name, err)
+		}
+		defer func() {
+			stats := cache.Stats()
+			l.Info("Pre-image cache stats", "hits", stats.Hits, "misses", stats.Misses, "evictions", stats.Evictions,
+				"entries", stats.Entries, "size", stats.Size, "hit_rate", stats.HitRate())
+		}()
+		oracle = cache
+	}
+	var replayer *mipsevm.SyscallReplayer
+	if replayPath := ctx.Path(RunReplaySyscallsFlag.Name); replayPath != "" {
+		if po.cmd != nil {
+			return errors.New("cannot replay syscalls with a pre-image server, the recorded preimages are used instead")
+		}
+		replayer, err = loadSyscallReplayer(replayPath)
+		if err != nil {
+			return err
+		}
+		oracle = replayer
+	}
+	var recorder *mipsevm.SyscallRecorder
+	if recordPath := ctx.Path(RunRecordSyscallsFlag.Name); recordPath != "" {
+		out, err := ioutil.OpenCompressed(recordPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to open syscall recording: %w", err)
+		}
+		// The recording is kept if the run fails, to reproduce the failure.
+		buf := bufio.NewWriter(out)
+		defer func() {
+			if err := buf.Flush(); err != nil {
+				l.Error("Failed to write syscall recording", "err", err)
+			}
+			if err := out.Close(); err != nil {
+				l.Error("Failed to close syscall recording", "err", err)
+			}
+		}()
+		recorder = mipsevm.NewSyscallRecorder(oracle, buf)
+		oracle = recorder
+	}
+	vm := state.CreateVM(l, oracle, outLog, errLog, meta)
+	validation, err := newOnchainValidation(ctx, l, state.Version, oracle)
+	if err != nil {
+		return err
+	}
 
 	// Enable debug/stats tracking as requested
 	debugProgram := ctx.Bool(RunDebugFlag.Name)
@@ -412,16 +673,70 @@ func Run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to initialize debug mode: %w", err)
 		}
 	}
+	if ctx.Bool(RunDebugStackGuardFlag.Name) {
+		if !debugProgram {
+			return errors.New("cannot enable the stack guard without debug mode")
+		}
+		cfg := mipsevm.DefaultStackGuardConfig()
+		cfg.MainStackSize = arch.Word(ctx.Uint64(RunDebugMainStackSizeFlag.Name))
+		cfg.ThreadStackSize = arch.Word(ctx.Uint64(RunDebugThreadStackSizeFlag.Name))
+		vm.EnableStackGuard(cfg)
+	}
 	if debugInfoFile := ctx.Path(RunDebugInfoFlag.Name); debugInfoFile != "" {
 		vm.EnableStats()
 	}
+	if syscallStatsFile := ctx.Path(RunSyscallStatsFlag.Name); syscallStatsFile != "" {
+		vm.EnableSyscallStats()
+	}
+	if recorder != nil {
+		vm.EnableSyscallObserver(recorder)
+	} else if replayer != nil {
+		vm.EnableSyscallObserver(replayer)
+	}
+	if accessStatsFile := ctx.Path(RunAccessStatsFlag.Name); accessStatsFile != "" {
+		window := ctx.Uint64(RunAccessStatsWindowFlag.Name)
+		if window == 0 {
+			return fmt.Errorf("invalid %v: must not be zero", RunAccessStatsWindowFlag.Name)
+		}
+		vm.EnableAccessTracing(window)
+	}
+
+	profilePath := ctx.Path(RunProfileFlag.Name)
+	if profilePath != "" {
+		interval := ctx.Uint64(RunProfileIntervalFlag.Name)
+		if interval == 0 {
+			return fmt.Errorf("invalid %v: must not be zero", RunProfileIntervalFlag.Name)
+		}
+		vm.EnableProfiling(interval)
+	}
+
+	var preimageManifest *mipsevm.PreimageManifest
+	if ctx.Path(RunPreimageManifestFlag.Name) != "" {
+		preimageManifest = mipsevm.NewPreimageManifest()
+	}
 
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
+	proofCompact := ctx.Bool(RunProofCompactFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
+	snapshots := versions.NewSnapshotWriter(ctx.Uint64(RunSnapshotFullEveryFlag.Name), OutFilePerm)
 
-	stepFn := vm.Step
+	// VM panics are always recovered, to report the fault in the guest program
+	var proc *os.ProcessState
 	if po.cmd != nil {
-		stepFn = Guard(po.cmd.ProcessState, stepFn)
+		proc = po.cmd.ProcessState
+	}
+	stepFn := Guard(proc, vm.Step)
+	runStepsFn := GuardRunSteps(proc, vm.RunSteps)
+	elfPath := ctx.Path(RunELFFlag.Name)
+
+	// Steps run in batches, up to the next step a step matcher matches, unless there are checks after every step
+	batchSteps := validation == nil && preimageManifest == nil &&
+		!stopAtAnyPreimage && len(stopAtPreimageKeyPrefix) == 0 && stopAtPreimageLargerThan == 0
+	nextMatches := []NextMatch{
+		ctx.Generic(RunStopAtFlag.Name).(*StepMatcherFlag).NextMatch(),
+		ctx.Generic(RunProofAtFlag.Name).(*StepMatcherFlag).NextMatch(),
+		ctx.Generic(RunSnapshotAtFlag.Name).(*StepMatcherFlag).NextMatch(),
+		ctx.Generic(RunInfoAtFlag.Name).(*StepMatcherFlag).NextMatch(),
 	}
 
 	start := time.Now()
@@ -430,20 +745,25 @@ func Run(ctx *cli.Context) error {
 
 	for !state.GetExited() {
 		step := state.GetStep()
+		validate := validation != nil && validation.sample()
 		if step%100 == 0 { // don't do the ctx err check (includes lock) too often
 			if err := ctx.Context.Err(); err != nil {
 				return err
 			}
+			if telemetry != nil && telemetry.Due() {
+				telemetry.Sample(state)
+			}
 		}
 
 		if infoAt(state) {
 			delta := time.Since(start)
 			pc := state.GetPC()
-			insn := mipsexec.LoadSubWord(state.GetMemory(), pc, 4, false, new(mipsexec.NoopMemoryTracker))
+			insn := binary.BigEndian.Uint32(state.GetMemory().ReadRegion(pc, 4))
 			l.Info("processing",
 				"step", step,
 				"pc", mipsevm.HexU32(state.GetPC()),
 				"insn", mipsevm.HexU32(insn),
+				"disasm", disasm.Disassemble(uint64(pc), insn),
 				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
 				"pages", state.GetMemory().PageCount(),
 				"mem", state.GetMemory().Usage(),
@@ -462,7 +782,7 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := serialize.Write(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
+			if _, err := snapshots.Write(fmt.Sprintf(snapshotFmt, step), state); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
@@ -470,7 +790,16 @@ func Run(ctx *cli.Context) error {
 		if proofAt(state) {
 			witness, err := stepFn(true)
 			if err != nil {
-				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.GetPC(), err)
+				if debugProgram {
+					vm.Traceback()
+				}
+				return fmt.Errorf("failed at proof-gen step %d (%s): %w", step, locateFault(l, elfPath, meta, state.FPVMState), err)
+			}
+			if telemetry != nil {
+				telemetry.AddWitness(witness)
+			}
+			if validate {
+				validation.validate(step, witness, state)
 			}
 			_, postStateHash := state.EncodeWitness()
 			proof := &Proof{
@@ -478,25 +807,56 @@ func Run(ctx *cli.Context) error {
 				Pre:       witness.StateHash,
 				Post:      postStateHash,
 				StateData: witness.State,
-				ProofData: witness.ProofData,
+				Build:     state.Build,
+			}
+			if proofCompact {
+				compact, err := mipsevm.CompactProofData(witness.ProofData)
+				if err != nil {
+					return fmt.Errorf("failed to compact proof data at step %d: %w", step, err)
+				}
+				proof.CompactProofData = compact
+			} else {
+				proof.ProofData = witness.ProofData
 			}
 			if witness.HasPreimage() {
 				proof.OracleKey = witness.PreimageKey[:]
 				proof.OracleValue = witness.PreimageValue
 				proof.OracleOffset = witness.PreimageOffset
 			}
-			if err := jsonutil.WriteJSON(proof, ioutil.ToStdOutOrFileOrNoop(fmt.Sprintf(proofFmt, step), OutFilePerm)); err != nil {
+			if err := writeProof(proofFmt, proof); err != nil {
 				return fmt.Errorf("failed to write proof data: %w", err)
 			}
+		} else if batchSteps {
+			// End the batch where the context is checked, or at the next matched step
+			end := (step/100 + 1) * 100
+			for _, next := range nextMatches {
+				end = min(end, next(step+1))
+			}
+			executed, err := runStepsFn(end - step)
+			if err != nil {
+				if debugProgram {
+					vm.Traceback()
+				}
+				return fmt.Errorf("failed at step %d (%s): %w", step+executed, locateFault(l, elfPath, meta, state.FPVMState), err)
+			}
 		} else {
-			_, err = stepFn(false)
+			witness, err := stepFn(validate)
 			if err != nil {
-				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
+				if debugProgram {
+					vm.Traceback()
+				}
+				return fmt.Errorf("failed at step %d (%s): %w", step, locateFault(l, elfPath, meta, state.FPVMState), err)
+			}
+			if validate {
+				validation.validate(step, witness, state)
 			}
 		}
 
 		lastPreimageKey, lastPreimageValue, lastPreimageOffset := vm.LastPreimage()
 		if lastPreimageOffset != ^arch.Word(0) {
+			if preimageManifest != nil {
+				preimageManifest.Record(step, lastPreimageKey, lastPreimageValue)
+			}
 			if stopAtAnyPreimage {
 				l.Info("Stopping at preimage read")
 				break
@@ -515,11 +875,32 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 	l.Info("Execution stopped", "exited", state.GetExited(), "code", state.GetExitCode())
+	if replayer != nil {
+		l.Info("Replayed syscalls", "remaining", replayer.Remaining())
+	}
+	if validation != nil {
+		validation.logSummary()
+	}
+	if telemetry != nil {
+		telemetry.Sample(state)
+	}
 	if debugProgram {
 		vm.Traceback()
 	}
+	guestPanic := errLog.Panic()
+	if guestPanic == nil {
+		guestPanic = outLog.Panic()
+	}
+	if guestPanic != nil {
+		guestPanic.Log(guestLogger.With("module", "guest"))
+		if panicOutputFile := ctx.Path(RunPanicOutputFlag.Name); panicOutputFile != "" {
+			if err := jsonutil.WriteJSON(guestPanic, ioutil.ToStdOutOrFileOrNoop(panicOutputFile, OutFilePerm)); err != nil {
+				return fmt.Errorf("failed to write panic output: %w", err)
+			}
+		}
+	}
 
-	if err := serialize.Write(ctx.Path(RunOutputFlag.Name), state, OutFilePerm); err != nil {
+	if err := writeState(ctx.Path(RunOutputFlag.Name), state); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	if debugInfoFile := ctx.Path(RunDebugInfoFlag.Name); debugInfoFile != "" {
@@ -527,6 +908,37 @@ func Run(ctx *cli.Context) error {
 			return fmt.Errorf("failed to write benchmark data: %w", err)
 		}
 	}
+	if syscallStatsFile := ctx.Path(RunSyscallStatsFlag.Name); syscallStatsFile != "" {
+		if err := jsonutil.WriteJSON(vm.GetSyscallStats(), ioutil.ToStdOutOrFileOrNoop(syscallStatsFile, OutFilePerm)); err != nil {
+			return fmt.Errorf("failed to write syscall stats: %w", err)
+		}
+	}
+	if accessStatsFile := ctx.Path(RunAccessStatsFlag.Name); accessStatsFile != "" {
+		stats := vm.GetAccessStats()
+		l.Info("Memory access stats", "windows", len(stats.Windows), "branches", stats.TotalBranches,
+			"branchesSize", stats.TotalBranches*stats.BranchSize, "pages", stats.TotalPages,
+			"maxWindowPages", stats.MaxPages, "maxWindowMultiproofSize", stats.MaxMultiproofSize)
+		if err := jsonutil.WriteJSON(stats, ioutil.ToStdOutOrFileOrNoop(accessStatsFile, OutFilePerm)); err != nil {
+			return fmt.Errorf("failed to write access stats: %w", err)
+		}
+	}
+	if preimageManifestFile := ctx.Path(RunPreimageManifestFlag.Name); preimageManifestFile != "" {
+		if err := jsonutil.WriteJSON(preimageManifest, ioutil.ToStdOutOrFileOrNoop(preimageManifestFile, OutFilePerm)); err != nil {
+			return fmt.Errorf("failed to write preimage manifest: %w", err)
+		}
+	}
+	if profilePath != "" {
+		prof := vm.GetProfile()
+		if err := writeProfile(profilePath, prof, state.Version); err != nil {
+			return err
+		}
+		total := prof.TotalSamples()
+		functions := prof.Functions()
+		for i, fn := range functions[:min(profileReportTop, len(functions))] {
+			l.Info("Hot function", "rank", i+1, "name", fn.Name, "steps", fn.Samples*prof.Interval,
+				"percent", 100*float64(fn.Samples)/float64(total))
+		}
+	}
 	return nil
 }
 
@@ -536,30 +948,77 @@ func CreateRunCommand(action cli.ActionFunc) *cli.Command {
 		Usage:       "Run VM step(s) and generate proof data to replicate onchain.",
 		Description: "Run VM step(s) and generate proof data to replicate onchain. See flags to match when to output a proof, a snapshot, or to stop early.",
 		Action:      action,
-		Flags: []cli.Flag{
+		Flags: append([]cli.Flag{
 			RunInputFlag,
 			RunOutputFlag,
 			RunProofAtFlag,
 			RunProofFmtFlag,
+			RunProofCompactFlag,
 			RunSnapshotAtFlag,
 			RunSnapshotFmtFlag,
+			RunSnapshotFullEveryFlag,
 			RunStopAtFlag,
 			RunStopAtPreimageFlag,
 			RunStopAtPreimageTypeFlag,
 			RunStopAtPreimageLargerThanFlag,
 			RunMetaFlag,
+			RunELFFlag,
 			RunInfoAtFlag,
+			RunHashWorkersFlag,
 			RunPProfCPU,
 			RunDebugFlag,
+			RunDebugStackGuardFlag,
+			RunDebugMainStackSizeFlag,
+			RunDebugThreadStackSizeFlag,
 			RunDebugInfoFlag,
-		},
+			RunSyscallStatsFlag,
+			RunAccessStatsFlag,
+			RunAccessStatsWindowFlag,
+			RunPanicOutputFlag,
+			RunPreimageManifestFlag,
+			RunProfileFlag,
+			RunProfileIntervalFlag,
+			RunSyncHintsFlag,
+			RunPreimageCacheSizeFlag,
+			RunPreimageCacheEntriesFlag,
+			RunRecordSyscallsFlag,
+			RunReplaySyscallsFlag,
+			RunValidateOnchainRateFlag,
+			RunValidateOnchainArtifactsFlag,
+			RunTelemetryIntervalFlag,
+		}, opmetrics.CLIFlags("CANNON")...),
 	}
 }
 
 var RunCommand = CreateRunCommand(Run)
 
+// writeProof writes the proof to the file named by the format, or as a frame to stdout if the format is stream.Path.
+func writeProof(proofFmt string, proof *Proof) error {
+	if proofFmt == stream.Path {
+		data, err := json.Marshal(proof)
+		if err != nil {
+			return fmt.Errorf("failed to encode proof: %w", err)
+		}
+		return stdoutStream().WriteFrame(stream.KindProof, data)
+	}
+	return jsonutil.WriteJSON(proof, ioutil.ToStdOutOrFileOrNoop(fmt.Sprintf(proofFmt, proof.Step), OutFilePerm))
+}
+
+// streamsToStdout returns whether the output state or proofs are written as frames to stdout.
+func streamsToStdout(ctx *cli.Context) bool {
+	return ctx.Path(RunOutputFlag.Name) == stream.Path || ctx.String(RunProofFmtFlag.Name) == stream.Path
+}
+
 func checkFlags(ctx *cli.Context) error {
-	if output := ctx.Path(RunOutputFlag.Name); output != "" {
+	if streamsToStdout(ctx) {
+		// Any other output to stdout would corrupt the stream of frames
+		for _, flag := range []*cli.PathFlag{RunDebugInfoFlag, RunSyscallStatsFlag, RunAccessStatsFlag, RunPanicOutputFlag, RunPreimageManifestFlag, RunProfileFlag} {
+			if ctx.Path(flag.Name) == stream.Path {
+				return fmt.Errorf("cannot write --%s to stdout, stdout is used for the stream of states and proofs", flag.Name)
+			}
+		}
+	}
+	if output := ctx.Path(RunOutputFlag.Name); output != "" && output != stream.Path {
 		if !serialize.IsBinaryFile(output) {
 			return errors.New("invalid --output file format. Only binary file formats (ending in .bin or bin.gz) are supported")
 		}
@@ -569,5 +1028,25 @@ func checkFlags(ctx *cli.Context) error {
 			return errors.New("invalid --snapshot-fmt file format. Only binary file formats (ending in .bin or bin.gz) are supported")
 		}
 	}
+	if ctx.IsSet(RunRecordSyscallsFlag.Name) && ctx.IsSet(RunReplaySyscallsFlag.Name) {
+		return fmt.Errorf("cannot use both --%s and --%s", RunRecordSyscallsFlag.Name, RunReplaySyscallsFlag.Name)
+	}
+	if err := opmetrics.ReadCLIConfig(ctx).Check(); err != nil {
+		return fmt.Errorf("invalid metrics config: %w", err)
+	}
 	return nil
 }
+
+// loadSyscallReplayer reads a recording of --record-syscalls.
+func loadSyscallReplayer(path string) (*mipsevm.SyscallReplayer, error) {
+	in, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open syscall recording: %w", err)
+	}
+	defer in.Close()
+	replayer, err := mipsevm.NewSyscallReplayer(bufio.NewReader(in))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read syscall recording %v: %w", path, err)
+	}
+	return replayer, nil
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+var (
+	SnapshotsFmtFlag = &cli.StringFlag{
+		Name:  "snapshot-fmt",
+		Usage: "format of the snapshot file names, as passed to `cannon run --snapshot-fmt`",
+		Value: "state-%d.bin.gz",
+	}
+	SnapshotsStepFlag = &cli.Uint64Flag{
+		Name:     "step",
+		Usage:    "step to restore the state of. The last snapshot at or before the step is restored.",
+		Required: true,
+	}
+	SnapshotsOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of the restored binary state",
+		TakesFile: true,
+		Value:     "state.bin.gz",
+	}
+	SnapshotsKeepFlag = &cli.GenericFlag{
+		Name:     "keep",
+		Usage:    "step pattern of the snapshots to keep: " + patternHelp + ". The last snapshot, and the bases of kept deltas, are always kept.",
+		Value:    new(StepMatcherFlag),
+		Required: true,
+	}
+	SnapshotsFullEveryFlag = &cli.Uint64Flag{
+		Name:  "full-every",
+		Usage: "write every n-th snapshot in full, and the snapshots in between as deltas against the last full snapshot. 1 writes all snapshots in full.",
+		Value: 16,
+	}
+)
+
+// snapshotStep matches step patterns against the step of a snapshot.
+type snapshotStep uint64
+
+func (s snapshotStep) GetStep() uint64 {
+	return uint64(s)
+}
+
+func listSnapshots(ctx *cli.Context) ([]versions.SnapshotInfo, error) {
+	format := ctx.String(SnapshotsFmtFlag.Name)
+	if !serialize.IsBinaryFile(fmt.Sprintf(format, 0)) {
+		return nil, fmt.Errorf("invalid --%s file format. Only binary file formats (ending in .bin or bin.gz) are supported", SnapshotsFmtFlag.Name)
+	}
+	return versions.ListSnapshots(format)
+}
+
+func SnapshotsList(ctx *cli.Context) error {
+	snaps, err := listSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "step\tkind\tbase\tsize\tpath")
+	for _, snap := range snaps {
+		kind, base := "full", "-"
+		if snap.Delta {
+			kind, base = "delta", fmt.Sprint(snap.BaseStep)
+		}
+		size := "?"
+		if stat, err := os.Stat(snap.Path); err == nil {
+			size = fmt.Sprint(stat.Size())
+		}
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", snap.Step, kind, base, size, snap.Path)
+	}
+	return w.Flush()
+}
+
+func SnapshotsRestore(ctx *cli.Context) error {
+	snaps, err := listSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	state, err := versions.RestoreSnapshot(snaps, ctx.Uint64(SnapshotsStepFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to restore snapshot: %w", err)
+	}
+	output := ctx.Path(SnapshotsOutputFlag.Name)
+	if err := serialize.Write(output, state, OutFilePerm); err != nil {
+		return fmt.Errorf("failed to write state output: %w", err)
+	}
+	Logger(os.Stderr, log.LevelInfo).Info("Restored snapshot", "step", state.GetStep(), "output", output)
+	return nil
+}
+
+func SnapshotsPrune(ctx *cli.Context) error {
+	snaps, err := listSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	l := Logger(os.Stderr, log.LevelInfo)
+	keep := ctx.Generic(SnapshotsKeepFlag.Name).(*StepMatcherFlag).Matcher()
+	remove := versions.PruneSnapshots(snaps, func(step uint64) bool {
+		return keep(snapshotStep(step))
+	})
+	for _, snap := range remove {
+		if err := os.Remove(snap.Path); err != nil {
+			return fmt.Errorf("failed to remove snapshot: %w", err)
+		}
+	}
+	l.Info("Pruned snapshots", "removed", len(remove), "kept", len(snaps)-len(remove))
+	return nil
+}
+
+func SnapshotsCompact(ctx *cli.Context) error {
+	snaps, err := listSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	compacted, err := versions.CompactSnapshots(snaps, ctx.Uint64(SnapshotsFullEveryFlag.Name), OutFilePerm)
+	if err != nil {
+		return err
+	}
+	deltas := 0
+	for _, snap := range compacted {
+		if snap.Delta {
+			deltas++
+		}
+	}
+	Logger(os.Stderr, log.LevelInfo).Info("Compacted snapshots", "full", len(compacted)-deltas, "deltas", deltas)
+	return nil
+}
+
+func CreateSnapshotsCommand(list, restore, prune, compact cli.ActionFunc) *cli.Command {
+	return &cli.Command{
+		Name:  "snapshots",
+		Usage: "Manage the full and delta state snapshots of a run",
+		Description: "Manage the state snapshots written by `cannon run --snapshot-at`. " +
+			"With --snapshot-full-every, runs write most snapshots as deltas against the last full snapshot, " +
+			"which are restored to a full state to resume from.",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List the snapshots, with the full snapshot that each delta is applied to",
+				Action: list,
+				Flags:  []cli.Flag{SnapshotsFmtFlag},
+			},
+			{
+				Name:   "restore",
+				Usage:  "Restore the full state of the last snapshot at or before a step, to resume a run from",
+				Action: restore,
+				Flags:  []cli.Flag{SnapshotsFmtFlag, SnapshotsStepFlag, SnapshotsOutputFlag},
+			},
+			{
+				Name:   "prune",
+				Usage:  "Remove the snapshots that do not match a step pattern",
+				Action: prune,
+				Flags:  []cli.Flag{SnapshotsFmtFlag, SnapshotsKeepFlag},
+			},
+			{
+				Name:   "compact",
+				Usage:  "Rewrite the snapshots in place as deltas, with a full snapshot every n snapshots",
+				Action: compact,
+				Flags:  []cli.Flag{SnapshotsFmtFlag, SnapshotsFullEveryFlag},
+			},
+		},
+	}
+}
+
+var SnapshotsCommand = CreateSnapshotsCommand(SnapshotsList, SnapshotsRestore, SnapshotsPrune, SnapshotsCompact)
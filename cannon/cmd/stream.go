@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"os"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+// stdoutStream is the stream of frames written to stdout, shared by all outputs that are streamed.
+var stdoutStream = sync.OnceValue(func() *stream.Writer {
+	return stream.NewWriter(os.Stdout)
+})
+
+// loadState loads the state from the path, or reads it from stdin if the path is stream.Path.
+func loadState(path string) (*versions.VersionedState, error) {
+	if path == stream.Path {
+		return stream.NewReader(os.Stdin).ReadState()
+	}
+	return versions.LoadStateFromFile(path)
+}
+
+// writeState writes the state to the path, or to stdout if the path is stream.Path.
+// Nothing is written if the path is empty.
+func writeState(path string, state *versions.VersionedState) error {
+	if path == stream.Path {
+		return stdoutStream().WriteState(state)
+	}
+	return serialize.Write(path, state, OutFilePerm)
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+const metricsNamespace = "cannon"
+
+// defaultTelemetryInterval is the interval of the telemetry if only the metrics server is enabled.
+const defaultTelemetryInterval = 10 * time.Second
+
+var (
+	RunTelemetryIntervalFlag = &cli.DurationFlag{
+		Name:  "telemetry-interval",
+		Usage: "interval to log host telemetry at (heap, VM memory pages, steps/sec, witness bytes/sec). Disabled if 0, unless the metrics server is enabled.",
+	}
+)
+
+// telemetryMetrics are the host telemetry metrics, served by the metrics server.
+type telemetryMetrics struct {
+	step                  prometheus.Gauge
+	stepsPerSecond        prometheus.Gauge
+	memoryPages           prometheus.Gauge
+	heapBytes             prometheus.Gauge
+	witnessBytes          prometheus.Counter
+	witnessBytesPerSecond prometheus.Gauge
+}
+
+func newTelemetryMetrics(registry *prometheus.Registry) *telemetryMetrics {
+	factory := opmetrics.With(registry)
+	return &telemetryMetrics{
+		step: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "step",
+			Help:      "Current step of the VM",
+		}),
+		stepsPerSecond: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "steps_per_second",
+			Help:      "Steps executed per second since the previous sample",
+		}),
+		memoryPages: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "memory_pages",
+			Help:      "Number of pages allocated in the VM memory",
+		}),
+		heapBytes: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "heap_bytes",
+			Help:      "Bytes of allocated heap objects of the host",
+		}),
+		witnessBytes: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "witness_bytes_total",
+			Help:      "Bytes of state and proof data of the generated step witnesses",
+		}),
+		witnessBytesPerSecond: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "witness_bytes_per_second",
+			Help:      "Bytes of step witnesses generated per second since the previous sample",
+		}),
+	}
+}
+
+// telemetryState is the part of the VM state that is sampled.
+type telemetryState interface {
+	GetStep() uint64
+	GetMemory() *memory.Memory
+}
+
+// Telemetry periodically samples the memory usage and throughput of the host while it runs the VM,
+// to capacity-plan provers and to detect degradations across releases.
+type Telemetry struct {
+	log      log.Logger
+	interval time.Duration
+	metrics  *telemetryMetrics // nil if the metrics are not served
+
+	lastTime     time.Time
+	lastStep     uint64
+	witnessBytes uint64 // since the last sample
+}
+
+// NewTelemetry creates telemetry that samples at the given interval, starting at the given step.
+// The metrics are updated too if registry is not nil.
+func NewTelemetry(logger log.Logger, interval time.Duration, registry *prometheus.Registry, step uint64) *Telemetry {
+	t := &Telemetry{
+		log:      logger,
+		interval: interval,
+		lastTime: time.Now(),
+		lastStep: step,
+	}
+	if registry != nil {
+		t.metrics = newTelemetryMetrics(registry)
+	}
+	return t
+}
+
+// AddWitness accounts for a generated step witness.
+func (t *Telemetry) AddWitness(wit *mipsevm.StepWitness) {
+	n := len(wit.State) + len(wit.ProofData)
+	t.witnessBytes += uint64(n)
+	if t.metrics != nil {
+		t.metrics.witnessBytes.Add(float64(n))
+	}
+}
+
+// Due returns whether the interval elapsed since the previous sample.
+func (t *Telemetry) Due() bool {
+	return time.Since(t.lastTime) >= t.interval
+}
+
+// Sample logs the telemetry of the current state, and updates the metrics.
+func (t *Telemetry) Sample(state telemetryState) {
+	now := time.Now()
+	elapsed := now.Sub(t.lastTime).Seconds()
+	step := state.GetStep()
+	var stepsPerSecond, witnessBytesPerSecond float64
+	if elapsed > 0 {
+		stepsPerSecond = float64(step-t.lastStep) / elapsed
+		witnessBytesPerSecond = float64(t.witnessBytes) / elapsed
+	}
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	pages := state.GetMemory().PageCount()
+
+	t.log.Info("Telemetry",
+		"step", step,
+		"steps_per_sec", stepsPerSecond,
+		"pages", pages,
+		"heap", memStats.HeapAlloc,
+		"heap_sys", memStats.HeapSys,
+		"witness_bytes_per_sec", witnessBytesPerSecond,
+	)
+	if t.metrics != nil {
+		t.metrics.step.Set(float64(step))
+		t.metrics.stepsPerSecond.Set(stepsPerSecond)
+		t.metrics.memoryPages.Set(float64(pages))
+		t.metrics.heapBytes.Set(float64(memStats.HeapAlloc))
+		t.metrics.witnessBytesPerSecond.Set(witnessBytesPerSecond)
+	}
+
+	t.lastTime = now
+	t.lastStep = step
+	t.witnessBytes = 0
+}
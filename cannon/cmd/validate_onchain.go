@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/onchain"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+var (
+	RunValidateOnchainRateFlag = &cli.Uint64Flag{
+		Name:  "validate-onchain-rate",
+		Usage: "validate 1 in N steps, sampled at random, against the onchain VM contracts in a local EVM, and log the steps for which the onchain VM does not produce the same post-state. 0 disables the validation.",
+	}
+	RunValidateOnchainArtifactsFlag = &cli.PathFlag{
+		Name:      "validate-onchain-artifacts",
+		Usage:     "forge artifacts directory with the MIPS64 and PreimageOracle contracts to validate steps against, e.g. packages/contracts-bedrock/forge-artifacts. The contracts must support the version of the input state.",
+		TakesFile: true,
+	}
+)
+
+// onchainValidation validates sampled steps of a run against the onchain VM.
+// Divergences are logged with the inputs to reproduce them, and do not stop the run.
+type onchainValidation struct {
+	log       log.Logger
+	validator *onchain.Validator
+	rate      uint64
+
+	validated   uint64
+	skipped     uint64
+	divergences uint64
+}
+
+// newOnchainValidation returns the validation configured by the flags, or nil if it is disabled.
+func newOnchainValidation(ctx *cli.Context, l log.Logger, version versions.StateVersion, localOracle mipsevm.PreimageOracle) (*onchainValidation, error) {
+	rate := ctx.Uint64(RunValidateOnchainRateFlag.Name)
+	if rate == 0 {
+		return nil, nil
+	}
+	artifactsDir := ctx.Path(RunValidateOnchainArtifactsFlag.Name)
+	if artifactsDir == "" {
+		return nil, fmt.Errorf("--%s is required to validate steps against the onchain VM", RunValidateOnchainArtifactsFlag.Name)
+	}
+	artifacts, err := onchain.LoadArtifacts(artifactsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load onchain VM contracts: %w", err)
+	}
+	contracts := &onchain.ContractMetadata{Artifacts: artifacts, Addresses: onchain.DefaultAddresses(), Version: uint8(version)}
+	validator, err := onchain.NewValidator(contracts, localOracle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy onchain VM contracts: %w", err)
+	}
+	return &onchainValidation{log: l, validator: validator, rate: rate}, nil
+}
+
+// sample returns true if the next step is to be validated.
+func (v *onchainValidation) sample() bool {
+	return rand.Uint64N(v.rate) == 0
+}
+
+// validate validates the step of the witness, that resulted in the current state.
+func (v *onchainValidation) validate(step uint64, witness *mipsevm.StepWitness, state interface{ EncodeWitness() ([]byte, common.Hash) }) {
+	post, postHash := state.EncodeWitness()
+	div, err := v.validator.Validate(step, witness, post, postHash)
+	if errors.Is(err, onchain.ErrUnsupportedPreimage) {
+		v.skipped++
+		v.log.Debug("Skipping onchain validation of step", "step", step, "err", err)
+		return
+	} else if err != nil {
+		v.skipped++
+		v.log.Warn("Failed to validate step onchain", "step", step, "err", err)
+		return
+	}
+	v.validated++
+	if div == nil {
+		return
+	}
+	v.divergences++
+	v.log.Error("Onchain VM diverged", "step", step, "err", div.Error,
+		"pre", div.PreStateHash, "post", div.PostStateHash, "onchainPost", div.OnchainPostStateHash,
+		"state", hexutil.Bytes(witness.State), "proof", hexutil.Bytes(witness.ProofData),
+		"preimageKey", common.Hash(witness.PreimageKey), "preimageOffset", witness.PreimageOffset, "preimageValue", hexutil.Bytes(witness.PreimageValue),
+		"stepInput", div.StepInput, "preimageOracleInput", div.PreimageOracleInput,
+		"postState", div.PostState, "onchainPostState", div.OnchainPostState)
+}
+
+func (v *onchainValidation) logSummary() {
+	v.log.Info("Onchain validation", "validated", v.validated, "skipped", v.skipped, "divergences", v.divergences)
+}
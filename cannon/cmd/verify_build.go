@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	VerifyBuildAttestationFlag = &cli.PathFlag{
+		Name:      "attestation",
+		Usage:     "path of the published build attestation JSON to compare against. The build info is printed as attestation if omitted.",
+		TakesFile: true,
+	}
+	VerifyBuildInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of a binary state to verify the build of, instead of the cannon binary",
+		TakesFile: true,
+	}
+)
+
+func VerifyBuild(ctx *cli.Context) error {
+	info := buildinfo.Current()
+	source := "binary"
+	if input := ctx.Path(VerifyBuildInputFlag.Name); input != "" {
+		state, err := versions.LoadStateFromFile(input)
+		if err != nil {
+			return fmt.Errorf("invalid input state (%v): %w", input, err)
+		}
+		if state.Build == nil {
+			return fmt.Errorf("state %v does not record the build that wrote it", input)
+		}
+		info = state.Build
+		source = input
+	}
+	attestationPath := ctx.Path(VerifyBuildAttestationFlag.Name)
+	if attestationPath == "" {
+		if err := jsonutil.WriteJSON(info, ioutil.ToStdOut()); err != nil {
+			return fmt.Errorf("failed to write build info: %w", err)
+		}
+		return nil
+	}
+	attested, err := jsonutil.LoadJSON[buildinfo.Info](attestationPath)
+	if err != nil {
+		return fmt.Errorf("failed to load attestation: %w", err)
+	}
+	if err := info.Verify(attested); err != nil {
+		return fmt.Errorf("build of %s: %w", source, err)
+	}
+	Logger(os.Stderr, log.LevelInfo).Info("Build matches attestation", "source", source, "build", info)
+	return nil
+}
+
+var VerifyBuildCommand = &cli.Command{
+	Name:  "verify-build",
+	Usage: "Verify the build of cannon, or of the cannon that wrote a state, against a published attestation",
+	Description: "Compares the git commit, go version and build flags of the cannon binary, or those recorded in a state with --input, " +
+		"against a published attestation. Without --attestation, the build info is printed in the attestation format.",
+	Action: VerifyBuild,
+	Flags: []cli.Flag{
+		VerifyBuildAttestationFlag,
+		VerifyBuildInputFlag,
+	},
+}
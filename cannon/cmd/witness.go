@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 
-	factory "github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,7 +19,7 @@ import (
 var (
 	WitnessInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state.",
+		Usage:     "path of input binary state. Use - to read the state from a stream of frames on stdin.",
 		TakesFile: true,
 		Required:  true,
 	}
@@ -24,6 +28,26 @@ var (
 		Usage:     "path to write binary witness.",
 		TakesFile: true,
 	}
+	WitnessPrettyFlag = &cli.BoolFlag{
+		Name: "pretty",
+		Usage: "print a human-readable report of the state instead of the JSON summary: " +
+			"the thread stacks, the registers of each thread, futex waits, the LL reservation, and recent memory writes.",
+	}
+	WitnessMetaFlag = &cli.PathFlag{
+		Name:      "meta",
+		Usage:     "path to metadata file for symbol lookup of the thread PCs and return addresses in the --pretty report.",
+		TakesFile: true,
+	}
+	WitnessSinceFlag = &cli.PathFlag{
+		Name:      "since",
+		Usage:     "path of an earlier state of the same run, to list the memory written since in the --pretty report.",
+		TakesFile: true,
+	}
+	WitnessMaxWordsFlag = &cli.IntFlag{
+		Name:  "max-words",
+		Usage: "maximum number of written words to list per memory page in the --pretty report. 0 lists all of them.",
+		Value: 16,
+	}
 )
 
 type response struct {
@@ -32,12 +56,14 @@ type response struct {
 	Step        uint64        `json:"step"`
 	Exited      bool          `json:"exited"`
 	ExitCode    uint8         `json:"exitCode"`
+	// Build is the build of cannon that wrote the state, if recorded.
+	Build *buildinfo.Info `json:"build,omitempty"`
 }
 
 func Witness(ctx *cli.Context) error {
 	input := ctx.Path(WitnessInputFlag.Name)
 	witnessOutput := ctx.Path(WitnessOutputFlag.Name)
-	state, err := factory.LoadStateFromFile(input)
+	state, err := loadState(input)
 	if err != nil {
 		return fmt.Errorf("invalid input state (%v): %w", input, err)
 	}
@@ -47,12 +73,16 @@ func Witness(ctx *cli.Context) error {
 			return fmt.Errorf("writing output to %v: %w", witnessOutput, err)
 		}
 	}
+	if ctx.Bool(WitnessPrettyFlag.Name) {
+		return writeStateReport(ctx, input, state)
+	}
 	output := response{
 		WitnessHash: h,
 		Witness:     witness,
 		Step:        state.GetStep(),
 		Exited:      state.GetExited(),
 		ExitCode:    state.GetExitCode(),
+		Build:       state.Build,
 	}
 	if err := jsonutil.WriteJSON(output, ioutil.ToStdOut()); err != nil {
 		return fmt.Errorf("failed to write response: %w", err)
@@ -60,15 +90,54 @@ func Witness(ctx *cli.Context) error {
 	return nil
 }
 
+// writeStateReport writes the human-readable report of the state, for the --pretty mode.
+func writeStateReport(ctx *cli.Context, input string, state *versions.VersionedState) error {
+	st, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return fmt.Errorf("unsupported state type %T in %v", state.FPVMState, input)
+	}
+	cfg := multithreaded.ReportConfig{MaxWritesPerPage: ctx.Int(WitnessMaxWordsFlag.Name)}
+	if metaPath := ctx.Path(WitnessMetaFlag.Name); metaPath != "" {
+		meta, err := jsonutil.LoadJSON[program.Metadata](metaPath)
+		if err != nil {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		}
+		cfg.Meta = meta
+	}
+	if since := ctx.Path(WitnessSinceFlag.Name); since != "" {
+		if since == stream.Path && input == stream.Path {
+			return fmt.Errorf("only one of --%s and --%s can be read from stdin", WitnessInputFlag.Name, WitnessSinceFlag.Name)
+		}
+		prev, err := loadMultithreadedState(since)
+		if err != nil {
+			return err
+		}
+		cfg.Prev = prev.state
+	}
+	if _, err := fmt.Fprintf(ctx.App.Writer, "State version: %s\n", state.Version); err != nil {
+		return err
+	}
+	if state.Build != nil {
+		if _, err := fmt.Fprintf(ctx.App.Writer, "Build: %s\n", state.Build); err != nil {
+			return err
+		}
+	}
+	return multithreaded.WriteReport(ctx.App.Writer, st, cfg)
+}
+
 func CreateWitnessCommand(action cli.ActionFunc) *cli.Command {
 	return &cli.Command{
 		Name:        "witness",
 		Usage:       "Convert a Cannon JSON state into a binary witness",
-		Description: "Convert a Cannon JSON state into a binary witness. Basic data about the state is printed to stdout in JSON format.",
+		Description: "Convert a Cannon JSON state into a binary witness. Basic data about the state is printed to stdout in JSON format, or a human-readable report of the state with --pretty.",
 		Action:      action,
 		Flags: []cli.Flag{
 			WitnessInputFlag,
 			WitnessOutputFlag,
+			WitnessPrettyFlag,
+			WitnessMetaFlag,
+			WitnessSinceFlag,
+			WitnessMaxWordsFlag,
 		},
 	}
 }
@@ -21,6 +21,10 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.ProfileCommand,
+		cmd.SnapshotsCommand,
+		cmd.VerifyBuildCommand,
+		cmd.CompareStatesCommand,
 	}
 	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
 	err := app.RunContext(ctx, os.Args)
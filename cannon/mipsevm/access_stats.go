@@ -0,0 +1,36 @@
+package mipsevm
+
+// AccessWindow holds the memory accesses of a window of consecutive steps.
+type AccessWindow struct {
+	StartStep uint64 `json:"start_step"`
+	Steps     uint64 `json:"steps"`
+	// Branches is the number of distinct memory leaves accessed by each step, summed over the steps of the window.
+	// Each of these is proven with its own Merkle branch in the witness of the step.
+	Branches uint64 `json:"branches"`
+	// Pages is the number of distinct memory pages accessed in the window.
+	Pages uint64 `json:"pages"`
+	// Leaves is the number of distinct 32-byte memory leaves accessed in the window.
+	Leaves uint64 `json:"leaves"`
+	// MultiproofNodes is the number of sibling nodes of a Merkle multiproof of all the leaves accessed in the window.
+	// The fewer nodes, the more the accesses of the window share the branches of the memory tree.
+	MultiproofNodes uint64 `json:"multiproof_nodes"`
+	// MultiproofSize is the size in bytes of the multiproof, the leaves and their sibling nodes.
+	MultiproofSize uint64 `json:"multiproof_size"`
+}
+
+// AccessStats is the per-run memory access statistics artifact, to quantify the proof size of the memory accesses
+// of a program, and the effect of its data layout on it.
+type AccessStats struct {
+	WindowSize uint64 `json:"window_size"`
+	TotalSteps uint64 `json:"total_steps"`
+	// BranchSize is the size in bytes of the Merkle branch of a memory access, in the witness of a step.
+	BranchSize    uint64 `json:"branch_size"`
+	TotalBranches uint64 `json:"total_branches"`
+	// TotalPages is the number of distinct memory pages accessed in the run.
+	TotalPages uint64 `json:"total_pages"`
+	MaxPages   uint64 `json:"max_pages"`
+	// MaxMultiproofSize is the largest multiproof size of the windows.
+	MaxMultiproofSize uint64 `json:"max_multiproof_size"`
+	// Windows is ordered by step
+	Windows []AccessWindow `json:"windows"`
+}
@@ -1,6 +1,6 @@
 package arch
 
-import "encoding/binary"
+import "debug/elf"
 
 type (
 	// Word differs from the traditional meaning in MIPS. The type represents the *maximum* architecture specific access length and value sizes
@@ -20,6 +20,9 @@ const (
 	HeapEnd         = 0x00_00_60_00_00_00_00_00
 	ProgramBreak    = 0x00_00_40_00_00_00_00_00
 	HighMemoryStart = 0x00_00_7F_FF_FF_FF_F0_00
+
+	// ELFClass is the class of the ELF files the VM can load.
+	ELFClass = elf.ELFCLASS64
 )
 
 // MIPS64 syscall table - https://github.com/torvalds/linux/blob/3efc57369a0ce8f76bf0804f7e673982384e4ac9/arch/mips/kernel/syscalls/syscall_n64.tbl. Generate the syscall numbers using the Makefile in that directory.
@@ -88,18 +91,90 @@ const (
 	SysTimerDelete  = 5220
 )
 
+// Syscall numbers of the musl runtime, see mipsevm.FeatureToggles.SupportMuslRuntime
+const (
+	SysSetThreadArea = 5242
+	SysSetTidAddress = 5212
+	SysSetRobustList = 5268
+	SysGetRobustList = 5269
+	SysPoll          = 5007
+	SysMremap        = 5024
+)
+
 var ByteOrderWord = byteOrder64{}
 
 type byteOrder64 struct{}
 
 func (bo byteOrder64) Word(b []byte) Word {
-	return binary.BigEndian.Uint64(b)
+	return GuestByteOrder.Uint64(b)
 }
 
 func (bo byteOrder64) AppendWord(b []byte, v uint64) []byte {
-	return binary.BigEndian.AppendUint64(b, v)
+	return GuestByteOrder.AppendUint64(b, v)
 }
 
 func (bo byteOrder64) PutWord(b []byte, v uint64) {
-	binary.BigEndian.PutUint64(b, v)
+	GuestByteOrder.PutUint64(b, v)
+}
+
+var syscallNames = map[Word]string{
+	SysMmap:          "mmap",
+	SysBrk:           "brk",
+	SysClone:         "clone",
+	SysExitGroup:     "exit_group",
+	SysRead:          "read",
+	SysWrite:         "write",
+	SysFcntl:         "fcntl",
+	SysExit:          "exit",
+	SysSchedYield:    "sched_yield",
+	SysGetTID:        "gettid",
+	SysFutex:         "futex",
+	SysOpen:          "open",
+	SysNanosleep:     "nanosleep",
+	SysClockGetTime:  "clock_gettime",
+	SysGetpid:        "getpid",
+	SysGetRandom:     "getrandom",
+	SysMunmap:        "munmap",
+	SysMprotect:      "mprotect",
+	SysGetAffinity:   "sched_getaffinity",
+	SysMadvise:       "madvise",
+	SysRtSigprocmask: "rt_sigprocmask",
+	SysSigaltstack:   "sigaltstack",
+	SysRtSigaction:   "rt_sigaction",
+	SysPrlimit64:     "prlimit64",
+	SysClose:         "close",
+	SysPread64:       "pread64",
+	SysStat:          "stat",
+	SysFstat:         "fstat",
+	SysOpenAt:        "openat",
+	SysReadlink:      "readlink",
+	SysReadlinkAt:    "readlinkat",
+	SysIoctl:         "ioctl",
+	SysEpollCreate1:  "epoll_create1",
+	SysPipe2:         "pipe2",
+	SysEpollCtl:      "epoll_ctl",
+	SysEpollPwait:    "epoll_pwait",
+	SysUname:         "uname",
+	SysGetuid:        "getuid",
+	SysGetgid:        "getgid",
+	SysMinCore:       "mincore",
+	SysTgkill:        "tgkill",
+	SysGetRLimit:     "getrlimit",
+	SysLseek:         "lseek",
+	SysEventFd2:      "eventfd2",
+	SysSetITimer:     "setitimer",
+	SysTimerCreate:   "timer_create",
+	SysTimerSetTime:  "timer_settime",
+	SysTimerDelete:   "timer_delete",
+	SysSetThreadArea: "set_thread_area",
+	SysSetTidAddress: "set_tid_address",
+	SysSetRobustList: "set_robust_list",
+	SysGetRobustList: "get_robust_list",
+	SysPoll:          "poll",
+	SysMremap:        "mremap",
+}
+
+// SyscallName returns the linux name of the specified syscall number, or an empty string if it is not known.
+func SyscallName(num Word) string {
+	return syscallNames[num]
 }
@@ -1,5 +1,17 @@
 package arch
 
+import (
+	"debug/elf"
+	"encoding/binary"
+)
+
+// GuestByteOrder is the byte order of the guest. The VM only runs big-endian MIPS programs: every conversion
+// between a word of guest memory and its bytes must use this byte order, usually through ByteOrderWord.
+var GuestByteOrder = binary.BigEndian
+
+// ELFData is the data encoding of the ELF files the VM can load, matching GuestByteOrder.
+const ELFData = elf.ELFDATA2MSB
+
 type ByteOrder interface {
 	Word([]byte) Word
 	AppendWord([]byte, Word) []byte
@@ -0,0 +1,279 @@
+package mipsevm
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	// hintQueueSize is the number of hints that can be queued before Hint blocks.
+	hintQueueSize = 256
+	// maxHintBatch is the maximum number of queued hints that are processed together.
+	maxHintBatch = 64
+	// maxPrefetchedPreimages bounds the prefetched preimages that were not requested yet.
+	maxPrefetchedPreimages = 1024
+)
+
+// DefaultPrefetchHintTypes are the hint types of op-program that the guest follows up with a
+// request of the keccak256 preimage of the hinted hash.
+var DefaultPrefetchHintTypes = []string{"l1-block-header", "l2-block-header", "l2-state-node", "l2-code"}
+
+// HintPrefetchKeyFn returns the key of the preimage that the guest is expected to request after
+// the given hint, if any.
+type HintPrefetchKeyFn func(hint []byte) (key [32]byte, ok bool)
+
+// KeccakHintPrefetchKey returns a HintPrefetchKeyFn for hints of the form "<type> 0x<data>" of
+// the given types, whose data starts with a hash. The preimage of such a hint is the keccak256
+// preimage of the hash.
+func KeccakHintPrefetchKey(hintTypes ...string) HintPrefetchKeyFn {
+	return func(hint []byte) ([32]byte, bool) {
+		hintType, data, ok := strings.Cut(string(hint), " ")
+		if !ok || !strings.HasPrefix(data, "0x") {
+			return [32]byte{}, false
+		}
+		found := false
+		for _, t := range hintTypes {
+			found = found || t == hintType
+		}
+		// The data may be followed by e.g. a chain ID.
+		raw := common.FromHex(data)
+		if !found || len(raw) < common.HashLength {
+			return [32]byte{}, false
+		}
+		return preimage.Keccak256Key(common.BytesToHash(raw[:common.HashLength])).PreimageKey(), true
+	}
+}
+
+// AsyncHintMetrics records the processing of the hints and the prefetching of the preimages of an
+// AsyncHintOracle.
+type AsyncHintMetrics interface {
+	RecordHintBatch(size int)
+	RecordPrefetch()
+	RecordPrefetchHit()
+	RecordPrefetchMiss()
+}
+
+// AsyncHintStats are the totals of an AsyncHintOracle.
+type AsyncHintStats struct {
+	Hints   uint64 `json:"hints"`
+	Batches uint64 `json:"batches"`
+	// Prefetches is the number of preimages that were prefetched after their hint.
+	Prefetches uint64 `json:"prefetches"`
+	// PrefetchHits and PrefetchMisses are the number of preimage requests that were served from
+	// the prefetched preimages, and that were requested from the oracle.
+	PrefetchHits   uint64 `json:"prefetch_hits"`
+	PrefetchMisses uint64 `json:"prefetch_misses"`
+}
+
+// HitRate returns the ratio of the preimage requests that were served from the prefetched preimages.
+func (s AsyncHintStats) HitRate() float64 {
+	if s.PrefetchHits+s.PrefetchMisses == 0 {
+		return 0
+	}
+	return float64(s.PrefetchHits) / float64(s.PrefetchHits+s.PrefetchMisses)
+}
+
+// AsyncHintOracle wraps a PreimageOracle to process the hints of the guest in the background,
+// instead of stalling the VM until the oracle handled each hint. Queued hints are sent to the
+// oracle in batches, after which the preimages they announce are prefetched.
+//
+// The guest observes the same preimages: hints have no effect on the VM state, and preimages are
+// content-addressed. A preimage that was not prefetched is requested from the oracle only after
+// all queued hints were processed, so the oracle is hinted before it serves the preimage, like
+// when hints are processed synchronously.
+//
+// Hint and GetPreimage must be called from a single goroutine, like the VM does. A failure of the
+// oracle in the background is re-raised as a panic by the next call.
+type AsyncHintOracle struct {
+	po          PreimageOracle
+	prefetchKey HintPrefetchKeyFn
+	metrics     AsyncHintMetrics
+
+	queue chan []byte
+	done  chan struct{}
+	// poLock serializes the requests to the wrapped oracle.
+	poLock sync.Mutex
+
+	mu   sync.Mutex
+	idle *sync.Cond
+	// pending is the number of hints that are queued or being processed.
+	pending    int
+	prefetched map[[32]byte][]byte
+	stats      AsyncHintStats
+	err        error
+}
+
+var _ PreimageOracle = (*AsyncHintOracle)(nil)
+
+// NewAsyncHintOracle starts processing hints for the given oracle in the background, until Close.
+// The preimages of the hints that prefetchKey returns a key for are prefetched, if prefetchKey is
+// not nil. metrics may be nil.
+func NewAsyncHintOracle(po PreimageOracle, prefetchKey HintPrefetchKeyFn, metrics AsyncHintMetrics) *AsyncHintOracle {
+	o := &AsyncHintOracle{
+		po:          po,
+		prefetchKey: prefetchKey,
+		metrics:     metrics,
+		queue:       make(chan []byte, hintQueueSize),
+		done:        make(chan struct{}),
+		prefetched:  make(map[[32]byte][]byte),
+	}
+	o.idle = sync.NewCond(&o.mu)
+	go o.run()
+	return o
+}
+
+func (o *AsyncHintOracle) Hint(v []byte) {
+	o.mu.Lock()
+	if o.err != nil {
+		o.mu.Unlock()
+		panic(o.err)
+	}
+	o.pending++
+	o.stats.Hints++
+	o.mu.Unlock()
+	// The VM may reuse the hint buffer.
+	o.queue <- bytes.Clone(v)
+}
+
+func (o *AsyncHintOracle) GetPreimage(k [32]byte) []byte {
+	o.mu.Lock()
+	if data, ok := o.takePrefetched(k); ok {
+		o.mu.Unlock()
+		return data
+	}
+	for o.pending > 0 && o.err == nil {
+		o.idle.Wait()
+	}
+	if o.err != nil {
+		o.mu.Unlock()
+		panic(o.err)
+	}
+	// The preimage may have been prefetched while waiting.
+	if data, ok := o.takePrefetched(k); ok {
+		o.mu.Unlock()
+		return data
+	}
+	o.stats.PrefetchMisses++
+	if o.metrics != nil {
+		o.metrics.RecordPrefetchMiss()
+	}
+	o.mu.Unlock()
+
+	o.poLock.Lock()
+	defer o.poLock.Unlock()
+	return o.po.GetPreimage(k)
+}
+
+// takePrefetched removes and returns the prefetched preimage of the key, if any.
+// The caller must hold mu.
+func (o *AsyncHintOracle) takePrefetched(k [32]byte) ([]byte, bool) {
+	data, ok := o.prefetched[k]
+	if !ok {
+		return nil, false
+	}
+	delete(o.prefetched, k)
+	o.stats.PrefetchHits++
+	if o.metrics != nil {
+		o.metrics.RecordPrefetchHit()
+	}
+	return data, true
+}
+
+// Stats returns the totals of the hints and prefetches so far.
+func (o *AsyncHintOracle) Stats() AsyncHintStats {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.stats
+}
+
+// Close stops processing hints after the queued hints, and must be called before closing the
+// wrapped oracle. Hint must not be called after Close.
+func (o *AsyncHintOracle) Close() {
+	close(o.queue)
+	<-o.done
+}
+
+func (o *AsyncHintOracle) run() {
+	defer close(o.done)
+	for hint := range o.queue {
+		batch := [][]byte{hint}
+	drain:
+		for len(batch) < maxHintBatch {
+			select {
+			case next, ok := <-o.queue:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, next)
+			default:
+				break drain
+			}
+		}
+		o.processBatch(batch)
+	}
+}
+
+// processBatch sends the hints to the oracle, and then prefetches their preimages, so the oracle
+// prepares the preimages of all the hints before serving any of them.
+func (o *AsyncHintOracle) processBatch(batch [][]byte) {
+	defer func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if r := recover(); r != nil && o.err == nil {
+			o.err = fmt.Errorf("failed to process hints: %v", r)
+		}
+		o.pending -= len(batch)
+		o.idle.Broadcast()
+	}()
+	o.mu.Lock()
+	failed := o.err != nil
+	o.mu.Unlock()
+	if failed {
+		return // drain the queue without using the oracle
+	}
+
+	o.poLock.Lock()
+	for _, hint := range batch {
+		o.po.Hint(hint)
+	}
+	o.poLock.Unlock()
+	o.mu.Lock()
+	o.stats.Batches++
+	if o.metrics != nil {
+		o.metrics.RecordHintBatch(len(batch))
+	}
+	o.mu.Unlock()
+
+	if o.prefetchKey == nil {
+		return
+	}
+	for _, hint := range batch {
+		key, ok := o.prefetchKey(hint)
+		if !ok {
+			continue
+		}
+		o.mu.Lock()
+		_, exists := o.prefetched[key]
+		full := len(o.prefetched) >= maxPrefetchedPreimages
+		o.mu.Unlock()
+		if exists || full {
+			continue
+		}
+		o.poLock.Lock()
+		data := o.po.GetPreimage(key)
+		o.poLock.Unlock()
+		o.mu.Lock()
+		o.prefetched[key] = data
+		o.stats.Prefetches++
+		if o.metrics != nil {
+			o.metrics.RecordPrefetch()
+		}
+		o.mu.Unlock()
+	}
+}
@@ -0,0 +1,186 @@
+package mipsevm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// hintedOracle serves a preimage only after its hint, like a host that prepares the preimages of
+// every hint.
+type hintedOracle struct {
+	mu        sync.Mutex
+	preimages map[[32]byte]hintedPreimage
+	hinted    map[string]bool
+	hints     []string
+	// block, if set, is received from before every hint is handled.
+	block chan struct{}
+	fail  bool
+}
+
+type hintedPreimage struct {
+	hint  string
+	value []byte
+}
+
+func newHintedOracle() *hintedOracle {
+	return &hintedOracle{
+		preimages: make(map[[32]byte]hintedPreimage),
+		hinted:    make(map[string]bool),
+	}
+}
+
+// add adds the keccak256 preimage of value, announced by a hint of the given type for its hash.
+func (o *hintedOracle) add(hintType string, value string) ([]byte, [32]byte) {
+	hash := crypto.Keccak256Hash([]byte(value))
+	hint := hintType + " " + hash.Hex()
+	key := preimage.Keccak256Key(hash).PreimageKey()
+	o.preimages[key] = hintedPreimage{hint: hint, value: []byte(value)}
+	return []byte(hint), key
+}
+
+func (o *hintedOracle) Hint(v []byte) {
+	if o.block != nil {
+		<-o.block
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.fail {
+		panic(errors.New("host exited"))
+	}
+	o.hints = append(o.hints, string(v))
+	o.hinted[string(v)] = true
+}
+
+func (o *hintedOracle) GetPreimage(k [32]byte) []byte {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	p, ok := o.preimages[k]
+	if !ok || !o.hinted[p.hint] {
+		panic("preimage was not hinted")
+	}
+	return p.value
+}
+
+type countingHintMetrics struct {
+	batches, prefetches, hits, misses int
+}
+
+func (m *countingHintMetrics) RecordHintBatch(size int) { m.batches++ }
+func (m *countingHintMetrics) RecordPrefetch()          { m.prefetches++ }
+func (m *countingHintMetrics) RecordPrefetchHit()       { m.hits++ }
+func (m *countingHintMetrics) RecordPrefetchMiss()      { m.misses++ }
+
+func TestKeccakHintPrefetchKey(t *testing.T) {
+	fn := KeccakHintPrefetchKey("l2-block-header", "l2-code")
+	hash := common.Hash{0xaa}
+	want := preimage.Keccak256Key(hash).PreimageKey()
+
+	key, ok := fn([]byte("l2-code " + hash.Hex()))
+	require.True(t, ok)
+	require.Equal(t, want, key)
+	// trailing data, e.g. the chain ID, is ignored
+	key, ok = fn([]byte("l2-block-header " + hash.Hex() + "0000000000000384"))
+	require.True(t, ok)
+	require.Equal(t, want, key)
+
+	for _, hint := range []string{
+		"l1-blob " + hash.Hex(),
+		"l2-code 0x1234",
+		"l2-code",
+		"l2-code " + hash.Hex()[2:],
+	} {
+		_, ok := fn([]byte(hint))
+		require.False(t, ok, hint)
+	}
+}
+
+func TestAsyncHintOracle(t *testing.T) {
+	prefetchKey := KeccakHintPrefetchKey("header")
+
+	t.Run("prefetch", func(t *testing.T) {
+		po := newHintedOracle()
+		hint, key := po.add("header", "block 1")
+		metrics := &countingHintMetrics{}
+		o := NewAsyncHintOracle(po, prefetchKey, metrics)
+		o.Hint(hint)
+		require.Equal(t, []byte("block 1"), o.GetPreimage(key))
+		o.Close()
+
+		stats := o.Stats()
+		require.Equal(t, AsyncHintStats{Hints: 1, Batches: 1, Prefetches: 1, PrefetchHits: 1}, stats)
+		require.Equal(t, 1.0, stats.HitRate())
+		require.Equal(t, &countingHintMetrics{batches: 1, prefetches: 1, hits: 1}, metrics)
+	})
+
+	t.Run("miss waits for queued hints", func(t *testing.T) {
+		po := newHintedOracle()
+		po.block = make(chan struct{})
+		// hints of other types are not prefetched, but must reach the host before the request
+		hint, key := po.add("other", "block 2")
+		o := NewAsyncHintOracle(po, prefetchKey, nil)
+		o.Hint(hint)
+		o.Hint([]byte("unrelated"))
+
+		result := make(chan []byte)
+		go func() {
+			result <- o.GetPreimage(key)
+		}()
+		close(po.block)
+		require.Equal(t, []byte("block 2"), <-result)
+		o.Close()
+
+		require.Equal(t, []string{string(hint), "unrelated"}, po.hints)
+		stats := o.Stats()
+		require.Equal(t, uint64(2), stats.Hints)
+		require.Zero(t, stats.Prefetches)
+		require.Zero(t, stats.PrefetchHits)
+		require.Equal(t, uint64(1), stats.PrefetchMisses)
+		require.Zero(t, stats.HitRate())
+	})
+
+	t.Run("batches queued hints", func(t *testing.T) {
+		po := newHintedOracle()
+		po.block = make(chan struct{})
+		o := NewAsyncHintOracle(po, prefetchKey, nil)
+		var keys [][32]byte
+		for i := 0; i < 10; i++ {
+			hint, key := po.add("header", fmt.Sprintf("block %d", i))
+			o.Hint(hint)
+			keys = append(keys, key)
+		}
+		close(po.block)
+		for i, key := range keys {
+			require.Equal(t, []byte(fmt.Sprintf("block %d", i)), o.GetPreimage(key))
+		}
+		o.Close()
+
+		// The hints are queued while the host handles the first one.
+		stats := o.Stats()
+		require.LessOrEqual(t, stats.Batches, uint64(2))
+		stats.Batches = 0
+		require.Equal(t, AsyncHintStats{Hints: 10, Prefetches: 10, PrefetchHits: 10}, stats)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		po := newHintedOracle()
+		po.fail = true
+		_, key := po.add("header", "block 3")
+		o := NewAsyncHintOracle(po, prefetchKey, nil)
+		o.Hint([]byte("hint"))
+		require.PanicsWithError(t, "failed to process hints: host exited", func() {
+			o.GetPreimage(key)
+		})
+		require.Panics(t, func() {
+			o.Hint([]byte("hint"))
+		})
+		o.Close()
+	})
+}
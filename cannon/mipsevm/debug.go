@@ -15,4 +15,26 @@ type DebugInfo struct {
 	ReservationInvalidationCount uint64 `json:"reservation_invalidation_count"`
 	ForcedPreemptionCount        uint64 `json:"forced_preemption_count"`
 	IdleStepCountThread0         uint64 `json:"idle_step_count_thread_0"`
+	// Threads is ordered by thread id. It includes the exited threads that were tracked by the stats.
+	Threads []ThreadInfo `json:"threads,omitempty"`
+}
+
+// ThreadInfo holds the scheduling of a thread, to diagnose threads that make no progress,
+// like threads that keep waiting on a futex that is never released.
+type ThreadInfo struct {
+	ThreadId uint64 `json:"thread_id"`
+	Exited   bool   `json:"exited"`
+	// Current is set for the thread that runs the next step.
+	Current  bool           `json:"current"`
+	PC       hexutil.Uint64 `json:"pc"`
+	Function string         `json:"function,omitempty"`
+	// The scheduling stats below are only tracked with stats enabled.
+	Steps uint64 `json:"steps"`
+	// Preemptions is the number of times the thread was preempted after running for the scheduler quantum.
+	Preemptions uint64 `json:"preemptions"`
+	// Yields is the number of times the thread yielded with a syscall, including futex waits.
+	Yields        uint64         `json:"yields"`
+	FutexWaits    uint64         `json:"futex_waits"`
+	LastFutexAddr hexutil.Uint64 `json:"last_futex_addr,omitempty"`
+	LastStep      uint64         `json:"last_step"`
 }
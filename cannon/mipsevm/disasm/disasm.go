@@ -0,0 +1,364 @@
+// Package disasm decodes and disassembles the MIPS instructions supported by the cannon VM.
+// It is used for human-readable fault reports, trace logs and diff tools, and does not execute anything.
+package disasm
+
+import "fmt"
+
+// RegNames are the names of the registers in the MIPS n64 ABI.
+var RegNames = [32]string{
+	"zero", "at", "v0", "v1", "a0", "a1", "a2", "a3",
+	"a4", "a5", "a6", "a7", "t0", "t1", "t2", "t3",
+	"s0", "s1", "s2", "s3", "s4", "s5", "s6", "s7",
+	"t8", "t9", "k0", "k1", "gp", "sp", "fp", "ra",
+}
+
+// Opcodes of the instructions that are decoded by their other fields.
+const (
+	OpSpecial  = 0x00
+	OpRegImm   = 0x01
+	OpSpecial2 = 0x1C
+	OpSpecial3 = 0x1F
+)
+
+// Instruction is a decoded MIPS instruction. Which fields are meaningful depends on the instruction format.
+type Instruction struct {
+	Raw uint32
+	// Opcode is the primary opcode, the top 6 bits.
+	Opcode uint32
+	// Rs, Rt and Rd are register numbers.
+	Rs, Rt, Rd uint32
+	// Shamt is the shift amount of shift instructions.
+	Shamt uint32
+	// Fun is the function field of R-type instructions, the bottom 6 bits.
+	Fun uint32
+	// Imm is the 16-bit immediate of I-type instructions.
+	Imm uint16
+	// Target is the 26-bit target of J-type instructions.
+	Target uint32
+}
+
+// Opcode returns the primary opcode of the instruction.
+func Opcode(insn uint32) uint32 {
+	return insn >> 26
+}
+
+// Fun returns the function field of the instruction.
+func Fun(insn uint32) uint32 {
+	return insn & 0x3f
+}
+
+// Decode splits the instruction into its fields.
+func Decode(insn uint32) Instruction {
+	return Instruction{
+		Raw:    insn,
+		Opcode: Opcode(insn),
+		Rs:     (insn >> 21) & 0x1F,
+		Rt:     (insn >> 16) & 0x1F,
+		Rd:     (insn >> 11) & 0x1F,
+		Shamt:  (insn >> 6) & 0x1F,
+		Fun:    Fun(insn),
+		Imm:    uint16(insn),
+		Target: insn & 0x03FFFFFF,
+	}
+}
+
+// SignedImm returns the sign-extended immediate.
+func (i Instruction) SignedImm() int64 {
+	return int64(int16(i.Imm))
+}
+
+// BranchTarget returns the address that the branch instruction at pc jumps to, if taken.
+func (i Instruction) BranchTarget(pc uint64) uint64 {
+	return pc + 4 + uint64(i.SignedImm()<<2)
+}
+
+// JumpTarget returns the address that the jump instruction at pc jumps to.
+// The target is in the 256 MB region of the delay slot.
+func (i Instruction) JumpTarget(pc uint64) uint64 {
+	return ((pc + 4) &^ 0x0FFFFFFF) | uint64(i.Target<<2)
+}
+
+// Disassemble returns the instruction at pc in MIPS assembly, e.g. "addiu $sp, $sp, -32".
+// The pc is used to resolve branch and jump targets.
+// Instructions that are not supported by the VM are returned as a ".word" directive.
+func Disassemble(pc uint64, insn uint32) string {
+	return Decode(insn).Disassemble(pc)
+}
+
+// Disassemble returns the instruction at pc in MIPS assembly, see Disassemble.
+func (i Instruction) Disassemble(pc uint64) string {
+	switch i.Opcode {
+	case OpSpecial:
+		return i.special()
+	case OpRegImm:
+		return i.regImm(pc)
+	case OpSpecial2:
+		return i.special2()
+	case OpSpecial3:
+		return i.special3()
+	case 0x02:
+		return fmt.Sprintf("j 0x%x", i.JumpTarget(pc))
+	case 0x03:
+		return fmt.Sprintf("jal 0x%x", i.JumpTarget(pc))
+	case 0x04:
+		if i.Rs == 0 && i.Rt == 0 {
+			return fmt.Sprintf("b 0x%x", i.BranchTarget(pc))
+		}
+		return fmt.Sprintf("beq %s, %s, 0x%x", reg(i.Rs), reg(i.Rt), i.BranchTarget(pc))
+	case 0x05:
+		return fmt.Sprintf("bne %s, %s, 0x%x", reg(i.Rs), reg(i.Rt), i.BranchTarget(pc))
+	case 0x06:
+		return fmt.Sprintf("blez %s, 0x%x", reg(i.Rs), i.BranchTarget(pc))
+	case 0x07:
+		return fmt.Sprintf("bgtz %s, 0x%x", reg(i.Rs), i.BranchTarget(pc))
+	case 0x0F:
+		return fmt.Sprintf("lui %s, 0x%x", reg(i.Rt), i.Imm)
+	}
+	if name, ok := immArith[i.Opcode]; ok {
+		if i.Opcode >= 0x0C && i.Opcode <= 0x0E { // andi, ori, xori zero-extend the immediate
+			return fmt.Sprintf("%s %s, %s, 0x%x", name, reg(i.Rt), reg(i.Rs), i.Imm)
+		}
+		return fmt.Sprintf("%s %s, %s, %d", name, reg(i.Rt), reg(i.Rs), i.SignedImm())
+	}
+	if name, ok := memOps[i.Opcode]; ok {
+		return fmt.Sprintf("%s %s, %d(%s)", name, reg(i.Rt), i.SignedImm(), reg(i.Rs))
+	}
+	if name, ok := fpMemOps[i.Opcode]; ok {
+		return fmt.Sprintf("%s $f%d, %d(%s)", name, i.Rt, i.SignedImm(), reg(i.Rs))
+	}
+	if i.Opcode == 0x11 {
+		return i.cop1()
+	}
+	return i.word()
+}
+
+var immArith = map[uint32]string{
+	0x08: "addi",
+	0x09: "addiu",
+	0x0A: "slti",
+	0x0B: "sltiu",
+	0x0C: "andi",
+	0x0D: "ori",
+	0x0E: "xori",
+	0x18: "daddi",
+	0x19: "daddiu",
+}
+
+var memOps = map[uint32]string{
+	0x1A: "ldl",
+	0x1B: "ldr",
+	0x20: "lb",
+	0x21: "lh",
+	0x22: "lwl",
+	0x23: "lw",
+	0x24: "lbu",
+	0x25: "lhu",
+	0x26: "lwr",
+	0x27: "lwu",
+	0x28: "sb",
+	0x29: "sh",
+	0x2A: "swl",
+	0x2B: "sw",
+	0x2C: "sdl",
+	0x2D: "sdr",
+	0x2E: "swr",
+	0x30: "ll",
+	0x34: "lld",
+	0x37: "ld",
+	0x38: "sc",
+	0x3C: "scd",
+	0x3F: "sd",
+}
+
+// fpMemOps are the floating point loads and stores, of the form "op ft, offset(base)".
+var fpMemOps = map[uint32]string{
+	0x31: "lwc1",
+	0x35: "ldc1",
+	0x39: "swc1",
+	0x3D: "sdc1",
+}
+
+// cop1Moves are the COP1 instructions that move between general purpose and floating point registers,
+// of the form "op rt, fs", by the format field of the instruction.
+var cop1Moves = map[uint32]string{
+	0x00: "mfc1",
+	0x01: "dmfc1",
+	0x02: "cfc1",
+	0x03: "mfhc1",
+	0x04: "mtc1",
+	0x05: "dmtc1",
+	0x06: "ctc1",
+	0x07: "mthc1",
+}
+
+// specialRegs are the SPECIAL instructions of the form "op rd, rs, rt".
+var specialRegs = map[uint32]string{
+	0x0A: "movz",
+	0x0B: "movn",
+	0x20: "add",
+	0x21: "addu",
+	0x22: "sub",
+	0x23: "subu",
+	0x24: "and",
+	0x25: "or",
+	0x26: "xor",
+	0x27: "nor",
+	0x2A: "slt",
+	0x2B: "sltu",
+	0x2C: "dadd",
+	0x2D: "daddu",
+	0x2E: "dsub",
+	0x2F: "dsubu",
+}
+
+// specialShifts are the SPECIAL instructions of the form "op rd, rt, sa".
+var specialShifts = map[uint32]string{
+	0x00: "sll",
+	0x02: "srl",
+	0x03: "sra",
+	0x38: "dsll",
+	0x3A: "dsrl",
+	0x3B: "dsra",
+	0x3C: "dsll32",
+	0x3E: "dsrl32",
+	0x3F: "dsra32",
+}
+
+// specialRotates are the rotations of the form "op rd, rt, sa", encoded as the shift of the same function with rs=1.
+var specialRotates = map[uint32]string{
+	0x02: "rotr",
+	0x3A: "drotr",
+	0x3E: "drotr32",
+}
+
+// specialVarShifts are the SPECIAL instructions of the form "op rd, rt, rs".
+var specialVarShifts = map[uint32]string{
+	0x04: "sllv",
+	0x06: "srlv",
+	0x07: "srav",
+	0x14: "dsllv",
+	0x16: "dsrlv",
+	0x17: "dsrav",
+}
+
+// specialMulDiv are the SPECIAL instructions of the form "op rs, rt", writing to HI and LO.
+var specialMulDiv = map[uint32]string{
+	0x18: "mult",
+	0x19: "multu",
+	0x1A: "div",
+	0x1B: "divu",
+	0x1C: "dmult",
+	0x1D: "dmultu",
+	0x1E: "ddiv",
+	0x1F: "ddivu",
+}
+
+func (i Instruction) special() string {
+	if i.Raw == 0 {
+		return "nop"
+	}
+	switch i.Fun {
+	case 0x08:
+		return "jr " + reg(i.Rs)
+	case 0x09:
+		if i.Rd == 31 {
+			return "jalr " + reg(i.Rs)
+		}
+		return fmt.Sprintf("jalr %s, %s", reg(i.Rd), reg(i.Rs))
+	case 0x0C:
+		return "syscall"
+	case 0x0F:
+		return "sync"
+	case 0x10:
+		return "mfhi " + reg(i.Rd)
+	case 0x11:
+		return "mthi " + reg(i.Rs)
+	case 0x12:
+		return "mflo " + reg(i.Rd)
+	case 0x13:
+		return "mtlo " + reg(i.Rs)
+	}
+	if name, ok := specialRegs[i.Fun]; ok {
+		return fmt.Sprintf("%s %s, %s, %s", name, reg(i.Rd), reg(i.Rs), reg(i.Rt))
+	}
+	if name, ok := specialRotates[i.Fun]; ok && i.Rs == 1 {
+		return fmt.Sprintf("%s %s, %s, %d", name, reg(i.Rd), reg(i.Rt), i.Shamt)
+	}
+	if i.Fun == 0x06 && i.Shamt == 1 {
+		return fmt.Sprintf("rotrv %s, %s, %s", reg(i.Rd), reg(i.Rt), reg(i.Rs))
+	}
+	if name, ok := specialShifts[i.Fun]; ok {
+		return fmt.Sprintf("%s %s, %s, %d", name, reg(i.Rd), reg(i.Rt), i.Shamt)
+	}
+	if name, ok := specialVarShifts[i.Fun]; ok {
+		return fmt.Sprintf("%s %s, %s, %s", name, reg(i.Rd), reg(i.Rt), reg(i.Rs))
+	}
+	if name, ok := specialMulDiv[i.Fun]; ok {
+		return fmt.Sprintf("%s %s, %s", name, reg(i.Rs), reg(i.Rt))
+	}
+	return i.word()
+}
+
+func (i Instruction) regImm(pc uint64) string {
+	var name string
+	switch i.Rt {
+	case 0x00:
+		name = "bltz"
+	case 0x01:
+		name = "bgez"
+	case 0x10:
+		name = "bltzal"
+	case 0x11:
+		if i.Rs == 0 {
+			return fmt.Sprintf("bal 0x%x", i.BranchTarget(pc))
+		}
+		name = "bgezal"
+	default:
+		return i.word()
+	}
+	return fmt.Sprintf("%s %s, 0x%x", name, reg(i.Rs), i.BranchTarget(pc))
+}
+
+func (i Instruction) special2() string {
+	switch i.Fun {
+	case 0x02:
+		return fmt.Sprintf("mul %s, %s, %s", reg(i.Rd), reg(i.Rs), reg(i.Rt))
+	case 0x20:
+		return fmt.Sprintf("clz %s, %s", reg(i.Rd), reg(i.Rs))
+	case 0x21:
+		return fmt.Sprintf("clo %s, %s", reg(i.Rd), reg(i.Rs))
+	case 0x24:
+		return fmt.Sprintf("dclz %s, %s", reg(i.Rd), reg(i.Rs))
+	case 0x25:
+		return fmt.Sprintf("dclo %s, %s", reg(i.Rd), reg(i.Rs))
+	}
+	return i.word()
+}
+
+func (i Instruction) special3() string {
+	if i.Fun == 0x20 && i.Rs == 0 { // BSHFL
+		switch i.Shamt {
+		case 0x10:
+			return fmt.Sprintf("seb %s, %s", reg(i.Rd), reg(i.Rt))
+		case 0x18:
+			return fmt.Sprintf("seh %s, %s", reg(i.Rd), reg(i.Rt))
+		}
+	}
+	return i.word()
+}
+
+// cop1 disassembles the moves of COP1. The other floating point instructions are not disassembled.
+func (i Instruction) cop1() string {
+	if name, ok := cop1Moves[i.Rs]; ok {
+		return fmt.Sprintf("%s %s, $f%d", name, reg(i.Rt), i.Rd)
+	}
+	return i.word()
+}
+
+func (i Instruction) word() string {
+	return fmt.Sprintf(".word 0x%08x", i.Raw)
+}
+
+func reg(r uint32) string {
+	return "$" + RegNames[r&0x1F]
+}
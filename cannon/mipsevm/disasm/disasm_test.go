@@ -0,0 +1,172 @@
+package disasm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	// The encodings were produced by an independent MIPS64 assembler.
+	cases := []struct {
+		insn uint32
+		want string
+	}{
+		{insn: 0x0000_0000, want: "nop"},
+		{insn: 0xc481_fffc, want: "lwc1 $f1, -4($a0)"},
+		{insn: 0xd7ac_0008, want: "ldc1 $f12, 8($sp)"},
+		{insn: 0xe4a2_0000, want: "swc1 $f2, 0($a1)"},
+		{insn: 0xf7a0_0010, want: "sdc1 $f0, 16($sp)"},
+		{insn: 0x4402_6000, want: "mfc1 $v0, $f12"},
+		{insn: 0x44a1_0000, want: "dmtc1 $at, $f0"},
+		{insn: 0x000d_6100, want: "sll $t0, $t1, 4"},
+		{insn: 0x000d_67c2, want: "srl $t0, $t1, 31"},
+		{insn: 0x0004_1043, want: "sra $v0, $a0, 1"},
+		{insn: 0x002d_60c2, want: "rotr $t0, $t1, 3"},
+		{insn: 0x01cd_6004, want: "sllv $t0, $t1, $t2"},
+		{insn: 0x01cd_6006, want: "srlv $t0, $t1, $t2"},
+		{insn: 0x01cd_6046, want: "rotrv $t0, $t1, $t2"},
+		{insn: 0x01cd_6007, want: "srav $t0, $t1, $t2"},
+		{insn: 0x03e0_0008, want: "jr $ra"},
+		{insn: 0x0320_f809, want: "jalr $t9"},
+		{insn: 0x0320_8009, want: "jalr $s0, $t9"},
+		{insn: 0x0085_100a, want: "movz $v0, $a0, $a1"},
+		{insn: 0x0085_100b, want: "movn $v0, $a0, $a1"},
+		{insn: 0x0000_000c, want: "syscall"},
+		{insn: 0x0000_000f, want: "sync"},
+		{insn: 0x0000_6010, want: "mfhi $t0"},
+		{insn: 0x01a0_0011, want: "mthi $t1"},
+		{insn: 0x0000_7012, want: "mflo $t2"},
+		{insn: 0x01e0_0013, want: "mtlo $t3"},
+		{insn: 0x01cd_6014, want: "dsllv $t0, $t1, $t2"},
+		{insn: 0x01cd_6016, want: "dsrlv $t0, $t1, $t2"},
+		{insn: 0x01cd_6017, want: "dsrav $t0, $t1, $t2"},
+		{insn: 0x0085_0018, want: "mult $a0, $a1"},
+		{insn: 0x0085_0019, want: "multu $a0, $a1"},
+		{insn: 0x0085_001a, want: "div $a0, $a1"},
+		{insn: 0x0085_001b, want: "divu $a0, $a1"},
+		{insn: 0x0085_001c, want: "dmult $a0, $a1"},
+		{insn: 0x0085_001d, want: "dmultu $a0, $a1"},
+		{insn: 0x0085_001e, want: "ddiv $a0, $a1"},
+		{insn: 0x0085_001f, want: "ddivu $a0, $a1"},
+		{insn: 0x0085_1020, want: "add $v0, $a0, $a1"},
+		{insn: 0x0085_1021, want: "addu $v0, $a0, $a1"},
+		{insn: 0x0085_1022, want: "sub $v0, $a0, $a1"},
+		{insn: 0x0085_1023, want: "subu $v0, $a0, $a1"},
+		{insn: 0x0085_1024, want: "and $v0, $a0, $a1"},
+		{insn: 0x0085_1025, want: "or $v0, $a0, $a1"},
+		{insn: 0x0085_1026, want: "xor $v0, $a0, $a1"},
+		{insn: 0x0085_1027, want: "nor $v0, $a0, $a1"},
+		{insn: 0x0085_102a, want: "slt $v0, $a0, $a1"},
+		{insn: 0x0085_102b, want: "sltu $v0, $a0, $a1"},
+		{insn: 0x0085_102c, want: "dadd $v0, $a0, $a1"},
+		{insn: 0x0085_102d, want: "daddu $v0, $a0, $a1"},
+		{insn: 0x0085_102e, want: "dsub $v0, $a0, $a1"},
+		{insn: 0x0085_102f, want: "dsubu $v0, $a0, $a1"},
+		{insn: 0x000d_60f8, want: "dsll $t0, $t1, 3"},
+		{insn: 0x000d_60fa, want: "dsrl $t0, $t1, 3"},
+		{insn: 0x000d_60fb, want: "dsra $t0, $t1, 3"},
+		{insn: 0x000d_60fc, want: "dsll32 $t0, $t1, 3"},
+		{insn: 0x000d_60fe, want: "dsrl32 $t0, $t1, 3"},
+		{insn: 0x000d_60ff, want: "dsra32 $t0, $t1, 3"},
+		{insn: 0x002d_60fa, want: "drotr $t0, $t1, 3"},
+		{insn: 0x002d_60fe, want: "drotr32 $t0, $t1, 3"},
+		{insn: 0x7c04_1420, want: "seb $v0, $a0"},
+		{insn: 0x7c04_1620, want: "seh $v0, $a0"},
+		{insn: 0x23bd_ffe0, want: "addi $sp, $sp, -32"},
+		{insn: 0x27bd_ffe0, want: "addiu $sp, $sp, -32"},
+		{insn: 0x2882_0064, want: "slti $v0, $a0, 100"},
+		{insn: 0x2c82_0064, want: "sltiu $v0, $a0, 100"},
+		{insn: 0x3082_00ff, want: "andi $v0, $a0, 0xff"},
+		{insn: 0x3482_8000, want: "ori $v0, $a0, 0x8000"},
+		{insn: 0x3882_0001, want: "xori $v0, $a0, 0x1"},
+		{insn: 0x3c01_1234, want: "lui $at, 0x1234"},
+		{insn: 0x63bd_fff0, want: "daddi $sp, $sp, -16"},
+		{insn: 0x67bd_fff0, want: "daddiu $sp, $sp, -16"},
+		{insn: 0x688c_0007, want: "ldl $t0, 7($a0)"},
+		{insn: 0x6c8c_0000, want: "ldr $t0, 0($a0)"},
+		{insn: 0x83ac_ffff, want: "lb $t0, -1($sp)"},
+		{insn: 0x87ac_0002, want: "lh $t0, 2($sp)"},
+		{insn: 0x888c_0003, want: "lwl $t0, 3($a0)"},
+		{insn: 0x8fbf_001c, want: "lw $ra, 28($sp)"},
+		{insn: 0x908c_0000, want: "lbu $t0, 0($a0)"},
+		{insn: 0x948c_0002, want: "lhu $t0, 2($a0)"},
+		{insn: 0x988c_0000, want: "lwr $t0, 0($a0)"},
+		{insn: 0x9c8c_0004, want: "lwu $t0, 4($a0)"},
+		{insn: 0xa08c_0000, want: "sb $t0, 0($a0)"},
+		{insn: 0xa48c_0002, want: "sh $t0, 2($a0)"},
+		{insn: 0xa88c_0003, want: "swl $t0, 3($a0)"},
+		{insn: 0xafbf_001c, want: "sw $ra, 28($sp)"},
+		{insn: 0xb08c_0007, want: "sdl $t0, 7($a0)"},
+		{insn: 0xb48c_0000, want: "sdr $t0, 0($a0)"},
+		{insn: 0xb88c_0000, want: "swr $t0, 0($a0)"},
+		{insn: 0xc08c_0000, want: "ll $t0, 0($a0)"},
+		{insn: 0xd08c_0000, want: "lld $t0, 0($a0)"},
+		{insn: 0xdfbf_0008, want: "ld $ra, 8($sp)"},
+		{insn: 0xe08c_0000, want: "sc $t0, 0($a0)"},
+		{insn: 0xf08c_0000, want: "scd $t0, 0($a0)"},
+		{insn: 0xffbf_0008, want: "sd $ra, 8($sp)"},
+		{insn: 0x7085_1002, want: "mul $v0, $a0, $a1"},
+		{insn: 0x7082_1020, want: "clz $v0, $a0"},
+		{insn: 0x7082_1021, want: "clo $v0, $a0"},
+		{insn: 0x7082_1024, want: "dclz $v0, $a0"},
+		{insn: 0x7082_1025, want: "dclo $v0, $a0"},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%08x", c.insn), func(t *testing.T) {
+			require.Equal(t, c.want, Disassemble(0x1000, c.insn))
+		})
+	}
+}
+
+func TestDisassembleBranches(t *testing.T) {
+	cases := []struct {
+		pc   uint64
+		insn uint32
+		want string
+	}{
+		{pc: 0x1000, insn: 0x1085_0004, want: "beq $a0, $a1, 0x1014"},
+		{pc: 0x1000, insn: 0x1000_0003, want: "b 0x1010"},
+		{pc: 0x1000, insn: 0x1485_0004, want: "bne $a0, $a1, 0x1014"},
+		{pc: 0x1000, insn: 0x1880_ffff, want: "blez $a0, 0x1000"},
+		{pc: 0x1000, insn: 0x1c80_0002, want: "bgtz $a0, 0x100c"},
+		{pc: 0x1000, insn: 0x0480_0001, want: "bltz $a0, 0x1008"},
+		{pc: 0x1000, insn: 0x0481_fffe, want: "bgez $a0, 0xffc"},
+		{pc: 0x1000, insn: 0x0490_0001, want: "bltzal $a0, 0x1008"},
+		{pc: 0x1000, insn: 0x0491_0001, want: "bgezal $a0, 0x1008"},
+		{pc: 0x1000, insn: 0x0411_0001, want: "bal 0x1008"},
+		{pc: 0x1000, insn: 0x0800_0400, want: "j 0x1000"},
+		{pc: 0x1000, insn: 0x0c00_0400, want: "jal 0x1000"},
+		// the jump target is in the 256 MB region of the delay slot, not of the jump
+		{pc: 0x1000_0000_0fff_fffc, insn: 0x0800_0400, want: "j 0x1000000010001000"},
+	}
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			require.Equal(t, c.want, Disassemble(c.pc, c.insn))
+		})
+	}
+}
+
+func TestDisassembleUnsupported(t *testing.T) {
+	for _, insn := range []uint32{
+		0x4000_0000, // COP0
+		0x0000_0001, // SPECIAL with unused function
+		0x0482_0001, // REGIMM with unsupported rt
+		0x7000_0003, // SPECIAL2 with unsupported function
+		0x7c04_1020, // SPECIAL3 BSHFL with unsupported sa
+	} {
+		require.Equal(t, fmt.Sprintf(".word 0x%08x", insn), Disassemble(0, insn))
+	}
+}
+
+func TestDecode(t *testing.T) {
+	i := Decode(0x27bd_ffe0) // addiu $sp, $sp, -32
+	require.Equal(t, uint32(0x09), i.Opcode)
+	require.Equal(t, uint32(29), i.Rs)
+	require.Equal(t, uint32(29), i.Rt)
+	require.Equal(t, uint16(0xffe0), i.Imm)
+	require.Equal(t, int64(-32), i.SignedImm())
+	require.Equal(t, Opcode(i.Raw), i.Opcode)
+	require.Equal(t, Fun(i.Raw), i.Fun)
+}
@@ -0,0 +1,376 @@
+package exec
+
+import (
+	"math/bits"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// The instructions that ExecuteMipsInstruction computes the value of are decoded with a table,
+// indexed by their opcode, or by their function for the SPECIAL, SPECIAL2 and SPECIAL3 opcodes.
+// Entries that share an index are told apart by the other fields of the instruction, e.g. rotr is srl with rs=1.
+
+// instruction is an entry of the decode table.
+type instruction struct {
+	name   string
+	opcode uint32
+	// fun is the function of SPECIAL, SPECIAL2 and SPECIAL3 instructions, and ignored for other opcodes.
+	fun uint32
+	// mask and match select the encodings of the entry among the entries of its index: insn&mask == match.
+	mask, match uint32
+	// mips64 is set for the instructions that only exist on MIPS64.
+	mips64 bool
+	// enabled returns whether the instruction is supported with the given features, nil if it is always supported.
+	// The encodings of an instruction that is not supported decode to the next entry of the index that matches them.
+	enabled func(features mipsevm.FeatureToggles) bool
+	// exec returns the value of the instruction: the value of the destination register, or the memory word of stores.
+	exec func(insn uint32, rs, rt, mem Word) Word
+}
+
+const (
+	tableOpcode = iota
+	tableSpecial
+	tableSpecial2
+	tableSpecial3
+)
+
+// decodeTable holds the entries of instructions, by table and index.
+var decodeTable [4][64][]*instruction
+
+func init() {
+	for i := range instructions {
+		entry := &instructions[i]
+		table, index := decodeIndex(entry.opcode, entry.fun)
+		decodeTable[table][index] = append(decodeTable[table][index], entry)
+	}
+}
+
+func decodeIndex(opcode, fun uint32) (int, uint32) {
+	switch opcode {
+	case OpSpecial:
+		return tableSpecial, fun
+	case OpSpecial2:
+		return tableSpecial2, fun
+	case OpSpecial3:
+		return tableSpecial3, fun
+	default:
+		return tableOpcode, opcode
+	}
+}
+
+// decode returns the entry of the instruction, or nil if the instruction is not supported with the given features.
+func decode(insn, opcode, fun uint32, features mipsevm.FeatureToggles) *instruction {
+	table, index := decodeIndex(opcode, fun)
+	for _, entry := range decodeTable[table][index] {
+		if insn&entry.mask != entry.match {
+			continue
+		}
+		if entry.mips64 && arch.IsMips32 {
+			continue
+		}
+		if entry.enabled != nil && !entry.enabled(features) {
+			continue
+		}
+		return entry
+	}
+	return nil
+}
+
+func supportDclzDclo(features mipsevm.FeatureToggles) bool { return features.SupportDclzDclo }
+
+func supportRotrSebSeh(features mipsevm.FeatureToggles) bool { return features.SupportRotrSebSeh }
+
+// Fields of the encodings that extend other instructions
+const (
+	maskRs = 0x1F << 21
+	maskSa = 0x1F << 6
+)
+
+var instructions = []instruction{
+	// SPECIAL
+	{name: "sll", opcode: OpSpecial, fun: 0x00, exec: func(insn uint32, rs, rt, mem Word) Word {
+		shiftAmt := (insn >> 6) & 0x1F
+		return SignExtend((rt<<shiftAmt)&U32Mask, 32)
+	}},
+	{name: "rotr", opcode: OpSpecial, fun: 0x02, mask: maskRs, match: 1 << 21, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(Word(bits.RotateLeft32(uint32(rt), -int((insn>>6)&0x1F))), 32)
+	}},
+	{name: "srl", opcode: OpSpecial, fun: 0x02, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend((rt&U32Mask)>>((insn>>6)&0x1F), 32)
+	}},
+	{name: "sra", opcode: OpSpecial, fun: 0x03, exec: func(insn uint32, rs, rt, mem Word) Word {
+		shamt := Word((insn >> 6) & 0x1F)
+		return SignExtend((rt&U32Mask)>>shamt, 32-shamt)
+	}},
+	{name: "sllv", opcode: OpSpecial, fun: 0x04, exec: func(insn uint32, rs, rt, mem Word) Word {
+		shiftAmt := rs & 0x1F
+		return SignExtend((rt<<shiftAmt)&U32Mask, 32)
+	}},
+	{name: "rotrv", opcode: OpSpecial, fun: 0x06, mask: maskSa, match: 1 << 6, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(Word(bits.RotateLeft32(uint32(rt), -int(rs&0x1F))), 32)
+	}},
+	{name: "srlv", opcode: OpSpecial, fun: 0x06, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend((rt&U32Mask)>>(rs&0x1F), 32)
+	}},
+	{name: "srav", opcode: OpSpecial, fun: 0x07, exec: func(insn uint32, rs, rt, mem Word) Word {
+		shamt := Word(rs & 0x1F)
+		return SignExtend((rt&U32Mask)>>shamt, 32-shamt)
+	}},
+	// functs in range [0x8, 0x1b] for 32-bit and [0x8, 0x1f] for 64-bit are handled specially by other functions
+	{name: "jr", opcode: OpSpecial, fun: 0x08, exec: execRs},
+	{name: "jalr", opcode: OpSpecial, fun: 0x09, exec: execRs},
+	{name: "movz", opcode: OpSpecial, fun: 0x0A, exec: execRs},
+	{name: "movn", opcode: OpSpecial, fun: 0x0B, exec: execRs},
+	{name: "syscall", opcode: OpSpecial, fun: 0x0C, exec: execRs},
+	// 0x0d - break not supported
+	{name: "sync", opcode: OpSpecial, fun: 0x0F, exec: execRs},
+	{name: "mfhi", opcode: OpSpecial, fun: 0x10, exec: execRs},
+	{name: "mthi", opcode: OpSpecial, fun: 0x11, exec: execRs},
+	{name: "mflo", opcode: OpSpecial, fun: 0x12, exec: execRs},
+	{name: "mtlo", opcode: OpSpecial, fun: 0x13, exec: execRs},
+	{name: "dsllv", opcode: OpSpecial, fun: 0x14, mips64: true, exec: execRt},
+	{name: "dsrlv", opcode: OpSpecial, fun: 0x16, mips64: true, exec: execRt},
+	{name: "dsrav", opcode: OpSpecial, fun: 0x17, mips64: true, exec: execRt},
+	{name: "mult", opcode: OpSpecial, fun: 0x18, exec: execRs},
+	{name: "multu", opcode: OpSpecial, fun: 0x19, exec: execRs},
+	{name: "div", opcode: OpSpecial, fun: 0x1A, exec: execRs},
+	{name: "divu", opcode: OpSpecial, fun: 0x1B, exec: execRs},
+	{name: "dmult", opcode: OpSpecial, fun: 0x1C, mips64: true, exec: execRs},
+	{name: "dmultu", opcode: OpSpecial, fun: 0x1D, mips64: true, exec: execRs},
+	{name: "ddiv", opcode: OpSpecial, fun: 0x1E, mips64: true, exec: execRs},
+	{name: "ddivu", opcode: OpSpecial, fun: 0x1F, mips64: true, exec: execRs},
+	{name: "add", opcode: OpSpecial, fun: 0x20, exec: execAdd},
+	{name: "addu", opcode: OpSpecial, fun: 0x21, exec: execAddu},
+	{name: "sub", opcode: OpSpecial, fun: 0x22, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(Word(int32(rs)-int32(rt)), 32)
+	}},
+	{name: "subu", opcode: OpSpecial, fun: 0x23, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(Word(uint32(rs)-uint32(rt)), 32)
+	}},
+	{name: "and", opcode: OpSpecial, fun: 0x24, exec: execAnd},
+	{name: "or", opcode: OpSpecial, fun: 0x25, exec: execOr},
+	{name: "xor", opcode: OpSpecial, fun: 0x26, exec: execXor},
+	{name: "nor", opcode: OpSpecial, fun: 0x27, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return ^(rs | rt)
+	}},
+	{name: "slt", opcode: OpSpecial, fun: 0x2A, exec: execSlt},
+	{name: "sltu", opcode: OpSpecial, fun: 0x2B, exec: execSltu},
+	{name: "dadd", opcode: OpSpecial, fun: 0x2C, mips64: true, exec: execDadd},
+	{name: "daddu", opcode: OpSpecial, fun: 0x2D, mips64: true, exec: execDadd},
+	{name: "dsub", opcode: OpSpecial, fun: 0x2E, mips64: true, exec: execDsub},
+	{name: "dsubu", opcode: OpSpecial, fun: 0x2F, mips64: true, exec: execDsub},
+	{name: "dsll", opcode: OpSpecial, fun: 0x38, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return rt << ((insn >> 6) & 0x1f)
+	}},
+	{name: "drotr", opcode: OpSpecial, fun: 0x3A, mask: maskRs, match: 1 << 21, mips64: true, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.RotateLeft64(uint64(rt), -int((insn>>6)&0x1f)))
+	}},
+	{name: "dsrl", opcode: OpSpecial, fun: 0x3A, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return rt >> ((insn >> 6) & 0x1f)
+	}},
+	{name: "dsra", opcode: OpSpecial, fun: 0x3B, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(int64(rt) >> ((insn >> 6) & 0x1f))
+	}},
+	{name: "dsll32", opcode: OpSpecial, fun: 0x3C, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return rt << (((insn >> 6) & 0x1f) + 32)
+	}},
+	{name: "drotr32", opcode: OpSpecial, fun: 0x3E, mask: maskRs, match: 1 << 21, mips64: true, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.RotateLeft64(uint64(rt), -int(((insn>>6)&0x1f)+32)))
+	}},
+	{name: "dsrl32", opcode: OpSpecial, fun: 0x3E, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return rt >> (((insn >> 6) & 0x1f) + 32)
+	}},
+	{name: "dsra32", opcode: OpSpecial, fun: 0x3F, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(int64(rt) >> (((insn >> 6) & 0x1f) + 32))
+	}},
+
+	// SPECIAL2
+	{name: "mul", opcode: OpSpecial2, fun: 0x02, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(Word(int32(rs)*int32(rt)), 32)
+	}},
+	{name: "clz", opcode: OpSpecial2, fun: 0x20, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.LeadingZeros32(uint32(rs)))
+	}},
+	{name: "clo", opcode: OpSpecial2, fun: 0x21, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.LeadingZeros32(^uint32(rs)))
+	}},
+	{name: "dclz", opcode: OpSpecial2, fun: 0x24, mips64: true, enabled: supportDclzDclo, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.LeadingZeros64(uint64(rs)))
+	}},
+	{name: "dclo", opcode: OpSpecial2, fun: 0x25, mips64: true, enabled: supportDclzDclo, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return Word(bits.LeadingZeros64(^uint64(rs)))
+	}},
+
+	// SPECIAL3
+	{name: "seb", opcode: OpSpecial3, fun: FunBshfl, mask: maskRs | maskSa, match: 0x10 << 6, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(rt&0xFF, 8)
+	}},
+	{name: "seh", opcode: OpSpecial3, fun: FunBshfl, mask: maskRs | maskSa, match: 0x18 << 6, enabled: supportRotrSebSeh, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(rt&0xFFFF, 16)
+	}},
+
+	// I-type arithmetic, computed like their R-type counterparts with the immediate as rt
+	{name: "addi", opcode: 0x08, exec: execAdd},
+	{name: "addiu", opcode: 0x09, exec: execAddu},
+	{name: "slti", opcode: 0x0A, exec: execSlt},
+	{name: "sltiu", opcode: 0x0B, exec: execSltu},
+	{name: "andi", opcode: 0x0C, exec: execAnd},
+	{name: "ori", opcode: 0x0D, exec: execOr},
+	{name: "xori", opcode: 0x0E, exec: execXor},
+	{name: "lui", opcode: 0x0F, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SignExtend(rt<<16, 32)
+	}},
+	{name: "daddi", opcode: 0x18, mips64: true, exec: execDadd},
+	{name: "daddiu", opcode: 0x19, mips64: true, exec: execDadd},
+
+	// Loads and stores
+	{name: "lb", opcode: 0x20, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SelectSubWord(rs, mem, 1, true)
+	}},
+	{name: "lh", opcode: 0x21, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SelectSubWord(rs, mem, 2, true)
+	}},
+	{name: "lwl", opcode: 0x22, exec: func(insn uint32, rs, rt, mem Word) Word {
+		if arch.IsMips32 {
+			val := mem << ((rs & 3) * 8)
+			mask := Word(uint32(U32Mask) << ((rs & 3) * 8))
+			return SignExtend(((rt & ^mask)|val)&U32Mask, 32)
+		} else {
+			// similar to the above mips32 implementation but loads are constrained to the nearest 4-byte memory word
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := w << ((rs & 3) * 8)
+			mask := Word(uint32(U32Mask) << ((rs & 3) * 8))
+			return SignExtend(((rt & ^mask)|Word(val))&U32Mask, 32)
+		}
+	}},
+	{name: "lw", opcode: 0x23, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SelectSubWord(rs, mem, 4, true)
+	}},
+	{name: "lbu", opcode: 0x24, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SelectSubWord(rs, mem, 1, false)
+	}},
+	{name: "lhu", opcode: 0x25, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return SelectSubWord(rs, mem, 2, false)
+	}},
+	{name: "lwr", opcode: 0x26, exec: func(insn uint32, rs, rt, mem Word) Word {
+		if arch.IsMips32 {
+			val := mem >> (24 - (rs&3)*8)
+			mask := Word(uint32(U32Mask) >> (24 - (rs&3)*8))
+			return SignExtend(((rt & ^mask)|val)&U32Mask, 32)
+		} else {
+			// similar to the above mips32 implementation but constrained to the nearest 4-byte memory word
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := w >> (24 - (rs&3)*8)
+			mask := uint32(U32Mask) >> (24 - (rs&3)*8)
+			lwrResult := (uint32(rt) & ^mask) | val
+			if rs&3 == 3 { // loaded bit 31
+				return SignExtend(Word(lwrResult), 32)
+			} else {
+				// NOTE: cannon64 implementation specific: We leave the upper word untouched
+				rtMask := uint64(0xFF_FF_FF_FF_00_00_00_00)
+				return (rt & Word(rtMask)) | Word(lwrResult)
+			}
+		}
+	}},
+	{name: "sb", opcode: 0x28, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return UpdateSubWord(rs, mem, 1, rt)
+	}},
+	{name: "sh", opcode: 0x29, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return UpdateSubWord(rs, mem, 2, rt)
+	}},
+	{name: "swl", opcode: 0x2A, exec: func(insn uint32, rs, rt, mem Word) Word {
+		if arch.IsMips32 {
+			val := rt >> ((rs & 3) * 8)
+			mask := uint32(U32Mask) >> ((rs & 3) * 8)
+			return (mem & Word(^mask)) | val
+		} else {
+			sr := (rs & 3) << 3
+			val := ((rt & U32Mask) >> sr) << (32 - ((rs & 0x4) << 3))
+			mask := (uint64(U32Mask) >> sr) << (32 - ((rs & 0x4) << 3))
+			return (mem & Word(^mask)) | val
+		}
+	}},
+	{name: "sw", opcode: 0x2B, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return UpdateSubWord(rs, mem, 4, rt)
+	}},
+	{name: "swr", opcode: 0x2E, exec: func(insn uint32, rs, rt, mem Word) Word {
+		if arch.IsMips32 {
+			val := rt << (24 - (rs&3)*8)
+			mask := uint32(U32Mask) << (24 - (rs&3)*8)
+			return (mem & Word(^mask)) | val
+		} else {
+			// similar to the above mips32 implementation but constrained to the nearest 4-byte memory word
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := rt << (24 - (rs&3)*8)
+			mask := uint32(U32Mask) << (24 - (rs&3)*8)
+			swrResult := (w & ^mask) | uint32(val)
+			return UpdateSubWord(rs, mem, 4, Word(swrResult))
+		}
+	}},
+
+	// MIPS64 loads and stores
+	{name: "ldl", opcode: 0x1A, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		sl := (rs & 0x7) << 3
+		val := mem << sl
+		mask := ^Word(0) << sl
+		return val | (rt & ^mask)
+	}},
+	{name: "ldr", opcode: 0x1B, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		sr := 56 - ((rs & 0x7) << 3)
+		val := mem >> sr
+		mask := ^Word(0) << (64 - sr)
+		return val | (rt & mask)
+	}},
+	{name: "lwu", opcode: 0x27, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return (mem >> (32 - ((rs & 0x4) << 3))) & U32Mask
+	}},
+	{name: "sdl", opcode: 0x2C, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		sr := (rs & 0x7) << 3
+		val := rt >> sr
+		mask := ^Word(0) >> sr
+		return val | (mem & ^mask)
+	}},
+	{name: "sdr", opcode: 0x2D, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		sl := 56 - ((rs & 0x7) << 3)
+		val := rt << sl
+		mask := ^Word(0) << sl
+		return val | (mem & ^mask)
+	}},
+	{name: "ld", opcode: 0x37, mips64: true, exec: func(insn uint32, rs, rt, mem Word) Word {
+		return mem
+	}},
+	{name: "sd", opcode: 0x3F, mips64: true, exec: execRt},
+}
+
+func execRs(insn uint32, rs, rt, mem Word) Word { return rs }
+
+func execRt(insn uint32, rs, rt, mem Word) Word { return rt }
+
+func execAdd(insn uint32, rs, rt, mem Word) Word { return SignExtend(Word(int32(rs)+int32(rt)), 32) }
+
+func execAddu(insn uint32, rs, rt, mem Word) Word { return SignExtend(Word(uint32(rs)+uint32(rt)), 32) }
+
+func execAnd(insn uint32, rs, rt, mem Word) Word { return rs & rt }
+
+func execOr(insn uint32, rs, rt, mem Word) Word { return rs | rt }
+
+func execXor(insn uint32, rs, rt, mem Word) Word { return rs ^ rt }
+
+func execSlt(insn uint32, rs, rt, mem Word) Word {
+	if arch.SignedInteger(rs) < arch.SignedInteger(rt) {
+		return 1
+	}
+	return 0
+}
+
+func execSltu(insn uint32, rs, rt, mem Word) Word {
+	if rs < rt {
+		return 1
+	}
+	return 0
+}
+
+func execDadd(insn uint32, rs, rt, mem Word) Word { return rs + rt }
+
+func execDsub(insn uint32, rs, rt, mem Word) Word { return rs - rt }
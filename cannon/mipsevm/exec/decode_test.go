@@ -0,0 +1,75 @@
+package exec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestDecodeTable(t *testing.T) {
+	features := mipsevm.FeatureToggles{SupportDclzDclo: true, SupportRotrSebSeh: true}
+	for i := range instructions {
+		entry := &instructions[i]
+		t.Run(entry.name, func(t *testing.T) {
+			insn := entry.opcode<<26 | entry.match
+			if _, index := decodeIndex(entry.opcode, entry.fun); index == entry.fun {
+				insn |= entry.fun
+			}
+			actual := decode(insn, entry.opcode, entry.fun, features)
+			require.NotNil(t, actual)
+			require.Equal(t, entry.name, actual.name, "entry must not be shadowed by another entry of its index")
+		})
+	}
+}
+
+func TestExecuteMipsInstruction_Mips64r2(t *testing.T) {
+	rotr := func(sa uint32) uint32 { return 1<<21 | 13<<16 | 12<<11 | sa<<6 | 0x02 }
+	rotrv := uint32(14<<21 | 13<<16 | 12<<11 | 1<<6 | 0x06)
+	drotr := func(sa uint32) uint32 { return 1<<21 | 13<<16 | 12<<11 | sa<<6 | 0x3A }
+	drotr32 := func(sa uint32) uint32 { return 1<<21 | 13<<16 | 12<<11 | sa<<6 | 0x3E }
+	bshfl := func(sa uint32) uint32 { return OpSpecial3<<26 | 4<<16 | 2<<11 | sa<<6 | FunBshfl }
+	cases := []struct {
+		name     string
+		insn     uint32
+		rs, rt   Word
+		expected Word
+		// disabled is the value without the feature, as the instruction it extends, if it extends one
+		disabled *Word
+	}{
+		{name: "rotr", insn: rotr(4), rt: 0x1234_5678, expected: 0xFFFF_FFFF_8123_4567, disabled: ptr(0x0123_4567)},
+		{name: "rotr 0", insn: rotr(0), rt: 0xFFFF_FFFF_8000_0001, expected: 0xFFFF_FFFF_8000_0001, disabled: ptr(0xFFFF_FFFF_8000_0001)},
+		{name: "rotr ignores upper word", insn: rotr(1), rt: 0xAAAA_AAAA_0000_0002, expected: 0x1, disabled: ptr(0x1)},
+		{name: "rotrv", insn: rotrv, rs: 0x24, rt: 0x1234_5678, expected: 0xFFFF_FFFF_8123_4567, disabled: ptr(0x0123_4567)},
+		{name: "drotr", insn: drotr(4), rt: 0x1234_5678_9ABC_DEF0, expected: 0x0123_4567_89AB_CDEF, disabled: ptr(0x0123_4567_89AB_CDEF)},
+		{name: "drotr wraps", insn: drotr(8), rt: 0x1234_5678_9ABC_DEF0, expected: 0xF012_3456_789A_BCDE, disabled: ptr(0x0012_3456_789A_BCDE)},
+		{name: "drotr32", insn: drotr32(4), rt: 0x1234_5678_9ABC_DEF0, expected: 0x89AB_CDEF_0123_4567, disabled: ptr(0x0123_4567)},
+		{name: "seb positive", insn: bshfl(0x10), rt: 0xFFFF_FF7F, expected: 0x7F},
+		{name: "seb negative", insn: bshfl(0x10), rt: 0x80, expected: 0xFFFF_FFFF_FFFF_FF80},
+		{name: "seh positive", insn: bshfl(0x18), rt: 0xFFFF_7FFF, expected: 0x7FFF},
+		{name: "seh negative", insn: bshfl(0x18), rt: 0x8000, expected: 0xFFFF_FFFF_FFFF_8000},
+	}
+	for _, c := range cases {
+		opcode, fun := c.insn>>26, c.insn&0x3F
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ExecuteMipsInstruction(c.insn, opcode, fun, c.rs, c.rt, 0, mipsevm.FeatureToggles{SupportRotrSebSeh: true})
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+		t.Run(c.name+" disabled", func(t *testing.T) {
+			actual, err := ExecuteMipsInstruction(c.insn, opcode, fun, c.rs, c.rt, 0, mipsevm.FeatureToggles{})
+			if c.disabled != nil {
+				require.NoError(t, err)
+				require.Equal(t, *c.disabled, actual)
+			} else {
+				require.EqualError(t, err, fmt.Sprintf("invalid instruction: %x", c.insn))
+			}
+		})
+	}
+}
+
+func ptr(w Word) *Word {
+	return &w
+}
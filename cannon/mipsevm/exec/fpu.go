@@ -0,0 +1,60 @@
+package exec
+
+// Cannon has no floating point unit: guest programs must be built with soft-float, e.g. GOMIPS64=softfloat.
+// The instructions of the FPU are decoded to report them precisely, instead of as invalid instructions.
+
+const (
+	OpCop1  = 0x11
+	OpCop1x = 0x13
+	OpLwc1  = 0x31
+	OpLdc1  = 0x35
+	OpSwc1  = 0x39
+	OpSdc1  = 0x3D
+
+	// FunMovci is the function of movf and movt, the SPECIAL instructions that move on a floating point condition
+	FunMovci = 0x01
+)
+
+// FPUClass is the class of a floating point instruction.
+type FPUClass string
+
+const (
+	FPULoad      FPUClass = "load"
+	FPUStore     FPUClass = "store"
+	FPUMove      FPUClass = "move"
+	FPUBranch    FPUClass = "branch"
+	FPUCompute   FPUClass = "compute"
+	FPUIndexed   FPUClass = "indexed"
+	FPUCondMove  FPUClass = "conditional move"
+	FPUCop1Other FPUClass = "cop1"
+)
+
+// FloatingPointClass returns the class of the instruction if it is a floating point instruction.
+func FloatingPointClass(insn, opcode, fun uint32) (FPUClass, bool) {
+	switch opcode {
+	case OpLwc1, OpLdc1:
+		return FPULoad, true
+	case OpSwc1, OpSdc1:
+		return FPUStore, true
+	case OpCop1x:
+		// lwxc1, ldxc1, swxc1, sdxc1, prefx, and the fused multiply-adds
+		return FPUIndexed, true
+	case 0:
+		if fun == FunMovci {
+			return FPUCondMove, true
+		}
+		return "", false
+	case OpCop1:
+		switch format := (insn >> 21) & 0x1F; format {
+		case 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07: // mfc1, dmfc1, cfc1, mfhc1, mtc1, dmtc1, ctc1, mthc1
+			return FPUMove, true
+		case 0x08: // bc1f, bc1t, bc1fl, bc1tl
+			return FPUBranch, true
+		case 0x10, 0x11, 0x14, 0x15, 0x16: // single, double, word, long and paired-single formats
+			return FPUCompute, true
+		default:
+			return FPUCop1Other, true
+		}
+	}
+	return "", false
+}
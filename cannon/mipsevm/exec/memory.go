@@ -11,8 +11,14 @@ type MemTracker interface {
 	TrackMemAccess(addr Word)
 }
 
+// MemAccessObserver observes the memory accesses of the steps, whether or not they are proven.
+type MemAccessObserver interface {
+	ObserveMemAccess(addr Word)
+}
+
 type MemoryTrackerImpl struct {
 	memory          *memory.Memory
+	observer        MemAccessObserver
 	lastMemAccess   Word
 	memProofEnabled bool
 	// proof of first unique memory access
@@ -25,7 +31,15 @@ func NewMemoryTracker(memory *memory.Memory) *MemoryTrackerImpl {
 	return &MemoryTrackerImpl{memory: memory}
 }
 
+// SetObserver sets the observer of the memory accesses, or removes it if nil.
+func (m *MemoryTrackerImpl) SetObserver(observer MemAccessObserver) {
+	m.observer = observer
+}
+
 func (m *MemoryTrackerImpl) TrackMemAccess(effAddr Word) {
+	if m.observer != nil {
+		m.observer.ObserveMemAccess(effAddr)
+	}
 	if m.memProofEnabled && m.lastMemAccess != effAddr {
 		if m.lastMemAccess != ^Word(0) {
 			panic(fmt.Errorf("unexpected different mem access at %08x, already have access at %08x buffered", effAddr, m.lastMemAccess))
@@ -38,7 +52,13 @@ func (m *MemoryTrackerImpl) TrackMemAccess(effAddr Word) {
 // TrackMemAccess2 creates a proof for a memory access following a call to TrackMemAccess
 // This is used to generate proofs for contiguous memory accesses within the same step
 func (m *MemoryTrackerImpl) TrackMemAccess2(effAddr Word) {
-	if m.memProofEnabled && m.lastMemAccess+arch.WordSizeBytes != effAddr {
+	if m.observer != nil {
+		m.observer.ObserveMemAccess(effAddr)
+	}
+	if !m.memProofEnabled {
+		return
+	}
+	if m.lastMemAccess+arch.WordSizeBytes != effAddr {
 		panic(fmt.Errorf("unexpected disjointed mem access at %08x, last memory access is at %08x buffered", effAddr, m.lastMemAccess))
 	}
 	m.lastMemAccess = effAddr
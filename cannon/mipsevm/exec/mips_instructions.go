@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
@@ -16,6 +17,16 @@ const (
 	OpStoreConditional64 = 0x3c
 	OpLoadDoubleLeft     = 0x1A
 	OpLoadDoubleRight    = 0x1B
+	OpSpecial            = 0x00
+	OpSpecial2           = 0x1C
+	OpSpecial3           = 0x1F
+
+	// FunBshfl is the function of the SPECIAL3 instructions that shuffle bytes and halfwords, like seb and seh
+	FunBshfl = 0x20
+	// FunRdhwr is the function of rdhwr, a SPECIAL3 instruction
+	FunRdhwr = 0x3B
+	// HwrUserLocal is the hardware register of rdhwr that holds the thread pointer
+	HwrUserLocal = 29
 
 	// Return address register
 	RegRA = 31
@@ -30,8 +41,8 @@ func GetInstructionDetails(pc Word, memory *memory.Memory) (insn, opcode, fun ui
 	}
 	word := memory.GetWord(pc & arch.AddressMask)
 	insn = uint32(SelectSubWord(pc, word, 4, false))
-	opcode = insn >> 26 // First 6-bits
-	fun = insn & 0x3f   // Last 6-bits
+	opcode = disasm.Opcode(insn)
+	fun = disasm.Fun(insn)
 
 	return insn, opcode, fun
 }
@@ -66,7 +77,7 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]Word, memory
 		rt = registers[rtReg]
 		// store actual rt with lwu, ldl and ldr
 		rdReg = rtReg
-	} else if opcode == 0 || opcode == 0x1c {
+	} else if opcode == OpSpecial || opcode == OpSpecial2 || opcode == OpSpecial3 {
 		// R-type (stores rd)
 		rt = registers[rtReg]
 		rdReg = Word((insn >> 11) & 0x1F)
@@ -117,7 +128,10 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]Word, memory
 	}
 
 	// ALU
-	val := ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem, features)
+	val, err := ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem, features)
+	if err != nil {
+		return
+	}
 
 	funSel := uint32(0x1c)
 	if !arch.IsMips32 {
@@ -182,298 +196,17 @@ func assertMips64Fun(fun uint32) {
 	}
 }
 
-func ExecuteMipsInstruction(insn uint32, opcode uint32, fun uint32, rs, rt, mem Word, features mipsevm.FeatureToggles) Word {
-	if opcode == 0 || (opcode >= 8 && opcode < 0xF) || (!arch.IsMips32 && (opcode == 0x18 || opcode == 0x19)) {
-		// transform ArithLogI to SPECIAL
-		switch opcode {
-		case 8:
-			fun = 0x20 // addi
-		case 9:
-			fun = 0x21 // addiu
-		case 0xA:
-			fun = 0x2A // slti
-		case 0xB:
-			fun = 0x2B // sltiu
-		case 0xC:
-			fun = 0x24 // andi
-		case 0xD:
-			fun = 0x25 // ori
-		case 0xE:
-			fun = 0x26 // xori
-		case 0x18:
-			fun = 0x2c // daddi
-		case 0x19:
-			fun = 0x2d // daddiu
-		}
-
-		switch fun {
-		case 0x00: // sll
-			shiftAmt := (insn >> 6) & 0x1F
-			return SignExtend((rt<<shiftAmt)&U32Mask, 32)
-		case 0x02: // srl
-			return SignExtend((rt&U32Mask)>>((insn>>6)&0x1F), 32)
-		case 0x03: // sra
-			shamt := Word((insn >> 6) & 0x1F)
-			return SignExtend((rt&U32Mask)>>shamt, 32-shamt)
-		case 0x04: // sllv
-			shiftAmt := rs & 0x1F
-			return SignExtend((rt<<shiftAmt)&U32Mask, 32)
-		case 0x06: // srlv
-			return SignExtend((rt&U32Mask)>>(rs&0x1F), 32)
-		case 0x07: // srav
-			shamt := Word(rs & 0x1F)
-			return SignExtend((rt&U32Mask)>>shamt, 32-shamt)
-		// functs in range [0x8, 0x1b] for 32-bit and [0x8, 0x1f] for 64-bit are handled specially by other functions
-		case 0x08: // jr
-			return rs
-		case 0x09: // jalr
-			return rs
-		case 0x0a: // movz
-			return rs
-		case 0x0b: // movn
-			return rs
-		case 0x0c: // syscall
-			return rs
-		// 0x0d - break not supported
-		case 0x0f: // sync
-			return rs
-		case 0x10: // mfhi
-			return rs
-		case 0x11: // mthi
-			return rs
-		case 0x12: // mflo
-			return rs
-		case 0x13: // mtlo
-			return rs
-		case 0x14: // dsllv
-			assertMips64(insn)
-			return rt
-		case 0x16: // dsrlv
-			assertMips64(insn)
-			return rt
-		case 0x17: // dsrav
-			assertMips64(insn)
-			return rt
-		case 0x18: // mult
-			return rs
-		case 0x19: // multu
-			return rs
-		case 0x1a: // div
-			return rs
-		case 0x1b: // divu
-			return rs
-		case 0x1C: // dmult
-			assertMips64(insn)
-			return rs
-		case 0x1D: // dmultu
-			assertMips64(insn)
-			return rs
-		case 0x1E: // ddiv
-			assertMips64(insn)
-			return rs
-		case 0x1F: // ddivu
-			assertMips64(insn)
-			return rs
-		// The rest includes transformed R-type arith imm instructions
-		case 0x20: // add
-			return SignExtend(Word(int32(rs)+int32(rt)), 32)
-		case 0x21: // addu
-			return SignExtend(Word(uint32(rs)+uint32(rt)), 32)
-		case 0x22: // sub
-			return SignExtend(Word(int32(rs)-int32(rt)), 32)
-		case 0x23: // subu
-			return SignExtend(Word(uint32(rs)-uint32(rt)), 32)
-		case 0x24: // and
-			return rs & rt
-		case 0x25: // or
-			return rs | rt
-		case 0x26: // xor
-			return rs ^ rt
-		case 0x27: // nor
-			return ^(rs | rt)
-		case 0x2a: // slti
-			if arch.SignedInteger(rs) < arch.SignedInteger(rt) {
-				return 1
-			}
-			return 0
-		case 0x2b: // sltiu
-			if rs < rt {
-				return 1
-			}
-			return 0
-		case 0x2c: // dadd
-			assertMips64(insn)
-			return rs + rt
-		case 0x2d: // daddu
-			assertMips64(insn)
-			return rs + rt
-		case 0x2e: // dsub
-			assertMips64(insn)
-			return rs - rt
-		case 0x2f: // dsubu
-			assertMips64(insn)
-			return rs - rt
-		case 0x38: // dsll
-			assertMips64(insn)
-			return rt << ((insn >> 6) & 0x1f)
-		case 0x3A: // dsrl
-			assertMips64(insn)
-			return rt >> ((insn >> 6) & 0x1f)
-		case 0x3B: // dsra
-			assertMips64(insn)
-			return Word(int64(rt) >> ((insn >> 6) & 0x1f))
-		case 0x3C: // dsll32
-			assertMips64(insn)
-			return rt << (((insn >> 6) & 0x1f) + 32)
-		case 0x3E: // dsrl32
-			assertMips64(insn)
-			return rt >> (((insn >> 6) & 0x1f) + 32)
-		case 0x3F: // dsra32
-			assertMips64(insn)
-			return Word(int64(rt) >> (((insn >> 6) & 0x1f) + 32))
-		default:
-			panic(fmt.Sprintf("invalid instruction: 0x%08x", insn))
-		}
-	} else {
-		switch opcode {
-		// SPECIAL2
-		case 0x1C:
-			switch {
-			case fun == 0x2: // mul
-				return SignExtend(Word(int32(rs)*int32(rt)), 32)
-			case fun == 0x20 || fun == 0x21: // clz, clo
-				if fun == 0x20 {
-					rs = ^rs
-				}
-				i := uint32(0)
-				for ; rs&0x80000000 != 0; i++ {
-					rs <<= 1
-				}
-				return Word(i)
-			case features.SupportDclzDclo && (fun == 0x24 || fun == 0x25): // dclz, dclo
-				assertMips64Fun(insn)
-				if fun == 0x24 {
-					rs = ^rs
-				}
-				i := uint32(0)
-				for ; uint64(rs)&0x80000000_00000000 != 0; i++ {
-					rs <<= 1
-				}
-				return Word(i)
-			}
-		case 0x0F: // lui
-			return SignExtend(rt<<16, 32)
-		case 0x20: // lb
-			return SelectSubWord(rs, mem, 1, true)
-		case 0x21: // lh
-			return SelectSubWord(rs, mem, 2, true)
-		case 0x22: // lwl
-			if arch.IsMips32 {
-				val := mem << ((rs & 3) * 8)
-				mask := Word(uint32(U32Mask) << ((rs & 3) * 8))
-				return SignExtend(((rt & ^mask)|val)&U32Mask, 32)
-			} else {
-				// similar to the above mips32 implementation but loads are constrained to the nearest 4-byte memory word
-				w := uint32(SelectSubWord(rs, mem, 4, false))
-				val := w << ((rs & 3) * 8)
-				mask := Word(uint32(U32Mask) << ((rs & 3) * 8))
-				return SignExtend(((rt & ^mask)|Word(val))&U32Mask, 32)
-			}
-		case 0x23: // lw
-			return SelectSubWord(rs, mem, 4, true)
-		case 0x24: // lbu
-			return SelectSubWord(rs, mem, 1, false)
-		case 0x25: //  lhu
-			return SelectSubWord(rs, mem, 2, false)
-		case 0x26: //  lwr
-			if arch.IsMips32 {
-				val := mem >> (24 - (rs&3)*8)
-				mask := Word(uint32(U32Mask) >> (24 - (rs&3)*8))
-				return SignExtend(((rt & ^mask)|val)&U32Mask, 32)
-			} else {
-				// similar to the above mips32 implementation but constrained to the nearest 4-byte memory word
-				w := uint32(SelectSubWord(rs, mem, 4, false))
-				val := w >> (24 - (rs&3)*8)
-				mask := uint32(U32Mask) >> (24 - (rs&3)*8)
-				lwrResult := (uint32(rt) & ^mask) | val
-				if rs&3 == 3 { // loaded bit 31
-					return SignExtend(Word(lwrResult), 32)
-				} else {
-					// NOTE: cannon64 implementation specific: We leave the upper word untouched
-					rtMask := uint64(0xFF_FF_FF_FF_00_00_00_00)
-					return (rt & Word(rtMask)) | Word(lwrResult)
-				}
-			}
-		case 0x28: //  sb
-			return UpdateSubWord(rs, mem, 1, rt)
-		case 0x29: //  sh
-			return UpdateSubWord(rs, mem, 2, rt)
-		case 0x2a: //  swl
-			if arch.IsMips32 {
-				val := rt >> ((rs & 3) * 8)
-				mask := uint32(U32Mask) >> ((rs & 3) * 8)
-				return (mem & Word(^mask)) | val
-			} else {
-				sr := (rs & 3) << 3
-				val := ((rt & U32Mask) >> sr) << (32 - ((rs & 0x4) << 3))
-				mask := (uint64(U32Mask) >> sr) << (32 - ((rs & 0x4) << 3))
-				return (mem & Word(^mask)) | val
-			}
-		case 0x2b: //  sw
-			return UpdateSubWord(rs, mem, 4, rt)
-		case 0x2e: //  swr
-			if arch.IsMips32 {
-				val := rt << (24 - (rs&3)*8)
-				mask := uint32(U32Mask) << (24 - (rs&3)*8)
-				return (mem & Word(^mask)) | val
-			} else {
-				// similar to the above mips32 implementation but constrained to the nearest 4-byte memory word
-				w := uint32(SelectSubWord(rs, mem, 4, false))
-				val := rt << (24 - (rs&3)*8)
-				mask := uint32(U32Mask) << (24 - (rs&3)*8)
-				swrResult := (w & ^mask) | uint32(val)
-				return UpdateSubWord(rs, mem, 4, Word(swrResult))
-			}
-
-		// MIPS64
-		case 0x1A: // ldl
-			assertMips64(insn)
-			sl := (rs & 0x7) << 3
-			val := mem << sl
-			mask := ^Word(0) << sl
-			return val | (rt & ^mask)
-		case 0x1B: // ldr
-			assertMips64(insn)
-			sr := 56 - ((rs & 0x7) << 3)
-			val := mem >> sr
-			mask := ^Word(0) << (64 - sr)
-			return val | (rt & mask)
-		case 0x27: // lwu
-			assertMips64(insn)
-			return (mem >> (32 - ((rs & 0x4) << 3))) & U32Mask
-		case 0x2C: // sdl
-			assertMips64(insn)
-			sr := (rs & 0x7) << 3
-			val := rt >> sr
-			mask := ^Word(0) >> sr
-			return val | (mem & ^mask)
-		case 0x2D: // sdr
-			assertMips64(insn)
-			sl := 56 - ((rs & 0x7) << 3)
-			val := rt << sl
-			mask := ^Word(0) << sl
-			return val | (mem & ^mask)
-		case 0x37: // ld
-			assertMips64(insn)
-			return mem
-		case 0x3F: // sd
-			assertMips64(insn)
-			return rt
-		default:
-			panic(fmt.Sprintf("invalid instruction: %x", insn))
+// ExecuteMipsInstruction returns the value of the instruction, as decoded by the decode table of the given features.
+// It returns an error if the instruction is not supported with the features.
+func ExecuteMipsInstruction(insn uint32, opcode uint32, fun uint32, rs, rt, mem Word, features mipsevm.FeatureToggles) (Word, error) {
+	entry := decode(insn, opcode, fun, features)
+	if entry == nil {
+		if opcode == OpSpecial {
+			return 0, fmt.Errorf("invalid instruction: 0x%08x", insn)
 		}
+		return 0, fmt.Errorf("invalid instruction: %x", insn)
 	}
-	panic(fmt.Sprintf("invalid instruction: %x", insn))
+	return entry.exec(insn, rs, rt, mem), nil
 }
 
 func SignExtend(dat Word, idx Word) Word {
@@ -36,10 +36,22 @@ const (
 // Errors
 const (
 	SysErrorSignal = ^Word(0)
+	MipsEINTR      = 0x4
 	MipsEBADF      = 0x9
 	MipsEINVAL     = 0x16
 	MipsEAGAIN     = 0xb
 	MipsETIMEDOUT  = 0x91
+	MipsENOSYS     = 0x59
+)
+
+// Musl runtime constants, see mipsevm.FeatureToggles.SupportMuslRuntime
+const (
+	// RobustListHeadSize is the size of the struct robust_list_head of set_robust_list.
+	RobustListHeadSize = 3 * arch.WordSizeBytes
+	// SigaltstackDisable is the SS_DISABLE flag of a stack_t, for threads without a signal stack.
+	SigaltstackDisable = 2
+	// SigaltstackFlagsOffset is the offset of ss_flags in a MIPS stack_t {ss_sp, ss_size, ss_flags}.
+	SigaltstackFlagsOffset = 2 * arch.WordSizeBytes
 )
 
 // SysFutex-related constants
@@ -102,6 +114,10 @@ const (
 	ClockGettimeRealtimeFlag = 0
 	// ClockGettimeMonotonicFlag is the clock_gettime clock id for Linux's monotonic clock: https://github.com/torvalds/linux/blob/ad618736883b8970f66af799e34007475fe33a68/include/uapi/linux/time.h#L50
 	ClockGettimeMonotonicFlag = 1
+	// ClockGettimeThreadCputimeFlag is the clock_gettime clock id for the CPU time of the calling thread: https://github.com/torvalds/linux/blob/ad618736883b8970f66af799e34007475fe33a68/include/uapi/linux/time.h#L52
+	ClockGettimeThreadCputimeFlag = 3
+	// ClockGettimeBoottimeFlag is the clock_gettime clock id for Linux's monotonic clock that includes suspended time: https://github.com/torvalds/linux/blob/ad618736883b8970f66af799e34007475fe33a68/include/uapi/linux/time.h#L56
+	ClockGettimeBoottimeFlag = 7
 )
 
 func GetSyscallArgs(registers *[32]Word) (syscallNum, a0, a1, a2 Word) {
@@ -213,14 +229,13 @@ func HandleSysWrite(a0, a1, a2 Word,
 
 	switch a0 {
 	case FdStdout:
-		_, _ = io.Copy(stdOut, memory.ReadMemoryRange(a1, a2))
+		_, _ = memory.CopyRegionTo(stdOut, a1, a2)
 		v0 = a2
 	case FdStderr:
-		_, _ = io.Copy(stdErr, memory.ReadMemoryRange(a1, a2))
+		_, _ = memory.CopyRegionTo(stdErr, a1, a2)
 		v0 = a2
 	case FdHintWrite:
-		hintData, _ := io.ReadAll(memory.ReadMemoryRange(a1, a2))
-		lastHint = append(lastHint, hintData...)
+		lastHint = append(lastHint, memory.ReadRegion(a1, a2)...)
 		for len(lastHint) >= 4 { // process while there is enough data to check if there are any hints
 			hintLen := binary.BigEndian.Uint32(lastHint[:4])
 			if hintLen <= uint32(len(lastHint[4:])) {
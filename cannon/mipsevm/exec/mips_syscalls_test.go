@@ -0,0 +1,82 @@
+package exec
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+type recordingOracle struct {
+	preimage []byte
+	hints    [][]byte
+}
+
+func (o *recordingOracle) Hint(v []byte) {
+	o.hints = append(o.hints, bytes.Clone(v))
+}
+
+func (o *recordingOracle) GetPreimage(k [32]byte) []byte {
+	return o.preimage
+}
+
+type recordingMemTracker struct {
+	accesses []Word
+}
+
+func (m *recordingMemTracker) TrackMemAccess(addr Word) {
+	m.accesses = append(m.accesses, addr)
+}
+
+// The syscalls below move bytes between guest memory and the host. The guest is big-endian, so the
+// bytes must appear in guest memory in the same order as on the host, with the first byte in the
+// most significant byte of a word.
+
+func TestHandleSysReadPreimageByteOrder(t *testing.T) {
+	data := []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}
+	reader := NewTrackingPreimageOracleReader(&recordingOracle{preimage: data})
+	mem := memory.NewMemory()
+	key := [32]byte{31: 1}
+
+	// The length prefix is read as a big-endian word.
+	v0, v1, offset, _, _ := HandleSysRead(FdPreimageRead, 0x1000, 8, key, 0, reader, mem, new(recordingMemTracker))
+	require.Equal(t, Word(8), v0)
+	require.Zero(t, v1)
+	require.Equal(t, Word(len(data)), mem.GetWord(0x1000))
+
+	// An unaligned read only fills the rest of the word, in order.
+	v0, _, _, _, _ = HandleSysRead(FdPreimageRead, 0x2003, 8, key, offset, reader, mem, new(recordingMemTracker))
+	require.Equal(t, Word(arch.WordSizeBytes-3), v0)
+	require.Equal(t, arch.GuestByteOrder.Uint64([]byte{0, 0, 0, 0x11, 0x22, 0x33, 0x44, 0x55}), mem.GetWord(0x2000))
+	require.Equal(t, []byte{0x11, 0x22, 0x33, 0x44, 0x55}, mem.ReadRegion(0x2003, 5))
+}
+
+func TestHandleSysWritePreimageKeyByteOrder(t *testing.T) {
+	mem := memory.NewMemory()
+	require.NoError(t, mem.SetMemoryRange(0x1000, bytes.NewReader([]byte{1, 2, 3, 4, 5, 6, 7, 8})))
+	oracle := &recordingOracle{}
+
+	v0, v1, _, key, _ := HandleSysWrite(FdPreimageWrite, 0x1002, 4, nil, [32]byte{}, 0, oracle, mem, new(recordingMemTracker), io.Discard, io.Discard)
+	require.Equal(t, Word(4), v0)
+	require.Zero(t, v1)
+	require.Equal(t, []byte{3, 4, 5, 6}, key[28:])
+	require.Equal(t, make([]byte, 28), key[:28])
+}
+
+func TestHandleSysWriteHintByteOrder(t *testing.T) {
+	mem := memory.NewMemory()
+	// The hint length prefix is big-endian, as written by the preimage client.
+	hints := []byte{0, 0, 0, 3, 'a', 'b', 'c', 0, 0, 1, 0}
+	require.NoError(t, mem.SetMemoryRange(0x1000, bytes.NewReader(hints)))
+	oracle := &recordingOracle{}
+
+	v0, _, lastHint, _, _ := HandleSysWrite(FdHintWrite, 0x1000, Word(len(hints)), nil, [32]byte{}, 0, oracle, mem, new(recordingMemTracker), io.Discard, io.Discard)
+	require.Equal(t, Word(len(hints)), v0)
+	require.Equal(t, [][]byte{[]byte("abc")}, oracle.hints)
+	// The second hint is incomplete: 256 bytes long, and buffered until the rest is written.
+	require.Equal(t, []byte{0, 0, 1, 0}, []byte(lastHint))
+}
@@ -78,6 +78,19 @@ type FeatureToggles struct {
 	SupportDclzDclo            bool
 	SupportNoopMprotect        bool
 	SupportWorkingSysGetRandom bool
+	// SupportExtendedClockGettime supports the CLOCK_THREAD_CPUTIME_ID and CLOCK_BOOTTIME clocks of clock_gettime,
+	// which read as the monotonic clock. Without it, clock_gettime fails with EINVAL for these clocks.
+	SupportExtendedClockGettime bool
+	// SupportMuslRuntime supports the runtime of statically linked musl programs, like Rust programs built for
+	// linux/mips64 musl: the thread pointer of set_thread_area and rdhwr, set_tid_address, robust futex lists,
+	// the sigaltstack of a thread, and failing poll and mremap.
+	SupportMuslRuntime bool
+	// SupportRotrSebSeh executes the MIPS64 release 2 instructions rotr, rotrv, drotr, drotr32, seb and seh.
+	// Without it, the rotations execute as the shifts they share their function with, and seb and seh are invalid.
+	SupportRotrSebSeh bool
+	// SchedQuantum is the number of steps a thread runs before it is preempted, exec.SchedQuantum if zero.
+	// It must match the quantum of the onchain VM of the state version.
+	SchedQuantum uint64
 }
 
 type FPVM interface {
@@ -87,6 +100,12 @@ type FPVM interface {
 	// Step executes a single instruction and returns the witness for the step
 	Step(includeProof bool) (*StepWitness, error)
 
+	// RunSteps executes up to n steps without proofs, and returns the number of steps executed.
+	// It stops early if the VM exits, or at the first step that fails, which is not counted.
+	// The state transitions are the same as n calls of Step(false), without the per-step bookkeeping of proofs.
+	// LastPreimage returns the last preimage read by any of the steps.
+	RunSteps(n uint64) (uint64, error)
+
 	// CheckInfiniteLoop returns true if the vm is stuck in an infinite loop
 	CheckInfiniteLoop() bool
 
@@ -105,6 +124,42 @@ type FPVM interface {
 	// EnableStats if supported by the VM, enables some additional statistics that can be retrieved via GetDebugInfo()
 	EnableStats()
 
+	// EnableSyscallStats enables per-syscall frequency and latency tracking that can be retrieved via GetSyscallStats()
+	EnableSyscallStats()
+
+	// EnableSyscallObserver notifies the observer after each syscall the guest executes,
+	// e.g. to record the syscalls with a SyscallRecorder, or to replay them with a SyscallReplayer.
+	EnableSyscallObserver(obs SyscallObserver)
+
+	// EnableSyscallFaults fails the syscalls of the guest that the injector selects, for tests.
+	// Steps with a failed syscall do not match the onchain VM.
+	EnableSyscallFaults(inj SyscallFaultInjector)
+
+	// EnableAccessTracing enables the tracing of the memory accesses of each step, aggregated per window of
+	// windowSize steps, that can be retrieved via GetAccessStats()
+	EnableAccessTracing(windowSize uint64)
+
+	// EnableProfiling enables counting the instructions executed at each PC, sampled every interval steps,
+	// that can be retrieved via GetProfile()
+	EnableProfiling(interval uint64)
+
+	// EnableStackGuard enables the detection of stack overflows in the guest program, for debugging.
+	// A detected overflow fails the step with an error, it does not affect the state transition.
+	EnableStackGuard(cfg StackGuardConfig)
+
+	// GetSyscallStats returns the aggregated per-syscall statistics, or nil if syscall stats are not enabled
+	GetSyscallStats() *SyscallStats
+
+	// GetAccessStats returns the memory access statistics, or nil if access tracing is not enabled
+	GetAccessStats() *AccessStats
+
+	// GetProfile returns the instruction-level profile, or nil if profiling is not enabled
+	GetProfile() *Profile
+
+	// Hooks returns the hooks that run after each step, for consumers to register callbacks
+	// at step intervals or at specific steps.
+	Hooks() *StepHooks
+
 	// LookupSymbol returns the symbol located at the specified address.
 	// May return an empty string if there's no symbol table available.
 	LookupSymbol(addr arch.Word) string
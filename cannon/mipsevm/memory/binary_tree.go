@@ -2,14 +2,21 @@ package memory
 
 import (
 	"math/bits"
+	"sync"
 )
 
+// minParallelPages is the minimum number of pages to hash before the hashing is spread over the workers.
+// Below it, the overhead of the workers outweighs the gain, e.g. when hashing the few pages written by a single step.
+const minParallelPages = 16
+
 // BinaryTreeIndex is a representation of the state of the memory in a binary merkle tree.
 type BinaryTreeIndex struct {
 	// generalized index -> merkle root or nil if invalidated
 	nodes map[uint64]*[32]byte
 	// Reference to the page table from Memory.
 	pageTable map[Word]*CachedPage
+	// hashWorkers is the number of workers that hash the pages in parallel. 0 or 1 hashes sequentially.
+	hashWorkers int
 }
 
 func NewBinaryTreeMemory() *Memory {
@@ -31,9 +38,30 @@ func NewBinaryTreeIndex(pages map[Word]*CachedPage) *BinaryTreeIndex {
 
 func (m *BinaryTreeIndex) New(pages map[Word]*CachedPage) PageIndex {
 	x := NewBinaryTreeIndex(pages)
+	x.hashWorkers = m.hashWorkers
+	return x
+}
+
+func (m *BinaryTreeIndex) Copy(pages map[Word]*CachedPage) PageIndex {
+	x := NewBinaryTreeIndex(pages)
+	x.hashWorkers = m.hashWorkers
+	for gindex, n := range m.nodes {
+		if n == nil {
+			x.nodes[gindex] = nil
+			continue
+		}
+		// nodes are released to the pool when invalidated, so they cannot be shared
+		c := GetByte32()
+		*c = *n
+		x.nodes[gindex] = c
+	}
 	return x
 }
 
+func (m *BinaryTreeIndex) SetHashWorkers(workers int) {
+	m.hashWorkers = workers
+}
+
 func (m *BinaryTreeIndex) Invalidate(addr Word) {
 	// find the gindex of the first page covering the address: i.e. ((1 << WordSize) | addr) >> PageAddrSize
 	// Avoid 64-bit overflow by distributing the right shift across the OR.
@@ -50,6 +78,13 @@ func (m *BinaryTreeIndex) Invalidate(addr Word) {
 }
 
 func (m *BinaryTreeIndex) MerkleizeSubtree(gindex uint64) [32]byte {
+	if uint64(bits.Len64(gindex)) <= PageKeySize {
+		m.hashDirtyPages(gindex)
+	}
+	return m.merkleizeSubtree(gindex)
+}
+
+func (m *BinaryTreeIndex) merkleizeSubtree(gindex uint64) [32]byte {
 	l := uint64(bits.Len64(gindex))
 	if l > MemProofLeafCount {
 		panic("gindex too deep")
@@ -72,8 +107,8 @@ func (m *BinaryTreeIndex) MerkleizeSubtree(gindex uint64) [32]byte {
 	if n != nil {
 		return *n
 	}
-	left := m.MerkleizeSubtree(gindex << 1)
-	right := m.MerkleizeSubtree((gindex << 1) | 1)
+	left := m.merkleizeSubtree(gindex << 1)
+	right := m.merkleizeSubtree((gindex << 1) | 1)
 	r := GetByte32()
 	HashPairNodes(r, &left, &right)
 	m.nodes[gindex] = r
@@ -81,6 +116,7 @@ func (m *BinaryTreeIndex) MerkleizeSubtree(gindex uint64) [32]byte {
 }
 
 func (m *BinaryTreeIndex) MerkleProof(addr Word) (out [MemProofSize]byte) {
+	m.hashDirtyPages(1)
 	proof := m.traverseBranch(1, addr, 0)
 	// encode the proof
 	for i := 0; i < MemProofLeafCount; i++ {
@@ -92,7 +128,7 @@ func (m *BinaryTreeIndex) MerkleProof(addr Word) (out [MemProofSize]byte) {
 func (m *BinaryTreeIndex) traverseBranch(parent uint64, addr Word, depth uint8) (proof [][32]byte) {
 	if depth == WordSize-5 {
 		proof = make([][32]byte, 0, WordSize-5+1)
-		proof = append(proof, m.MerkleizeSubtree(parent))
+		proof = append(proof, m.merkleizeSubtree(parent))
 		return
 	}
 	if depth > WordSize-5 {
@@ -104,7 +140,7 @@ func (m *BinaryTreeIndex) traverseBranch(parent uint64, addr Word, depth uint8)
 		self, sibling = sibling, self
 	}
 	proof = m.traverseBranch(self, addr, depth+1)
-	siblingNode := m.MerkleizeSubtree(sibling)
+	siblingNode := m.merkleizeSubtree(sibling)
 	proof = append(proof, siblingNode)
 	return
 }
@@ -125,3 +161,45 @@ func (m *BinaryTreeIndex) AddPage(pageIndex Word) {
 		k >>= 1
 	}
 }
+
+// hashDirtyPages computes the roots of the invalidated pages within the subtree of the gindex with the hash workers,
+// so that merkleizing the subtree only has to hash the nodes above the pages.
+// Every page is hashed by exactly one worker, and only the page's own cache is written,
+// so the resulting roots are identical to sequential hashing.
+func (m *BinaryTreeIndex) hashDirtyPages(gindex uint64) {
+	if m.hashWorkers <= 1 {
+		return
+	}
+	var dirty []*CachedPage
+	m.collectDirtyPages(gindex, &dirty)
+	if len(dirty) < minParallelPages {
+		return // hashed sequentially while merkleizing
+	}
+	workers := min(m.hashWorkers, len(dirty))
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < len(dirty); i += workers {
+				dirty[i].MerkleRoot()
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// collectDirtyPages walks the invalidated branches of the tree, and collects the pages at the end of them that are invalidated.
+func (m *BinaryTreeIndex) collectDirtyPages(gindex uint64, out *[]*CachedPage) {
+	if uint64(bits.Len64(gindex)) > PageKeySize {
+		if p, ok := m.pageTable[Word(gindex&PageKeyMask)]; ok && !p.getBit(1) {
+			*out = append(*out, p)
+		}
+		return
+	}
+	if n, ok := m.nodes[gindex]; !ok || n != nil {
+		return // zeroed, or still valid
+	}
+	m.collectDirtyPages(gindex<<1, out)
+	m.collectDirtyPages((gindex<<1)|1, out)
+}
@@ -72,3 +72,8 @@ var zeroHashes = func() [256][32]byte {
 	}
 	return out
 }()
+
+// ZeroHash returns the root of a fully zeroed sub-tree of the given height, where height 0 is a single 32-byte leaf.
+func ZeroHash(height int) [32]byte {
+	return zeroHashes[height]
+}
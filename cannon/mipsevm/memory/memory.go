@@ -32,12 +32,16 @@ type Memory struct {
 	// Note: since we don't de-alloc Pages, we don't do ref-counting.
 	// Once a page exists, it doesn't leave memory.
 	// This map will usually be shared with the PageIndex as well.
+	// Pages may be shared with copies of the memory, copy-on-write, see Copy.
 	pageTable map[Word]*CachedPage
 
 	// two caches: we often read instructions from one page, and do memory things with another page.
 	// this prevents map lookups each instruction
 	lastPageKeys [2]Word
 	lastPage     [2]*CachedPage
+
+	// poison is nil unless memory poisoning is enabled, see EnablePoison.
+	poison *poison
 }
 
 type PageIndex interface {
@@ -46,8 +50,11 @@ type PageIndex interface {
 	MerkleProof(addr Word) [MemProofSize]byte
 	MerkleizeSubtree(gindex uint64) [32]byte
 	Invalidate(addr Word)
+	SetHashWorkers(workers int)
 
 	New(pages map[Word]*CachedPage) PageIndex
+	// Copy returns an index of the pages with the same nodes as this index, for pages with the same contents.
+	Copy(pages map[Word]*CachedPage) PageIndex
 }
 
 func NewMemory() *Memory {
@@ -62,6 +69,12 @@ func (m *Memory) MerkleProof(addr Word) [MemProofSize]byte {
 	return m.merkleIndex.MerkleProof(addr)
 }
 
+// SetHashWorkers sets the number of workers that hash the memory pages in parallel when merkleizing.
+// The roots and proofs are identical for any number of workers. 0 or 1 hashes sequentially.
+func (m *Memory) SetHashWorkers(workers int) {
+	m.merkleIndex.SetHashWorkers(workers)
+}
+
 func (m *Memory) PageCount() int {
 	return len(m.pageTable)
 }
@@ -79,6 +92,8 @@ func (m *Memory) MerkleizeSubtree(gindex uint64) [32]byte {
 	return m.merkleIndex.MerkleizeSubtree(gindex)
 }
 
+// PageLookup returns the page of the given index, if it is allocated.
+// The page may be shared with copies of the memory, and must not be modified.
 func (m *Memory) PageLookup(pageIndex Word) (*CachedPage, bool) {
 	// hit caches
 	if pageIndex == m.lastPageKeys[0] {
@@ -100,6 +115,23 @@ func (m *Memory) PageLookup(pageIndex Word) (*CachedPage, bool) {
 	return p, ok
 }
 
+// writablePage returns the page of the given index, if it is allocated, to write to it.
+// A page that is shared with copies of the memory is first replaced with a clone of its own.
+func (m *Memory) writablePage(pageIndex Word) (*CachedPage, bool) {
+	p, ok := m.PageLookup(pageIndex)
+	if !ok || !p.shared.Load() {
+		return p, ok
+	}
+	p = p.clone()
+	m.pageTable[pageIndex] = p
+	for i := range m.lastPageKeys {
+		if m.lastPageKeys[i] == pageIndex {
+			m.lastPage[i] = p
+		}
+	}
+	return p, true
+}
+
 func (m *Memory) SetMemoryRange(addr Word, r io.Reader) error {
 	for {
 		pageIndex := addr >> PageAddrSize
@@ -114,12 +146,17 @@ func (m *Memory) SetMemoryRange(addr Word, r io.Reader) error {
 			return err
 		}
 
-		p, ok := m.PageLookup(pageIndex)
+		p, ok := m.writablePage(pageIndex)
 		if !ok {
 			p = m.AllocPage(pageIndex)
+		} else {
+			m.merkleIndex.Invalidate(addr) // the branch of the page may have been hashed already
 		}
 		p.InvalidateFull()
 		copy(p.Data[pageAddr:], chunk[:n])
+		if m.poison != nil {
+			m.poison.markWritten(addr, Word(n))
+		}
 		addr += Word(n)
 	}
 }
@@ -133,7 +170,7 @@ func (m *Memory) SetWord(addr Word, v Word) {
 
 	pageIndex := addr >> PageAddrSize
 	pageAddr := addr & PageAddrMask
-	p, ok := m.PageLookup(pageIndex)
+	p, ok := m.writablePage(pageIndex)
 	if !ok {
 		// allocate the page if we have not already.
 		// Go may mmap relatively large ranges, but we only allocate the pages just in time.
@@ -146,6 +183,9 @@ func (m *Memory) SetWord(addr Word, v Word) {
 		}
 	}
 	arch.ByteOrderWord.PutWord(p.Data[pageAddr:pageAddr+arch.WordSizeBytes], v)
+	if m.poison != nil {
+		m.poison.markWritten(addr, arch.WordSizeBytes)
+	}
 }
 
 // GetWord reads the maximum sized value, [arch.Word], located at the specified address.
@@ -158,6 +198,9 @@ func (m *Memory) GetWord(addr Word) Word {
 	pageIndex := addr >> PageAddrSize
 	p, ok := m.PageLookup(pageIndex)
 	if !ok {
+		if m.poison != nil && m.poison.contains(pageIndex) {
+			return m.poison.pattern
+		}
 		return 0
 	}
 	pageAddr := addr & PageAddrMask
@@ -166,6 +209,9 @@ func (m *Memory) GetWord(addr Word) Word {
 
 func (m *Memory) AllocPage(pageIndex Word) *CachedPage {
 	p := &CachedPage{Data: new(Page)}
+	if m.poison != nil {
+		m.poison.poisonPage(pageIndex, p.Data)
+	}
 	m.pageTable[pageIndex] = p
 	m.merkleIndex.AddPage(pageIndex)
 	return p
@@ -207,6 +253,55 @@ func (r *memReader) Read(dest []byte) (n int, err error) {
 	return n, nil
 }
 
+// zeroPage is the content of pages that are not allocated.
+var zeroPage Page
+
+// ReadRegion returns a copy of the length bytes of memory starting at addr. Unallocated memory reads as zeroes.
+// This is a host-side read: it copies whole page slices at once, does not produce any proof data,
+// and does not affect the page lookup caches of the VM. Use it to extract large buffers from the guest.
+func (m *Memory) ReadRegion(addr Word, length Word) []byte {
+	out := make([]byte, 0, length)
+	_ = m.forEachRegionChunk(addr, length, func(chunk []byte) error {
+		out = append(out, chunk...)
+		return nil
+	})
+	return out
+}
+
+// CopyRegionTo writes the length bytes of memory starting at addr to w, without intermediate copies.
+// Like ReadRegion, it is a host-side read. It returns the number of bytes written.
+func (m *Memory) CopyRegionTo(w io.Writer, addr Word, length Word) (int64, error) {
+	var written int64
+	err := m.forEachRegionChunk(addr, length, func(chunk []byte) error {
+		n, err := w.Write(chunk)
+		written += int64(n)
+		return err
+	})
+	return written, err
+}
+
+// forEachRegionChunk calls fn with the memory of the region, one page at a time.
+// The chunks alias the pages, and must not be retained or modified.
+// The region may wrap around the address range, and may not be aligned.
+func (m *Memory) forEachRegionChunk(addr Word, length Word, fn func(chunk []byte) error) error {
+	for length > 0 {
+		start := addr & PageAddrMask
+		n := min(PageSize-start, length)
+		var chunk []byte
+		if p, ok := m.pageTable[addr>>PageAddrSize]; ok {
+			chunk = p.Data[start : start+n]
+		} else {
+			chunk = zeroPage[start : start+n]
+		}
+		if err := fn(chunk); err != nil {
+			return err
+		}
+		addr += n
+		length -= n
+	}
+	return nil
+}
+
 func (m *Memory) UsageRaw() uint64 {
 	return uint64(len(m.pageTable)) * PageSize
 }
@@ -226,22 +321,36 @@ func (m *Memory) Usage() string {
 	return fmt.Sprintf("%.1f %ciB", float64(total)/float64(div), "KMGTPE"[exp])
 }
 
+// Copy returns a copy of the memory that shares the pages with the memory, copy-on-write:
+// a shared page is only cloned when either memory writes to it. Copies are cheap in time and space,
+// e.g. to branch the execution of a state, and the memories can be used independently, including concurrently.
+// The merkle tree of the memory is copied too, so the copy does not rehash the pages.
 func (m *Memory) Copy() *Memory {
-	pages := make(map[Word]*CachedPage)
-	table := m.merkleIndex.New(pages)
-	out := &Memory{
-		merkleIndex:  table,
+	pages := make(map[Word]*CachedPage, len(m.pageTable))
+	for k, page := range m.pageTable {
+		// Hash the page before it is shared: merkleizing a shared page then only reads its cache
+		page.MerkleRoot()
+		page.shared.Store(true)
+		pages[k] = page
+	}
+	return &Memory{
+		merkleIndex:  m.merkleIndex.Copy(pages),
 		pageTable:    pages,
 		lastPageKeys: [2]Word{^Word(0), ^Word(0)}, // default to invalid keys, to not match any pages
 		lastPage:     [2]*CachedPage{nil, nil},
 	}
+}
 
-	for k, page := range m.pageTable {
-		data := new(Page)
-		*data = *page.Data
-		out.AllocPage(k).Data = data
+// SharedPageCount returns the number of pages of the memory that are shared with copies of the memory.
+// The pages may have been cloned by all the copies since, as the references to the pages are not counted.
+func (m *Memory) SharedPageCount() int {
+	count := 0
+	for _, page := range m.pageTable {
+		if page.shared.Load() {
+			count++
+		}
 	}
-	return out
+	return count
 }
 
 // Serialize writes the memory in a simple binary format which can be read again using Deserialize
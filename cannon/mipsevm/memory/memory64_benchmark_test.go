@@ -1,6 +1,9 @@
 package memory
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"testing"
 
 	"math/rand"
@@ -132,3 +135,94 @@ func benchMerkleRootCalculation(size int) func(b *testing.B, m *Memory) {
 		}
 	}
 }
+
+// BenchmarkReadRegion compares the host-side extraction of large buffers from memory:
+// word-by-word reads, the streaming memory reader, and the region reads.
+func BenchmarkReadRegion(b *testing.B) {
+	readers := []struct {
+		name string
+		fn   func(m *Memory, addr Word, length Word)
+	}{
+		{"GetWord", func(m *Memory, addr Word, length Word) {
+			out := make([]byte, length)
+			for i := Word(0); i < length; i += arch.WordSizeBytes {
+				arch.ByteOrderWord.PutWord(out[i:], m.GetWord(addr+i))
+			}
+		}},
+		{"ReadMemoryRange", func(m *Memory, addr Word, length Word) {
+			_, _ = io.ReadAll(m.ReadMemoryRange(addr, length))
+		}},
+		{"ReadRegion", func(m *Memory, addr Word, length Word) {
+			_ = m.ReadRegion(addr, length)
+		}},
+		{"CopyRegionTo", func(m *Memory, addr Word, length Word) {
+			_, _ = m.CopyRegionTo(io.Discard, addr, length)
+		}},
+	}
+	for _, size := range []Word{64 << 10, 1 << 20, 16 << 20} {
+		m := NewBinaryTreeMemory()
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		addr := Word(0x1000_0000)
+		if err := m.SetMemoryRange(addr, bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+		for _, r := range readers {
+			b.Run(fmt.Sprintf("%s_%dKiB", r.name, size>>10), func(b *testing.B) {
+				b.SetBytes(int64(size))
+				for i := 0; i < b.N; i++ {
+					r.fn(m, addr, size)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkParallelMerkleRoot measures the merkleization of a 64-bit state of which all pages were written,
+// e.g. after loading a program or a snapshot, with different numbers of hash workers.
+func BenchmarkParallelMerkleRoot(b *testing.B) {
+	const pages = 16_384 // 64 MiB
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			m := NewBinaryTreeMemory()
+			m.SetHashWorkers(workers)
+			for i := Word(0); i < pages; i++ {
+				m.SetWord(i*PageSize, i)
+				m.SetWord(0x7FFF_0000_0000+i*PageSize, i)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				for p := Word(0); p < 2*pages; p++ {
+					addr := p * PageSize
+					if p >= pages {
+						addr = 0x7FFF_0000_0000 + (p-pages)*PageSize
+					}
+					m.SetWord(addr, Word(i))
+				}
+				b.StartTimer()
+				_ = m.MerkleRoot()
+			}
+		})
+	}
+}
+
+func BenchmarkCopyAndBranch(b *testing.B) {
+	const pages = 16_384 // 64 MiB
+	m := NewBinaryTreeMemory()
+	for i := Word(0); i < pages; i++ {
+		m.SetWord(i*PageSize, i)
+	}
+	m.MerkleRoot()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// branch the execution: a few writes, and a new root
+		cpy := m.Copy()
+		for j := Word(0); j < 16; j++ {
+			cpy.SetWord(j*PageSize*64+8, Word(i))
+		}
+		cpy.MerkleRoot()
+	}
+}
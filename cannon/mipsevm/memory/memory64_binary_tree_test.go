@@ -6,6 +6,7 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"io"
+	mathrand "math/rand"
 	"strings"
 	"testing"
 
@@ -138,6 +139,40 @@ func TestMemory64BinaryTreeReadWrite(t *testing.T) {
 		require.Equal(t, make([]byte, 10), res[len(res)-10:], "empty end")
 	})
 
+	t.Run("read region", func(t *testing.T) {
+		m := NewBinaryTreeMemory()
+		data := make([]byte, 3*PageSize+123)
+		_, err := rand.Read(data[:])
+		require.NoError(t, err)
+		addr := Word(2*PageSize - 77)
+		require.NoError(t, m.SetMemoryRange(addr, bytes.NewReader(data)))
+		pre := m.MerkleRoot()
+		pageCount := m.PageCount()
+
+		res := m.ReadRegion(addr-10, Word(len(data)+20))
+		require.Equal(t, make([]byte, 10), res[:10], "empty start")
+		require.Equal(t, data, res[10:len(res)-10], "result")
+		require.Equal(t, make([]byte, 10), res[len(res)-10:], "empty end")
+
+		expected, err := io.ReadAll(m.ReadMemoryRange(addr-10, Word(len(data)+20)))
+		require.NoError(t, err)
+		require.Equal(t, expected, res, "must match the memory reader")
+
+		var buf bytes.Buffer
+		n, err := m.CopyRegionTo(&buf, addr+5, 2*PageSize)
+		require.NoError(t, err)
+		require.EqualValues(t, 2*PageSize, n)
+		require.Equal(t, data[5:5+2*PageSize], buf.Bytes())
+
+		require.Empty(t, m.ReadRegion(addr, 0))
+		require.Equal(t, make([]byte, 100), m.ReadRegion(0x10_0000_0000, 100), "unallocated memory")
+		require.Equal(t, pageCount, m.PageCount(), "reads must not allocate pages")
+		require.Equal(t, pre, m.MerkleRoot())
+
+		res[10] ^= 0xff
+		require.Equal(t, data[0], m.ReadRegion(addr, 1)[0], "result must be a copy")
+	})
+
 	t.Run("empty range", func(t *testing.T) {
 		m := NewBinaryTreeMemory()
 		addr := Word(0xAABBCC00)
@@ -251,3 +286,125 @@ func TestMemory64BinaryTreeCopy(t *testing.T) {
 	require.Equal(t, Word(0xAABB), mcpy.GetWord(0xAABBCCDD_8000))
 	require.Equal(t, m.MerkleRoot(), mcpy.MerkleRoot())
 }
+
+func TestMemory64BinaryTreeCopyOnWrite(t *testing.T) {
+	addrs := []Word{0x1000, 0x2008, 0x7FFF_0000_0010}
+	m := NewBinaryTreeMemory()
+	for i, addr := range addrs {
+		m.SetWord(addr, Word(i+1))
+	}
+	root := m.MerkleRoot()
+	cpy := m.Copy()
+	require.Equal(t, 3, m.SharedPageCount())
+	require.Equal(t, 3, cpy.SharedPageCount())
+	require.Equal(t, root, cpy.MerkleRoot())
+
+	// writes to the copy clone the page, and do not affect the memory
+	cpy.SetWord(0x2008, 0xAA)
+	require.NoError(t, cpy.SetMemoryRange(0x1004, bytes.NewReader([]byte{0xBB})))
+	require.Equal(t, 1, cpy.SharedPageCount())
+	require.Equal(t, 3, m.SharedPageCount())
+	require.Equal(t, Word(2), m.GetWord(0x2008))
+	require.Equal(t, Word(1), m.GetWord(0x1000))
+	require.Equal(t, root, m.MerkleRoot())
+
+	// writes to the memory do not affect the copy
+	m.SetWord(0x7FFF_0000_0010, 0xCC)
+	require.Equal(t, Word(3), cpy.GetWord(0x7FFF_0000_0010))
+
+	// the roots and proofs match memories written from scratch
+	expected := func(writes map[Word]Word) *Memory {
+		out := NewBinaryTreeMemory()
+		for addr, v := range writes {
+			out.SetWord(addr, v)
+		}
+		return out
+	}
+	expectedCpy := expected(map[Word]Word{0x1000: 1, 0x2008: 0xAA, 0x7FFF_0000_0010: 3})
+	require.NoError(t, expectedCpy.SetMemoryRange(0x1004, bytes.NewReader([]byte{0xBB})))
+	expectedM := expected(map[Word]Word{0x1000: 1, 0x2008: 2, 0x7FFF_0000_0010: 0xCC})
+	require.Equal(t, expectedCpy.MerkleRoot(), cpy.MerkleRoot())
+	require.Equal(t, expectedM.MerkleRoot(), m.MerkleRoot())
+	for _, addr := range addrs {
+		require.Equal(t, expectedCpy.MerkleProof(addr), cpy.MerkleProof(addr))
+		require.Equal(t, expectedM.MerkleProof(addr), m.MerkleProof(addr))
+	}
+}
+
+func TestMemory64BinaryTreeConcurrentCopies(t *testing.T) {
+	base := NewBinaryTreeMemory()
+	for i := Word(0); i < 64; i++ {
+		base.SetWord(i*PageSize, i)
+	}
+	// leave the pages and the tree dirty, as copies must not hash shared pages concurrently
+	for i := Word(0); i < 64; i += 2 {
+		base.SetWord(i*PageSize+8, i)
+	}
+	copies := make([]*Memory, 4)
+	for i := range copies {
+		copies[i] = base.Copy()
+	}
+	roots := make([][32]byte, len(copies))
+	done := make(chan struct{})
+	for i, cpy := range copies {
+		go func(i int, cpy *Memory) {
+			defer func() { done <- struct{}{} }()
+			for j := Word(0); j < 64; j += Word(i + 1) {
+				cpy.SetWord(j*PageSize+16, Word(i))
+				_ = cpy.MerkleRoot()
+			}
+			roots[i] = cpy.MerkleRoot()
+		}(i, cpy)
+	}
+	for range copies {
+		<-done
+	}
+	for i, cpy := range copies {
+		expected := NewBinaryTreeMemory()
+		for j := Word(0); j < 64; j++ {
+			expected.SetWord(j*PageSize, base.GetWord(j*PageSize))
+			expected.SetWord(j*PageSize+8, base.GetWord(j*PageSize+8))
+			expected.SetWord(j*PageSize+16, cpy.GetWord(j*PageSize+16))
+		}
+		require.Equal(t, expected.MerkleRoot(), roots[i])
+	}
+}
+
+func TestMemory64BinaryTreeParallelHashing(t *testing.T) {
+	rng := mathrand.New(mathrand.NewSource(1234))
+	seq := NewBinaryTreeMemory()
+	par := NewBinaryTreeMemory()
+	par.SetHashWorkers(4)
+	write := func(n int) {
+		for i := 0; i < n; i++ {
+			// spread the writes over the low and high address ranges, to exercise sparse branches
+			addr := Word(rng.Intn(1<<24)) &^ 7
+			if i%2 == 1 {
+				addr |= 0x7FFF_0000_0000
+			}
+			v := Word(rng.Uint64())
+			seq.SetWord(addr, v)
+			par.SetWord(addr, v)
+		}
+	}
+
+	write(5000)
+	require.Equal(t, seq.MerkleRoot(), par.MerkleRoot())
+	for _, addr := range []Word{0, 0x1000, 0x7FFF_0000_0008} {
+		require.Equal(t, seq.MerkleProof(addr), par.MerkleProof(addr))
+	}
+
+	// few dirty pages are hashed sequentially
+	write(3)
+	require.Equal(t, seq.MerkleRoot(), par.MerkleRoot())
+
+	write(2000)
+	require.Equal(t, seq.MerkleProof(0x7FFF_0000_0008), par.MerkleProof(0x7FFF_0000_0008))
+	require.Equal(t, seq.MerkleizeSubtree(2), par.MerkleizeSubtree(2))
+	require.Equal(t, seq.MerkleRoot(), par.MerkleRoot())
+
+	cpy := par.Copy()
+	write(1000)
+	require.Equal(t, seq.MerkleRoot(), par.MerkleRoot())
+	require.NotEqual(t, seq.MerkleRoot(), cpy.MerkleRoot())
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
 )
 
 var zlibWriterPool = sync.Pool{
@@ -70,6 +71,21 @@ type CachedPage struct {
 	Cache [PageSize / 32][32]byte
 	// bit set to 1 if the intermediate node is valid
 	OkLow, OkHigh uint64 // size is PageSize / 32 == 64 + 64 == 128
+
+	// shared is set once the page is shared by copies of the memory, see Memory.Copy.
+	// A shared page is fully hashed, and never modified: memories clone it before they write to it.
+	shared atomic.Bool
+}
+
+// clone returns a copy of the page, with its data and hashes, that is not shared.
+func (p *CachedPage) clone() *CachedPage {
+	data := *p.Data
+	return &CachedPage{
+		Data:   &data,
+		Cache:  p.Cache,
+		OkLow:  p.OkLow,
+		OkHigh: p.OkHigh,
+	}
 }
 
 func (p *CachedPage) getLowHighMask(k uint64) (
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// pageWords is the number of words in a page.
+const pageWords = PageSize / arch.WordSizeBytes
+
+// poison tracks which words of the poisoned region of memory were never written, when memory poisoning is enabled.
+// The pages that exist when poisoning is enabled, e.g. the loaded program, are considered initialized.
+// Pages of the region that are allocated afterwards are filled with the poison pattern, and their words are poisoned
+// until written. Pages of the region that are not allocated read as the poison pattern.
+type poison struct {
+	pattern Word
+	// firstPage and lastPage are the page indices of the poisoned region, inclusive.
+	firstPage, lastPage Word
+	// written is a bitmap of the written words, of every page that was allocated since poisoning was enabled.
+	written map[Word]*[pageWords / 64]uint64
+}
+
+// EnablePoison makes memory of the pages that overlap with [start, end) and are allocated from now on
+// read as the given pattern, instead of zeroes, until it is written.
+// Use Poisoned to check if a read accesses such uninitialized memory.
+//
+// This is a testing mode, to find guest programs that rely on uninitialized memory to be zeroed:
+// it changes the memory contents, and thus the state hash, so it must not be used for proofs.
+// Words are tracked as a whole: a partial write initializes the word. Copies of the memory are not poisoned.
+func (m *Memory) EnablePoison(pattern Word, start, end Word) {
+	if end <= start {
+		panic(fmt.Errorf("invalid poisoned region: %x - %x", start, end))
+	}
+	m.poison = &poison{
+		pattern:   pattern,
+		firstPage: start >> PageAddrSize,
+		lastPage:  (end - 1) >> PageAddrSize,
+		written:   make(map[Word]*[pageWords / 64]uint64),
+	}
+}
+
+// Poisoned returns whether the word at the given address was never written since poisoning was enabled.
+// It always returns false if poisoning is not enabled.
+func (m *Memory) Poisoned(addr Word) bool {
+	if m.poison == nil {
+		return false
+	}
+	pageIndex := addr >> PageAddrSize
+	if !m.poison.contains(pageIndex) {
+		return false
+	}
+	written, ok := m.poison.written[pageIndex]
+	if !ok {
+		// pages that existed before poisoning was enabled are initialized
+		_, allocated := m.pageTable[pageIndex]
+		return !allocated
+	}
+	i := (addr & PageAddrMask) / arch.WordSizeBytes
+	return written[i/64]&(1<<(i%64)) == 0
+}
+
+func (p *poison) contains(pageIndex Word) bool {
+	return pageIndex >= p.firstPage && pageIndex <= p.lastPage
+}
+
+// poisonPage fills a newly allocated page of the poisoned region with the poison pattern.
+func (p *poison) poisonPage(pageIndex Word, page *Page) {
+	if !p.contains(pageIndex) {
+		return
+	}
+	for i := 0; i < PageSize; i += arch.WordSizeBytes {
+		arch.ByteOrderWord.PutWord(page[i:i+arch.WordSizeBytes], p.pattern)
+	}
+	p.written[pageIndex] = new([pageWords / 64]uint64)
+}
+
+// markWritten marks the words that overlap with the given range as written.
+func (p *poison) markWritten(addr Word, length Word) {
+	if length == 0 {
+		return
+	}
+	end := addr + length - 1
+	for w := addr &^ arch.ExtMask; ; w += arch.WordSizeBytes {
+		if written, ok := p.written[w>>PageAddrSize]; ok {
+			i := (w & PageAddrMask) / arch.WordSizeBytes
+			written[i/64] |= 1 << (i % 64)
+		}
+		if w >= end&^arch.ExtMask {
+			return
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestMemoryPoison(t *testing.T) {
+	const pattern = Word(0xdeadbeef)
+	const start, end = Word(0x10000), Word(0x20000)
+
+	t.Run("disabled", func(t *testing.T) {
+		m := NewMemory()
+		require.False(t, m.Poisoned(start))
+		require.Equal(t, Word(0), m.GetWord(start))
+	})
+
+	t.Run("unallocated pages", func(t *testing.T) {
+		m := NewMemory()
+		m.EnablePoison(pattern, start, end)
+		require.True(t, m.Poisoned(start))
+		require.Equal(t, pattern, m.GetWord(start))
+		require.True(t, m.Poisoned(end-arch.WordSizeBytes))
+		require.False(t, m.Poisoned(end), "outside of the region")
+		require.Equal(t, Word(0), m.GetWord(end))
+		require.False(t, m.Poisoned(start-arch.WordSizeBytes))
+	})
+
+	t.Run("existing pages", func(t *testing.T) {
+		m := NewMemory()
+		m.SetWord(start, 1)
+		m.EnablePoison(pattern, start, end)
+		require.False(t, m.Poisoned(start))
+		require.False(t, m.Poisoned(start+arch.WordSizeBytes), "pages loaded before poisoning are initialized")
+		require.Equal(t, Word(0), m.GetWord(start+arch.WordSizeBytes))
+	})
+
+	t.Run("SetWord", func(t *testing.T) {
+		m := NewMemory()
+		m.EnablePoison(pattern, start, end)
+		addr := start + 4*arch.WordSizeBytes
+		m.SetWord(addr, 1)
+		require.False(t, m.Poisoned(addr))
+		require.Equal(t, Word(1), m.GetWord(addr))
+		require.True(t, m.Poisoned(addr-arch.WordSizeBytes))
+		require.True(t, m.Poisoned(addr+arch.WordSizeBytes))
+		require.Equal(t, pattern, m.GetWord(addr+arch.WordSizeBytes), "rest of the page is filled with the pattern")
+	})
+
+	t.Run("SetMemoryRange", func(t *testing.T) {
+		m := NewMemory()
+		m.EnablePoison(pattern, start, end)
+		// unaligned range that crosses a page boundary
+		addr := start + PageSize - 3
+		require.NoError(t, m.SetMemoryRange(addr, bytes.NewReader(make([]byte, arch.WordSizeBytes+6))))
+		require.True(t, m.Poisoned(start+PageSize-2*arch.WordSizeBytes))
+		require.False(t, m.Poisoned(start+PageSize-arch.WordSizeBytes))
+		require.False(t, m.Poisoned(start+PageSize))
+		require.False(t, m.Poisoned(start+PageSize+arch.WordSizeBytes))
+		require.True(t, m.Poisoned(start+PageSize+2*arch.WordSizeBytes))
+	})
+
+	t.Run("invalid region", func(t *testing.T) {
+		require.Panics(t, func() { NewMemory().EnablePoison(pattern, end, start) })
+	})
+}
@@ -0,0 +1,138 @@
+package multithreaded
+
+import (
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+const (
+	// leafAddrSize is the number of address bits within a 32-byte leaf of the memory tree
+	leafAddrSize = 5
+	// branchDepth is the number of sibling nodes of the Merkle branch of a leaf
+	branchDepth = memory.MemProofLeafCount - 1
+)
+
+// accessTracer records the memory leaves accessed by each step, including the instruction fetch,
+// and aggregates them per window of steps.
+type accessTracer struct {
+	windowSize uint64
+	window     mipsevm.AccessWindow
+	// leaves accessed in the current window
+	leaves map[Word]struct{}
+	// leaves accessed by the current step
+	stepLeaves []Word
+	pages      map[Word]struct{}
+	windows    []mipsevm.AccessWindow
+}
+
+var _ exec.MemAccessObserver = (*accessTracer)(nil)
+
+func newAccessTracer(windowSize uint64) *accessTracer {
+	return &accessTracer{
+		windowSize: windowSize,
+		leaves:     make(map[Word]struct{}),
+		pages:      make(map[Word]struct{}),
+	}
+}
+
+// beginStep starts tracing the accesses of the step, in the window of the step.
+func (t *accessTracer) beginStep(step uint64) {
+	t.endStep()
+	start := step - step%t.windowSize
+	if t.window.Steps > 0 && t.window.StartStep != start {
+		t.windows = append(t.windows, t.windowStats())
+		clear(t.leaves)
+		t.window = mipsevm.AccessWindow{}
+	}
+	if t.window.Steps == 0 {
+		t.window.StartStep = start
+	}
+	t.window.Steps += 1
+}
+
+func (t *accessTracer) ObserveMemAccess(addr Word) {
+	leaf := addr >> leafAddrSize
+	if !slices.Contains(t.stepLeaves, leaf) {
+		t.stepLeaves = append(t.stepLeaves, leaf)
+	}
+}
+
+func (t *accessTracer) endStep() {
+	t.window.Branches += uint64(len(t.stepLeaves))
+	for _, leaf := range t.stepLeaves {
+		t.leaves[leaf] = struct{}{}
+		t.pages[leaf>>(memory.PageAddrSize-leafAddrSize)] = struct{}{}
+	}
+	t.stepLeaves = t.stepLeaves[:0]
+}
+
+// windowStats returns the statistics of the current window.
+func (t *accessTracer) windowStats() mipsevm.AccessWindow {
+	w := t.window
+	leaves := make([]Word, 0, len(t.leaves))
+	for leaf := range t.leaves {
+		leaves = append(leaves, leaf)
+	}
+	slices.Sort(leaves)
+	var lastPage Word
+	for i, leaf := range leaves {
+		page := leaf >> (memory.PageAddrSize - leafAddrSize)
+		if i == 0 || page != lastPage {
+			w.Pages += 1
+		}
+		lastPage = page
+	}
+	w.Leaves = uint64(len(leaves))
+	w.MultiproofNodes = multiproofNodes(leaves)
+	w.MultiproofSize = (w.Leaves + w.MultiproofNodes) * 32
+	return w
+}
+
+// accessStats returns the statistics of the windows traced so far, including the current window.
+func (t *accessTracer) accessStats(totalSteps uint64) *mipsevm.AccessStats {
+	t.endStep()
+	out := &mipsevm.AccessStats{
+		WindowSize: t.windowSize,
+		TotalSteps: totalSteps,
+		BranchSize: memory.MemProofSize,
+		TotalPages: uint64(len(t.pages)),
+		Windows:    slices.Clone(t.windows),
+	}
+	if t.window.Steps > 0 {
+		out.Windows = append(out.Windows, t.windowStats())
+	}
+	if out.Windows == nil {
+		out.Windows = []mipsevm.AccessWindow{}
+	}
+	for _, w := range out.Windows {
+		out.TotalBranches += w.Branches
+		out.MaxPages = max(out.MaxPages, w.Pages)
+		out.MaxMultiproofSize = max(out.MaxMultiproofSize, w.MultiproofSize)
+	}
+	return out
+}
+
+// multiproofNodes returns the number of sibling nodes of a Merkle multiproof of the sorted, distinct leaves:
+// the siblings of the nodes on the branches of the leaves, that are not on a branch themselves.
+// The leaves are overwritten.
+func multiproofNodes(leaves []Word) uint64 {
+	var nodes uint64
+	level := leaves
+	for d := 0; d < branchDepth; d++ {
+		parents := level[:0]
+		for i := 0; i < len(level); i++ {
+			node := level[i]
+			if node&1 == 0 && i+1 < len(level) && level[i+1] == node|1 {
+				i++ // the sibling is on a branch too
+			} else {
+				nodes += 1
+			}
+			parents = append(parents, node>>1)
+		}
+		level = parents
+	}
+	return nodes
+}
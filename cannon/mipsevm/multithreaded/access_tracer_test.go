@@ -0,0 +1,88 @@
+package multithreaded
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestAccessTracer(t *testing.T) {
+	tracer := newAccessTracer(10)
+	tracer.beginStep(0)
+	tracer.ObserveMemAccess(0x1000)
+	tracer.ObserveMemAccess(0x1008) // same leaf
+	tracer.ObserveMemAccess(0x2000)
+	tracer.beginStep(1)
+	tracer.ObserveMemAccess(0x1000)
+	tracer.beginStep(12)
+	tracer.ObserveMemAccess(0x3000)
+
+	// leaves 0x80 and 0x100 have distinct siblings up to the level of their common ancestor
+	firstNodes := uint64(2*8 + branchDepth - 9)
+	expected := &mipsevm.AccessStats{
+		WindowSize:        10,
+		TotalSteps:        13,
+		BranchSize:        memory.MemProofSize,
+		TotalBranches:     4,
+		TotalPages:        3,
+		MaxPages:          2,
+		MaxMultiproofSize: (2 + firstNodes) * 32,
+		Windows: []mipsevm.AccessWindow{
+			{StartStep: 0, Steps: 2, Branches: 3, Pages: 2, Leaves: 2, MultiproofNodes: firstNodes, MultiproofSize: (2 + firstNodes) * 32},
+			{StartStep: 10, Steps: 1, Branches: 1, Pages: 1, Leaves: 1, MultiproofNodes: branchDepth, MultiproofSize: memory.MemProofSize},
+		},
+	}
+	require.Equal(t, expected, tracer.accessStats(13))
+	require.Equal(t, expected, tracer.accessStats(13), "stats do not change the traced accesses")
+}
+
+func TestAccessTracer_Empty(t *testing.T) {
+	tracer := newAccessTracer(10)
+	require.Equal(t, &mipsevm.AccessStats{
+		WindowSize: 10,
+		TotalSteps: 5,
+		BranchSize: memory.MemProofSize,
+		Windows:    []mipsevm.AccessWindow{},
+	}, tracer.accessStats(5))
+}
+
+func TestMultiproofNodes(t *testing.T) {
+	require.Zero(t, multiproofNodes(nil))
+	require.Equal(t, uint64(branchDepth), multiproofNodes([]Word{5}))
+	require.Equal(t, uint64(branchDepth-1), multiproofNodes([]Word{0, 1}), "siblings")
+	require.Equal(t, uint64(branchDepth), multiproofNodes([]Word{0, 2}))
+	require.Equal(t, uint64(branchDepth-2), multiproofNodes([]Word{0, 1, 2, 3}))
+	require.Equal(t, uint64(2*branchDepth-2), multiproofNodes([]Word{0, 1 << (branchDepth - 1)}), "disjoint halves")
+}
+
+func TestInstrumentedState_AccessTracing(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x40000000)
+	testutil.StoreInstruction(state.Memory, 0x1000, 0x8c_43_00_08) // lw $v1, 8($v0)
+	testutil.StoreInstruction(state.Memory, 0x1004, 0x8c_43_00_08) // lw $v1, 8($v0)
+	state.GetRegistersRef()[2] = 0x2000
+	us := latestVm(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), nil)
+	require.Nil(t, us.GetAccessStats())
+
+	us.EnableAccessTracing(100)
+	for i := 0; i < 2; i++ {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+	stats := us.GetAccessStats()
+	require.Equal(t, uint64(2), stats.TotalSteps)
+	nodes := multiproofNodes([]Word{0x1000 >> 5, 0x2008 >> 5})
+	require.Equal(t, []mipsevm.AccessWindow{{
+		StartStep:       0,
+		Steps:           2,
+		Branches:        4,
+		Pages:           2,
+		Leaves:          2,
+		MultiproofNodes: nodes,
+		MultiproofSize:  (2 + nodes) * 32,
+	}}, stats.Windows)
+}
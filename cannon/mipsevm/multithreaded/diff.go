@@ -0,0 +1,224 @@
+package multithreaded
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// StateDiff is the difference between two states a and b. Only what differs is included.
+type StateDiff struct {
+	// Fields are the scalar fields of the states that differ, e.g. the heap or the step.
+	Fields []FieldDiff `json:"fields,omitempty"`
+	// Threads are the threads that differ, by thread id.
+	Threads []ThreadDiff `json:"threads,omitempty"`
+	// Pages are the memory pages that differ, by page index.
+	Pages []PageDiff `json:"pages,omitempty"`
+}
+
+// FieldDiff is a field that differs, with its formatted values in a and b.
+type FieldDiff struct {
+	Name string `json:"name"`
+	A    string `json:"a"`
+	B    string `json:"b"`
+}
+
+// ThreadDiff is a thread that differs between the states.
+type ThreadDiff struct {
+	ThreadId Word `json:"threadId"`
+	// Only is "a" or "b" if the thread only exists in that state, and empty if it exists in both.
+	Only string `json:"only,omitempty"`
+	// Fields are the fields of the thread that differ: its position in the thread stacks, CPU scalars,
+	// exit status and registers.
+	Fields []FieldDiff `json:"fields,omitempty"`
+}
+
+// PageDiff is a memory page that differs between the states.
+// Pages that only exist in one state are compared with a page of zeros.
+type PageDiff struct {
+	Index   Word `json:"index"`
+	Address Word `json:"address"`
+	// Only is "a" or "b" if the page is only allocated in that state, and empty if it is allocated in both.
+	Only string `json:"only,omitempty"`
+	// DifferingWords is the number of words of the page that differ.
+	DifferingWords int `json:"differingWords"`
+	// Words are the first words that differ, up to the limit of DiffStates.
+	Words []WordDiff `json:"words"`
+}
+
+// WordDiff is a memory word that differs between the states.
+type WordDiff struct {
+	Address Word `json:"address"`
+	A       Word `json:"a"`
+	B       Word `json:"b"`
+}
+
+// Empty returns true if the states do not differ.
+func (d *StateDiff) Empty() bool {
+	return len(d.Fields) == 0 && len(d.Threads) == 0 && len(d.Pages) == 0
+}
+
+// DiffStates returns the difference between the states a and b.
+// At most maxWordsPerPage differing words are listed per page, all of them if it is zero.
+func DiffStates(a, b *State, maxWordsPerPage int) *StateDiff {
+	diff := &StateDiff{}
+	fields := &diff.Fields
+	diffField(fields, "preimageKey", a.PreimageKey, b.PreimageKey)
+	diffWord(fields, "preimageOffset", a.PreimageOffset, b.PreimageOffset)
+	diffWord(fields, "heap", a.Heap, b.Heap)
+	diffField(fields, "llReservationStatus", a.LLReservationStatus, b.LLReservationStatus)
+	diffWord(fields, "llAddress", a.LLAddress, b.LLAddress)
+	diffField(fields, "llOwnerThread", a.LLOwnerThread, b.LLOwnerThread)
+	diffField(fields, "exitCode", a.ExitCode, b.ExitCode)
+	diffField(fields, "exited", a.Exited, b.Exited)
+	diffField(fields, "step", a.Step, b.Step)
+	diffField(fields, "stepsSinceLastContextSwitch", a.StepsSinceLastContextSwitch, b.StepsSinceLastContextSwitch)
+	diffField(fields, "traverseRight", a.TraverseRight, b.TraverseRight)
+	diffField(fields, "nextThreadId", a.NextThreadId, b.NextThreadId)
+	diffField(fields, "threadCount", a.ThreadCount(), b.ThreadCount())
+
+	diff.Threads = diffThreads(a, b)
+	diff.Pages = diffPages(a.Memory, b.Memory, maxWordsPerPage)
+	return diff
+}
+
+// threadPosition is the position of a thread in the thread stacks of a state.
+type threadPosition struct {
+	thread *ThreadState
+	// position is e.g. "left[0]", where 0 is the bottom of the stack, and "(current)" is appended for the current thread.
+	position string
+}
+
+func threadPositions(s *State) map[Word]threadPosition {
+	out := make(map[Word]threadPosition, s.ThreadCount())
+	var current *ThreadState
+	if active := s.getActiveThreadStack(); len(active) > 0 {
+		current = active[len(active)-1]
+	}
+	add := func(stack string, threads []*ThreadState) {
+		for i, t := range threads {
+			position := fmt.Sprintf("%s[%d]", stack, i)
+			if t == current {
+				position += " (current)"
+			}
+			out[t.ThreadId] = threadPosition{thread: t, position: position}
+		}
+	}
+	add("left", s.LeftThreadStack)
+	add("right", s.RightThreadStack)
+	return out
+}
+
+func diffThreads(a, b *State) []ThreadDiff {
+	threadsA := threadPositions(a)
+	threadsB := threadPositions(b)
+	var ids []Word
+	for id := range threadsA {
+		ids = append(ids, id)
+	}
+	for id := range threadsB {
+		if _, ok := threadsA[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	var out []ThreadDiff
+	for _, id := range ids {
+		ta, okA := threadsA[id]
+		tb, okB := threadsB[id]
+		switch {
+		case !okB:
+			out = append(out, ThreadDiff{ThreadId: id, Only: "a"})
+			continue
+		case !okA:
+			out = append(out, ThreadDiff{ThreadId: id, Only: "b"})
+			continue
+		}
+		var fields []FieldDiff
+		diffField(&fields, "position", ta.position, tb.position)
+		diffWord(&fields, "pc", ta.thread.Cpu.PC, tb.thread.Cpu.PC)
+		diffWord(&fields, "nextPC", ta.thread.Cpu.NextPC, tb.thread.Cpu.NextPC)
+		diffWord(&fields, "lo", ta.thread.Cpu.LO, tb.thread.Cpu.LO)
+		diffWord(&fields, "hi", ta.thread.Cpu.HI, tb.thread.Cpu.HI)
+		diffField(&fields, "exitCode", ta.thread.ExitCode, tb.thread.ExitCode)
+		diffField(&fields, "exited", ta.thread.Exited, tb.thread.Exited)
+		for i := range ta.thread.Registers {
+			diffWord(&fields, "$"+disasm.RegNames[i], ta.thread.Registers[i], tb.thread.Registers[i])
+		}
+		if len(fields) > 0 {
+			out = append(out, ThreadDiff{ThreadId: id, Fields: fields})
+		}
+	}
+	return out
+}
+
+func diffPages(a, b *memory.Memory, maxWords int) []PageDiff {
+	pagesA := make(map[Word]*memory.Page, a.PageCount())
+	_ = a.ForEachPage(func(index Word, page *memory.Page) error {
+		pagesA[index] = page
+		return nil
+	})
+	pagesB := make(map[Word]*memory.Page, b.PageCount())
+	_ = b.ForEachPage(func(index Word, page *memory.Page) error {
+		pagesB[index] = page
+		return nil
+	})
+	var indices []Word
+	for index := range pagesA {
+		indices = append(indices, index)
+	}
+	for index := range pagesB {
+		if _, ok := pagesA[index]; !ok {
+			indices = append(indices, index)
+		}
+	}
+	slices.Sort(indices)
+
+	var zero memory.Page
+	var out []PageDiff
+	for _, index := range indices {
+		pa, okA := pagesA[index]
+		pb, okB := pagesB[index]
+		d := PageDiff{Index: index, Address: index << memory.PageAddrSize, Words: []WordDiff{}}
+		switch {
+		case !okB:
+			d.Only = "a"
+			pb = &zero
+		case !okA:
+			d.Only = "b"
+			pa = &zero
+		}
+		if *pa == *pb {
+			continue
+		}
+		for offset := 0; offset < memory.PageSize; offset += arch.WordSizeBytes {
+			wa := arch.ByteOrderWord.Word(pa[offset : offset+arch.WordSizeBytes])
+			wb := arch.ByteOrderWord.Word(pb[offset : offset+arch.WordSizeBytes])
+			if wa == wb {
+				continue
+			}
+			d.DifferingWords++
+			if maxWords == 0 || len(d.Words) < maxWords {
+				d.Words = append(d.Words, WordDiff{Address: d.Address + Word(offset), A: wa, B: wb})
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+func diffField[T comparable](fields *[]FieldDiff, name string, a, b T) {
+	if a != b {
+		*fields = append(*fields, FieldDiff{Name: name, A: fmt.Sprintf("%v", a), B: fmt.Sprintf("%v", b)})
+	}
+}
+
+func diffWord(fields *[]FieldDiff, name string, a, b Word) {
+	if a != b {
+		*fields = append(*fields, FieldDiff{Name: name, A: fmt.Sprintf("0x%x", a), B: fmt.Sprintf("0x%x", b)})
+	}
+}
@@ -0,0 +1,90 @@
+package multithreaded
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffStates(t *testing.T) {
+	t.Run("equal", func(t *testing.T) {
+		a := CreateInitialState(0x1000, 0x4000)
+		a.Memory.SetWord(0x2000, 0x1234)
+		b := CreateInitialState(0x1000, 0x4000)
+		b.Memory.SetWord(0x2000, 0x1234)
+		diff := DiffStates(a, b, 0)
+		require.True(t, diff.Empty())
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		a := CreateInitialState(0x1000, 0x4000)
+		b := CreateInitialState(0x1000, 0x5000)
+		b.Step = 10
+		b.Exited = true
+		diff := DiffStates(a, b, 0)
+		require.Equal(t, []FieldDiff{
+			{Name: "heap", A: "0x4000", B: "0x5000"},
+			{Name: "exited", A: "false", B: "true"},
+			{Name: "step", A: "0", B: "10"},
+		}, diff.Fields)
+		require.Empty(t, diff.Threads)
+		require.Empty(t, diff.Pages)
+	})
+
+	t.Run("threads", func(t *testing.T) {
+		a := CreateInitialState(0x1000, 0x4000)
+		b := CreateInitialState(0x1000, 0x4000)
+		b.GetCurrentThread().Registers[29] = 0x7fff_0000
+		b.GetCurrentThread().Cpu.PC = 0x1004
+		second := CreateEmptyThread()
+		second.ThreadId = 1
+		b.RightThreadStack = append(b.RightThreadStack, second)
+		b.NextThreadId = 2
+
+		diff := DiffStates(a, b, 0)
+		require.Equal(t, []FieldDiff{
+			{Name: "nextThreadId", A: "1", B: "2"},
+			{Name: "threadCount", A: "1", B: "2"},
+		}, diff.Fields)
+		require.Equal(t, []ThreadDiff{
+			{ThreadId: 0, Fields: []FieldDiff{
+				{Name: "pc", A: "0x1000", B: "0x1004"},
+				{Name: "$sp", A: "0x0", B: "0x7fff0000"},
+			}},
+			{ThreadId: 1, Only: "b"},
+		}, diff.Threads)
+
+		// Moving the thread to the other stack changes its position
+		a.TraverseRight = true
+		a.RightThreadStack, a.LeftThreadStack = a.LeftThreadStack, a.RightThreadStack
+		diff = DiffStates(a, CreateInitialState(0x1000, 0x4000), 0)
+		require.Equal(t, []FieldDiff{{Name: "traverseRight", A: "true", B: "false"}}, diff.Fields)
+		require.Equal(t, []ThreadDiff{
+			{ThreadId: 0, Fields: []FieldDiff{{Name: "position", A: "right[0] (current)", B: "left[0] (current)"}}},
+		}, diff.Threads)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		a := CreateEmptyState()
+		b := CreateEmptyState()
+		a.Memory.SetWord(0x1000, 1)
+		b.Memory.SetWord(0x1000, 2)
+		b.Memory.SetWord(0x1010, 3)
+		b.Memory.SetWord(0x1020, 4)
+		// allocated zero pages do not differ from unallocated pages
+		a.Memory.SetWord(0x10_0000, 0)
+		b.Memory.SetWord(0x20_0000, 5)
+
+		diff := DiffStates(a, b, 2)
+		require.Empty(t, diff.Fields)
+		require.Equal(t, []PageDiff{
+			{Index: 0x1, Address: 0x1000, DifferingWords: 3, Words: []WordDiff{
+				{Address: 0x1000, A: 1, B: 2},
+				{Address: 0x1010, A: 0, B: 3},
+			}},
+			{Index: 0x200, Address: 0x20_0000, Only: "b", DifferingWords: 1, Words: []WordDiff{
+				{Address: 0x20_0000, A: 0, B: 5},
+			}},
+		}, diff.Pages)
+	})
+}
@@ -0,0 +1,29 @@
+package multithreaded
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// FloatingPointError is returned by Step when a thread executes a floating point instruction.
+// Cannon has no floating point unit, so the program was built without soft-float, or links code that uses the FPU.
+// The onchain VM rejects the instruction as invalid.
+type FloatingPointError struct {
+	ThreadId Word
+	PC       Word
+	Insn     uint32
+	Class    exec.FPUClass
+	// Function is the symbol of the function of the instruction, if the metadata of the program is known.
+	Function string
+}
+
+func (e *FloatingPointError) Error() string {
+	function := e.Function
+	if function == "" {
+		function = "unknown"
+	}
+	return fmt.Sprintf("floating point unsupported at pc 0x%x (function %s): %s instruction 0x%08x (%s) in thread %d",
+		e.PC, function, e.Class, e.Insn, disasm.Disassemble(uint64(e.PC), e.Insn), e.ThreadId)
+}
@@ -0,0 +1,93 @@
+package multithreaded
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestFloatingPointInstructions(t *testing.T) {
+	cop1 := func(format, rest uint32) uint32 {
+		return exec.OpCop1<<26 | format<<21 | rest
+	}
+	cases := []struct {
+		name  string
+		insn  uint32
+		class exec.FPUClass
+	}{
+		{name: "lwc1", insn: exec.OpLwc1<<26 | 29<<21 | 2<<16 | 0x8, class: exec.FPULoad},
+		{name: "ldc1", insn: exec.OpLdc1<<26 | 29<<21 | 2<<16 | 0x8, class: exec.FPULoad},
+		{name: "swc1", insn: exec.OpSwc1<<26 | 29<<21 | 2<<16 | 0x8, class: exec.FPUStore},
+		{name: "sdc1", insn: exec.OpSdc1<<26 | 29<<21 | 2<<16 | 0x8, class: exec.FPUStore},
+		{name: "mfc1", insn: cop1(0x00, 4<<16|2<<11), class: exec.FPUMove},
+		{name: "dmtc1", insn: cop1(0x05, 4<<16|2<<11), class: exec.FPUMove},
+		{name: "cfc1", insn: cop1(0x02, 4<<16|31<<11), class: exec.FPUMove},
+		{name: "bc1t", insn: cop1(0x08, 1<<16|0x10), class: exec.FPUBranch},
+		{name: "add.d", insn: cop1(0x11, 4<<16|2<<11|0<<6|0x00), class: exec.FPUCompute},
+		{name: "sqrt.s", insn: cop1(0x10, 2<<11|0<<6|0x04), class: exec.FPUCompute},
+		{name: "c.eq.d", insn: cop1(0x11, 4<<16|2<<11|0x32), class: exec.FPUCompute},
+		{name: "cvt.d.l", insn: cop1(0x15, 2<<11|0<<6|0x21), class: exec.FPUCompute},
+		{name: "cop1 reserved format", insn: cop1(0x1F, 0), class: exec.FPUCop1Other},
+		{name: "ldxc1", insn: exec.OpCop1x<<26 | 29<<21 | 4<<16 | 0<<6 | 0x01, class: exec.FPUIndexed},
+		{name: "madd.d", insn: exec.OpCop1x<<26 | 6<<21 | 4<<16 | 2<<11 | 0<<6 | 0x21, class: exec.FPUIndexed},
+		{name: "movt", insn: 4<<21 | 1<<16 | 2<<11 | exec.FunMovci, class: exec.FPUCondMove},
+	}
+	meta := &program.Metadata{Symbols: []program.Symbol{{Name: "main.compute", Start: 0x1000, Size: 0x100}}}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := CreateEmptyState()
+			pc := Word(0x1010)
+			state.GetCurrentThread().Cpu.PC = pc
+			state.GetCurrentThread().Cpu.NextPC = pc + 4
+			testutil.StoreInstruction(state.Memory, pc, c.insn)
+			vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), meta, allFeaturesEnabled())
+
+			_, err := vm.Step(false)
+			var fpErr *FloatingPointError
+			require.ErrorAs(t, err, &fpErr)
+			require.Equal(t, Word(0), fpErr.ThreadId)
+			require.Equal(t, pc, fpErr.PC)
+			require.Equal(t, c.insn, fpErr.Insn)
+			require.Equal(t, c.class, fpErr.Class)
+			require.Equal(t, "main.compute", fpErr.Function)
+			require.ErrorContains(t, err, "floating point unsupported at pc 0x1010 (function main.compute): "+string(c.class))
+			require.Equal(t, pc, state.GetPC(), "instruction must not execute")
+		})
+	}
+}
+
+func TestNonFloatingPointInstructions(t *testing.T) {
+	// Instructions that share an opcode or function with floating point instructions
+	for _, insn := range []uint32{
+		0x00_00_00_00,                   // nop
+		4<<21 | 1<<16 | 2<<11 | 0x21,    // addu
+		0x1C<<26 | 4<<21 | 2<<11 | 0x20, // clz
+	} {
+		_, ok := exec.FloatingPointClass(insn, disasm.Opcode(insn), disasm.Fun(insn))
+		require.False(t, ok, "instruction 0x%08x", insn)
+	}
+}
+
+// TestFloatingPointProgram runs a program built with hardfloat, and checks that it stops at its first floating point instruction.
+func TestFloatingPointProgram(t *testing.T) {
+	state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath("fpu", testutil.Go1_24), CreateInitialState)
+	vm := latestVm(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), meta)
+
+	var err error
+	for i := 0; i < 10_000_000 && !state.GetExited(); i++ {
+		if _, err = vm.Step(false); err != nil {
+			break
+		}
+	}
+	var fpErr *FloatingPointError
+	require.ErrorAs(t, err, &fpErr, "program must stop at a floating point instruction")
+	require.Equal(t, meta.LookupSymbol(fpErr.PC), fpErr.Function)
+	require.NotContains(t, fpErr.Function, "!", "function must be known")
+	t.Log(err)
+}
@@ -1,7 +1,9 @@
 package multithreaded
 
 import (
+	"cmp"
 	"io"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
@@ -21,10 +23,18 @@ type InstrumentedState struct {
 	memoryTracker *exec.MemoryTrackerImpl
 	stackTracker  ThreadedStackTracker
 	statsTracker  StatsTracker
+	syscallStats  *syscallStatsTracker
+	syscallObs    mipsevm.SyscallObserver
+	syscallFaults mipsevm.SyscallFaultInjector
+	accessTracer  *accessTracer
+	profiler      *profileTracker
+	stackGuard    *stackGuard
 
 	preimageOracle *exec.TrackingPreimageOracleReader
 	meta           mipsevm.Metadata
 	features       mipsevm.FeatureToggles
+
+	hooks mipsevm.StepHooks
 }
 
 var _ mipsevm.FPVM = (*InstrumentedState)(nil)
@@ -57,9 +67,59 @@ func (m *InstrumentedState) EnableStats() {
 	m.statsTracker = NewStatsTracker()
 }
 
+func (m *InstrumentedState) EnableSyscallStats() {
+	m.syscallStats = newSyscallStatsTracker()
+}
+
+func (m *InstrumentedState) EnableSyscallObserver(obs mipsevm.SyscallObserver) {
+	m.syscallObs = obs
+}
+
+func (m *InstrumentedState) EnableSyscallFaults(inj mipsevm.SyscallFaultInjector) {
+	m.syscallFaults = inj
+}
+
+func (m *InstrumentedState) EnableAccessTracing(windowSize uint64) {
+	m.accessTracer = newAccessTracer(windowSize)
+	m.memoryTracker.SetObserver(m.accessTracer)
+}
+
+func (m *InstrumentedState) EnableProfiling(interval uint64) {
+	m.profiler = newProfileTracker(interval)
+}
+
+func (m *InstrumentedState) EnableStackGuard(cfg mipsevm.StackGuardConfig) {
+	m.stackGuard = newStackGuard(cfg)
+}
+
+func (m *InstrumentedState) GetSyscallStats() *mipsevm.SyscallStats {
+	if m.syscallStats == nil {
+		return nil
+	}
+	return m.syscallStats.syscallStats(m.state.GetStep())
+}
+
+func (m *InstrumentedState) GetAccessStats() *mipsevm.AccessStats {
+	if m.accessTracer == nil {
+		return nil
+	}
+	return m.accessTracer.accessStats(m.state.GetStep())
+}
+
+func (m *InstrumentedState) GetProfile() *mipsevm.Profile {
+	if m.profiler == nil {
+		return nil
+	}
+	return m.profiler.profile(m.LookupSymbol)
+}
+
 func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err error) {
 	m.preimageOracle.Reset()
 	m.memoryTracker.Reset(proof)
+	step := m.state.GetStep()
+	if m.accessTracer != nil && !m.state.Exited {
+		m.accessTracer.beginStep(step)
+	}
 
 	if proof {
 		proofData := make([]byte, 0)
@@ -92,9 +152,40 @@ func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err erro
 			wit.PreimageValue = lastPreimage
 		}
 	}
+	// Exited states do not advance, and should not trigger the hooks again
+	if m.state.GetStep() != step {
+		if err := m.hooks.Run(m.state); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
+func (m *InstrumentedState) RunSteps(n uint64) (uint64, error) {
+	// Proofs are disabled for the whole run, the trackers are reset once instead of at every step
+	m.preimageOracle.Reset()
+	m.memoryTracker.Reset(false)
+	for i := uint64(0); i < n; i++ {
+		if m.state.Exited {
+			return i, nil
+		}
+		if m.accessTracer != nil {
+			m.accessTracer.beginStep(m.state.Step)
+		}
+		if err := m.mipsStep(); err != nil {
+			return i, err
+		}
+		if err := m.hooks.Run(m.state); err != nil {
+			return i, err
+		}
+	}
+	return n, nil
+}
+
+func (m *InstrumentedState) Hooks() *mipsevm.StepHooks {
+	return &m.hooks
+}
+
 func (m *InstrumentedState) CheckInfiniteLoop() bool {
 	return false
 }
@@ -116,9 +207,33 @@ func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 		TotalSteps:          m.state.GetStep(),
 	}
 	m.statsTracker.populateDebugInfo(debugInfo)
+	m.populateThreads(debugInfo)
 	return debugInfo
 }
 
+// populateThreads adds the threads of the state to the debug info, with the instruction they run next.
+func (m *InstrumentedState) populateThreads(debugInfo *mipsevm.DebugInfo) {
+	current := m.state.GetCurrentThread()
+	threads := append(slices.Clone(m.state.LeftThreadStack), m.state.RightThreadStack...)
+	for _, thread := range threads {
+		i := slices.IndexFunc(debugInfo.Threads, func(info mipsevm.ThreadInfo) bool {
+			return info.ThreadId == thread.ThreadId
+		})
+		if i < 0 {
+			debugInfo.Threads = append(debugInfo.Threads, mipsevm.ThreadInfo{ThreadId: thread.ThreadId})
+			i = len(debugInfo.Threads) - 1
+		}
+		info := &debugInfo.Threads[i]
+		info.Exited = thread.Exited
+		info.Current = thread == current
+		info.PC = hexutil.Uint64(thread.Cpu.PC)
+		info.Function = m.LookupSymbol(thread.Cpu.PC)
+	}
+	slices.SortFunc(debugInfo.Threads, func(a, b mipsevm.ThreadInfo) int {
+		return cmp.Compare(a.ThreadId, b.ThreadId)
+	})
+}
+
 func (m *InstrumentedState) Traceback() {
 	m.stackTracker.Traceback()
 }
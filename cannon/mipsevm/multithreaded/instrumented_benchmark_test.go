@@ -0,0 +1,49 @@
+package multithreaded
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func BenchmarkInstrumentedState_Run(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		run  func(vm mipsevm.FPVM, state *State) error
+	}{
+		{"Step", func(vm mipsevm.FPVM, state *State) error {
+			for !state.GetExited() {
+				if _, err := vm.Step(false); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"RunSteps", func(vm mipsevm.FPVM, state *State) error {
+			for !state.GetExited() {
+				if _, err := vm.RunSteps(100); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			var steps uint64
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				state, meta := testutil.LoadELFProgram(b, testutil.ProgramPath("random", testutil.Go1_24), CreateInitialState)
+				vm := latestVm(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), meta)
+				b.StartTimer()
+				if err := bm.run(vm, state); err != nil {
+					b.Fatal(err)
+				}
+				steps += state.GetStep()
+			}
+			b.ReportMetric(float64(steps)/b.Elapsed().Seconds(), "steps/s")
+		})
+	}
+}
@@ -2,6 +2,7 @@ package multithreaded
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -9,14 +10,18 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
 
@@ -32,6 +37,233 @@ func TestInstrumentedState_Claim(t *testing.T) {
 	})
 }
 
+func TestInstrumentedState_RustHello(t *testing.T) {
+	t.Parallel()
+	testutil.RunVMTest_RustHello(t, CreateInitialState, getVmFactory(allFeaturesEnabled()))
+}
+
+func TestInstrumentedState_RustClaim(t *testing.T) {
+	t.Parallel()
+	testutil.RunVMTest_RustClaim(t, CreateInitialState, getVmFactory(allFeaturesEnabled()))
+}
+
+func TestInstrumentedState_StepHooks(t *testing.T) {
+	state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath("random", testutil.Go1_24), CreateInitialState)
+	us := latestVm(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), meta)
+
+	var intervalSteps []uint64
+	us.Hooks().Every(1000, func(view mipsevm.StateView) error {
+		intervalSteps = append(intervalSteps, view.GetStep())
+		return nil
+	})
+	var atView mipsevm.StateView
+	var atRegisters [32]arch.Word
+	atCalls := 0
+	us.Hooks().At(1234, func(view mipsevm.StateView) error {
+		atCalls++
+		atView = view
+		atRegisters = view.GetRegisters()
+		_, hash := view.EncodeWitness()
+		_, expected := state.EncodeWitness()
+		require.Equal(t, expected, hash)
+		return nil
+	})
+	for i := 0; i < 5_000; i++ {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		if i == 1233 {
+			require.Equal(t, atRegisters, *state.GetRegistersRef(), "view must reflect the state after the step")
+		}
+	}
+	require.Equal(t, []uint64{1000, 2000, 3000, 4000, 5000}, intervalSteps)
+	require.Equal(t, 1, atCalls)
+	require.Equal(t, uint64(5000), atView.GetStep(), "view is live, not a copy")
+
+	errHook := errors.New("stop")
+	us.Hooks().At(5010, func(view mipsevm.StateView) error {
+		return errHook
+	})
+	for state.GetStep() < 5009 {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+	_, err := us.Step(false)
+	require.ErrorIs(t, err, errHook)
+}
+
+func TestInstrumentedState_RunSteps(t *testing.T) {
+	for _, programName := range []string{"hello", "random", "mt-general"} {
+		for _, batchSize := range []uint64{1, 1000, 100_000} {
+			t.Run(fmt.Sprintf("%s-%d", programName, batchSize), func(t *testing.T) {
+				newVm := func(stdOut io.Writer) (*State, mipsevm.FPVM, *[]uint64) {
+					state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath(programName, testutil.Go1_24), CreateInitialState)
+					us := latestVm(state, testutil.StaticOracle(t, []byte{}), stdOut, io.Discard, testutil.CreateLogger(), meta)
+					var hookSteps []uint64
+					us.Hooks().Every(10_000, func(view mipsevm.StateView) error {
+						hookSteps = append(hookSteps, view.GetStep())
+						return nil
+					})
+					return state, us, &hookSteps
+				}
+				var slowOut, fastOut bytes.Buffer
+				slowState, slowVm, slowHooks := newVm(&slowOut)
+				fastState, fastVm, fastHooks := newVm(&fastOut)
+
+				compareAt := uint64(0)
+				for !fastState.GetExited() {
+					require.Less(t, fastState.GetStep(), uint64(5_000_000), "must complete program")
+					executed, err := fastVm.RunSteps(batchSize)
+					require.NoError(t, err)
+					for i := uint64(0); i < executed; i++ {
+						_, err := slowVm.Step(false)
+						require.NoError(t, err)
+					}
+					if !fastState.GetExited() {
+						require.Equal(t, batchSize, executed, "batches run to completion until the program exits")
+					}
+					require.Equal(t, slowState.GetStep(), fastState.GetStep())
+					// Merkleization is expensive, the states are compared periodically
+					if fastState.GetStep() >= compareAt {
+						_, slowHash := slowState.EncodeWitness()
+						_, fastHash := fastState.EncodeWitness()
+						require.Equal(t, slowHash, fastHash, "states must match at step %d", fastState.GetStep())
+						compareAt += 100_000
+					}
+				}
+				_, slowHash := slowState.EncodeWitness()
+				_, fastHash := fastState.EncodeWitness()
+				require.Equal(t, slowHash, fastHash)
+				require.True(t, slowState.GetExited())
+				require.Equal(t, slowOut.String(), fastOut.String())
+				require.NotEmpty(t, *fastHooks)
+				require.Equal(t, *slowHooks, *fastHooks)
+
+				executed, err := fastVm.RunSteps(batchSize)
+				require.NoError(t, err)
+				require.Zero(t, executed, "exited states do not advance")
+			})
+		}
+	}
+}
+
+func TestInstrumentedState_SyscallRecordReplay(t *testing.T) {
+	// hello does not use the oracle, the recorder wraps a nil oracle
+	run := func(po mipsevm.PreimageOracle, observer mipsevm.SyscallObserver) (*State, error) {
+		state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath("hello", testutil.Go1_24), CreateInitialState)
+		us := latestVm(state, po, io.Discard, io.Discard, testutil.CreateLogger(), meta)
+		us.EnableSyscallObserver(observer)
+		for i := 0; i < 500_000 && !state.GetExited(); i++ {
+			if _, err := us.Step(false); err != nil {
+				return state, err
+			}
+		}
+		return state, nil
+	}
+
+	var recording bytes.Buffer
+	recorder := mipsevm.NewSyscallRecorder(nil, &recording)
+	recorded, err := run(recorder, recorder)
+	require.NoError(t, err)
+	require.True(t, recorded.GetExited())
+
+	replayer, err := mipsevm.NewSyscallReplayer(bytes.NewReader(recording.Bytes()))
+	require.NoError(t, err)
+	syscalls := replayer.Remaining()
+	require.NotZero(t, syscalls)
+	replayed, err := run(replayer, replayer)
+	require.NoError(t, err)
+	require.Zero(t, replayer.Remaining())
+	_, recordedHash := recorded.EncodeWitness()
+	_, replayedHash := replayed.EncodeWitness()
+	require.Equal(t, recordedHash, replayedHash)
+
+	// A recording of another run does not match: the first syscall of the run is replaced by the last one.
+	lines := bytes.SplitAfter(recording.Bytes(), []byte("\n"))
+	tampered := bytes.Join(append([][]byte{lines[syscalls-1]}, lines[1:]...), nil)
+	replayer, err = mipsevm.NewSyscallReplayer(bytes.NewReader(tampered))
+	require.NoError(t, err)
+	diverged, err := run(replayer, replayer)
+	require.ErrorIs(t, err, mipsevm.ErrSyscallDiverged)
+	require.False(t, diverged.GetExited())
+	require.Equal(t, syscalls, replayer.Remaining())
+}
+
+func TestInstrumentedState_SyscallFaults(t *testing.T) {
+	futexWait := func(call mipsevm.SyscallCall) bool {
+		return call.Args[1] == exec.FutexWaitPrivate
+	}
+	cases := []struct {
+		name           string
+		programName    string
+		faults         []testutil.SyscallFault
+		expectedOutput string
+		expectInjected int
+	}{
+		{
+			name:           "write retried on EINTR",
+			programName:    "hello",
+			faults:         []testutil.SyscallFault{{Num: arch.SysWrite, Errno: exec.MipsEINTR, Call: 1}},
+			expectedOutput: "hello world!\n",
+			expectInjected: 1,
+		},
+		{
+			name:           "write fails with EBADF",
+			programName:    "hello",
+			faults:         []testutil.SyscallFault{{Num: arch.SysWrite, Errno: exec.MipsEBADF}},
+			expectedOutput: "",
+			expectInjected: 1,
+		},
+		{
+			name:           "futex wait interrupted",
+			programName:    "mt-general",
+			faults:         []testutil.SyscallFault{{Num: arch.SysFutex, Errno: exec.MipsEINTR, Match: futexWait}},
+			expectedOutput: "waitgroup result: 42\nchannels result: 1234\nGC complete!\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath(c.programName, testutil.Go1_24), CreateInitialState)
+			var stdOutBuf bytes.Buffer
+			us := latestVm(state, nil, &stdOutBuf, io.Discard, testutil.CreateLogger(), meta)
+			faults := testutil.NewSyscallFaults(c.faults...)
+			us.EnableSyscallFaults(faults)
+			observed := make(map[uint64]mipsevm.SyscallCall)
+			us.EnableSyscallObserver(observerFunc(func(call mipsevm.SyscallCall) error {
+				observed[call.Step] = call
+				return nil
+			}))
+			for i := 0; i < 5_000_000 && !state.GetExited(); i++ {
+				_, err := us.Step(false)
+				require.NoError(t, err)
+			}
+			require.True(t, state.GetExited(), "must complete program")
+			require.Equal(t, uint8(0), state.GetExitCode(), "exit with 0")
+			require.True(t, strings.HasSuffix(stdOutBuf.String(), c.expectedOutput), stdOutBuf.String())
+			if c.expectedOutput == "" {
+				require.Empty(t, stdOutBuf.String())
+			}
+
+			injected := faults.Injected()
+			if c.expectInjected != 0 {
+				require.Len(t, injected, c.expectInjected)
+			} else {
+				require.NotEmpty(t, injected)
+			}
+			for _, call := range injected {
+				require.True(t, faults.Failed(call.Step))
+				require.Equal(t, call, observed[call.Step], "failed syscalls are observed with their errno")
+			}
+			require.False(t, faults.Failed(state.GetStep()))
+		})
+	}
+}
+
+type observerFunc func(call mipsevm.SyscallCall) error
+
+func (f observerFunc) ObserveSyscall(call mipsevm.SyscallCall) error {
+	return f(call)
+}
+
 func TestInstrumentedState_Random(t *testing.T) {
 	state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath("random", testutil.Go1_24), CreateInitialState)
 
@@ -83,6 +315,21 @@ func TestInstrumentedState_Random(t *testing.T) {
 	}
 }
 
+// TestInstrumentedState_Determinism runs programs, that use host-facing syscalls such as clock_gettime and getrandom,
+// under different host environments, and checks that the execution does not depend on the host.
+// Not parallel: the host environment is modified for each run.
+func TestInstrumentedState_Determinism(t *testing.T) {
+	cfg := testutil.DeterminismConfig{
+		MaxSteps:           5_000_000,
+		CheckpointInterval: 50_000,
+	}
+	for _, programName := range []string{"hello", "random", "mt-general"} {
+		t.Run(programName, func(t *testing.T) {
+			testutil.RunDeterminismAudit(t, testutil.ProgramPath(programName, testutil.Go1_24), CreateInitialState, latestVm, testutil.DefaultHostEnvs(), cfg)
+		})
+	}
+}
+
 func TestInstrumentedState_SyscallEventFdProgram(t *testing.T) {
 	runTestAcrossVms(t, "SyscallEventFdProgram", func(t *testing.T, vmFactory testutil.VMFactory[*State], goTarget testutil.GoTarget) {
 		state, meta := testutil.LoadELFProgram(t, testutil.ProgramPath("syscall-eventfd", goTarget), CreateInitialState)
@@ -404,6 +651,36 @@ func getVmFactory(featureToggles mipsevm.FeatureToggles) testutil.VMFactory[*Sta
 	}
 }
 
+// TestInstrumentedState_WitnessSize guards the size of the witness of a step, which must fit in the calldata of an
+// onchain step. The pre-image parts, the variable inputs of the onchain calls, are checked by the EVM tests.
+func TestInstrumentedState_WitnessSize(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x40000000)
+	testutil.StoreInstruction(state.Memory, 0x1000, 0x8c_43_00_08) // lw $v1, 8($v0)
+	us := latestVm(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), nil)
+	wit, err := us.Step(true)
+	require.NoError(t, err)
+	require.Len(t, wit.State, STATE_WITNESS_SIZE)
+	require.Len(t, wit.ProofData, THREAD_WITNESS_SIZE+3*memory.MemProofSize)
+	require.LessOrEqual(t, wit.StepInputSize(), mipsevm.MaxStepInputSize)
+}
+
+func TestInstrumentedState_SchedQuantum(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x40000000) // zeroed memory runs nops
+	features := allFeaturesEnabled()
+	features.SchedQuantum = 3
+	us := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), nil, features)
+	us.EnableStats()
+	for i := 0; i < 8; i++ {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+	debugInfo := us.GetDebugInfo()
+	require.Equal(t, uint64(2), debugInfo.ForcedPreemptionCount)
+	require.Equal(t, []mipsevm.ThreadInfo{
+		{ThreadId: 0, Current: true, PC: 0x1000 + 6*4, Steps: 6, Preemptions: 2, LastStep: 7},
+	}, debugInfo.Threads)
+}
+
 func latestVm(state *State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, meta *program.Metadata) mipsevm.FPVM {
 	vmFactory := getVmFactory(allFeaturesEnabled())
 	return vmFactory(state, po, stdOut, stdErr, log, meta)
@@ -424,6 +701,45 @@ func allFeaturesEnabled() mipsevm.FeatureToggles {
 	return toggles
 }
 
+func TestInstrumentedState_ClockGettime(t *testing.T) {
+	const pc = Word(0x1000)
+	const timespec = Word(0x2000)
+	clockGettime := func(t *testing.T, features mipsevm.FeatureToggles, clkid Word) (vm *InstrumentedState, ret, errno Word) {
+		vm = NewInstrumentedState(CreateEmptyState(), nil, io.Discard, io.Discard, testutil.CreateLogger(), nil, features)
+		vm.state.Step = 3*exec.HZ + 42
+		thread := vm.state.GetCurrentThread()
+		thread.Cpu.PC = pc
+		thread.Cpu.NextPC = pc + 4
+		testutil.StoreInstruction(vm.state.Memory, pc, 0x00_00_00_0C) // syscall
+		thread.Registers[register.RegSyscallNum] = arch.SysClockGetTime
+		thread.Registers[register.RegA0] = clkid
+		thread.Registers[register.RegA1] = timespec
+		_, err := vm.Step(true)
+		require.NoError(t, err)
+		return vm, thread.Registers[register.RegSyscallRet1], thread.Registers[register.RegSyscallErrno]
+	}
+
+	for _, clkid := range []Word{exec.ClockGettimeMonotonicFlag, exec.ClockGettimeThreadCputimeFlag, exec.ClockGettimeBoottimeFlag} {
+		t.Run(fmt.Sprintf("clock %d", clkid), func(t *testing.T) {
+			vm, ret, errno := clockGettime(t, mipsevm.FeatureToggles{SupportExtendedClockGettime: true}, clkid)
+			require.Zero(t, errno)
+			require.Zero(t, ret)
+			// the step is incremented before the syscall
+			require.Equal(t, Word(3), vm.state.Memory.GetWord(timespec))
+			require.Equal(t, Word(43*(1_000_000_000/exec.HZ)), vm.state.Memory.GetWord(timespec+arch.WordSizeBytes))
+		})
+	}
+
+	for _, clkid := range []Word{exec.ClockGettimeThreadCputimeFlag, exec.ClockGettimeBoottimeFlag} {
+		t.Run(fmt.Sprintf("clock %d disabled", clkid), func(t *testing.T) {
+			vm, ret, errno := clockGettime(t, mipsevm.FeatureToggles{}, clkid)
+			require.Equal(t, exec.SysErrorSignal, errno)
+			require.Equal(t, Word(exec.MipsEINVAL), ret)
+			require.Zero(t, vm.state.Memory.GetWord(timespec))
+		})
+	}
+}
+
 // Unit test splitmix64 based on Apache Commons RNG unit tests
 // See: https://github.com/apache/commons-rng/blob/df772c2f5b0644a71398e925206039a2ae516ab2/commons-rng-core/src/test/java/org/apache/commons/rng/core/source64/SplitMix64Test.java
 func TestSplitmix64(t *testing.T) {
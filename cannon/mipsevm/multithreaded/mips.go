@@ -3,6 +3,7 @@ package multithreaded
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -17,6 +18,44 @@ import (
 
 type Word = arch.Word
 
+// handleInstrumentedSyscall handles the syscall of the thread, or fails it if the syscall fault injector selects it,
+// and reports it to the syscall stats and observer.
+func (m *InstrumentedState) handleInstrumentedSyscall(thread *ThreadState) error {
+	syscallNum, a0, a1, a2 := exec.GetSyscallArgs(&thread.Registers)
+	start := time.Now()
+	var err error
+	if errno, fail := m.injectSyscallFault(thread, syscallNum, a0, a1, a2); fail {
+		exec.HandleSyscallUpdates(&thread.Cpu, &thread.Registers, errno, exec.SysErrorSignal)
+	} else {
+		err = m.handleSyscall()
+	}
+	if m.syscallStats != nil {
+		m.syscallStats.trackSyscall(syscallNum, m.state.GetStep(), time.Since(start))
+	}
+	if err != nil || m.syscallObs == nil {
+		return err
+	}
+	return m.syscallObs.ObserveSyscall(mipsevm.SyscallCall{
+		Step:     m.state.GetStep(),
+		ThreadId: thread.ThreadId,
+		Num:      syscallNum,
+		Args:     [3]Word{a0, a1, a2},
+		Ret:      [2]Word{thread.Registers[register.RegSyscallRet1], thread.Registers[register.RegSyscallErrno]},
+	})
+}
+
+func (m *InstrumentedState) injectSyscallFault(thread *ThreadState, syscallNum, a0, a1, a2 Word) (Word, bool) {
+	if m.syscallFaults == nil {
+		return 0, false
+	}
+	return m.syscallFaults.InjectSyscallFault(mipsevm.SyscallCall{
+		Step:     m.state.GetStep(),
+		ThreadId: thread.ThreadId,
+		Num:      syscallNum,
+		Args:     [3]Word{a0, a1, a2},
+	})
+}
+
 func (m *InstrumentedState) handleSyscall() error {
 	thread := m.state.GetCurrentThread()
 
@@ -69,6 +108,9 @@ func (m *InstrumentedState) handleSyscall() error {
 		// Note: We need to call stackTracker after pushThread
 		// to ensure we are tracking in the context of the new thread
 		m.stackTracker.PushStack(stackCaller, stackTarget)
+		if m.stackGuard != nil {
+			m.stackGuard.trackThread(newThread.ThreadId, a1)
+		}
 		return nil
 	case arch.SysExitGroup:
 		m.state.Exited = true
@@ -116,6 +158,7 @@ func (m *InstrumentedState) handleSyscall() error {
 				v0 = exec.MipsEAGAIN
 				v1 = exec.SysErrorSignal
 			} else {
+				m.statsTracker.trackFutexWait(thread.ThreadId, effFutexAddr)
 				m.syscallYield(thread)
 				return nil
 			}
@@ -133,13 +176,16 @@ func (m *InstrumentedState) handleSyscall() error {
 		v0 = exec.MipsEBADF
 		v1 = exec.SysErrorSignal
 	case arch.SysClockGetTime:
-		switch a0 {
-		case exec.ClockGettimeRealtimeFlag, exec.ClockGettimeMonotonicFlag:
+		switch {
+		case a0 == exec.ClockGettimeRealtimeFlag || a0 == exec.ClockGettimeMonotonicFlag ||
+			(m.features.SupportExtendedClockGettime && (a0 == exec.ClockGettimeThreadCputimeFlag || a0 == exec.ClockGettimeBoottimeFlag)):
 			v0, v1 = 0, 0
 			var secs, nsecs Word
-			if a0 == exec.ClockGettimeMonotonicFlag {
+			if a0 != exec.ClockGettimeRealtimeFlag {
 				// monotonic clock_gettime is used by Go guest programs for goroutine scheduling and to implement
 				// `time.Sleep` (and other sleep related operations).
+				// The VM is never suspended, so the boot time is the monotonic time. The steps of each thread
+				// are not part of the state, so the CPU time of a thread is the monotonic time too.
 				secs = Word(m.state.Step / exec.HZ)
 				nsecs = Word((m.state.Step % exec.HZ) * (1_000_000_000 / exec.HZ))
 			} // else realtime set to Unix Epoch
@@ -163,15 +209,27 @@ func (m *InstrumentedState) handleSyscall() error {
 			v0, v1 = m.syscallGetRandom(a0, a1)
 		}
 		// Otherwise, ignored (noop)
+	case arch.SysSetThreadArea, arch.SysSetTidAddress, arch.SysSetRobustList, arch.SysGetRobustList, arch.SysPoll, arch.SysMremap:
+		if !m.features.SupportMuslRuntime {
+			m.handleUnrecognizedSyscall(syscallNum)
+		}
+		v0, v1 = m.syscallMuslRuntime(thread, syscallNum, a0, a1)
 	case arch.SysMunmap:
 	case arch.SysMprotect:
 		if !m.features.SupportNoopMprotect {
 			m.handleUnrecognizedSyscall(syscallNum)
 		}
+		// Page permissions are not tracked: they are not part of the state witness, so the onchain VM could not
+		// enforce them, and a write to a read-only page could not fault the same way onchain.
 	case arch.SysGetAffinity:
 	case arch.SysMadvise:
 	case arch.SysRtSigprocmask:
 	case arch.SysSigaltstack:
+		// args: a0 = ss, a1 = old_ss. The stack is never used, as signals are not delivered.
+		if m.features.SupportMuslRuntime && a1 != 0 {
+			v0, v1 = m.syscallSigaltstackDisabled(a1)
+		}
+		// Otherwise, ignored (noop)
 	case arch.SysRtSigaction:
 	case arch.SysPrlimit64:
 	case arch.SysClose:
@@ -264,6 +322,49 @@ func splitmix64(seed uint64) uint64 {
 	return z ^ (z >> 31)
 }
 
+// syscallMuslRuntime handles the syscalls that the musl runtime makes to set up a thread,
+// and to probe for features that are not supported.
+func (m *InstrumentedState) syscallMuslRuntime(thread *ThreadState, syscallNum, a0, a1 Word) (v0, v1 Word) {
+	switch syscallNum {
+	case arch.SysSetThreadArea:
+		// args: a0 = thread pointer
+		thread.Registers[register.RegThreadPointer] = a0
+	case arch.SysSetTidAddress:
+		// The tid address is not cleared on exit, as threads of musl programs cannot be cloned.
+		return thread.ThreadId, 0
+	case arch.SysSetRobustList:
+		// args: a0 = head, a1 = len. The list is not walked on exit, as for set_tid_address.
+		if a1 != exec.RobustListHeadSize {
+			return exec.MipsEINVAL, exec.SysErrorSignal
+		}
+	case arch.SysGetRobustList, arch.SysMremap:
+		// Robust lists are never read back. mremap fails, so that musl's realloc falls back to a new mapping,
+		// and pthread_getattr_np stops probing the size of the main thread stack.
+		return exec.MipsENOSYS, exec.SysErrorSignal
+	case arch.SysPoll:
+		// No file descriptor has any event: the standard file descriptors are open and never block.
+	}
+	return 0, 0
+}
+
+// syscallSigaltstackDisabled writes the SS_DISABLE flag to the ss_flags of the old stack, as a thread has no signal stack.
+func (m *InstrumentedState) syscallSigaltstackDisabled(oldStack Word) (v0, v1 Word) {
+	flagsAddr := oldStack + exec.SigaltstackFlagsOffset
+	exec.StoreSubWord(m.state.Memory, flagsAddr, 4, exec.SigaltstackDisable, m.memoryTracker)
+	m.handleMemoryUpdate(flagsAddr & arch.AddressMask)
+	return 0, 0
+}
+
+// handleRdhwr emulates rdhwr of the UserLocal hardware register, like the kernel does, by reading the thread pointer.
+func (m *InstrumentedState) handleRdhwr(insn uint32) error {
+	if (insn>>11)&0x1F != exec.HwrUserLocal {
+		return fmt.Errorf("invalid instruction: 0x%08x", insn)
+	}
+	regs := m.state.GetRegistersRef()
+	rtReg := Word((insn >> 16) & 0x1F)
+	return exec.HandleRd(m.state.getCpuRef(), regs, rtReg, regs[register.RegThreadPointer], true)
+}
+
 func (m *InstrumentedState) handleUnrecognizedSyscall(syscallNum Word) {
 	m.Traceback()
 	panic(fmt.Sprintf("unrecognized syscall: %d", syscallNum))
@@ -273,9 +374,18 @@ func (m *InstrumentedState) syscallYield(thread *ThreadState) {
 	v0 := Word(0)
 	v1 := Word(0)
 	exec.HandleSyscallUpdates(&thread.Cpu, &thread.Registers, v0, v1)
+	m.statsTracker.trackYield(thread.ThreadId)
 	m.preemptThread(thread)
 }
 
+// schedQuantum returns the number of steps a thread runs before it is preempted.
+func (m *InstrumentedState) schedQuantum() uint64 {
+	if m.features.SchedQuantum == 0 {
+		return exec.SchedQuantum
+	}
+	return m.features.SchedQuantum
+}
+
 func (m *InstrumentedState) mipsStep() error {
 	err := m.doMipsStep()
 	if err != nil {
@@ -302,32 +412,56 @@ func (m *InstrumentedState) doMipsStep() error {
 
 	if thread.Exited {
 		m.popThread()
+		m.statsTracker.trackThreadExited(thread.ThreadId)
 		m.stackTracker.DropThread(thread.ThreadId)
+		if m.stackGuard != nil {
+			m.stackGuard.dropThread(thread.ThreadId)
+		}
 		return nil
 	}
 
-	if m.state.StepsSinceLastContextSwitch >= exec.SchedQuantum {
+	if quantum := m.schedQuantum(); m.state.StepsSinceLastContextSwitch >= quantum {
 		// Force a context switch as this thread has been active too long
 		if m.state.ThreadCount() > 1 {
 			// Log if we're hitting our context switch limit - only matters if we have > 1 thread
 			if m.log.Enabled(context.Background(), log.LevelTrace) {
-				msg := fmt.Sprintf("Thread has reached maximum execution steps (%v) - preempting.", exec.SchedQuantum)
+				msg := fmt.Sprintf("Thread has reached maximum execution steps (%v) - preempting.", quantum)
 				m.log.Trace(msg, "threadId", thread.ThreadId, "threadCount", m.state.ThreadCount(), "pc", thread.Cpu.PC)
 			}
 		}
 		m.preemptThread(thread)
-		m.statsTracker.trackForcedPreemption()
+		m.statsTracker.trackForcedPreemption(thread.ThreadId)
 		return nil
 	}
 	m.state.StepsSinceLastContextSwitch += 1
+	m.statsTracker.trackThreadStep(thread.ThreadId, m.state.GetStep())
 
 	//instruction fetch
 	insn, opcode, fun := exec.GetInstructionDetails(m.state.GetPC(), m.state.Memory)
+	if m.accessTracer != nil {
+		m.accessTracer.ObserveMemAccess(m.state.GetPC())
+	}
+	if m.profiler != nil {
+		m.profiler.trackInstruction(m.state.GetStep(), m.state.GetPC(), insn)
+	}
+
+	if class, ok := exec.FloatingPointClass(insn, opcode, fun); ok {
+		return &FloatingPointError{
+			ThreadId: thread.ThreadId,
+			PC:       m.state.GetPC(),
+			Insn:     insn,
+			Class:    class,
+			Function: m.LookupSymbol(m.state.GetPC()),
+		}
+	}
 
 	// Handle syscall separately
 	// syscall (can read and write)
 	if opcode == 0 && fun == 0xC {
-		return m.handleSyscall()
+		if m.syscallStats == nil && m.syscallObs == nil && m.syscallFaults == nil {
+			return m.handleSyscall()
+		}
+		return m.handleInstrumentedSyscall(thread)
 	}
 
 	// Handle RMW (read-modify-write) ops
@@ -341,13 +475,23 @@ func (m *InstrumentedState) doMipsStep() error {
 		return m.handleRMWOps(insn, opcode)
 	}
 
+	if opcode == exec.OpSpecial3 && fun == exec.FunRdhwr && m.features.SupportMuslRuntime {
+		return m.handleRdhwr(insn)
+	}
+
 	// Exec the rest of the step logic
+	pc := m.state.GetPC()
 	memUpdated, effMemAddr, err := exec.ExecMipsCoreStepLogic(m.state.getCpuRef(), m.state.GetRegistersRef(), m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker, m.features)
 	if err != nil {
 		return err
 	}
 	if memUpdated {
 		m.handleMemoryUpdate(effMemAddr)
+		if m.stackGuard != nil {
+			if err := m.stackGuard.checkWrite(thread, pc, insn, effMemAddr); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,123 @@
+package multithreaded
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestMuslRuntime(t *testing.T) {
+	if arch.IsMips32 {
+		t.Skip("the musl runtime is only supported by 64-bit state versions")
+	}
+	const pc = Word(0x1000)
+	// rdhwr $3, $29
+	const rdhwrUserLocal = uint32(0x7c_03_e8_3b)
+
+	setup := func(t *testing.T, features mipsevm.FeatureToggles) *InstrumentedState {
+		return NewInstrumentedState(CreateEmptyState(), nil, io.Discard, io.Discard, testutil.CreateLogger(), nil, features)
+	}
+	tryStep := func(vm *InstrumentedState, insn uint32) error {
+		thread := vm.state.GetCurrentThread()
+		thread.Cpu.PC = pc
+		thread.Cpu.NextPC = pc + 4
+		testutil.StoreInstruction(vm.state.Memory, pc, insn)
+		_, err := vm.Step(true)
+		return err
+	}
+	step := func(t *testing.T, vm *InstrumentedState, insn uint32) {
+		require.NoError(t, tryStep(vm, insn))
+	}
+	syscall := func(t *testing.T, vm *InstrumentedState, num, a0, a1 Word) (v0, errno Word) {
+		regs := vm.state.GetRegistersRef()
+		regs[register.RegSyscallNum] = num
+		regs[register.RegA0] = a0
+		regs[register.RegA1] = a1
+		step(t, vm, 0x00_00_00_0C)
+		return regs[register.RegSyscallRet1], regs[register.RegSyscallErrno]
+	}
+
+	t.Run("thread pointer", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		tp := Word(0x1234_7000)
+		ret, errno := syscall(t, vm, arch.SysSetThreadArea, tp, 0)
+		require.Zero(t, errno)
+		require.Zero(t, ret)
+		require.Equal(t, tp, vm.state.GetRegistersRef()[register.RegThreadPointer])
+
+		step(t, vm, rdhwrUserLocal)
+		require.Equal(t, tp, vm.state.GetRegistersRef()[3])
+		require.Equal(t, pc+4, vm.state.GetCurrentThread().Cpu.PC)
+	})
+
+	t.Run("rdhwr of other hardware registers", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		// rdhwr $3, $2
+		require.EqualError(t, tryStep(vm, 0x7c_03_10_3b), "invalid instruction: 0x7c03103b")
+	})
+
+	t.Run("set_tid_address", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		ret, errno := syscall(t, vm, arch.SysSetTidAddress, 0x2000, 0)
+		require.Zero(t, errno)
+		require.Equal(t, vm.state.GetCurrentThread().ThreadId, ret)
+	})
+
+	t.Run("robust lists", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		ret, errno := syscall(t, vm, arch.SysSetRobustList, 0x2000, exec.RobustListHeadSize)
+		require.Zero(t, errno)
+		require.Zero(t, ret)
+		ret, errno = syscall(t, vm, arch.SysSetRobustList, 0x2000, 12)
+		require.Equal(t, exec.SysErrorSignal, errno)
+		require.Equal(t, Word(exec.MipsEINVAL), ret)
+		ret, errno = syscall(t, vm, arch.SysGetRobustList, 0, 0x2000)
+		require.Equal(t, exec.SysErrorSignal, errno)
+		require.Equal(t, Word(exec.MipsENOSYS), ret)
+	})
+
+	t.Run("sigaltstack", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		oldStack := Word(0x2000)
+		vm.state.Memory.SetWord(oldStack+exec.SigaltstackFlagsOffset, ^Word(0))
+		ret, errno := syscall(t, vm, arch.SysSigaltstack, 0, oldStack)
+		require.Zero(t, errno)
+		require.Zero(t, ret)
+		require.Equal(t, Word(exec.SigaltstackDisable)<<32|0xFFFF_FFFF, vm.state.Memory.GetWord(oldStack+exec.SigaltstackFlagsOffset))
+	})
+
+	t.Run("poll and mremap", func(t *testing.T) {
+		vm := setup(t, allFeaturesEnabled())
+		ret, errno := syscall(t, vm, arch.SysPoll, 0x2000, 3)
+		require.Zero(t, errno)
+		require.Zero(t, ret)
+		ret, errno = syscall(t, vm, arch.SysMremap, 0x2000, memory.PageSize)
+		require.Equal(t, exec.SysErrorSignal, errno)
+		require.Equal(t, Word(exec.MipsENOSYS), ret)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		features := allFeaturesEnabled()
+		features.SupportMuslRuntime = false
+		vm := setup(t, features)
+		require.Panics(t, func() { syscall(t, vm, arch.SysSetThreadArea, 0x1234, 0) })
+
+		vm = setup(t, features)
+		require.ErrorContains(t, tryStep(vm, rdhwrUserLocal), "invalid instruction")
+
+		// sigaltstack stays a noop
+		vm = setup(t, features)
+		ret, errno := syscall(t, vm, arch.SysSigaltstack, 0, 0x2000)
+		require.Zero(t, errno)
+		require.Zero(t, ret)
+		require.Zero(t, vm.state.Memory.GetWord(0x2000+exec.SigaltstackFlagsOffset))
+	})
+}
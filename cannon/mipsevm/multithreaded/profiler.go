@@ -0,0 +1,47 @@
+package multithreaded
+
+import (
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// profileTracker counts the instructions executed at each PC, at every interval steps.
+type profileTracker struct {
+	interval uint64
+	counts   map[Word]*mipsevm.InstructionCount
+}
+
+func newProfileTracker(interval uint64) *profileTracker {
+	if interval == 0 {
+		panic("profile interval must not be zero")
+	}
+	return &profileTracker{
+		interval: interval,
+		counts:   make(map[Word]*mipsevm.InstructionCount),
+	}
+}
+
+func (p *profileTracker) trackInstruction(step uint64, pc Word, insn uint32) {
+	if step%p.interval != 0 {
+		return
+	}
+	c, ok := p.counts[pc]
+	if !ok {
+		c = &mipsevm.InstructionCount{PC: pc, Insn: insn}
+		p.counts[pc] = c
+	}
+	c.Samples += 1
+}
+
+func (p *profileTracker) profile(lookupSymbol func(addr Word) string) *mipsevm.Profile {
+	out := &mipsevm.Profile{
+		Interval:     p.interval,
+		Instructions: make([]mipsevm.InstructionCount, 0, len(p.counts)),
+	}
+	for _, c := range p.counts {
+		c := *c
+		c.Function = lookupSymbol(c.PC)
+		out.Instructions = append(out.Instructions, c)
+	}
+	out.SortInstructions()
+	return out
+}
@@ -0,0 +1,49 @@
+package multithreaded
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestProfileTracker(t *testing.T) {
+	lookup := func(addr Word) string {
+		return fmt.Sprintf("fn%d", addr/0x100)
+	}
+
+	t.Run("exact", func(t *testing.T) {
+		tracker := newProfileTracker(1)
+		tracker.trackInstruction(1, 0x100, 0xaa)
+		tracker.trackInstruction(2, 0x104, 0xbb)
+		tracker.trackInstruction(3, 0x200, 0xcc)
+		tracker.trackInstruction(4, 0x104, 0xbb)
+		expected := &mipsevm.Profile{
+			Interval: 1,
+			Instructions: []mipsevm.InstructionCount{
+				{PC: 0x104, Insn: 0xbb, Function: "fn1", Samples: 2},
+				{PC: 0x100, Insn: 0xaa, Function: "fn1", Samples: 1},
+				{PC: 0x200, Insn: 0xcc, Function: "fn2", Samples: 1},
+			},
+		}
+		require.Equal(t, expected, tracker.profile(lookup))
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		tracker := newProfileTracker(2)
+		for step := uint64(1); step <= 6; step++ {
+			tracker.trackInstruction(step, Word(0x100+4*step), 0)
+		}
+		prof := tracker.profile(lookup)
+		require.Equal(t, uint64(3), prof.TotalSamples())
+		require.Equal(t, []Word{0x108, 0x110, 0x118}, []Word{prof.Instructions[0].PC, prof.Instructions[1].PC, prof.Instructions[2].PC})
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+		require.Panics(t, func() {
+			newProfileTracker(0)
+		})
+	})
+}
@@ -0,0 +1,231 @@
+package multithreaded
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+)
+
+// syscallInsn is the encoding of the syscall instruction.
+const syscallInsn = 0xC
+
+// ReportConfig configures the state report of WriteReport.
+type ReportConfig struct {
+	// Meta resolves the PCs and return addresses of the threads to symbols, if set.
+	Meta mipsevm.Metadata
+	// Prev is an earlier state of the same run, to report the memory written since, if set.
+	Prev *State
+	// MaxWritesPerPage is the maximum number of written words listed per page, all of them if zero.
+	MaxWritesPerPage int
+}
+
+// WriteReport writes a human-readable report of the state: the scalar fields, the thread stacks,
+// the registers of each thread, the futex each thread yielded on, the LL reservation,
+// and the memory written since cfg.Prev.
+//
+// The state does not record futex waits. A thread is reported as waiting on a futex if it was preempted
+// right after a futex wait syscall, as the arguments of the syscall are still in its registers.
+func WriteReport(w io.Writer, s *State, cfg ReportConfig) error {
+	r := &reportWriter{w: w, meta: cfg.Meta}
+	r.printf("Step %d (%d steps since the last context switch)\n", s.Step, s.StepsSinceLastContextSwitch)
+	if s.Exited {
+		r.printf("Exited with code %d\n", s.ExitCode)
+	} else {
+		r.printf("Running\n")
+	}
+	r.printf("Heap: 0x%x\n", s.Heap)
+	r.printf("Preimage: key %s, offset 0x%x\n", s.PreimageKey, s.PreimageOffset)
+	r.printf("LL reservation: %s\n", llReservation(s))
+	r.printf("Next thread id: %d\n", s.NextThreadId)
+
+	r.printf("\nThread stacks (top first, > marks the current thread):\n")
+	r.threadStacks(s)
+
+	for _, pos := range sortedThreadPositions(s) {
+		r.printf("\n")
+		r.thread(s, pos)
+	}
+
+	if cfg.Prev != nil {
+		r.printf("\nMemory written since step %d:\n", cfg.Prev.Step)
+		r.memoryWrites(DiffStates(cfg.Prev, s, cfg.MaxWritesPerPage).Pages)
+	}
+	return r.err
+}
+
+type reportWriter struct {
+	w    io.Writer
+	meta mipsevm.Metadata
+	err  error
+}
+
+func (r *reportWriter) printf(format string, args ...any) {
+	if r.err != nil {
+		return
+	}
+	_, r.err = fmt.Fprintf(r.w, format, args...)
+}
+
+// symbol returns the symbol of the address, formatted to follow the address, or empty if there is none.
+func (r *reportWriter) symbol(addr Word) string {
+	if r.meta == nil {
+		return ""
+	}
+	// Addresses outside of the symbols resolve to pseudo-symbols like "!unknown"
+	if sym := r.meta.LookupSymbol(addr); sym != "" && !strings.HasPrefix(sym, "!") {
+		return " <" + sym + ">"
+	}
+	return ""
+}
+
+// threadStacks draws the left and right thread stacks side by side, with the tops of the stacks aligned.
+func (r *reportWriter) threadStacks(s *State) {
+	current := currentThread(s)
+	column := func(name string, stack []*ThreadState, active bool) []string {
+		header := name
+		if active {
+			header += " (active)"
+		}
+		out := []string{header}
+		for i := len(stack) - 1; i >= 0; i-- {
+			t := stack[i]
+			marker := " "
+			if t == current {
+				marker = ">"
+			}
+			line := fmt.Sprintf("%s thread %d", marker, t.ThreadId)
+			if t.Exited {
+				line += fmt.Sprintf(" (exited %d)", t.ExitCode)
+			}
+			out = append(out, line)
+		}
+		if len(stack) == 0 {
+			out = append(out, "  (empty)")
+		}
+		return out
+	}
+	left := column("left", s.LeftThreadStack, !s.TraverseRight)
+	right := column("right", s.RightThreadStack, s.TraverseRight)
+	width := 0
+	for _, line := range left {
+		width = max(width, len(line))
+	}
+	for i := 0; i < max(len(left), len(right)); i++ {
+		var l, rt string
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			rt = right[i]
+		}
+		r.printf("  %s%s   %s\n", l, strings.Repeat(" ", width-len(l)), strings.TrimRight(rt, " "))
+	}
+}
+
+func (r *reportWriter) thread(s *State, pos threadPosition) {
+	t := pos.thread
+	r.printf("Thread %d at %s", t.ThreadId, pos.position)
+	if t.Exited {
+		r.printf(", exited with code %d", t.ExitCode)
+	}
+	r.printf("\n")
+	insn, _, _ := exec.GetInstructionDetails(t.Cpu.PC&^0x3, s.Memory)
+	r.printf("  pc:     0x%x%s: %s\n", t.Cpu.PC, r.symbol(t.Cpu.PC), disasm.Disassemble(uint64(t.Cpu.PC), insn))
+	r.printf("  nextPC: 0x%x\n", t.Cpu.NextPC)
+	ra := t.Registers[register.RegRA]
+	r.printf("  ra:     0x%x%s\n", ra, r.symbol(ra))
+	r.printf("  lo: 0x%x, hi: 0x%x\n", t.Cpu.LO, t.Cpu.HI)
+	if addr, val, ok := yieldedFutexWait(s, t); ok {
+		r.printf("  futex:  yielded waiting on 0x%x for value 0x%x, now 0x%x\n", addr, val, futexValue(s, addr))
+	}
+	r.printf("  registers:\n")
+	for i := 0; i < len(t.Registers); i += 4 {
+		r.printf("   ")
+		for j := i; j < i+4; j++ {
+			r.printf(" %5s 0x%0*x", "$"+disasm.RegNames[j], arch.WordSizeBytes*2, t.Registers[j])
+		}
+		r.printf("\n")
+	}
+}
+
+func (r *reportWriter) memoryWrites(pages []PageDiff) {
+	if len(pages) == 0 {
+		r.printf("  (none)\n")
+		return
+	}
+	for _, p := range pages {
+		r.printf("  page 0x%x, %d words written", p.Address, p.DifferingWords)
+		if p.Only == "b" {
+			r.printf(", newly allocated")
+		}
+		r.printf("\n")
+		for _, word := range p.Words {
+			r.printf("    0x%x: 0x%x -> 0x%x\n", word.Address, word.A, word.B)
+		}
+		if omitted := p.DifferingWords - len(p.Words); omitted > 0 {
+			r.printf("    ... %d more\n", omitted)
+		}
+	}
+}
+
+func llReservation(s *State) string {
+	switch s.LLReservationStatus {
+	case LLStatusNone:
+		return "none"
+	case LLStatusActive32bit:
+		return fmt.Sprintf("32-bit at 0x%x, held by thread %d", s.LLAddress, s.LLOwnerThread)
+	case LLStatusActive64bit:
+		return fmt.Sprintf("64-bit at 0x%x, held by thread %d", s.LLAddress, s.LLOwnerThread)
+	default:
+		return fmt.Sprintf("unknown status %d at 0x%x, held by thread %d", s.LLReservationStatus, s.LLAddress, s.LLOwnerThread)
+	}
+}
+
+func currentThread(s *State) *ThreadState {
+	if active := s.getActiveThreadStack(); len(active) > 0 {
+		return active[len(active)-1]
+	}
+	return nil
+}
+
+// sortedThreadPositions returns the threads of the state in the order of the report:
+// the left stack and then the right stack, each from the top.
+func sortedThreadPositions(s *State) []threadPosition {
+	positions := threadPositions(s)
+	var out []threadPosition
+	for _, stack := range [][]*ThreadState{s.LeftThreadStack, s.RightThreadStack} {
+		for i := len(stack) - 1; i >= 0; i-- {
+			out = append(out, positions[stack[i].ThreadId])
+		}
+	}
+	return out
+}
+
+// yieldedFutexWait returns the futex address and the expected value of the futex wait syscall that the thread
+// yielded on, if the previous instruction of the thread is a syscall with the arguments of a futex wait.
+// The syscall number is overwritten by the result, the result of a yield is 0.
+func yieldedFutexWait(s *State, t *ThreadState) (addr Word, val uint32, ok bool) {
+	if t.Exited || t.Cpu.PC&0x3 != 0 || t.Cpu.PC < 4 {
+		return 0, 0, false
+	}
+	insn, _, _ := exec.GetInstructionDetails(t.Cpu.PC-4, s.Memory)
+	if insn != syscallInsn || t.Cpu.NextPC != t.Cpu.PC+4 {
+		return 0, 0, false
+	}
+	if t.Registers[register.RegSyscallRet1] != 0 || t.Registers[register.RegSyscallErrno] != 0 ||
+		t.Registers[register.RegSyscallParam2] != exec.FutexWaitPrivate {
+		return 0, 0, false
+	}
+	return t.Registers[register.RegSyscallParam1] & ^Word(0x3), uint32(t.Registers[register.RegSyscallParam3]), true
+}
+
+func futexValue(s *State, addr Word) uint32 {
+	word := s.Memory.GetWord(addr & arch.AddressMask)
+	return uint32(exec.SelectSubWord(addr, word, 4, false))
+}
@@ -0,0 +1,70 @@
+package multithreaded
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestWriteReport(t *testing.T) {
+	prev := CreateInitialState(0x1000, 0x40000)
+	prev.Step = 100
+	state := CreateInitialState(0x1000, 0x40000)
+	state.Step = 150
+	state.StepsSinceLastContextSwitch = 7
+	state.LLReservationStatus = LLStatusActive64bit
+	state.LLAddress = 0x2000
+	state.LLOwnerThread = 0
+	testutil.StoreInstruction(state.Memory, 0x1000, 0x24020001) // addiu $v0, $zero, 1
+
+	// Thread 1 yielded in a futex wait on 0x3000 for value 2, which is now 3
+	waiting := CreateEmptyThread()
+	waiting.ThreadId = 1
+	testutil.StoreInstruction(state.Memory, 0x1100, syscallInsn)
+	waiting.Cpu.PC = 0x1104
+	waiting.Cpu.NextPC = 0x1108
+	waiting.Registers[register.RegSyscallParam1] = 0x3000
+	waiting.Registers[register.RegSyscallParam2] = exec.FutexWaitPrivate
+	waiting.Registers[register.RegSyscallParam3] = 2
+	waiting.Registers[register.RegRA] = 0x1004
+	require.NoError(t, state.Memory.SetMemoryRange(0x3000, bytes.NewReader(testutil.Uint32ToBytes(3))))
+	exited := CreateEmptyThread()
+	exited.ThreadId = 2
+	exited.Exited = true
+	exited.ExitCode = 1
+	state.RightThreadStack = []*ThreadState{exited, waiting}
+	state.NextThreadId = 3
+
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.main", Start: 0x1000, Size: 0x100},
+		{Name: "runtime.futex", Start: 0x1100, Size: 0x100},
+	}}
+	var out bytes.Buffer
+	require.NoError(t, WriteReport(&out, state, ReportConfig{Meta: meta, Prev: prev, MaxWritesPerPage: 1}))
+	report := out.String()
+
+	require.Contains(t, report, "Step 150 (7 steps since the last context switch)\nRunning\n")
+	require.Contains(t, report, "LL reservation: 64-bit at 0x2000, held by thread 0\n")
+	require.Contains(t, report, "  left (active)   right\n"+
+		"  > thread 0        thread 1\n"+
+		"                    thread 2 (exited 1)\n")
+	require.Contains(t, report, "Thread 0 at left[0] (current)\n  pc:     0x1000 <main.main>: addiu $v0, $zero, 1\n")
+	require.Contains(t, report, "Thread 1 at right[1]\n  pc:     0x1104 <runtime.futex>: ")
+	require.Contains(t, report, "  ra:     0x1004 <main.main>\n")
+	require.Contains(t, report, "  futex:  yielded waiting on 0x3000 for value 0x2, now 0x3\n")
+	require.Contains(t, report, "Thread 2 at right[0], exited with code 1\n")
+	require.NotContains(t, report, "yielded waiting on 0x0")
+	require.Contains(t, report, "  ra:     0x0\n")
+	require.Contains(t, report, "      $a0 0x"+strings.Repeat("0", arch.WordSizeBytes*2-4)+"3000 ")
+	require.Contains(t, report, "Memory written since step 100:\n"+
+		"  page 0x1000, 2 words written, newly allocated\n    0x1000: 0x0 -> ")
+	require.Contains(t, report, "    ... 1 more\n  page 0x3000, 1 words written, newly allocated\n")
+}
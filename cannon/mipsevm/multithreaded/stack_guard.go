@@ -0,0 +1,90 @@
+package multithreaded
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+)
+
+// StackOverflowError is returned by Step when a thread writes into the guard region below its stack.
+type StackOverflowError struct {
+	ThreadId Word
+	PC       Word
+	// Insn is the store instruction that wrote below the stack.
+	Insn uint32
+	SP   Word
+	Addr Word
+	// StackBottom is the lowest address of the stack of the thread.
+	StackBottom Word
+}
+
+func (e *StackOverflowError) Error() string {
+	return fmt.Sprintf("stack overflow in thread %d at pc 0x%x (%s): write to 0x%x is %d bytes below the stack bottom 0x%x (sp=0x%x)",
+		e.ThreadId, e.PC, disasm.Disassemble(uint64(e.PC), e.Insn), e.Addr, e.StackBottom-e.Addr, e.StackBottom, e.SP)
+}
+
+type stackBounds struct {
+	top    Word
+	bottom Word
+}
+
+// stackGuard tracks the stack bounds of every thread, and checks the stack pointer relative writes of the thread against them.
+// It is a debugging aid only: it does not change the state transition, it only stops execution with an actionable error.
+//
+// The check is a heuristic. Goroutines run on stacks allocated on the heap, so only writes relative to $sp
+// that land in the guard region of the current thread, while $sp itself is within the stack or the guard region, are reported.
+type stackGuard struct {
+	cfg    mipsevm.StackGuardConfig
+	stacks map[Word]stackBounds
+}
+
+func newStackGuard(cfg mipsevm.StackGuardConfig) *stackGuard {
+	// The stack of the initial thread is set up by program.PatchStack.
+	initThreadId := Word(0)
+	return &stackGuard{
+		cfg: cfg,
+		stacks: map[Word]stackBounds{
+			initThreadId: {top: arch.HighMemoryStart, bottom: arch.HighMemoryStart - cfg.MainStackSize},
+		},
+	}
+}
+
+// trackThread records the stack of a thread created with clone.
+// Threads that already existed when the guard was enabled have unknown stacks and are not checked.
+func (g *stackGuard) trackThread(threadId Word, sp Word) {
+	g.stacks[threadId] = stackBounds{top: sp, bottom: sp - g.cfg.ThreadStackSize}
+}
+
+func (g *stackGuard) dropThread(threadId Word) {
+	delete(g.stacks, threadId)
+}
+
+// checkWrite checks a memory write of the instruction executed by the thread at the given pc.
+func (g *stackGuard) checkWrite(thread *ThreadState, pc Word, insn uint32, addr Word) error {
+	if disasm.Decode(insn).Rs != register.RegSP {
+		return nil
+	}
+	bounds, ok := g.stacks[thread.ThreadId]
+	if !ok {
+		return nil
+	}
+	guardBottom := bounds.bottom - g.cfg.GuardSize
+	sp := thread.Registers[register.RegSP]
+	if sp < guardBottom || sp > bounds.top {
+		return nil
+	}
+	if addr < guardBottom || addr >= bounds.bottom {
+		return nil
+	}
+	return &StackOverflowError{
+		ThreadId:    thread.ThreadId,
+		PC:          pc,
+		Insn:        insn,
+		SP:          sp,
+		Addr:        addr,
+		StackBottom: bounds.bottom,
+	}
+}
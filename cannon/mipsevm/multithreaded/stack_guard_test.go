@@ -0,0 +1,77 @@
+package multithreaded
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestStackGuard(t *testing.T) {
+	cfg := mipsevm.DefaultStackGuardConfig()
+	mainBottom := arch.HighMemoryStart - cfg.MainStackSize
+	// sw $a1, offset(base)
+	sw := func(base uint32, offset int16) uint32 {
+		return 0x2B<<26 | base<<21 | register.RegA1<<16 | uint32(uint16(offset))
+	}
+	cases := []struct {
+		name     string
+		sp       Word
+		insn     uint32
+		overflow bool
+	}{
+		{name: "write within stack", sp: mainBottom + 0x100, insn: sw(register.RegSP, -0x10)},
+		{name: "write into guard", sp: mainBottom + 0x8, insn: sw(register.RegSP, -0x10), overflow: true},
+		{name: "sp within guard", sp: mainBottom - 0x100, insn: sw(register.RegSP, 0x8), overflow: true},
+		{name: "write below guard", sp: mainBottom - cfg.GuardSize - 0x100, insn: sw(register.RegSP, 0x8)},
+		{name: "write not relative to sp", sp: mainBottom + 0x100, insn: sw(register.RegA0, 0x0)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			state := CreateEmptyState()
+			pc := Word(0x1000)
+			state.GetCurrentThread().Cpu.PC = pc
+			state.GetCurrentThread().Cpu.NextPC = pc + 4
+			state.GetRegistersRef()[register.RegSP] = c.sp
+			state.GetRegistersRef()[register.RegA0] = mainBottom - 0x10
+			testutil.StoreInstruction(state.Memory, pc, c.insn)
+			vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger(), nil, allFeaturesEnabled())
+			vm.EnableStackGuard(cfg)
+
+			_, err := vm.Step(false)
+			if !c.overflow {
+				require.NoError(t, err)
+				return
+			}
+			var overflow *StackOverflowError
+			require.ErrorAs(t, err, &overflow)
+			require.Equal(t, Word(0), overflow.ThreadId)
+			require.Equal(t, pc, overflow.PC)
+			require.Equal(t, c.insn, overflow.Insn)
+			require.Equal(t, mainBottom, overflow.StackBottom)
+			require.ErrorContains(t, err, "stack overflow in thread 0 at pc 0x1000 (sw $a1, ")
+		})
+	}
+}
+
+func TestStackGuard_Threads(t *testing.T) {
+	cfg := mipsevm.DefaultStackGuardConfig()
+	g := newStackGuard(cfg)
+	top := Word(0x10_0000)
+	g.trackThread(1, top)
+	thread := &ThreadState{ThreadId: 1}
+	thread.Registers[register.RegSP] = top - cfg.ThreadStackSize
+	insn := uint32(0x2B<<26 | register.RegSP<<21 | register.RegA1<<16)
+
+	require.Error(t, g.checkWrite(thread, 0x1000, insn, top-cfg.ThreadStackSize-8))
+	require.NoError(t, g.checkWrite(thread, 0x1000, insn, top-cfg.ThreadStackSize))
+
+	// Threads with unknown stacks are not checked
+	g.dropThread(1)
+	require.NoError(t, g.checkWrite(thread, 0x1000, insn, top-cfg.ThreadStackSize-8))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -103,6 +104,26 @@ func CreateInitialState(pc, heapStart Word) *State {
 	return state
 }
 
+// Copy returns a copy of the state, to branch its execution.
+// The memory is copied copy-on-write, so the copy is cheap and the states share the pages that neither modifies.
+func (s *State) Copy() *State {
+	out := *s
+	out.Memory = s.Memory.Copy()
+	out.LeftThreadStack = copyThreadStack(s.LeftThreadStack)
+	out.RightThreadStack = copyThreadStack(s.RightThreadStack)
+	out.LastHint = slices.Clone(s.LastHint)
+	return &out
+}
+
+func copyThreadStack(stack []*ThreadState) []*ThreadState {
+	out := make([]*ThreadState, len(stack))
+	for i, t := range stack {
+		thread := *t
+		out[i] = &thread
+	}
+	return out
+}
+
 func (s *State) CreateVM(logger log.Logger, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, meta mipsevm.Metadata, features mipsevm.FeatureToggles) mipsevm.FPVM {
 	logger.Info("Using cannon multithreaded VM", "is32", arch.IsMips32)
 	return NewInstrumentedState(s, po, stdOut, stdErr, logger, meta, features)
@@ -274,6 +274,37 @@ func TestSerializeStateRoundTrip(t *testing.T) {
 	require.Equal(t, state, state2, "must roundtrip state")
 }
 
+func TestState_Copy(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x20000)
+	state.Memory.SetWord(0x1000, 0xAA)
+	state.Memory.SetWord(0x8000, 0xBB)
+	state.RightThreadStack = []*ThreadState{{ThreadId: 7, Cpu: mipsevm.CpuScalars{PC: 0x2000, NextPC: 0x2004}}}
+	state.LastHint = hexutil.Bytes{0x01, 0x02}
+	witness, hash := state.EncodeWitness()
+
+	cpy := state.Copy()
+	cpyWitness, cpyHash := cpy.EncodeWitness()
+	require.Equal(t, witness, cpyWitness)
+	require.Equal(t, hash, cpyHash)
+	require.Equal(t, state.LastHint, cpy.LastHint)
+	require.Equal(t, 2, cpy.Memory.SharedPageCount())
+
+	// the branches of the execution are independent
+	cpy.GetCurrentThread().Registers[2] = 0xCC
+	cpy.RightThreadStack[0].Cpu.PC = 0x3000
+	cpy.LeftThreadStack = append(cpy.LeftThreadStack, CreateEmptyThread())
+	cpy.Memory.SetWord(0x8000, 0xDD)
+	cpy.LastHint[0] = 0xFF
+	cpy.Step++
+	_, hash2 := state.EncodeWitness()
+	require.Equal(t, hash, hash2)
+	require.Equal(t, Word(0xBB), state.Memory.GetWord(0x8000))
+	require.Equal(t, Word(0x2000), state.RightThreadStack[0].Cpu.PC)
+	require.Len(t, state.LeftThreadStack, 1)
+	require.Equal(t, hexutil.Bytes{0x01, 0x02}, state.LastHint)
+	require.Equal(t, 1, cpy.Memory.SharedPageCount(), "only the written page is cloned")
+}
+
 func TestState_EmptyThreadsRoot(t *testing.T) {
 	data := [64]byte{}
 	expectedEmptyRoot := crypto.Keccak256Hash(data[:])
@@ -1,6 +1,10 @@
 package multithreaded
 
 import (
+	"cmp"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	lru "github.com/hashicorp/golang-lru/v2/simplelru"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
@@ -12,8 +16,12 @@ type StatsTracker interface {
 	trackSCSuccess(threadId Word, step uint64)
 	trackSCFailure(threadId Word, step uint64)
 	trackReservationInvalidation()
-	trackForcedPreemption()
+	trackForcedPreemption(tid Word)
 	trackThreadActivated(tid Word, step uint64)
+	trackThreadStep(tid Word, step uint64)
+	trackYield(tid Word)
+	trackFutexWait(tid Word, addr Word)
+	trackThreadExited(tid Word)
 	populateDebugInfo(debugInfo *mipsevm.DebugInfo)
 }
 
@@ -28,8 +36,12 @@ func (s *noopStatsTracker) trackLL(threadId Word, step uint64)             {}
 func (s *noopStatsTracker) trackSCSuccess(threadId Word, step uint64)      {}
 func (s *noopStatsTracker) trackSCFailure(threadId Word, step uint64)      {}
 func (s *noopStatsTracker) trackReservationInvalidation()                  {}
-func (s *noopStatsTracker) trackForcedPreemption()                         {}
+func (s *noopStatsTracker) trackForcedPreemption(tid Word)                 {}
 func (s *noopStatsTracker) trackThreadActivated(tid Word, step uint64)     {}
+func (s *noopStatsTracker) trackThreadStep(tid Word, step uint64)          {}
+func (s *noopStatsTracker) trackYield(tid Word)                            {}
+func (s *noopStatsTracker) trackFutexWait(tid Word, addr Word)             {}
+func (s *noopStatsTracker) trackThreadExited(tid Word)                     {}
 func (s *noopStatsTracker) populateDebugInfo(debugInfo *mipsevm.DebugInfo) {}
 
 var _ StatsTracker = (*noopStatsTracker)(nil)
@@ -48,6 +60,8 @@ type statsTrackerImpl struct {
 	reservationInvalidationCount uint64
 	forcedPreemptionCount        uint64
 	idleStepCountThread0         uint64
+	// Scheduling stats of each thread
+	threads map[Word]*mipsevm.ThreadInfo
 }
 
 func (s *statsTrackerImpl) populateDebugInfo(debugInfo *mipsevm.DebugInfo) {
@@ -57,6 +71,12 @@ func (s *statsTrackerImpl) populateDebugInfo(debugInfo *mipsevm.DebugInfo) {
 	debugInfo.ReservationInvalidationCount = s.reservationInvalidationCount
 	debugInfo.ForcedPreemptionCount = s.forcedPreemptionCount
 	debugInfo.IdleStepCountThread0 = s.idleStepCountThread0
+	for _, info := range s.threads {
+		debugInfo.Threads = append(debugInfo.Threads, *info)
+	}
+	slices.SortFunc(debugInfo.Threads, func(a, b mipsevm.ThreadInfo) int {
+		return cmp.Compare(a.ThreadId, b.ThreadId)
+	})
 }
 
 func (s *statsTrackerImpl) trackLL(threadId Word, step uint64) {
@@ -89,8 +109,38 @@ func (s *statsTrackerImpl) trackReservationInvalidation() {
 	s.reservationInvalidationCount += 1
 }
 
-func (s *statsTrackerImpl) trackForcedPreemption() {
+func (s *statsTrackerImpl) trackForcedPreemption(tid Word) {
 	s.forcedPreemptionCount += 1
+	s.thread(tid).Preemptions += 1
+}
+
+func (s *statsTrackerImpl) trackThreadStep(tid Word, step uint64) {
+	info := s.thread(tid)
+	info.Steps += 1
+	info.LastStep = step
+}
+
+func (s *statsTrackerImpl) trackYield(tid Word) {
+	s.thread(tid).Yields += 1
+}
+
+func (s *statsTrackerImpl) trackFutexWait(tid Word, addr Word) {
+	info := s.thread(tid)
+	info.FutexWaits += 1
+	info.LastFutexAddr = hexutil.Uint64(addr)
+}
+
+func (s *statsTrackerImpl) trackThreadExited(tid Word) {
+	s.thread(tid).Exited = true
+}
+
+func (s *statsTrackerImpl) thread(tid Word) *mipsevm.ThreadInfo {
+	info, ok := s.threads[tid]
+	if !ok {
+		info = &mipsevm.ThreadInfo{ThreadId: tid}
+		s.threads[tid] = info
+	}
+	return info
 }
 
 func (s *statsTrackerImpl) trackThreadActivated(tid Word, step uint64) {
@@ -117,6 +167,7 @@ func newStatsTracker(cacheSize int) StatsTracker {
 
 	return &statsTrackerImpl{
 		lastLLStepByThread: llStepCache,
+		threads:            make(map[Word]*mipsevm.ThreadInfo),
 	}
 }
 
@@ -61,13 +61,16 @@ func TestStatsTracker(t *testing.T) {
 		},
 		{
 			name:       "Force preemption",
-			operations: []Operation{forcePreempt()},
-			expected:   &mipsevm.DebugInfo{ForcedPreemptionCount: 1},
+			operations: []Operation{forcePreempt(1)},
+			expected:   &mipsevm.DebugInfo{ForcedPreemptionCount: 1, Threads: []mipsevm.ThreadInfo{{ThreadId: 1, Preemptions: 1}}},
 		},
 		{
 			name:       "Force preemption multiple times",
-			operations: []Operation{forcePreempt(), forcePreempt()},
-			expected:   &mipsevm.DebugInfo{ForcedPreemptionCount: 2},
+			operations: []Operation{forcePreempt(1), forcePreempt(0)},
+			expected: &mipsevm.DebugInfo{ForcedPreemptionCount: 2, Threads: []mipsevm.ThreadInfo{
+				{ThreadId: 0, Preemptions: 1},
+				{ThreadId: 1, Preemptions: 1},
+			}},
 		},
 		{
 			name:       "Preempt thread 0 for thread 0",
@@ -79,6 +82,14 @@ func TestStatsTracker(t *testing.T) {
 			operations: []Operation{activateThread(1, 10), activateThread(0, 20), activateThread(0, 21), activateThread(1, 22), activateThread(0, 25)},
 			expected:   &mipsevm.DebugInfo{IdleStepCountThread0: 13},
 		},
+		{
+			name:       "Thread scheduling",
+			operations: []Operation{threadStep(0, 1), threadStep(0, 2), yield(0), threadStep(1, 3), futexWait(1, 0x1000), yield(1), threadStep(0, 4), forcePreempt(0), threadExited(1)},
+			expected: &mipsevm.DebugInfo{ForcedPreemptionCount: 1, Threads: []mipsevm.ThreadInfo{
+				{ThreadId: 0, Steps: 3, Preemptions: 1, Yields: 1, LastStep: 4},
+				{ThreadId: 1, Exited: true, Steps: 1, Yields: 1, FutexWaits: 1, LastFutexAddr: 0x1000, LastStep: 3},
+			}},
+		},
 	}
 
 	for _, c := range cases {
@@ -122,9 +133,33 @@ func invalidateReservation() Operation {
 	}
 }
 
-func forcePreempt() Operation {
+func forcePreempt(tid Word) Operation {
+	return func(tracker StatsTracker) {
+		tracker.trackForcedPreemption(tid)
+	}
+}
+
+func threadStep(tid Word, step uint64) Operation {
+	return func(tracker StatsTracker) {
+		tracker.trackThreadStep(tid, step)
+	}
+}
+
+func yield(tid Word) Operation {
+	return func(tracker StatsTracker) {
+		tracker.trackYield(tid)
+	}
+}
+
+func futexWait(tid Word, addr Word) Operation {
+	return func(tracker StatsTracker) {
+		tracker.trackFutexWait(tid, addr)
+	}
+}
+
+func threadExited(tid Word) Operation {
 	return func(tracker StatsTracker) {
-		tracker.trackForcedPreemption()
+		tracker.trackThreadExited(tid)
 	}
 }
 
@@ -0,0 +1,59 @@
+package multithreaded
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// syscallStatsTracker aggregates the frequency and host latency of each syscall executed by the guest.
+type syscallStatsTracker struct {
+	stats map[Word]*mipsevm.SyscallStat
+}
+
+func newSyscallStatsTracker() *syscallStatsTracker {
+	return &syscallStatsTracker{
+		stats: make(map[Word]*mipsevm.SyscallStat),
+	}
+}
+
+func (s *syscallStatsTracker) trackSyscall(syscallNum Word, step uint64, elapsed time.Duration) {
+	stat, ok := s.stats[syscallNum]
+	if !ok {
+		stat = &mipsevm.SyscallStat{
+			SyscallNum: syscallNum,
+			Name:       arch.SyscallName(syscallNum),
+			FirstStep:  step,
+		}
+		s.stats[syscallNum] = stat
+	}
+	ns := uint64(elapsed.Nanoseconds())
+	stat.Count += 1
+	stat.LastStep = step
+	stat.TotalTimeNs += ns
+	if ns > stat.MaxTimeNs {
+		stat.MaxTimeNs = ns
+	}
+}
+
+func (s *syscallStatsTracker) syscallStats(totalSteps uint64) *mipsevm.SyscallStats {
+	out := &mipsevm.SyscallStats{
+		TotalSteps: totalSteps,
+		Syscalls:   make([]mipsevm.SyscallStat, 0, len(s.stats)),
+	}
+	for _, stat := range s.stats {
+		out.TotalSyscalls += stat.Count
+		out.TotalTimeNs += stat.TotalTimeNs
+		out.Syscalls = append(out.Syscalls, *stat)
+	}
+	slices.SortFunc(out.Syscalls, func(a, b mipsevm.SyscallStat) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SyscallNum, b.SyscallNum)
+	})
+	return out
+}
@@ -0,0 +1,38 @@
+package multithreaded
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestSyscallStatsTracker(t *testing.T) {
+	tracker := newSyscallStatsTracker()
+	tracker.trackSyscall(arch.SysFutex, 10, 5*time.Nanosecond)
+	tracker.trackSyscall(arch.SysClockGetTime, 12, 1*time.Nanosecond)
+	tracker.trackSyscall(arch.SysClockGetTime, 20, 3*time.Nanosecond)
+	tracker.trackSyscall(arch.SysClockGetTime, 25, 2*time.Nanosecond)
+	tracker.trackSyscall(arch.SysFutex, 30, 7*time.Nanosecond)
+	tracker.trackSyscall(arch.SysRead, 31, 100*time.Nanosecond)
+
+	expected := &mipsevm.SyscallStats{
+		TotalSteps:    40,
+		TotalSyscalls: 6,
+		TotalTimeNs:   118,
+		Syscalls: []mipsevm.SyscallStat{
+			{SyscallNum: arch.SysClockGetTime, Name: "clock_gettime", Count: 3, FirstStep: 12, LastStep: 25, TotalTimeNs: 6, MaxTimeNs: 3},
+			{SyscallNum: arch.SysFutex, Name: "futex", Count: 2, FirstStep: 10, LastStep: 30, TotalTimeNs: 12, MaxTimeNs: 7},
+			{SyscallNum: arch.SysRead, Name: "read", Count: 1, FirstStep: 31, LastStep: 31, TotalTimeNs: 100, MaxTimeNs: 100},
+		},
+	}
+	require.Equal(t, expected, tracker.syscallStats(40))
+}
+
+func TestSyscallStatsTracker_Empty(t *testing.T) {
+	tracker := newSyscallStatsTracker()
+	require.Equal(t, &mipsevm.SyscallStats{TotalSteps: 5, Syscalls: []mipsevm.SyscallStat{}}, tracker.syscallStats(5))
+}
@@ -0,0 +1,222 @@
+// Package onchain runs steps of the VM on the onchain MIPS VM contracts, in a local go-ethereum EVM.
+package onchain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// ErrUnsupportedPreimage is returned for steps that read a preimage which cannot be loaded into the onchain oracle.
+var ErrUnsupportedPreimage = errors.New("unsupported pre-image")
+
+type Artifacts struct {
+	MIPS   *foundry.Artifact
+	Oracle *foundry.Artifact
+}
+
+type Addresses struct {
+	MIPS         common.Address
+	Oracle       common.Address
+	Sender       common.Address
+	FeeRecipient common.Address
+}
+
+type ContractMetadata struct {
+	Artifacts *Artifacts
+	Addresses *Addresses
+	Version   uint8 // versions.StateVersion can't be used as it causes dependency cycles
+}
+
+// LoadArtifacts loads the MIPS64 and PreimageOracle contracts from a forge artifacts directory,
+// e.g. packages/contracts-bedrock/forge-artifacts.
+func LoadArtifacts(artifactsDir string) (*Artifacts, error) {
+	artifactFS := foundry.OpenArtifactsDir(artifactsDir)
+	mips, err := artifactFS.ReadArtifact("MIPS64.sol", "MIPS64")
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := artifactFS.ReadArtifact("PreimageOracle.sol", "PreimageOracle")
+	if err != nil {
+		return nil, err
+	}
+	return &Artifacts{
+		MIPS:   mips,
+		Oracle: oracle,
+	}, nil
+}
+
+// DefaultAddresses returns the addresses to deploy the contracts at, and to call them from.
+// The MIPS address is replaced by the address it is deployed at.
+func DefaultAddresses() *Addresses {
+	return &Addresses{
+		MIPS:         common.Address{0: 0xff, 19: 1},
+		Oracle:       common.Address{0: 0xff, 19: 2},
+		Sender:       common.Address{0x13, 0x37},
+		FeeRecipient: common.Address{0xaa},
+	}
+}
+
+// NewEVMEnv creates an EVM with an in-memory state, and deploys the contracts to it.
+func NewEVMEnv(contracts *ContractMetadata) (*vm.EVM, *state.StateDB, error) {
+	// Temporary hack until Cancun is activated on mainnet
+	cpy := *params.MainnetChainConfig
+	chainCfg := &cpy // don't modify the global chain config
+	// Activate Cancun for EIP-4844 KZG point evaluation precompile
+	cancunActivation := *chainCfg.ShanghaiTime + 10
+	chainCfg.CancunTime = &cancunActivation
+	offsetBlocks := uint64(1000) // blocks after cancun fork
+	bc := &testChain{
+		config:    chainCfg,
+		startTime: *chainCfg.CancunTime + offsetBlocks*12,
+	}
+	header := bc.GetHeader(common.Hash{}, 17034870+offsetBlocks)
+	db := rawdb.NewMemoryDatabase()
+	statedb := state.NewDatabase(triedb.NewDatabase(db, nil), nil)
+	state, err := state.New(types.EmptyRootHash, statedb)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create memory state db: %w", err)
+	}
+	blockContext := core.NewEVMBlockContext(header, bc, nil, chainCfg, state)
+	vmCfg := vm.Config{}
+
+	env := vm.NewEVM(blockContext, state, chainCfg, vmCfg)
+	// pre-deploy the contracts
+	env.StateDB.SetCode(contracts.Addresses.Oracle, contracts.Artifacts.Oracle.DeployedBytecode.Object)
+
+	var ctorArgs []byte
+	if contracts.Version == 0 { // Old MIPS.sol doesn't specify the state version in the constructor
+		var mipsCtorArgs [32]byte
+		copy(mipsCtorArgs[12:], contracts.Addresses.Oracle[:])
+		ctorArgs = mipsCtorArgs[:]
+	} else {
+		var mipsCtorArgs [64]byte
+		copy(mipsCtorArgs[12:], contracts.Addresses.Oracle[:])
+		vers := uint256.NewInt(uint64(contracts.Version)).Bytes32()
+		copy(mipsCtorArgs[32:], vers[:])
+		ctorArgs = mipsCtorArgs[:]
+	}
+	mipsDeploy := append(bytes.Clone(contracts.Artifacts.MIPS.Bytecode.Object), ctorArgs...)
+	startingGas := uint64(30_000_000)
+	retVal, deployedMipsAddr, leftOverGas, err := env.Create(contracts.Addresses.Sender, mipsDeploy, startingGas, common.U2560)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to deploy MIPS contract. error: '%w'. return value: 0x%x. took %d gas", err, retVal, startingGas-leftOverGas)
+	}
+	contracts.Addresses.MIPS = deployedMipsAddr
+
+	rules := env.ChainConfig().Rules(header.Number, true, header.Time)
+	env.StateDB.Prepare(rules, contracts.Addresses.Sender, contracts.Addresses.FeeRecipient, &contracts.Addresses.MIPS, vm.ActivePrecompiles(rules), nil)
+	return env, state, nil
+}
+
+type testChain struct {
+	config    *params.ChainConfig
+	startTime uint64
+}
+
+func (d *testChain) Engine() consensus.Engine {
+	return ethash.NewFullFaker()
+}
+
+func (d *testChain) Config() *params.ChainConfig {
+	return d.config
+}
+
+func (d *testChain) GetHeader(h common.Hash, n uint64) *types.Header {
+	parentHash := common.Hash{0: 0xff}
+	binary.BigEndian.PutUint64(parentHash[1:], n-1)
+	return &types.Header{
+		ParentHash:      parentHash,
+		UncleHash:       types.EmptyUncleHash,
+		Coinbase:        common.Address{},
+		Root:            common.Hash{},
+		TxHash:          types.EmptyTxsHash,
+		ReceiptHash:     types.EmptyReceiptsHash,
+		Bloom:           types.Bloom{},
+		Difficulty:      big.NewInt(0),
+		Number:          new(big.Int).SetUint64(n),
+		GasLimit:        30_000_000,
+		GasUsed:         0,
+		Time:            d.startTime + n*12,
+		Extra:           nil,
+		MixDigest:       common.Hash{},
+		Nonce:           types.BlockNonce{},
+		BaseFee:         big.NewInt(7),
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+}
+
+// EncodeStepInput encodes the call of the step function of the MIPS contract.
+func EncodeStepInput(wit *mipsevm.StepWitness, localContext mipsevm.LocalContext, mips *foundry.Artifact) ([]byte, error) {
+	return mips.ABI.Pack("step", wit.State, wit.ProofData, localContext)
+}
+
+// EncodePreimageOracleInput encodes the call of the pre-image oracle contract that loads the part of the preimage
+// that is read by a step. Precompile preimages are loaded with their input, as returned by the localOracle.
+func EncodePreimageOracleInput(oracle *foundry.Artifact, localOracle mipsevm.PreimageOracle, preimageKey [32]byte, preimageValue []byte, preimageOffset arch.Word, localContext mipsevm.LocalContext) ([]byte, error) {
+	if preimageKey == ([32]byte{}) {
+		return nil, errors.New("cannot encode pre-image oracle input, witness has no pre-image to proof")
+	}
+
+	switch preimage.KeyType(preimageKey[0]) {
+	case preimage.LocalKeyType:
+		if len(preimageValue) > 32+8 {
+			return nil, fmt.Errorf("local pre-image exceeds maximum size of 32 bytes with key 0x%x", preimageKey)
+		}
+		preimagePart := preimageValue[8:]
+		var tmp [32]byte
+		copy(tmp[:], preimagePart)
+		input, err := oracle.ABI.Pack("loadLocalData",
+			new(big.Int).SetBytes(preimageKey[1:]),
+			localContext,
+			tmp,
+			new(big.Int).SetUint64(uint64(len(preimagePart))),
+			new(big.Int).SetUint64(uint64(preimageOffset)),
+		)
+		return input, err
+	case preimage.Keccak256KeyType:
+		input, err := oracle.ABI.Pack(
+			"loadKeccak256PreimagePart",
+			new(big.Int).SetUint64(uint64(preimageOffset)),
+			preimageValue[8:])
+		return input, err
+	case preimage.PrecompileKeyType:
+		if localOracle == nil {
+			return nil, errors.New("local oracle is required for precompile preimages")
+		}
+		preimage := localOracle.GetPreimage(preimage.Keccak256Key(preimageKey).PreimageKey())
+		precompile := common.BytesToAddress(preimage[:20])
+		requiredGas := binary.BigEndian.Uint64(preimage[20:28])
+		callInput := preimage[28:]
+		input, err := oracle.ABI.Pack(
+			"loadPrecompilePreimagePart",
+			new(big.Int).SetUint64(uint64(preimageOffset)),
+			precompile,
+			requiredGas,
+			callInput,
+		)
+		return input, err
+	default:
+		return nil, fmt.Errorf("%w type %d, cannot prepare preimage with key %x offset %d for oracle",
+			ErrUnsupportedPreimage, preimageKey[0], preimageKey, preimageOffset)
+	}
+}
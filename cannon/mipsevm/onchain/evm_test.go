@@ -0,0 +1,25 @@
+package onchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestEncodePreimageOracleInput(t *testing.T) {
+	t.Run("no pre-image", func(t *testing.T) {
+		_, err := EncodePreimageOracleInput(nil, nil, [32]byte{}, nil, 0, mipsevm.LocalContext{})
+		require.ErrorContains(t, err, "witness has no pre-image")
+	})
+
+	t.Run("unsupported pre-image", func(t *testing.T) {
+		for _, keyType := range []preimage.KeyType{preimage.Sha256KeyType, preimage.BlobKeyType} {
+			key := [32]byte{0: byte(keyType), 31: 1}
+			_, err := EncodePreimageOracleInput(nil, nil, key, []byte{0, 0, 0, 0, 0, 0, 0, 1, 0xaa}, 0, mipsevm.LocalContext{})
+			require.ErrorIs(t, err, ErrUnsupportedPreimage)
+		}
+	})
+}
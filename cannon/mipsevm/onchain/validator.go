@@ -0,0 +1,111 @@
+package onchain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// maxStepGas should be less than the L1 gas limit
+const maxStepGas = 20_000_000
+
+// Divergence is a step for which the onchain VM did not produce the post-state of the VM,
+// with the inputs of the onchain calls to reproduce it.
+type Divergence struct {
+	Step uint64 `json:"step"`
+	// PreStateHash is the hash of the state before the step.
+	PreStateHash common.Hash `json:"preStateHash"`
+	// StepInput is the encoded call of the step function of the MIPS contract.
+	StepInput hexutil.Bytes `json:"stepInput"`
+	// PreimageOracleInput is the encoded call that loads the preimage of the step into the oracle, if any.
+	PreimageOracleInput hexutil.Bytes `json:"preimageOracleInput,omitempty"`
+	PostState           hexutil.Bytes `json:"postState"`
+	PostStateHash       common.Hash   `json:"postStateHash"`
+	// OnchainPostState is the post-state logged by the onchain VM, if it completed the step.
+	OnchainPostState     hexutil.Bytes `json:"onchainPostState,omitempty"`
+	OnchainPostStateHash common.Hash   `json:"onchainPostStateHash"`
+	// Error is the failure of the onchain VM, if it did not complete the step.
+	Error string `json:"error,omitempty"`
+}
+
+// Validator runs steps on the onchain VM, to check that the VM produces the same post-states.
+// Steps are validated independently: the EVM state is reverted after each step.
+type Validator struct {
+	contracts   *ContractMetadata
+	env         *vm.EVM
+	evmState    *state.StateDB
+	localOracle mipsevm.PreimageOracle
+}
+
+// NewValidator deploys the contracts to a new EVM.
+// The localOracle provides the inputs of precompile preimages, and may be nil if the program does not read them.
+func NewValidator(contracts *ContractMetadata, localOracle mipsevm.PreimageOracle) (*Validator, error) {
+	env, evmState, err := NewEVMEnv(contracts)
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{
+		contracts:   contracts,
+		env:         env,
+		evmState:    evmState,
+		localOracle: localOracle,
+	}, nil
+}
+
+// Validate runs the step of the witness on the onchain VM, and compares the result with the post-state of the VM.
+// It returns nil if they match, and the divergence otherwise.
+// ErrUnsupportedPreimage is returned if the step reads a preimage that cannot be loaded into the onchain oracle.
+func (v *Validator) Validate(step uint64, wit *mipsevm.StepWitness, post []byte, postHash common.Hash) (*Divergence, error) {
+	snap := v.env.StateDB.Snapshot()
+	defer v.env.StateDB.RevertToSnapshot(snap)
+
+	div := &Divergence{
+		Step:          step,
+		PreStateHash:  wit.StateHash,
+		PostState:     post,
+		PostStateHash: postHash,
+	}
+	addrs := v.contracts.Addresses
+	if wit.HasPreimage() {
+		poInput, err := EncodePreimageOracleInput(v.contracts.Artifacts.Oracle, v.localOracle, wit.PreimageKey, wit.PreimageValue, wit.PreimageOffset, mipsevm.LocalContext{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode pre-image oracle input of step %d: %w", step, err)
+		}
+		div.PreimageOracleInput = poInput
+		if _, _, err := v.env.Call(addrs.Sender, addrs.Oracle, poInput, maxStepGas, common.U2560); err != nil {
+			div.Error = fmt.Sprintf("failed to load pre-image: %v", err)
+			return div, nil
+		}
+	}
+	input, err := EncodeStepInput(wit, mipsevm.LocalContext{}, v.contracts.Artifacts.MIPS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode step input of step %d: %w", step, err)
+	}
+	div.StepInput = input
+	ret, _, err := v.env.Call(addrs.Sender, addrs.MIPS, input, maxStepGas, common.U2560)
+	if err != nil {
+		div.Error = fmt.Sprintf("step failed: %v, with return value 0x%x", err, ret)
+		return div, nil
+	}
+	if len(ret) != 32 {
+		div.Error = fmt.Sprintf("expected 32-byte state hash, got 0x%x", ret)
+		return div, nil
+	}
+	div.OnchainPostStateHash = common.Hash(ret)
+	logs := v.evmState.Logs()
+	if len(logs) != 1 {
+		div.Error = fmt.Sprintf("expected a log with the post-state, got %d logs", len(logs))
+		return div, nil
+	}
+	div.OnchainPostState = logs[0].Data
+	if div.OnchainPostStateHash != postHash || !bytes.Equal(div.OnchainPostState, post) {
+		return div, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,143 @@
+package mipsevm
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GuestPanic is a Go runtime panic, or fatal error, of the guest program, extracted from its output.
+type GuestPanic struct {
+	// Message is the panic message, e.g. "panic: oh no", including any following lines up to the first backtrace.
+	Message    string           `json:"message"`
+	Goroutines []GoroutineTrace `json:"goroutines"`
+}
+
+// GoroutineTrace is the backtrace of a single goroutine.
+type GoroutineTrace struct {
+	// Header is the goroutine header, e.g. "goroutine 1 [running]:"
+	Header string       `json:"header"`
+	Frames []TraceFrame `json:"frames"`
+}
+
+type TraceFrame struct {
+	// Function is the function call, e.g. "main.main()", or "created by main.main in goroutine 1".
+	Function string `json:"function"`
+	// Location is the source location, e.g. "/app/main.go:10 +0x1c". Empty if not available.
+	Location string `json:"location,omitempty"`
+}
+
+// Log writes the panic as structured log entries: one for the panic itself, and one per goroutine backtrace.
+func (p *GuestPanic) Log(l log.Logger) {
+	l.Error("Guest program panicked", "message", p.Message, "goroutines", len(p.Goroutines))
+	for _, g := range p.Goroutines {
+		frames := make([]string, 0, len(g.Frames))
+		for _, f := range g.Frames {
+			if f.Location != "" {
+				frames = append(frames, f.Function+" at "+f.Location)
+			} else {
+				frames = append(frames, f.Function)
+			}
+		}
+		l.Error("Guest goroutine backtrace", "goroutine", g.Header, "frames", frames)
+	}
+}
+
+// PanicDetector forwards the output of a guest program stream,
+// while detecting Go runtime panic markers in it and extracting the goroutine backtraces that follow.
+// The guest output is written in arbitrary chunks, so partial lines are buffered until complete.
+type PanicDetector struct {
+	w io.Writer
+
+	// line is the incomplete last line of the output
+	line strings.Builder
+
+	panic *GuestPanic
+	// messageEnded is set once the message of the panic is complete, at the first empty line after the marker
+	messageEnded bool
+	// goroutine is the backtrace currently being parsed, if any
+	goroutine *GoroutineTrace
+}
+
+// maxPanicMessageLines caps the lines of a panic message, for a panic marker printed by the program
+// to not collect all of its following output.
+const maxPanicMessageLines = 16
+
+func NewPanicDetector(w io.Writer) *PanicDetector {
+	return &PanicDetector{w: w}
+}
+
+func (d *PanicDetector) Write(b []byte) (int, error) {
+	n, err := d.w.Write(b)
+	for _, c := range b[:n] {
+		if c == '\n' {
+			d.processLine(d.line.String())
+			d.line.Reset()
+		} else {
+			d.line.WriteByte(c)
+		}
+	}
+	return n, err
+}
+
+// Panic returns the detected panic, or nil if no panic with backtrace was detected in the output.
+func (d *PanicDetector) Panic() *GuestPanic {
+	if d.line.Len() > 0 {
+		d.processLine(d.line.String())
+		d.line.Reset()
+	}
+	d.goroutine = nil
+	if d.panic == nil || len(d.panic.Goroutines) == 0 {
+		return nil
+	}
+	return d.panic
+}
+
+func isPanicMarker(line string) bool {
+	return strings.HasPrefix(line, "panic: ") || strings.HasPrefix(line, "fatal error: ")
+}
+
+func isGoroutineHeader(line string) bool {
+	return strings.HasPrefix(line, "goroutine ") && strings.HasSuffix(line, ":")
+}
+
+func (d *PanicDetector) processLine(line string) {
+	line = strings.TrimSuffix(line, "\r")
+	if d.panic == nil {
+		if isPanicMarker(line) {
+			d.panic = &GuestPanic{Message: line}
+		}
+		return
+	}
+	switch {
+	case isGoroutineHeader(line):
+		d.panic.Goroutines = append(d.panic.Goroutines, GoroutineTrace{Header: line})
+		d.goroutine = &d.panic.Goroutines[len(d.panic.Goroutines)-1]
+	case line == "":
+		// the message and the goroutine backtraces are separated by empty lines
+		d.messageEnded = true
+		d.goroutine = nil
+	case d.goroutine != nil:
+		if strings.HasPrefix(line, "\t") && len(d.goroutine.Frames) > 0 {
+			d.goroutine.Frames[len(d.goroutine.Frames)-1].Location = strings.TrimPrefix(line, "\t")
+		} else {
+			d.goroutine.Frames = append(d.goroutine.Frames, TraceFrame{Function: line})
+		}
+	case len(d.panic.Goroutines) == 0:
+		if d.messageEnded {
+			// The marker was not followed by a backtrace: start over at the next marker.
+			if isPanicMarker(line) {
+				d.panic = &GuestPanic{Message: line}
+				d.messageEnded = false
+			}
+			return
+		}
+		// e.g. "[signal SIGSEGV: segmentation violation ...]", or nested panics
+		if strings.Count(d.panic.Message, "\n")+1 < maxPanicMessageLines {
+			d.panic.Message += "\n" + line
+		} else {
+			d.messageEnded = true
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package mipsevm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPanicOutput = `starting program
+panic: something went wrong
+[recovered]
+
+goroutine 1 [running]:
+main.fail(...)
+	/app/main.go:12
+main.main()
+	/app/main.go:7 +0x1c
+
+goroutine 5 [chan receive]:
+main.worker()
+	/app/worker.go:20 +0x40
+created by main.main in goroutine 1
+	/app/main.go:5 +0x10
+`
+
+func TestPanicDetector(t *testing.T) {
+	expected := &GuestPanic{
+		Message: "panic: something went wrong\n[recovered]",
+		Goroutines: []GoroutineTrace{
+			{
+				Header: "goroutine 1 [running]:",
+				Frames: []TraceFrame{
+					{Function: "main.fail(...)", Location: "/app/main.go:12"},
+					{Function: "main.main()", Location: "/app/main.go:7 +0x1c"},
+				},
+			},
+			{
+				Header: "goroutine 5 [chan receive]:",
+				Frames: []TraceFrame{
+					{Function: "main.worker()", Location: "/app/worker.go:20 +0x40"},
+					{Function: "created by main.main in goroutine 1", Location: "/app/main.go:5 +0x10"},
+				},
+			},
+		},
+	}
+
+	t.Run("single write", func(t *testing.T) {
+		var out bytes.Buffer
+		d := NewPanicDetector(&out)
+		n, err := d.Write([]byte(testPanicOutput))
+		require.NoError(t, err)
+		require.Equal(t, len(testPanicOutput), n)
+		require.Equal(t, testPanicOutput, out.String(), "output is forwarded")
+		require.Equal(t, expected, d.Panic())
+	})
+
+	t.Run("chunked writes", func(t *testing.T) {
+		var out bytes.Buffer
+		d := NewPanicDetector(&out)
+		data := []byte(testPanicOutput)
+		for i := 0; i < len(data); i += 7 {
+			_, err := d.Write(data[i:min(i+7, len(data))])
+			require.NoError(t, err)
+		}
+		require.Equal(t, testPanicOutput, out.String())
+		require.Equal(t, expected, d.Panic())
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		d := NewPanicDetector(new(bytes.Buffer))
+		_, err := d.Write([]byte("hello\nworld\ngoroutine 1 [running]:\n"))
+		require.NoError(t, err)
+		require.Nil(t, d.Panic())
+	})
+
+	t.Run("panic marker without backtrace", func(t *testing.T) {
+		d := NewPanicDetector(new(bytes.Buffer))
+		_, err := d.Write([]byte("panic: this is just text\nmore text"))
+		require.NoError(t, err)
+		require.Nil(t, d.Panic())
+	})
+
+	t.Run("message ends at empty line", func(t *testing.T) {
+		d := NewPanicDetector(new(bytes.Buffer))
+		_, err := d.Write([]byte("panic: this is just text\n\nmore text\n" + testPanicOutput))
+		require.NoError(t, err)
+		require.Equal(t, expected, d.Panic(), "the marker without backtrace is replaced by the next one")
+	})
+
+	t.Run("message is capped", func(t *testing.T) {
+		d := NewPanicDetector(new(bytes.Buffer))
+		_, err := d.Write([]byte("panic: this is just text\n" + strings.Repeat("more text\n", 100) + "goroutine 1 [running]:\nmain.main()\n"))
+		require.NoError(t, err)
+		p := d.Panic()
+		require.NotNil(t, p)
+		require.Equal(t, maxPanicMessageLines, strings.Count(p.Message, "\n")+1)
+	})
+
+	t.Run("fatal error", func(t *testing.T) {
+		d := NewPanicDetector(new(bytes.Buffer))
+		_, err := d.Write([]byte("fatal error: all goroutines are asleep - deadlock!\n\ngoroutine 1 [select (no cases)]:\nmain.main()\n\t/app/main.go:3 +0x8"))
+		require.NoError(t, err)
+		p := d.Panic()
+		require.NotNil(t, p)
+		require.Equal(t, "fatal error: all goroutines are asleep - deadlock!", p.Message)
+		require.Equal(t, []TraceFrame{{Function: "main.main()", Location: "/app/main.go:3 +0x8"}}, p.Goroutines[0].Frames)
+	})
+}
@@ -0,0 +1,115 @@
+package mipsevm
+
+import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2/simplelru"
+)
+
+// PreimageCacheMetrics records the requests to a PreimageCache, and its size.
+type PreimageCacheMetrics interface {
+	RecordPreimageCacheHit()
+	RecordPreimageCacheMiss()
+	RecordPreimageCacheEviction()
+	RecordPreimageCacheSize(entries int, size uint64)
+}
+
+// PreimageCacheStats are the totals of a PreimageCache.
+type PreimageCacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	// Entries and Size are the number of cached preimages, and their combined size in bytes.
+	Entries int    `json:"entries"`
+	Size    uint64 `json:"size"`
+}
+
+// HitRate returns the ratio of the preimage requests that were served from the cache.
+func (s PreimageCacheStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// PreimageCache wraps a PreimageOracle to serve repeated requests of the same preimage from memory,
+// instead of from the oracle. The least recently requested preimages are evicted to stay within
+// the limits of the number of preimages and their combined size.
+//
+// The guest observes the same preimages: preimages are content-addressed, and the local preimages
+// do not change during a run. Hints are passed to the oracle as-is.
+//
+// Hint and GetPreimage must be called from a single goroutine, like the VM does.
+type PreimageCache struct {
+	po      PreimageOracle
+	metrics PreimageCacheMetrics
+	maxSize uint64
+
+	cache *lru.LRU[[32]byte, []byte]
+	stats PreimageCacheStats
+}
+
+var _ PreimageOracle = (*PreimageCache)(nil)
+
+// NewPreimageCache caches up to maxEntries preimages of the oracle, with a combined size of up to
+// maxSize bytes. Preimages larger than maxSize are not cached. metrics may be nil.
+func NewPreimageCache(po PreimageOracle, maxEntries int, maxSize uint64, metrics PreimageCacheMetrics) (*PreimageCache, error) {
+	c := &PreimageCache{
+		po:      po,
+		metrics: metrics,
+		maxSize: maxSize,
+	}
+	cache, err := lru.NewLRU[[32]byte, []byte](maxEntries, c.onEvict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create preimage cache: %w", err)
+	}
+	c.cache = cache
+	return c, nil
+}
+
+func (c *PreimageCache) Hint(v []byte) {
+	c.po.Hint(v)
+}
+
+func (c *PreimageCache) GetPreimage(k [32]byte) []byte {
+	if data, ok := c.cache.Get(k); ok {
+		c.stats.Hits++
+		if c.metrics != nil {
+			c.metrics.RecordPreimageCacheHit()
+		}
+		return data
+	}
+	c.stats.Misses++
+	if c.metrics != nil {
+		c.metrics.RecordPreimageCacheMiss()
+	}
+	data := c.po.GetPreimage(k)
+	if size := uint64(len(data)); size <= c.maxSize {
+		for c.stats.Size+size > c.maxSize {
+			c.cache.RemoveOldest()
+		}
+		c.cache.Add(k, data)
+		c.stats.Size += size
+		c.stats.Entries = c.cache.Len()
+		if c.metrics != nil {
+			c.metrics.RecordPreimageCacheSize(c.stats.Entries, c.stats.Size)
+		}
+	}
+	return data
+}
+
+// onEvict is called by the cache when a preimage is removed, to not exceed the number of preimages,
+// or by GetPreimage, to not exceed the size.
+func (c *PreimageCache) onEvict(_ [32]byte, data []byte) {
+	c.stats.Evictions++
+	c.stats.Size -= uint64(len(data))
+	c.stats.Entries = c.cache.Len()
+	if c.metrics != nil {
+		c.metrics.RecordPreimageCacheEviction()
+	}
+}
+
+// Stats returns the totals of the requests to the cache so far, and its current size.
+func (c *PreimageCache) Stats() PreimageCacheStats {
+	return c.stats
+}
@@ -0,0 +1,104 @@
+package mipsevm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingOracle serves preimages of the size of the first byte of their key, and counts the requests.
+type countingOracle struct {
+	hints    []string
+	requests map[[32]byte]int
+}
+
+func (o *countingOracle) Hint(v []byte) {
+	o.hints = append(o.hints, string(v))
+}
+
+func (o *countingOracle) GetPreimage(k [32]byte) []byte {
+	o.requests[k]++
+	return make([]byte, k[0])
+}
+
+type cacheMetrics struct {
+	hits, misses, evictions int
+	entries                 int
+	size                    uint64
+}
+
+func (m *cacheMetrics) RecordPreimageCacheHit()      { m.hits++ }
+func (m *cacheMetrics) RecordPreimageCacheMiss()     { m.misses++ }
+func (m *cacheMetrics) RecordPreimageCacheEviction() { m.evictions++ }
+func (m *cacheMetrics) RecordPreimageCacheSize(entries int, size uint64) {
+	m.entries, m.size = entries, size
+}
+
+func TestPreimageCache(t *testing.T) {
+	key := func(size byte, id byte) [32]byte {
+		return [32]byte{0: size, 31: id}
+	}
+
+	t.Run("repeated requests", func(t *testing.T) {
+		po := &countingOracle{requests: make(map[[32]byte]int)}
+		m := &cacheMetrics{}
+		c, err := NewPreimageCache(po, 10, 1000, m)
+		require.NoError(t, err)
+		c.Hint([]byte("hint"))
+		for i := 0; i < 3; i++ {
+			require.Len(t, c.GetPreimage(key(10, 1)), 10)
+			require.Len(t, c.GetPreimage(key(20, 2)), 20)
+		}
+		require.Equal(t, []string{"hint"}, po.hints, "hints are passed as-is")
+		require.Equal(t, 1, po.requests[key(10, 1)])
+		require.Equal(t, 1, po.requests[key(20, 2)])
+		require.Equal(t, PreimageCacheStats{Hits: 4, Misses: 2, Entries: 2, Size: 30}, c.Stats())
+		require.InDelta(t, 4.0/6, c.Stats().HitRate(), 1e-9)
+		require.Equal(t, &cacheMetrics{hits: 4, misses: 2, entries: 2, size: 30}, m)
+	})
+
+	t.Run("evict least recently requested over max entries", func(t *testing.T) {
+		po := &countingOracle{requests: make(map[[32]byte]int)}
+		c, err := NewPreimageCache(po, 2, 1000, nil)
+		require.NoError(t, err)
+		c.GetPreimage(key(1, 1))
+		c.GetPreimage(key(1, 2))
+		c.GetPreimage(key(1, 1)) // 2 is now the least recently requested
+		c.GetPreimage(key(1, 3))
+		require.Equal(t, PreimageCacheStats{Hits: 1, Misses: 3, Evictions: 1, Entries: 2, Size: 2}, c.Stats())
+		c.GetPreimage(key(1, 1))
+		c.GetPreimage(key(1, 2))
+		require.Equal(t, 1, po.requests[key(1, 1)])
+		require.Equal(t, 2, po.requests[key(1, 2)])
+	})
+
+	t.Run("evict least recently requested over max size", func(t *testing.T) {
+		po := &countingOracle{requests: make(map[[32]byte]int)}
+		m := &cacheMetrics{}
+		c, err := NewPreimageCache(po, 10, 100, m)
+		require.NoError(t, err)
+		c.GetPreimage(key(40, 1))
+		c.GetPreimage(key(40, 2))
+		c.GetPreimage(key(50, 3)) // evicts 1
+		require.Equal(t, PreimageCacheStats{Misses: 3, Evictions: 1, Entries: 2, Size: 90}, c.Stats())
+		c.GetPreimage(key(100, 4)) // evicts 2 and 3
+		require.Equal(t, PreimageCacheStats{Misses: 4, Evictions: 3, Entries: 1, Size: 100}, c.Stats())
+		require.Equal(t, &cacheMetrics{misses: 4, evictions: 3, entries: 1, size: 100}, m)
+	})
+
+	t.Run("preimages larger than max size are not cached", func(t *testing.T) {
+		po := &countingOracle{requests: make(map[[32]byte]int)}
+		c, err := NewPreimageCache(po, 10, 100, nil)
+		require.NoError(t, err)
+		c.GetPreimage(key(10, 1))
+		c.GetPreimage(key(101, 2))
+		c.GetPreimage(key(101, 2))
+		require.Equal(t, 2, po.requests[key(101, 2)])
+		require.Equal(t, PreimageCacheStats{Misses: 3, Entries: 1, Size: 10}, c.Stats())
+	})
+
+	t.Run("invalid max entries", func(t *testing.T) {
+		_, err := NewPreimageCache(&countingOracle{}, 0, 100, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package mipsevm
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// PreimageUsage describes a single preimage that was requested during a run.
+type PreimageUsage struct {
+	Type string      `json:"type"`
+	Key  common.Hash `json:"key"`
+	// Size is the size of the preimage in bytes, excluding the length prefix.
+	Size      uint64 `json:"size"`
+	FirstStep uint64 `json:"first_step"`
+	// Reads is the number of steps that read (part of) the preimage.
+	Reads uint64 `json:"reads"`
+}
+
+// PreimageManifest is the per-run artifact listing every preimage the program depends on.
+type PreimageManifest struct {
+	TotalSize uint64 `json:"total_size"`
+	// Preimages is ordered by the step at which each preimage was first requested
+	Preimages []PreimageUsage `json:"preimages"`
+
+	index map[common.Hash]int
+}
+
+func NewPreimageManifest() *PreimageManifest {
+	return &PreimageManifest{
+		Preimages: []PreimageUsage{},
+		index:     make(map[common.Hash]int),
+	}
+}
+
+// Record records a read of the preimage with the given key at the given step.
+// The preimage is expected to include the 8 byte length prefix, as returned by FPVM.LastPreimage.
+func (m *PreimageManifest) Record(step uint64, key [32]byte, preimageWithPrefix []byte) {
+	k := common.Hash(key)
+	if i, ok := m.index[k]; ok {
+		m.Preimages[i].Reads++
+		return
+	}
+	size := uint64(0)
+	if len(preimageWithPrefix) > 8 {
+		size = uint64(len(preimageWithPrefix) - 8)
+	}
+	m.index[k] = len(m.Preimages)
+	m.Preimages = append(m.Preimages, PreimageUsage{
+		Type:      PreimageKeyTypeName(key[0]),
+		Key:       k,
+		Size:      size,
+		FirstStep: step,
+		Reads:     1,
+	})
+	m.TotalSize += size
+}
+
+// PreimageKeyTypeName returns the name of the preimage key type, as identified by the first byte of the key.
+func PreimageKeyTypeName(keyType byte) string {
+	switch preimage.KeyType(keyType) {
+	case preimage.LocalKeyType:
+		return "local"
+	case preimage.Keccak256KeyType:
+		return "keccak"
+	case preimage.GlobalGenericKeyType:
+		return "global-generic"
+	case preimage.Sha256KeyType:
+		return "sha256"
+	case preimage.BlobKeyType:
+		return "blob"
+	case preimage.PrecompileKeyType:
+		return "precompile"
+	default:
+		return fmt.Sprintf("unknown(%d)", keyType)
+	}
+}
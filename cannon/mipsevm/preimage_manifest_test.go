@@ -0,0 +1,50 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func withLengthPrefix(data []byte) []byte {
+	out := binary.BigEndian.AppendUint64(nil, uint64(len(data)))
+	return append(out, data...)
+}
+
+func TestPreimageManifest(t *testing.T) {
+	localKey := preimage.LocalIndexKey(1).PreimageKey()
+	keccakKey := preimage.Keccak256Key(common.Hash{0xaa}).PreimageKey()
+	blobKey := preimage.BlobKey(common.Hash{0xbb}).PreimageKey()
+
+	m := NewPreimageManifest()
+	m.Record(10, localKey, withLengthPrefix(make([]byte, 32)))
+	m.Record(11, localKey, withLengthPrefix(make([]byte, 32)))
+	m.Record(20, keccakKey, withLengthPrefix(make([]byte, 100)))
+	m.Record(30, blobKey, withLengthPrefix(make([]byte, 32)))
+	m.Record(40, localKey, withLengthPrefix(make([]byte, 32)))
+	m.Record(50, keccakKey, withLengthPrefix(nil))
+
+	require.Equal(t, uint64(164), m.TotalSize)
+	require.Equal(t, []PreimageUsage{
+		{Type: "local", Key: localKey, Size: 32, FirstStep: 10, Reads: 3},
+		{Type: "keccak", Key: keccakKey, Size: 100, FirstStep: 20, Reads: 2},
+		{Type: "blob", Key: blobKey, Size: 32, FirstStep: 30, Reads: 1},
+	}, m.Preimages)
+}
+
+func TestPreimageManifestEmptyJSON(t *testing.T) {
+	data, err := json.Marshal(NewPreimageManifest())
+	require.NoError(t, err)
+	require.JSONEq(t, `{"total_size":0,"preimages":[]}`, string(data))
+}
+
+func TestPreimageKeyTypeName(t *testing.T) {
+	require.Equal(t, "precompile", PreimageKeyTypeName(byte(preimage.PrecompileKeyType)))
+	require.Equal(t, "sha256", PreimageKeyTypeName(byte(preimage.Sha256KeyType)))
+	require.Equal(t, "unknown(42)", PreimageKeyTypeName(42))
+}
@@ -0,0 +1,175 @@
+package mipsevm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+)
+
+// insnLabel is the numeric pprof label of a sample that holds the instruction at its PC.
+const insnLabel = "insn"
+
+// InstructionCount is the number of samples that executed the instruction at a PC.
+type InstructionCount struct {
+	PC       arch.Word `json:"pc"`
+	Insn     uint32    `json:"insn"`
+	Function string    `json:"function"`
+	Samples  uint64    `json:"samples"`
+}
+
+// FunctionCount is the number of samples that executed an instruction of a function.
+type FunctionCount struct {
+	Name    string `json:"name"`
+	Samples uint64 `json:"samples"`
+	// Instructions is the number of distinct instructions of the function that were sampled.
+	Instructions int `json:"instructions"`
+}
+
+// Profile is the instruction-level profile of a run.
+type Profile struct {
+	// Interval is the number of steps per sample. Instructions are counted exactly if it is 1.
+	Interval uint64 `json:"interval"`
+	// Instructions is ordered by descending sample count
+	Instructions []InstructionCount `json:"instructions"`
+}
+
+// TotalSamples returns the number of samples of the profile.
+func (p *Profile) TotalSamples() uint64 {
+	var total uint64
+	for _, c := range p.Instructions {
+		total += c.Samples
+	}
+	return total
+}
+
+// Functions aggregates the samples of the instructions by function, ordered by descending sample count.
+func (p *Profile) Functions() []FunctionCount {
+	byName := make(map[string]*FunctionCount)
+	for _, c := range p.Instructions {
+		fn, ok := byName[c.Function]
+		if !ok {
+			fn = &FunctionCount{Name: c.Function}
+			byName[c.Function] = fn
+		}
+		fn.Samples += c.Samples
+		fn.Instructions++
+	}
+	out := make([]FunctionCount, 0, len(byName))
+	for _, fn := range byName {
+		out = append(out, *fn)
+	}
+	slices.SortFunc(out, func(a, b FunctionCount) int {
+		if c := cmp.Compare(b.Samples, a.Samples); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return out
+}
+
+// SortInstructions orders the instructions by descending sample count, and by PC.
+func (p *Profile) SortInstructions() {
+	slices.SortFunc(p.Instructions, func(a, b InstructionCount) int {
+		if c := cmp.Compare(b.Samples, a.Samples); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.PC, b.PC)
+	})
+}
+
+// WriteReport writes the top functions and instructions of the profile as text tables.
+func (p *Profile) WriteReport(out io.Writer, top int) error {
+	total := p.TotalSamples()
+	percent := func(samples uint64) float64 {
+		if total == 0 {
+			return 0
+		}
+		return 100 * float64(samples) / float64(total)
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "total samples: %d, steps per sample: %d\n\n", total, p.Interval)
+	_, _ = fmt.Fprintln(w, "steps\tflat%\tinsns\tfunction")
+	functions := p.Functions()
+	for _, fn := range functions[:min(top, len(functions))] {
+		_, _ = fmt.Fprintf(w, "%d\t%.2f%%\t%d\t%s\n", fn.Samples*p.Interval, percent(fn.Samples), fn.Instructions, fn.Name)
+	}
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "steps\tflat%\tpc\tinstruction\tfunction")
+	for _, c := range p.Instructions[:min(top, len(p.Instructions))] {
+		_, _ = fmt.Fprintf(w, "%d\t%.2f%%\t%#x\t%s\t%s\n", c.Samples*p.Interval, percent(c.Samples), c.PC,
+			disasm.Disassemble(uint64(c.PC), c.Insn), c.Function)
+	}
+	return w.Flush()
+}
+
+// PProf converts the profile to a pprof profile, with a location per sampled PC.
+func (p *Profile) PProf() *profile.Profile {
+	out := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}, {Type: "steps", Unit: "count"}},
+		PeriodType: &profile.ValueType{Type: "steps", Unit: "count"},
+		Period:     int64(p.Interval),
+	}
+	mapping := &profile.Mapping{ID: 1, File: "guest", HasFunctions: true}
+	out.Mapping = []*profile.Mapping{mapping}
+	functions := make(map[string]*profile.Function)
+	for i, c := range p.Instructions {
+		fn, ok := functions[c.Function]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(functions) + 1), Name: c.Function, SystemName: c.Function}
+			functions[c.Function] = fn
+			out.Function = append(out.Function, fn)
+		}
+		if i == 0 || uint64(c.PC) < mapping.Start {
+			mapping.Start = uint64(c.PC)
+		}
+		mapping.Limit = max(mapping.Limit, uint64(c.PC)+4)
+		loc := &profile.Location{ID: uint64(i + 1), Mapping: mapping, Address: uint64(c.PC), Line: []profile.Line{{Function: fn}}}
+		out.Location = append(out.Location, loc)
+		out.Sample = append(out.Sample, &profile.Sample{
+			Location: []*profile.Location{loc},
+			Value:    []int64{int64(c.Samples), int64(c.Samples * p.Interval)},
+			NumLabel: map[string][]int64{insnLabel: {int64(c.Insn)}},
+		})
+	}
+	return out
+}
+
+// ProfileFromPProf reads a profile that was converted with Profile.PProf.
+func ProfileFromPProf(pp *profile.Profile) (*Profile, error) {
+	if pp.Period <= 0 {
+		return nil, errors.New("profile has no sample interval")
+	}
+	out := &Profile{Interval: uint64(pp.Period)}
+	byPC := make(map[arch.Word]int)
+	for _, s := range pp.Sample {
+		if len(s.Location) == 0 || len(s.Value) == 0 {
+			return nil, errors.New("profile sample has no location or value")
+		}
+		loc := s.Location[0]
+		pc := arch.Word(loc.Address)
+		i, ok := byPC[pc]
+		if !ok {
+			c := InstructionCount{PC: pc}
+			if len(loc.Line) > 0 && loc.Line[0].Function != nil {
+				c.Function = loc.Line[0].Function.Name
+			}
+			if insn := s.NumLabel[insnLabel]; len(insn) > 0 {
+				c.Insn = uint32(insn[0])
+			}
+			i = len(out.Instructions)
+			byPC[pc] = i
+			out.Instructions = append(out.Instructions, c)
+		}
+		out.Instructions[i].Samples += uint64(s.Value[0])
+	}
+	out.SortInstructions()
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package mipsevm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfile() *Profile {
+	return &Profile{
+		Interval: 10,
+		Instructions: []InstructionCount{
+			{PC: 0x1008, Insn: 0x00851021, Function: "runtime.memmove", Samples: 5},
+			{PC: 0x2000, Insn: 0x00000000, Function: "main.main", Samples: 3},
+			{PC: 0x100c, Insn: 0x00000000, Function: "runtime.memmove", Samples: 2},
+		},
+	}
+}
+
+func TestProfileFunctions(t *testing.T) {
+	prof := testProfile()
+	require.Equal(t, uint64(10), prof.TotalSamples())
+	require.Equal(t, []FunctionCount{
+		{Name: "runtime.memmove", Samples: 7, Instructions: 2},
+		{Name: "main.main", Samples: 3, Instructions: 1},
+	}, prof.Functions())
+}
+
+func TestProfilePProf(t *testing.T) {
+	prof := testProfile()
+	var buf bytes.Buffer
+	require.NoError(t, prof.PProf().Write(&buf))
+
+	pp, err := profile.Parse(&buf)
+	require.NoError(t, err)
+	require.NoError(t, pp.CheckValid())
+	require.Equal(t, int64(10), pp.Period)
+	require.Len(t, pp.Sample, 3)
+	require.Equal(t, []int64{5, 50}, pp.Sample[0].Value)
+
+	restored, err := ProfileFromPProf(pp)
+	require.NoError(t, err)
+	require.Equal(t, prof, restored)
+}
+
+func TestProfileWriteReport(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testProfile().WriteReport(&buf, 1))
+	report := buf.String()
+	require.Contains(t, report, "total samples: 10, steps per sample: 10")
+	require.Contains(t, report, "runtime.memmove")
+	require.NotContains(t, report, "main.main", "only the top function and instruction are reported")
+	require.Contains(t, report, "0x1008")
+	require.Equal(t, 7, strings.Count(report, "\n"))
+}
@@ -3,6 +3,7 @@ package program
 import (
 	"bytes"
 	"debug/elf"
+	"errors"
 	"fmt"
 	"io"
 
@@ -18,15 +19,75 @@ const (
 
 type Word = arch.Word
 
+var (
+	ErrUnexpectedMachine    = errors.New("ELF is not a MIPS program")
+	ErrUnexpectedEndianness = errors.New("ELF has unexpected endianness")
+	ErrUnexpectedClass      = errors.New("ELF has unexpected class")
+	ErrDynamicallyLinked    = errors.New("ELF is dynamically linked")
+)
+
+// CheckELF checks that the ELF file is a MIPS program of the word size and byte order of the VM.
+// The VM reads and writes guest memory in arch.GuestByteOrder, so a program of the other byte order
+// would load without error but decode every instruction and word incorrectly.
+func CheckELF(f *elf.File) error {
+	if f.Machine != elf.EM_MIPS {
+		return fmt.Errorf("%w: got machine %s", ErrUnexpectedMachine, f.Machine)
+	}
+	if f.Data != arch.ELFData {
+		return fmt.Errorf("%w: expected %s, got %s", ErrUnexpectedEndianness, arch.ELFData, f.Data)
+	}
+	if f.Class != arch.ELFClass {
+		return fmt.Errorf("%w: expected %s, got %s", ErrUnexpectedClass, arch.ELFClass, f.Class)
+	}
+	return nil
+}
+
 type CreateInitialFPVMState[T mipsevm.FPVMState] func(pc, heapStart Word) T
 
 func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[T]) (T, error) {
 	var empty T
 	s := initState(Word(f.Entry), HEAP_START)
 
+	segments, err := ELFSegments(f)
+	if err != nil {
+		return empty, err
+	}
+	if err := loadSegments(s, segments); err != nil {
+		return empty, err
+	}
+	return s, nil
+}
+
+// Segment is a range of memory to initialize when loading a program.
+type Segment struct {
+	// Name identifies the segment in errors.
+	Name  string
+	Vaddr uint64
+	Memsz uint64
+	// Data provides exactly Memsz bytes.
+	Data io.Reader
+}
+
+// End returns the address after the last byte of the segment.
+func (s *Segment) End() uint64 {
+	return s.Vaddr + s.Memsz
+}
+
+// ELFSegments returns the segments of the ELF file to load into memory, with zero-length segments omitted.
+// The ELF file must pass CheckELF, and be statically linked, as the VM has no dynamic loader.
+// The PT_TLS segment is not loaded: it is the template of the thread-local storage, which a PT_LOAD
+// segment already contains, and which the runtime of the program copies for every thread.
+func ELFSegments(f *elf.File) ([]Segment, error) {
+	if err := CheckELF(f); err != nil {
+		return nil, err
+	}
+	var out []Segment
 	for i, prog := range f.Progs {
-		if prog.Type == elf.PT_MIPS_ABIFLAGS {
+		switch prog.Type {
+		case elf.PT_MIPS_ABIFLAGS, elf.PT_TLS:
 			continue
+		case elf.PT_INTERP:
+			return nil, fmt.Errorf("%w: program segment %d requests an interpreter", ErrDynamicallyLinked, i)
 		}
 
 		r := io.Reader(io.NewSectionReader(prog, 0, int64(prog.Filesz)))
@@ -35,10 +96,10 @@ func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[
 				if prog.Filesz < prog.Memsz {
 					r = io.MultiReader(r, bytes.NewReader(make([]byte, prog.Memsz-prog.Filesz)))
 				} else {
-					return empty, fmt.Errorf("invalid PT_LOAD program segment %d, file size (%d) > mem size (%d)", i, prog.Filesz, prog.Memsz)
+					return nil, fmt.Errorf("invalid PT_LOAD program segment %d, file size (%d) > mem size (%d)", i, prog.Filesz, prog.Memsz)
 				}
 			} else {
-				return empty, fmt.Errorf("program segment %d has different file size (%d) than mem size (%d): filling for non PT_LOAD segments is not supported", i, prog.Filesz, prog.Memsz)
+				return nil, fmt.Errorf("program segment %d has different file size (%d) than mem size (%d): filling for non PT_LOAD segments is not supported", i, prog.Filesz, prog.Memsz)
 			}
 		}
 
@@ -47,27 +108,42 @@ func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[
 			continue
 		}
 
-		// Calculate the architecture-specific last valid memory address
-		var lastMemoryAddr uint64
-		if arch.IsMips32 {
-			// 32-bit virtual address space
-			lastMemoryAddr = (1 << 32) - 1
-		} else {
-			// 48-bit virtual address space
-			lastMemoryAddr = (1 << 48) - 1
+		seg := Segment{Name: fmt.Sprintf("program %d", i), Vaddr: prog.Vaddr, Memsz: prog.Memsz, Data: r}
+		if err := checkSegmentRange(&seg); err != nil {
+			return nil, err
 		}
+		out = append(out, seg)
+	}
+	return out, nil
+}
 
-		lastByteToWrite := prog.Vaddr + prog.Memsz - 1
-		if lastByteToWrite > lastMemoryAddr || lastByteToWrite < prog.Vaddr {
-			return empty, fmt.Errorf("program %d out of memory range: %x - %x (size: %x)", i, prog.Vaddr, lastByteToWrite, prog.Memsz)
-		}
-		if lastByteToWrite >= HEAP_START {
-			return empty, fmt.Errorf("program %d overlaps with heap: %x - %x (size: %x). The heap start offset must be reconfigured", i, prog.Vaddr, lastByteToWrite, prog.Memsz)
-		}
-		if err := s.GetMemory().SetMemoryRange(Word(prog.Vaddr), r); err != nil {
-			return empty, fmt.Errorf("failed to read program segment %d: %w", i, err)
-		}
+// checkSegmentRange checks that the segment fits in the address space, below the heap.
+func checkSegmentRange(seg *Segment) error {
+	// Calculate the architecture-specific last valid memory address
+	var lastMemoryAddr uint64
+	if arch.IsMips32 {
+		// 32-bit virtual address space
+		lastMemoryAddr = (1 << 32) - 1
+	} else {
+		// 48-bit virtual address space
+		lastMemoryAddr = (1 << 48) - 1
 	}
 
-	return s, nil
+	lastByteToWrite := seg.Vaddr + seg.Memsz - 1
+	if lastByteToWrite > lastMemoryAddr || lastByteToWrite < seg.Vaddr {
+		return fmt.Errorf("%s out of memory range: %x - %x (size: %x)", seg.Name, seg.Vaddr, lastByteToWrite, seg.Memsz)
+	}
+	if lastByteToWrite >= HEAP_START {
+		return fmt.Errorf("%s overlaps with heap: %x - %x (size: %x). The heap start offset must be reconfigured", seg.Name, seg.Vaddr, lastByteToWrite, seg.Memsz)
+	}
+	return nil
+}
+
+func loadSegments[T mipsevm.FPVMState](s T, segments []Segment) error {
+	for _, seg := range segments {
+		if err := s.GetMemory().SetMemoryRange(Word(seg.Vaddr), seg.Data); err != nil {
+			return fmt.Errorf("failed to read %s: %w", seg.Name, err)
+		}
+	}
+	return nil
 }
@@ -43,6 +43,9 @@ func TestLoadELF(t *testing.T) {
 		{name: "MIPS Flags segment, invalid file size", progType: elf.PT_MIPS_ABIFLAGS, fileSize: dataSize * 2, memSize: dataSize, vAddr: 0x4000, shouldIgnore: true},
 		{name: "MIPS Flags segment, out-of-range", progType: elf.PT_MIPS_ABIFLAGS, fileSize: dataSize, memSize: dataSize, vAddr: lastAddr, shouldIgnore: true},
 		{name: "MIPS Flags segment, overlaps heap", progType: elf.PT_MIPS_ABIFLAGS, fileSize: dataSize, memSize: dataSize, vAddr: lastValidAddr, shouldIgnore: true},
+		{name: "TLS segment, memSize > fileSize", progType: elf.PT_TLS, fileSize: dataSize, memSize: dataSize * 2, vAddr: 0x4000, shouldIgnore: true},
+		{name: "TLS segment, overlaps heap", progType: elf.PT_TLS, fileSize: dataSize, memSize: dataSize, vAddr: lastValidAddr, shouldIgnore: true},
+		{name: "Interpreter segment", progType: elf.PT_INTERP, fileSize: dataSize, memSize: dataSize, vAddr: 0x4000, expectedErr: "dynamically linked"},
 		{name: "Other segment, fileSize > memSize", progType: elf.PT_DYNAMIC, fileSize: dataSize * 2, memSize: dataSize, vAddr: 0x4000, expectedErr: "filling for non PT_LOAD segments is not supported"},
 		{name: "Other segment, memSize > fileSize", progType: elf.PT_DYNAMIC, fileSize: dataSize, memSize: dataSize * 2, vAddr: 0x4000, expectedErr: "filling for non PT_LOAD segments is not supported"},
 		{name: "Other segment, out-of-range", progType: elf.PT_DYNAMIC, fileSize: dataSize, memSize: dataSize, vAddr: lastAddr, expectedErr: "out of memory range"},
@@ -81,3 +84,37 @@ func TestLoadELF(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckELF(t *testing.T) {
+	valid := elf.FileHeader{Class: arch.ELFClass, Data: arch.ELFData, Machine: elf.EM_MIPS}
+	tests := []struct {
+		name        string
+		modify      func(h *elf.FileHeader)
+		expectedErr error
+	}{
+		{name: "valid", modify: func(h *elf.FileHeader) {}},
+		{name: "little-endian", modify: func(h *elf.FileHeader) { h.Data = elf.ELFDATA2LSB }, expectedErr: ErrUnexpectedEndianness},
+		{name: "no data encoding", modify: func(h *elf.FileHeader) { h.Data = elf.ELFDATANONE }, expectedErr: ErrUnexpectedEndianness},
+		{name: "wrong machine", modify: func(h *elf.FileHeader) { h.Machine = elf.EM_X86_64 }, expectedErr: ErrUnexpectedMachine},
+		{name: "wrong class", modify: func(h *elf.FileHeader) { h.Class = elf.ELFCLASS32 }, expectedErr: ErrUnexpectedClass},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := valid
+			tt.modify(&header)
+			f := &elf.File{FileHeader: header}
+			if tt.expectedErr == nil {
+				require.NoError(t, CheckELF(f))
+				return
+			}
+			require.ErrorIs(t, CheckELF(f), tt.expectedErr)
+
+			// Loading rejects the file before reading any segment.
+			prog, reader := testutil.MockProgWithReader(elf.PT_LOAD, 8, 8, 0x4000, make([]byte, 8))
+			f.Progs = []*elf.Prog{prog}
+			_, err := LoadELF(f, testutil.MockCreateInitState)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Zero(t, reader.BytesRead)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package program
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// Manifest describes a program that is split over multiple images, e.g. a small loader plus the payload it runs,
+// which are loaded into a single VM state.
+//
+// Example:
+//
+//	{
+//	  "images": [
+//	    {"name": "loader", "elf": "loader.elf", "entry": true},
+//	    {"name": "payload", "raw": "payload.bin", "addr": "0x20000000"}
+//	  ]
+//	}
+type Manifest struct {
+	Images []ManifestImage `json:"images"`
+}
+
+// ManifestImage is an image of a manifest: either an ELF file, of which the segments are loaded at their virtual addresses,
+// or a raw binary file, which is loaded at Addr. Paths are relative to the manifest.
+type ManifestImage struct {
+	Name string `json:"name"`
+	ELF  string `json:"elf,omitempty"`
+	Raw  string `json:"raw,omitempty"`
+	// Addr is the address to load the raw file at.
+	Addr hexutil.Uint64 `json:"addr,omitempty"`
+	// Entry marks the ELF file of which the entry point is the initial PC. Exactly one image is the entry.
+	Entry bool `json:"entry,omitempty"`
+}
+
+// ReadManifest reads the manifest at the given path, and resolves the paths of its images relative to the manifest.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m Manifest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest %q: %w", path, err)
+	}
+	if len(m.Images) == 0 {
+		return nil, fmt.Errorf("manifest %q has no images", path)
+	}
+	names := make(map[string]bool)
+	dir := filepath.Dir(path)
+	for i := range m.Images {
+		img := &m.Images[i]
+		if img.Name == "" {
+			return nil, fmt.Errorf("image %d of manifest %q has no name", i, path)
+		}
+		if names[img.Name] {
+			return nil, fmt.Errorf("duplicate image %q in manifest %q", img.Name, path)
+		}
+		names[img.Name] = true
+		if img.ELF != "" && !filepath.IsAbs(img.ELF) {
+			img.ELF = filepath.Join(dir, img.ELF)
+		}
+		if img.Raw != "" && !filepath.IsAbs(img.Raw) {
+			img.Raw = filepath.Join(dir, img.Raw)
+		}
+	}
+	return &m, nil
+}
+
+// Image is an image of a program to load, see ManifestImage.
+type Image struct {
+	Name  string
+	ELF   *elf.File
+	Raw   []byte
+	Addr  Word
+	Entry bool
+}
+
+// Open opens the images of the manifest. The returned close function closes the ELF files,
+// after the images have been loaded.
+func (m *Manifest) Open() (images []Image, closeFn func() error, err error) {
+	var files []*elf.File
+	closeAll := func() error {
+		var result error
+		for _, f := range files {
+			result = errors.Join(result, f.Close())
+		}
+		return result
+	}
+	defer func() {
+		if err != nil {
+			_ = closeAll()
+		}
+	}()
+	images = make([]Image, 0, len(m.Images))
+	for _, img := range m.Images {
+		out := Image{Name: img.Name, Addr: Word(img.Addr), Entry: img.Entry}
+		switch {
+		case img.ELF != "" && img.Raw != "":
+			return nil, nil, fmt.Errorf("image %q must have either an ELF or a raw file, not both", img.Name)
+		case img.ELF != "":
+			f, err := elf.Open(img.ELF)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to open ELF file of image %q: %w", img.Name, err)
+			}
+			files = append(files, f)
+			if err := CheckELF(f); err != nil {
+				return nil, nil, fmt.Errorf("invalid ELF file of image %q: %w", img.Name, err)
+			}
+			out.ELF = f
+		case img.Raw != "":
+			data, err := os.ReadFile(img.Raw)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to read raw file of image %q: %w", img.Name, err)
+			}
+			out.Raw = data
+		default:
+			return nil, nil, fmt.Errorf("image %q must have either an ELF or a raw file", img.Name)
+		}
+		images = append(images, out)
+	}
+	return images, closeAll, nil
+}
+
+// LoadImages loads the images into a single VM state. The initial PC is the entry point of the entry image.
+// The segments of different images must not overlap.
+func LoadImages[T mipsevm.FPVMState](images []Image, initState CreateInitialFPVMState[T]) (T, error) {
+	var empty T
+	var entry *Image
+	for i := range images {
+		img := &images[i]
+		if !img.Entry {
+			continue
+		}
+		if entry != nil {
+			return empty, fmt.Errorf("images %q and %q are both marked as entry", entry.Name, img.Name)
+		}
+		if img.ELF == nil {
+			return empty, fmt.Errorf("entry image %q is not an ELF file", img.Name)
+		}
+		entry = img
+	}
+	if entry == nil {
+		return empty, errors.New("no image is marked as entry")
+	}
+
+	type imageSegment struct {
+		image int
+		Segment
+	}
+	var segments []imageSegment
+	for i, img := range images {
+		var segs []Segment
+		if img.ELF != nil {
+			var err error
+			segs, err = ELFSegments(img.ELF)
+			if err != nil {
+				return empty, fmt.Errorf("invalid image %q: %w", img.Name, err)
+			}
+		} else if len(img.Raw) > 0 {
+			seg := Segment{Name: "raw data", Vaddr: uint64(img.Addr), Memsz: uint64(len(img.Raw)), Data: bytes.NewReader(img.Raw)}
+			if err := checkSegmentRange(&seg); err != nil {
+				return empty, fmt.Errorf("invalid image %q: %w", img.Name, err)
+			}
+			segs = []Segment{seg}
+		}
+		for _, seg := range segs {
+			seg.Name = fmt.Sprintf("%s of image %q", seg.Name, img.Name)
+			segments = append(segments, imageSegment{image: i, Segment: seg})
+		}
+	}
+
+	// Segments of the same ELF file may overlap, e.g. a PT_NOTE segment within a PT_LOAD segment,
+	// but the images must be disjoint, or one image would silently overwrite another.
+	sorted := slices.Clone(segments)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Vaddr < sorted[j].Vaddr })
+	for i := range sorted {
+		for j := i + 1; j < len(sorted) && sorted[j].Vaddr < sorted[i].End(); j++ {
+			if sorted[i].image != sorted[j].image {
+				return empty, fmt.Errorf("%s (%x - %x) overlaps with %s (%x - %x)",
+					sorted[i].Name, sorted[i].Vaddr, sorted[i].End()-1, sorted[j].Name, sorted[j].Vaddr, sorted[j].End()-1)
+			}
+		}
+	}
+
+	s := initState(Word(entry.ELF.Entry), HEAP_START)
+	flat := make([]Segment, len(segments))
+	for i, seg := range segments {
+		flat[i] = seg.Segment
+	}
+	if err := loadSegments(s, flat); err != nil {
+		return empty, err
+	}
+	return s, nil
+}
+
+// MakeImagesMetadata returns the metadata of the ELF files of the images, with the symbols of all files combined.
+func MakeImagesMetadata(images []Image) (*Metadata, error) {
+	out := &Metadata{}
+	for _, img := range images {
+		if img.ELF == nil {
+			continue
+		}
+		meta, err := MakeMetadata(img.ELF)
+		if err != nil {
+			return nil, fmt.Errorf("image %q: %w", img.Name, err)
+		}
+		out.Symbols = append(out.Symbols, meta.Symbols...)
+	}
+	sort.SliceStable(out.Symbols, func(i, j int) bool {
+		return out.Symbols[i].Start < out.Symbols[j].Start
+	})
+	return out, nil
+}
@@ -0,0 +1,170 @@
+package program
+
+import (
+	"debug/elf"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program/testutil"
+)
+
+func mockELF(entry uint64, progs ...*elf.Prog) *elf.File {
+	f := testutil.MockELFFile(progs)
+	f.Entry = entry
+	return f
+}
+
+func mockLoad(vaddr uint64, data []byte) *elf.Prog {
+	prog, _ := testutil.MockProgWithReader(elf.PT_LOAD, uint64(len(data)), uint64(len(data)), vaddr, data)
+	return prog
+}
+
+func TestLoadImages(t *testing.T) {
+	loaderCode := []byte{0x11, 0x22, 0x33, 0x44}
+	payloadCode := []byte{0x55, 0x66, 0x77, 0x88}
+	raw := []byte{0x99, 0xaa}
+	images := []Image{
+		{Name: "loader", ELF: mockELF(0x1000, mockLoad(0x1000, loaderCode)), Entry: true},
+		{Name: "payload", ELF: mockELF(0x8000, mockLoad(0x8000, payloadCode))},
+		{Name: "config", Raw: raw, Addr: 0x9000},
+	}
+	var pc Word
+	state, err := LoadImages(images, func(entry, heapStart Word) *testutil.MockFPVMState {
+		pc = entry
+		return testutil.MockCreateInitState(entry, heapStart)
+	})
+	require.NoError(t, err)
+	require.Equal(t, Word(0x1000), pc, "PC is the entry point of the entry image")
+
+	read := func(addr uint64, size int) []byte {
+		data, err := io.ReadAll(state.GetMemory().ReadMemoryRange(arch.Word(addr), arch.Word(size)))
+		require.NoError(t, err)
+		return data
+	}
+	require.Equal(t, loaderCode, read(0x1000, len(loaderCode)))
+	require.Equal(t, payloadCode, read(0x8000, len(payloadCode)))
+	require.Equal(t, raw, read(0x9000, len(raw)))
+}
+
+func TestLoadImagesInvalid(t *testing.T) {
+	data := make([]byte, 0x100)
+	initState := testutil.MockCreateInitState
+	tests := []struct {
+		name        string
+		images      []Image
+		expectedErr string
+	}{
+		{
+			name:        "No entry",
+			images:      []Image{{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data))}},
+			expectedErr: "no image is marked as entry",
+		},
+		{
+			name: "Two entries",
+			images: []Image{
+				{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data)), Entry: true},
+				{Name: "b", ELF: mockELF(0x2000, mockLoad(0x2000, data)), Entry: true},
+			},
+			expectedErr: `images "a" and "b" are both marked as entry`,
+		},
+		{
+			name:        "Raw entry",
+			images:      []Image{{Name: "a", Raw: data, Addr: 0x1000, Entry: true}},
+			expectedErr: `entry image "a" is not an ELF file`,
+		},
+		{
+			name: "ELF images overlap",
+			images: []Image{
+				{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data)), Entry: true},
+				{Name: "b", ELF: mockELF(0x10f0, mockLoad(0x10ff, data))},
+			},
+			expectedErr: `program 0 of image "a" (1000 - 10ff) overlaps with program 0 of image "b" (10ff - 11fe)`,
+		},
+		{
+			name: "Raw image overlaps",
+			images: []Image{
+				{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data), mockLoad(0x4000, data)), Entry: true},
+				{Name: "b", Raw: data, Addr: 0x3f01},
+			},
+			expectedErr: `raw data of image "b" (3f01 - 4000) overlaps with program 1 of image "a" (4000 - 40ff)`,
+		},
+		{
+			name: "Raw image overlaps heap",
+			images: []Image{
+				{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data)), Entry: true},
+				{Name: "b", Raw: data, Addr: HEAP_START - 1},
+			},
+			expectedErr: `invalid image "b": raw data overlaps with heap`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadImages(tt.images, initState)
+			require.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+
+	t.Run("Adjacent images", func(t *testing.T) {
+		images := []Image{
+			{Name: "a", ELF: mockELF(0x1000, mockLoad(0x1000, data)), Entry: true},
+			{Name: "b", Raw: data, Addr: 0x1100},
+		}
+		_, err := LoadImages(images, initState)
+		require.NoError(t, err)
+	})
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "manifest.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	m, err := ReadManifest(write(`{"images": [
+		{"name": "loader", "elf": "bin/loader.elf", "entry": true},
+		{"name": "payload", "raw": "/abs/payload.bin", "addr": "0x20000000"}
+	]}`))
+	require.NoError(t, err)
+	require.Equal(t, &Manifest{Images: []ManifestImage{
+		{Name: "loader", ELF: filepath.Join(dir, "bin/loader.elf"), Entry: true},
+		{Name: "payload", Raw: "/abs/payload.bin", Addr: 0x20000000},
+	}}, m)
+
+	_, err = ReadManifest(write(`{"images": []}`))
+	require.ErrorContains(t, err, "has no images")
+	_, err = ReadManifest(write(`{"images": [{"elf": "a.elf"}]}`))
+	require.ErrorContains(t, err, "has no name")
+	_, err = ReadManifest(write(`{"images": [{"name": "a", "elf": "a.elf"}, {"name": "a", "raw": "a.bin"}]}`))
+	require.ErrorContains(t, err, `duplicate image "a"`)
+	_, err = ReadManifest(write(`{"images": [{"name": "a", "path": "a.elf"}]}`))
+	require.ErrorContains(t, err, "unknown field")
+}
+
+func TestManifestOpen(t *testing.T) {
+	dir := t.TempDir()
+	rawPath := filepath.Join(dir, "payload.bin")
+	require.NoError(t, os.WriteFile(rawPath, []byte{1, 2, 3}, 0o644))
+
+	m := &Manifest{Images: []ManifestImage{{Name: "payload", Raw: rawPath, Addr: 0x1000}}}
+	images, closeFn, err := m.Open()
+	require.NoError(t, err)
+	require.Equal(t, []Image{{Name: "payload", Raw: []byte{1, 2, 3}, Addr: 0x1000}}, images)
+	require.NoError(t, closeFn())
+
+	m = &Manifest{Images: []ManifestImage{{Name: "both", Raw: rawPath, ELF: rawPath}}}
+	_, _, err = m.Open()
+	require.ErrorContains(t, err, "not both")
+	m = &Manifest{Images: []ManifestImage{{Name: "none"}}}
+	_, _, err = m.Open()
+	require.ErrorContains(t, err, "must have either an ELF or a raw file")
+	m = &Manifest{Images: []ManifestImage{{Name: "bad", ELF: rawPath}}}
+	_, _, err = m.Open()
+	require.ErrorContains(t, err, `failed to open ELF file of image "bad"`)
+}
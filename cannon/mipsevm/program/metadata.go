@@ -54,6 +54,21 @@ func (m *Metadata) LookupSymbol(addr Word) string {
 	return out.Name
 }
 
+// NearestSymbol returns the symbol with the highest start at or before the address, or nil if there is none.
+// Unlike LookupSymbol, the address may be past the end of the symbol.
+func (m *Metadata) NearestSymbol(addr Word) *Symbol {
+	i := sort.Search(len(m.Symbols), func(i int) bool {
+		return m.Symbols[i].Start > addr
+	})
+	// Skip unnamed symbols, e.g. of sections
+	for ; i > 0; i-- {
+		if m.Symbols[i-1].Name != "" {
+			return &m.Symbols[i-1]
+		}
+	}
+	return nil
+}
+
 func (m *Metadata) CreateSymbolMatcher(name string) mipsevm.SymbolMatcher {
 	for _, s := range m.Symbols {
 		if s.Name == name {
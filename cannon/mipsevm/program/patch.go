@@ -2,7 +2,10 @@ package program
 
 import (
 	"bytes"
+	"debug/elf"
+	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
@@ -12,8 +15,30 @@ import (
 
 const WordSizeBytes = arch.WordSizeBytes
 
+// Types of the entries of the auxiliary vector
+const (
+	AT_PHDR  = 3
+	AT_PHENT = 4
+	AT_PHNUM = 5
+)
+
+// elf64PhoffOffset is the offset of e_phoff, the file offset of the program headers, in the header of a 64-bit ELF file.
+const elf64PhoffOffset = 0x20
+
+// AuxvEntry is an entry of the auxiliary vector of the initial stack frame.
+type AuxvEntry struct {
+	Type  Word
+	Value Word
+}
+
 // PatchStack sets up the program's initial stack frame and stack pointer
 func PatchStack(st mipsevm.FPVMState) error {
+	return PatchStackWithAuxv(st)
+}
+
+// PatchStackWithAuxv sets up the initial stack frame like PatchStack, with additional entries of the auxiliary vector
+// after the AT_PAGESZ and AT_RANDOM entries. Without additional entries, the stack frame is the same as of PatchStack.
+func PatchStackWithAuxv(st mipsevm.FPVMState, auxv ...AuxvEntry) error {
 	// setup stack pointer
 	sp := Word(arch.HighMemoryStart)
 	// allocate 1 page for the initial stack data, and 16KB = 4 pages for the stack to grow
@@ -28,7 +53,8 @@ func PatchStack(st mipsevm.FPVMState) error {
 		_ = st.GetMemory().SetMemoryRange(addr, bytes.NewReader(dat[:]))
 	}
 
-	auxv3Offset := sp + WordSizeBytes*10
+	auxvEnd := Word(9 + 2*len(auxv))
+	auxv3Offset := sp + WordSizeBytes*(auxvEnd+1)
 	randomness := []byte("4;byfairdiceroll")
 	randomness = pad(randomness)
 	_ = st.GetMemory().SetMemoryRange(auxv3Offset, bytes.NewReader(randomness))
@@ -53,11 +79,57 @@ func PatchStack(st mipsevm.FPVMState) error {
 	storeMem(sp+WordSizeBytes*6, 4096)        // auxv[1] = page size of 4 KiB (value) - (== minPhysPageSize)
 	storeMem(sp+WordSizeBytes*7, 25)          // auxv[2] = AT_RANDOM
 	storeMem(sp+WordSizeBytes*8, auxv3Offset) // auxv[3] = address of 16 bytes containing random value
-	storeMem(sp+WordSizeBytes*9, 0)           // auxv[term] = 0
+	for i, entry := range auxv {
+		storeMem(sp+WordSizeBytes*Word(9+2*i), entry.Type)
+		storeMem(sp+WordSizeBytes*Word(10+2*i), entry.Value)
+	}
+	storeMem(sp+WordSizeBytes*auxvEnd, 0) // auxv[term] = 0
 
 	return nil
 }
 
+// ProgramHeadersAuxv returns the AT_PHDR, AT_PHENT and AT_PHNUM entries of the auxiliary vector for the program headers
+// of the ELF file, with which the runtime of a static musl program finds its PT_TLS segment.
+// The program headers must be loaded into memory, as a PT_PHDR segment or as part of the PT_LOAD segment of the ELF header.
+func ProgramHeadersAuxv(f *elf.File) ([]AuxvEntry, error) {
+	if err := CheckELF(f); err != nil {
+		return nil, err
+	}
+	phdr, err := programHeadersAddr(f)
+	if err != nil {
+		return nil, err
+	}
+	return []AuxvEntry{
+		{Type: AT_PHDR, Value: phdr},
+		{Type: AT_PHENT, Value: Word(binary.Size(elf.Prog64{}))},
+		{Type: AT_PHNUM, Value: Word(len(f.Progs))},
+	}, nil
+}
+
+func programHeadersAddr(f *elf.File) (Word, error) {
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_PHDR {
+			return prog.Vaddr, nil
+		}
+	}
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_LOAD || prog.Off != 0 {
+			continue
+		}
+		// The segment starts with the ELF header, which has the file offset of the program headers
+		var phoff [8]byte
+		if _, err := prog.ReadAt(phoff[:], elf64PhoffOffset); err != nil {
+			return 0, fmt.Errorf("failed to read the ELF header: %w", err)
+		}
+		off := f.ByteOrder.Uint64(phoff[:])
+		if off+uint64(len(f.Progs)*binary.Size(elf.Prog64{})) > prog.Filesz {
+			return 0, fmt.Errorf("program headers at offset %d are not loaded", off)
+		}
+		return prog.Vaddr + off, nil
+	}
+	return 0, errors.New("program headers are not loaded")
+}
+
 // pad adds appropriate padding to buf to end at Word alignment
 func pad(buf []byte) []byte {
 	if len(buf)%WordSizeBytes == 0 {
@@ -0,0 +1,78 @@
+package program
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+)
+
+func TestPatchStack(t *testing.T) {
+	sp := Word(arch.HighMemoryStart)
+	stackWord := func(st *testutil.MockFPVMState, i Word) Word {
+		return st.GetMemory().GetWord(sp + WordSizeBytes*i)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		st := testutil.MockCreateInitState(0, HEAP_START)
+		require.NoError(t, PatchStack(st))
+		require.Equal(t, sp, st.GetRegistersRef()[register.RegSP])
+		require.Equal(t, Word(1), stackWord(st, 0), "argc")
+		require.Equal(t, Word(25), stackWord(st, 7), "AT_RANDOM")
+		require.Equal(t, sp+WordSizeBytes*10, stackWord(st, 8), "random bytes follow the auxiliary vector")
+		require.Zero(t, stackWord(st, 9), "end of auxiliary vector")
+
+		same := testutil.MockCreateInitState(0, HEAP_START)
+		require.NoError(t, PatchStackWithAuxv(same))
+		require.Equal(t, st.GetMemory().MerkleRoot(), same.GetMemory().MerkleRoot())
+	})
+
+	t.Run("auxv", func(t *testing.T) {
+		st := testutil.MockCreateInitState(0, HEAP_START)
+		require.NoError(t, PatchStackWithAuxv(st, AuxvEntry{Type: AT_PHDR, Value: 0x10040}, AuxvEntry{Type: AT_PHNUM, Value: 7}))
+		require.Equal(t, Word(25), stackWord(st, 7), "AT_RANDOM")
+		require.Equal(t, sp+WordSizeBytes*14, stackWord(st, 8), "random bytes follow the auxiliary vector")
+		require.Equal(t, []Word{AT_PHDR, 0x10040, AT_PHNUM, 7, 0}, []Word{stackWord(st, 9), stackWord(st, 10), stackWord(st, 11), stackWord(st, 12), stackWord(st, 13)})
+	})
+}
+
+func TestProgramHeadersAuxv(t *testing.T) {
+	phent := Word(binary.Size(elf.Prog64{}))
+
+	t.Run("PT_PHDR", func(t *testing.T) {
+		progs := []*elf.Prog{
+			testutil.MockProg(elf.PT_PHDR, 2*uint64(phent), 2*uint64(phent), 0x10040),
+			testutil.MockProg(elf.PT_LOAD, 0x100, 0x100, 0x10000),
+		}
+		auxv, err := ProgramHeadersAuxv(testutil.MockELFFile(progs))
+		require.NoError(t, err)
+		require.Equal(t, []AuxvEntry{{AT_PHDR, 0x10040}, {AT_PHENT, phent}, {AT_PHNUM, 2}}, auxv)
+	})
+
+	t.Run("PT_LOAD of the ELF header", func(t *testing.T) {
+		header := make([]byte, 0x200)
+		binary.BigEndian.PutUint64(header[elf64PhoffOffset:], 0x40)
+		prog, _ := testutil.MockProgWithReader(elf.PT_LOAD, uint64(len(header)), uint64(len(header)), 0x120000, header)
+		f := testutil.MockELFFile([]*elf.Prog{prog, testutil.MockProg(elf.PT_TLS, 0x10, 0x20, 0x121000)})
+		f.ByteOrder = binary.BigEndian
+		auxv, err := ProgramHeadersAuxv(f)
+		require.NoError(t, err)
+		require.Equal(t, []AuxvEntry{{AT_PHDR, 0x120040}, {AT_PHENT, phent}, {AT_PHNUM, 2}}, auxv)
+
+		binary.BigEndian.PutUint64(header[elf64PhoffOffset:], 0x1f0)
+		_, err = ProgramHeadersAuxv(f)
+		require.ErrorContains(t, err, "are not loaded")
+	})
+
+	t.Run("not loaded", func(t *testing.T) {
+		prog := testutil.MockProg(elf.PT_LOAD, 0x100, 0x100, 0x10000)
+		prog.Off = 0x1000
+		_, err := ProgramHeadersAuxv(testutil.MockELFFile([]*elf.Prog{prog}))
+		require.ErrorContains(t, err, "program headers are not loaded")
+	})
+}
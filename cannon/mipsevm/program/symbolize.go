@@ -0,0 +1,89 @@
+package program
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"fmt"
+)
+
+// Location is an address of the guest program, resolved to the nearest symbol and its source line.
+type Location struct {
+	Addr Word
+	// Symbol is the name of the nearest symbol at or before the address, or empty if there is none.
+	Symbol string
+	// Offset is the offset of the address from the start of the symbol.
+	Offset Word
+	// File and Line are the source line of the address, or empty if the program has no DWARF line info for it.
+	File string
+	Line int
+}
+
+func (l Location) String() string {
+	out := fmt.Sprintf("0x%x", l.Addr)
+	if l.Symbol != "" {
+		out += fmt.Sprintf(" %s+0x%x", l.Symbol, l.Offset)
+	}
+	if l.File != "" {
+		out += fmt.Sprintf(" (%s:%d)", l.File, l.Line)
+	}
+	return out
+}
+
+// Symbolizer resolves addresses of the guest program to locations,
+// with the symbols of the metadata, and the DWARF line info of the ELF if there is one.
+type Symbolizer struct {
+	meta  *Metadata
+	dwarf *dwarf.Data
+}
+
+// NewSymbolizer creates a symbolizer with the symbols of the metadata, and the DWARF line info of the ELF.
+// The ELF is optional, and its symbols are used if the metadata has none.
+func NewSymbolizer(meta *Metadata, elfProgram *elf.File) (*Symbolizer, error) {
+	s := &Symbolizer{meta: meta}
+	if elfProgram == nil {
+		return s, nil
+	}
+	if s.meta == nil || len(s.meta.Symbols) == 0 {
+		m, err := MakeMetadata(elfProgram)
+		if err != nil {
+			return nil, err
+		}
+		s.meta = m
+	}
+	// Programs built without DWARF are symbolized without source lines
+	if data, err := elfProgram.DWARF(); err == nil {
+		s.dwarf = data
+	}
+	return s, nil
+}
+
+// Locate resolves the address to its location. Unknown parts of the location are left empty.
+func (s *Symbolizer) Locate(addr Word) Location {
+	loc := Location{Addr: addr}
+	if s.meta != nil {
+		if sym := s.meta.NearestSymbol(addr); sym != nil {
+			loc.Symbol = sym.Name
+			loc.Offset = addr - sym.Start
+		}
+	}
+	if s.dwarf != nil {
+		loc.File, loc.Line = lookupLine(s.dwarf, uint64(addr))
+	}
+	return loc
+}
+
+func lookupLine(data *dwarf.Data, pc uint64) (file string, line int) {
+	cu, err := data.Reader().SeekPC(pc)
+	if err != nil {
+		return "", 0
+	}
+	lr, err := data.LineReader(cu)
+	if err != nil || lr == nil {
+		return "", 0
+	}
+	var entry dwarf.LineEntry
+	if err := lr.SeekPC(pc, &entry); err != nil || entry.File == nil {
+		return "", 0
+	}
+	return entry.File.Name, entry.Line
+}
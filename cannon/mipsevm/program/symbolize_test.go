@@ -0,0 +1,71 @@
+package program
+
+import (
+	"debug/elf"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNearestSymbol(t *testing.T) {
+	meta := &Metadata{Symbols: []Symbol{
+		{Name: "a", Start: 0x100, Size: 0x10},
+		{Name: "", Start: 0x200, Size: 0},
+		{Name: "b", Start: 0x300, Size: 0x10},
+	}}
+	require.Nil(t, meta.NearestSymbol(0xff))
+	require.Equal(t, "a", meta.NearestSymbol(0x100).Name)
+	require.Equal(t, "a", meta.NearestSymbol(0x10f).Name)
+	require.Equal(t, "a", meta.NearestSymbol(0x250).Name, "past the end, and unnamed symbols are skipped")
+	require.Equal(t, "b", meta.NearestSymbol(0x400).Name)
+	require.Nil(t, (&Metadata{}).NearestSymbol(0x100))
+}
+
+func TestSymbolizer(t *testing.T) {
+	elfProgram, err := elf.Open("../../testdata/go-1-24/bin/hello.64.elf")
+	require.NoError(t, err)
+	defer elfProgram.Close()
+	meta, err := MakeMetadata(elfProgram)
+	require.NoError(t, err)
+	var mainStart Word
+	for _, s := range meta.Symbols {
+		if s.Name == "main.main" {
+			mainStart = s.Start
+		}
+	}
+	require.NotZero(t, mainStart)
+
+	t.Run("with DWARF", func(t *testing.T) {
+		s, err := NewSymbolizer(nil, elfProgram)
+		require.NoError(t, err)
+		loc := s.Locate(mainStart + 4)
+		require.Equal(t, "main.main", loc.Symbol)
+		require.Equal(t, Word(4), loc.Offset)
+		require.True(t, strings.HasSuffix(loc.File, "hello/main.go"), loc.File)
+		require.Equal(t, 5, loc.Line)
+		require.Contains(t, loc.String(), " main.main+0x4 (")
+		require.True(t, strings.HasSuffix(loc.String(), "hello/main.go:5)"), loc.String())
+	})
+
+	t.Run("symbols of ELF without metadata symbols", func(t *testing.T) {
+		s, err := NewSymbolizer(&Metadata{}, elfProgram)
+		require.NoError(t, err)
+		require.Equal(t, "main.main", s.Locate(mainStart).Symbol)
+	})
+
+	t.Run("without DWARF", func(t *testing.T) {
+		s, err := NewSymbolizer(meta, nil)
+		require.NoError(t, err)
+		loc := s.Locate(mainStart + 4)
+		require.Equal(t, Location{Addr: mainStart + 4, Symbol: "main.main", Offset: 4}, loc)
+	})
+
+	t.Run("unknown address", func(t *testing.T) {
+		s, err := NewSymbolizer(&Metadata{Symbols: []Symbol{{Name: "a", Start: 0x100, Size: 0x10}}}, elfProgram)
+		require.NoError(t, err)
+		loc := s.Locate(0x10)
+		require.Equal(t, Location{Addr: 0x10}, loc)
+		require.Equal(t, "0x10", loc.String())
+	})
+}
@@ -13,9 +13,12 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
-// MockELFFile create a mock ELF file with custom program segments
+// MockELFFile create a mock ELF file with custom program segments, with the header of a program the VM can run
 func MockELFFile(progs []*elf.Prog) *elf.File {
-	return &elf.File{Progs: progs}
+	return &elf.File{
+		FileHeader: elf.FileHeader{Class: arch.ELFClass, Data: arch.ELFData, Machine: elf.EM_MIPS},
+		Progs:      progs,
+	}
 }
 
 // MockProg sets up a elf.Prog structure for testing
@@ -62,14 +65,15 @@ func MockCreateInitState(pc, heapStart arch.Word) *MockFPVMState {
 }
 
 type MockFPVMState struct {
-	memory *memory.Memory
+	memory    *memory.Memory
+	registers [32]arch.Word
 }
 
 var _ mipsevm.FPVMState = (*MockFPVMState)(nil)
 
 func newMockFPVMState() *MockFPVMState {
 	mem := memory.NewMemory()
-	state := MockFPVMState{mem}
+	state := MockFPVMState{memory: mem}
 	return &state
 }
 
@@ -101,8 +105,8 @@ func (m MockFPVMState) GetCpu() mipsevm.CpuScalars {
 	panic("not implemented")
 }
 
-func (m MockFPVMState) GetRegistersRef() *[32]arch.Word {
-	panic("not implemented")
+func (m *MockFPVMState) GetRegistersRef() *[32]arch.Word {
+	return &m.registers
 }
 
 func (m MockFPVMState) GetStep() uint64 {
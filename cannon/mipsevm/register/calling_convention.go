@@ -12,10 +12,19 @@ const (
 	RegA2 = 6
 	// 4th syscall argument; set to 0/1 for success/error
 	RegA3 = 7
+	// Kernel-reserved register, never used by programs
+	RegK1 = 27
 	// Stack pointer
 	RegSP = 29
+	// Return address
+	RegRA = 31
 )
 
+// RegThreadPointer holds the thread pointer of a thread, which is set with set_thread_area and read with rdhwr,
+// see mipsevm.FeatureToggles.SupportMuslRuntime. The kernel keeps it outside the registers, but as $k1 is reserved
+// for the kernel, it can hold the thread pointer without changing the layout of the thread state.
+const RegThreadPointer = RegK1
+
 // FYI: https://web.archive.org/web/20231223163047/https://www.linux-mips.org/wiki/Syscall
 
 const (
@@ -0,0 +1,28 @@
+package mipsevm
+
+import (
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// StackGuardConfig configures the heuristic detection of stack overflows in the guest program.
+// The stack of a thread spans the configured size below the stack pointer the thread started with,
+// and the guard region spans GuardSize below the stack.
+type StackGuardConfig struct {
+	// MainStackSize is the size of the stack of the initial thread, below arch.HighMemoryStart.
+	MainStackSize arch.Word
+	// ThreadStackSize is the size of the stack of cloned threads, below the stack pointer passed to clone.
+	ThreadStackSize arch.Word
+	// GuardSize is the size of the guard region below each stack.
+	GuardSize arch.Word
+}
+
+// DefaultStackGuardConfig matches the stacks the Go runtime uses for its threads:
+// 64KB for the initial thread, and 16KB for the g0 stack of threads created with clone.
+func DefaultStackGuardConfig() StackGuardConfig {
+	return StackGuardConfig{
+		MainStackSize:   64 * 1024,
+		ThreadStackSize: 16 * 1024,
+		GuardSize:       memory.PageSize,
+	}
+}
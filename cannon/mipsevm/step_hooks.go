@@ -0,0 +1,156 @@
+package mipsevm
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// StateView is a read-only view of the VM state, as passed to step hooks.
+type StateView interface {
+	GetPC() arch.Word
+	GetCpu() CpuScalars
+	// GetRegisters returns a copy of the currently active registers
+	GetRegisters() [32]arch.Word
+	GetHeap() arch.Word
+	GetStep() uint64
+	GetExited() bool
+	GetExitCode() uint8
+	GetPreimageKey() common.Hash
+	GetPreimageOffset() arch.Word
+	// GetMemoryWord returns the word at the given aligned memory address
+	GetMemoryWord(addr arch.Word) arch.Word
+	// ReadMemoryRegion returns a copy of the length bytes of memory starting at addr, see memory.Memory.ReadRegion
+	ReadMemoryRegion(addr arch.Word, length arch.Word) []byte
+	EncodeWitness() (witness []byte, hash common.Hash)
+	// Serialize writes the full state, e.g. to checkpoint it
+	Serialize(out io.Writer) error
+}
+
+type stateView struct {
+	s FPVMState
+}
+
+// NewStateView returns a read-only view of the given state.
+func NewStateView(state FPVMState) StateView {
+	return stateView{s: state}
+}
+
+func (v stateView) GetPC() arch.Word {
+	return v.s.GetPC()
+}
+
+func (v stateView) GetCpu() CpuScalars {
+	return v.s.GetCpu()
+}
+
+func (v stateView) GetRegisters() [32]arch.Word {
+	return *v.s.GetRegistersRef()
+}
+
+func (v stateView) GetHeap() arch.Word {
+	return v.s.GetHeap()
+}
+
+func (v stateView) GetStep() uint64 {
+	return v.s.GetStep()
+}
+
+func (v stateView) GetExited() bool {
+	return v.s.GetExited()
+}
+
+func (v stateView) GetExitCode() uint8 {
+	return v.s.GetExitCode()
+}
+
+func (v stateView) GetPreimageKey() common.Hash {
+	return v.s.GetPreimageKey()
+}
+
+func (v stateView) GetPreimageOffset() arch.Word {
+	return v.s.GetPreimageOffset()
+}
+
+func (v stateView) GetMemoryWord(addr arch.Word) arch.Word {
+	return v.s.GetMemory().GetWord(addr)
+}
+
+func (v stateView) ReadMemoryRegion(addr arch.Word, length arch.Word) []byte {
+	return v.s.GetMemory().ReadRegion(addr, length)
+}
+
+func (v stateView) EncodeWitness() ([]byte, common.Hash) {
+	return v.s.EncodeWitness()
+}
+
+func (v stateView) Serialize(out io.Writer) error {
+	return v.s.Serialize(out)
+}
+
+// StepHook is called with a read-only view of the state, after the VM executed a step.
+// An error returned by the hook is returned by the Step call that triggered it.
+type StepHook func(view StateView) error
+
+type intervalHook struct {
+	interval uint64
+	fn       StepHook
+}
+
+// StepHooks holds the callbacks to run after a VM step, either at a fixed step interval, or at specific steps.
+// The zero value has no hooks.
+type StepHooks struct {
+	intervals []intervalHook
+	atStep    map[uint64][]StepHook
+}
+
+// Every registers a hook to run after every step that results in a step count that is a multiple of the interval.
+func (h *StepHooks) Every(interval uint64, fn StepHook) {
+	if interval == 0 {
+		panic("step hook interval must not be zero")
+	}
+	h.intervals = append(h.intervals, intervalHook{interval: interval, fn: fn})
+}
+
+// At registers a hook to run once, after the step that results in the given step count.
+func (h *StepHooks) At(step uint64, fn StepHook) {
+	if h.atStep == nil {
+		h.atStep = make(map[uint64][]StepHook)
+	}
+	h.atStep[step] = append(h.atStep[step], fn)
+}
+
+// Empty returns true if no hooks are registered.
+func (h *StepHooks) Empty() bool {
+	return len(h.intervals) == 0 && len(h.atStep) == 0
+}
+
+// Run runs the hooks that match the current step of the given state.
+// Hooks registered for the specific step run first, followed by the interval hooks, each in order of registration.
+func (h *StepHooks) Run(state FPVMState) error {
+	if h.Empty() {
+		return nil
+	}
+	step := state.GetStep()
+	view := NewStateView(state)
+	if hooks, ok := h.atStep[step]; ok {
+		delete(h.atStep, step)
+		for _, fn := range hooks {
+			if err := fn(view); err != nil {
+				return fmt.Errorf("step hook at step %d failed: %w", step, err)
+			}
+		}
+	}
+	for _, x := range h.intervals {
+		if step%x.interval != 0 {
+			continue
+		}
+		if err := x.fn(view); err != nil {
+			return fmt.Errorf("step hook at interval %d failed at step %d: %w", x.interval, step, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package mipsevm
+
+import "github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+
+// SyscallFaultInjector fails syscalls of the guest with an errno instead of executing them,
+// to exercise the error paths of the guest in tests.
+//
+// A failed syscall has no side effects: the VM only sets the errno registers and advances the PC.
+// The onchain VM executes the syscall instead, so steps with a failed syscall cannot be proven.
+type SyscallFaultInjector interface {
+	// InjectSyscallFault is called before each syscall the guest executes, with the Ret of the call unset.
+	// It returns the errno the syscall fails with, and whether the syscall fails.
+	InjectSyscallFault(call SyscallCall) (errno arch.Word, fail bool)
+}
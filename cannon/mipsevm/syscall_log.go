@@ -0,0 +1,164 @@
+package mipsevm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// SyscallCall is a syscall executed by the guest, as observed by the VM.
+type SyscallCall struct {
+	Step     uint64    `json:"step"`
+	ThreadId arch.Word `json:"thread_id"`
+	Num      arch.Word `json:"num"`
+	// Args are the first 3 arguments of the syscall.
+	Args [3]arch.Word `json:"args"`
+	// Ret are the return value and the errno registers after the syscall.
+	Ret [2]arch.Word `json:"ret"`
+}
+
+func (c SyscallCall) String() string {
+	name := arch.SyscallName(c.Num)
+	if name == "" {
+		name = fmt.Sprintf("syscall_%d", c.Num)
+	}
+	return fmt.Sprintf("%s(0x%x, 0x%x, 0x%x) = (0x%x, 0x%x) by thread %d at step %d",
+		name, c.Args[0], c.Args[1], c.Args[2], c.Ret[0], c.Ret[1], c.ThreadId, c.Step)
+}
+
+// SyscallObserver is notified after each syscall the guest executes.
+// An error returned by the observer is returned by the Step call that executed the syscall.
+type SyscallObserver interface {
+	ObserveSyscall(call SyscallCall) error
+}
+
+// RecordedPreimage is a preimage that the oracle returned during a syscall.
+type RecordedPreimage struct {
+	Key   common.Hash   `json:"key"`
+	Value hexutil.Bytes `json:"value"`
+}
+
+// SyscallRecord is a syscall with the oracle interactions that happened during it.
+type SyscallRecord struct {
+	SyscallCall
+	Name      string             `json:"name,omitempty"`
+	Hints     []hexutil.Bytes    `json:"hints,omitempty"`
+	Preimages []RecordedPreimage `json:"preimages,omitempty"`
+}
+
+// SyscallRecorder writes every syscall of the guest, with the hints and preimages of the oracle interactions,
+// as a line of JSON per syscall. The recording can be replayed with a SyscallReplayer.
+//
+// The recorder wraps the oracle of the VM, to capture the oracle interactions, and observes the syscalls of the VM.
+type SyscallRecorder struct {
+	po  PreimageOracle
+	enc *json.Encoder
+
+	hints     []hexutil.Bytes
+	preimages []RecordedPreimage
+}
+
+var (
+	_ PreimageOracle  = (*SyscallRecorder)(nil)
+	_ SyscallObserver = (*SyscallRecorder)(nil)
+)
+
+func NewSyscallRecorder(po PreimageOracle, w io.Writer) *SyscallRecorder {
+	return &SyscallRecorder{po: po, enc: json.NewEncoder(w)}
+}
+
+func (r *SyscallRecorder) Hint(v []byte) {
+	r.hints = append(r.hints, bytes.Clone(v))
+	r.po.Hint(v)
+}
+
+func (r *SyscallRecorder) GetPreimage(k [32]byte) []byte {
+	v := r.po.GetPreimage(k)
+	r.preimages = append(r.preimages, RecordedPreimage{Key: k, Value: v})
+	return v
+}
+
+func (r *SyscallRecorder) ObserveSyscall(call SyscallCall) error {
+	rec := SyscallRecord{
+		SyscallCall: call,
+		Name:        arch.SyscallName(call.Num),
+		Hints:       r.hints,
+		Preimages:   r.preimages,
+	}
+	r.hints = nil
+	r.preimages = nil
+	if err := r.enc.Encode(rec); err != nil {
+		return fmt.Errorf("failed to record syscall at step %d: %w", call.Step, err)
+	}
+	return nil
+}
+
+// ErrSyscallDiverged is returned when a replayed syscall does not match the recording.
+var ErrSyscallDiverged = errors.New("syscall diverged from the recording")
+
+// SyscallReplayer serves the preimages of a recording of a SyscallRecorder as oracle, so the run can be reproduced
+// without a preimage server, and checks that the syscalls of the guest match the recording.
+// Hints are not needed to replay the run, and are ignored.
+type SyscallReplayer struct {
+	records   []SyscallRecord
+	next      int
+	preimages map[common.Hash][]byte
+}
+
+var (
+	_ PreimageOracle  = (*SyscallReplayer)(nil)
+	_ SyscallObserver = (*SyscallReplayer)(nil)
+)
+
+// NewSyscallReplayer reads the recording of a SyscallRecorder.
+func NewSyscallReplayer(r io.Reader) (*SyscallReplayer, error) {
+	out := &SyscallReplayer{preimages: make(map[common.Hash][]byte)}
+	dec := json.NewDecoder(r)
+	for {
+		var rec SyscallRecord
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode syscall record %d: %w", len(out.records), err)
+		}
+		for _, p := range rec.Preimages {
+			out.preimages[p.Key] = p.Value
+		}
+		out.records = append(out.records, rec)
+	}
+	return out, nil
+}
+
+func (r *SyscallReplayer) Hint(v []byte) {}
+
+func (r *SyscallReplayer) GetPreimage(k [32]byte) []byte {
+	v, ok := r.preimages[k]
+	if !ok {
+		panic(fmt.Sprintf("preimage %x was not recorded", k))
+	}
+	return v
+}
+
+func (r *SyscallReplayer) ObserveSyscall(call SyscallCall) error {
+	if r.next >= len(r.records) {
+		return fmt.Errorf("%w: %s was not recorded, the recording has %d syscalls", ErrSyscallDiverged, call, len(r.records))
+	}
+	rec := r.records[r.next]
+	if rec.SyscallCall != call {
+		return fmt.Errorf("%w: syscall %d is %s, recorded %s", ErrSyscallDiverged, r.next, call, rec.SyscallCall)
+	}
+	r.next++
+	return nil
+}
+
+// Remaining returns the number of recorded syscalls that were not replayed yet.
+func (r *SyscallReplayer) Remaining() int {
+	return len(r.records) - r.next
+}
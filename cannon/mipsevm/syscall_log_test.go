@@ -0,0 +1,71 @@
+package mipsevm
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestSyscallRecordReplay(t *testing.T) {
+	po := newHintedOracle()
+	hint, key := po.add("l2-block", "block data")
+	read := SyscallCall{Step: 10, ThreadId: 1, Num: arch.SysRead, Args: [3]arch.Word{5, 0x1000, 32}, Ret: [2]arch.Word{18, 0}}
+	write := SyscallCall{Step: 20, ThreadId: 1, Num: arch.SysWrite, Args: [3]arch.Word{1, 0x2000, 5}, Ret: [2]arch.Word{5, 0}}
+
+	var recording bytes.Buffer
+	recorder := NewSyscallRecorder(po, &recording)
+	recorder.Hint(hint)
+	require.Equal(t, []byte("block data"), recorder.GetPreimage(key))
+	require.NoError(t, recorder.ObserveSyscall(read))
+	require.NoError(t, recorder.ObserveSyscall(write))
+	require.Equal(t, []string{string(hint)}, po.hints)
+
+	lines := strings.Split(strings.TrimSpace(recording.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"name":"read"`)
+	require.Contains(t, lines[0], `"preimages"`)
+	require.NotContains(t, lines[1], `"preimages"`, "oracle interactions are recorded with the syscall they happened in")
+
+	t.Run("matching", func(t *testing.T) {
+		replayer, err := NewSyscallReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, 2, replayer.Remaining())
+		replayer.Hint(hint)
+		require.Equal(t, []byte("block data"), replayer.GetPreimage(key))
+		require.NoError(t, replayer.ObserveSyscall(read))
+		require.NoError(t, replayer.ObserveSyscall(write))
+		require.Zero(t, replayer.Remaining())
+
+		err = replayer.ObserveSyscall(SyscallCall{Step: 30, Num: arch.SysExitGroup})
+		require.ErrorIs(t, err, ErrSyscallDiverged)
+		require.ErrorContains(t, err, "exit_group")
+	})
+
+	t.Run("diverging", func(t *testing.T) {
+		replayer, err := NewSyscallReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+		diverged := read
+		diverged.Ret[0] = 17
+		err = replayer.ObserveSyscall(diverged)
+		require.ErrorIs(t, err, ErrSyscallDiverged)
+		require.ErrorContains(t, err, "syscall 0 is read(0x5, 0x1000, 0x20) = (0x11, 0x0)")
+		require.Equal(t, 2, replayer.Remaining())
+	})
+
+	t.Run("unrecorded preimage", func(t *testing.T) {
+		replayer, err := NewSyscallReplayer(bytes.NewReader(recording.Bytes()))
+		require.NoError(t, err)
+		require.PanicsWithValue(t, "preimage 0000000000000000000000000000000000000000000000000000000000000000 was not recorded", func() {
+			replayer.GetPreimage([32]byte{})
+		})
+	})
+
+	t.Run("invalid recording", func(t *testing.T) {
+		_, err := NewSyscallReplayer(strings.NewReader(lines[0] + "\n{"))
+		require.ErrorContains(t, err, "failed to decode syscall record 1")
+	})
+}
@@ -0,0 +1,22 @@
+package mipsevm
+
+// SyscallStat holds the aggregated statistics for a single syscall number.
+type SyscallStat struct {
+	SyscallNum uint64 `json:"syscall_num"`
+	Name       string `json:"name,omitempty"`
+	Count      uint64 `json:"count"`
+	FirstStep  uint64 `json:"first_step"`
+	LastStep   uint64 `json:"last_step"`
+	// TotalTimeNs is the cumulative host time spent handling the syscall, including any preimage oracle round trips.
+	TotalTimeNs uint64 `json:"total_time_ns"`
+	MaxTimeNs   uint64 `json:"max_time_ns"`
+}
+
+// SyscallStats is the per-run syscall statistics artifact.
+type SyscallStats struct {
+	TotalSteps    uint64 `json:"total_steps"`
+	TotalSyscalls uint64 `json:"total_syscalls"`
+	TotalTimeNs   uint64 `json:"total_time_ns"`
+	// Syscalls is ordered by descending call count
+	Syscalls []SyscallStat `json:"syscalls"`
+}
@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+// DifferentialConfig configures a cross-version differential execution check.
+type DifferentialConfig struct {
+	// Previous and Current are the state versions to compare.
+	Previous versions.StateVersion
+	Current  versions.StateVersion
+	MaxSteps uint64
+	// CheckpointInterval is the number of steps between state hash comparisons.
+	CheckpointInterval uint64
+}
+
+// DefaultDifferentialConfig compares the current version with the experimental version,
+// which is the next version to be released.
+func DefaultDifferentialConfig() DifferentialConfig {
+	return DifferentialConfig{
+		Previous:           versions.GetCurrentVersion(),
+		Current:            versions.GetExperimentalVersion(),
+		MaxSteps:           5_000_000,
+		CheckpointInterval: 50_000,
+	}
+}
+
+// DifferentialCase is a program that is executed on both versions of a differential check.
+type DifferentialCase struct {
+	Program  string
+	GoTarget testutil.GoTarget
+	// NewOracle creates the preimage oracle of each execution. No oracle is used if nil.
+	NewOracle func() mipsevm.PreimageOracle
+	// ExpectedDifference documents why the versions are expected to execute the program differently.
+	// The check then asserts that the executions still differ, so the documented difference is removed with the change.
+	ExpectedDifference string
+}
+
+// SupportsGoTarget returns true if a VM with the given features can run programs built for the Go target.
+func SupportsGoTarget(features mipsevm.FeatureToggles, goTarget testutil.GoTarget) bool {
+	// The Go 1.24 runtime requires a working getrandom syscall
+	return goTarget != testutil.Go1_24 || features.SupportWorkingSysGetRandom
+}
+
+// RunDifferentialCheck executes every program on the previous and current version, and asserts that
+// both executions took the same steps, through the same states, and produced the same output and exit status.
+// Programs that are not supported by the features of both versions are skipped.
+// Not parallel: the executions modify the environment, see testutil.RunWithHostEnv.
+func RunDifferentialCheck(t *testing.T, cfg DifferentialConfig, cases []DifferentialCase) {
+	if cfg.Previous == cfg.Current {
+		t.Skipf("no previous version to compare %v with", cfg.Current)
+	}
+	for _, c := range cases {
+		t.Run(c.Program+"-"+string(c.GoTarget), func(t *testing.T) {
+			for _, version := range []versions.StateVersion{cfg.Previous, cfg.Current} {
+				if !SupportsGoTarget(versions.FeaturesForVersion(version), c.GoTarget) {
+					t.Skipf("%v programs are not supported by %v", c.GoTarget, version)
+				}
+			}
+			prev := runDifferentialVersion(t, cfg.Previous, c, cfg)
+			cur := runDifferentialVersion(t, cfg.Current, c, cfg)
+			err := testutil.CompareRuns(prev, cur)
+			if c.ExpectedDifference == "" {
+				require.NoErrorf(t, err, "unexpected change in behaviour from %v to %v", cfg.Previous, cfg.Current)
+				return
+			}
+			require.Errorf(t, err, "expected difference from %v to %v no longer exists: %s", cfg.Previous, cfg.Current, c.ExpectedDifference)
+			t.Logf("Expected difference from %v to %v, %s: %v", cfg.Previous, cfg.Current, c.ExpectedDifference, err)
+		})
+	}
+}
+
+func runDifferentialVersion(t *testing.T, version versions.StateVersion, c DifferentialCase, cfg DifferentialConfig) *testutil.DeterminismRun {
+	features := versions.FeaturesForVersion(version)
+	vmFactory := func(state *multithreaded.State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, meta *program.Metadata) mipsevm.FPVM {
+		return multithreaded.NewInstrumentedState(state, po, stdOut, stdErr, log, meta, features)
+	}
+	var run *testutil.DeterminismRun
+	t.Run(version.String(), func(t *testing.T) {
+		run = testutil.RunWithHostEnv(t, testutil.ProgramPath(c.Program, c.GoTarget), multithreaded.CreateInitialState, vmFactory,
+			testutil.HostEnv{Name: version.String()},
+			testutil.DeterminismConfig{MaxSteps: cfg.MaxSteps, CheckpointInterval: cfg.CheckpointInterval, NewOracle: c.NewOracle})
+	})
+	require.NotNil(t, run, "execution on %v must complete", version)
+	return run
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+// TestDifferential_Versions checks that the next release of the VM executes the test programs
+// exactly like the current release, except for the documented differences.
+// The expected differences must be revisited whenever the compared versions change.
+func TestDifferential_Versions(t *testing.T) {
+	claimOracle := func() mipsevm.PreimageOracle {
+		oracle, _, _ := testutil.ClaimTestOracle(t)
+		return oracle
+	}
+	var cases []DifferentialCase
+	for _, goTarget := range []testutil.GoTarget{testutil.Go1_23, testutil.Go1_24} {
+		cases = append(cases,
+			DifferentialCase{Program: "hello", GoTarget: goTarget},
+			DifferentialCase{Program: "claim", GoTarget: goTarget, NewOracle: claimOracle},
+			DifferentialCase{Program: "mt-general", GoTarget: goTarget},
+			DifferentialCase{Program: "random", GoTarget: goTarget,
+				ExpectedDifference: "getrandom returns random bytes from multithreaded64-5, it was a noop before"},
+		)
+	}
+	RunDifferentialCheck(t, DefaultDifferentialConfig(), cases)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	mttestutil "github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
 )
@@ -574,6 +575,10 @@ func getNoopSyscalls64(vmVersion versions.StateVersion) map[string]uint32 {
 	if features.SupportWorkingSysGetRandom {
 		delete(noOpCalls, "SysGetRandom")
 	}
+	if features.SupportMuslRuntime {
+		// sigaltstack writes the flags of the old stack
+		delete(noOpCalls, "SysSigaltstack")
+	}
 	return noOpCalls
 }
 
@@ -584,6 +589,9 @@ func getSupportedSyscalls(vmVersion versions.StateVersion) []uint32 {
 	if features.SupportWorkingSysGetRandom {
 		supportedSyscalls = append(supportedSyscalls, arch.SysGetRandom)
 	}
+	if features.SupportMuslRuntime {
+		supportedSyscalls = append(supportedSyscalls, arch.SysSetThreadArea, arch.SysSetTidAddress, arch.SysSetRobustList, arch.SysGetRobustList, arch.SysPoll, arch.SysMremap, arch.SysSigaltstack)
+	}
 	return supportedSyscalls
 }
 
@@ -638,3 +646,101 @@ func TestEVM_UndefinedSyscall(t *testing.T) {
 		}
 	}
 }
+
+func TestEVM_MuslRuntime(t *testing.T) {
+	const oldStack = Word(0x2000)
+	cases := []struct {
+		name        string
+		syscallNum  Word
+		a0, a1      Word
+		expectedV0  Word
+		expectedV1  Word
+		expectation func(t *testing.T, expected *mttestutil.ExpectedMTState)
+	}{
+		{name: "set_thread_area", syscallNum: arch.SysSetThreadArea, a0: 0x1234_7000, expectation: func(t *testing.T, expected *mttestutil.ExpectedMTState) {
+			expected.ActiveThread().Registers[register.RegThreadPointer] = 0x1234_7000
+		}},
+		{name: "set_tid_address", syscallNum: arch.SysSetTidAddress, a0: 0x2000, expectation: func(t *testing.T, expected *mttestutil.ExpectedMTState) {
+			expected.ActiveThread().Registers[2] = expected.ActiveThread().ThreadId
+		}},
+		{name: "set_robust_list", syscallNum: arch.SysSetRobustList, a0: 0x2000, a1: exec.RobustListHeadSize},
+		{name: "set_robust_list with invalid length", syscallNum: arch.SysSetRobustList, a0: 0x2000, a1: 12, expectedV0: exec.MipsEINVAL, expectedV1: exec.SysErrorSignal},
+		{name: "get_robust_list", syscallNum: arch.SysGetRobustList, a1: 0x2000, expectedV0: exec.MipsENOSYS, expectedV1: exec.SysErrorSignal},
+		{name: "poll", syscallNum: arch.SysPoll, a0: 0x2000, a1: 3},
+		{name: "mremap", syscallNum: arch.SysMremap, a0: 0x2000, a1: 0x1000, expectedV0: exec.MipsENOSYS, expectedV1: exec.SysErrorSignal},
+		{name: "sigaltstack", syscallNum: arch.SysSigaltstack, a1: oldStack, expectation: func(t *testing.T, expected *mttestutil.ExpectedMTState) {
+			expected.ExpectMemoryWriteUint32(t, oldStack+exec.SigaltstackFlagsOffset, exec.SigaltstackDisable)
+		}},
+		{name: "sigaltstack without old stack", syscallNum: arch.SysSigaltstack},
+	}
+
+	for _, ver := range GetMipsVersionTestCases(t) {
+		if !versions.FeaturesForVersion(ver.Version).SupportMuslRuntime {
+			continue
+		}
+		for i, c := range cases {
+			t.Run(fmt.Sprintf("%v (%v)", c.name, ver.Name), func(t *testing.T) {
+				t.Parallel()
+				goVm, state, contracts := setupWithTestCase(t, ver, i*2333, nil)
+				testutil.StoreInstruction(state.Memory, state.GetPC(), syscallInsn)
+				state.GetRegistersRef()[2] = c.syscallNum
+				state.GetRegistersRef()[4] = c.a0
+				state.GetRegistersRef()[5] = c.a1
+				step := state.Step
+
+				expected := mttestutil.NewExpectedMTState(state)
+				expected.ExpectStep()
+				expected.ActiveThread().Registers[2] = c.expectedV0
+				expected.ActiveThread().Registers[7] = c.expectedV1
+				if c.expectation != nil {
+					c.expectation(t, expected)
+				}
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				expected.Validate(t, state)
+				testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts)
+			})
+		}
+	}
+}
+
+func TestEVM_Rdhwr(t *testing.T) {
+	cases := []struct {
+		name  string
+		insn  uint32
+		valid bool
+	}{
+		{name: "UserLocal", insn: 0x7c_03_e8_3b, valid: true},                // rdhwr $3, $29
+		{name: "other hardware register", insn: 0x7c_03_10_3b, valid: false}, // rdhwr $3, $2
+	}
+
+	for _, ver := range GetMipsVersionTestCases(t) {
+		for i, c := range cases {
+			t.Run(fmt.Sprintf("%v (%v)", c.name, ver.Name), func(t *testing.T) {
+				t.Parallel()
+				goVm, state, contracts := setupWithTestCase(t, ver, i*7919, nil)
+				testutil.StoreInstruction(state.Memory, state.GetPC(), c.insn)
+				state.GetRegistersRef()[register.RegThreadPointer] = 0x1234_7000
+				step := state.Step
+
+				if !c.valid || !versions.FeaturesForVersion(ver.Version).SupportMuslRuntime {
+					proofData := multiThreadedProofGenerator(t, state)
+					_, err := goVm.Step(true)
+					require.ErrorContains(t, err, "invalid instruction")
+					testutil.AssertEVMReverts(t, state, contracts, nil, proofData, testutil.CreateErrorStringMatcher("invalid instruction"))
+					return
+				}
+
+				expected := mttestutil.NewExpectedMTState(state)
+				expected.ExpectStep()
+				expected.ActiveThread().Registers[3] = 0x1234_7000
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				expected.Validate(t, state)
+				testutil.ValidateEVM(t, stepWitness, step, goVm, multithreaded.GetStateHashFn(), contracts)
+			})
+		}
+	}
+}
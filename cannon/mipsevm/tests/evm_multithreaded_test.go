@@ -806,6 +806,14 @@ func TestEVM_SysClockGettimeRealtime(t *testing.T) {
 	testEVM_SysClockGettime(t, exec.ClockGettimeRealtimeFlag)
 }
 
+func TestEVM_SysClockGettimeThreadCputime(t *testing.T) {
+	testEVM_SysClockGettime(t, exec.ClockGettimeThreadCputimeFlag)
+}
+
+func TestEVM_SysClockGettimeBoottime(t *testing.T) {
+	testEVM_SysClockGettime(t, exec.ClockGettimeBoottimeFlag)
+}
+
 func testEVM_SysClockGettime(t *testing.T, clkid Word) {
 	llVariations := []struct {
 		name                   string
@@ -873,17 +881,24 @@ func testEVM_SysClockGettime(t *testing.T, clkid Word) {
 
 					expected := mttestutil.NewExpectedMTState(state)
 					expected.ExpectStep()
-					expected.ActiveThread().Registers[2] = 0
-					expected.ActiveThread().Registers[7] = 0
-					next := state.Step + 1
-					var secs, nsecs Word
-					if clkid == exec.ClockGettimeMonotonicFlag {
-						secs = Word(next / exec.HZ)
-						nsecs = Word((next % exec.HZ) * (1_000_000_000 / exec.HZ))
+					extendedClock := clkid == exec.ClockGettimeThreadCputimeFlag || clkid == exec.ClockGettimeBoottimeFlag
+					supported := !extendedClock || versions.FeaturesForVersion(ver.Version).SupportExtendedClockGettime
+					if !supported {
+						expected.ActiveThread().Registers[2] = exec.MipsEINVAL
+						expected.ActiveThread().Registers[7] = exec.SysErrorSignal
+					} else {
+						expected.ActiveThread().Registers[2] = 0
+						expected.ActiveThread().Registers[7] = 0
+						next := state.Step + 1
+						var secs, nsecs Word
+						if clkid != exec.ClockGettimeRealtimeFlag {
+							secs = Word(next / exec.HZ)
+							nsecs = Word((next % exec.HZ) * (1_000_000_000 / exec.HZ))
+						}
+						expected.ExpectMemoryWordWrite(effAddr, secs)
+						expected.ExpectMemoryWordWrite(effAddr2, nsecs)
 					}
-					expected.ExpectMemoryWordWrite(effAddr, secs)
-					expected.ExpectMemoryWordWrite(effAddr2, nsecs)
-					if llVar.shouldClearReservation {
+					if llVar.shouldClearReservation && supported {
 						expected.LLReservationStatus = multithreaded.LLStatusNone
 						expected.LLAddress = 0
 						expected.LLOwnerThread = 0
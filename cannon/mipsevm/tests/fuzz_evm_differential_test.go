@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+// Instruction encodings that the differential fuzzer generates: every opcode implemented by the VMs, and the
+// valid values of the field that selects the operation for the opcodes that have one.
+// Syscalls are not generated, their behaviour depends on the preimage oracle and is covered by the syscall fuzzers.
+var (
+	differentialOpcodes = []uint32{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+		0x18, 0x19, exec.OpLoadDoubleLeft, exec.OpLoadDoubleRight, 0x1c, exec.OpSpecial3,
+		0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28, 0x29, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e,
+		exec.OpLoadLinked, exec.OpLoadLinked64, 0x37, exec.OpStoreConditional, exec.OpStoreConditional64, 0x3f,
+	}
+	differentialSpecialFuns = []uint32{
+		0x00, 0x02, 0x03, 0x04, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0f,
+		0x10, 0x11, 0x12, 0x13, 0x14, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+		0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x2a, 0x2b, 0x2c, 0x2d, 0x2e, 0x2f,
+		0x38, 0x3a, 0x3b, 0x3c, 0x3e, 0x3f,
+	}
+	differentialSpecial2Funs = []uint32{0x02, 0x20, 0x21, 0x24, 0x25}
+	differentialRegimmRts    = []uint32{0x00, 0x01, 0x10, 0x11}
+)
+
+// toDifferentialInstruction maps an arbitrary instruction to one of the encodings of the differential fuzzer.
+// Valid instructions are kept as is, so that seeds from the existing test cases run their instruction.
+func toDifferentialInstruction(insn uint32) uint32 {
+	opcode := pickEncoding(differentialOpcodes, insn>>26)
+	insn = opcode<<26 | insn&0x03_FF_FF_FF
+	switch opcode {
+	case 0x00:
+		return insn&^0x3F | pickEncoding(differentialSpecialFuns, insn&0x3F)
+	case 0x1c:
+		return insn&^0x3F | pickEncoding(differentialSpecial2Funs, insn&0x3F)
+	case 0x01:
+		return insn&^(0x1F<<16) | pickEncoding(differentialRegimmRts, (insn>>16)&0x1F)<<16
+	case exec.OpSpecial3:
+		// rdhwr of the UserLocal hardware register, the only SPECIAL3 instruction
+		return insn&^(0x1F<<11|0x3F) | exec.HwrUserLocal<<11 | exec.FunRdhwr
+	}
+	return insn
+}
+
+func pickEncoding(valid []uint32, v uint32) uint32 {
+	if slices.Contains(valid, v) {
+		return v
+	}
+	return valid[v%uint32(len(valid))]
+}
+
+// encodeOperatorTestCase returns the instruction of an operator test case, see testOperators.
+func encodeOperatorTestCase(tt operatorTestCase) uint32 {
+	if tt.isImm {
+		return tt.opcode<<26 | 17<<21 | 8<<16 | uint32(tt.imm)
+	}
+	return 17<<21 | 18<<16 | 8<<11 | tt.funct
+}
+
+// FuzzStateDifferential executes random instructions on random states with the Go VM and the MIPS contract,
+// and asserts that both produce the same post-state, or both fail.
+func FuzzStateDifferential(f *testing.F) {
+	for i, tt := range append(slices.Clone(operatorTestCases), bitwiseTestCases...) {
+		f.Add(encodeOperatorTestCase(tt), randomizeUpperWord(signExtend64(tt.rs)), randomizeUpperWord(signExtend64(tt.rt)), int64(i))
+	}
+	for i, tt := range append(slices.Clone(operator64TestCases), bitwise64TestCases...) {
+		f.Add(encodeOperatorTestCase(tt), tt.rs, tt.rt, int64(i))
+	}
+
+	versions := GetMipsVersionTestCases(f)
+	f.Fuzz(func(t *testing.T, insn uint32, rs Word, rt Word, seed int64) {
+		insn = toDifferentialInstruction(insn)
+		for _, v := range versions {
+			t.Run(v.Name, func(t *testing.T) {
+				differentialStep(t, v, insn, rs, rt, seed)
+			})
+		}
+	})
+}
+
+func differentialStep(t *testing.T, v VersionedVMTestCase, insn uint32, rs Word, rt Word, seed int64) {
+	newVM := func() mipsevm.FPVM {
+		goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), testutil.WithRandomization(seed))
+		state := goVm.GetState()
+		// $zero is left as is, the VMs never write it
+		if rsReg := (insn >> 21) & 0x1F; rsReg != 0 {
+			state.GetRegistersRef()[rsReg] = rs
+		}
+		if rtReg := (insn >> 16) & 0x1F; rtReg != 0 {
+			state.GetRegistersRef()[rtReg] = rt
+		}
+		testutil.StoreInstruction(state.GetMemory(), state.GetPC(), insn)
+		return goVm
+	}
+
+	goVm := newVM()
+	step := goVm.GetState().GetStep()
+	var stepWitness *mipsevm.StepWitness
+	var err error
+	panicked := func() (panicked bool) {
+		defer func() {
+			if recover() != nil {
+				panicked = true
+			}
+		}()
+		stepWitness, err = goVm.Step(true)
+		return false
+	}()
+	if panicked || err != nil {
+		t.Logf("Go VM failed to execute instruction 0x%08x, panicked: %v, err: %v", insn, panicked, err)
+		// The step failed part-way, so the prestate is recreated for the contract.
+		// The memory proof is of the effective address of loads and stores, see the memory proof of TestEVM_Fault.
+		state := newVM().GetState()
+		effAddr := (state.GetRegistersRef()[(insn>>21)&0x1F] + exec.SignExtendImmediate(insn)) & arch.AddressMask
+		proofData := v.ProofGenerator(t, state, effAddr)
+		testutil.AssertEVMReverts(t, state, v.Contracts, nil, proofData, testutil.CreateNoopErrorMatcher())
+		return
+	}
+	testutil.ValidateEVM(t, stepWitness, step, goVm, v.StateHashFn, v.Contracts)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	mttestutil "github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded/testutil"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
 
@@ -30,6 +31,15 @@ type ElfVMFactory func(t require.TestingT, elfFile string, po mipsevm.PreimageOr
 
 func multiThreadElfVmFactory(t require.TestingT, elfFile string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, features mipsevm.FeatureToggles) mipsevm.FPVM {
 	state, meta := testutil.LoadELFProgram(t, elfFile, multithreaded.CreateInitialState)
+	return newMultiThreadElfVm(t, state, meta, po, stdOut, stdErr, log, features)
+}
+
+func multiThreadMuslElfVmFactory(t require.TestingT, elfFile string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, features mipsevm.FeatureToggles) mipsevm.FPVM {
+	state, meta := testutil.LoadMuslELFProgram(t, elfFile, multithreaded.CreateInitialState)
+	return newMultiThreadElfVm(t, state, meta, po, stdOut, stdErr, log, features)
+}
+
+func newMultiThreadElfVm(t require.TestingT, state *multithreaded.State, meta *program.Metadata, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, features mipsevm.FeatureToggles) mipsevm.FPVM {
 	fpvm := multithreaded.NewInstrumentedState(state, po, stdOut, stdErr, log, meta, features)
 	require.NoError(t, fpvm.InitDebug())
 	return fpvm
@@ -56,14 +66,16 @@ func multiThreadedProofGenerator(t require.TestingT, state mipsevm.FPVMState, me
 }
 
 type VersionedVMTestCase struct {
-	Name           string
-	Contracts      *testutil.ContractMetadata
-	StateHashFn    mipsevm.HashFn
-	VMFactory      VMFactory
-	ElfVMFactory   ElfVMFactory
-	ProofGenerator ProofGenerator
-	Version        versions.StateVersion
-	GoTarget       testutil.GoTarget
+	Name         string
+	Contracts    *testutil.ContractMetadata
+	StateHashFn  mipsevm.HashFn
+	VMFactory    VMFactory
+	ElfVMFactory ElfVMFactory
+	// MuslElfVMFactory loads statically linked musl programs, see testutil.LoadMuslELFProgram.
+	MuslElfVMFactory ElfVMFactory
+	ProofGenerator   ProofGenerator
+	Version          versions.StateVersion
+	GoTarget         testutil.GoTarget
 }
 
 func GetMultiThreadedTestCase(t require.TestingT, version versions.StateVersion, goTarget testutil.GoTarget) VersionedVMTestCase {
@@ -78,6 +90,9 @@ func GetMultiThreadedTestCase(t require.TestingT, version versions.StateVersion,
 		ElfVMFactory: func(t require.TestingT, elfFile string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger) mipsevm.FPVM {
 			return multiThreadElfVmFactory(t, elfFile, po, stdOut, stdErr, log, features)
 		},
+		MuslElfVMFactory: func(t require.TestingT, elfFile string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger) mipsevm.FPVM {
+			return multiThreadMuslElfVmFactory(t, elfFile, po, stdOut, stdErr, log, features)
+		},
 		ProofGenerator: multiThreadedProofGenerator,
 		Version:        version,
 		GoTarget:       goTarget,
@@ -90,7 +105,7 @@ func GetMipsVersionTestCases(t require.TestingT) []VersionedVMTestCase {
 		if !arch.IsMips32 && versions.IsSupportedMultiThreaded64(version) {
 			goTarget := testutil.Go1_23
 			features := versions.FeaturesForVersion(version)
-			if features.SupportWorkingSysGetRandom {
+			if SupportsGoTarget(features, testutil.Go1_24) {
 				goTarget = testutil.Go1_24
 			}
 			cases = append(cases, GetMultiThreadedTestCase(t, version, goTarget))
@@ -0,0 +1,15 @@
+package tests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	// Surface the worst-case witness size of the suite, to spot growth before it reaches the ceiling.
+	testutil.ReportWitnessSizes(os.Stdout)
+	os.Exit(code)
+}
@@ -0,0 +1,212 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/register"
+)
+
+// HostEnv describes the host conditions that a determinism audit run executes under.
+// Every run also gets its own fresh TMPDIR.
+type HostEnv struct {
+	Name string
+	// Env holds the environment variables to set for the duration of the run.
+	Env map[string]string
+	// Delay is slept every DelayInterval steps, to skew the wall-clock time observed by the host between runs.
+	Delay         time.Duration
+	DelayInterval uint64
+}
+
+// DefaultHostEnvs returns two host environments that differ in their environment variables and wall-clock timing.
+func DefaultHostEnvs() []HostEnv {
+	return []HostEnv{
+		{
+			Name: "plain",
+			Env:  map[string]string{"TZ": "UTC", "LANG": "C"},
+		},
+		{
+			Name:          "skewed",
+			Env:           map[string]string{"TZ": "Asia/Tokyo", "LANG": "ja_JP.UTF-8", "CANNON_DETERMINISM_AUDIT": "1"},
+			Delay:         time.Millisecond,
+			DelayInterval: 100_000,
+		},
+	}
+}
+
+type DeterminismConfig struct {
+	MaxSteps uint64
+	// CheckpointInterval is the number of steps between state hash checkpoints.
+	CheckpointInterval uint64
+	// NewOracle creates the preimage oracle of each run. No oracle is used if nil.
+	NewOracle func() mipsevm.PreimageOracle
+}
+
+type Checkpoint struct {
+	Step      uint64
+	StateHash common.Hash
+	// PC and Insn are the next instruction to execute, to locate a divergence in the program.
+	PC   arch.Word
+	Insn uint32
+}
+
+func (c Checkpoint) String() string {
+	return fmt.Sprintf("state %s at step %d, pc 0x%x (%s)", c.StateHash, c.Step, c.PC, disasm.Disassemble(uint64(c.PC), c.Insn))
+}
+
+// SyscallRecord is a syscall executed by the guest program, and the results it returned.
+type SyscallRecord struct {
+	Step    uint64
+	Syscall arch.Word
+	V0      arch.Word
+	A3      arch.Word
+}
+
+func (r SyscallRecord) String() string {
+	return fmt.Sprintf("%s (%d) at step %d", arch.SyscallName(r.Syscall), r.Syscall, r.Step)
+}
+
+// DeterminismRun is the execution trace of a single determinism audit run.
+type DeterminismRun struct {
+	Env         string
+	Checkpoints []Checkpoint
+	Syscalls    []SyscallRecord
+	Stdout      []byte
+	Stderr      []byte
+	Exited      bool
+	ExitCode    uint8
+}
+
+// RunWithHostEnv runs the program to completion, or MaxSteps, under the given host environment,
+// recording a state hash every CheckpointInterval steps, and the results of every syscall.
+// The environment is modified with t.Setenv, so the test must not be parallel.
+func RunWithHostEnv[T mipsevm.FPVMState](t *testing.T, programPath string, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], env HostEnv, cfg DeterminismConfig) *DeterminismRun {
+	t.Setenv("TMPDIR", t.TempDir())
+	for k, v := range env.Env {
+		t.Setenv(k, v)
+	}
+
+	state, meta := LoadELFProgram(t, programPath, initState)
+	var oracle mipsevm.PreimageOracle
+	if cfg.NewOracle != nil {
+		oracle = cfg.NewOracle()
+	}
+	var stdOut, stdErr bytes.Buffer
+	vm := vmFactory(state, oracle, &stdOut, &stdErr, CreateLogger(), meta)
+
+	run := &DeterminismRun{Env: env.Name}
+	checkpoint := func(view mipsevm.StateView) error {
+		_, hash := view.EncodeWitness()
+		pc := view.GetPC()
+		insn := binary.BigEndian.Uint32(view.ReadMemoryRegion(pc, 4))
+		run.Checkpoints = append(run.Checkpoints, Checkpoint{Step: view.GetStep(), StateHash: hash, PC: pc, Insn: insn})
+		return nil
+	}
+	require.NoError(t, checkpoint(mipsevm.NewStateView(state)))
+	if cfg.CheckpointInterval > 0 {
+		vm.Hooks().Every(cfg.CheckpointInterval, checkpoint)
+	}
+	if env.DelayInterval > 0 {
+		vm.Hooks().Every(env.DelayInterval, func(mipsevm.StateView) error {
+			time.Sleep(env.Delay)
+			return nil
+		})
+	}
+	for !state.GetExited() && state.GetStep() < cfg.MaxSteps {
+		step := state.GetStep()
+		_, opcode, fun := exec.GetInstructionDetails(state.GetPC(), state.GetMemory())
+		isSyscall := opcode == 0 && fun == 0xC
+		syscallNum := state.GetRegistersRef()[register.RegSyscallNum]
+
+		_, err := vm.Step(false)
+		require.NoErrorf(t, err, "step %d", step)
+
+		// Note that the multithreaded VM may switch threads after a syscall,
+		// in which case the registers are those of the next thread. That is still deterministic.
+		if isSyscall {
+			regs := state.GetRegistersRef()
+			run.Syscalls = append(run.Syscalls, SyscallRecord{
+				Step:    step,
+				Syscall: syscallNum,
+				V0:      regs[register.RegSyscallRet1],
+				A3:      regs[register.RegSyscallErrno],
+			})
+		}
+	}
+	if last := run.Checkpoints[len(run.Checkpoints)-1]; last.Step != state.GetStep() {
+		require.NoError(t, checkpoint(mipsevm.NewStateView(state)))
+	}
+	run.Stdout = stdOut.Bytes()
+	run.Stderr = stdErr.Bytes()
+	run.Exited = state.GetExited()
+	run.ExitCode = state.GetExitCode()
+	return run
+}
+
+// CompareRuns checks that two runs of the same program executed identically.
+// If a syscall returned different results, that syscall is reported as having leaked host state,
+// since the diverging state hashes that follow are only a consequence of it.
+func CompareRuns(a, b *DeterminismRun) error {
+	for i := 0; i < len(a.Syscalls) && i < len(b.Syscalls); i++ {
+		x, y := a.Syscalls[i], b.Syscalls[i]
+		if x.Step != y.Step || x.Syscall != y.Syscall {
+			return fmt.Errorf("syscall %d diverged: %s in %q, but %s in %q", i, x, a.Env, y, b.Env)
+		}
+		if x.V0 != y.V0 || x.A3 != y.A3 {
+			return fmt.Errorf("syscall %s leaked host state: returned v0=%#x a3=%#x in %q, but v0=%#x a3=%#x in %q",
+				x, x.V0, x.A3, a.Env, y.V0, y.A3, b.Env)
+		}
+	}
+	for i := 0; i < len(a.Checkpoints) && i < len(b.Checkpoints); i++ {
+		x, y := a.Checkpoints[i], b.Checkpoints[i]
+		if x != y {
+			return fmt.Errorf("checkpoint %d diverged: %s in %q, but %s in %q", i, x, a.Env, y, b.Env)
+		}
+	}
+	if len(a.Checkpoints) != len(b.Checkpoints) {
+		return fmt.Errorf("number of checkpoints diverged: %d in %q, but %d in %q", len(a.Checkpoints), a.Env, len(b.Checkpoints), b.Env)
+	}
+	if len(a.Syscalls) != len(b.Syscalls) {
+		return fmt.Errorf("number of syscalls diverged: %d in %q, but %d in %q", len(a.Syscalls), a.Env, len(b.Syscalls), b.Env)
+	}
+	if !bytes.Equal(a.Stdout, b.Stdout) {
+		return fmt.Errorf("stdout diverged: %q in %q, but %q in %q", a.Stdout, a.Env, b.Stdout, b.Env)
+	}
+	if !bytes.Equal(a.Stderr, b.Stderr) {
+		return fmt.Errorf("stderr diverged: %q in %q, but %q in %q", a.Stderr, a.Env, b.Stderr, b.Env)
+	}
+	if a.Exited != b.Exited || a.ExitCode != b.ExitCode {
+		return fmt.Errorf("exit status diverged: exited=%v code=%d in %q, but exited=%v code=%d in %q",
+			a.Exited, a.ExitCode, a.Env, b.Exited, b.ExitCode, b.Env)
+	}
+	return nil
+}
+
+// RunDeterminismAudit runs the program once in each of the host environments,
+// and asserts that every run executed identically to the first.
+func RunDeterminismAudit[T mipsevm.FPVMState](t *testing.T, programPath string, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], envs []HostEnv, cfg DeterminismConfig) {
+	require.GreaterOrEqual(t, len(envs), 2, "need at least two host environments to compare")
+	runs := make([]*DeterminismRun, len(envs))
+	for i, env := range envs {
+		t.Run(env.Name, func(t *testing.T) {
+			runs[i] = RunWithHostEnv(t, programPath, initState, vmFactory, env, cfg)
+		})
+	}
+	for _, run := range runs {
+		require.NotNil(t, run, "all runs must complete")
+	}
+	for _, run := range runs[1:] {
+		require.NoError(t, CompareRuns(runs[0], run))
+	}
+}
@@ -0,0 +1,66 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestCompareRuns(t *testing.T) {
+	newRun := func(env string) *DeterminismRun {
+		return &DeterminismRun{
+			Env: env,
+			Checkpoints: []Checkpoint{
+				{Step: 0, StateHash: common.Hash{0x01}},
+				{Step: 100, StateHash: common.Hash{0x02}},
+			},
+			Syscalls: []SyscallRecord{
+				{Step: 10, Syscall: arch.SysClockGetTime, V0: 0, A3: 0},
+				{Step: 50, Syscall: arch.SysGetRandom, V0: 8, A3: 0},
+			},
+			Stdout:   []byte("hello"),
+			Exited:   true,
+			ExitCode: 0,
+		}
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		require.NoError(t, CompareRuns(newRun("a"), newRun("b")))
+	})
+
+	t.Run("syscall result leaked", func(t *testing.T) {
+		a, b := newRun("a"), newRun("b")
+		b.Syscalls[1].V0 = 4
+		b.Checkpoints[1].StateHash = common.Hash{0x03}
+		err := CompareRuns(a, b)
+		require.ErrorContains(t, err, "leaked host state")
+		require.ErrorContains(t, err, arch.SyscallName(arch.SysGetRandom))
+	})
+
+	t.Run("syscall sequence diverged", func(t *testing.T) {
+		a, b := newRun("a"), newRun("b")
+		b.Syscalls[0].Step = 11
+		require.ErrorContains(t, CompareRuns(a, b), "syscall 0 diverged")
+	})
+
+	t.Run("checkpoint diverged", func(t *testing.T) {
+		a, b := newRun("a"), newRun("b")
+		b.Checkpoints[1].StateHash = common.Hash{0x03}
+		require.ErrorContains(t, CompareRuns(a, b), "checkpoint 1 diverged")
+	})
+
+	t.Run("output diverged", func(t *testing.T) {
+		a, b := newRun("a"), newRun("b")
+		b.Stdout = []byte("world")
+		require.ErrorContains(t, CompareRuns(a, b), "stdout diverged")
+	})
+
+	t.Run("exit diverged", func(t *testing.T) {
+		a, b := newRun("a"), newRun("b")
+		b.ExitCode = 1
+		require.ErrorContains(t, CompareRuns(a, b), "exit status diverged")
+	})
+}
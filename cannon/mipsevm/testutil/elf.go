@@ -3,6 +3,8 @@ package testutil
 import (
 	"debug/elf"
 	"fmt"
+	"os"
+	"testing"
 
 	"github.com/stretchr/testify/require"
 
@@ -18,6 +20,15 @@ const (
 )
 
 func LoadELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initState program.CreateInitialFPVMState[T]) (T, *program.Metadata) {
+	return loadELFProgram(t, name, initState, false)
+}
+
+// LoadMuslELFProgram loads a statically linked musl program, with its program headers in the auxiliary vector of the initial stack.
+func LoadMuslELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initState program.CreateInitialFPVMState[T]) (T, *program.Metadata) {
+	return loadELFProgram(t, name, initState, true)
+}
+
+func loadELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initState program.CreateInitialFPVMState[T], musl bool) (T, *program.Metadata) {
 	elfProgram, err := elf.Open(name)
 	require.NoError(t, err, "open ELF file")
 	meta, err := program.MakeMetadata(elfProgram)
@@ -26,7 +37,12 @@ func LoadELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initSt
 	state, err := program.LoadELF(elfProgram, initState)
 	require.NoError(t, err, "load ELF into state")
 
-	require.NoError(t, program.PatchStack(state), "add initial stack")
+	var auxv []program.AuxvEntry
+	if musl {
+		auxv, err = program.ProgramHeadersAuxv(elfProgram)
+		require.NoError(t, err, "locate program headers")
+	}
+	require.NoError(t, program.PatchStackWithAuxv(state, auxv...), "add initial stack")
 	return state, meta
 }
 
@@ -34,3 +50,14 @@ func LoadELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initSt
 func ProgramPath(programName string, goTarget GoTarget) string {
 	return fmt.Sprintf("../../testdata/%s/bin/%s.64.elf", goTarget, programName)
 }
+
+// RustProgramPath returns the ELF test program built with Rust for linux/mips64 musl.
+// The test is skipped if the program is not built, as it requires a nightly Rust toolchain and a musl cross-compiler,
+// see testdata/rust/README.md.
+func RustProgramPath(t testing.TB, programName string) string {
+	path := fmt.Sprintf("../../testdata/rust/bin/%s.64.elf", programName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		t.Skipf("Rust test program %s is not built", programName)
+	}
+	return path
+}
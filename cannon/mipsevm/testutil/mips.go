@@ -1,11 +1,8 @@
 package testutil
 
 import (
-	"encoding/binary"
-	"errors"
 	"fmt"
 	"math"
-	"math/big"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -19,8 +16,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/onchain"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
-	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 // maxStepGas should be less than the L1 gas limit
@@ -42,6 +39,10 @@ type MIPSEVM struct {
 
 func newMIPSEVM(t testing.TB, contracts *ContractMetadata, opts ...evmOption) *MIPSEVM {
 	env, evmState := NewEVMEnv(t, contracts)
+	return newMIPSEVMWithEnv(contracts, env, evmState, opts...)
+}
+
+func newMIPSEVMWithEnv(contracts *ContractMetadata, env *vm.EVM, evmState *state.StateDB, opts ...evmOption) *MIPSEVM {
 	sender := common.Address{0x13, 0x37}
 	startingGas := uint64(maxStepGas)
 	evm := &MIPSEVM{sender, startingGas, env, evmState, contracts.Addresses, nil, contracts.Artifacts, math.MaxUint64, nil, nil}
@@ -85,106 +86,92 @@ func (m *MIPSEVM) SetSourceMapTracer(t *testing.T, version MipsVersion) {
 
 // Step is a pure function that computes the poststate from the VM state encoded in the StepWitness.
 func (m *MIPSEVM) Step(t *testing.T, stepWitness *mipsevm.StepWitness, step uint64, stateHashFn mipsevm.HashFn) []byte {
+	if stepWitness.HasPreimage() {
+		t.Logf("reading preimage key %x at offset %d", stepWitness.PreimageKey, stepWitness.PreimageOffset)
+	}
+	evmPost, postHash, gasUsed, err := m.runStep(t.Name(), stepWitness, step, stateHashFn)
+	require.NoError(t, err)
+	if step%100_000 == 0 {
+		t.Logf("EVM step %d took %d gas, and returned stateHash %s", step, gasUsed, postHash)
+	}
+	return evmPost
+}
+
+// runStep runs the step of the witness on the EVM, and returns the post-state logged by the EVM, its hash and the gas used.
+// Errors are returned rather than failing the test, so steps can run outside the goroutine of the test.
+func (m *MIPSEVM) runStep(test string, stepWitness *mipsevm.StepWitness, step uint64, stateHashFn mipsevm.HashFn) ([]byte, common.Hash, uint64, error) {
 	m.lastStep = step
 	m.lastStepInput = nil
 	m.lastPreimageOracleInput = nil
 
 	// we take a snapshot so we can clean up the state, and isolate the logs of this instruction run.
 	snap := m.env.StateDB.Snapshot()
+	defer m.env.StateDB.RevertToSnapshot(snap)
 
 	if stepWitness.HasPreimage() {
-		t.Logf("reading preimage key %x at offset %d", stepWitness.PreimageKey, stepWitness.PreimageOffset)
-		poInput, err := m.encodePreimageOracleInput(t, stepWitness.PreimageKey, stepWitness.PreimageValue, stepWitness.PreimageOffset, mipsevm.LocalContext{})
+		poInput, err := m.encodePreimageOracleInput(stepWitness.PreimageKey, stepWitness.PreimageValue, stepWitness.PreimageOffset, mipsevm.LocalContext{})
 		m.lastPreimageOracleInput = poInput
-		require.NoError(t, err, "encode preimage oracle input")
+		if err != nil {
+			return nil, common.Hash{}, 0, fmt.Errorf("encode preimage oracle input: %w", err)
+		}
+		if err := witnessSizes.Record(oracleInput, test, step, len(poInput)); err != nil {
+			return nil, common.Hash{}, 0, err
+		}
 		_, leftOverGas, err := m.env.Call(m.sender, m.addrs.Oracle, poInput, m.startingGas, common.U2560)
-		require.NoErrorf(t, err, "evm should not fail, took %d gas", m.startingGas-leftOverGas)
+		if err != nil {
+			return nil, common.Hash{}, 0, fmt.Errorf("evm should not fail, took %d gas: %w", m.startingGas-leftOverGas, err)
+		}
 	}
 
-	input := EncodeStepInput(t, stepWitness, mipsevm.LocalContext{}, m.artifacts.MIPS)
+	input, err := encodeStepInput(stepWitness, mipsevm.LocalContext{}, m.artifacts.MIPS)
+	if err != nil {
+		return nil, common.Hash{}, 0, fmt.Errorf("encode step input: %w", err)
+	}
 	m.lastStepInput = input
+	if err := witnessSizes.Record(stepInput, test, step, len(input)); err != nil {
+		return nil, common.Hash{}, 0, err
+	}
 	ret, leftOverGas, err := m.env.Call(m.sender, m.addrs.MIPS, input, m.startingGas, common.U2560)
-	require.NoError(t, err, "evm should not fail, but got %v with return value 0x%x", err, ret)
-	require.Len(t, ret, 32, "expecting 32-byte state hash")
+	if err != nil {
+		return nil, common.Hash{}, 0, fmt.Errorf("evm should not fail, but got %w with return value 0x%x", err, ret)
+	}
+	if len(ret) != 32 {
+		return nil, common.Hash{}, 0, fmt.Errorf("expecting 32-byte state hash, got 0x%x", ret)
+	}
 	// remember state hash, to check it against state
 	postHash := common.Hash(*(*[32]byte)(ret))
 	logs := m.evmState.Logs()
-	require.Equal(t, 1, len(logs), "expecting a log with post-state")
+	if len(logs) != 1 {
+		return nil, common.Hash{}, 0, fmt.Errorf("expecting a log with post-state, got %d logs", len(logs))
+	}
 	evmPost := logs[0].Data
 
 	stateHash, err := stateHashFn(evmPost)
-	require.NoError(t, err, "state hash could not be computed")
-	require.Equal(t, stateHash, postHash, "logged state must be accurate")
-
-	m.env.StateDB.RevertToSnapshot(snap)
-	if step%100_000 == 0 {
-		t.Logf("EVM step %d took %d gas, and returned stateHash %s", step, m.startingGas-leftOverGas, postHash)
+	if err != nil {
+		return nil, common.Hash{}, 0, fmt.Errorf("state hash could not be computed: %w", err)
 	}
-	return evmPost
+	if stateHash != postHash {
+		return nil, common.Hash{}, 0, fmt.Errorf("logged state must be accurate: logged state hash %s, returned %s", stateHash, postHash)
+	}
+	return evmPost, postHash, m.startingGas - leftOverGas, nil
 }
 
 func EncodeStepInput(t *testing.T, wit *mipsevm.StepWitness, localContext mipsevm.LocalContext, mips *foundry.Artifact) []byte {
-	input, err := mips.ABI.Pack("step", wit.State, wit.ProofData, localContext)
+	input, err := encodeStepInput(wit, localContext, mips)
 	require.NoError(t, err)
 	return input
 }
 
-func (m *MIPSEVM) encodePreimageOracleInput(t *testing.T, preimageKey [32]byte, preimageValue []byte, preimageOffset arch.Word, localContext mipsevm.LocalContext) ([]byte, error) {
-	if preimageKey == ([32]byte{}) {
-		return nil, errors.New("cannot encode pre-image oracle input, witness has no pre-image to proof")
-	}
-	localOracle := m.localOracle
-	oracle := m.artifacts.Oracle
+func encodeStepInput(wit *mipsevm.StepWitness, localContext mipsevm.LocalContext, mips *foundry.Artifact) ([]byte, error) {
+	return onchain.EncodeStepInput(wit, localContext, mips)
+}
 
-	switch preimage.KeyType(preimageKey[0]) {
-	case preimage.LocalKeyType:
-		if len(preimageValue) > 32+8 {
-			return nil, fmt.Errorf("local pre-image exceeds maximum size of 32 bytes with key 0x%x", preimageKey)
-		}
-		preimagePart := preimageValue[8:]
-		var tmp [32]byte
-		copy(tmp[:], preimagePart)
-		input, err := oracle.ABI.Pack("loadLocalData",
-			new(big.Int).SetBytes(preimageKey[1:]),
-			localContext,
-			tmp,
-			new(big.Int).SetUint64(uint64(len(preimagePart))),
-			new(big.Int).SetUint64(uint64(preimageOffset)),
-		)
-		require.NoError(t, err)
-		return input, nil
-	case preimage.Keccak256KeyType:
-		input, err := oracle.ABI.Pack(
-			"loadKeccak256PreimagePart",
-			new(big.Int).SetUint64(uint64(preimageOffset)),
-			preimageValue[8:])
-		require.NoError(t, err)
-		return input, nil
-	case preimage.PrecompileKeyType:
-		if localOracle == nil {
-			return nil, errors.New("local oracle is required for precompile preimages")
-		}
-		preimage := localOracle.GetPreimage(preimage.Keccak256Key(preimageKey).PreimageKey())
-		precompile := common.BytesToAddress(preimage[:20])
-		requiredGas := binary.BigEndian.Uint64(preimage[20:28])
-		callInput := preimage[28:]
-		input, err := oracle.ABI.Pack(
-			"loadPrecompilePreimagePart",
-			new(big.Int).SetUint64(uint64(preimageOffset)),
-			precompile,
-			requiredGas,
-			callInput,
-		)
-		require.NoError(t, err)
-		return input, nil
-	default:
-		return nil, fmt.Errorf("unsupported pre-image type %d, cannot prepare preimage with key %x offset %d for oracle",
-			preimageKey[0], preimageKey, preimageOffset)
-	}
+func (m *MIPSEVM) encodePreimageOracleInput(preimageKey [32]byte, preimageValue []byte, preimageOffset arch.Word, localContext mipsevm.LocalContext) ([]byte, error) {
+	return onchain.EncodePreimageOracleInput(m.artifacts.Oracle, m.localOracle, preimageKey, preimageValue, preimageOffset, localContext)
 }
 
 func (m *MIPSEVM) assertPreimageOracleReverts(t *testing.T, preimageKey [32]byte, preimageValue []byte, preimageOffset arch.Word) {
-	poInput, err := m.encodePreimageOracleInput(t, preimageKey, preimageValue, preimageOffset, mipsevm.LocalContext{})
+	poInput, err := m.encodePreimageOracleInput(preimageKey, preimageValue, preimageOffset, mipsevm.LocalContext{})
 	require.NoError(t, err, "encode preimage oracle input")
 	_, _, evmErr := m.env.Call(m.sender, m.addrs.Oracle, poInput, m.startingGas, common.U2560)
 
@@ -0,0 +1,167 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// stepValidation is a step submitted to a ParallelEvmValidator, with the post-state of the Go VM to validate it against.
+type stepValidation struct {
+	seq     uint64
+	step    uint64
+	witness *mipsevm.StepWitness
+	goPost  []byte
+}
+
+type stepFailure struct {
+	seq                 uint64
+	step                uint64
+	err                 error
+	stepInput           []byte
+	preimageOracleInput []byte
+}
+
+// validateFn validates a step on the EVM of a worker.
+// It returns the step and pre-image oracle inputs that were encoded, to report them on failure.
+type validateFn func(worker int, v *stepValidation) (stepInput []byte, preimageOracleInput []byte, err error)
+
+// ParallelEvmValidator validates the steps of a long-running test against the EVM on a pool of workers,
+// while the test keeps stepping the Go VM. The contracts are deployed once, and each worker steps on its own copy of
+// the deployed state.
+//
+// Steps may be validated out of order, but failures are reported deterministically: the failure of the earliest
+// submitted step is reported, as the serial EvmValidator would. Call Wait before checking the results of the test.
+type ParallelEvmValidator struct {
+	validate validateFn
+
+	jobs    chan *stepValidation
+	workers sync.WaitGroup
+	nextSeq uint64
+
+	mu       sync.Mutex
+	done     bool
+	failures []stepFailure
+	// firstFailed is the sequence number of the earliest failed step, or math.MaxUint64 if none failed.
+	// It is only updated with mu held, but read without, as steps submitted after it are not validated.
+	firstFailed atomic.Uint64
+}
+
+// NewParallelEvmValidator creates a validator with the given number of workers, or one per CPU if workers is zero.
+func NewParallelEvmValidator(t *testing.T, hashFn mipsevm.HashFn, contracts *ContractMetadata, workers int, opts ...evmOption) *ParallelEvmValidator {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	env, evmState := NewEVMEnv(t, contracts)
+	evms := make([]*MIPSEVM, workers)
+	for i := range evms {
+		workerState := evmState.Copy()
+		workerEnv := vm.NewEVM(env.Context, workerState, env.ChainConfig(), env.Config)
+		evms[i] = newMIPSEVMWithEnv(contracts, workerEnv, workerState, opts...)
+	}
+	test := t.Name()
+	return newParallelValidator(t, workers, func(worker int, v *stepValidation) ([]byte, []byte, error) {
+		evm := evms[worker]
+		evmPost, _, _, err := evm.runStep(test, v.witness, v.step, hashFn)
+		if err == nil && !bytes.Equal(v.goPost, evmPost) {
+			err = fmt.Errorf("mipsevm produced different state than EVM: %s != %s",
+				hexutil.Bytes(v.goPost), hexutil.Bytes(evmPost))
+		}
+		return evm.lastStepInput, evm.lastPreimageOracleInput, err
+	})
+}
+
+func newParallelValidator(t *testing.T, workers int, validate validateFn) *ParallelEvmValidator {
+	v := &ParallelEvmValidator{
+		validate: validate,
+		jobs:     make(chan *stepValidation, 4*workers),
+	}
+	v.firstFailed.Store(^uint64(0))
+	for i := 0; i < workers; i++ {
+		v.workers.Add(1)
+		go v.work(i)
+	}
+	// the workers are stopped, and failures reported, even if the test does not wait for them
+	t.Cleanup(func() { v.Wait(t) })
+	return v
+}
+
+func (v *ParallelEvmValidator) work(worker int) {
+	defer v.workers.Done()
+	for job := range v.jobs {
+		if job.seq > v.firstFailed.Load() {
+			continue
+		}
+		stepInput, poInput, err := v.validate(worker, job)
+		if err == nil {
+			continue
+		}
+		v.mu.Lock()
+		v.failures = append(v.failures, stepFailure{
+			seq:                 job.seq,
+			step:                job.step,
+			err:                 err,
+			stepInput:           stepInput,
+			preimageOracleInput: poInput,
+		})
+		if job.seq < v.firstFailed.Load() {
+			v.firstFailed.Store(job.seq)
+		}
+		v.mu.Unlock()
+	}
+}
+
+// ValidateEVM submits a step to be validated against the post-state of the Go VM, which is encoded before returning.
+// It has the signature of EvmValidator.ValidateEVM, so long-running tests can switch between the two.
+// If a submitted step already failed, the test is failed once the steps submitted before it are validated.
+func (v *ParallelEvmValidator) ValidateEVM(t *testing.T, stepWitness *mipsevm.StepWitness, step uint64, goVm mipsevm.FPVM) {
+	if v.firstFailed.Load() != ^uint64(0) {
+		v.Wait(t)
+	}
+	goPost, _ := goVm.GetState().EncodeWitness()
+	v.submit(stepWitness, step, goPost)
+}
+
+func (v *ParallelEvmValidator) submit(stepWitness *mipsevm.StepWitness, step uint64, goPost []byte) {
+	v.jobs <- &stepValidation{seq: v.nextSeq, step: step, witness: stepWitness, goPost: goPost}
+	v.nextSeq++
+}
+
+// Wait waits for the submitted steps to be validated, and fails the test with the earliest failed step.
+// No steps can be submitted after Wait.
+func (v *ParallelEvmValidator) Wait(t *testing.T) {
+	if first := v.wait(); first != nil {
+		t.Logf("Failed while executing step %d with\n\tstep input: %x\n\tpreimageOracle input: %x", first.step, first.stepInput, first.preimageOracleInput)
+		t.Fatalf("EVM validation failed at step %d: %v", first.step, first.err)
+	}
+}
+
+// wait stops the workers once the submitted steps are validated, and returns the earliest failed step, if any.
+func (v *ParallelEvmValidator) wait() *stepFailure {
+	v.mu.Lock()
+	if !v.done {
+		v.done = true
+		close(v.jobs)
+	}
+	v.mu.Unlock()
+	v.workers.Wait()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if len(v.failures) == 0 {
+		return nil
+	}
+	sort.Slice(v.failures, func(i, j int) bool { return v.failures[i].seq < v.failures[j].seq })
+	first := v.failures[0]
+	v.failures = nil // reported once, if the test waits before its cleanup
+	return &first
+}
@@ -0,0 +1,53 @@
+package testutil
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestParallelEvmValidator(t *testing.T) {
+	t.Run("valid steps", func(t *testing.T) {
+		var validated atomic.Uint64
+		v := newParallelValidator(t, 4, func(worker int, s *stepValidation) ([]byte, []byte, error) {
+			validated.Add(1)
+			return nil, nil, nil
+		})
+		for step := uint64(0); step < 100; step++ {
+			v.submit(&mipsevm.StepWitness{}, step, nil)
+		}
+		require.Nil(t, v.wait())
+		require.Equal(t, uint64(100), validated.Load())
+	})
+
+	t.Run("earliest failure is reported", func(t *testing.T) {
+		release := make(chan struct{})
+		v := newParallelValidator(t, 4, func(worker int, s *stepValidation) ([]byte, []byte, error) {
+			switch s.step {
+			case 1000:
+				// fails after the later steps did
+				<-release
+				return []byte{1}, []byte{2}, fmt.Errorf("step %d failed", s.step)
+			case 1003, 1005:
+				return nil, nil, fmt.Errorf("step %d failed", s.step)
+			}
+			return nil, nil, nil
+		})
+		for step := uint64(1000); step < 1008; step++ {
+			v.submit(&mipsevm.StepWitness{}, step, nil)
+		}
+		close(release)
+		first := v.wait()
+		require.NotNil(t, first)
+		require.Equal(t, uint64(1000), first.step)
+		require.ErrorContains(t, first.err, "step 1000 failed")
+		require.Equal(t, []byte{1}, first.stepInput)
+		require.Equal(t, []byte{2}, first.preimageOracleInput)
+		// failures are reported once
+		require.Nil(t, v.wait())
+	})
+}
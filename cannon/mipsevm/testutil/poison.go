@@ -0,0 +1,75 @@
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/disasm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// PoisonPattern is a poison pattern that is unlikely to be a valid pointer, size or instruction.
+const PoisonPattern = Word(0xdeadbeefdeadbeef & uint64(^Word(0)))
+
+// PoisonedRead is a read of uninitialized memory by the guest program, see memory.Memory.EnablePoison.
+type PoisonedRead struct {
+	Step uint64
+	// PC and Insn are the instruction that reads the poisoned memory.
+	PC   Word
+	Insn uint32
+	// Addr is the address of the poisoned word.
+	Addr Word
+}
+
+func (r PoisonedRead) String() string {
+	return fmt.Sprintf("read of uninitialized memory at 0x%x at step %d, pc 0x%x (%s)", r.Addr, r.Step, r.PC, disasm.Disassemble(uint64(r.PC), r.Insn))
+}
+
+// NextPoisonedRead returns the address of the poisoned word that the instruction at the PC reads, if any:
+// either the instruction itself, or the memory that it loads.
+// Stores are not reads, even though the VM loads the word that it updates.
+func NextPoisonedRead(mem *memory.Memory, pc Word, registers *[32]Word) (Word, bool) {
+	if mem.Poisoned(pc) {
+		return pc, true
+	}
+	insn := GetInstruction(mem, pc)
+	opcode := insn >> 26
+	isLoad := (opcode >= 0x20 && opcode < 0x28) || // lb, lh, lwl, lw, lbu, lhu, lwr, lwu
+		opcode == exec.OpLoadDoubleLeft || opcode == exec.OpLoadDoubleRight ||
+		opcode == exec.OpLoadLinked ||
+		(!arch.IsMips32 && (opcode == exec.OpLoadLinked64 || opcode == 0x37)) // lld, ld
+	if !isLoad {
+		return 0, false
+	}
+	rs := registers[(insn>>21)&0x1F]
+	addr := (rs + exec.SignExtendImmediate(insn)) & arch.AddressMask
+	if mem.Poisoned(addr) {
+		return addr, true
+	}
+	return 0, false
+}
+
+// RunUntilPoisonedRead poisons the memory of [start, end) that the program allocates from now on,
+// and runs the VM until the program exits, maxSteps elapse, or it reads poisoned memory.
+// It returns the first read of poisoned memory, or nil if there was none.
+//
+// The poisoned memory differs from the memory that the VM would have onchain,
+// so the state hashes of the run must not be compared with a run without poisoning.
+func RunUntilPoisonedRead(t require.TestingT, vm mipsevm.FPVM, pattern Word, start, end Word, maxSteps uint64) *PoisonedRead {
+	state := vm.GetState()
+	mem := state.GetMemory()
+	mem.EnablePoison(pattern, start, end)
+	for !state.GetExited() && state.GetStep() < maxSteps {
+		pc := state.GetPC()
+		if addr, ok := NextPoisonedRead(mem, pc, state.GetRegistersRef()); ok {
+			return &PoisonedRead{Step: state.GetStep(), PC: pc, Insn: GetInstruction(mem, pc), Addr: addr}
+		}
+		_, err := vm.Step(false)
+		require.NoErrorf(t, err, "step %d", state.GetStep())
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+func TestNextPoisonedRead(t *testing.T) {
+	const pc, heap = Word(0x1000), Word(0x20000)
+	lw := uint32(0x23<<26 | 4<<21 | 8<<16 | 8) // lw $t0, 8($a0)
+	sw := uint32(0x2b<<26 | 4<<21 | 8<<16 | 8) // sw $t0, 8($a0)
+	var registers [32]Word
+	registers[4] = heap
+
+	setup := func(insn uint32) *memory.Memory {
+		mem := memory.NewMemory()
+		StoreInstruction(mem, pc, insn)
+		mem.EnablePoison(PoisonPattern, heap, heap+0x10000)
+		return mem
+	}
+
+	mem := setup(lw)
+	addr, ok := NextPoisonedRead(mem, pc, &registers)
+	require.True(t, ok)
+	require.Equal(t, heap+8, addr)
+
+	mem.SetWord(heap+8, 1)
+	_, ok = NextPoisonedRead(mem, pc, &registers)
+	require.False(t, ok, "written memory is not poisoned")
+
+	mem = setup(sw)
+	_, ok = NextPoisonedRead(mem, pc, &registers)
+	require.False(t, ok, "stores are not reads")
+
+	addr, ok = NextPoisonedRead(mem, heap+0x100, &registers)
+	require.True(t, ok, "instruction fetch")
+	require.Equal(t, heap+0x100, addr)
+}
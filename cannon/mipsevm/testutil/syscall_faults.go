@@ -0,0 +1,88 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+// SyscallFault fails a syscall of the guest with an errno, instead of executing it.
+type SyscallFault struct {
+	Num   arch.Word
+	Errno arch.Word
+	// Step fails the syscall only if it executes at the step, if non-zero.
+	// Steps are numbered like mipsevm.SyscallCall, by the step count of the state after the syscall.
+	Step uint64
+	// Call fails only the n-th call of the syscall, counting from 1, if non-zero.
+	// Without Step and Call, every call of the syscall fails.
+	Call int
+	// Match fails only the calls of the syscall it returns true for, e.g. to select by the arguments, if set.
+	Match func(call mipsevm.SyscallCall) bool
+}
+
+// SyscallFaults fails the syscalls of the guest that match its faults, to exercise the error paths of the guest,
+// e.g. retrying a futex wait on EINTR, or a read on EAGAIN. It is enabled with FPVM.EnableSyscallFaults.
+//
+// The onchain VM executes the failed syscalls, so the steps with a failed syscall cannot be validated with the EVM.
+// The other steps, including the handling of the errors by the guest, can: see ValidateEVM.
+type SyscallFaults struct {
+	faults   []SyscallFault
+	calls    map[arch.Word]int
+	injected []mipsevm.SyscallCall
+}
+
+var _ mipsevm.SyscallFaultInjector = (*SyscallFaults)(nil)
+
+func NewSyscallFaults(faults ...SyscallFault) *SyscallFaults {
+	return &SyscallFaults{
+		faults: faults,
+		calls:  make(map[arch.Word]int),
+	}
+}
+
+func (f *SyscallFaults) InjectSyscallFault(call mipsevm.SyscallCall) (arch.Word, bool) {
+	f.calls[call.Num]++
+	for _, fault := range f.faults {
+		if fault.Num != call.Num ||
+			(fault.Step != 0 && fault.Step != call.Step) ||
+			(fault.Call != 0 && fault.Call != f.calls[call.Num]) ||
+			(fault.Match != nil && !fault.Match(call)) {
+			continue
+		}
+		call.Ret = [2]arch.Word{fault.Errno, exec.SysErrorSignal}
+		f.injected = append(f.injected, call)
+		return fault.Errno, true
+	}
+	return 0, false
+}
+
+// Injected returns the syscalls that were failed so far, with the errno they failed with.
+func (f *SyscallFaults) Injected() []mipsevm.SyscallCall {
+	return f.injected
+}
+
+// Failed returns whether the syscall of the step was failed.
+func (f *SyscallFaults) Failed(step uint64) bool {
+	for _, call := range f.injected {
+		if call.Step == step {
+			return true
+		}
+	}
+	return false
+}
+
+// StepValidator validates the steps of the Go VM with the EVM, like EvmValidator and ParallelEvmValidator.
+type StepValidator interface {
+	ValidateEVM(t *testing.T, stepWitness *mipsevm.StepWitness, step uint64, goVm mipsevm.FPVM)
+}
+
+// ValidateEVM validates the step with the validator, unless the syscall of the step was failed.
+// step is the step count of the state before the step, as passed to the validator.
+func (f *SyscallFaults) ValidateEVM(t *testing.T, validator StepValidator, stepWitness *mipsevm.StepWitness, step uint64, goVm mipsevm.FPVM) {
+	if f.Failed(step + 1) {
+		return
+	}
+	validator.ValidateEVM(t, stepWitness, step, goVm)
+}
@@ -0,0 +1,56 @@
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+func TestSyscallFaults(t *testing.T) {
+	faults := NewSyscallFaults(
+		SyscallFault{Num: arch.SysRead, Errno: exec.MipsEAGAIN, Call: 2},
+		SyscallFault{Num: arch.SysWrite, Errno: exec.MipsEBADF, Step: 5},
+		SyscallFault{Num: arch.SysFutex, Errno: exec.MipsEINTR, Match: func(call mipsevm.SyscallCall) bool {
+			return call.Args[1] == exec.FutexWaitPrivate
+		}},
+	)
+	inject := func(step uint64, num arch.Word, args ...arch.Word) (arch.Word, bool) {
+		call := mipsevm.SyscallCall{Step: step, Num: num}
+		copy(call.Args[:], args)
+		return faults.InjectSyscallFault(call)
+	}
+	expectPass := func(errno arch.Word, fail bool) {
+		require.False(t, fail)
+		require.Zero(t, errno)
+	}
+
+	expectPass(inject(1, arch.SysRead))
+	errno, fail := inject(2, arch.SysRead)
+	require.True(t, fail)
+	require.Equal(t, arch.Word(exec.MipsEAGAIN), errno)
+	expectPass(inject(3, arch.SysRead))
+
+	expectPass(inject(4, arch.SysWrite))
+	errno, fail = inject(5, arch.SysWrite)
+	require.True(t, fail)
+	require.Equal(t, arch.Word(exec.MipsEBADF), errno)
+
+	expectPass(inject(6, arch.SysFutex, 0x1000, exec.FutexWakePrivate))
+	_, fail = inject(7, arch.SysFutex, 0x1000, exec.FutexWaitPrivate)
+	require.True(t, fail)
+	_, fail = inject(8, arch.SysFutex, 0x1000, exec.FutexWaitPrivate)
+	require.True(t, fail)
+
+	var steps []uint64
+	for _, call := range faults.Injected() {
+		steps = append(steps, call.Step)
+		require.Equal(t, exec.SysErrorSignal, call.Ret[1])
+	}
+	require.Equal(t, []uint64{2, 5, 7, 8}, steps)
+	require.True(t, faults.Failed(5))
+	require.False(t, faults.Failed(6))
+}
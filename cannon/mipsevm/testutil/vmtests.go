@@ -18,7 +18,17 @@ type StateFactory[T mipsevm.FPVMState] func() T
 
 func RunVMTest_Hello[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], goTarget GoTarget) {
 	state, meta := LoadELFProgram(t, ProgramPath("hello", goTarget), initState)
+	runVMTestHello(t, state, meta, vmFactory)
+}
+
+// RunVMTest_RustHello runs the Rust version of the hello program, see RustProgramPath.
+// The VM must support the musl runtime.
+func RunVMTest_RustHello[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T]) {
+	state, meta := LoadMuslELFProgram(t, RustProgramPath(t, "hello"), initState)
+	runVMTestHello(t, state, meta, vmFactory)
+}
 
+func runVMTestHello[T mipsevm.FPVMState](t *testing.T, state T, meta *program.Metadata, vmFactory VMFactory[T]) {
 	var stdOutBuf, stdErrBuf bytes.Buffer
 	us := vmFactory(state, nil, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr), CreateLogger(), meta)
 
@@ -40,7 +50,17 @@ func RunVMTest_Hello[T mipsevm.FPVMState](t *testing.T, initState program.Create
 
 func RunVMTest_Claim[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], goTarget GoTarget) {
 	state, meta := LoadELFProgram(t, ProgramPath("claim", goTarget), initState)
+	runVMTestClaim(t, state, meta, vmFactory)
+}
+
+// RunVMTest_RustClaim runs the Rust version of the claim program, see RustProgramPath.
+// The VM must support the musl runtime.
+func RunVMTest_RustClaim[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T]) {
+	state, meta := LoadMuslELFProgram(t, RustProgramPath(t, "claim"), initState)
+	runVMTestClaim(t, state, meta, vmFactory)
+}
 
+func runVMTestClaim[T mipsevm.FPVMState](t *testing.T, state T, meta *program.Metadata, vmFactory VMFactory[T]) {
 	oracle, expectedStdOut, expectedStdErr := ClaimTestOracle(t)
 
 	var stdOutBuf, stdErrBuf bytes.Buffer
@@ -0,0 +1,89 @@
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// MaxWitnessSizeEnv overrides the ceiling, in bytes, of the step and pre-image oracle inputs that the EVM tests encode.
+// It defaults to mipsevm.MaxStepInputSize.
+const MaxWitnessSizeEnv = "CANNON_MAX_WITNESS_SIZE"
+
+type witnessKind string
+
+const (
+	stepInput   witnessKind = "step"
+	oracleInput witnessKind = "pre-image oracle"
+)
+
+type witnessSize struct {
+	size int
+	test string
+	step uint64
+}
+
+// WitnessSizes tracks the largest inputs of the onchain calls of the EVM tests,
+// so worst-case witness growth is caught by the tests rather than by failing onchain calls.
+type WitnessSizes struct {
+	mu      sync.Mutex
+	ceiling int
+	largest map[witnessKind]witnessSize
+}
+
+func NewWitnessSizes(ceiling int) *WitnessSizes {
+	return &WitnessSizes{ceiling: ceiling, largest: make(map[witnessKind]witnessSize)}
+}
+
+// witnessSizes is shared by all the EVM tests of a test binary, see ReportWitnessSizes.
+var witnessSizes = NewWitnessSizes(maxWitnessSizeFromEnv())
+
+func maxWitnessSizeFromEnv() int {
+	v, ok := os.LookupEnv(MaxWitnessSizeEnv)
+	if !ok {
+		return mipsevm.MaxStepInputSize
+	}
+	ceiling, err := strconv.Atoi(v)
+	if err != nil || ceiling <= 0 {
+		panic(fmt.Errorf("invalid %s %q, expected a positive number of bytes", MaxWitnessSizeEnv, v))
+	}
+	return ceiling
+}
+
+// Record accounts for the input of an onchain call, and returns an error if it exceeds the ceiling.
+func (w *WitnessSizes) Record(kind witnessKind, test string, step uint64, size int) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if size > w.largest[kind].size {
+		w.largest[kind] = witnessSize{size: size, test: test, step: step}
+	}
+	if size > w.ceiling {
+		return fmt.Errorf("%s input of step %d is %d bytes, above the ceiling of %d bytes (see %s)",
+			kind, step, size, w.ceiling, MaxWitnessSizeEnv)
+	}
+	return nil
+}
+
+// Report writes the largest inputs of the onchain calls that were recorded.
+func (w *WitnessSizes) Report(out io.Writer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, kind := range []witnessKind{stepInput, oracleInput} {
+		largest, ok := w.largest[kind]
+		if !ok {
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "largest %s input: %d bytes of %d bytes ceiling, at step %d of %s\n",
+			kind, largest.size, w.ceiling, largest.step, largest.test)
+	}
+}
+
+// ReportWitnessSizes writes the largest step and pre-image oracle inputs encoded by the EVM tests that ran.
+// Call it from TestMain after the tests ran, to surface the worst-case witness size of the test suite.
+func ReportWitnessSizes(out io.Writer) {
+	witnessSizes.Report(out)
+}
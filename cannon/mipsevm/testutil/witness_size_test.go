@@ -0,0 +1,27 @@
+package testutil
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWitnessSizes(t *testing.T) {
+	sizes := NewWitnessSizes(1000)
+	require.NoError(t, sizes.Record(stepInput, "TestA", 1, 500))
+	require.NoError(t, sizes.Record(stepInput, "TestB", 7, 900))
+	require.NoError(t, sizes.Record(stepInput, "TestC", 2, 600))
+	require.NoError(t, sizes.Record(oracleInput, "TestC", 3, 1000))
+
+	var out bytes.Buffer
+	sizes.Report(&out)
+	require.Equal(t, "largest step input: 900 bytes of 1000 bytes ceiling, at step 7 of TestB\n"+
+		"largest pre-image oracle input: 1000 bytes of 1000 bytes ceiling, at step 3 of TestC\n", out.String())
+
+	err := sizes.Record(oracleInput, "TestD", 4, 1001)
+	require.ErrorContains(t, err, "pre-image oracle input of step 4 is 1001 bytes, above the ceiling of 1000 bytes")
+	out.Reset()
+	sizes.Report(&out)
+	require.Contains(t, out.String(), "largest pre-image oracle input: 1001 bytes of 1000 bytes ceiling, at step 4 of TestD")
+}
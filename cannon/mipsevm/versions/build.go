@@ -0,0 +1,55 @@
+package versions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+var ErrInvalidBuildInfo = errors.New("invalid build info")
+
+// buildMagic marks the build info that follows the state in a serialized state file.
+var buildMagic = [4]byte{'c', 'b', 'l', 'd'}
+
+// writeBuildTrailer writes the build info that follows the state in a serialized state file.
+// Readers that predate it ignore it, as they stop reading after the state.
+//
+// Magic              [4]byte "cbld"
+// len(Info)          uint32
+// Info               JSON
+func writeBuildTrailer(w io.Writer, info *buildinfo.Info) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMagic[:]); err != nil {
+		return err
+	}
+	return serialize.NewBinaryWriter(w).WriteBytes(data)
+}
+
+// readBuildTrailer reads the build info that follows the state, or returns nil if there is none.
+func readBuildTrailer(in io.Reader) (*buildinfo.Info, error) {
+	var magic [4]byte
+	if _, err := io.ReadFull(in, magic[:]); errors.Is(err, io.EOF) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildInfo, err)
+	}
+	if magic != buildMagic {
+		return nil, fmt.Errorf("%w: unexpected data after state", ErrInvalidBuildInfo)
+	}
+	var data []byte
+	if err := serialize.NewBinaryReader(in).ReadBytes(&data); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildInfo, err)
+	}
+	var info buildinfo.Info
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBuildInfo, err)
+	}
+	return &info, nil
+}
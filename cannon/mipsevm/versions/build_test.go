@@ -0,0 +1,58 @@
+package versions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+)
+
+func TestBuildTrailer(t *testing.T) {
+	build := &buildinfo.Info{GitCommit: "abcd", GoVersion: "go1.23.8", FlagsHash: common.Hash{0xaa}}
+
+	t.Run("with build", func(t *testing.T) {
+		expected, err := NewFromState(VersionMultiThreaded64_v5, multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		expected.Build = build
+		actual, err := LoadStateFromFile(writeToFile(t, "state.bin.gz", expected))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("without build", func(t *testing.T) {
+		expected, err := NewFromState(VersionMultiThreaded64_v5, multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		actual, err := LoadStateFromFile(writeToFile(t, "state.bin.gz", expected))
+		require.NoError(t, err)
+		require.Nil(t, actual.Build)
+	})
+
+	t.Run("state hash excludes build", func(t *testing.T) {
+		state, err := NewFromState(VersionMultiThreaded64_v5, multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		_, expected := state.EncodeWitness()
+		state.Build = build
+		actual, err := LoadStateFromFile(writeToFile(t, "state.bin.gz", state))
+		require.NoError(t, err)
+		_, hash := actual.EncodeWitness()
+		require.Equal(t, expected, hash)
+	})
+
+	t.Run("invalid trailer", func(t *testing.T) {
+		state, err := NewFromState(VersionMultiThreaded64_v5, multithreaded.CreateEmptyState())
+		require.NoError(t, err)
+		state.Build = build
+		var buf bytes.Buffer
+		require.NoError(t, state.Serialize(&buf))
+		data := buf.Bytes()
+		data = data[:len(data)-1]
+		require.ErrorIs(t, new(VersionedState).Deserialize(bytes.NewReader(data)), ErrInvalidBuildInfo)
+
+		data = append(bytes.Clone(data[:len(data)-60]), []byte("garbage!")...)
+		require.Error(t, new(VersionedState).Deserialize(bytes.NewReader(data)))
+	})
+}
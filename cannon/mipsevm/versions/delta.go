@@ -0,0 +1,277 @@
+package versions
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+// deltaMagic prefixes delta snapshots, to tell them apart from full states, which start with their version.
+var deltaMagic = [4]byte{'c', 'd', 'l', 't'}
+
+var (
+	ErrNotDelta      = errors.New("not a delta snapshot")
+	ErrDeltaMismatch = errors.New("delta does not apply to the base state")
+)
+
+// DeltaPage is a memory page of a delta snapshot.
+type DeltaPage struct {
+	Index arch.Word
+	Data  *memory.Page
+}
+
+// StateDelta is a snapshot of a state, encoded as the difference with an earlier full snapshot, the base:
+// the memory pages that were allocated or written since the base,
+// and the state without its memory, which holds the registers of the threads and the other fields of the VM.
+type StateDelta struct {
+	Version   StateVersion
+	BaseStep  uint64
+	BaseHash  common.Hash
+	Step      uint64
+	StateHash common.Hash
+	// Pages is ordered by page index.
+	Pages []DeltaPage
+	// State is the state without any memory pages, as per FPVMState.Serialize.
+	State []byte
+}
+
+// DeltaTracker encodes snapshots of a state as deltas against the full snapshot it was created from.
+type DeltaTracker struct {
+	version  StateVersion
+	baseStep uint64
+	baseHash common.Hash
+	// pageRoots holds the merkle root of every page of the base,
+	// which is cached by the memory, so changed pages are found without keeping a copy of the base.
+	pageRoots map[arch.Word][32]byte
+}
+
+// NewDeltaTracker returns a tracker of the changes of the state since now.
+func NewDeltaTracker(base *VersionedState) *DeltaTracker {
+	_, hash := base.EncodeWitness()
+	mem := base.GetMemory()
+	roots := make(map[arch.Word][32]byte, mem.PageCount())
+	forEachCachedPage(mem, func(index arch.Word, page *memory.CachedPage) {
+		roots[index] = page.MerkleRoot()
+	})
+	return &DeltaTracker{
+		version:   base.Version,
+		baseStep:  base.GetStep(),
+		baseHash:  hash,
+		pageRoots: roots,
+	}
+}
+
+// BaseStep returns the step of the base snapshot of the deltas.
+func (t *DeltaTracker) BaseStep() uint64 {
+	return t.baseStep
+}
+
+// Delta encodes the state as the difference with the base.
+func (t *DeltaTracker) Delta(state *VersionedState) (*StateDelta, error) {
+	if state.Version != t.version {
+		return nil, fmt.Errorf("%w: state version %v, base version %v", ErrDeltaMismatch, state.Version, t.version)
+	}
+	st, ok := state.FPVMState.(*multithreaded.State)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownVersion, state.FPVMState)
+	}
+	_, hash := state.EncodeWitness()
+	delta := &StateDelta{
+		Version:   state.Version,
+		BaseStep:  t.baseStep,
+		BaseHash:  t.baseHash,
+		Step:      state.GetStep(),
+		StateHash: hash,
+	}
+	forEachCachedPage(st.Memory, func(index arch.Word, page *memory.CachedPage) {
+		if root, ok := t.pageRoots[index]; ok && root == page.MerkleRoot() {
+			return
+		}
+		data := *page.Data
+		delta.Pages = append(delta.Pages, DeltaPage{Index: index, Data: &data})
+	})
+	slices.SortFunc(delta.Pages, func(a, b DeltaPage) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	noMemory := *st
+	noMemory.Memory = memory.NewMemory()
+	var buf bytes.Buffer
+	if err := noMemory.Serialize(&buf); err != nil {
+		return nil, fmt.Errorf("failed to serialize state: %w", err)
+	}
+	delta.State = buf.Bytes()
+	return delta, nil
+}
+
+func forEachCachedPage(mem *memory.Memory, fn func(index arch.Word, page *memory.CachedPage)) {
+	_ = mem.ForEachPage(func(index arch.Word, _ *memory.Page) error {
+		page, _ := mem.PageLookup(index)
+		fn(index, page)
+		return nil
+	})
+}
+
+// Apply restores the state of the delta from its base, which is not modified.
+func (d *StateDelta) Apply(base *VersionedState) (*VersionedState, error) {
+	if base.Version != d.Version || base.GetStep() != d.BaseStep {
+		return nil, fmt.Errorf("%w: delta of %v state at step %d, base is %v state at step %d",
+			ErrDeltaMismatch, d.Version, d.BaseStep, base.Version, base.GetStep())
+	}
+	if _, hash := base.EncodeWitness(); hash != d.BaseHash {
+		return nil, fmt.Errorf("%w: base state hash %s, delta expects %s", ErrDeltaMismatch, hash, d.BaseHash)
+	}
+
+	var buf bytes.Buffer
+	if err := serialize.NewBinaryWriter(&buf).WriteUInt(d.Version); err != nil {
+		return nil, err
+	}
+	buf.Write(d.State)
+	out := new(VersionedState)
+	if err := out.Deserialize(&buf); err != nil {
+		return nil, fmt.Errorf("failed to deserialize state: %w", err)
+	}
+	st, ok := out.FPVMState.(*multithreaded.State)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnknownVersion, out.FPVMState)
+	}
+	// The copy is not merkleized yet, so the pages can be written without invalidating the merkle tree.
+	mem := base.GetMemory().Copy()
+	for _, page := range d.Pages {
+		if err := mem.SetMemoryRange(page.Index<<memory.PageAddrSize, bytes.NewReader(page.Data[:])); err != nil {
+			return nil, fmt.Errorf("failed to write page %d: %w", page.Index, err)
+		}
+	}
+	st.Memory = mem
+	if _, hash := out.EncodeWitness(); hash != d.StateHash {
+		return nil, fmt.Errorf("restored state hash %s does not match delta state hash %s", hash, d.StateHash)
+	}
+	// Deltas are written by the build that wrote their base
+	out.Build = base.Build
+	return out, nil
+}
+
+// Serialize writes the delta in a simple binary format which can be read again using Deserialize.
+// The header is written before the pages, so it can be read without reading the pages, see ReadDeltaHeader.
+//
+// Magic              [4]byte "cdlt"
+// Version            uint8
+// BaseStep           uint64
+// BaseHash           [32]byte
+// Step               uint64
+// StateHash          [32]byte
+// len(Pages)         Word
+// For each page:
+//
+//	page index        Word
+//	page Data         [PageSize]byte
+//
+// len(State)         uint32
+// State              []byte
+func (d *StateDelta) Serialize(w io.Writer) error {
+	if _, err := w.Write(deltaMagic[:]); err != nil {
+		return err
+	}
+	bout := serialize.NewBinaryWriter(w)
+	if err := bout.WriteUInt(d.Version); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(d.BaseStep); err != nil {
+		return err
+	}
+	if err := bout.WriteHash(d.BaseHash); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(d.Step); err != nil {
+		return err
+	}
+	if err := bout.WriteHash(d.StateHash); err != nil {
+		return err
+	}
+	if err := bout.WriteUInt(arch.Word(len(d.Pages))); err != nil {
+		return err
+	}
+	for _, page := range d.Pages {
+		if err := bout.WriteUInt(page.Index); err != nil {
+			return err
+		}
+		if _, err := w.Write(page.Data[:]); err != nil {
+			return err
+		}
+	}
+	return bout.WriteBytes(d.State)
+}
+
+func (d *StateDelta) Deserialize(in io.Reader) error {
+	if err := d.readHeader(in); err != nil {
+		return err
+	}
+	bin := serialize.NewBinaryReader(in)
+	var pageCount arch.Word
+	if err := bin.ReadUInt(&pageCount); err != nil {
+		return err
+	}
+	d.Pages = nil
+	for i := arch.Word(0); i < pageCount; i++ {
+		page := DeltaPage{Data: new(memory.Page)}
+		if err := bin.ReadUInt(&page.Index); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(in, page.Data[:]); err != nil {
+			return err
+		}
+		d.Pages = append(d.Pages, page)
+	}
+	return bin.ReadBytes(&d.State)
+}
+
+func (d *StateDelta) readHeader(in io.Reader) error {
+	var magic [4]byte
+	if _, err := io.ReadFull(in, magic[:]); err != nil {
+		return err
+	}
+	if magic != deltaMagic {
+		return ErrNotDelta
+	}
+	bin := serialize.NewBinaryReader(in)
+	if err := bin.ReadUInt(&d.Version); err != nil {
+		return err
+	}
+	if !IsSupportedMultiThreaded64(d.Version) {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, d.Version)
+	}
+	if err := bin.ReadUInt(&d.BaseStep); err != nil {
+		return err
+	}
+	if err := bin.ReadHash(&d.BaseHash); err != nil {
+		return err
+	}
+	if err := bin.ReadUInt(&d.Step); err != nil {
+		return err
+	}
+	return bin.ReadHash(&d.StateHash)
+}
+
+// ReadDeltaHeader reads the fields of a delta snapshot before its pages, from a decompressed stream.
+// It returns ErrNotDelta if the stream is not a delta snapshot, e.g. if it is a full state.
+func ReadDeltaHeader(in io.Reader) (*StateDelta, error) {
+	d := new(StateDelta)
+	if err := d.readHeader(in); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func LoadDeltaFromFile(path string) (*StateDelta, error) {
+	return serialize.LoadSerializedBinary[StateDelta](path)
+}
@@ -0,0 +1,119 @@
+package versions
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+)
+
+// testState returns a state with a few memory pages, so deltas have unchanged pages to skip.
+func testState(t *testing.T) *VersionedState {
+	st := multithreaded.CreateInitialState(0x1000, 0x40000000)
+	for i := arch.Word(0); i < 8; i++ {
+		st.Memory.SetWord(i*memory.PageSize, i+1)
+	}
+	state, err := NewFromState(GetCurrentVersion(), st)
+	require.NoError(t, err)
+	return state
+}
+
+// advance changes the registers and a few words of memory, as if the state ran for the given number of steps.
+func advance(state *VersionedState, steps uint64) {
+	st := state.FPVMState.(*multithreaded.State)
+	st.Step += steps
+	thread := st.GetCurrentThread()
+	thread.Cpu.PC += 4 * arch.Word(steps)
+	thread.Cpu.NextPC = thread.Cpu.PC + 4
+	thread.Registers[2] = arch.Word(st.Step)
+	st.Memory.SetWord(arch.Word(st.Step%8)*memory.PageSize+8, arch.Word(st.Step))
+	// a newly allocated page
+	st.Memory.SetWord(arch.Word(0x100+st.Step)*memory.PageSize, arch.Word(st.Step))
+}
+
+func copyState(t *testing.T, state *VersionedState) *VersionedState {
+	var buf bytes.Buffer
+	require.NoError(t, state.Serialize(&buf))
+	out := new(VersionedState)
+	require.NoError(t, out.Deserialize(&buf))
+	return out
+}
+
+func TestDelta(t *testing.T) {
+	base := testState(t)
+	tracker := NewDeltaTracker(base)
+	baseCopy := copyState(t, base)
+
+	state := base
+	advance(state, 10)
+	delta, err := tracker.Delta(state)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), delta.BaseStep)
+	require.Equal(t, uint64(10), delta.Step)
+	// the changed page, and the new page
+	require.Len(t, delta.Pages, 2)
+	require.Equal(t, arch.Word(2), delta.Pages[0].Index)
+	require.Equal(t, arch.Word(0x100+10), delta.Pages[1].Index)
+
+	restored, err := delta.Apply(baseCopy)
+	require.NoError(t, err)
+	_, expectedHash := state.EncodeWitness()
+	_, restoredHash := restored.EncodeWitness()
+	require.Equal(t, expectedHash, restoredHash)
+	require.Equal(t, state.GetStep(), restored.GetStep())
+	require.Equal(t, state.GetMemory().PageCount(), restored.GetMemory().PageCount())
+
+	t.Run("changes accumulate since the base", func(t *testing.T) {
+		advance(state, 3)
+		delta, err := tracker.Delta(state)
+		require.NoError(t, err)
+		require.Len(t, delta.Pages, 4)
+		restored, err := delta.Apply(baseCopy)
+		require.NoError(t, err)
+		_, expectedHash := state.EncodeWitness()
+		_, restoredHash := restored.EncodeWitness()
+		require.Equal(t, expectedHash, restoredHash)
+	})
+
+	t.Run("serialization", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, delta.Serialize(&buf))
+		header, err := ReadDeltaHeader(bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		require.Equal(t, delta.Step, header.Step)
+		require.Equal(t, delta.BaseHash, header.BaseHash)
+		require.Nil(t, header.Pages)
+
+		decoded := new(StateDelta)
+		require.NoError(t, decoded.Deserialize(&buf))
+		require.Equal(t, delta, decoded)
+	})
+
+	t.Run("full state is not a delta", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, base.Serialize(&buf))
+		_, err := ReadDeltaHeader(&buf)
+		require.ErrorIs(t, err, ErrNotDelta)
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+		_, err := delta.Apply(restored)
+		require.ErrorIs(t, err, ErrDeltaMismatch)
+
+		other := copyState(t, baseCopy)
+		other.GetMemory().SetWord(0, 0xff)
+		_, err = delta.Apply(other)
+		require.ErrorIs(t, err, ErrDeltaMismatch)
+	})
+
+	t.Run("corrupt delta", func(t *testing.T) {
+		corrupt := *delta
+		corrupt.Pages = corrupt.Pages[:1]
+		_, err := corrupt.Apply(baseCopy)
+		require.ErrorContains(t, err, "does not match delta state hash")
+	})
+}
@@ -1,8 +1,14 @@
 package versions
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/pprof/profile"
 
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/serialize"
@@ -31,3 +37,40 @@ func DetectVersion(path string) (StateVersion, error) {
 	}
 	return ver, nil
 }
+
+// profileVersionPrefix prefixes the pprof comment that records the state version a profile was taken with.
+const profileVersionPrefix = "cannon-state-version: "
+
+// ProfileComment returns the pprof comment that records the state version a profile was taken with.
+func ProfileComment(ver StateVersion) string {
+	return profileVersionPrefix + strconv.FormatUint(uint64(ver), 10)
+}
+
+// DetectProfileVersion returns the state version recorded in a pprof profile written by `cannon run --profile`.
+func DetectProfileVersion(path string) (StateVersion, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	pp, err := profile.Parse(f)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse profile %q: %w", path, err)
+	}
+	for _, c := range pp.Comments {
+		v, ok := strings.CutPrefix(c, profileVersionPrefix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("invalid state version in profile %q: %w", path, err)
+		}
+		ver := StateVersion(n)
+		if !IsValidStateVersion(ver) {
+			return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, ver)
+		}
+		return ver, nil
+	}
+	return 0, errors.New("profile does not record a state version")
+}
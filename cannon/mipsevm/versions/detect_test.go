@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-service/serialize"
+	"github.com/google/pprof/profile"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
@@ -92,6 +93,49 @@ func TestDetectVersion_invalid(t *testing.T) {
 	})
 }
 
+func TestDetectProfileVersion(t *testing.T) {
+	writeProfile := func(t *testing.T, comments ...string) string {
+		path := filepath.Join(t.TempDir(), "profile.pb.gz")
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+		pp := &profile.Profile{
+			SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}},
+			PeriodType: &profile.ValueType{Type: "steps", Unit: "count"},
+			Period:     1,
+			Comments:   comments,
+		}
+		require.NoError(t, pp.Write(f))
+		return path
+	}
+
+	for _, version := range StateVersionTypes {
+		t.Run(version.String(), func(t *testing.T) {
+			path := writeProfile(t, "other comment", ProfileComment(version))
+			detected, err := DetectProfileVersion(path)
+			require.NoError(t, err)
+			require.Equal(t, version, detected)
+		})
+	}
+
+	t.Run("no version", func(t *testing.T) {
+		_, err := DetectProfileVersion(writeProfile(t))
+		require.ErrorContains(t, err, "does not record a state version")
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := DetectProfileVersion(writeProfile(t, ProfileComment(0xFF)))
+		require.ErrorIs(t, err, ErrUnknownVersion)
+	})
+
+	t.Run("not a profile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profile.pb.gz")
+		require.NoError(t, os.WriteFile(path, []byte("ekans"), 0o644))
+		_, err := DetectProfileVersion(path)
+		require.ErrorContains(t, err, "failed to parse profile")
+	})
+}
+
 func writeToFile(t *testing.T, filename string, data serialize.Serializable) string {
 	dir := t.TempDir()
 	path := filepath.Join(dir, filename)
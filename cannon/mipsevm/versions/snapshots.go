@@ -0,0 +1,250 @@
+package versions
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+var ErrNoSnapshot = errors.New("no snapshot")
+
+// SnapshotInfo describes a full or delta snapshot file of a run.
+type SnapshotInfo struct {
+	Path string `json:"path"`
+	Step uint64 `json:"step"`
+	// Delta is true if the snapshot is a delta against the full snapshot at BaseStep.
+	Delta    bool   `json:"delta"`
+	BaseStep uint64 `json:"baseStep,omitempty"`
+}
+
+// ListSnapshots returns the snapshots with a path that matches the format, e.g. "snapshots/state-%d.bin.gz",
+// ordered by step. The step of a snapshot is read from its path.
+func ListSnapshots(format string) ([]SnapshotInfo, error) {
+	dir := filepath.Dir(format)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot dir %q: %w", dir, err)
+	}
+	var out []SnapshotInfo
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		var step uint64
+		if _, err := fmt.Sscanf(filepath.Base(path), filepath.Base(format), &step); err != nil {
+			continue
+		}
+		if fmt.Sprintf(filepath.Base(format), step) != entry.Name() {
+			continue // e.g. a suffix after the format, or leading zeros that the format would not write
+		}
+		info, err := readSnapshotInfo(path, step)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, info)
+	}
+	slices.SortFunc(out, func(a, b SnapshotInfo) int {
+		return cmp.Compare(a.Step, b.Step)
+	})
+	return out, nil
+}
+
+// DetectSnapshotsVersion returns the state version of the snapshots that match the format,
+// read from the first full snapshot. Deltas do not record the state version of their base.
+func DetectSnapshotsVersion(format string) (StateVersion, error) {
+	snaps, err := ListSnapshots(format)
+	if err != nil {
+		return 0, err
+	}
+	for _, snap := range snaps {
+		if !snap.Delta {
+			return DetectVersion(snap.Path)
+		}
+	}
+	return 0, fmt.Errorf("%w: no full snapshot matches %q", ErrNoSnapshot, format)
+}
+
+func readSnapshotInfo(path string, step uint64) (SnapshotInfo, error) {
+	info := SnapshotInfo{Path: path, Step: step}
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("failed to open snapshot %q: %w", path, err)
+	}
+	defer f.Close()
+	header, err := ReadDeltaHeader(f)
+	if errors.Is(err, ErrNotDelta) {
+		return info, nil
+	} else if err != nil {
+		return SnapshotInfo{}, fmt.Errorf("failed to read snapshot %q: %w", path, err)
+	}
+	if header.Step != step {
+		return SnapshotInfo{}, fmt.Errorf("snapshot %q is of step %d", path, header.Step)
+	}
+	info.Delta = true
+	info.BaseStep = header.BaseStep
+	return info, nil
+}
+
+func findSnapshot(snaps []SnapshotInfo, step uint64) (SnapshotInfo, bool) {
+	i, found := slices.BinarySearchFunc(snaps, step, func(s SnapshotInfo, step uint64) int {
+		return cmp.Compare(s.Step, step)
+	})
+	if !found {
+		return SnapshotInfo{}, false
+	}
+	return snaps[i], true
+}
+
+// RestoreSnapshot returns the state of the last snapshot at or before the given step,
+// applying it to its base if it is a delta. The snapshots must be ordered by step.
+func RestoreSnapshot(snaps []SnapshotInfo, step uint64) (*VersionedState, error) {
+	i, _ := slices.BinarySearchFunc(snaps, step+1, func(s SnapshotInfo, step uint64) int {
+		return cmp.Compare(s.Step, step)
+	})
+	if i == 0 {
+		return nil, fmt.Errorf("%w at or before step %d", ErrNoSnapshot, step)
+	}
+	return loadSnapshot(snaps, snaps[i-1])
+}
+
+func loadSnapshot(snaps []SnapshotInfo, snap SnapshotInfo) (*VersionedState, error) {
+	if !snap.Delta {
+		return LoadStateFromFile(snap.Path)
+	}
+	base, ok := findSnapshot(snaps, snap.BaseStep)
+	if !ok || base.Delta {
+		return nil, fmt.Errorf("%w: full snapshot of step %d, the base of %q", ErrNoSnapshot, snap.BaseStep, snap.Path)
+	}
+	baseState, err := LoadStateFromFile(base.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load base snapshot %q: %w", base.Path, err)
+	}
+	delta, err := LoadDeltaFromFile(snap.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load delta snapshot %q: %w", snap.Path, err)
+	}
+	return delta.Apply(baseState)
+}
+
+// SnapshotWriter writes every fullEvery-th snapshot in full,
+// and the snapshots in between as deltas against the last full snapshot.
+type SnapshotWriter struct {
+	fullEvery uint64
+	perm      os.FileMode
+	count     uint64
+	tracker   *DeltaTracker
+}
+
+// NewSnapshotWriter returns a writer of snapshots. Every snapshot is written in full if fullEvery is 0 or 1.
+func NewSnapshotWriter(fullEvery uint64, perm os.FileMode) *SnapshotWriter {
+	return &SnapshotWriter{fullEvery: fullEvery, perm: perm}
+}
+
+// Write writes the snapshot of the state, and returns whether it was written as delta.
+func (w *SnapshotWriter) Write(path string, state *VersionedState) (bool, error) {
+	defer func() { w.count++ }()
+	if w.fullEvery <= 1 || w.count%w.fullEvery == 0 || w.tracker == nil {
+		if err := serialize.Write(path, state, w.perm); err != nil {
+			return false, fmt.Errorf("failed to write full snapshot: %w", err)
+		}
+		if w.fullEvery > 1 {
+			w.tracker = NewDeltaTracker(state)
+		}
+		return false, nil
+	}
+	delta, err := w.tracker.Delta(state)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode delta snapshot: %w", err)
+	}
+	if err := serialize.Write(path, delta, w.perm); err != nil {
+		return false, fmt.Errorf("failed to write delta snapshot: %w", err)
+	}
+	return true, nil
+}
+
+// PruneSnapshots returns the snapshots to remove to only keep the snapshots matching keep, and the last snapshot.
+// Full snapshots that a kept delta is applied to are kept as well.
+func PruneSnapshots(snaps []SnapshotInfo, keep func(step uint64) bool) []SnapshotInfo {
+	kept := make(map[uint64]bool)
+	for i, snap := range snaps {
+		if keep(snap.Step) || i == len(snaps)-1 {
+			kept[snap.Step] = true
+			if snap.Delta {
+				kept[snap.BaseStep] = true
+			}
+		}
+	}
+	var remove []SnapshotInfo
+	for _, snap := range snaps {
+		if !kept[snap.Step] {
+			remove = append(remove, snap)
+		}
+	}
+	return remove
+}
+
+// CompactSnapshots rewrites the snapshots in place, so that every fullEvery-th snapshot is a full snapshot,
+// and the snapshots in between are deltas against the last full snapshot before them.
+// All snapshots are written in full if fullEvery is 0 or 1. The snapshots must be ordered by step.
+func CompactSnapshots(snaps []SnapshotInfo, fullEvery uint64, perm os.FileMode) ([]SnapshotInfo, error) {
+	// The last delta that is applied to each full snapshot, so it is kept in memory while needed,
+	// even after its file is rewritten as a delta.
+	lastUse := make(map[uint64]int)
+	for i, snap := range snaps {
+		if snap.Delta {
+			lastUse[snap.BaseStep] = i
+		}
+	}
+	bases := make(map[uint64]*VersionedState)
+	writer := NewSnapshotWriter(fullEvery, perm)
+	out := make([]SnapshotInfo, 0, len(snaps))
+	for i, snap := range snaps {
+		var state *VersionedState
+		if snap.Delta {
+			base, ok := bases[snap.BaseStep]
+			if !ok {
+				return nil, fmt.Errorf("%w: full snapshot of step %d, the base of %q", ErrNoSnapshot, snap.BaseStep, snap.Path)
+			}
+			delta, err := LoadDeltaFromFile(snap.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load delta snapshot %q: %w", snap.Path, err)
+			}
+			state, err = delta.Apply(base)
+			if err != nil {
+				return nil, fmt.Errorf("failed to restore delta snapshot %q: %w", snap.Path, err)
+			}
+		} else {
+			var err error
+			state, err = LoadStateFromFile(snap.Path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load full snapshot %q: %w", snap.Path, err)
+			}
+			if _, ok := lastUse[snap.Step]; ok {
+				bases[snap.Step] = state
+			}
+		}
+		for step, last := range lastUse {
+			if last <= i {
+				delete(bases, step)
+				delete(lastUse, step)
+			}
+		}
+		isDelta, err := writer.Write(snap.Path, state)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite snapshot %q: %w", snap.Path, err)
+		}
+		compacted := SnapshotInfo{Path: snap.Path, Step: snap.Step, Delta: isDelta}
+		if isDelta {
+			compacted.BaseStep = writer.tracker.BaseStep()
+		}
+		out = append(out, compacted)
+	}
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package versions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// writeSnapshots writes a snapshot every 10 steps up to the given step, and returns the state hash of every snapshot.
+func writeSnapshots(t *testing.T, format string, fullEvery uint64, last uint64) map[uint64]common.Hash {
+	state := testState(t)
+	writer := NewSnapshotWriter(fullEvery, 0o644)
+	hashes := make(map[uint64]common.Hash)
+	for step := uint64(0); step <= last; step += 10 {
+		if step > 0 {
+			advance(state, 10)
+		}
+		isDelta, err := writer.Write(fmt.Sprintf(format, step), state)
+		require.NoError(t, err)
+		require.Equal(t, fullEvery > 1 && (step/10)%fullEvery != 0, isDelta)
+		_, hashes[step] = state.EncodeWitness()
+	}
+	return hashes
+}
+
+func requireRestores(t *testing.T, snaps []SnapshotInfo, hashes map[uint64]common.Hash) {
+	for step, expected := range hashes {
+		state, err := RestoreSnapshot(snaps, step+5)
+		require.NoError(t, err)
+		require.Equal(t, step, state.GetStep())
+		_, hash := state.EncodeWitness()
+		require.Equal(t, expected, hash, "snapshot of step %d", step)
+	}
+}
+
+func TestSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	format := filepath.Join(dir, "state-%d.bin.gz")
+	hashes := writeSnapshots(t, format, 4, 90)
+	// files that do not match the format
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state-010.bin.gz"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "out.bin.gz"), nil, 0o644))
+
+	snaps, err := ListSnapshots(format)
+	require.NoError(t, err)
+	require.Len(t, snaps, 10)
+	for i, snap := range snaps {
+		step := uint64(i * 10)
+		require.Equal(t, fmt.Sprintf(format, step), snap.Path)
+		require.Equal(t, step, snap.Step)
+		require.Equal(t, i%4 != 0, snap.Delta)
+		if snap.Delta {
+			require.Equal(t, uint64(i/4*40), snap.BaseStep)
+		}
+	}
+
+	t.Run("detect version", func(t *testing.T) {
+		ver, err := DetectSnapshotsVersion(format)
+		require.NoError(t, err)
+		require.Equal(t, GetCurrentVersion(), ver)
+		_, err = DetectSnapshotsVersion(filepath.Join(dir, "other-%d.bin.gz"))
+		require.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		requireRestores(t, snaps, hashes)
+		_, err := RestoreSnapshot(snaps[1:], 5)
+		require.ErrorIs(t, err, ErrNoSnapshot)
+		// the base of the delta is missing
+		_, err = RestoreSnapshot(snaps[1:], 15)
+		require.ErrorIs(t, err, ErrNoSnapshot)
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		remove := PruneSnapshots(snaps, func(step uint64) bool { return step%30 == 0 })
+		var removed []uint64
+		for _, snap := range remove {
+			removed = append(removed, snap.Step)
+		}
+		// 30 and 60 are kept with their bases 0 and 40, 90 is the last snapshot and 80 its base
+		require.Equal(t, []uint64{10, 20, 50, 70}, removed)
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		compacted, err := CompactSnapshots(snaps, 3, 0o644)
+		require.NoError(t, err)
+		listed, err := ListSnapshots(format)
+		require.NoError(t, err)
+		require.Equal(t, compacted, listed)
+		for i, snap := range listed {
+			require.Equal(t, i%3 != 0, snap.Delta)
+			if snap.Delta {
+				require.Equal(t, uint64(i/3*30), snap.BaseStep)
+			}
+		}
+		requireRestores(t, listed, hashes)
+
+		expanded, err := CompactSnapshots(listed, 1, 0o644)
+		require.NoError(t, err)
+		for _, snap := range expanded {
+			require.False(t, snap.Delta)
+		}
+		requireRestores(t, expanded, hashes)
+	})
+}
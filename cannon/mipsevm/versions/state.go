@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/op-service/serialize"
 	"github.com/ethereum/go-ethereum/log"
@@ -51,6 +53,8 @@ func NewFromState(vers StateVersion, state mipsevm.FPVMState) (*VersionedState,
 type VersionedState struct {
 	Version StateVersion
 	mipsevm.FPVMState
+	// Build is the build of cannon that wrote the state, if recorded. It is not part of the state witness.
+	Build *buildinfo.Info
 }
 
 func (s *VersionedState) CreateVM(logger log.Logger, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, meta mipsevm.Metadata) mipsevm.FPVM {
@@ -59,7 +63,10 @@ func (s *VersionedState) CreateVM(logger log.Logger, po mipsevm.PreimageOracle,
 }
 
 func FeaturesForVersion(version StateVersion) mipsevm.FeatureToggles {
-	features := mipsevm.FeatureToggles{}
+	features := mipsevm.FeatureToggles{
+		// All multithreaded versions share the quantum of the onchain VM
+		SchedQuantum: exec.SchedQuantum,
+	}
 	// Set any required feature toggles based on the state version here.
 	if version >= VersionMultiThreaded64_v4 {
 		features.SupportMinimalSysEventFd2 = true
@@ -69,6 +76,11 @@ func FeaturesForVersion(version StateVersion) mipsevm.FeatureToggles {
 	if version >= VersionMultiThreaded64_v5 {
 		features.SupportWorkingSysGetRandom = true
 	}
+	if version >= VersionMultiThreaded64_v6 {
+		features.SupportExtendedClockGettime = true
+		features.SupportRotrSebSeh = true
+		features.SupportMuslRuntime = true
+	}
 	return features
 }
 
@@ -77,7 +89,13 @@ func (s *VersionedState) Serialize(w io.Writer) error {
 	if err := bout.WriteUInt(s.Version); err != nil {
 		return err
 	}
-	return s.FPVMState.Serialize(w)
+	if err := s.FPVMState.Serialize(w); err != nil {
+		return err
+	}
+	if s.Build != nil {
+		return writeBuildTrailer(w, s.Build)
+	}
+	return nil
 }
 
 func (s *VersionedState) Deserialize(in io.Reader) error {
@@ -95,6 +113,11 @@ func (s *VersionedState) Deserialize(in io.Reader) error {
 			return err
 		}
 		s.FPVMState = state
+		build, err := readBuildTrailer(in)
+		if err != nil {
+			return err
+		}
+		s.Build = build
 		return nil
 	} else {
 		return fmt.Errorf("%w: %d", ErrUnknownVersion, s.Version)
@@ -27,6 +27,8 @@ const (
 	VersionMultiThreaded64_v4
 	// VersionMultiThreaded64_v5 adds support for a working (non-noop) getrandom syscall
 	VersionMultiThreaded64_v5
+	// VersionMultiThreaded64_v6 adds support for the CLOCK_THREAD_CPUTIME_ID and CLOCK_BOOTTIME clocks of clock_gettime
+	VersionMultiThreaded64_v6
 )
 
 var StateVersionTypes = []StateVersion{
@@ -39,6 +41,7 @@ var StateVersionTypes = []StateVersion{
 	VersionMultiThreaded64_v3,
 	VersionMultiThreaded64_v4,
 	VersionMultiThreaded64_v5,
+	VersionMultiThreaded64_v6,
 }
 
 func (s StateVersion) String() string {
@@ -61,6 +64,8 @@ func (s StateVersion) String() string {
 		return "multithreaded64-4"
 	case VersionMultiThreaded64_v5:
 		return "multithreaded64-5"
+	case VersionMultiThreaded64_v6:
+		return "multithreaded64-6"
 	default:
 		return "unknown"
 	}
@@ -86,6 +91,8 @@ func ParseStateVersion(ver string) (StateVersion, error) {
 		return VersionMultiThreaded64_v4, nil
 	case "multithreaded64-5":
 		return VersionMultiThreaded64_v5, nil
+	case "multithreaded64-6":
+		return VersionMultiThreaded64_v6, nil
 	default:
 		return StateVersion(0), errors.New("unknown state version")
 	}
@@ -115,9 +122,10 @@ func GetExperimentalVersion() StateVersion {
 	return StateVersionTypes[lastVersionIndex]
 }
 
-// IsSupportedMultiThreaded64 returns true if the state version is a 64-bit multithreaded VM that is currently supported
+// IsSupportedMultiThreaded64 returns true if the state version is a 64-bit multithreaded VM that is currently supported.
+// VersionMultiThreaded64_v5 sits between the current and the experimental version, so it is listed explicitly.
 func IsSupportedMultiThreaded64(ver StateVersion) bool {
-	return ver == GetCurrentVersion() || ver == GetExperimentalVersion()
+	return ver == GetCurrentVersion() || ver == VersionMultiThreaded64_v5 || ver == GetExperimentalVersion()
 }
 
 // IsSupported returns true if the state version is currently supported
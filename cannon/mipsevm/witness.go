@@ -7,6 +7,11 @@ import (
 
 type LocalContext common.Hash
 
+// MaxStepInputSize is the default ceiling of the calldata of an onchain step, and of the pre-image oracle call that
+// loads its pre-image: the 128 KiB transaction size limit of the geth transaction pool, above which the calls cannot
+// be sent as regular transactions.
+const MaxStepInputSize = 128 * 1024
+
 type StepWitness struct {
 	// encoded state witness
 	State     []byte
@@ -23,6 +28,17 @@ func (wit *StepWitness) HasPreimage() bool {
 	return wit.PreimageKey != ([32]byte{})
 }
 
+// StepInputSize returns the size of the ABI-encoded calldata of the step(bytes,bytes,bytes32) call of the witness.
+func (wit *StepWitness) StepInputSize() int {
+	// selector, and the offsets of the two dynamic arguments and the local context
+	return 4 + 3*32 + abiBytesSize(len(wit.State)) + abiBytesSize(len(wit.ProofData))
+}
+
+// abiBytesSize returns the size of the ABI encoding of a dynamic bytes value: its length, and the padded data.
+func abiBytesSize(n int) int {
+	return 32 + (n+31)/32*32
+}
+
 type HashFn func(sw []byte) (common.Hash, error)
 
 func AppendBoolToWitness(witnessData []byte, boolVal bool) []byte {
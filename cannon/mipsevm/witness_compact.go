@@ -0,0 +1,138 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// CompactProofVersion is the version byte of the compact proof-data encoding.
+const CompactProofVersion = 1
+
+// Node tags of the compact proof-data encoding.
+const (
+	// compactNodeLiteral is followed by the 32-byte node.
+	compactNodeLiteral = 0
+	// compactNodeZero is the root of a zeroed sub-tree of the height of the node.
+	compactNodeZero = 1
+	// compactNodeCopy + i is a copy of the node at the same level of the i-th earlier memory proof.
+	compactNodeCopy = 2
+)
+
+// maxCompactProofs is the max number of memory proofs that can be referenced by a node tag.
+const maxCompactProofs = 256 - compactNodeCopy
+
+var ErrInvalidCompactProof = errors.New("invalid compact proof data")
+
+// CompactProofData encodes the proof-data of a StepWitness in a compact form.
+//
+// The proof-data is a VM-specific prefix (e.g. the thread witness), shorter than a memory proof,
+// followed by one or more memory merkle proofs. Memory accesses within the same step are often close together,
+// so these proofs largely overlap, and sparse memory results in many zero sub-tree nodes.
+// Each proof node is thus either encoded as-is, as a zero sub-tree, or as a reference to the same node in an earlier proof.
+//
+// The compact form is not understood by the on-chain VM: it must be expanded with ExpandProofData before submission.
+//
+// Format:
+// version(1) prefix-length(2) prefix proof-count(1) [node-tag(1) [node(32)]]*
+func CompactProofData(proofData []byte) ([]byte, error) {
+	prefixLen := len(proofData) % memory.MemProofSize
+	proofCount := len(proofData) / memory.MemProofSize
+	if prefixLen > 0xffff {
+		return nil, fmt.Errorf("proof-data prefix too large: %d bytes", prefixLen)
+	}
+	if proofCount > maxCompactProofs {
+		return nil, fmt.Errorf("too many memory proofs: %d", proofCount)
+	}
+	out := make([]byte, 0, 4+len(proofData))
+	out = append(out, CompactProofVersion)
+	out = binary.BigEndian.AppendUint16(out, uint16(prefixLen))
+	out = append(out, proofData[:prefixLen]...)
+	out = append(out, byte(proofCount))
+	proofs := proofData[prefixLen:]
+	for j := 0; j < proofCount; j++ {
+	nodes:
+		for i := 0; i < memory.MemProofLeafCount; i++ {
+			node := compactProofNode(proofs, j, i)
+			if [32]byte(node) == zeroNode(i) {
+				out = append(out, compactNodeZero)
+				continue
+			}
+			for k := 0; k < j; k++ {
+				if [32]byte(compactProofNode(proofs, k, i)) == [32]byte(node) {
+					out = append(out, byte(compactNodeCopy+k))
+					continue nodes
+				}
+			}
+			out = append(out, compactNodeLiteral)
+			out = append(out, node...)
+		}
+	}
+	return out, nil
+}
+
+// ExpandProofData decodes proof-data encoded with CompactProofData back into the regular proof-data format.
+func ExpandProofData(data []byte) ([]byte, error) {
+	if len(data) < 3 {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidCompactProof)
+	}
+	if data[0] != CompactProofVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidCompactProof, data[0])
+	}
+	prefixLen := int(binary.BigEndian.Uint16(data[1:3]))
+	data = data[3:]
+	if len(data) < prefixLen+1 {
+		return nil, fmt.Errorf("%w: truncated prefix", ErrInvalidCompactProof)
+	}
+	proofCount := int(data[prefixLen])
+	out := make([]byte, 0, prefixLen+proofCount*memory.MemProofSize)
+	out = append(out, data[:prefixLen]...)
+	data = data[prefixLen+1:]
+	for j := 0; j < proofCount; j++ {
+		for i := 0; i < memory.MemProofLeafCount; i++ {
+			if len(data) < 1 {
+				return nil, fmt.Errorf("%w: truncated proof %d at node %d", ErrInvalidCompactProof, j, i)
+			}
+			tag := data[0]
+			data = data[1:]
+			switch {
+			case tag == compactNodeLiteral:
+				if len(data) < 32 {
+					return nil, fmt.Errorf("%w: truncated proof %d at node %d", ErrInvalidCompactProof, j, i)
+				}
+				out = append(out, data[:32]...)
+				data = data[32:]
+			case tag == compactNodeZero:
+				node := zeroNode(i)
+				out = append(out, node[:]...)
+			default:
+				k := int(tag - compactNodeCopy)
+				if k >= j {
+					return nil, fmt.Errorf("%w: proof %d node %d references proof %d", ErrInvalidCompactProof, j, i, k)
+				}
+				out = append(out, compactProofNode(out[prefixLen:], k, i)...)
+			}
+		}
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidCompactProof, len(data))
+	}
+	return out, nil
+}
+
+// compactProofNode returns the i-th node of the j-th memory proof.
+func compactProofNode(proofs []byte, j int, i int) []byte {
+	offset := j*memory.MemProofSize + i*32
+	return proofs[offset : offset+32]
+}
+
+// zeroNode returns the value of the i-th node of a memory proof, if the node covers only zeroed memory.
+// The first node is the leaf itself, the second is its sibling, and every next node is a sibling one level up.
+func zeroNode(i int) [32]byte {
+	if i == 0 {
+		return memory.ZeroHash(0)
+	}
+	return memory.ZeroHash(i - 1)
+}
@@ -0,0 +1,86 @@
+package mipsevm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+func TestCompactProofData(t *testing.T) {
+	mem := memory.NewMemory()
+	pc := arch.Word(0x1000)
+	addr := arch.Word(0x7f_0000_1230)
+	mem.SetWord(pc, 0x1234)
+	mem.SetWord(addr, 0xabcd)
+	mem.SetWord(addr+arch.WordSizeBytes, 0xef01)
+	mem.SetWord(0xff_0000_0000, 0x42)
+
+	prefix := make([]byte, 123)
+	for i := range prefix {
+		prefix[i] = byte(i)
+	}
+	insnProof := mem.MerkleProof(pc)
+	memProof := mem.MerkleProof(addr)
+	memProof2 := mem.MerkleProof(addr + arch.WordSizeBytes)
+
+	cases := []struct {
+		name      string
+		proofData []byte
+	}{
+		{"prefix-only", prefix},
+		{"empty", []byte{}},
+		{"single-proof", append(append([]byte{}, prefix...), insnProof[:]...)},
+		{"adjacent-proofs", append(append(append(append([]byte{}, prefix...), insnProof[:]...), memProof[:]...), memProof2[:]...)},
+		{"no-prefix", append(append([]byte{}, memProof[:]...), memProof2[:]...)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			compact, err := CompactProofData(c.proofData)
+			require.NoError(t, err)
+			expanded, err := ExpandProofData(compact)
+			require.NoError(t, err)
+			require.Equal(t, c.proofData, expanded)
+		})
+	}
+
+	t.Run("shrinks overlapping proofs", func(t *testing.T) {
+		proofData := append(append(append(append([]byte{}, prefix...), insnProof[:]...), memProof[:]...), memProof2[:]...)
+		compact, err := CompactProofData(proofData)
+		require.NoError(t, err)
+		// The second memory proof shares all but its leaf nodes with the first,
+		// and the sparse memory leaves most sibling nodes zeroed.
+		require.Less(t, len(compact), len(prefix)+2*memory.MemProofSize)
+	})
+}
+
+func TestExpandProofData_Invalid(t *testing.T) {
+	mem := memory.NewMemory()
+	mem.SetWord(0x1000, 0x1234)
+	proof := mem.MerkleProof(0x1000)
+	compact, err := CompactProofData(proof[:])
+	require.NoError(t, err)
+
+	t.Run("unsupported version", func(t *testing.T) {
+		data := append([]byte{}, compact...)
+		data[0] = CompactProofVersion + 1
+		_, err := ExpandProofData(data)
+		require.ErrorIs(t, err, ErrInvalidCompactProof)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ExpandProofData(compact[:len(compact)-1])
+		require.ErrorIs(t, err, ErrInvalidCompactProof)
+	})
+	t.Run("trailing data", func(t *testing.T) {
+		_, err := ExpandProofData(append(append([]byte{}, compact...), 0))
+		require.ErrorIs(t, err, ErrInvalidCompactProof)
+	})
+	t.Run("forward reference", func(t *testing.T) {
+		// version, empty prefix, one proof, which references itself
+		data := []byte{CompactProofVersion, 0, 0, 1, compactNodeCopy}
+		_, err := ExpandProofData(data)
+		require.ErrorIs(t, err, ErrInvalidCompactProof)
+	})
+}
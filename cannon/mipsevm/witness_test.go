@@ -0,0 +1,23 @@
+package mipsevm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepInputSize(t *testing.T) {
+	bytesType, err := abi.NewType("bytes", "", nil)
+	require.NoError(t, err)
+	bytes32Type, err := abi.NewType("bytes32", "", nil)
+	require.NoError(t, err)
+	args := abi.Arguments{{Type: bytesType}, {Type: bytesType}, {Type: bytes32Type}}
+
+	for _, size := range []struct{ state, proof int }{{0, 0}, {1, 31}, {32, 64}, {188, 4000}, {189, 33}} {
+		wit := &StepWitness{State: make([]byte, size.state), ProofData: make([]byte, size.proof)}
+		packed, err := args.Pack(wit.State, wit.ProofData, [32]byte{})
+		require.NoError(t, err)
+		require.Equal(t, 4+len(packed), wit.StepInputSize(), "state %d bytes, proof %d bytes", size.state, size.proof)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/cannon/stream"
+)
+
+func CompareStates(ctx *cli.Context) error {
+	if len(os.Args) == 3 && os.Args[2] == "--help" {
+		if err := list(); err != nil {
+			return err
+		}
+		fmt.Println("use `--a <valid input file> --b <valid input file> --help` to get more detailed help")
+		return nil
+	}
+
+	versionA, err := detectCompareVersion(os.Args[1:], "--a")
+	if err != nil {
+		return err
+	}
+	versionB, err := detectCompareVersion(os.Args[1:], "--b")
+	if err != nil {
+		return err
+	}
+	if versionA == nil && versionB == nil {
+		return errors.New("only one of --a and --b can be read from stdin")
+	}
+	// The newest of the two cannons also loads the state of the older version.
+	version := versionA
+	if version == nil || (versionB != nil && *versionB > *version) {
+		version = versionB
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], *version)
+}
+
+// detectCompareVersion detects the version of the state at a path flag.
+// It returns nil if the state is read from stdin, which cannot be read ahead of cannon.
+func detectCompareVersion(args []string, flag string) (*versions.StateVersion, error) {
+	path, err := parseFlag(args, flag)
+	if err != nil {
+		return nil, err
+	}
+	if path == stream.Path {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("file `%s` does not exist", path)
+	}
+	version, err := versions.DetectVersion(path)
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+var CompareStatesCommand = &cli.Command{
+	Name:            "compare-states",
+	Usage:           "Print the difference between two states",
+	Description:     "Print the difference between two states, using the cannon of the newest of their state versions.",
+	Action:          CompareStates,
+	SkipFlagParsing: true,
+}
@@ -43,6 +43,7 @@ func ExecuteCannon(ctx context.Context, args []string, ver versions.StateVersion
 
 	// nosemgrep: go.lang.security.audit.dangerous-exec-command.dangerous-exec-command
 	cmd := exec.CommandContext(ctx, cannonProgramPath, args...)
+	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	err = cmd.Start()
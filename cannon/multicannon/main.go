@@ -30,6 +30,10 @@ func main() {
 		LoadELFCommand,
 		WitnessCommand,
 		RunCommand,
+		ProfileCommand,
+		SnapshotsCommand,
+		VerifyBuildCommand,
+		CompareStatesCommand,
 		ListCommand,
 	}
 	ctx := ctxinterrupt.WithCancelOnInterrupt(context.Background())
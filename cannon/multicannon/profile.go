@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func Profile(ctx *cli.Context) error {
+	inputPath, err := parsePathFlag(os.Args[1:], "--input")
+	if err != nil {
+		return err
+	}
+	version, err := versions.DetectProfileVersion(inputPath)
+	if err != nil {
+		return err
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], version)
+}
+
+var ProfileCommand = &cli.Command{
+	Name:            "profile",
+	Usage:           "Report the hot paths of an instruction-level profile of the guest program",
+	Description:     "Report the hot paths of a profile written by `cannon run --profile`, using the cannon of the state version the profile was taken with.",
+	Action:          Profile,
+	SkipFlagParsing: true,
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+// defaultSnapshotFmt matches the default of `cannon snapshots --snapshot-fmt`.
+const defaultSnapshotFmt = "state-%d.bin.gz"
+
+func Snapshots(ctx *cli.Context) error {
+	if len(os.Args) == 2 || (len(os.Args) == 3 && os.Args[2] == "--help") {
+		if err := list(); err != nil {
+			return err
+		}
+		fmt.Println("use `snapshots <list|restore|prune|compact> --snapshot-fmt <format of existing snapshots> --help` to get more detailed help")
+		return nil
+	}
+
+	format, err := parseFlag(os.Args[1:], "--snapshot-fmt")
+	if err != nil {
+		if !errors.Is(err, errMissingFlag) {
+			return err
+		}
+		format = defaultSnapshotFmt
+	}
+	version, err := versions.DetectSnapshotsVersion(format)
+	if err != nil {
+		return err
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], version)
+}
+
+var SnapshotsCommand = &cli.Command{
+	Name:            "snapshots",
+	Usage:           "Manage the full and delta state snapshots of a run",
+	Description:     "Manage the state snapshots written by `cannon run --snapshot-at`, using the cannon of the state version of the snapshots.",
+	Action:          Snapshots,
+	SkipFlagParsing: true,
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 )
 
+var errMissingFlag = errors.New("missing flag")
+
 // parseFlag reads a flag argument. It assumes the flag has an argument
 func parseFlag(args []string, flag string) (string, error) {
 	for i := 0; i < len(args); i++ {
@@ -22,7 +24,7 @@ func parseFlag(args []string, flag string) (string, error) {
 			}
 		}
 	}
-	return "", fmt.Errorf("missing flag: %s", flag)
+	return "", fmt.Errorf("%w: %s", errMissingFlag, flag)
 }
 
 func parsePathFlag(args []string, flag string) (string, error) {
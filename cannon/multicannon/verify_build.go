@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func VerifyBuild(ctx *cli.Context) error {
+	// Without --input, the build of the cannon of the current state version is verified.
+	version := versions.GetCurrentVersion()
+	inputPath, err := parsePathFlag(os.Args[1:], "--input")
+	if err == nil {
+		version, err = versions.DetectVersion(inputPath)
+		if err != nil {
+			return err
+		}
+	} else if !errors.Is(err, errMissingFlag) {
+		return err
+	}
+	return ExecuteCannon(ctx.Context, os.Args[1:], version)
+}
+
+var VerifyBuildCommand = &cli.Command{
+	Name:            "verify-build",
+	Usage:           "Verify the build of cannon, or of the cannon that wrote a state, against a published attestation",
+	Description:     "Verify the build of the cannon of the current state version, or of the cannon that wrote a state with --input, against a published attestation.",
+	Action:          VerifyBuild,
+	SkipFlagParsing: true,
+}
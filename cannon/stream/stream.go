@@ -0,0 +1,164 @@
+// Package stream implements the framed binary encoding of the states and proofs that cannon reads from stdin and
+// writes to stdout, to chain cannon in pipelines without intermediate files.
+//
+// A stream is a sequence of frames. Each frame is encoded as:
+//   - the magic "CNFR"
+//   - the kind of the payload (uint8)
+//   - the length of the payload (uint64)
+//   - the payload
+//
+// All numbers are encoded using big endian.
+// States are framed in their binary serialization, uncompressed. Proofs are framed in the JSON encoding of proof files.
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+	"github.com/ethereum-optimism/optimism/op-service/serialize"
+)
+
+// Path is the path of inputs and outputs that are streamed over stdin and stdout.
+const Path = "-"
+
+// MaxPayloadSize is the size of the largest payload of a frame.
+const MaxPayloadSize = 1 << 36
+
+var frameMagic = [4]byte{'C', 'N', 'F', 'R'}
+
+var (
+	ErrInvalidFrame = errors.New("invalid frame")
+	ErrNoState      = errors.New("no state in stream")
+)
+
+// Kind is the kind of the payload of a frame.
+type Kind uint8
+
+const (
+	KindState Kind = 1
+	KindProof Kind = 2
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindState:
+		return "state"
+	case KindProof:
+		return "proof"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+// Writer writes frames to an output stream. Each frame is flushed once written,
+// so the reader of the stream can consume it while the writer keeps going.
+type Writer struct {
+	w *bufio.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+func (w *Writer) WriteFrame(kind Kind, payload []byte) error {
+	if uint64(len(payload)) > MaxPayloadSize {
+		return fmt.Errorf("%w: %v payload of %d bytes exceeds the maximum of %d", ErrInvalidFrame, kind, len(payload), MaxPayloadSize)
+	}
+	out := serialize.NewBinaryWriter(w.w)
+	if err := out.WriteUInt(frameMagic); err != nil {
+		return fmt.Errorf("failed to write frame header: %w", err)
+	}
+	if err := out.WriteUInt(kind); err != nil {
+		return fmt.Errorf("failed to write frame header: %w", err)
+	}
+	if err := out.WriteUInt(uint64(len(payload))); err != nil {
+		return fmt.Errorf("failed to write frame header: %w", err)
+	}
+	if _, err := w.w.Write(payload); err != nil {
+		return fmt.Errorf("failed to write %v payload: %w", kind, err)
+	}
+	if err := w.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush %v frame: %w", kind, err)
+	}
+	return nil
+}
+
+// WriteState writes a frame with the binary serialization of the state.
+func (w *Writer) WriteState(state *versions.VersionedState) error {
+	var buf bytes.Buffer
+	if err := state.Serialize(&buf); err != nil {
+		return fmt.Errorf("failed to serialize state: %w", err)
+	}
+	return w.WriteFrame(KindState, buf.Bytes())
+}
+
+// Reader reads frames from an input stream.
+type Reader struct {
+	r *bufio.Reader
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next reads the next frame. Returns io.EOF iff the stream ends before the frame,
+// and io.ErrUnexpectedEOF if the stream ends within the frame.
+func (r *Reader) Next() (Kind, []byte, error) {
+	in := serialize.NewBinaryReader(r.r)
+	var magic [4]byte
+	if err := in.ReadUInt(&magic); err != nil {
+		return 0, nil, err
+	}
+	if magic != frameMagic {
+		return 0, nil, fmt.Errorf("%w: unexpected magic %x", ErrInvalidFrame, magic[:])
+	}
+	var kind Kind
+	if err := in.ReadUInt(&kind); err != nil {
+		return 0, nil, fmt.Errorf("failed to read frame header: %w", noEOF(err))
+	}
+	var size uint64
+	if err := in.ReadUInt(&size); err != nil {
+		return 0, nil, fmt.Errorf("failed to read frame header: %w", noEOF(err))
+	}
+	if size > MaxPayloadSize {
+		return 0, nil, fmt.Errorf("%w: %v payload of %d bytes exceeds the maximum of %d", ErrInvalidFrame, kind, size, MaxPayloadSize)
+	}
+	// The payload is not allocated upfront, so a corrupt size fails on the end of the stream instead of allocating it.
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r.r, int64(size)); err != nil {
+		return 0, nil, fmt.Errorf("failed to read %v payload: %w", kind, noEOF(err))
+	}
+	return kind, payload.Bytes(), nil
+}
+
+// ReadState reads the next state of the stream. Frames of other kinds before the state are skipped.
+// Returns ErrNoState if the stream ends before a state.
+func (r *Reader) ReadState() (*versions.VersionedState, error) {
+	for {
+		kind, payload, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNoState
+		} else if err != nil {
+			return nil, err
+		}
+		if kind != KindState {
+			continue
+		}
+		var state versions.VersionedState
+		if err := state.Deserialize(bytes.NewReader(payload)); err != nil {
+			return nil, fmt.Errorf("failed to deserialize state: %w", err)
+		}
+		return &state, nil
+	}
+}
+
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
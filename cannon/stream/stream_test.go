@@ -0,0 +1,104 @@
+package stream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/buildinfo"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/versions"
+)
+
+func TestFrames(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.WriteFrame(KindProof, []byte(`{"step":1}`)))
+	require.NoError(t, w.WriteFrame(KindProof, nil))
+	require.NoError(t, w.WriteFrame(KindState, []byte{1, 2, 3}))
+
+	r := NewReader(&buf)
+	kind, payload, err := r.Next()
+	require.NoError(t, err)
+	require.Equal(t, KindProof, kind)
+	require.Equal(t, []byte(`{"step":1}`), payload)
+
+	kind, payload, err = r.Next()
+	require.NoError(t, err)
+	require.Equal(t, KindProof, kind)
+	require.Empty(t, payload)
+
+	kind, payload, err = r.Next()
+	require.NoError(t, err)
+	require.Equal(t, KindState, kind)
+	require.Equal(t, []byte{1, 2, 3}, payload)
+
+	_, _, err = r.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestFrameFlushed(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.WriteFrame(KindProof, []byte{0xaa}))
+	require.Equal(t, 4+1+8+1, buf.Len(), "frame must be written without waiting for more frames")
+}
+
+func TestInvalidFrames(t *testing.T) {
+	frame := func() []byte {
+		var buf bytes.Buffer
+		require.NoError(t, NewWriter(&buf).WriteFrame(KindState, []byte{1, 2, 3, 4}))
+		return buf.Bytes()
+	}
+
+	t.Run("magic", func(t *testing.T) {
+		data := frame()
+		data[0] = 'X'
+		_, _, err := NewReader(bytes.NewReader(data)).Next()
+		require.ErrorIs(t, err, ErrInvalidFrame)
+	})
+
+	t.Run("truncated header", func(t *testing.T) {
+		_, _, err := NewReader(bytes.NewReader(frame()[:6])).Next()
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("truncated payload", func(t *testing.T) {
+		data := frame()
+		_, _, err := NewReader(bytes.NewReader(data[:len(data)-1])).Next()
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("oversized payload", func(t *testing.T) {
+		data := frame()
+		data[5] = 0xff // most significant byte of the payload size
+		_, _, err := NewReader(bytes.NewReader(data)).Next()
+		require.ErrorIs(t, err, ErrInvalidFrame)
+	})
+}
+
+func TestState(t *testing.T) {
+	expected, err := versions.NewFromState(versions.VersionMultiThreaded64_v5, multithreaded.CreateEmptyState())
+	require.NoError(t, err)
+	expected.Build = &buildinfo.Info{GitCommit: "abcd", GoVersion: "go1.23.8", FlagsHash: common.Hash{0xaa}}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	require.NoError(t, w.WriteFrame(KindProof, []byte(`{"step":1}`)))
+	require.NoError(t, w.WriteState(expected))
+	require.NoError(t, w.WriteState(expected))
+
+	r := NewReader(&buf)
+	actual, err := r.ReadState()
+	require.NoError(t, err, "proof frames before the state are skipped")
+	require.Equal(t, expected, actual)
+	actual, err = r.ReadState()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = r.ReadState()
+	require.ErrorIs(t, err, ErrNoState)
+}
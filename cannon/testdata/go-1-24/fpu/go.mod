@@ -0,0 +1,5 @@
+module fpu
+
+go 1.24
+
+toolchain go1.24.2
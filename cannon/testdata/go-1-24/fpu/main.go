@@ -0,0 +1,19 @@
+// Command fpu uses floating point arithmetic. Unlike the other test programs, it is built with hardfloat,
+// to check that the VM reports the floating point instructions it does not support.
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	// The operands depend on the input, so the arithmetic is not folded at build time
+	x := float64(len(os.Args)) + 0.5
+	y := x * x / 3
+	if y > x {
+		fmt.Println("greater", y)
+	} else {
+		fmt.Println("smaller", y)
+	}
+}
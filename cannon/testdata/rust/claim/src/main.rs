@@ -0,0 +1,98 @@
+//! Rust version of the Go claim program: it verifies a claim about a state transition,
+//! with the pre-images of the inputs served by the pre-image oracle of the VM.
+
+use std::fs::File;
+use std::io::{self, Read, Write};
+use std::mem::ManuallyDrop;
+use std::os::fd::FromRawFd;
+use std::process;
+
+const HINT_READ_FD: i32 = 3;
+const HINT_WRITE_FD: i32 = 4;
+const PREIMAGE_READ_FD: i32 = 5;
+const PREIMAGE_WRITE_FD: i32 = 6;
+
+const LOCAL_KEY_TYPE: u8 = 1;
+const KECCAK256_KEY_TYPE: u8 = 2;
+
+/// Returns the file of a file descriptor that the VM keeps open.
+fn fd(fd: i32) -> ManuallyDrop<File> {
+    ManuallyDrop::new(unsafe { File::from_raw_fd(fd) })
+}
+
+fn local_index_key(index: u64) -> [u8; 32] {
+    let mut key = [0u8; 32];
+    key[0] = LOCAL_KEY_TYPE;
+    key[24..].copy_from_slice(&index.to_be_bytes());
+    key
+}
+
+fn keccak256_key(hash: &[u8]) -> [u8; 32] {
+    let mut key: [u8; 32] = hash.try_into().expect("hash is 32 bytes");
+    key[0] = KECCAK256_KEY_TYPE;
+    key
+}
+
+/// Fetches the pre-image of the key: the key is written to the oracle,
+/// which responds with the length of the pre-image, and the pre-image.
+fn get(key: [u8; 32]) -> io::Result<Vec<u8>> {
+    fd(PREIMAGE_WRITE_FD).write_all(&key)?;
+    let mut reader = fd(PREIMAGE_READ_FD);
+    let mut length = [0u8; 8];
+    reader.read_exact(&mut length)?;
+    let mut data = vec![0u8; u64::from_be_bytes(length) as usize];
+    reader.read_exact(&mut data)?;
+    Ok(data)
+}
+
+/// Sends a hint, prefixed with its length, and waits for the host to acknowledge it.
+fn hint(hint: &str) -> io::Result<()> {
+    let mut writer = fd(HINT_WRITE_FD);
+    writer.write_all(&(hint.len() as u32).to_be_bytes())?;
+    writer.write_all(hint.as_bytes())?;
+    let mut ack = [0u8; 1];
+    fd(HINT_READ_FD).read_exact(&mut ack)
+}
+
+fn hex(data: &[u8]) -> String {
+    data.iter().map(|b| format!("{b:02x}")).collect()
+}
+
+fn be_u64(data: &[u8]) -> u64 {
+    u64::from_be_bytes(data[..8].try_into().expect("at least 8 bytes"))
+}
+
+fn main() -> io::Result<()> {
+    eprint!("started!");
+
+    let pre_hash = get(local_index_key(0))?;
+    let diff_hash = get(local_index_key(1))?;
+    let claim_data = get(local_index_key(2))?;
+
+    // Hints are used to indicate which things the program will access,
+    // so the server can be prepared to serve the corresponding pre-images.
+    hint(&format!("fetch-state {}", hex(&pre_hash)))?;
+    let pre = get(keccak256_key(&pre_hash))?;
+
+    // Multiple pre-images may be fetched based on a hint.
+    // E.g. when we need all values of a merkle-tree.
+    hint(&format!("fetch-diff {}", hex(&diff_hash)))?;
+    let diff = get(keccak256_key(&diff_hash))?;
+    let diff_part_a = get(keccak256_key(&diff[..32]))?;
+    let diff_part_b = get(keccak256_key(&diff[32..]))?;
+
+    // Example state-transition function: s' = s*a + b
+    let s = be_u64(&pre);
+    let a = be_u64(&diff_part_a);
+    let b = be_u64(&diff_part_b);
+    println!("computing {s} * {a} + {b}");
+    let s_out = s.wrapping_mul(a).wrapping_add(b);
+
+    let s_claim = be_u64(&claim_data);
+    if s_out != s_claim {
+        println!("claim {s_out} is bad! Correct result is {s_claim}");
+        process::exit(1);
+    }
+    println!("claim {s_out} is good!");
+    Ok(())
+}
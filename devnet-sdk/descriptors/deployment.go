@@ -21,10 +21,22 @@ type PortInfo struct {
 // EndpointMap is a map of service names to their endpoints.
 type EndpointMap map[string]*PortInfo
 
+// ServiceResources are the resources a service is deployed with.
+// Zero values are unset: no requirement, or no limit.
+type ServiceResources struct {
+	MinCPU     int `json:"min_cpu,omitempty"`     // millicores
+	MaxCPU     int `json:"max_cpu,omitempty"`     // millicores
+	MinMemory  int `json:"min_mem,omitempty"`     // MB
+	MaxMemory  int `json:"max_mem,omitempty"`     // MB
+	VolumeSize int `json:"volume_size,omitempty"` // MB
+}
+
 // Service represents a chain service (e.g. batcher, proposer, challenger)
 type Service struct {
 	Name      string      `json:"name"`
 	Endpoints EndpointMap `json:"endpoints"`
+	// Resources are the resources the service is deployed with, if known
+	Resources *ServiceResources `json:"resources,omitempty"`
 }
 
 // ServiceMap is a map of service names to services.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/kurtosis-devnet/pkg/build"
@@ -60,6 +61,12 @@ type dockerBuildJob struct {
 	done        chan struct{}
 }
 
+// imageOverrideEnvVar returns the environment variable with the image that replaces the local build of a project,
+// like DEVNET_IMAGE_OP_NODE for op-node.
+func imageOverrideEnvVar(projectName string) string {
+	return "DEVNET_IMAGE_" + strings.ToUpper(strings.ReplaceAll(projectName, "-", "_"))
+}
+
 func (f *Templater) localDockerImageOption(_ context.Context) tmpl.TemplateContextOptions {
 	// Initialize the build jobs map if it's nil
 	if f.buildJobs == nil {
@@ -72,6 +79,10 @@ func (f *Templater) localDockerImageOption(_ context.Context) tmpl.TemplateConte
 
 	// Function that gets called during template rendering
 	return tmpl.WithFunction("localDockerImage", func(projectName string) (string, error) {
+		// An image of another version, like those of the version matrix of op-e2e, replaces the local build
+		if image := os.Getenv(imageOverrideEnvVar(projectName)); image != "" {
+			return image, nil
+		}
 		tag := imageTag(projectName)
 
 		// First, check if we already have this build job
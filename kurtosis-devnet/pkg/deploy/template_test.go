@@ -57,6 +57,30 @@ artifacts: {{localContractArtifacts "l1"}}`
 	assert.Contains(t, buf.String(), "test-project:test-enclave")
 }
 
+func TestRenderTemplate_ImageOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "template.yaml")
+	require.NoError(t, os.WriteFile(templatePath, []byte(`
+node: {{ localDockerImage "op-node" }}
+supervisor: {{ localDockerImage "op-supervisor" }}`), 0644))
+	t.Setenv("DEVNET_IMAGE_OP_NODE", "op-node:v1.13.3")
+
+	templater := &Templater{
+		enclave:      "test-enclave",
+		dryRun:       true,
+		baseDir:      tmpDir,
+		templateFile: templatePath,
+		buildDir:     tmpDir,
+		urlBuilder: func(path ...string) string {
+			return "http://localhost:8080/" + strings.Join(path, "/")
+		},
+	}
+	buf, err := templater.Render(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "node: op-node:v1.13.3")
+	assert.Contains(t, buf.String(), "supervisor: op-supervisor:test-enclave")
+}
+
 func TestRenderTemplate_DryRun(t *testing.T) {
 	// Create a temporary directory for test files
 	tmpDir, err := os.MkdirTemp("", "template-test-dryrun")
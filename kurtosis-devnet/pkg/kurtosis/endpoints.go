@@ -237,18 +237,45 @@ func (f *ServiceFinder) triageByLabels(svc *inspect.Service, name string, endpoi
 		}
 		idx = i
 	}
+	// TODO: eventually we can retire the "name" part, but it doesn't hurt for now
+	accept := acceptNamesOrIDs(strings.Split(id, "-")...)
 	return &triagedService{
-		tag: tag,
-		idx: idx,
-		// TODO: eventually we can retire the "name" part, but it doesn't hurt for now
-		accept: acceptNamesOrIDs(strings.Split(id, "-")...),
+		tag:    tag,
+		idx:    idx,
+		accept: accept,
 		svc: &descriptors.Service{
 			Name:      name,
 			Endpoints: endpoints,
+			Resources: f.participantResources(accept, svc.Labels[nodeNameLabel], tag),
 		},
 	}
 }
 
+// participantResources returns the resources a service of a participant is deployed with, as specified
+// for the chain, or nil if they are not specified.
+func (f *ServiceFinder) participantResources(accept chainAcceptor, participant string, tag string) *descriptors.ServiceResources {
+	if participant == "" {
+		return nil
+	}
+	for _, chain := range f.l2Chains {
+		if !accept(chain) {
+			continue
+		}
+		p, ok := chain.Participants[participant]
+		if !ok {
+			return nil
+		}
+		switch tag {
+		case "el":
+			return p.EL
+		case "cl":
+			return p.CL
+		}
+		return nil
+	}
+	return nil
+}
+
 func (f *ServiceFinder) triage() {
 	rules := serviceParserRules{
 		"el":         f.triageNode("el-"),
@@ -75,7 +75,7 @@ func TestFindChainServices(t *testing.T) {
 
 	// Test L2 services for both chains
 	for _, chain := range chains {
-		t.Run(fmt.Sprintf("L2 %s services", chain), func(t *testing.T) {
+		t.Run(fmt.Sprintf("L2 %s services", chain.Name), func(t *testing.T) {
 			nodes, services := finder.FindL2Services(chain)
 
 			assert.Equal(t, 1, len(nodes), "Should have exactly 1 node")
@@ -297,6 +297,40 @@ func createTestDepSets(t *testing.T) map[string]descriptors.DepSet {
 	}
 }
 
+func TestFindChainServicesResources(t *testing.T) {
+	chain := &spec.ChainSpec{
+		Name:      "op-kurtosis",
+		NetworkID: "2151908",
+		Participants: map[string]*spec.ParticipantSpec{
+			"node0": {EL: &descriptors.ServiceResources{MaxMemory: 4096}},
+		},
+	}
+	services := inspect.ServiceMap{
+		"op-el-2151908-node0-op-geth": &inspect.Service{
+			Labels: map[string]string{
+				kindLabel:      "el",
+				networkIDLabel: "2151908",
+				nodeNameLabel:  "node0",
+				nodeIndexLabel: "0",
+			},
+		},
+		"op-cl-2151908-node0-op-node": &inspect.Service{
+			Labels: map[string]string{
+				kindLabel:      "cl",
+				networkIDLabel: "2151908",
+				nodeNameLabel:  "node0",
+				nodeIndexLabel: "0",
+			},
+		},
+	}
+
+	finder := NewServiceFinder(services, WithL2Chains([]*spec.ChainSpec{chain}))
+	nodes, _ := finder.FindL2Services(chain)
+	require.Len(t, nodes, 1)
+	require.Equal(t, &descriptors.ServiceResources{MaxMemory: 4096}, nodes[0].Services["el"].Resources)
+	require.Nil(t, nodes[0].Services["cl"].Resources, "services without resource settings have no resources")
+}
+
 // TestTriageFunctions tests the actual implementation of triage functions
 func TestTriageFunctions(t *testing.T) {
 	// Create a minimal finder with default values
@@ -5,6 +5,8 @@ import (
 	"io"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 )
 
 const (
@@ -16,6 +18,15 @@ const (
 type ChainSpec struct {
 	Name      string
 	NetworkID string
+	// Participants are the participants of the chain that are deployed with resource settings, by name
+	Participants map[string]*ParticipantSpec
+}
+
+// ParticipantSpec represents the resources of the services of a participant.
+// A service without resource settings has nil resources.
+type ParticipantSpec struct {
+	EL *descriptors.ServiceResources
+	CL *descriptors.ServiceResources
 }
 
 type FeatureList []string
@@ -41,9 +52,39 @@ type NetworkParams struct {
 	NetworkID string `yaml:"network_id"`
 }
 
+// ResourcesConfig represents the resource settings of a participant service in the YAML
+type ResourcesConfig struct {
+	MinCPU     int `yaml:"min_cpu"`
+	MaxCPU     int `yaml:"max_cpu"`
+	MinMemory  int `yaml:"min_mem"`
+	MaxMemory  int `yaml:"max_mem"`
+	VolumeSize int `yaml:"volume_size"`
+}
+
+// serviceResources returns the resources of the settings, or nil if none is set
+func (c ResourcesConfig) serviceResources() *descriptors.ServiceResources {
+	if c == (ResourcesConfig{}) {
+		return nil
+	}
+	return &descriptors.ServiceResources{
+		MinCPU:     c.MinCPU,
+		MaxCPU:     c.MaxCPU,
+		MinMemory:  c.MinMemory,
+		MaxMemory:  c.MaxMemory,
+		VolumeSize: c.VolumeSize,
+	}
+}
+
+// ParticipantConfig represents a participant of a chain in the YAML
+type ParticipantConfig struct {
+	EL ResourcesConfig `yaml:"el"`
+	CL ResourcesConfig `yaml:"cl"`
+}
+
 // ChainConfig represents a chain configuration in the YAML
 type ChainConfig struct {
-	NetworkParams NetworkParams `yaml:"network_params"`
+	Participants  map[string]ParticipantConfig `yaml:"participants"`
+	NetworkParams NetworkParams                `yaml:"network_params"`
 }
 
 // InteropConfig represents the interop section in the YAML
@@ -122,10 +163,21 @@ func (s *Spec) ExtractData(r io.Reader) (*EnclaveSpec, error) {
 
 	// Extract chain specifications
 	for name, chain := range yamlSpec.OptimismPackage.Chains {
-		result.Chains = append(result.Chains, &ChainSpec{
+		chainSpec := &ChainSpec{
 			Name:      name,
 			NetworkID: chain.NetworkParams.NetworkID,
-		})
+		}
+		for participant, cfg := range chain.Participants {
+			el, cl := cfg.EL.serviceResources(), cfg.CL.serviceResources()
+			if el == nil && cl == nil {
+				continue
+			}
+			if chainSpec.Participants == nil {
+				chainSpec.Participants = make(map[string]*ParticipantSpec)
+			}
+			chainSpec.Participants[participant] = &ParticipantSpec{EL: el, CL: cl}
+		}
+		result.Chains = append(result.Chains, chainSpec)
 	}
 
 	return result, nil
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
 )
 
 func TestParseSpec(t *testing.T) {
@@ -59,6 +61,42 @@ ethereum_package:
 	}
 }
 
+func TestParseSpecParticipantResources(t *testing.T) {
+	yamlContent := `
+optimism_package:
+  chains:
+    op-kurtosis:
+      participants:
+        node0:
+          el:
+            type: op-geth
+            min_cpu: 500
+            max_cpu: 2000
+            min_mem: 1024
+            max_mem: 4096
+            volume_size: 10000
+          cl:
+            type: op-node
+            max_mem: 512
+        node1:
+          el:
+            type: op-geth
+            max_cpu: 0
+      network_params:
+        network_id: "2151908"
+`
+
+	result, err := NewSpec().ExtractData(strings.NewReader(yamlContent))
+	require.NoError(t, err)
+	require.Len(t, result.Chains, 1)
+	require.Equal(t, map[string]*ParticipantSpec{
+		"node0": {
+			EL: &descriptors.ServiceResources{MinCPU: 500, MaxCPU: 2000, MinMemory: 1024, MaxMemory: 4096, VolumeSize: 10000},
+			CL: &descriptors.ServiceResources{MaxMemory: 512},
+		},
+	}, result.Chains[0].Participants, "participants without resource settings are omitted")
+}
+
 func TestParseSpecErrors(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/telemetry"
+)
+
+// Hook is a command that a gate runs before or after its tests, e.g. to fund accounts, deploy fixtures,
+// or capture a metrics snapshot. Exactly one of Command and Package is set.
+type Hook struct {
+	Name string `yaml:"name"`
+	// Command is a shell command.
+	Command string `yaml:"command,omitempty"`
+	// Package is a Go package that is run with "go run", from the test directory.
+	Package string   `yaml:"package,omitempty"`
+	Args    []string `yaml:"args,omitempty"`
+	// Timeout limits the duration of the hook, if not zero.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+// GateHooks are the hooks of a gate. Pre hooks run before the tests of the gate, and stop the run if one fails.
+// Post hooks run after the tests, also if the tests or another post hook failed, so that they can clean up.
+type GateHooks struct {
+	Pre  []Hook `yaml:"pre,omitempty"`
+	Post []Hook `yaml:"post,omitempty"`
+}
+
+// validatorsConfig is the part of the validators file that the runner reads: the hooks and metric assertions of the gates,
+// next to their tests.
+type validatorsConfig struct {
+	Gates []gateConfig `yaml:"gates"`
+}
+
+type gateConfig struct {
+	ID       string            `yaml:"id"`
+	Inherits []string          `yaml:"inherits"`
+	Hooks    GateHooks         `yaml:"hooks"`
+	Metrics  []MetricAssertion `yaml:"metrics"`
+}
+
+// visitGates reads the validators file, and calls visit with the gate and the gates it inherits, once each.
+// Inherited gates are visited before the gates that inherit them.
+func visitGates(validatorsPath string, gate string, visit func(g gateConfig) error) error {
+	data, err := os.ReadFile(validatorsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read validators file: %w", err)
+	}
+	var cfg validatorsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return fmt.Errorf("failed to parse validators file: %w", err)
+	}
+	visited := make(map[string]bool)
+	var walk func(id string, path []string) error
+	walk = func(id string, path []string) error {
+		if slices.Contains(path, id) {
+			return fmt.Errorf("gate %q inherits itself", id)
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		idx := slices.IndexFunc(cfg.Gates, func(g gateConfig) bool { return g.ID == id })
+		if idx < 0 {
+			return fmt.Errorf("unknown gate %q", id)
+		}
+		g := cfg.Gates[idx]
+		for _, parent := range g.Inherits {
+			if err := walk(parent, append(path, id)); err != nil {
+				return err
+			}
+		}
+		return visit(g)
+	}
+	return walk(gate, nil)
+}
+
+// loadGateHooks reads the hooks of the gate from the validators file.
+// The hooks of inherited gates are included once: their pre hooks run first, and their post hooks last.
+func loadGateHooks(validatorsPath string, gate string) (GateHooks, error) {
+	var out GateHooks
+	err := visitGates(validatorsPath, gate, func(g gateConfig) error {
+		for _, h := range append(slices.Clone(g.Hooks.Pre), g.Hooks.Post...) {
+			if (h.Command == "") == (h.Package == "") {
+				return fmt.Errorf("hook %q of gate %q must have either a command or a package", h.Name, g.ID)
+			}
+		}
+		out.Pre = append(out.Pre, g.Hooks.Pre...)
+		out.Post = append(slices.Clone(g.Hooks.Post), out.Post...)
+		return nil
+	})
+	if err != nil {
+		return GateHooks{}, err
+	}
+	return out, nil
+}
+
+// hookSteps returns the steps that run the hooks.
+func hookSteps(tracer trace.Tracer, phase string, hooks []Hook, devnet string, gate string, testDir string) []step {
+	steps := make([]step, 0, len(hooks))
+	for _, h := range hooks {
+		steps = append(steps, step{
+			name:   fmt.Sprintf("%s-hook %s", phase, h.Name),
+			always: phase == "post",
+			run: func(ctx context.Context) error {
+				return runHook(ctx, tracer, phase, h, devnet, gate, testDir)
+			},
+		})
+	}
+	return steps
+}
+
+// runHook runs the hook with the same telemetry context and devnet environment as the acceptance tests.
+func runHook(ctx context.Context, tracer trace.Tracer, phase string, h Hook, devnet string, gate string, testDir string) error {
+	ctx, span := tracer.Start(ctx, fmt.Sprintf("%s hook %s", phase, h.Name),
+		trace.WithAttributes(attribute.String("gate", gate), attribute.String("phase", phase)))
+	defer span.End()
+
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+
+	var cmd *exec.Cmd
+	if h.Command != "" {
+		cmd = exec.CommandContext(ctx, "sh", append([]string{"-c", h.Command, h.Name}, h.Args...)...)
+	} else {
+		cmd = exec.CommandContext(ctx, "go", append([]string{"run", h.Package}, h.Args...)...)
+	}
+	cmd.Dir = testDir
+	cmd.Env = append(telemetry.InstrumentEnvironment(ctx, os.Environ()),
+		"DEVNET_ENV_URL="+devnetURL(devnet),
+		"DEVSTACK_ORCHESTRATOR=sysext",
+		"ACCEPTANCE_GATE="+gate,
+		"ACCEPTANCE_HOOK_PHASE="+phase,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s hook %q failed: %w", phase, h.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const testValidators = `
+gates:
+  - id: base
+    hooks:
+      pre:
+        - name: fund
+          package: ./tools/fund
+          args: ["--amount", "10"]
+      post:
+        - name: snapshot
+          command: echo snapshot
+    tests:
+      - package: github.com/ethereum-optimism/optimism/op-acceptance-tests/tests/base
+  - id: isthmus
+    inherits:
+      - base
+    tests:
+      - package: github.com/ethereum-optimism/optimism/op-acceptance-tests/tests/isthmus
+  - id: interop
+    inherits:
+      - base
+      - isthmus
+    hooks:
+      pre:
+        - name: deploy-fixtures
+          command: ./deploy.sh
+          timeout: 5m
+      post:
+        - name: cleanup
+          command: ./cleanup.sh
+  - id: broken
+    hooks:
+      pre:
+        - name: nothing
+`
+
+func writeValidators(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "acceptance-tests.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLoadGateHooks(t *testing.T) {
+	path := writeValidators(t, testValidators)
+
+	hooks, err := loadGateHooks(path, "isthmus")
+	require.NoError(t, err)
+	require.Equal(t, GateHooks{
+		Pre:  []Hook{{Name: "fund", Package: "./tools/fund", Args: []string{"--amount", "10"}}},
+		Post: []Hook{{Name: "snapshot", Command: "echo snapshot"}},
+	}, hooks)
+
+	// The hooks of base are inherited twice, but run once
+	hooks, err = loadGateHooks(path, "interop")
+	require.NoError(t, err)
+	require.Equal(t, GateHooks{
+		Pre: []Hook{
+			{Name: "fund", Package: "./tools/fund", Args: []string{"--amount", "10"}},
+			{Name: "deploy-fixtures", Command: "./deploy.sh", Timeout: 5 * time.Minute},
+		},
+		Post: []Hook{
+			{Name: "cleanup", Command: "./cleanup.sh"},
+			{Name: "snapshot", Command: "echo snapshot"},
+		},
+	}, hooks)
+
+	_, err = loadGateHooks(path, "broken")
+	require.ErrorContains(t, err, "must have either a command or a package")
+	_, err = loadGateHooks(path, "unknown")
+	require.ErrorContains(t, err, "unknown gate")
+}
+
+func TestLoadGateHooksCycle(t *testing.T) {
+	path := writeValidators(t, `
+gates:
+  - id: a
+    inherits: [b]
+  - id: b
+    inherits: [a]
+`)
+	_, err := loadGateHooks(path, "a")
+	require.ErrorContains(t, err, "inherits itself")
+}
+
+func TestRunHooks(t *testing.T) {
+	dir := t.TempDir()
+	hooks := []Hook{
+		{Name: "env", Command: `echo "$ACCEPTANCE_GATE $ACCEPTANCE_HOOK_PHASE $DEVNET_ENV_URL $1" > out.txt`, Args: []string{"arg"}},
+		{Name: "failing", Command: "exit 3"},
+		{Name: "after", Command: "touch after.txt"},
+	}
+	steps := hookSteps(noop.NewTracerProvider().Tracer("test"), "post", hooks, "simple", "base", dir)
+	result := &GateResult{}
+	err := runSteps(context.Background(), steps, result)
+	require.ErrorContains(t, err, `post hook "failing" failed`)
+
+	out, err := os.ReadFile(filepath.Join(dir, "out.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "base post kt://simple arg\n", string(out))
+	require.FileExists(t, filepath.Join(dir, "after.txt"), "post hooks run after a failure")
+	require.Len(t, result.Steps, 3)
+	require.Equal(t, "post-hook env", result.Steps[0].Name)
+}
+
+func TestRunStepsAlways(t *testing.T) {
+	var ran []string
+	steps := []step{
+		{name: "failing", run: func(ctx context.Context) error {
+			ran = append(ran, "failing")
+			return io.ErrUnexpectedEOF
+		}},
+		{name: "after", run: func(ctx context.Context) error {
+			ran = append(ran, "after")
+			return nil
+		}},
+		{name: "cleanup", always: true, run: func(ctx context.Context) error {
+			ran = append(ran, "cleanup")
+			return io.ErrClosedPipe
+		}},
+	}
+	result := &GateResult{}
+	err := runSteps(context.Background(), steps, result)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF, "the first failure is returned")
+	require.Equal(t, []string{"failing", "cleanup"}, ran)
+	require.Len(t, result.Steps, 2)
+	require.Equal(t, io.ErrClosedPipe.Error(), result.Steps[1].Error)
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	shellenv "github.com/ethereum-optimism/optimism/devnet-sdk/shell/env"
 	"github.com/ethereum-optimism/optimism/devnet-sdk/telemetry"
 	"github.com/honeycombio/otel-config-go/otelconfig"
 	"github.com/urfave/cli/v2"
@@ -19,6 +22,12 @@ const (
 	defaultDevnet   = "simple"
 	defaultGate     = "holocene"
 	defaultAcceptor = "op-acceptor"
+
+	// The toolcache defaults match the versions of the repo-wide mise config.
+	defaultToolcacheAcceptorVersion = "v0.4.1"
+	defaultToolcacheAcceptorURL     = "https://github.com/ethereum-optimism/infra/releases/download/op-acceptor/{version}/op-acceptor-{version}-{os}-{arch}.tar.gz"
+	defaultToolcacheKurtosisVersion = "1.8.1"
+	defaultToolcacheKurtosisURL     = "https://github.com/kurtosis-tech/kurtosis-cli-release-artifacts/releases/download/{version}/kurtosis-cli_{version}_{os}_{arch}.tar.gz"
 )
 
 var (
@@ -36,16 +45,14 @@ var (
 		EnvVars: []string{"GATE"},
 	}
 	testDirFlag = &cli.StringFlag{
-		Name:     "testdir",
-		Usage:    "Path to the test directory",
-		Required: true,
-		EnvVars:  []string{"TEST_DIR"},
+		Name:    "testdir",
+		Usage:   "Path to the test directory. Required, unless replayed with --from-reproduce",
+		EnvVars: []string{"TEST_DIR"},
 	}
 	validatorsFlag = &cli.StringFlag{
-		Name:     "validators",
-		Usage:    "Path to the validators YAML file",
-		Required: true,
-		EnvVars:  []string{"VALIDATORS"},
+		Name:    "validators",
+		Usage:   "Path to the validators YAML file. Required, unless replayed with --from-reproduce",
+		EnvVars: []string{"VALIDATORS"},
 	}
 	logLevelFlag = &cli.StringFlag{
 		Name:    "log.level",
@@ -54,10 +61,9 @@ var (
 		EnvVars: []string{"LOG_LEVEL"},
 	}
 	kurtosisDirFlag = &cli.StringFlag{
-		Name:     "kurtosis-dir",
-		Usage:    "Path to the kurtosis-devnet directory",
-		Required: true,
-		EnvVars:  []string{"KURTOSIS_DIR"},
+		Name:    "kurtosis-dir",
+		Usage:   "Path to the kurtosis-devnet directory. Required, unless replayed with --from-reproduce",
+		EnvVars: []string{"KURTOSIS_DIR"},
 	}
 	acceptorFlag = &cli.StringFlag{
 		Name:    "acceptor",
@@ -71,8 +77,105 @@ var (
 		Value:   false,
 		EnvVars: []string{"REUSE_DEVNET"},
 	}
+	resultsEndpointFlag = &cli.StringFlag{
+		Name:    "results.endpoint",
+		Usage:   "HTTP endpoint of the results service to publish the gate results to. Publishing is disabled if empty",
+		EnvVars: []string{"RESULTS_ENDPOINT"},
+	}
+	resultsSecretFlag = &cli.StringFlag{
+		Name:    "results.hmac-secret",
+		Usage:   "Shared secret to sign the published results with, using HMAC-SHA256",
+		EnvVars: []string{"RESULTS_HMAC_SECRET"},
+	}
+	resultsAttemptsFlag = &cli.IntFlag{
+		Name:    "results.max-attempts",
+		Usage:   "Maximum number of attempts to publish the gate results",
+		Value:   5,
+		EnvVars: []string{"RESULTS_MAX_ATTEMPTS"},
+	}
+	resultsArtifactsFlag = &cli.StringSliceFlag{
+		Name:    "results.artifacts",
+		Usage:   "Links to the artifacts of the run, e.g. logs in CI, to include in the published results",
+		EnvVars: []string{"RESULTS_ARTIFACTS"},
+	}
+	resultsBranchFlag = &cli.StringFlag{
+		Name:    "results.branch",
+		Usage:   "Branch that is tested, to include in the published results",
+		EnvVars: []string{"RESULTS_BRANCH", "CIRCLE_BRANCH"},
+	}
+	resultsCommitFlag = &cli.StringFlag{
+		Name:    "results.commit",
+		Usage:   "Commit that is tested, to include in the published results",
+		EnvVars: []string{"RESULTS_COMMIT", "CIRCLE_SHA1"},
+	}
+	reproduceFileFlag = &cli.StringFlag{
+		Name:    "reproduce.file",
+		Usage:   "Path to capture the effective configuration of the run to, to replay it with --from-reproduce. Capturing is disabled if empty",
+		Value:   "reproduce.json",
+		EnvVars: []string{"REPRODUCE_FILE"},
+	}
+	fromReproduceFlag = &cli.StringFlag{
+		Name:    "from-reproduce",
+		Usage:   "Path to a reproduce file to replay the run from. Flags and env vars that are set explicitly take precedence",
+		EnvVars: []string{"FROM_REPRODUCE"},
+	}
+	metricAssertionsFlag = &cli.BoolFlag{
+		Name:    "metrics.assertions",
+		Usage:   "Scrape the metrics of the devnet components after the tests, and fail the gate if they break the metric assertions of the gate",
+		Value:   false,
+		EnvVars: []string{"METRICS_ASSERTIONS"},
+	}
+	toolcacheDirFlag = &cli.StringFlag{
+		Name:    "toolcache.dir",
+		Usage:   "Directory to download the pinned op-acceptor and kurtosis into and run them from. --acceptor and the tools on PATH are used if empty",
+		EnvVars: []string{"TOOLCACHE_DIR"},
+	}
+	toolcacheAcceptorVersionFlag = &cli.StringFlag{
+		Name:    "toolcache.acceptor-version",
+		Usage:   "Version of op-acceptor to download into the toolcache",
+		Value:   defaultToolcacheAcceptorVersion,
+		EnvVars: []string{"TOOLCACHE_ACCEPTOR_VERSION"},
+	}
+	toolcacheAcceptorURLFlag = &cli.StringFlag{
+		Name:    "toolcache.acceptor-url",
+		Usage:   "Download URL of op-acceptor, with {version}, {os} and {arch} placeholders",
+		Value:   defaultToolcacheAcceptorURL,
+		EnvVars: []string{"TOOLCACHE_ACCEPTOR_URL"},
+	}
+	toolcacheAcceptorChecksumFlag = &cli.StringFlag{
+		Name:    "toolcache.acceptor-sha256",
+		Usage:   "SHA256 checksum of the op-acceptor download for the current platform. Required with --toolcache.dir",
+		EnvVars: []string{"TOOLCACHE_ACCEPTOR_SHA256"},
+	}
+	toolcacheKurtosisVersionFlag = &cli.StringFlag{
+		Name:    "toolcache.kurtosis-version",
+		Usage:   "Version of the kurtosis CLI to download into the toolcache",
+		Value:   defaultToolcacheKurtosisVersion,
+		EnvVars: []string{"TOOLCACHE_KURTOSIS_VERSION"},
+	}
+	toolcacheKurtosisURLFlag = &cli.StringFlag{
+		Name:    "toolcache.kurtosis-url",
+		Usage:   "Download URL of the kurtosis CLI, with {version}, {os} and {arch} placeholders",
+		Value:   defaultToolcacheKurtosisURL,
+		EnvVars: []string{"TOOLCACHE_KURTOSIS_URL"},
+	}
+	toolcacheKurtosisChecksumFlag = &cli.StringFlag{
+		Name:    "toolcache.kurtosis-sha256",
+		Usage:   "SHA256 checksum of the kurtosis CLI download for the current platform. Required with --toolcache.dir",
+		EnvVars: []string{"TOOLCACHE_KURTOSIS_SHA256"},
+	}
 )
 
+// step is a named step of the acceptance test run.
+type step struct {
+	name string
+	// skip is true if the step does not have to run
+	skip bool
+	// always is true if the step runs also after an earlier step failed, e.g. to clean up
+	always bool
+	run    func(ctx context.Context) error
+}
+
 func main() {
 	app := &cli.App{
 		Name:  "op-acceptance-test",
@@ -86,6 +189,22 @@ func main() {
 			kurtosisDirFlag,
 			acceptorFlag,
 			reuseDevnetFlag,
+			resultsEndpointFlag,
+			resultsSecretFlag,
+			resultsAttemptsFlag,
+			resultsArtifactsFlag,
+			resultsBranchFlag,
+			resultsCommitFlag,
+			reproduceFileFlag,
+			fromReproduceFlag,
+			metricAssertionsFlag,
+			toolcacheDirFlag,
+			toolcacheAcceptorVersionFlag,
+			toolcacheAcceptorURLFlag,
+			toolcacheAcceptorChecksumFlag,
+			toolcacheKurtosisVersionFlag,
+			toolcacheKurtosisURLFlag,
+			toolcacheKurtosisChecksumFlag,
 		},
 		Action: runAcceptanceTest,
 	}
@@ -97,6 +216,26 @@ func main() {
 }
 
 func runAcceptanceTest(c *cli.Context) error {
+	repoRoot := findRepoRoot(c.Context)
+	if path := c.String(fromReproduceFlag.Name); path != "" {
+		r, err := readReproduce(path)
+		if err != nil {
+			return err
+		}
+		warnings, err := applyReproduce(c.Context, c, repoRoot, r)
+		if err != nil {
+			return fmt.Errorf("failed to replay %s: %w", path, err)
+		}
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+	}
+	for _, f := range []*cli.StringFlag{testDirFlag, validatorsFlag, kurtosisDirFlag} {
+		if c.String(f.Name) == "" {
+			return fmt.Errorf("required flag %q not set", f.Name)
+		}
+	}
+
 	// Get command line arguments
 	devnet := c.String(devnetFlag.Name)
 	gate := c.String(gateFlag.Name)
@@ -106,6 +245,13 @@ func runAcceptanceTest(c *cli.Context) error {
 	kurtosisDir := c.String(kurtosisDirFlag.Name)
 	acceptor := c.String(acceptorFlag.Name)
 	reuseDevnet := c.Bool(reuseDevnetFlag.Name)
+	if dir := c.String(toolcacheDirFlag.Name); dir != "" {
+		var err error
+		acceptor, err = bootstrapTools(c.Context, c, dir)
+		if err != nil {
+			return fmt.Errorf("failed to bootstrap tools: %w", err)
+		}
+	}
 	// Get the absolute path of the test directory
 	absTestDir, err := filepath.Abs(testDir)
 	if err != nil {
@@ -138,25 +284,154 @@ func runAcceptanceTest(c *cli.Context) error {
 	ctx, span := tracer.Start(ctx, "op-acceptance-tests")
 	defer span.End()
 
-	steps := []func(ctx context.Context) error{
-		func(ctx context.Context) error {
-			if reuseDevnet {
-				return nil
-			}
-			return deployDevnet(ctx, tracer, devnet, absKurtosisDir)
+	hooks, err := loadGateHooks(absValidators, gate)
+	if err != nil {
+		return fmt.Errorf("failed to load hooks of gate %s: %w", gate, err)
+	}
+	metricAssertions, err := loadGateMetricAssertions(absValidators, gate)
+	if err != nil {
+		return fmt.Errorf("failed to load metric assertions of gate %s: %w", gate, err)
+	}
+
+	result := &GateResult{
+		Gate:      gate,
+		Devnet:    devnet,
+		Branch:    c.String(resultsBranchFlag.Name),
+		Commit:    c.String(resultsCommitFlag.Name),
+		StartedAt: time.Now(),
+		Artifacts: c.StringSlice(resultsArtifactsFlag.Name),
+	}
+
+	steps := []step{
+		{
+			name: "deploy-devnet",
+			skip: reuseDevnet,
+			run: func(ctx context.Context) error {
+				return deployDevnet(ctx, tracer, devnet, absKurtosisDir)
+			},
 		},
-		func(ctx context.Context) error {
+	}
+	steps = append(steps, hookSteps(tracer, "pre", hooks.Pre, devnet, gate, absTestDir)...)
+	steps = append(steps, step{
+		name: "run-acceptor",
+		run: func(ctx context.Context) error {
 			return runOpAcceptor(ctx, tracer, devnet, gate, absTestDir, absValidators, logLevel, acceptor)
 		},
+	})
+	if len(metricAssertions) > 0 {
+		// The metrics are checked before the post hooks, that may tear down the devnet components.
+		steps = append(steps, metricAssertionsStep(tracer, metricAssertions, c.Bool(metricAssertionsFlag.Name), devnet, gate, result))
+	}
+	steps = append(steps, hookSteps(tracer, "post", hooks.Post, devnet, gate, absTestDir)...)
+
+	runErr := runSteps(ctx, steps, result)
+	result.DurationMs = time.Since(result.StartedAt).Milliseconds()
+	result.Passed = runErr == nil
+	if runErr != nil {
+		result.Error = runErr.Error()
 	}
 
-	for _, step := range steps {
-		if err := step(ctx); err != nil {
-			return fmt.Errorf("failed to run step: %w", err)
+	endpoint := c.String(resultsEndpointFlag.Name)
+	reproduceFile := c.String(reproduceFileFlag.Name)
+	if endpoint != "" || reproduceFile != "" {
+		// The devnet descriptor is only available if the devnet was deployed
+		if devnetEnv, err := loadDevnetDescriptor(devnet); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load devnet descriptor: %v\n", err)
+		} else {
+			result.DevnetDescriptor = devnetEnv
 		}
 	}
 
-	return nil
+	if reproduceFile != "" {
+		r := captureReproduce(ctx, c, repoRoot, acceptor)
+		r.DevnetDescriptor = result.DevnetDescriptor
+		// Capturing is best-effort, like publishing.
+		if err := writeReproduce(reproduceFile, r); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	if endpoint != "" {
+		publisher := newResultsPublisher(endpoint, c.String(resultsSecretFlag.Name), c.Int(resultsAttemptsFlag.Name))
+		// Publishing is best-effort: the outcome of the run is determined by the gate result only.
+		if err := publisher.Publish(ctx, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to publish gate results: %v\n", err)
+		}
+	}
+
+	return runErr
+}
+
+// runSteps runs the steps in order, until one fails, and records the result of each step.
+// After a failure, only the steps that always run are run. The first failure is returned.
+func runSteps(ctx context.Context, steps []step, result *GateResult) error {
+	var firstErr error
+	for _, s := range steps {
+		if firstErr != nil && !s.always {
+			continue
+		}
+		if s.skip {
+			result.Steps = append(result.Steps, StepResult{Name: s.name, Passed: true, Skipped: true})
+			continue
+		}
+		start := time.Now()
+		err := s.run(ctx)
+		stepResult := StepResult{
+			Name:       s.name,
+			Passed:     err == nil,
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		if err != nil {
+			stepResult.Error = err.Error()
+		}
+		result.Steps = append(result.Steps, stepResult)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to run step %s: %w", s.name, err)
+		}
+	}
+	return firstErr
+}
+
+// bootstrapTools downloads the pinned op-acceptor and kurtosis CLI into the toolcache, if they are not cached yet.
+// It puts kurtosis in front of PATH for the devnet deployment, and returns the path of op-acceptor.
+func bootstrapTools(ctx context.Context, c *cli.Context, dir string) (string, error) {
+	cache := newToolCache(dir)
+	acceptor, err := cache.Ensure(ctx, Tool{
+		Name:    "op-acceptor",
+		Version: c.String(toolcacheAcceptorVersionFlag.Name),
+		URL:     c.String(toolcacheAcceptorURLFlag.Name),
+		SHA256:  c.String(toolcacheAcceptorChecksumFlag.Name),
+	})
+	if err != nil {
+		return "", err
+	}
+	kurtosis, err := cache.Ensure(ctx, Tool{
+		Name:    "kurtosis",
+		Version: c.String(toolcacheKurtosisVersionFlag.Name),
+		URL:     c.String(toolcacheKurtosisURLFlag.Name),
+		SHA256:  c.String(toolcacheKurtosisChecksumFlag.Name),
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := prependPath(filepath.Dir(kurtosis)); err != nil {
+		return "", fmt.Errorf("failed to add kurtosis to PATH: %w", err)
+	}
+	fmt.Printf("Using cached op-acceptor %s and kurtosis %s\n", acceptor, kurtosis)
+	return acceptor, nil
+}
+
+func devnetURL(devnet string) string {
+	return fmt.Sprintf("kt://%s", devnet)
+}
+
+// loadDevnetDescriptor loads the descriptor of the deployed devnet.
+func loadDevnetDescriptor(devnet string) (*descriptors.DevnetEnvironment, error) {
+	devnetEnv, err := shellenv.LoadDevnetFromURL(devnetURL(devnet))
+	if err != nil {
+		return nil, err
+	}
+	return devnetEnv.Env, nil
 }
 
 func deployDevnet(ctx context.Context, tracer trace.Tracer, devnet string, kurtosisDir string) error {
@@ -187,7 +462,7 @@ func runOpAcceptor(ctx context.Context, tracer trace.Tracer, devnet string, gate
 		"--log.level", logLevel,
 	)
 	acceptorCmd.Env = append(env,
-		fmt.Sprintf("DEVNET_ENV_URL=kt://%s", devnet),
+		"DEVNET_ENV_URL="+devnetURL(devnet),
 		"DEVSTACK_ORCHESTRATOR=sysext", // make devstack-based tests use the provisioned devnet
 	)
 	acceptorCmd.Stdout = os.Stdout
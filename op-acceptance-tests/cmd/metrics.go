@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+)
+
+// metricsScrapeTimeout limits the duration of scraping the metrics of a single component.
+const metricsScrapeTimeout = 10 * time.Second
+
+// MetricAssertion is a rule on a metric of the devnet components, that a gate checks after its tests,
+// to catch degradations that the tests do not detect, e.g. reorgs, database corruption or RPC errors.
+type MetricAssertion struct {
+	Name string `yaml:"name"`
+	// Component is the service of the devnet components to scrape, as named in the devnet descriptor,
+	// e.g. "cl", "el" or "supervisor". Every instance of the service, on every chain, has to pass.
+	Component string `yaml:"component"`
+	// Metric is the name of the metric. Its value is the sum of the samples of the metric that have all the Labels.
+	// Metrics that a component does not export have the value 0, like counters that were never incremented.
+	Metric string            `yaml:"metric"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+	// Per is the name of a metric to divide the value by, e.g. the total of requests for an error rate, if not empty.
+	// Its value is the sum of the samples of the metric that have all the PerLabels. A value divided by 0 is 0.
+	Per       string            `yaml:"per,omitempty"`
+	PerLabels map[string]string `yaml:"per_labels,omitempty"`
+	// Max is the highest value that passes.
+	Max float64 `yaml:"max"`
+}
+
+// MetricAssertionResult is the value of a metric assertion for one component, as published with the gate results.
+type MetricAssertionResult struct {
+	Name      string  `json:"name"`
+	Component string  `json:"component"`
+	Value     float64 `json:"value"`
+	Max       float64 `json:"max"`
+	Passed    bool    `json:"passed"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// metricsTarget is a devnet component to scrape.
+type metricsTarget struct {
+	// name identifies the component, e.g. "op-kurtosis/cl/op-cl-1-op-node-op-geth-op-kurtosis".
+	name string
+	url  string
+}
+
+// loadGateMetricAssertions reads the metric assertions of the gate, including those of inherited gates,
+// from the validators file.
+func loadGateMetricAssertions(validatorsPath string, gate string) ([]MetricAssertion, error) {
+	var out []MetricAssertion
+	err := visitGates(validatorsPath, gate, func(g gateConfig) error {
+		for _, m := range g.Metrics {
+			if m.Component == "" || m.Metric == "" {
+				return fmt.Errorf("metric assertion %q of gate %q must have a component and a metric", m.Name, g.ID)
+			}
+		}
+		out = append(out, g.Metrics...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// metricsTargets returns the instances of the component in the devnet that have a metrics endpoint.
+func metricsTargets(env *descriptors.DevnetEnvironment, component string) []metricsTarget {
+	chains := make([]*descriptors.Chain, 0, len(env.L2)+1)
+	if env.L1 != nil {
+		chains = append(chains, env.L1)
+	}
+	for _, l2 := range env.L2 {
+		if l2 != nil && l2.Chain != nil {
+			chains = append(chains, l2.Chain)
+		}
+	}
+	var services []*descriptors.Service
+	var chainNames []string
+	for _, chain := range chains {
+		for _, svc := range chain.Services[component] {
+			services = append(services, svc)
+			chainNames = append(chainNames, chain.Name)
+		}
+		for _, node := range chain.Nodes {
+			if svc, ok := node.Services[component]; ok {
+				services = append(services, svc)
+				chainNames = append(chainNames, chain.Name)
+			}
+		}
+	}
+	var targets []metricsTarget
+	seen := make(map[string]bool)
+	for i, svc := range services {
+		if svc == nil {
+			continue
+		}
+		endpoint, ok := svc.Endpoints["metrics"]
+		if !ok || endpoint == nil {
+			continue
+		}
+		scheme := endpoint.Scheme
+		if scheme == "" {
+			scheme = "http"
+		}
+		url := fmt.Sprintf("%s://%s:%d/metrics", scheme, endpoint.Host, endpoint.Port)
+		// Superchain services, like the supervisor, are listed by every chain that they serve
+		if seen[url] {
+			continue
+		}
+		seen[url] = true
+		targets = append(targets, metricsTarget{
+			name: fmt.Sprintf("%s/%s/%s", chainNames[i], component, svc.Name),
+			url:  url,
+		})
+	}
+	return targets
+}
+
+// checkMetricAssertions scrapes the components of the assertions, and evaluates the assertions against each of them.
+// It returns the results of all assertions, and an error if one of them failed.
+func checkMetricAssertions(ctx context.Context, env *descriptors.DevnetEnvironment, assertions []MetricAssertion) ([]MetricAssertionResult, error) {
+	scraped := make(map[string]map[string]*dto.MetricFamily)
+	scrapeErrs := make(map[string]error)
+	var results []MetricAssertionResult
+	var errs []error
+	for _, a := range assertions {
+		targets := metricsTargets(env, a.Component)
+		if len(targets) == 0 {
+			err := fmt.Errorf("no %s component with a metrics endpoint", a.Component)
+			results = append(results, MetricAssertionResult{Name: a.Name, Component: a.Component, Max: a.Max, Error: err.Error()})
+			errs = append(errs, fmt.Errorf("metric assertion %q: %w", a.Name, err))
+			continue
+		}
+		for _, target := range targets {
+			if _, ok := scraped[target.url]; !ok && scrapeErrs[target.url] == nil {
+				families, err := scrapeMetrics(ctx, target.url)
+				if err != nil {
+					scrapeErrs[target.url] = err
+				} else {
+					scraped[target.url] = families
+				}
+			}
+			result := MetricAssertionResult{Name: a.Name, Component: target.name, Max: a.Max}
+			if err := scrapeErrs[target.url]; err != nil {
+				result.Error = err.Error()
+				errs = append(errs, fmt.Errorf("metric assertion %q of %s: %w", a.Name, target.name, err))
+			} else {
+				result.Value = a.evaluate(scraped[target.url])
+				result.Passed = result.Value <= a.Max
+				if !result.Passed {
+					errs = append(errs, fmt.Errorf("metric assertion %q of %s: %s is %v, above the maximum of %v", a.Name, target.name, a.describe(), result.Value, a.Max))
+				}
+			}
+			results = append(results, result)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// evaluate returns the value of the assertion in the scraped metric families.
+func (a MetricAssertion) evaluate(families map[string]*dto.MetricFamily) float64 {
+	value := sumMetric(families[a.Metric], a.Labels)
+	if a.Per == "" {
+		return value
+	}
+	per := sumMetric(families[a.Per], a.PerLabels)
+	if per == 0 {
+		return 0
+	}
+	return value / per
+}
+
+func (a MetricAssertion) describe() string {
+	if a.Per == "" {
+		return a.Metric
+	}
+	return a.Metric + " per " + a.Per
+}
+
+// sumMetric returns the sum of the samples of the metric family that have all the labels.
+// Histograms and summaries are summed by their number of observations.
+func sumMetric(family *dto.MetricFamily, labels map[string]string) float64 {
+	if family == nil {
+		return 0
+	}
+	var sum float64
+	for _, m := range family.GetMetric() {
+		if !hasLabels(m, labels) {
+			continue
+		}
+		switch {
+		case m.Counter != nil:
+			sum += m.Counter.GetValue()
+		case m.Gauge != nil:
+			sum += m.Gauge.GetValue()
+		case m.Untyped != nil:
+			sum += m.Untyped.GetValue()
+		case m.Histogram != nil:
+			sum += float64(m.Histogram.GetSampleCount())
+		case m.Summary != nil:
+			sum += float64(m.Summary.GetSampleCount())
+		}
+	}
+	return sum
+}
+
+func hasLabels(m *dto.Metric, labels map[string]string) bool {
+	for name, value := range labels {
+		found := false
+		for _, l := range m.GetLabel() {
+			if l.GetName() == name {
+				found = l.GetValue() == value
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// scrapeMetrics fetches and parses the metrics in the Prometheus text format at the URL.
+func scrapeMetrics(ctx context.Context, url string) (map[string]*dto.MetricFamily, error) {
+	ctx, cancel := context.WithTimeout(ctx, metricsScrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape metrics: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape metrics: unexpected status %s", resp.Status)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+	return families, nil
+}
+
+// metricAssertionsStep returns the step that checks the metric assertions against the devnet, and records their
+// results in the gate result. The step runs also after the tests failed, to report the degradations they may explain.
+func metricAssertionsStep(tracer trace.Tracer, assertions []MetricAssertion, enabled bool, devnet string, gate string, result *GateResult) step {
+	return step{
+		name:   "metric-assertions",
+		skip:   !enabled,
+		always: true,
+		run: func(ctx context.Context) error {
+			ctx, span := tracer.Start(ctx, "check metric assertions", trace.WithAttributes(attribute.String("gate", gate)))
+			defer span.End()
+
+			devnetEnv, err := loadDevnetDescriptor(devnet)
+			if err != nil {
+				return fmt.Errorf("failed to load devnet descriptor: %w", err)
+			}
+			results, err := checkMetricAssertions(ctx, devnetEnv, assertions)
+			result.MetricAssertions = results
+			for _, r := range results {
+				status := "passed"
+				if !r.Passed {
+					status = "FAILED"
+				}
+				fmt.Fprintf(os.Stderr, "Metric assertion %s of %s %s: %v (max %v)%s\n", r.Name, r.Component, status, r.Value, r.Max, errorSuffix(r.Error))
+			}
+			return err
+		},
+	}
+}
+
+func errorSuffix(err string) string {
+	if err == "" {
+		return ""
+	}
+	return ": " + strings.TrimSpace(err)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+)
+
+func TestLoadGateMetricAssertions(t *testing.T) {
+	path := writeValidators(t, `
+gates:
+  - id: base
+    metrics:
+      - name: no-reorgs
+        component: cl
+        metric: op_node_default_l1_reorg_depth_count
+        max: 0
+  - id: interop
+    inherits: [base]
+    metrics:
+      - name: rpc-error-rate
+        component: supervisor
+        metric: op_supervisor_default_rpc_server_requests_total
+        labels:
+          error: "true"
+        per: op_supervisor_default_rpc_server_requests_total
+        max: 0.01
+  - id: broken
+    metrics:
+      - name: no-metric
+        component: cl
+`)
+	assertions, err := loadGateMetricAssertions(path, "interop")
+	require.NoError(t, err)
+	require.Equal(t, []MetricAssertion{
+		{Name: "no-reorgs", Component: "cl", Metric: "op_node_default_l1_reorg_depth_count"},
+		{
+			Name:      "rpc-error-rate",
+			Component: "supervisor",
+			Metric:    "op_supervisor_default_rpc_server_requests_total",
+			Labels:    map[string]string{"error": "true"},
+			Per:       "op_supervisor_default_rpc_server_requests_total",
+			Max:       0.01,
+		},
+	}, assertions)
+
+	_, err = loadGateMetricAssertions(path, "broken")
+	require.ErrorContains(t, err, "must have a component and a metric")
+}
+
+const testMetrics = `# TYPE op_node_default_l1_reorg_depth_count counter
+op_node_default_l1_reorg_depth_count 0
+# TYPE rpc_requests_total counter
+rpc_requests_total{method="a",error="false"} 96
+rpc_requests_total{method="a",error="true"} 3
+rpc_requests_total{method="b",error="true"} 1
+# TYPE db_corrupted gauge
+db_corrupted{chain="10"} 0
+db_corrupted{chain="8453"} 1
+# TYPE rpc_latency_seconds histogram
+rpc_latency_seconds_bucket{le="1"} 4
+rpc_latency_seconds_bucket{le="+Inf"} 5
+rpc_latency_seconds_sum 2.5
+rpc_latency_seconds_count 5
+`
+
+// metricsServer serves the metrics, and returns its endpoint as in a devnet descriptor.
+func metricsServer(t *testing.T, metrics string) *descriptors.PortInfo {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, metrics)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return &descriptors.PortInfo{Host: u.Hostname(), Port: port}
+}
+
+func testDevnet(cl, supervisor *descriptors.PortInfo) *descriptors.DevnetEnvironment {
+	supervisorSvc := &descriptors.Service{Name: "op-supervisor", Endpoints: descriptors.EndpointMap{"metrics": supervisor}}
+	chain := func(name string) *descriptors.L2Chain {
+		return &descriptors.L2Chain{Chain: &descriptors.Chain{
+			Name:     name,
+			Services: descriptors.RedundantServiceMap{"supervisor": {supervisorSvc}},
+			Nodes: []descriptors.Node{{Services: descriptors.ServiceMap{
+				"cl": {Name: "op-node-" + name, Endpoints: descriptors.EndpointMap{"metrics": cl}},
+				"el": {Name: "op-geth-" + name, Endpoints: descriptors.EndpointMap{"rpc": cl}},
+			}}},
+		}}
+	}
+	return &descriptors.DevnetEnvironment{
+		L1: &descriptors.Chain{Name: "l1"},
+		L2: []*descriptors.L2Chain{chain("chain-a"), chain("chain-b")},
+	}
+}
+
+func TestMetricsTargets(t *testing.T) {
+	env := testDevnet(&descriptors.PortInfo{Host: "127.0.0.1", Port: 9001}, &descriptors.PortInfo{Host: "127.0.0.1", Port: 9002, Scheme: "https"})
+	env.L2[1].Nodes[0].Services["cl"].Endpoints["metrics"] = &descriptors.PortInfo{Host: "127.0.0.1", Port: 9003}
+
+	require.Equal(t, []metricsTarget{
+		{name: "chain-a/cl/op-node-chain-a", url: "http://127.0.0.1:9001/metrics"},
+		{name: "chain-b/cl/op-node-chain-b", url: "http://127.0.0.1:9003/metrics"},
+	}, metricsTargets(env, "cl"))
+	require.Equal(t, []metricsTarget{
+		{name: "chain-a/supervisor/op-supervisor", url: "https://127.0.0.1:9002/metrics"},
+	}, metricsTargets(env, "supervisor"), "the supervisor is shared by the chains")
+	require.Empty(t, metricsTargets(env, "el"), "components without metrics endpoint are not scraped")
+}
+
+func TestCheckMetricAssertions(t *testing.T) {
+	endpoint := metricsServer(t, testMetrics)
+	env := testDevnet(endpoint, endpoint)
+	env.L2 = env.L2[:1]
+
+	t.Run("passing", func(t *testing.T) {
+		results, err := checkMetricAssertions(context.Background(), env, []MetricAssertion{
+			{Name: "no-reorgs", Component: "cl", Metric: "op_node_default_l1_reorg_depth_count"},
+			{Name: "unexported", Component: "cl", Metric: "unknown_metric"},
+			{Name: "error-rate", Component: "supervisor", Metric: "rpc_requests_total", Labels: map[string]string{"error": "true"}, Per: "rpc_requests_total", Max: 0.05},
+			{Name: "latency-observations", Component: "cl", Metric: "rpc_latency_seconds", Max: 5},
+			{Name: "division-by-zero", Component: "cl", Metric: "rpc_requests_total", Per: "unknown_metric"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []MetricAssertionResult{
+			{Name: "no-reorgs", Component: "chain-a/cl/op-node-chain-a", Passed: true},
+			{Name: "unexported", Component: "chain-a/cl/op-node-chain-a", Passed: true},
+			{Name: "error-rate", Component: "chain-a/supervisor/op-supervisor", Value: 0.04, Max: 0.05, Passed: true},
+			{Name: "latency-observations", Component: "chain-a/cl/op-node-chain-a", Value: 5, Max: 5, Passed: true},
+			{Name: "division-by-zero", Component: "chain-a/cl/op-node-chain-a", Passed: true},
+		}, results)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		results, err := checkMetricAssertions(context.Background(), env, []MetricAssertion{
+			{Name: "no-corruption", Component: "cl", Metric: "db_corrupted"},
+			{Name: "chain-corruption", Component: "cl", Metric: "db_corrupted", Labels: map[string]string{"chain": "10"}},
+			{Name: "method-errors", Component: "cl", Metric: "rpc_requests_total", Labels: map[string]string{"method": "b", "error": "true"}},
+			{Name: "no-batcher", Component: "batcher", Metric: "db_corrupted"},
+		})
+		require.ErrorContains(t, err, `metric assertion "no-corruption" of chain-a/cl/op-node-chain-a: db_corrupted is 1, above the maximum of 0`)
+		require.ErrorContains(t, err, `metric assertion "method-errors"`)
+		require.ErrorContains(t, err, `metric assertion "no-batcher": no batcher component with a metrics endpoint`)
+		require.NotContains(t, err.Error(), "chain-corruption")
+		require.Len(t, results, 4)
+		require.False(t, results[0].Passed)
+		require.True(t, results[1].Passed)
+		require.Equal(t, 1.0, results[2].Value)
+		require.False(t, results[3].Passed)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		env := testDevnet(&descriptors.PortInfo{Host: "127.0.0.1", Port: 1}, endpoint)
+		env.L2 = env.L2[:1]
+		results, err := checkMetricAssertions(context.Background(), env, []MetricAssertion{
+			{Name: "no-reorgs", Component: "cl", Metric: "op_node_default_l1_reorg_depth_count"},
+		})
+		require.ErrorContains(t, err, "failed to scrape metrics")
+		require.Len(t, results, 1)
+		require.False(t, results[0].Passed)
+		require.NotEmpty(t, results[0].Error)
+	})
+}
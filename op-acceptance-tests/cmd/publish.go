@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+const (
+	// signatureHeader carries the hex-encoded HMAC-SHA256 of the request body, signed with the shared secret.
+	signatureHeader = "X-Signature-256"
+	signaturePrefix = "sha256="
+)
+
+// StepResult is the outcome of a single step of the acceptance test run.
+type StepResult struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// GateResult is the structured result of running a gate against a devnet,
+// as published to the central results service.
+type GateResult struct {
+	Gate       string    `json:"gate"`
+	Devnet     string    `json:"devnet"`
+	Branch     string    `json:"branch,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	Passed     bool      `json:"passed"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+
+	Steps []StepResult `json:"steps"`
+	// MetricAssertions are the results of the metric assertions of the gate, if they were checked.
+	MetricAssertions []MetricAssertionResult `json:"metricAssertions,omitempty"`
+	// Artifacts links to the logs and other artifacts of the run, e.g. in CI.
+	Artifacts []string `json:"artifacts,omitempty"`
+	// DevnetDescriptor describes the devnet that the gate ran against, if it could be loaded.
+	DevnetDescriptor *descriptors.DevnetEnvironment `json:"devnetDescriptor,omitempty"`
+}
+
+// errNonRetryable marks a publishing failure that will not succeed when retried.
+var errNonRetryable = errors.New("non-retryable")
+
+// resultsPublisher posts gate results to a HTTP endpoint.
+type resultsPublisher struct {
+	endpoint    string
+	secret      []byte
+	maxAttempts int
+	strategy    retry.Strategy
+	client      *http.Client
+}
+
+func newResultsPublisher(endpoint string, secret string, maxAttempts int) *resultsPublisher {
+	return &resultsPublisher{
+		endpoint:    endpoint,
+		secret:      []byte(secret),
+		maxAttempts: max(maxAttempts, 1),
+		strategy:    retry.Exponential(),
+		client:      &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// sign returns the signature header value of the body, or an empty string if no secret is configured.
+func (p *resultsPublisher) sign(body []byte) string {
+	if len(p.secret) == 0 {
+		return ""
+	}
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Publish posts the result, retrying on connection errors, rate-limiting and server errors.
+func (p *resultsPublisher) Publish(ctx context.Context, result *GateResult) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode gate result: %w", err)
+	}
+	signature := p.sign(body)
+
+	var lastErr error
+	for attempt := 0; attempt < p.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return errors.Join(lastErr, ctx.Err())
+			case <-time.After(p.strategy.Duration(attempt - 1)):
+			}
+		}
+		lastErr = p.post(ctx, body, signature)
+		if lastErr == nil || errors.Is(lastErr, errNonRetryable) {
+			return lastErr
+		}
+	}
+	return fmt.Errorf("failed to publish gate result after %d attempts: %w", p.maxAttempts, lastErr)
+}
+
+func (p *resultsPublisher) post(ctx context.Context, body []byte, signature string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %w", errNonRetryable, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(signatureHeader, signature)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post gate result: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("results service responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return fmt.Errorf("%w: %w", errNonRetryable, err)
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+func testResult() *GateResult {
+	return &GateResult{
+		Gate:       "interop",
+		Devnet:     "interop-devnet",
+		Branch:     "develop",
+		Passed:     true,
+		StartedAt:  time.Unix(1700000000, 0).UTC(),
+		DurationMs: 1234,
+		Steps:      []StepResult{{Name: "run-acceptor", Passed: true, DurationMs: 1200}},
+		Artifacts:  []string{"https://ci.example.com/job/1/artifacts"},
+	}
+}
+
+func TestResultsPublisher(t *testing.T) {
+	t.Run("signed", func(t *testing.T) {
+		var received GateResult
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			mac := hmac.New(sha256.New, []byte("secret"))
+			mac.Write(body)
+			require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get(signatureHeader))
+			require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			require.NoError(t, json.Unmarshal(body, &received))
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer srv.Close()
+
+		p := newResultsPublisher(srv.URL, "secret", 1)
+		require.NoError(t, p.Publish(context.Background(), testResult()))
+		require.Equal(t, *testResult(), received)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Empty(t, r.Header.Get(signatureHeader))
+		}))
+		defer srv.Close()
+
+		p := newResultsPublisher(srv.URL, "", 1)
+		require.NoError(t, p.Publish(context.Background(), testResult()))
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		p := newResultsPublisher(srv.URL, "secret", 5)
+		p.strategy = retry.Fixed(time.Millisecond)
+		require.NoError(t, p.Publish(context.Background(), testResult()))
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		p := newResultsPublisher(srv.URL, "secret", 3)
+		p.strategy = retry.Fixed(time.Millisecond)
+		require.ErrorContains(t, p.Publish(context.Background(), testResult()), "after 3 attempts")
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		attempts := 0
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+		}))
+		defer srv.Close()
+
+		p := newResultsPublisher(srv.URL, "secret", 5)
+		p.strategy = retry.Fixed(time.Millisecond)
+		err := p.Publish(context.Background(), testResult())
+		require.ErrorIs(t, err, errNonRetryable)
+		require.ErrorContains(t, err, "bad signature")
+		require.Equal(t, 1, attempts)
+	})
+}
+
+func TestRunSteps(t *testing.T) {
+	var ran []string
+	steps := []step{
+		{name: "skipped", skip: true, run: func(ctx context.Context) error {
+			ran = append(ran, "skipped")
+			return nil
+		}},
+		{name: "ok", run: func(ctx context.Context) error {
+			ran = append(ran, "ok")
+			return nil
+		}},
+		{name: "failing", run: func(ctx context.Context) error {
+			ran = append(ran, "failing")
+			return io.ErrUnexpectedEOF
+		}},
+		{name: "after", run: func(ctx context.Context) error {
+			ran = append(ran, "after")
+			return nil
+		}},
+	}
+	result := &GateResult{}
+	err := runSteps(context.Background(), steps, result)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Equal(t, []string{"ok", "failing"}, ran)
+	require.Len(t, result.Steps, 3)
+	require.True(t, result.Steps[0].Skipped)
+	require.True(t, result.Steps[1].Passed)
+	require.False(t, result.Steps[2].Passed)
+	require.Equal(t, io.ErrUnexpectedEOF.Error(), result.Steps[2].Error)
+}
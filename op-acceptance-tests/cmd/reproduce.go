@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+)
+
+var (
+	// reproducedEnvPrefixes are the prefixes of the environment variables that affect the run, besides those of the flags.
+	reproducedEnvPrefixes = []string{"NAT_", "DEVNET_", "DEVSTACK_", "ACCEPTOR_"}
+	// sensitiveEnvMarkers mark environment variables that must never be captured.
+	sensitiveEnvMarkers = []string{"SECRET", "TOKEN", "PASSWORD", "PRIVATE", "KEY"}
+)
+
+// Reproduce is the effective configuration of an acceptance test run, captured to replay the run elsewhere.
+type Reproduce struct {
+	CapturedAt time.Time `json:"capturedAt"`
+	// Flags are the values of the flags of the run, including defaults.
+	// Paths within the repository are relative to the root of the repository.
+	Flags map[string]string `json:"flags"`
+	// Env are the environment variables that affect the run.
+	Env map[string]string `json:"env"`
+	Git GitState          `json:"git"`
+	// Versions are the versions of the components used by the run, by component name.
+	Versions map[string]string `json:"versions"`
+	// DevnetDescriptor describes the devnet that the gate ran against, if it could be loaded.
+	DevnetDescriptor *descriptors.DevnetEnvironment `json:"devnetDescriptor,omitempty"`
+}
+
+// GitState is the state of the repository that the tests ran from.
+type GitState struct {
+	Commit string `json:"commit,omitempty"`
+	// Dirty is true if the repository had uncommitted changes.
+	Dirty bool `json:"dirty"`
+}
+
+// reproducibleFlags returns the flags of the app that are captured: all flags except secrets and the reproduce flags.
+func reproducibleFlags(flags []cli.Flag) []cli.Flag {
+	var out []cli.Flag
+	for _, f := range flags {
+		if f == resultsSecretFlag || f == reproduceFileFlag || f == fromReproduceFlag {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// isPathFlag returns true for the flags with paths, that are captured relative to the root of the repository.
+func isPathFlag(name string) bool {
+	return name == testDirFlag.Name || name == validatorsFlag.Name || name == kurtosisDirFlag.Name
+}
+
+// captureReproduce captures the effective configuration of the run.
+func captureReproduce(ctx context.Context, c *cli.Context, repoRoot string, acceptor string) *Reproduce {
+	r := &Reproduce{
+		CapturedAt: time.Now(),
+		Flags:      make(map[string]string),
+		Env:        make(map[string]string),
+		Versions:   make(map[string]string),
+	}
+	var flagEnvVars []string
+	for _, f := range reproducibleFlags(c.App.Flags) {
+		name := f.Names()[0]
+		var value string
+		if _, ok := f.(*cli.StringSliceFlag); ok {
+			value = strings.Join(c.StringSlice(name), ",")
+		} else {
+			value = fmt.Sprint(c.Value(name))
+		}
+		if isPathFlag(name) && value != "" {
+			value = relativeToRepo(repoRoot, value)
+		}
+		r.Flags[name] = value
+		if envFlag, ok := f.(interface{ GetEnvVars() []string }); ok {
+			flagEnvVars = append(flagEnvVars, envFlag.GetEnvVars()...)
+		}
+	}
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if isReproducedEnv(key, flagEnvVars) {
+			r.Env[key] = value
+		}
+	}
+
+	if commit, err := runCommand(ctx, repoRoot, "git", "rev-parse", "HEAD"); err == nil {
+		r.Git.Commit = commit
+	}
+	if status, err := runCommand(ctx, repoRoot, "git", "status", "--porcelain"); err == nil {
+		r.Git.Dirty = status != ""
+	}
+
+	r.Versions["go"] = runtime.Version()
+	for name, cmd := range map[string][]string{
+		"op-acceptor": {acceptor, "--version"},
+		"kurtosis":    {"kurtosis", "version"},
+		"just":        {"just", "--version"},
+	} {
+		version, err := runCommand(ctx, "", cmd[0], cmd[1:]...)
+		if err != nil {
+			version = "unknown"
+		}
+		r.Versions[name] = version
+	}
+	return r
+}
+
+func isReproducedEnv(key string, flagEnvVars []string) bool {
+	for _, marker := range sensitiveEnvMarkers {
+		if strings.Contains(key, marker) {
+			return false
+		}
+	}
+	if slices.Contains(flagEnvVars, key) {
+		return true
+	}
+	for _, prefix := range reproducedEnvPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// relativeToRepo returns the path relative to the root of the repository, or the path unchanged if it is outside of it.
+func relativeToRepo(repoRoot string, path string) string {
+	if repoRoot == "" {
+		return path
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	rel, err := filepath.Rel(repoRoot, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+func writeReproduce(path string, r *Reproduce) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode reproduce file: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write reproduce file: %w", err)
+	}
+	return nil
+}
+
+func readReproduce(path string) (*Reproduce, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reproduce file: %w", err)
+	}
+	var r Reproduce
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode reproduce file: %w", err)
+	}
+	return &r, nil
+}
+
+// applyReproduce replays the configuration of a captured run.
+// Flags and environment variables that are set explicitly take precedence over the captured values,
+// e.g. to point the run at a different devnet.
+// It returns warnings about the differences with the captured run that can not be replayed, like the git commit.
+func applyReproduce(ctx context.Context, c *cli.Context, repoRoot string, r *Reproduce) ([]string, error) {
+	for key, value := range r.Env {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("failed to set env var %s: %w", key, err)
+		}
+	}
+	for _, f := range reproducibleFlags(c.App.Flags) {
+		name := f.Names()[0]
+		value, ok := r.Flags[name]
+		if !ok || c.IsSet(name) {
+			continue
+		}
+		if isPathFlag(name) && value != "" && !filepath.IsAbs(value) && repoRoot != "" {
+			value = filepath.Join(repoRoot, value)
+		}
+		if err := c.Set(name, value); err != nil {
+			return nil, fmt.Errorf("failed to set flag %s: %w", name, err)
+		}
+	}
+
+	var warnings []string
+	if r.Git.Commit != "" {
+		if commit, err := runCommand(ctx, repoRoot, "git", "rev-parse", "HEAD"); err != nil {
+			warnings = append(warnings, fmt.Sprintf("unable to check git commit, captured run used %s", r.Git.Commit))
+		} else if commit != r.Git.Commit {
+			warnings = append(warnings, fmt.Sprintf("git commit %s differs from the captured run: %s", commit, r.Git.Commit))
+		}
+	}
+	if r.Git.Dirty {
+		warnings = append(warnings, "captured run had uncommitted changes")
+	}
+	if v := r.Versions["go"]; v != "" && v != runtime.Version() {
+		warnings = append(warnings, fmt.Sprintf("go version %s differs from the captured run: %s", runtime.Version(), v))
+	}
+	return warnings, nil
+}
+
+// findRepoRoot returns the root of the git repository of the working directory, or an empty string if it is unknown.
+func findRepoRoot(ctx context.Context) string {
+	root, err := runCommand(ctx, "", "git", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	return root
+}
+
+func runCommand(ctx context.Context, dir string, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// runApp runs an app with the flags of the runner, and returns the context of the action.
+func runApp(t *testing.T, args ...string) *cli.Context {
+	var ctx *cli.Context
+	app := &cli.App{
+		Flags: []cli.Flag{
+			devnetFlag,
+			gateFlag,
+			testDirFlag,
+			validatorsFlag,
+			kurtosisDirFlag,
+			resultsSecretFlag,
+			resultsArtifactsFlag,
+			reproduceFileFlag,
+			fromReproduceFlag,
+		},
+		Action: func(c *cli.Context) error {
+			ctx = c
+			return nil
+		},
+	}
+	require.NoError(t, app.Run(append([]string{"op-acceptance-test"}, args...)))
+	return ctx
+}
+
+func TestReproduce(t *testing.T) {
+	repoRoot := t.TempDir()
+	t.Setenv("NAT_STEADY_TIMEOUT", "5m")
+	t.Setenv("RESULTS_HMAC_SECRET", "secret")
+	t.Setenv("NAT_API_KEY", "secret")
+	t.Setenv("HOME_DIR", "/home/ci")
+
+	c := runApp(t,
+		"--devnet", "interop",
+		"--gate", "interop",
+		"--testdir", repoRoot,
+		"--validators", filepath.Join(repoRoot, "op-acceptance-tests/acceptance-tests.yaml"),
+		"--kurtosis-dir", "/opt/kurtosis-devnet",
+		"--results.artifacts", "https://ci.example.com/1,https://ci.example.com/2",
+	)
+	r := captureReproduce(context.Background(), c, repoRoot, "op-acceptor")
+
+	require.Equal(t, "interop", r.Flags[devnetFlag.Name])
+	require.Equal(t, ".", r.Flags[testDirFlag.Name])
+	require.Equal(t, "op-acceptance-tests/acceptance-tests.yaml", r.Flags[validatorsFlag.Name])
+	require.Equal(t, "/opt/kurtosis-devnet", r.Flags[kurtosisDirFlag.Name], "paths outside of the repository are kept")
+	require.Equal(t, "https://ci.example.com/1,https://ci.example.com/2", r.Flags[resultsArtifactsFlag.Name])
+	require.NotContains(t, r.Flags, resultsSecretFlag.Name)
+	require.NotContains(t, r.Flags, reproduceFileFlag.Name)
+	require.Equal(t, "5m", r.Env["NAT_STEADY_TIMEOUT"])
+	require.NotContains(t, r.Env, "RESULTS_HMAC_SECRET")
+	require.NotContains(t, r.Env, "NAT_API_KEY")
+	require.NotContains(t, r.Env, "HOME_DIR")
+	require.Contains(t, r.Versions, "go")
+
+	path := filepath.Join(t.TempDir(), "reproduce.json")
+	require.NoError(t, writeReproduce(path, r))
+	loaded, err := readReproduce(path)
+	require.NoError(t, err)
+
+	// Replay in a different checkout and environment
+	localRoot := t.TempDir()
+	t.Setenv("NAT_STEADY_TIMEOUT", "1m")
+	c = runApp(t, "--from-reproduce", path, "--gate", "base")
+	_, err = applyReproduce(context.Background(), c, localRoot, loaded)
+	require.NoError(t, err)
+
+	require.Equal(t, "interop", c.String(devnetFlag.Name))
+	require.Equal(t, "base", c.String(gateFlag.Name), "explicit flags take precedence")
+	require.Equal(t, localRoot, c.String(testDirFlag.Name))
+	require.Equal(t, filepath.Join(localRoot, "op-acceptance-tests/acceptance-tests.yaml"), c.String(validatorsFlag.Name))
+	require.Equal(t, "/opt/kurtosis-devnet", c.String(kurtosisDirFlag.Name))
+	require.Equal(t, []string{"https://ci.example.com/1", "https://ci.example.com/2"}, c.StringSlice(resultsArtifactsFlag.Name))
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Tool is a pinned release of a binary that the runner downloads into the toolcache, instead of using the one on PATH.
+type Tool struct {
+	Name    string
+	Version string
+	// URL is the download URL of the release for the current platform, with the {version}, {os} and {arch} placeholders.
+	// The release is either a .tar.gz archive that contains the binary, or the binary itself.
+	URL string
+	// SHA256 is the hex-encoded checksum of the download for the current platform.
+	SHA256 string
+}
+
+// DownloadURL returns the URL with the placeholders filled in for the current platform.
+func (t Tool) DownloadURL() string {
+	return strings.NewReplacer("{version}", t.Version, "{os}", runtime.GOOS, "{arch}", runtime.GOARCH).Replace(t.URL)
+}
+
+// toolCache downloads pinned tools into a directory, and reuses them across runs.
+// A tool is stored under <dir>/<name>/<version>/<checksum>/<name>, so changing the pinned checksum downloads it again.
+type toolCache struct {
+	dir    string
+	client *http.Client
+}
+
+func newToolCache(dir string) *toolCache {
+	return &toolCache{dir: dir, client: http.DefaultClient}
+}
+
+// Ensure returns the path of the binary of the tool, and downloads it first if it is not in the cache.
+// The download is verified against the pinned checksum before the binary is stored.
+func (c *toolCache) Ensure(ctx context.Context, tool Tool) (string, error) {
+	if tool.Version == "" {
+		return "", fmt.Errorf("no version of %s is pinned", tool.Name)
+	}
+	checksum := strings.ToLower(strings.TrimPrefix(tool.SHA256, "sha256:"))
+	if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != 2*sha256.Size {
+		return "", fmt.Errorf("invalid or missing sha256 checksum of %s %s for %s/%s: %q", tool.Name, tool.Version, runtime.GOOS, runtime.GOARCH, tool.SHA256)
+	}
+	binDir := filepath.Join(c.dir, tool.Name, tool.Version, checksum)
+	path := filepath.Join(binDir, tool.Name)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(binDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create toolcache directory: %w", err)
+	}
+	download, err := c.download(ctx, tool.DownloadURL(), binDir, checksum)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s %s: %w", tool.Name, tool.Version, err)
+	}
+	defer os.Remove(download)
+	// Extract into a temporary file, and rename it so an interrupted run never leaves a partial binary in the cache.
+	tmp, err := os.CreateTemp(binDir, tool.Name+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("failed to create binary of %s: %w", tool.Name, err)
+	}
+	defer os.Remove(tmp.Name())
+	err = extractBinary(download, tool.Name, tmp)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to extract %s: %w", tool.Name, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o755); err != nil {
+		return "", fmt.Errorf("failed to make %s executable: %w", tool.Name, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store %s: %w", tool.Name, err)
+	}
+	return path, nil
+}
+
+// download stores the content at the URL in a temporary file in dir, and returns its path if its checksum matches.
+func (c *toolCache) download(ctx context.Context, url string, dir string, checksum string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	f, err := os.CreateTemp(dir, "download.*.tmp")
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != checksum {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("checksum mismatch of %s: expected %s, got %s", url, checksum, got)
+	}
+	return f.Name(), nil
+}
+
+// extractBinary writes the binary with the given name to out. The download is either a gzipped tarball that
+// contains the binary, in any directory, or the binary itself.
+func extractBinary(download string, name string, out io.Writer) error {
+	f, err := os.Open(download)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if errors.Is(err, gzip.ErrHeader) {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		_, err = io.Copy(out, f)
+		return err
+	} else if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("archive does not contain %s", name)
+		} else if err != nil {
+			return err
+		}
+		if hdr.Typeflag == tar.TypeReg && filepath.Base(hdr.Name) == name {
+			_, err = io.Copy(out, tr)
+			return err
+		}
+	}
+}
+
+// prependPath puts the directories in front of PATH, so that the commands the runner starts, like just in the
+// kurtosis-devnet directory, find the cached tools first.
+func prependPath(dirs ...string) error {
+	return os.Setenv("PATH", strings.Join(append(dirs, os.Getenv("PATH")), string(os.PathListSeparator)))
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tarball(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// serve serves the download at /<version>/<os>/<arch>, and counts the requests.
+func serve(t *testing.T, download []byte) (string, *int) {
+	requests := new(int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.URL.Path != "/v1.2.3/"+runtime.GOOS+"/"+runtime.GOARCH {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(download)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL + "/{version}/{os}/{arch}", requests
+}
+
+func TestToolCache(t *testing.T) {
+	binary := []byte("#!/bin/sh\necho op-acceptor\n")
+
+	t.Run("tarball", func(t *testing.T) {
+		download := tarball(t, map[string][]byte{"README.md": []byte("readme"), "dist/op-acceptor": binary})
+		url, requests := serve(t, download)
+		cache := newToolCache(t.TempDir())
+		tool := Tool{Name: "op-acceptor", Version: "v1.2.3", URL: url, SHA256: checksum(download)}
+
+		path, err := cache.Ensure(context.Background(), tool)
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, binary, content)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NotZero(t, info.Mode()&0o111, "binary must be executable")
+		require.Equal(t, filepath.Join(cache.dir, "op-acceptor", "v1.2.3", checksum(download), "op-acceptor"), path)
+
+		// The cached binary is reused.
+		cached, err := cache.Ensure(context.Background(), tool)
+		require.NoError(t, err)
+		require.Equal(t, path, cached)
+		require.Equal(t, 1, *requests)
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		url, _ := serve(t, binary)
+		cache := newToolCache(t.TempDir())
+		path, err := cache.Ensure(context.Background(), Tool{Name: "kurtosis", Version: "v1.2.3", URL: url, SHA256: "sha256:" + checksum(binary)})
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, binary, content)
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		url, _ := serve(t, binary)
+		cache := newToolCache(t.TempDir())
+		_, err := cache.Ensure(context.Background(), Tool{Name: "kurtosis", Version: "v1.2.3", URL: url, SHA256: checksum([]byte("other"))})
+		require.ErrorContains(t, err, "checksum mismatch")
+		// Nothing is left in the cache.
+		entries, err := os.ReadDir(filepath.Join(cache.dir, "kurtosis", "v1.2.3", checksum([]byte("other"))))
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		download := tarball(t, map[string][]byte{"dist/other": binary})
+		url, _ := serve(t, download)
+		cache := newToolCache(t.TempDir())
+		_, err := cache.Ensure(context.Background(), Tool{Name: "op-acceptor", Version: "v1.2.3", URL: url, SHA256: checksum(download)})
+		require.ErrorContains(t, err, "does not contain op-acceptor")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		url, _ := serve(t, binary)
+		cache := newToolCache(t.TempDir())
+		_, err := cache.Ensure(context.Background(), Tool{Name: "kurtosis", Version: "v9.9.9", URL: url, SHA256: checksum(binary)})
+		require.ErrorContains(t, err, "unexpected status 404")
+	})
+
+	t.Run("missing checksum", func(t *testing.T) {
+		cache := newToolCache(t.TempDir())
+		_, err := cache.Ensure(context.Background(), Tool{Name: "kurtosis", Version: "v1.2.3", URL: "http://localhost/unused"})
+		require.ErrorContains(t, err, "invalid or missing sha256 checksum")
+	})
+}
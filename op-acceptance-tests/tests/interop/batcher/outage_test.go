@@ -0,0 +1,98 @@
+package batcher
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-acceptance-tests/tests/interop"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+const (
+	// outageL1Blocks is the number of L1 blocks the batcher of chain A stays offline for.
+	outageL1Blocks = 10
+	// outageMessages is the number of interop messages sent from chain A to chain B during the outage.
+	outageMessages = 5
+)
+
+type messagePair struct {
+	init *types.Receipt
+	exec *types.Receipt
+}
+
+// TestBatcherOutageRecovery halts the batcher of the initiating chain while interop messages continue
+// to be included on the unsafe chains, then resumes it. It asserts that no executing message is promoted
+// to cross-safe before its initiating message is local-safe, that local-safe catches up after the outage,
+// and that cross-safe then advances past every message without any of them being dropped.
+func TestBatcherOutageRecovery(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewSimpleInterop(t)
+	require := t.Require()
+	logger := t.Logger()
+	rng := rand.New(rand.NewSource(4242))
+
+	alice := sys.FunderA.NewFundedEOA(eth.OneEther)
+	bob := sys.FunderB.NewFundedEOA(eth.OneEther)
+	eventLoggerAddress := alice.DeployEventLogger()
+
+	// Start the outage from a cross-safe state, so the deployment itself is not affected by it.
+	sys.L2CLA.Advanced(suptypes.CrossSafe, 1, 30)
+
+	sys.L2BatcherA.Stop()
+	stoppedAt := sys.L1Network.WaitForBlock() // wait for new block, in case there is any batch left
+	sys.Supervisor.AwaitMinL1(stoppedAt.Number)
+	localSafeAtStop := sys.L2CLA.HeadBlockRef(suptypes.LocalSafe)
+	logger.Info("Batcher of chain A stopped", "l1", stoppedAt, "localSafe", localSafeAtStop)
+
+	pairs := make([]messagePair, 0, outageMessages)
+	for i := range outageMessages {
+		initIntent, initReceipt := alice.SendInitMessage(interop.RandomInitTrigger(rng, eventLoggerAddress, rng.Intn(5), rng.Intn(30)))
+		// Make sure the supervisor indexed the block with the initiating message
+		sys.Supervisor.WaitForUnsafeHeadToAdvance(alice.ChainID(), 2)
+		_, execReceipt := bob.SendExecMessage(initIntent, 0)
+		logger.Info("Sent interop message during batcher outage", "index", i,
+			"initBlock", initReceipt.BlockNumber, "execBlock", execReceipt.BlockNumber)
+		pairs = append(pairs, messagePair{init: initReceipt, exec: execReceipt})
+	}
+	firstExec := pairs[0].exec.BlockNumber.Uint64()
+	lastInit := pairs[len(pairs)-1].init.BlockNumber.Uint64()
+	lastExec := pairs[len(pairs)-1].exec.BlockNumber.Uint64()
+
+	// Keep the batcher offline for a while, with the messages only on the unsafe chain.
+	for range outageL1Blocks {
+		sys.L1Network.WaitForBlock()
+
+		crossSafeA := sys.Supervisor.ChainSyncStatus(sys.L2ChainA.ChainID(), suptypes.CrossSafe)
+		require.Less(crossSafeA.Number, pairs[0].init.BlockNumber.Uint64(),
+			"initiating messages must not become cross-safe while their batches are not submitted")
+		crossSafeB := sys.Supervisor.ChainSyncStatus(sys.L2ChainB.ChainID(), suptypes.CrossSafe)
+		require.Less(crossSafeB.Number, firstExec,
+			"executing messages must not be promoted to cross-safe before their initiating messages are safe")
+	}
+	localSafeA := sys.L2CLA.HeadBlockRef(suptypes.LocalSafe)
+	require.Less(localSafeA.Number, pairs[0].init.BlockNumber.Uint64(), "local-safe must not advance without batches")
+
+	logger.Info("Restarting batcher of chain A")
+	sys.L2BatcherA.Start()
+
+	// Local-safe catches up with all the messages sent during the outage, and cross-safe follows.
+	dsl.CheckAll(t,
+		sys.L2CLA.ReachedFn(suptypes.LocalSafe, lastInit, 100),
+		sys.L2CLA.ReachedFn(suptypes.CrossSafe, lastInit, 100),
+		sys.L2CLB.ReachedFn(suptypes.CrossSafe, lastExec, 100),
+	)
+
+	// None of the messages may have been dropped or reorged out while recovering.
+	for i, pair := range pairs {
+		init := sys.L2ELA.BlockRefByNumber(pair.init.BlockNumber.Uint64())
+		require.Equal(pair.init.BlockHash, init.Hash, "initiating message %d must remain canonical", i)
+		exec := sys.L2ELB.BlockRefByNumber(pair.exec.BlockNumber.Uint64())
+		require.Equal(pair.exec.BlockHash, exec.Hash, "executing message %d must remain canonical", i)
+	}
+}
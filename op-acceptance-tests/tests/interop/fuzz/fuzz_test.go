@@ -0,0 +1,149 @@
+package fuzz
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/constants"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/processors"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// defaultSteps is the number of actions of a scenario. Override it with NAT_FUZZ_STEPS.
+const defaultSteps = 8
+
+// TestInteropScenarioFuzz runs a randomized sequence of actions against the SimpleInterop system:
+// it sends interop messages between the chains, restarts the supervisor and the CL nodes, reorgs
+// the most recent L1 blocks and pauses the batchers. After every action it asserts that cross-safe
+// did not regress without an L1 reorg, and at the end that no message was executed twice and that
+// cross-safe caught up with every message.
+//
+// The schedule and the message payloads are generated from a seed, which is random unless it is set
+// with NAT_FUZZ_SEED, and is logged to reproduce a failing scenario.
+func TestInteropScenarioFuzz(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	sys := presets.NewSimpleInterop(t)
+	require := t.Require()
+	logger := t.Logger()
+
+	seed := time.Now().UnixNano()
+	if seedStr, exists := os.LookupEnv("NAT_FUZZ_SEED"); exists {
+		var err error
+		seed, err = strconv.ParseInt(seedStr, 10, 64)
+		require.NoError(err)
+	}
+	steps := defaultSteps
+	if stepsStr, exists := os.LookupEnv("NAT_FUZZ_STEPS"); exists {
+		var err error
+		steps, err = strconv.Atoi(stepsStr)
+		require.NoError(err)
+	}
+	gt.Cleanup(func() {
+		if gt.Failed() {
+			gt.Logf("reproduce the failed scenario with NAT_FUZZ_SEED=%d NAT_FUZZ_STEPS=%d", seed, steps)
+		}
+	})
+
+	schedule := newSchedule(seed, steps)
+	logger.Info("Generated scenario", "seed", seed, "steps", steps)
+	for i, a := range schedule {
+		logger.Info("Scheduled action", "step", i, "action", a)
+	}
+
+	s := newScenario(t, sys, seed)
+	startBlocks := make([]uint64, len(s.els))
+	for i, el := range s.els {
+		startBlocks[i] = el.BlockRefByLabel(eth.Unsafe).Number
+	}
+	inv := newInvariants(s)
+	for i, a := range schedule {
+		logger.Info("Running action", "step", i, "action", a)
+		s.run(a)
+		inv.checkCrossSafe()
+	}
+
+	// Cross-safe catches up with everything that was sent, once the faults are over.
+	var checks []dsl.CheckFunc
+	for i, cl := range s.cls {
+		checks = append(checks, cl.ReachedFn(suptypes.CrossSafe, s.els[i].BlockRefByLabel(eth.Unsafe).Number, 100))
+	}
+	dsl.CheckAll(t, checks...)
+	inv.checkCrossSafe()
+
+	for i, el := range s.els {
+		inv.checkExecutedOnce(el, startBlocks[i], s.cls[i].HeadBlockRef(suptypes.CrossSafe).Number)
+	}
+	logger.Info("Scenario passed", "seed", seed, "messages", s.messages)
+}
+
+// invariants checks the global invariants of a scenario.
+type invariants struct {
+	s *scenario
+	// crossSafe is the cross-safe block of every chain at the previous check.
+	crossSafe []eth.BlockID
+}
+
+func newInvariants(s *scenario) *invariants {
+	inv := &invariants{s: s, crossSafe: make([]eth.BlockID, len(s.cls))}
+	for i, cl := range s.cls {
+		inv.crossSafe[i] = cl.HeadBlockRef(suptypes.CrossSafe).ID()
+	}
+	return inv
+}
+
+// checkCrossSafe asserts that the cross-safe block of every chain did not go back, and that the
+// previous cross-safe block is still canonical, unless an L1 reorg was injected since the previous
+// check. The cross-safe blocks are read from the CL nodes, which persist across supervisor
+// restarts.
+func (inv *invariants) checkCrossSafe() {
+	s := inv.s
+	for i, cl := range s.cls {
+		crossSafe := cl.HeadBlockRef(suptypes.CrossSafe).ID()
+		prev := inv.crossSafe[i]
+		if !s.reorged {
+			s.t.Require().GreaterOrEqual(crossSafe.Number, prev.Number,
+				"cross-safe of chain %s went back from %s to %s without an L1 reorg", cl.ChainID(), prev, crossSafe)
+			s.t.Require().True(s.els[i].IsCanonical(prev),
+				"cross-safe block %s of chain %s was reorged out without an L1 reorg", prev, cl.ChainID())
+		}
+		inv.crossSafe[i] = crossSafe
+	}
+	s.reorged = false
+}
+
+// checkExecutedOnce asserts that no initiating message is executed more than once in the given
+// range of canonical blocks. The scenario executes every message once, so a second execution
+// means a transaction was replayed.
+func (inv *invariants) checkExecutedOnce(el *dsl.L2ELNode, from, to uint64) {
+	s := inv.s
+	client := el.Escape().EthClient()
+	executed := make(map[suptypes.Message]uint64)
+	for num := from; num <= to; num++ {
+		_, txs, err := client.InfoAndTxsByNumber(s.t.Ctx(), num)
+		s.t.Require().NoError(err)
+		for _, tx := range txs {
+			receipt, err := client.TransactionReceipt(s.t.Ctx(), tx.Hash())
+			s.t.Require().NoError(err)
+			for _, l := range receipt.Logs {
+				if l.Address != constants.CrossL2Inbox {
+					continue
+				}
+				msg, err := processors.MessageFromLog(l)
+				s.t.Require().NoError(err)
+				if msg == nil {
+					continue
+				}
+				prev, ok := executed[*msg]
+				s.t.Require().False(ok, "message %s executed in block %d of chain %s was already executed in block %d",
+					msg.Identifier, num, el.ChainID(), prev)
+				executed[*msg] = num
+			}
+		}
+	}
+}
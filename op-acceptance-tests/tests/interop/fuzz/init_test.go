@@ -0,0 +1,16 @@
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+)
+
+func TestMain(m *testing.M) {
+	presets.DoMain(m,
+		presets.WithSimpleInterop(),
+		// Large enough that the batcher pauses of a scenario do not expire the sequencing window,
+		// which would reorg out the unsafe chain (that case is covered by the seqwindow tests).
+		presets.WithSequencingWindow(60, 120),
+	)
+}
@@ -0,0 +1,207 @@
+package fuzz
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-acceptance-tests/tests/interop"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack/match"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-test-sequencer/sequencer/seqtypes"
+)
+
+type actionKind int
+
+const (
+	// sendMessage sends an initiating message on a chain, and executes it on the other chain.
+	sendMessage actionKind = iota
+	// restartSupervisor stops and starts the supervisor, and reconnects the CL nodes to it.
+	restartSupervisor
+	// restartCL stops and starts the CL node of a chain.
+	restartCL
+	// reorgL1 reorgs out the most recent L1 blocks.
+	reorgL1
+	// pauseBatcher stops the batcher of a chain for a number of L1 blocks.
+	pauseBatcher
+)
+
+// actionWeights are the relative frequencies of the actions in a schedule. Messages are the most
+// frequent, so the faults always have messages in flight to affect.
+var actionWeights = []struct {
+	kind   actionKind
+	weight int
+}{
+	{sendMessage, 10},
+	{restartSupervisor, 2},
+	{restartCL, 3},
+	{reorgL1, 2},
+	{pauseBatcher, 3},
+}
+
+// action is a step of a scenario.
+type action struct {
+	kind actionKind
+	// chain is the index of the chain the action applies to: the source chain of a message, or the
+	// chain of the restarted CL node or paused batcher.
+	chain int
+	// blocks is the number of L1 blocks a batcher is paused for, or the depth of an L1 reorg.
+	blocks int
+	// topics and dataLen are the payload of a message.
+	topics, dataLen int
+}
+
+func (a action) String() string {
+	switch a.kind {
+	case sendMessage:
+		return fmt.Sprintf("send message from chain %d (%d topics, %d bytes)", a.chain, a.topics, a.dataLen)
+	case restartSupervisor:
+		return "restart supervisor"
+	case restartCL:
+		return fmt.Sprintf("restart CL of chain %d", a.chain)
+	case reorgL1:
+		return fmt.Sprintf("reorg %d L1 blocks", a.blocks)
+	case pauseBatcher:
+		return fmt.Sprintf("pause batcher of chain %d for %d L1 blocks", a.chain, a.blocks)
+	default:
+		return fmt.Sprintf("unknown action %d", a.kind)
+	}
+}
+
+// newSchedule generates the actions of a scenario of the given number of steps. The schedule only
+// depends on the seed, so a failing scenario can be reproduced from its seed.
+func newSchedule(seed int64, steps int) []action {
+	rng := rand.New(rand.NewSource(seed))
+	total := 0
+	for _, w := range actionWeights {
+		total += w.weight
+	}
+	schedule := make([]action, 0, steps)
+	for range steps {
+		// Draw every field for every action, so the fields of an action don't depend on its kind.
+		pick := rng.Intn(total)
+		a := action{
+			chain:   rng.Intn(2),
+			blocks:  1 + rng.Intn(3),
+			topics:  rng.Intn(5),
+			dataLen: rng.Intn(100),
+		}
+		for _, w := range actionWeights {
+			if pick < w.weight {
+				a.kind = w.kind
+				break
+			}
+			pick -= w.weight
+		}
+		schedule = append(schedule, a)
+	}
+	return schedule
+}
+
+// scenario runs the actions of a schedule against the SimpleInterop system.
+type scenario struct {
+	t   devtest.T
+	sys *presets.SimpleInterop
+	rng *rand.Rand
+
+	// chains, CLs, batchers, eoas and eventLoggers are indexed by the chain of an action.
+	chains       []*dsl.L2Network
+	cls          []*dsl.L2CLNode
+	els          []*dsl.L2ELNode
+	batchers     []*dsl.L2Batcher
+	eoas         []*dsl.EOA
+	eventLoggers []common.Address
+
+	// messages is the number of messages sent so far.
+	messages int
+	// reorged is set when an L1 reorg was injected, and cleared by the invariant checks.
+	reorged bool
+}
+
+func newScenario(t devtest.T, sys *presets.SimpleInterop, seed int64) *scenario {
+	s := &scenario{
+		t:        t,
+		sys:      sys,
+		rng:      rand.New(rand.NewSource(seed)),
+		chains:   []*dsl.L2Network{sys.L2ChainA, sys.L2ChainB},
+		cls:      []*dsl.L2CLNode{sys.L2CLA, sys.L2CLB},
+		els:      []*dsl.L2ELNode{sys.L2ELA, sys.L2ELB},
+		batchers: []*dsl.L2Batcher{sys.L2BatcherA, sys.L2BatcherB},
+		eoas:     []*dsl.EOA{sys.FunderA.NewFundedEOA(eth.OneEther), sys.FunderB.NewFundedEOA(eth.OneEther)},
+	}
+	for _, eoa := range s.eoas {
+		s.eventLoggers = append(s.eventLoggers, eoa.DeployEventLogger())
+	}
+	return s
+}
+
+func (s *scenario) run(a action) {
+	switch a.kind {
+	case sendMessage:
+		s.sendMessage(a)
+	case restartSupervisor:
+		s.sys.Supervisor.Stop()
+		s.sys.Supervisor.Start()
+		// The supervisor does not reconnect to the CL nodes by itself.
+		for _, cl := range s.cls {
+			s.sys.Supervisor.AddManagedL2CL(cl)
+		}
+		for _, chain := range s.chains {
+			s.sys.Supervisor.WaitForUnsafeHeadToAdvance(chain.ChainID(), 2)
+		}
+	case restartCL:
+		s.cls[a.chain].Stop()
+		s.cls[a.chain].Start()
+		// The supervisor reconnects to the CL node once it is back.
+		s.sys.Supervisor.WaitForUnsafeHeadToAdvance(s.chains[a.chain].ChainID(), 2)
+	case reorgL1:
+		s.reorgL1(a.blocks)
+	case pauseBatcher:
+		s.batchers[a.chain].Stop()
+		for range a.blocks {
+			s.sys.L1Network.WaitForBlock()
+		}
+		s.batchers[a.chain].Start()
+	default:
+		s.t.Require().FailNow("unknown action", "%s", a)
+	}
+}
+
+func (s *scenario) sendMessage(a action) {
+	source, dest := a.chain, 1-a.chain
+	trigger := interop.RandomInitTrigger(s.rng, s.eventLoggers[source], a.topics, a.dataLen)
+	initIntent, initReceipt := s.eoas[source].SendInitMessage(trigger)
+	// Make sure the supervisor indexed the block with the initiating message.
+	s.sys.Supervisor.WaitForUnsafeHeadToAdvance(s.chains[source].ChainID(), 2)
+	_, execReceipt := s.eoas[dest].SendExecMessage(initIntent, 0)
+	s.messages++
+	s.t.Logger().Info("Sent interop message", "source", s.chains[source].ChainID(), "dest", s.chains[dest].ChainID(),
+		"initBlock", initReceipt.BlockNumber, "execBlock", execReceipt.BlockNumber)
+}
+
+// reorgL1 replaces the most recent blocks of the L1 chain with a new block, like
+// TestL2ReorgAfterL1Reorg.
+func (s *scenario) reorgL1(depth int) {
+	ts := s.sys.TestSequencer.Escape().ControlAPI(s.sys.L1Network.ChainID())
+	cl := s.sys.L1Network.Escape().L1CLNode(match.FirstL1CL)
+	sequence := func(parent common.Hash) {
+		s.t.Require().NoError(ts.New(s.t.Ctx(), seqtypes.BuildOpts{Parent: parent}))
+		s.t.Require().NoError(ts.Next(s.t.Ctx()))
+	}
+
+	s.sys.ControlPlane.FakePoSState(cl.ID(), stack.Stop)
+	for range depth + 1 {
+		sequence(common.Hash{})
+	}
+	tip := s.sys.L1EL.BlockRefByLabel(eth.Unsafe)
+	divergence := s.sys.L1EL.BlockRefByNumber(tip.Number - uint64(depth))
+	sequence(divergence.ParentHash)
+	s.sys.ControlPlane.FakePoSState(cl.ID(), stack.Start)
+	s.sys.L1EL.ReorgTriggered(divergence, 5)
+	s.reorged = true
+}
@@ -0,0 +1,204 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/accounting"
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txinclude"
+)
+
+// budgetReportFile is the name of the spend report in the artifacts directory.
+const budgetReportFile = "budget.json"
+
+// BudgetManager tracks the spend of every sender account, and refills the budget of an account
+// from the faucet of its chain when it runs out, up to a maximum number of refills per account.
+// Without refills, a test ends as soon as any account depletes its budget, which happens early in
+// long runs since the accounts don't spend evenly.
+type BudgetManager struct {
+	// ctx bounds the faucet requests, since the budget interface has no context.
+	ctx          context.Context
+	log          log.Logger
+	refillAmount eth.ETH
+	maxRefills   uint64
+
+	mu       sync.Mutex
+	faucets  map[eth.ChainID]apis.Faucet
+	accounts []*AccountBudget
+}
+
+// NewBudgetManager creates a budget manager that refills an account with refillAmount at most
+// maxRefills times. Refills are disabled if maxRefills is zero.
+func NewBudgetManager(ctx context.Context, logger log.Logger, refillAmount eth.ETH, maxRefills uint64) *BudgetManager {
+	return &BudgetManager{
+		ctx:          ctx,
+		log:          logger,
+		refillAmount: refillAmount,
+		maxRefills:   maxRefills,
+		faucets:      make(map[eth.ChainID]apis.Faucet),
+	}
+}
+
+// SetFaucet sets the faucet that refills the accounts of the chain.
+func (m *BudgetManager) SetFaucet(chainID eth.ChainID, faucet apis.Faucet) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.faucets[chainID] = faucet
+}
+
+func (m *BudgetManager) faucet(chainID eth.ChainID) apis.Faucet {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.faucets[chainID]
+}
+
+// NewAccount creates the budget of an account that was funded with the given amount.
+func (m *BudgetManager) NewAccount(chainID eth.ChainID, addr common.Address, amount eth.ETH) *AccountBudget {
+	b := &AccountBudget{
+		manager: m,
+		chainID: chainID,
+		addr:    addr,
+		budget:  accounting.NewBudget(amount),
+		funded:  amount,
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.accounts = append(m.accounts, b)
+	return b
+}
+
+// AccountBudget is the budget of a single sender account.
+type AccountBudget struct {
+	manager *BudgetManager
+	chainID eth.ChainID
+	addr    common.Address
+
+	mu     sync.Mutex
+	budget *accounting.Budget
+	funded eth.ETH // the initial funding plus the refills
+	// spent is the amount debited minus the amount credited back, e.g. the unused gas of included
+	// transactions.
+	spent   eth.ETH
+	refills uint64
+}
+
+var _ txinclude.Budget = (*AccountBudget)(nil)
+
+// Debit debits the budget, after refilling it if the remaining balance is insufficient.
+func (b *AccountBudget) Debit(amount eth.ETH) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for b.budget.Balance().Lt(amount) && b.refills < b.manager.maxRefills {
+		if err := b.refill(); err != nil {
+			// The debit fails with an overdraft error below, which ends the test.
+			b.manager.log.Warn("Failed to refill account budget", "chain", b.chainID, "addr", b.addr, "err", err)
+			break
+		}
+	}
+	if err := b.budget.Debit(amount); err != nil {
+		return err
+	}
+	b.spent = b.spent.Add(amount)
+	return nil
+}
+
+func (b *AccountBudget) Credit(amount eth.ETH) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.budget.Credit(amount)
+	var underflow bool
+	if b.spent, underflow = b.spent.SubUnderflow(amount); underflow {
+		b.spent = eth.ZeroWei
+	}
+}
+
+// refill requests the refill amount from the faucet of the chain of the account.
+func (b *AccountBudget) refill() error {
+	faucet := b.manager.faucet(b.chainID)
+	if faucet == nil {
+		return fmt.Errorf("no faucet for chain %s", b.chainID)
+	}
+	amount := b.manager.refillAmount
+	if err := faucet.RequestETH(b.manager.ctx, b.addr, amount); err != nil {
+		return fmt.Errorf("refill %s on chain %s: %w", b.addr, b.chainID, err)
+	}
+	b.refills++
+	b.funded = b.funded.Add(amount)
+	b.budget.Credit(amount)
+	budgetRefills.WithLabelValues(b.chainID.String()).Inc()
+	return nil
+}
+
+// AccountSpend is the spend of an account, as reported by BudgetManager.Report.
+type AccountSpend struct {
+	Address   common.Address `json:"address"`
+	Funded    eth.ETH        `json:"funded"`
+	Spent     eth.ETH        `json:"spent"`
+	Remaining eth.ETH        `json:"remaining"`
+	Refills   uint64         `json:"refills"`
+}
+
+// ChainSpend is the spend of the accounts of a chain, as reported by BudgetManager.Report.
+type ChainSpend struct {
+	Funded   eth.ETH        `json:"funded"`
+	Spent    eth.ETH        `json:"spent"`
+	Refills  uint64         `json:"refills"`
+	Accounts []AccountSpend `json:"accounts"`
+}
+
+// Report returns the spend of every chain, with its accounts sorted by spend in descending order.
+func (m *BudgetManager) Report() map[eth.ChainID]*ChainSpend {
+	m.mu.Lock()
+	accounts := append([]*AccountBudget(nil), m.accounts...)
+	m.mu.Unlock()
+
+	out := make(map[eth.ChainID]*ChainSpend)
+	for _, b := range accounts {
+		b.mu.Lock()
+		spend := AccountSpend{
+			Address:   b.addr,
+			Funded:    b.funded,
+			Spent:     b.spent,
+			Remaining: b.budget.Balance(),
+			Refills:   b.refills,
+		}
+		b.mu.Unlock()
+		chain, ok := out[b.chainID]
+		if !ok {
+			chain = &ChainSpend{}
+			out[b.chainID] = chain
+		}
+		chain.Funded = chain.Funded.Add(spend.Funded)
+		chain.Spent = chain.Spent.Add(spend.Spent)
+		chain.Refills += spend.Refills
+		chain.Accounts = append(chain.Accounts, spend)
+	}
+	for _, chain := range out {
+		sort.SliceStable(chain.Accounts, func(i, j int) bool {
+			return chain.Accounts[i].Spent.Gt(chain.Accounts[j].Spent)
+		})
+	}
+	return out
+}
+
+// SaveReport writes the report to budgetReportFile in the given directory.
+func (m *BudgetManager) SaveReport(dir string) error {
+	data, err := json.MarshalIndent(m.Report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode budget report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, budgetReportFile), data, 0644); err != nil {
+		return fmt.Errorf("write budget report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package loadtest
+
+import (
+	"context"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+)
+
+// calibrationMessages is the number of messages relayed to measure the gas of a message.
+const calibrationMessages = 5
+
+// initialTarget returns the initial number of messages per slot from source to dest. It is
+// NAT_INTEROP_LOADTEST_TARGET if set, and is discovered with calibrate otherwise.
+func initialTarget(ctx context.Context, t devtest.T, source, dest *L2) uint64 {
+	if _, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_TARGET"); exists {
+		return readTarget(t, "NAT_INTEROP_LOADTEST_TARGET", 0)
+	}
+	return calibrate(ctx, t, source, dest)
+}
+
+// calibrate relays calibrationMessages messages from source to dest one at a time, and measures
+// the gas that a message uses on each chain. It returns the number of messages per slot that fill
+// the gas target of whichever chain fills up first, which the schedulers then refine.
+func calibrate(ctx context.Context, t devtest.T, source, dest *L2) uint64 {
+	sourceBefore, destBefore := source.gasUsed.Load(), dest.gasUsed.Load()
+	for range calibrationMessages {
+		t.Require().NoError(relayMessage(ctx, t, source, dest), "relay calibration message")
+	}
+	sourceGas := (source.gasUsed.Load() - sourceBefore) / calibrationMessages
+	destGas := (dest.gasUsed.Load() - destBefore) / calibrationMessages
+	target := min(source.GasTarget()/sourceGas, dest.GasTarget()/destGas)
+	t.Logger().Info("Calibrated initial target", "messagesPerSlot", target,
+		"sourceGasTarget", source.GasTarget(), "sourceGasPerMessage", sourceGas,
+		"destGasTarget", dest.GasTarget(), "destGasPerMessage", destGas, "blockTime", dest.BlockTime())
+	return max(target, 1)
+}
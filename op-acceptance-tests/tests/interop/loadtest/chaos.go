@@ -0,0 +1,182 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+)
+
+// Fault is a disruption of a component of the network that chaos injection causes during a load
+// test.
+type Fault string
+
+const (
+	// FaultSequencer restarts the sequencing CL node of a chain.
+	FaultSequencer Fault = "sequencer"
+	// FaultSupervisor restarts the supervisor, and reconnects the CL nodes to it.
+	FaultSupervisor Fault = "supervisor"
+	// FaultBatcher pauses the batcher of a chain.
+	FaultBatcher Fault = "batcher"
+)
+
+// ParseFaults parses a comma-separated list of faults.
+func ParseFaults(s string) ([]Fault, error) {
+	var faults []Fault
+	for _, name := range strings.Split(s, ",") {
+		switch fault := Fault(strings.TrimSpace(name)); fault {
+		case FaultSequencer, FaultSupervisor, FaultBatcher:
+			faults = append(faults, fault)
+		default:
+			return nil, fmt.Errorf("unknown fault %q (expected sequencer, supervisor or batcher)", name)
+		}
+	}
+	return faults, nil
+}
+
+// recoveryFraction is the fraction of the throughput before a fault that the throughput must
+// return to after it.
+const recoveryFraction = 0.5
+
+type chaosConfig struct {
+	// interval is the time between the start of consecutive faults.
+	interval time.Duration
+	// downtime is the time a component is stopped or paused for.
+	downtime time.Duration
+	// recovery is the time after a fault within which the throughput must recover.
+	recovery time.Duration
+}
+
+// Chaos injects faults into the sysgo components of the network while a load test runs, and
+// asserts that the throughput of executed messages recovers after every fault. The faults rotate
+// over the configured kinds, and over the chains for the faults of a single chain.
+type Chaos struct {
+	t      devtest.T
+	sys    *presets.SimpleInterop
+	l2s    []*L2
+	cls    []*dsl.L2CLNode
+	faults []Fault
+	cfg    chaosConfig
+}
+
+// NewChaos creates chaos injection into the given network, which runs the given chains.
+func NewChaos(t devtest.T, sys *presets.SimpleInterop, l2s []*L2, faults []Fault, interval, downtime, recovery time.Duration) *Chaos {
+	return &Chaos{
+		t:      t,
+		sys:    sys,
+		l2s:    l2s,
+		cls:    []*dsl.L2CLNode{sys.L2CLA, sys.L2CLB},
+		faults: faults,
+		cfg: chaosConfig{
+			interval: interval,
+			downtime: downtime,
+			recovery: recovery,
+		},
+	}
+}
+
+// Start injects a fault every interval until the context is canceled.
+func (c *Chaos) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			// Measure the throughput before the fault over the whole interval.
+			before := c.executed()
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(c.cfg.interval):
+			}
+			baseline := float64(c.executed()-before) / c.cfg.interval.Seconds()
+			fault := c.faults[i%len(c.faults)]
+			// Faults of a single chain alternate between the chains.
+			chain := (i / len(c.faults)) % len(c.cls)
+			start := time.Now()
+			c.inject(fault, chain)
+			c.t.Logger().Info("Injected fault", "fault", fault, "chain", c.cls[chain].ChainID(),
+				"downtime", time.Since(start).Round(time.Millisecond))
+			c.awaitRecovery(ctx, fault, baseline)
+		}
+	}()
+}
+
+// inject stops or pauses a component for the downtime, and starts it again. The component is
+// started again even if the test ends during the fault, so the controls do not use the test
+// context.
+func (c *Chaos) inject(fault Fault, chain int) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	control := c.sys.ControlPlane
+	switch fault {
+	case FaultSequencer:
+		id := c.cls[chain].Escape().ID()
+		control.L2CLNodeState(id, stack.Stop)
+		time.Sleep(c.cfg.downtime)
+		control.L2CLNodeState(id, stack.Start)
+	case FaultSupervisor:
+		supervisor := c.sys.Supervisor.Escape()
+		control.SupervisorState(supervisor.ID(), stack.Stop)
+		time.Sleep(c.cfg.downtime)
+		control.SupervisorState(supervisor.ID(), stack.Start)
+		// The supervisor does not reconnect to the CL nodes by itself.
+		for _, cl := range c.cls {
+			endpoint, secret := cl.Escape().InteropRPC()
+			c.t.Require().NoError(supervisor.AdminAPI().AddL2RPC(ctx, endpoint, secret))
+		}
+	case FaultBatcher:
+		batcher := []*dsl.L2Batcher{c.sys.L2BatcherA, c.sys.L2BatcherB}[chain].Escape().ActivityAPI()
+		c.t.Require().NoError(batcher.StopBatcher(ctx))
+		time.Sleep(c.cfg.downtime)
+		c.t.Require().NoError(batcher.StartBatcher(ctx))
+	}
+}
+
+// awaitRecovery waits until the throughput over the last slots reaches recoveryFraction of the
+// baseline throughput, and fails the test if it does not within the recovery bound. Any executed
+// message counts as recovery if nothing was executed before the fault.
+func (c *Chaos) awaitRecovery(ctx context.Context, fault Fault, baseline float64) {
+	const windowSlots = 3
+	slot := c.l2s[0].BlockTime()
+	window := make([]uint64, 0, windowSlots+1)
+	window = append(window, c.executed())
+	start := time.Now()
+	deadline := time.After(c.cfg.recovery)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			c.t.Errorf("throughput did not recover to %.0f%% of %.2f msg/s within %s after %s fault",
+				recoveryFraction*100, baseline, c.cfg.recovery, fault)
+			return
+		case <-time.After(slot):
+		}
+		window = append(window, c.executed())
+		if len(window) > windowSlots+1 {
+			window = window[1:]
+		}
+		executed := window[len(window)-1] - window[0]
+		throughput := float64(executed) / (time.Duration(len(window)-1) * slot).Seconds()
+		if (baseline == 0 && executed > 0) || (baseline > 0 && throughput >= recoveryFraction*baseline) {
+			c.t.Logger().Info("Throughput recovered", "fault", fault, "after", time.Since(start).Round(time.Second),
+				"baseline", baseline, "throughput", throughput)
+			return
+		}
+	}
+}
+
+// executed returns the number of messages executed on all chains so far.
+func (c *Chaos) executed() uint64 {
+	var total uint64
+	for _, l2 := range c.l2s {
+		total += l2.executed.Load()
+	}
+	return total
+}
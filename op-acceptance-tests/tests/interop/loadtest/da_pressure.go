@@ -0,0 +1,143 @@
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-service/accounting"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txplan"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// daSink receives the transactions of the DA pressure. It has no code, so the transactions only
+// cost the intrinsic gas of their calldata.
+var daSink = common.HexToAddress("0xda00000000000000000000000000000000000000")
+
+// DAPressure fills the batches of the chains with incompressible calldata, so that their batchers
+// submit a target amount of data to L1 per L1 block, as blobs or as calldata depending on the
+// batcher configuration.
+type DAPressure struct {
+	l2s []*L2
+	// bytesPerSlot is the amount of calldata sent per L2 slot on every chain.
+	bytesPerSlot uint64
+	txSize       uint64
+}
+
+// NewDAPressure creates DA pressure that targets the given number of full blobs of batch data per
+// L1 block, spread evenly over the chains, in transactions of txSize bytes of calldata.
+func NewDAPressure(l2s []*L2, blobsPerL1Block uint64, l1BlockTime time.Duration, txSize uint64) *DAPressure {
+	bytesPerL1Block := blobsPerL1Block * eth.MaxBlobDataSize
+	slotsPerL1Block := max(uint64(l1BlockTime/l2s[0].BlockTime()), 1)
+	return &DAPressure{
+		l2s:          l2s,
+		bytesPerSlot: bytesPerL1Block / slotsPerL1Block / uint64(len(l2s)),
+		txSize:       txSize,
+	}
+}
+
+// Start sends the calldata of every slot on every chain until the context is canceled. The budget
+// of the senders is shared with the interop messages, so an overdraft ends the pressure.
+func (d *DAPressure) Start(ctx context.Context, t devtest.T, wg *sync.WaitGroup) {
+	txsPerSlot := max((d.bytesPerSlot+d.txSize-1)/d.txSize, 1)
+	t.Logger().Info("Starting DA pressure", "bytesPerSlot", d.bytesPerSlot, "txsPerSlot", txsPerSlot, "txSize", d.txSize)
+	for _, l2 := range d.l2s {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(l2.BlockTime())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+				}
+				for range txsPerSlot {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						d.send(ctx, t, l2)
+					}()
+				}
+			}
+		}()
+	}
+}
+
+func (d *DAPressure) send(ctx context.Context, t devtest.T, l2 *L2) {
+	data := make([]byte, d.txSize)
+	_, err := rand.Read(data)
+	t.Require().NoError(err)
+	_, err = l2.Include(ctx, t, txplan.WithTo(&daSink), txplan.WithData(data))
+	if err == nil {
+		daPressureBytes.WithLabelValues(l2.EL.ChainID().String()).Add(float64(len(data)))
+		return
+	}
+	if isBenignCancellationError(err) {
+		return
+	}
+	var overdraft *accounting.OverdraftError
+	if errors.As(err, &overdraft) {
+		t.Logger().Warn("DA pressure ran out of budget", "chain", l2.EL.ChainID(), "err", err)
+		return
+	}
+	t.Logger().Warn("Failed to include DA pressure transaction", "chain", l2.EL.ChainID(), "err", err)
+}
+
+// safeHeadLags is the maximum lag of the cross-safe head behind the unsafe head of every chain.
+type safeHeadLags struct {
+	mu  sync.Mutex
+	max map[eth.ChainID]uint64
+}
+
+func (s *safeHeadLags) observe(chainID eth.ChainID, lag uint64) {
+	safeHeadLag.WithLabelValues(chainID.String()).Set(float64(lag))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.max[chainID] = max(s.max[chainID], lag)
+}
+
+// Max returns the maximum lag of every chain so far, in blocks.
+func (s *safeHeadLags) Max() map[eth.ChainID]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.max)
+}
+
+// trackSafeHeadLag observes the number of blocks between the unsafe and the cross-safe head of
+// every chain, every L2 slot until the context is canceled.
+func trackSafeHeadLag(ctx context.Context, t devtest.T, wg *sync.WaitGroup, l2s []*L2) *safeHeadLags {
+	lags := &safeHeadLags{max: make(map[eth.ChainID]uint64, len(l2s))}
+	for _, l2 := range l2s {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client := l2.EL.Escape().EthClient()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(l2.BlockTime()):
+				}
+				unsafe, err := client.BlockRefByLabel(ctx, eth.Unsafe)
+				if isBenignCancellationError(err) {
+					return
+				}
+				t.Require().NoError(err)
+				safe, err := client.BlockRefByLabel(ctx, eth.Safe)
+				if isBenignCancellationError(err) {
+					return
+				}
+				t.Require().NoError(err)
+				lags.observe(l2.EL.ChainID(), unsafe.Number-min(safe.Number, unsafe.Number))
+			}
+		}()
+	}
+	return lags
+}
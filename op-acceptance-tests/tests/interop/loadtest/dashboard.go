@@ -0,0 +1,226 @@
+package loadtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// dashboardFile is the name of the Grafana dashboard in the artifacts directory.
+const dashboardFile = "grafana_dashboard.json"
+
+// Dashboard is a Grafana dashboard, in the format of the Grafana dashboard import.
+// Only the fields that the load test dashboards use are included.
+type Dashboard struct {
+	Inputs        []DashboardInput `json:"__inputs"`
+	Title         string           `json:"title"`
+	UID           string           `json:"uid"`
+	Tags          []string         `json:"tags"`
+	Time          DashboardTime    `json:"time"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Panels        []DashboardPanel `json:"panels"`
+}
+
+// DashboardInput is a data source that is selected when the dashboard is imported.
+type DashboardInput struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	PluginID string `json:"pluginId"`
+}
+
+type DashboardTime struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type DashboardPanel struct {
+	ID          int                   `json:"id"`
+	Title       string                `json:"title"`
+	Type        string                `json:"type"`
+	Datasource  DashboardDatasource   `json:"datasource"`
+	GridPos     DashboardGridPos      `json:"gridPos"`
+	FieldConfig DashboardFieldConfig  `json:"fieldConfig"`
+	Targets     []DashboardPanelQuery `json:"targets"`
+}
+
+type DashboardDatasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type DashboardGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type DashboardFieldConfig struct {
+	Defaults DashboardFieldDefaults `json:"defaults"`
+}
+
+type DashboardFieldDefaults struct {
+	Unit string `json:"unit,omitempty"`
+}
+
+type DashboardPanelQuery struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+// NewDashboard creates a dashboard of the load test metrics of a single run, as pushed to a
+// Prometheus push gateway by WithPushGateway, over the time range of the run.
+func NewDashboard(test, run string, from, to time.Time) *Dashboard {
+	// The push gateway attaches the grouping labels to every metric.
+	selector := fmt.Sprintf(`test=%q,run=%q`, test, run)
+	metric := func(name string) string {
+		return subsystemName + "_" + name
+	}
+	panels := []struct {
+		title   string
+		unit    string
+		queries []DashboardPanelQuery
+	}{
+		{
+			title: "Message Throughput",
+			unit:  "reqps",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`sum(rate(%s_count{%s,stage="e2e"}[1m]))`, metric(messageLatencyName), selector),
+				LegendFormat: "delivered messages",
+			}, {
+				Expr:         fmt.Sprintf(`sum by (source, destination) (rate(%s{%s}[1m]))`, metric(executedMessagesName), selector),
+				LegendFormat: "executed {{source}}->{{destination}}",
+			}},
+		},
+		{
+			title: "Message Latency Percentiles",
+			unit:  "s",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`histogram_quantile(0.5, sum by (le, stage) (rate(%s_bucket{%s}[1m])))`, metric(messageLatencyName), selector),
+				LegendFormat: "p50 {{stage}}",
+			}, {
+				Expr:         fmt.Sprintf(`histogram_quantile(0.9, sum by (le, stage) (rate(%s_bucket{%s}[1m])))`, metric(messageLatencyName), selector),
+				LegendFormat: "p90 {{stage}}",
+			}, {
+				Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (le, stage) (rate(%s_bucket{%s}[1m])))`, metric(messageLatencyName), selector),
+				LegendFormat: "p99 {{stage}}",
+			}},
+		},
+		{
+			title: "Propagation Latency Percentiles",
+			unit:  "s",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`histogram_quantile(0.5, sum by (le) (rate(%s_bucket{%s}[1m])))`, metric(propagationLatencyName), selector),
+				LegendFormat: "p50",
+			}, {
+				Expr:         fmt.Sprintf(`histogram_quantile(0.9, sum by (le) (rate(%s_bucket{%s}[1m])))`, metric(propagationLatencyName), selector),
+				LegendFormat: "p90",
+			}, {
+				Expr:         fmt.Sprintf(`histogram_quantile(0.99, sum by (le) (rate(%s_bucket{%s}[1m])))`, metric(propagationLatencyName), selector),
+				LegendFormat: "p99",
+			}},
+		},
+		{
+			title: "In-Flight Messages and Target",
+			unit:  "short",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`%s{%s}`, metric(inFlightMessagesName), selector),
+				LegendFormat: "in-flight",
+			}, {
+				Expr:         fmt.Sprintf(`%s{%s}`, metric(targetMessagesPerBlockName), selector),
+				LegendFormat: "target per block",
+			}},
+		},
+		{
+			title: "Gas Used",
+			unit:  "short",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`sum by (chain) (rate(%s{%s}[1m]))`, metric(gasUsedName), selector),
+				LegendFormat: "chain {{chain}}",
+			}},
+		},
+		{
+			title: "Transaction Submission Error Rate",
+			unit:  "percentunit",
+			queries: []DashboardPanelQuery{{
+				Expr: fmt.Sprintf(`sum by (chain) (rate(%[1]s{%[2]s,status!="success"}[1m])) / sum by (chain) (rate(%[1]s{%[2]s}[1m]))`,
+					metric(txSubmissionStatusCountName), selector),
+				LegendFormat: "{{chain}}",
+			}},
+		},
+		{
+			title: "Transaction Submission Errors by Status",
+			unit:  "reqps",
+			queries: []DashboardPanelQuery{{
+				Expr:         fmt.Sprintf(`sum by (chain, status) (rate(%s{%s,status!="success"}[1m]))`, metric(txSubmissionStatusCountName), selector),
+				LegendFormat: "{{chain}}: {{status}}",
+			}},
+		},
+	}
+
+	datasource := DashboardDatasource{Type: "prometheus", UID: "${DS_PROMETHEUS}"}
+	d := &Dashboard{
+		Inputs: []DashboardInput{{
+			Name:     "DS_PROMETHEUS",
+			Label:    "Prometheus",
+			Type:     "datasource",
+			PluginID: "prometheus",
+		}},
+		Title: fmt.Sprintf("Interop load test %s (%s)", test, run),
+		UID:   dashboardUID(test, run),
+		Tags:  []string{"interop", "loadtest"},
+		Time: DashboardTime{
+			From: from.UTC().Format(time.RFC3339),
+			To:   to.UTC().Format(time.RFC3339),
+		},
+		SchemaVersion: 39,
+	}
+	const width, height = 12, 8
+	for i, panel := range panels {
+		queries := make([]DashboardPanelQuery, len(panel.queries))
+		for j, q := range panel.queries {
+			q.RefID = string(rune('A' + j))
+			queries[j] = q
+		}
+		d.Panels = append(d.Panels, DashboardPanel{
+			ID:          i + 1,
+			Title:       panel.title,
+			Type:        "timeseries",
+			Datasource:  datasource,
+			GridPos:     DashboardGridPos{H: height, W: width, X: (i % 2) * width, Y: (i / 2) * height},
+			FieldConfig: DashboardFieldConfig{Defaults: DashboardFieldDefaults{Unit: panel.unit}},
+			Targets:     queries,
+		})
+	}
+	return d
+}
+
+// dashboardUID returns a UID that is unique per run, and valid in Grafana: at most 40 characters
+// of letters, digits, '-' and '_'.
+func dashboardUID(test, run string) string {
+	uid := sanitizePrometheusLabel(test) + "-" + run
+	if len(uid) > 40 {
+		uid = uid[len(uid)-40:]
+	}
+	return uid
+}
+
+// Save writes the dashboard to dashboardFile in the given directory.
+func (d *Dashboard) Save(dir string) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false) // Keep the PromQL and legends readable.
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(d); err != nil {
+		return fmt.Errorf("encode dashboard: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, dashboardFile), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write dashboard: %w", err)
+	}
+	return nil
+}
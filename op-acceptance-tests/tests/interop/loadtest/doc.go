@@ -3,26 +3,92 @@
 //
 // Configure global test behavior with the following environment variables:
 //
-//   - NAT_INTEROP_LOADTEST_TARGET (default: 100): the initial number of messages that should be
-//     passed per L2 slot in each test.
+//   - NAT_INTEROP_LOADTEST_TARGET (optional): the initial number of messages that should be
+//     passed per L2 slot in each test. If unset, each test first relays a few calibration messages
+//     to measure the gas of a message on the source and destination chains, and starts at the
+//     number of messages that fills the gas target of a block (the genesis gas limit over the
+//     EIP-1559 elasticity) of whichever chain fills up first.
 //   - NAT_INTEROP_LOADTEST_BUDGET (default: 1): the max amount of ETH to spend per L2 in each
 //     test.
+//   - NAT_INTEROP_LOADTEST_REFILLS (default: 0): the number of times the budget of a sender
+//     account may be refilled from the faucet of its chain when it runs out, by
+//     NAT_INTEROP_LOADTEST_BUDGET each time. Refills keep long runs going when the accounts don't
+//     spend evenly.
+//   - NAT_INTEROP_LOADTEST_FAUCET_ENDPOINT (optional): the URL of an op-faucet to refill from,
+//     instead of the faucets of the network. The default faucet of every chain is used, served at
+//     <endpoint>/chain/<chain ID>.
+//   - NAT_INTEROP_LOADTEST_WORKLOAD (default: minimal): the destination-side work of each
+//     executing message, one of minimal (only validate the message), erc20 (mint WETH and
+//     transfer it to a new recipient) or storage (write 10 new storage slots).
+//   - NAT_INTEROP_LOADTEST_METRICS_ENDPOINT (optional): the URL of a Prometheus push gateway. The
+//     client-side metrics (e.g., in-flight messages, message latencies and gas used) are pushed to
+//     it every L2 slot during the test, grouped by test name and run timestamp.
+//   - NAT_INTEROP_LOADTEST_RPC_POOL (default: false): spread the transactions and receipt queries
+//     of each chain across all of its EL nodes, instead of its public RPC. Requests an endpoint
+//     fails to answer are retried on the next one, and endpoints that lag more than
+//     NAT_INTEROP_LOADTEST_RPC_MAX_SKEW (default: 2) blocks behind the highest endpoint of the
+//     chain, or that fail the health check every L2 slot, leave the rotation until they catch up.
+//     The requests, errors and removals of every endpoint are saved to endpoints.json in the
+//     artifacts directory.
+//   - NAT_INTEROP_LOADTEST_TOPOLOGY (default: ring): the chains that send messages to each other
+//     in TestFanOut, one of ring, star or mesh.
+//   - NAT_INTEROP_LOADTEST_RECORD (optional): the file to record the initiating messages of the
+//     test to, as JSON: the time, chains, sender account and payload size of every message. Replay
+//     the recording with TestReplay.
+//   - NAT_INTEROP_LOADTEST_CHAOS (optional, sysgo only): a comma-separated list of faults to inject
+//     during the test, one or more of sequencer (restart the CL node of a chain), supervisor
+//     (restart the supervisor) and batcher (pause the batcher of a chain). The faults take turns
+//     every NAT_INTEROP_LOADTEST_CHAOS_INTERVAL (default: 1m), and stop or pause the component for
+//     NAT_INTEROP_LOADTEST_CHAOS_DOWNTIME (default: 10s). The test fails if the throughput of
+//     executed messages does not recover to half of its rate before the fault within
+//     NAT_INTEROP_LOADTEST_CHAOS_RECOVERY (default: 1m) after it.
+//   - NAT_INTEROP_LOADTEST_RECONCILE (default: true): at the end of the test, reconcile the
+//     executing messages that were confirmed on the client side with the executing messages that
+//     the supervisor indexed over the same blocks, by chain pair. A chain pair fails the test if
+//     the supervisor misses a confirmed message, or indexed more messages than the client sent,
+//     e.g. because of other interop traffic on the network. Disable it on shared networks.
+//   - NAT_INTEROP_LOADTEST_MIN_TPS (optional): the floor of the maximum sustained throughput, in
+//     executed messages per second over 10 consecutive slots. The test fails if the throughput
+//     stays below it.
 //
 // Individual tests may define their own environment variables of the form NAT_<test>_<name>. See
 // their go doc comments for details.
 //
-// Budget depletion and the go test timeout can end any test. They are interpreted as failures
-// unless noted otherwise.
+// Budget depletion, after any refills, and the go test timeout can end any test. They are
+// interpreted as failures unless noted otherwise.
 //
 // Each test increases the message throughput until some threshold is reached (e.g., the gas
 // target). The throughput is decreased if the threshold is exceeded or if errors are encountered
-// (e.g., transaction inclusion failures).
+// (e.g., transaction inclusion failures), except in TestRamp, which increases the throughput on a
+// fixed schedule and stops at the first inclusion failure.
 //
 // Visualizations for client-side metrics are stored in an artifacts directory, categorized by
-// test name and timestamp: <metric-name>_<YYYYMMDD-HHMMSS>.png.
+// test name and timestamp: <metric-name>_<YYYYMMDD-HHMMSS>.png. They include the distribution of
+// the propagation latency of the messages, the time between the blocks that include the initiating
+// and the executing message, as a histogram and by percentile, and for TestDAPressure, the DA
+// pressure and the lag of the cross-safe heads. The directory also contains the
+// spend of every sender account and chain, budget.json, and a summary of the run, as
+// summary.json and summary.csv: the maximum sustained throughput, the failed messages and
+// submissions by error category, the ETH spent per chain, and the reconciliation of the executed
+// messages with the supervisor. The metrics are also pushed live to
+// a Prometheus push gateway if NAT_INTEROP_LOADTEST_METRICS_ENDPOINT is set, in which case the
+// artifacts directory also contains a Grafana dashboard of the run, grafana_dashboard.json, to
+// import into a Grafana instance that uses the push gateway's Prometheus as data source.
 //
 // Examples:
 //
 //	NAT_INTEROP_LOADTEST_BUDGET=2 go test -v -run Burst
 //	NAT_INTEROP_LOADTEST_TARGET=500 go test -v -timeout 5m -run Steady
+//	NAT_INTEROP_LOADTEST_REFILLS=10 NAT_STEADY_TIMEOUT=2h go test -v -timeout 3h -run Steady
+//	NAT_INTEROP_LOADTEST_WORKLOAD=erc20 go test -v -run Steady
+//	NAT_RAMP_START=50 NAT_RAMP_STEP=25 NAT_RAMP_STEP_SLOTS=10 go test -v -timeout 10m -run Ramp
+//	NAT_BIDIRECTIONAL_TARGET_AB=200 NAT_BIDIRECTIONAL_TARGET_BA=50 go test -v -run Bidirectional
+//	NAT_INTEROP_LOADTEST_TOPOLOGY=mesh go test -v -run FanOut
+//	NAT_INTEROP_LOADTEST_RECORD=steady.json go test -v -run Steady
+//	NAT_REPLAY_FILE=steady.json go test -v -timeout 10m -run Replay
+//	NAT_DAPRESSURE_BLOBS=6 go test -v -timeout 10m -run DAPressure
+//	NAT_TOKENBRIDGE_TIMEOUT=5m go test -v -timeout 10m -run TokenBridge
+//	NAT_INTEROP_LOADTEST_RPC_POOL=true NAT_INTEROP_LOADTEST_RPC_MAX_SKEW=5 go test -v -run Steady
+//	NAT_INTEROP_LOADTEST_MIN_TPS=20 go test -v -timeout 5m -run Steady
+//	NAT_INTEROP_LOADTEST_CHAOS=sequencer,supervisor,batcher NAT_STEADY_TIMEOUT=10m go test -v -timeout 15m -run Steady
 package loadtest
@@ -0,0 +1,268 @@
+package loadtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txinclude"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const endpointReportFile = "endpoints.json"
+
+// EndpointClient is an EL RPC endpoint of a chain.
+type EndpointClient interface {
+	txinclude.EL
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
+}
+
+type poolEndpoint struct {
+	name   string
+	client EndpointClient
+
+	// The fields below are guarded by the mutex of the pool.
+	healthy  bool
+	head     uint64
+	requests uint64
+	// rejected requests were answered with an error, e.g., an invalid transaction. failed requests
+	// were not answered, and were retried on another endpoint.
+	rejected uint64
+	failed   uint64
+	removals uint64
+}
+
+// EndpointPool spreads the transactions and receipt queries of a chain across multiple EL RPC
+// endpoints, e.g., the replicas of a sysext network. Requests that an endpoint fails to answer are
+// retried on the next endpoint. Endpoints that lag behind the highest block of the pool by more
+// than the max skew, or that fail the health check, are removed from the rotation until they
+// catch up.
+type EndpointPool struct {
+	log     log.Logger
+	chainID eth.ChainID
+	maxSkew uint64
+
+	endpoints []*poolEndpoint
+	next      atomic.Uint64
+
+	mu sync.Mutex
+}
+
+var _ txinclude.EL = (*EndpointPool)(nil)
+
+// NewEndpointPool creates an empty pool for the chain. Endpoints can be added until Start.
+func NewEndpointPool(logger log.Logger, chainID eth.ChainID, maxSkew uint64) *EndpointPool {
+	return &EndpointPool{
+		log:     logger.New("chain", chainID),
+		chainID: chainID,
+		maxSkew: maxSkew,
+	}
+}
+
+// AddEndpoint adds an endpoint to the rotation.
+func (p *EndpointPool) AddEndpoint(name string, client EndpointClient) {
+	p.endpoints = append(p.endpoints, &poolEndpoint{name: name, client: client, healthy: true})
+}
+
+// Start checks the heads of the endpoints every interval until the context is done.
+func (p *EndpointPool) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.checkHealth(ctx, interval)
+			}
+		}
+	}()
+}
+
+func (p *EndpointPool) checkHealth(ctx context.Context, timeout time.Duration) {
+	heads := make([]uint64, len(p.endpoints))
+	errs := make([]error, len(p.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			info, err := e.client.InfoByLabel(ctx, eth.Unsafe)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			heads[i] = info.NumberU64()
+		}()
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return // the health of the endpoints is unknown
+	}
+
+	var highest uint64
+	for i, head := range heads {
+		if errs[i] == nil {
+			highest = max(highest, head)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, e := range p.endpoints {
+		skew := highest - min(heads[i], highest)
+		healthy := errs[i] == nil && skew <= p.maxSkew
+		if errs[i] == nil {
+			e.head = heads[i]
+			endpointHeadSkew.WithLabelValues(p.chainID.String(), e.name).Set(float64(skew))
+		}
+		if e.healthy && !healthy {
+			e.removals++
+			p.log.Warn("Removed endpoint from the rotation", "endpoint", e.name, "head", e.head, "highest", highest, "err", errs[i])
+		} else if !e.healthy && healthy {
+			p.log.Info("Restored endpoint to the rotation", "endpoint", e.name, "head", e.head)
+		}
+		e.healthy = healthy
+	}
+}
+
+// rotation returns the endpoints to try a request on, starting at the next healthy endpoint. All
+// endpoints are tried if none of them is healthy.
+func (p *EndpointPool) rotation() []*poolEndpoint {
+	start := p.next.Add(1)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var healthy, all []*poolEndpoint
+	for i := range p.endpoints {
+		e := p.endpoints[(start+uint64(i))%uint64(len(p.endpoints))]
+		all = append(all, e)
+		if e.healthy {
+			healthy = append(healthy, e)
+		}
+	}
+	if len(healthy) == 0 {
+		return all
+	}
+	return healthy
+}
+
+// do runs the request on the endpoints of the rotation until an endpoint answers it.
+func (p *EndpointPool) do(ctx context.Context, request func(EndpointClient) error) error {
+	var err error
+	for _, e := range p.rotation() {
+		err = request(e.client)
+		answered := !isEndpointFailure(ctx, err)
+		p.record(e, err, answered)
+		if answered {
+			return err
+		}
+	}
+	return err
+}
+
+func (p *EndpointPool) record(e *poolEndpoint, err error, answered bool) {
+	status := "success"
+	p.mu.Lock()
+	e.requests++
+	if !answered {
+		e.failed++
+		status = "failed"
+	} else if err != nil && !errors.Is(err, ethereum.NotFound) {
+		e.rejected++
+		status = "rejected"
+	}
+	p.mu.Unlock()
+	endpointRequests.WithLabelValues(p.chainID.String(), e.name, status).Inc()
+}
+
+// isEndpointFailure returns whether the endpoint failed to answer the request, as opposed to
+// answering it with an error.
+func isEndpointFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+func (p *EndpointPool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return p.do(ctx, func(c EndpointClient) error {
+		return c.SendTransaction(ctx, tx)
+	})
+}
+
+func (p *EndpointPool) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	err := p.do(ctx, func(c EndpointClient) error {
+		var err error
+		receipt, err = c.TransactionReceipt(ctx, hash)
+		return err
+	})
+	return receipt, err
+}
+
+// EndpointReport is the usage of an endpoint of a pool.
+type EndpointReport struct {
+	Name     string `json:"name"`
+	Requests uint64 `json:"requests"`
+	Rejected uint64 `json:"rejected"`
+	Failed   uint64 `json:"failed"`
+	// ErrorRate is the ratio of the requests that the endpoint failed to answer.
+	ErrorRate float64 `json:"error_rate"`
+	// Removals is the number of times the endpoint was removed from the rotation.
+	Removals uint64 `json:"removals"`
+	Healthy  bool   `json:"healthy"`
+}
+
+// Report returns the usage of every endpoint of the pool.
+func (p *EndpointPool) Report() []EndpointReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]EndpointReport, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		r := EndpointReport{
+			Name:     e.name,
+			Requests: e.requests,
+			Rejected: e.rejected,
+			Failed:   e.failed,
+			Removals: e.removals,
+			Healthy:  e.healthy,
+		}
+		if e.requests > 0 {
+			r.ErrorRate = float64(e.failed) / float64(e.requests)
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// SaveEndpointReports writes the reports of the pools to endpointReportFile in the given directory.
+func SaveEndpointReports(dir string, pools []*EndpointPool) error {
+	reports := make(map[eth.ChainID][]EndpointReport, len(pools))
+	for _, p := range pools {
+		reports[p.chainID] = p.Report()
+	}
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode endpoint reports: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, endpointReportFile), data, 0644); err != nil {
+		return fmt.Errorf("write endpoint reports: %w", err)
+	}
+	return nil
+}
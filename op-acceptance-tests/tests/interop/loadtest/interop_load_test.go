@@ -3,12 +3,15 @@ package loadtest
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,13 +21,17 @@ import (
 	"github.com/ethereum-optimism/optimism/op-devstack/dsl"
 	"github.com/ethereum-optimism/optimism/op-devstack/presets"
 	"github.com/ethereum-optimism/optimism/op-service/accounting"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/optimism/op-service/log/logfilter"
 	"github.com/ethereum-optimism/optimism/op-service/plan"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/txinclude"
 	"github.com/ethereum-optimism/optimism/op-service/txintent"
 	"github.com/ethereum-optimism/optimism/op-service/txplan"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Override this with the env var NAT_STEADY_TIMEOUT.
@@ -120,6 +127,533 @@ func TestBurst(gt *testing.T) {
 	}
 }
 
+// TestRamp increases the message throughput on a fixed schedule until transactions fail to be
+// included, to find the throughput at which interop messages saturate the chains. Unlike TestSteady
+// and TestBurst, the throughput does not adapt to the results, so runs are comparable with each
+// other.
+//
+// The throughput starts at NAT_RAMP_START messages per slot (default: the initial target, see
+// NAT_INTEROP_LOADTEST_TARGET), and increases by NAT_RAMP_STEP (default: 10) every
+// NAT_RAMP_STEP_SLOTS slots (default: 5), up to NAT_RAMP_MAX if set. The schedule stops at the
+// first inclusion failure. Once the in-flight messages are done, the test reports the earliest slot
+// in which a failed message was sent and the throughput of that slot. The test exits successfully
+// after that, or after the global go test deadline or the timeout specified by the
+// NAT_RAMP_TIMEOUT environment variable elapses, whichever comes first.
+func TestRamp(gt *testing.T) {
+	t := setupT(gt)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_RAMP_TIMEOUT")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	source, dest := setupL2s(t, ctx, &wg)
+
+	ramp := NewRamp(
+		readTarget(t, "NAT_RAMP_START", initialTarget(ctx, t, source, dest)),
+		readTarget(t, "NAT_RAMP_STEP", 10),
+		readTarget(t, "NAT_RAMP_STEP_SLOTS", 5),
+		readTarget(t, "NAT_RAMP_MAX", 0),
+		dest.BlockTime(),
+	)
+	rampCtx, stopRamp := context.WithCancel(ctx)
+	defer stopRamp()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ramp.Start(rampCtx)
+	}()
+
+	var sat saturation
+	var inFlight sync.WaitGroup
+	for slot := range ramp.Ready() {
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			err := relayMessage(ctx, t, source, dest)
+			if err == nil || isBenignCancellationError(err) {
+				return
+			}
+			var overdraft *accounting.OverdraftError
+			if errors.As(err, &overdraft) {
+				cancel()
+				t.Require().NoError(err)
+			}
+			sat.record(slot)
+			stopRamp()
+		}()
+	}
+	inFlight.Wait()
+	endTest(gt, cancel, sat.report(ramp))
+}
+
+// saturation records the earliest slot of TestRamp in which a message that failed to be included
+// was sent. Messages complete out of order, so a later failure may be from an earlier slot.
+type saturation struct {
+	mu     sync.Mutex
+	failed bool
+	slot   uint64
+	count  uint64
+}
+
+func (s *saturation) record(slot uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	if !s.failed || slot < s.slot {
+		s.failed = true
+		s.slot = slot
+	}
+}
+
+func (s *saturation) report(ramp *Ramp) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failed {
+		return "no inclusion failures before the ramp ended"
+	}
+	return fmt.Sprintf("inclusion failures began at slot %d (%d messages/slot), %d failed messages",
+		s.slot, ramp.RPS(s.slot), s.count)
+}
+
+// TestBidirectional relays messages from A to B and from B to A at the same time, each direction
+// at its own fixed target, to measure whether load in one direction degrades the other through
+// shared resources such as the supervisor and the sequencers.
+//
+// The test runs three phases of equal length: A to B only, B to A only, and both directions
+// concurrently. Each phase takes a quarter of the test timeout, leaving the rest to drain in-flight
+// messages. The throughput and latency of each direction when running alone are compared with
+// running concurrently, and are reported at the end of the test.
+//
+// The targets default to the initial target from A to B (see NAT_INTEROP_LOADTEST_TARGET), and can
+// be overridden per direction with NAT_BIDIRECTIONAL_TARGET_AB and NAT_BIDIRECTIONAL_TARGET_BA. If
+// NAT_BIDIRECTIONAL_MAX_DEGRADATION is set (e.g., 0.2), the test fails if the concurrent throughput
+// of either direction is more than that fraction lower than its throughput when running alone. The
+// test timeout is specified by the NAT_BIDIRECTIONAL_TIMEOUT environment variable.
+func TestBidirectional(gt *testing.T) {
+	t := setupT(gt)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_BIDIRECTIONAL_TIMEOUT")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	l2A, l2B := setupL2s(t, ctx, &wg)
+
+	defaultTarget := initialTarget(ctx, t, l2A, l2B)
+	ab := &direction{name: "A->B", source: l2A, dest: l2B, target: readTarget(t, "NAT_BIDIRECTIONAL_TARGET_AB", defaultTarget)}
+	ba := &direction{name: "B->A", source: l2B, dest: l2A, target: readTarget(t, "NAT_BIDIRECTIONAL_TARGET_BA", defaultTarget)}
+
+	deadline, _ := ctx.Deadline()
+	phaseDuration := time.Until(deadline) / 4
+	runPhase := func(dirs ...*direction) {
+		phaseCtx, phaseCancel := context.WithTimeout(ctx, phaseDuration)
+		defer phaseCancel()
+		var phaseWg sync.WaitGroup
+		for _, dir := range dirs {
+			// A scheduler without adjustments keeps the rate at the target.
+			aimd := startAIMD(phaseCtx, &phaseWg, dir.target, dir.dest.BlockTime())
+			phaseWg.Add(1)
+			go func() {
+				defer phaseWg.Done()
+				for range aimd.Ready() {
+					phaseWg.Add(1)
+					go func() {
+						defer phaseWg.Done()
+						start := time.Now()
+						// In-flight messages use the test context, to be drained after the phase.
+						err := relayMessage(ctx, t, dir.source, dir.dest)
+						var overdraft *accounting.OverdraftError
+						if errors.As(err, &overdraft) {
+							cancel()
+							t.Require().NoError(err)
+						}
+						dir.record(len(dirs) > 1, err == nil, time.Since(start))
+					}()
+				}
+			}()
+		}
+		phaseWg.Wait()
+	}
+	runPhase(ab)
+	runPhase(ba)
+	runPhase(ab, ba)
+
+	maxDegradation := -1.0
+	if maxDegradationStr, exists := os.LookupEnv("NAT_BIDIRECTIONAL_MAX_DEGRADATION"); exists {
+		var err error
+		maxDegradation, err = strconv.ParseFloat(maxDegradationStr, 64)
+		t.Require().NoError(err)
+	}
+	summaryAB, degradationAB := ab.report(phaseDuration)
+	summaryBA, degradationBA := ba.report(phaseDuration)
+	endTest(gt, cancel, summaryAB, summaryBA)
+	if maxDegradation >= 0 {
+		t.Require().LessOrEqualf(degradationAB, maxDegradation,
+			"throughput of %s degraded by more than %.2f under bidirectional load", ab.name, maxDegradation)
+		t.Require().LessOrEqualf(degradationBA, maxDegradation,
+			"throughput of %s degraded by more than %.2f under bidirectional load", ba.name, maxDegradation)
+	}
+}
+
+// direction is one of the directions of TestBidirectional, with the messages it completed while
+// running alone and while running concurrently with the other direction.
+type direction struct {
+	name         string
+	source, dest *L2
+	target       uint64
+
+	mu         sync.Mutex
+	alone      relayStats
+	concurrent relayStats
+}
+
+type relayStats struct {
+	completed uint64
+	failed    uint64
+	latency   time.Duration // sum of the e2e latencies of the completed messages
+}
+
+func (s relayStats) throughput(d time.Duration) float64 {
+	return float64(s.completed) / d.Seconds()
+}
+
+func (s relayStats) meanLatency() time.Duration {
+	if s.completed == 0 {
+		return 0
+	}
+	return s.latency / time.Duration(s.completed)
+}
+
+func (d *direction) record(concurrent bool, success bool, latency time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := &d.alone
+	if concurrent {
+		stats = &d.concurrent
+	}
+	if !success {
+		stats.failed++
+		return
+	}
+	stats.completed++
+	stats.latency += latency
+}
+
+// report summarizes the stats of the direction, and returns the fraction by which the throughput
+// decreased when running concurrently with the other direction.
+func (d *direction) report(phaseDuration time.Duration) (string, float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	aloneThroughput := d.alone.throughput(phaseDuration)
+	concurrentThroughput := d.concurrent.throughput(phaseDuration)
+	var degradation float64
+	if aloneThroughput > 0 {
+		degradation = 1 - concurrentThroughput/aloneThroughput
+	}
+	summary := fmt.Sprintf("%s (target %d/block): alone %.2f msg/s, mean latency %s, %d failed; "+
+		"concurrent %.2f msg/s, mean latency %s, %d failed; throughput degradation %.1f%%",
+		d.name, d.target,
+		aloneThroughput, d.alone.meanLatency(), d.alone.failed,
+		concurrentThroughput, d.concurrent.meanLatency(), d.concurrent.failed,
+		degradation*100)
+	return summary, degradation
+}
+
+// TestFanOut distributes initiating messages across all chains of the network, and executes every
+// message on each of the destinations of its source chain, as given by the topology in
+// NAT_INTEROP_LOADTEST_TOPOLOGY (default: ring):
+//
+//   - ring: every chain sends messages to the next chain.
+//   - star: the first chain sends messages to every other chain, which send messages back to it.
+//   - mesh: every chain sends messages to every other chain.
+//
+// A message only counts as delivered once it is executed on every destination. The number of
+// initiating messages per slot is adjusted like in TestBurst. Since every initiating message
+// results in as many executing messages as its source has destinations, the test reports the
+// resulting amplification and the executing messages per route at the end. The test exits
+// successfully after the global go test deadline or the timeout specified by the
+// NAT_FANOUT_TIMEOUT environment variable elapses, whichever comes first.
+func TestFanOut(gt *testing.T) {
+	t := setupT(gt)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_FANOUT_TIMEOUT")
+
+	topologyName := TopologyRing
+	if name, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_TOPOLOGY"); exists {
+		var err error
+		topologyName, err = ParseTopology(name)
+		t.Require().NoError(err)
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	l2s := setupChains(t, ctx, &wg)
+
+	var routes []*fanOutRoute
+	for i, destIndices := range topologyName.Destinations(len(l2s)) {
+		if len(destIndices) == 0 {
+			continue
+		}
+		route := &fanOutRoute{source: l2s[i]}
+		for _, j := range destIndices {
+			route.dests = append(route.dests, l2s[j])
+		}
+		routes = append(routes, route)
+	}
+	t.Require().NotEmpty(routes, "topology %s has no routes between %d chains", topologyName, len(l2s))
+	nextRoute := NewRoundRobin(routes)
+
+	aimd := startAIMD(ctx, &wg, initialTarget(ctx, t, routes[0].source, routes[0].dests[0]), l2s[0].BlockTime())
+	var inFlight sync.WaitGroup
+	for range aimd.Ready() {
+		route := nextRoute.Get()
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			executed, err := fanOutMessage(ctx, t, route.source, route.dests, newMessage(route.source, route.dests...))
+			route.record(executed, err == nil)
+			if err == nil {
+				aimd.Adjust(true)
+				return
+			}
+			if isBenignCancellationError(err) {
+				return
+			}
+			var overdraft *accounting.OverdraftError
+			if errors.As(err, &overdraft) {
+				cancel()
+			}
+			aimd.Adjust(false)
+		}()
+	}
+	inFlight.Wait()
+	endTest(gt, cancel, fanOutReport(topologyName, len(l2s), routes))
+}
+
+// fanOutRoute is a source chain of TestFanOut with its destinations, and the messages it sent.
+type fanOutRoute struct {
+	source *L2
+	dests  []*L2
+
+	mu        sync.Mutex
+	delivered uint64 // messages executed on every destination
+	partial   uint64 // messages executed on some, but not all destinations
+	executed  uint64 // executing messages, over all destinations
+}
+
+func (r *fanOutRoute) record(executed int, delivered bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.executed += uint64(executed)
+	if delivered {
+		r.delivered++
+	} else if executed > 0 {
+		r.partial++
+	}
+}
+
+func fanOutReport(topologyName Topology, numChains int, routes []*fanOutRoute) string {
+	var b strings.Builder
+	var delivered, partial, executed uint64
+	for _, r := range routes {
+		r.mu.Lock()
+		dests := make([]string, 0, len(r.dests))
+		for _, dest := range r.dests {
+			dests = append(dests, dest.EL.ChainID().String())
+		}
+		fmt.Fprintf(&b, "\n  %s -> [%s]: %d delivered, %d partially delivered, %d executing messages",
+			r.source.EL.ChainID(), strings.Join(dests, ", "), r.delivered, r.partial, r.executed)
+		delivered += r.delivered
+		partial += r.partial
+		executed += r.executed
+		r.mu.Unlock()
+	}
+	// The amplification is the number of executing messages per initiating message that was executed.
+	var amplification float64
+	if delivered+partial > 0 {
+		amplification = float64(executed) / float64(delivered+partial)
+	}
+	return fmt.Sprintf("%s topology over %d chains: %d messages delivered, %d partially delivered, "+
+		"%d executing messages (amplification %.2fx)%s",
+		topologyName, numChains, delivered, partial, executed, amplification, b.String())
+}
+
+// TestReplay sends the initiating messages of a recording again, at the recorded times, from the
+// recorded senders and with the recorded payload sizes, so regressions between releases can be
+// compared on identical traffic. Record any other test by setting NAT_INTEROP_LOADTEST_RECORD to
+// the file to write the recording to, and replay it by setting NAT_REPLAY_FILE to that file. The
+// test is skipped if NAT_REPLAY_FILE is not set.
+//
+// Chains are referred to by their position in the network, so a recording can be replayed against
+// a different network with at least as many chains. Like TestRamp, the throughput does not adapt
+// to the results. The test reports the delivered and failed messages once all messages are sent
+// and done, and exits successfully after that, or after the global go test deadline or the
+// timeout specified by the NAT_REPLAY_TIMEOUT environment variable elapses, whichever comes first.
+func TestReplay(gt *testing.T) {
+	t := setupT(gt)
+	path, exists := os.LookupEnv("NAT_REPLAY_FILE")
+	if !exists {
+		t.Skip("no recording to replay (set NAT_REPLAY_FILE)")
+	}
+	recording, err := LoadRecording(path)
+	t.Require().NoError(err)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_REPLAY_TIMEOUT")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	l2s := setupChains(t, ctx, &wg)
+	t.Require().NoError(recording.Check(len(l2s)))
+
+	var delivered, failed atomic.Uint64
+	var inFlight sync.WaitGroup
+	start := time.Now()
+	sent := 0
+replay:
+	for _, msg := range recording.Messages {
+		select {
+		case <-time.After(time.Until(start.Add(msg.Offset))):
+		case <-ctx.Done():
+			break replay
+		}
+		source := l2s[msg.Source]
+		dests := make([]*L2, 0, len(msg.Destinations))
+		for _, i := range msg.Destinations {
+			dests = append(dests, l2s[i])
+		}
+		sent++
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			_, err := fanOutMessage(ctx, t, source, dests, msg)
+			if err == nil {
+				delivered.Add(1)
+				return
+			}
+			if isBenignCancellationError(err) {
+				return
+			}
+			var overdraft *accounting.OverdraftError
+			if errors.As(err, &overdraft) {
+				cancel()
+				t.Require().NoError(err)
+			}
+			failed.Add(1)
+		}()
+	}
+	inFlight.Wait()
+	endTest(gt, cancel, fmt.Sprintf("replayed %d of %d messages of %s recorded on chains %v in %s: %d delivered, %d failed",
+		sent, len(recording.Messages), recording.Test, recording.Chains, time.Since(start).Round(time.Second),
+		delivered.Load(), failed.Load()))
+}
+
+// TestDAPressure passes interop messages from chain A to chain B while saturating the data
+// availability of L1 with the batches of both chains, to observe how DA backpressure affects the
+// time until interop messages are cross-safe. Both chains include incompressible calldata every
+// slot, so that their batchers submit NAT_DAPRESSURE_BLOBS (default: 3) full blobs of batch data
+// per L1 block together, in transactions of NAT_DAPRESSURE_TX_SIZE bytes (default: 32768). Whether
+// the batches are posted as blobs or as calldata depends on the batcher of the network.
+//
+// The throughput of the messages adapts like in TestBurst. The lag of the cross-safe head behind
+// the unsafe head of every chain is charted, and its maximum is reported at the end of the test.
+// The test will exit successfully after the global go test deadline or the timeout specified by
+// the NAT_DAPRESSURE_TIMEOUT environment variable elapses, whichever comes first.
+func TestDAPressure(gt *testing.T) {
+	t := setupT(gt)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_DAPRESSURE_TIMEOUT")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	sys, l2s := setupNetwork(t, ctx, &wg)
+	source, dest := l2s[0], l2s[1]
+
+	// The L1 block time is not configured in the network, so take it from the latest L1 blocks.
+	head := sys.L1EL.BlockRefByLabel(eth.Unsafe)
+	t.Require().NotZero(head.Number, "L1 must have produced a block")
+	parent := sys.L1EL.BlockRefByNumber(head.Number - 1)
+	l1BlockTime := time.Duration(head.Time-parent.Time) * time.Second
+
+	// Calibrate before the DA pressure, which uses gas on both chains.
+	target := initialTarget(ctx, t, source, dest)
+	pressure := NewDAPressure(l2s, readTarget(t, "NAT_DAPRESSURE_BLOBS", 3), l1BlockTime,
+		readTarget(t, "NAT_DAPRESSURE_TX_SIZE", 32*1024))
+	pressure.Start(ctx, t, &wg)
+	lags := trackSafeHeadLag(ctx, t, &wg, l2s)
+
+	aimd := startAIMD(ctx, &wg, target, dest.BlockTime())
+	var delivered, failed atomic.Uint64
+	for range aimd.Ready() {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := relayMessage(ctx, t, source, dest)
+			if err == nil {
+				delivered.Add(1)
+				aimd.Adjust(true)
+				return
+			}
+			failed.Add(1)
+			var overdraft *accounting.OverdraftError
+			if errors.As(err, &overdraft) {
+				cancel()
+			}
+			aimd.Adjust(false)
+		}()
+	}
+	t.Logger().Info("DA pressure results", "l1BlockTime", l1BlockTime, "delivered", delivered.Load(),
+		"failed", failed.Load(), "maxSafeHeadLag", lags.Max())
+}
+
+// TestTokenBridge bridges an ERC-7802 token between chain A and chain B in both directions
+// through the SuperchainTokenBridge. Every transfer burns the token on the source chain, sends a
+// message and relays it to mint the token on the destination chain, so its receipts carry the logs
+// of the token and the bridge besides the message. The token is deployed for the test, and every
+// sender account is funded with it on both chains.
+//
+// The throughput of the transfers adapts like in TestBurst. At the end of the test, the total
+// supply of the token across the chains must have dropped by exactly the amount that was sent but
+// not relayed, within the transfers whose transactions did not confirm. The test will exit
+// successfully after the global go test deadline or the timeout specified by the
+// NAT_TOKENBRIDGE_TIMEOUT environment variable elapses, whichever comes first.
+func TestTokenBridge(gt *testing.T) {
+	t := setupT(gt)
+	t, ctx, cancel := setupTestDeadline(t, "NAT_TOKENBRIDGE_TIMEOUT")
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	l2A, l2B := setupL2s(t, ctx, &wg)
+	bridge := NewTokenBridge(ctx, t, []*L2{l2A, l2B})
+
+	type route struct{ source, dest *L2 }
+	routes := NewRoundRobin([]route{{l2A, l2B}, {l2B, l2A}})
+	aimd := startAIMD(ctx, &wg, initialTarget(ctx, t, l2A, l2B), l2B.BlockTime())
+	var transfers sync.WaitGroup
+	for range aimd.Ready() {
+		r := routes.Get()
+		transfers.Add(1)
+		go func() {
+			defer transfers.Done()
+			err := bridge.Transfer(ctx, t, r.source, r.dest)
+			var overdraft *accounting.OverdraftError
+			if errors.As(err, &overdraft) {
+				cancel()
+			}
+			aimd.Adjust(err == nil)
+		}()
+	}
+	transfers.Wait()
+
+	// The test context may be done already, check the supply with a new one.
+	checkCtx, checkCancel := context.WithTimeout(context.Background(), time.Minute)
+	defer checkCancel()
+	endTest(gt, cancel, bridge.CheckSupply(checkCtx, t))
+}
+
+// endTest cancels the test context, which stops the collection of metrics, and logs the reports of
+// the test. The reports are logged to the go test output directly, so they are not muted by the log
+// filter.
+func endTest(gt *testing.T, cancel context.CancelFunc, reports ...string) {
+	cancel()
+	for _, report := range reports {
+		gt.Log(report)
+	}
+}
+
 func setupT(t *testing.T) devtest.T {
 	if testing.Short() || !flags.ReadTestConfig().EnableLoadTests {
 		t.Skip("skipping load test in short mode or if load tests are disabled (enable with -loadtest or NAT_LOADTEST=true)")
@@ -146,22 +680,96 @@ func setupTestDeadline(t devtest.T, varName string) (devtest.T, context.Context,
 }
 
 func setupLoadTest(t devtest.T, ctx context.Context, wg *sync.WaitGroup, aimdOpts ...AIMDOption) (*AIMD, *L2, *L2) {
-	sys := presets.NewSimpleInterop(t)
-	blockTime := time.Duration(sys.L2ChainB.Escape().RollupConfig().BlockTime) * time.Second
+	l2A, l2B := setupL2s(t, ctx, wg)
+	target := initialTarget(ctx, t, l2A, l2B)
+	aimd := startAIMD(ctx, wg, target, l2B.BlockTime(), aimdOpts...)
+	return aimd, l2A, l2B
+}
 
-	// Scheduler.
-	targetMessagePassesPerBlock := uint64(100)
-	if targetMsgPassesStr, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_TARGET"); exists {
-		var err error
-		targetMessagePassesPerBlock, err = strconv.ParseUint(targetMsgPassesStr, 10, 0)
-		t.Require().NoError(err)
+// readTarget reads a number of message passes per block from the given environment variable.
+func readTarget(t devtest.T, varName string, defaultTarget uint64) uint64 {
+	targetStr, exists := os.LookupEnv(varName)
+	if !exists {
+		return defaultTarget
+	}
+	target, err := strconv.ParseUint(targetStr, 10, 0)
+	t.Require().NoError(err)
+	return target
+}
+
+// readDuration reads a duration from the given environment variable.
+func readDuration(t devtest.T, varName string, defaultDuration time.Duration) time.Duration {
+	durationStr, exists := os.LookupEnv(varName)
+	if !exists {
+		return defaultDuration
+	}
+	duration, err := time.ParseDuration(durationStr)
+	t.Require().NoError(err)
+	return duration
+}
+
+// readFloat reads a number from the given environment variable.
+func readBool(t devtest.T, varName string, defaultValue bool) bool {
+	valueStr, exists := os.LookupEnv(varName)
+	if !exists {
+		return defaultValue
 	}
-	aimd := NewAIMD(targetMessagePassesPerBlock, blockTime, aimdOpts...)
+	value, err := strconv.ParseBool(valueStr)
+	t.Require().NoError(err)
+	return value
+}
+
+func readFloat(t devtest.T, varName string, defaultValue float64) float64 {
+	valueStr, exists := os.LookupEnv(varName)
+	if !exists {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	t.Require().NoError(err)
+	return value
+}
+
+// startAIMD starts a scheduler that runs until the context is canceled.
+func startAIMD(ctx context.Context, wg *sync.WaitGroup, target uint64, blockTime time.Duration, opts ...AIMDOption) *AIMD {
+	aimd := NewAIMD(target, blockTime, opts...)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		aimd.Start(ctx)
 	}()
+	return aimd
+}
+
+// setupL2s funds the EOAs and deploys the event loggers of both chains, and starts collecting metrics.
+func setupL2s(t devtest.T, ctx context.Context, wg *sync.WaitGroup) (*L2, *L2) {
+	l2s := setupChains(t, ctx, wg)
+	return l2s[0], l2s[1]
+}
+
+// chainSetup is a chain of the network to run load tests on.
+type chainSetup struct {
+	network *dsl.L2Network
+	faucet  *dsl.Faucet
+	// observer labels the transaction submission metrics of the chain.
+	observer ResubmitterObserver
+}
+
+// setupChains funds the EOAs and deploys the event loggers of every chain of the network, and
+// starts collecting metrics.
+func setupChains(t devtest.T, ctx context.Context, wg *sync.WaitGroup) []*L2 {
+	_, l2s := setupNetwork(t, ctx, wg)
+	return l2s
+}
+
+// setupNetwork is setupChains, for the tests that use the network as well.
+func setupNetwork(t devtest.T, ctx context.Context, wg *sync.WaitGroup) (*presets.SimpleInterop, []*L2) {
+	sys := presets.NewSimpleInterop(t)
+	blockTime := time.Duration(sys.L2ChainB.Escape().RollupConfig().BlockTime) * time.Second
+	// The tests with a single direction send messages from chain A to chain B.
+	chains := []chainSetup{
+		{network: sys.L2ChainA, faucet: sys.FaucetA, observer: "source"},
+		{network: sys.L2ChainB, faucet: sys.FaucetB, observer: "destination"},
+	}
 
 	// Chains.
 	budget := eth.OneEther
@@ -170,56 +778,116 @@ func setupLoadTest(t devtest.T, ctx context.Context, wg *sync.WaitGroup, aimdOpt
 		t.Require().NoError(err)
 		budget = eth.Ether(amount)
 	}
-	l2ELA := sys.L2ChainA.PublicRPC()
-	l2ELB := sys.L2ChainB.PublicRPC()
-	funderA := dsl.NewFunder(sys.Wallet, sys.FaucetA, l2ELA)
-	funderB := dsl.NewFunder(sys.Wallet, sys.FaucetB, l2ELB)
+	var maxRefills uint64
+	if refillsStr, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_REFILLS"); exists {
+		var err error
+		maxRefills, err = strconv.ParseUint(refillsStr, 10, 64)
+		t.Require().NoError(err)
+	}
+	faucetEndpoint, customFaucet := os.LookupEnv("NAT_INTEROP_LOADTEST_FAUCET_ENDPOINT")
+	budgets := NewBudgetManager(ctx, t.Logger(), budget, maxRefills)
+	var pools []*EndpointPool
+	usePool := readBool(t, "NAT_INTEROP_LOADTEST_RPC_POOL", false)
+	maxSkew := readTarget(t, "NAT_INTEROP_LOADTEST_RPC_MAX_SKEW", 2)
 	const numEOAs = 300
-	innerEOAsA := funderA.NewFundedEOAs(numEOAs, budget)
-	innerEOAsB := funderB.NewFundedEOAs(numEOAs, budget)
-	reliableELA := newReliableEL(l2ELA.Escape().EthClient(), blockTime, ResubmitterObserver("source"))
-	reliableELB := newReliableEL(l2ELB.Escape().EthClient(), blockTime, ResubmitterObserver("destination"))
-	eoasA := make([]*SyncEOA, 0, len(innerEOAsA))
-	eoasB := make([]*SyncEOA, 0, len(innerEOAsA))
-	for _, eoa := range innerEOAsA {
-		p := txinclude.NewPersistent(
-			txinclude.NewPkSigner(eoa.Key().Priv(), eoa.ChainID().ToBig()),
-			reliableELA,
-			txinclude.WithBudget(accounting.NewBudget(budget)),
-		)
-		eoasA = append(eoasA, &SyncEOA{
-			Plan:     eoa.Plan(),
-			Includer: p,
-		})
+	l2s := make([]*L2, 0, len(chains))
+	for i, chain := range chains {
+		el := chain.network.PublicRPC()
+		if customFaucet {
+			// op-faucet serves the default faucet of every chain at /chain/<chainID>.
+			rpc, err := client.NewRPC(ctx, t.Logger(), fmt.Sprintf("%s/chain/%s", strings.TrimSuffix(faucetEndpoint, "/"), el.ChainID()))
+			t.Require().NoError(err)
+			t.Cleanup(rpc.Close)
+			budgets.SetFaucet(el.ChainID(), sources.NewFaucetClient(rpc))
+		} else {
+			budgets.SetFaucet(el.ChainID(), chain.faucet.Escape().API())
+		}
+		funder := dsl.NewFunder(sys.Wallet, chain.faucet, el)
+		innerEOAs := funder.NewFundedEOAs(numEOAs, budget)
+		var submitter txinclude.EL = el.Escape().EthClient()
+		if usePool {
+			pool := NewEndpointPool(t.Logger(), el.ChainID(), maxSkew)
+			for _, node := range chain.network.Escape().L2ELNodes() {
+				pool.AddEndpoint(node.ID().String(), node.EthClient())
+			}
+			pool.Start(ctx, wg, blockTime)
+			pools = append(pools, pool)
+			submitter = pool
+		}
+		reliableEL := newReliableEL(submitter, blockTime, chain.observer)
+		eoas := make([]*SyncEOA, 0, len(innerEOAs))
+		for _, eoa := range innerEOAs {
+			p := txinclude.NewPersistent(
+				txinclude.NewPkSigner(eoa.Key().Priv(), eoa.ChainID().ToBig()),
+				reliableEL,
+				txinclude.WithBudget(budgets.NewAccount(el.ChainID(), eoa.Address(), budget)),
+			)
+			eoas = append(eoas, &SyncEOA{
+				Address:  eoa.Address(),
+				Plan:     eoa.Plan(),
+				Includer: p,
+			})
+		}
+		l2 := &L2{
+			Index:        i,
+			Config:       chain.network.Escape().ChainConfig(),
+			RollupConfig: chain.network.Escape().RollupConfig(),
+			EOAs:         NewRoundRobin(eoas),
+			EL:           el,
+		}
+		l2.DeployEventLogger(ctx, t)
+		l2s = append(l2s, l2)
 	}
-	for _, eoa := range innerEOAsB {
-		p := txinclude.NewPersistent(
-			txinclude.NewPkSigner(eoa.Key().Priv(), eoa.ChainID().ToBig()),
-			reliableELB,
-			txinclude.WithBudget(accounting.NewBudget(budget)),
-		)
-		eoasB = append(eoasB, &SyncEOA{
-			Plan:     eoa.Plan(),
-			Includer: p,
-		})
+
+	// Workloads. Each chain gets its own workload, as it may deploy contracts.
+	workload := os.Getenv("NAT_INTEROP_LOADTEST_WORKLOAD")
+	for _, l2 := range l2s {
+		var err error
+		l2.Workload, err = NewWorkload(workload)
+		t.Require().NoError(err)
+		l2.Workload.Deploy(ctx, t, l2)
 	}
-	l2A := &L2{
-		Config:       sys.L2ChainA.Escape().ChainConfig(),
-		RollupConfig: sys.L2ChainA.Escape().RollupConfig(),
-		EOAs:         NewRoundRobin(eoasA),
-		EL:           l2ELA,
+
+	// Recording. The deployments above are not recorded, only the messages of the test.
+	var recorder *Recorder
+	recordPath, record := os.LookupEnv("NAT_INTEROP_LOADTEST_RECORD")
+	if record {
+		chainIDs := make([]eth.ChainID, 0, len(l2s))
+		for _, l2 := range l2s {
+			chainIDs = append(chainIDs, l2.EL.ChainID())
+		}
+		recorder = NewRecorder(t.Name(), chainIDs)
+		for _, l2 := range l2s {
+			l2.recorder = recorder
+		}
+	}
+
+	// Message accounting.
+	ledger := NewMessageLedger()
+	for _, l2 := range l2s {
+		l2.ledger = ledger
 	}
-	l2B := &L2{
-		Config:       sys.L2ChainB.Escape().ChainConfig(),
-		RollupConfig: sys.L2ChainB.Escape().RollupConfig(),
-		EOAs:         NewRoundRobin(eoasB),
-		EL:           l2ELB,
+	reconcile := readBool(t, "NAT_INTEROP_LOADTEST_RECONCILE", true)
+
+	// Chaos.
+	if faultsStr, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_CHAOS"); exists {
+		faults, err := ParseFaults(faultsStr)
+		t.Require().NoError(err)
+		chaos := NewChaos(t, sys, l2s, faults,
+			readDuration(t, "NAT_INTEROP_LOADTEST_CHAOS_INTERVAL", time.Minute),
+			readDuration(t, "NAT_INTEROP_LOADTEST_CHAOS_DOWNTIME", 10*time.Second),
+			readDuration(t, "NAT_INTEROP_LOADTEST_CHAOS_RECOVERY", time.Minute))
+		chaos.Start(ctx, wg)
 	}
-	l2A.DeployEventLogger(ctx, t)
-	l2B.DeployEventLogger(ctx, t)
 
 	// Metrics.
-	metricsCollector := NewMetricsCollector(blockTime)
+	minTPS := readFloat(t, "NAT_INTEROP_LOADTEST_MIN_TPS", 0)
+	runTime := time.Now().Format("20060102-150405")
+	var metricsOpts []MetricsCollectorOption
+	if endpoint, exists := os.LookupEnv("NAT_INTEROP_LOADTEST_METRICS_ENDPOINT"); exists {
+		metricsOpts = append(metricsOpts, WithPushGateway(endpoint, t.Name(), runTime))
+	}
+	metricsCollector := NewMetricsCollector(blockTime, metricsOpts...)
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -230,47 +898,159 @@ func setupLoadTest(t devtest.T, ctx context.Context, wg *sync.WaitGroup, aimdOpt
 		t.Require().NoError(err)
 	}()
 	t.Cleanup(func() {
-		dir := filepath.Join("artifacts", t.Name()+"_"+time.Now().Format("20060102-150405"))
+		dir := filepath.Join("artifacts", t.Name()+"_"+runTime)
 		t.Require().NoError(os.MkdirAll(dir, 0755))
 		t.Require().NoError(metricsCollector.SaveGraphs(dir))
+		t.Require().NoError(metricsCollector.SaveDashboard(dir))
+		t.Require().NoError(budgets.SaveReport(dir))
+		if usePool {
+			t.Require().NoError(SaveEndpointReports(dir, pools))
+			for _, pool := range pools {
+				for _, r := range pool.Report() {
+					t.Logger().Info("Endpoint results", "chain", pool.chainID, "endpoint", r.Name, "requests", r.Requests,
+						"rejected", r.Rejected, "failed", r.Failed, "errorRate", r.ErrorRate, "removals", r.Removals)
+				}
+			}
+		}
+		summary, err := metricsCollector.Summary(t.Name(), budgets.Report(), minTPS)
+		t.Require().NoError(err)
+		throughputPassed := summary.Passed
+		var discrepancies []string
+		if reconcile {
+			chainIDs := make([]eth.ChainID, 0, len(l2s))
+			for _, l2 := range l2s {
+				chainIDs = append(chainIDs, l2.EL.ChainID())
+			}
+			// The test context is done by now.
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			summary.Accounting, err = ledger.Reconcile(ctx, sys.Supervisor.Escape().QueryAPI(), chainIDs, blockTime)
+			t.Require().NoError(err)
+			discrepancies = summary.Accounting.Discrepancies()
+			summary.Passed = summary.Passed && len(discrepancies) == 0
+		}
+		t.Require().NoError(summary.Save(dir))
+		if !throughputPassed {
+			t.Errorf("max sustained throughput of %.2f msg/s is below NAT_INTEROP_LOADTEST_MIN_TPS of %.2f msg/s",
+				summary.MaxSustainedTPS, summary.MinTPS)
+		}
+		for _, discrepancy := range discrepancies {
+			t.Errorf("message accounting discrepancy: %s", discrepancy)
+		}
+		if record {
+			t.Require().NoError(recorder.Save(recordPath))
+		}
 	})
 
-	return aimd, l2A, l2B
+	return sys, l2s
 }
 
 func relayMessage(ctx context.Context, t devtest.T, source, dest *L2) error {
-	rng := rand.New(rand.NewSource(1234))
 	inFlightMessages.Inc()
 	defer func() {
 		inFlightMessages.Dec()
 	}()
 	startE2E := time.Now()
 
-	startInit := startE2E
-	initTx, err := source.Include(ctx, t, planCall(t, interop.RandomInitTrigger(rng, source.EventLogger, rng.Intn(2), rng.Intn(5))))
+	initMsg, err := initMessage(ctx, t, source, newMessage(source, dest))
 	if err != nil {
 		return err
 	}
+	if err := execMessage(ctx, t, source, dest, initMsg); err != nil {
+		return err
+	}
+	messageLatency.WithLabelValues("e2e").Observe(time.Since(startE2E).Seconds())
+	return nil
+}
+
+// fanOutMessage sends the initiating message msg on the source chain, and executes it on every
+// destination chain concurrently. It returns the number of destinations on which the message was
+// executed, and the errors of the other destinations.
+func fanOutMessage(ctx context.Context, t devtest.T, source *L2, dests []*L2, msg RecordedMessage) (int, error) {
+	inFlightMessages.Inc()
+	defer func() {
+		inFlightMessages.Dec()
+	}()
+	startE2E := time.Now()
+
+	initMsg, err := initMessage(ctx, t, source, msg)
+	if err != nil {
+		return 0, err
+	}
+	errs := make([]error, len(dests))
+	var wg sync.WaitGroup
+	for i, dest := range dests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = execMessage(ctx, t, source, dest, initMsg)
+		}()
+	}
+	wg.Wait()
+	executed := 0
+	for _, err := range errs {
+		if err == nil {
+			executed++
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return executed, err
+	}
+	// The message is delivered once it is executed on every destination.
+	messageLatency.WithLabelValues("e2e").Observe(time.Since(startE2E).Seconds())
+	return executed, nil
+}
+
+// initMessage includes a transaction with a random initiating message of the sender and payload
+// size of msg on the source chain.
+func initMessage(ctx context.Context, t devtest.T, source *L2, msg RecordedMessage) (suptypes.Message, error) {
+	rng := rand.New(rand.NewSource(1234))
+	out, _, err := includeInit(ctx, t, source, msg.Sender, planCall(t, interop.RandomInitTrigger(rng, source.EventLogger, msg.Topics, msg.DataLen)))
+	if err != nil {
+		return suptypes.Message{}, err
+	}
+	t.Require().Len(out.Entries, 1)
+	return out.Entries[0], nil
+}
+
+// includeInit includes a transaction with initiating messages on the source chain, from the EOA
+// with the given index. It returns a message for every log of the transaction, and the logs.
+func includeInit(ctx context.Context, t devtest.T, source *L2, sender uint64, opts ...txplan.Option) (*txintent.InteropOutput, []*ethtypes.Log, error) {
+	startInit := time.Now()
+	initTx, err := source.IncludeFrom(ctx, t, sender, opts...)
+	if err != nil {
+		observeMessageError("init", err)
+		return nil, nil, err
+	}
 	messageLatency.WithLabelValues("init").Observe(time.Since(startInit).Seconds())
 	ref, err := source.EL.Escape().EthClient().BlockRefByHash(ctx, initTx.Receipt.BlockHash)
 	if isBenignCancellationError(err) {
-		return err
+		return nil, nil, err
 	}
 	t.Require().NoError(err)
 	out := new(txintent.InteropOutput)
 	err = out.FromReceipt(t.Ctx(), initTx.Receipt, ref, source.EL.ChainID())
 	if isBenignCancellationError(err) {
-		return err
+		return nil, nil, err
 	}
 	t.Require().NoError(err)
-	t.Require().Len(out.Entries, 1)
-	initMsg := out.Entries[0]
+	return out, initTx.Receipt.Logs, nil
+}
 
-	startExec := time.Now()
-	if _, err = dest.Include(ctx, t, planCall(t, &txintent.ExecTrigger{
+// execMessage includes a transaction executing the initiating message on the destination chain.
+func execMessage(ctx context.Context, t devtest.T, source, dest *L2, initMsg suptypes.Message) error {
+	return includeExec(ctx, t, source, dest, initMsg, dest.Workload.PlanExec(t, &txintent.ExecTrigger{
 		Executor: constants.CrossL2Inbox,
 		Msg:      initMsg,
-	}), func(tx *txplan.PlannedTx) {
+	}))
+}
+
+// includeExec includes a transaction on the destination chain that executes the initiating
+// message, planned with the given option.
+func includeExec(ctx context.Context, t devtest.T, source, dest *L2, initMsg suptypes.Message, exec txplan.Option) error {
+	startExec := time.Now()
+	dest.ledger.Sent(source.EL.ChainID(), dest.EL.ChainID())
+	execTx, err := dest.Include(ctx, t, exec, func(tx *txplan.PlannedTx) {
 		tx.AgainstBlock.Wrap(func(fn plan.Fn[eth.BlockInfo]) plan.Fn[eth.BlockInfo] {
 			// The tx is invalid until we know it will be included at a higher timestamp than any
 			// of the initiating messages, modulo reorgs. Wait to plan the relay tx against a
@@ -299,13 +1079,30 @@ func relayMessage(ctx context.Context, t devtest.T, source, dest *L2) error {
 			}
 			return fn
 		})
-	}); err != nil {
+	})
+	if err != nil {
+		observeMessageError("exec", err)
+		if isBenignCancellationError(err) {
+			dest.ledger.Unconfirmed(source.EL.ChainID(), dest.EL.ChainID())
+		}
 		return err
 	}
-	endExec := time.Now()
-	messageLatency.WithLabelValues("exec").Observe(endExec.Sub(startExec).Seconds())
+	messageLatency.WithLabelValues("exec").Observe(time.Since(startExec).Seconds())
+	executedMessages.WithLabelValues(source.EL.ChainID().String(), dest.EL.ChainID().String()).Inc()
+	dest.executed.Add(1)
 
-	messageLatency.WithLabelValues("e2e").Observe(endExec.Sub(startE2E).Seconds())
+	// The executing message is validated when it is included, so the propagation latency is the
+	// difference between the timestamps of the blocks of both messages.
+	execBlock, err := dest.EL.Escape().EthClient().InfoByHash(ctx, execTx.Receipt.BlockHash)
+	if isBenignCancellationError(err) {
+		// The message was included, but without the time of its block it cannot be reconciled.
+		dest.ledger.Unconfirmed(source.EL.ChainID(), dest.EL.ChainID())
+		return err
+	}
+	t.Require().NoError(err)
+	dest.ledger.Confirmed(source.EL.ChainID(), dest.EL.ChainID(), execBlock.Time())
+	propagationLatencies.Observe(source.EL.ChainID().String(), dest.EL.ChainID().String(),
+		float64(execBlock.Time()-initMsg.Identifier.Timestamp))
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/bindings"
 	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
@@ -42,21 +43,53 @@ func NewRoundRobin[T any](items []T) *RoundRobin[T] {
 }
 
 func (p *RoundRobin[T]) Get() T {
-	next := (p.index.Add(1) - 1) % uint64(len(p.items))
-	return p.items[next]
+	return p.At(p.Next())
+}
+
+// Next returns the index of the next item, and advances to the item after it.
+func (p *RoundRobin[T]) Next() uint64 {
+	return (p.index.Add(1) - 1) % uint64(len(p.items))
+}
+
+// At returns the item at the given index, modulo the number of items.
+func (p *RoundRobin[T]) At(i uint64) T {
+	return p.items[i%uint64(len(p.items))]
 }
 
 type SyncEOA struct {
+	Address  common.Address
 	Plan     txplan.Option
 	Includer txinclude.Includer
 }
 
 type L2 struct {
+	// Index is the position of the chain in the network, which recordings refer to chains by.
+	Index        int
 	Config       *params.ChainConfig
 	RollupConfig *rollup.Config
 	EL           *dsl.L2ELNode
 	EOAs         *RoundRobin[*SyncEOA]
 	EventLogger  common.Address
+	Workload     Workload
+
+	// recorder records the initiating messages sent from the chain, if set.
+	recorder *Recorder
+	// ledger counts the messages executed on the chain, to reconcile with the supervisor.
+	ledger *MessageLedger
+	// executed is the number of messages executed on the chain.
+	executed atomic.Uint64
+	// gasUsed is the gas used by the transactions included on the chain.
+	gasUsed atomic.Uint64
+}
+
+func (l2 *L2) BlockTime() time.Duration {
+	return time.Duration(l2.RollupConfig.BlockTime) * time.Second
+}
+
+// GasTarget returns the gas target of a block, from the genesis gas limit of the rollup config and
+// the EIP-1559 elasticity of the chain config.
+func (l2 *L2) GasTarget() uint64 {
+	return l2.RollupConfig.Genesis.SystemConfig.GasLimit / l2.Config.ElasticityMultiplier()
 }
 
 func (l2 *L2) DeployEventLogger(ctx context.Context, t devtest.T) {
@@ -66,7 +99,12 @@ func (l2 *L2) DeployEventLogger(ctx context.Context, t devtest.T) {
 }
 
 func (l2 *L2) Include(ctx context.Context, t devtest.T, opts ...txplan.Option) (*txinclude.IncludedTx, error) {
-	eoa := l2.EOAs.Get()
+	return l2.IncludeFrom(ctx, t, l2.EOAs.Next(), opts...)
+}
+
+// IncludeFrom is like Include, but sends the transaction from the EOA with the given index.
+func (l2 *L2) IncludeFrom(ctx context.Context, t devtest.T, sender uint64, opts ...txplan.Option) (*txinclude.IncludedTx, error) {
+	eoa := l2.EOAs.At(sender)
 	unsigned, err := txplan.NewPlannedTx(eoa.Plan, txplan.Combine(opts...)).Unsigned.Eval(ctx)
 	if err != nil {
 		// Context cancelations and i/o timeouts can cause an error (there may be other scenarios).
@@ -78,5 +116,7 @@ func (l2 *L2) Include(ctx context.Context, t devtest.T, opts ...txplan.Option) (
 		return nil, err // Allow the caller to check for budget overdrafts and context cancelation.
 	}
 	t.Require().Equal(ethtypes.ReceiptStatusSuccessful, includedTx.Receipt.Status)
+	gasUsed.WithLabelValues(l2.EL.ChainID().String()).Add(float64(includedTx.Receipt.GasUsed))
+	l2.gasUsed.Add(includedTx.Receipt.GasUsed)
 	return includedTx, nil
 }
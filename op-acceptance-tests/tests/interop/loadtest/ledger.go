@@ -0,0 +1,244 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/apis"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// supervisorStatsWindowBlocks is the number of blocks of a chain that each query of the
+// supervisor's messages spans, well within the blocks per chain of a causality graph, so the graphs
+// are not truncated.
+const supervisorStatsWindowBlocks = 512
+
+// ChainPair is a route of messages, from the chain of the initiating message to the chain that
+// executes it.
+type ChainPair struct {
+	Source      eth.ChainID `json:"source"`
+	Destination eth.ChainID `json:"destination"`
+}
+
+func (p ChainPair) String() string {
+	return fmt.Sprintf("%s->%s", p.Source, p.Destination)
+}
+
+// PairCounts are the client-side counts of the executing messages of a chain pair.
+type PairCounts struct {
+	// Sent is the number of executing messages that were submitted.
+	Sent uint64 `json:"sent"`
+	// Confirmed is the number of executing messages whose inclusion was confirmed by a receipt.
+	Confirmed uint64 `json:"confirmed"`
+	// Unconfirmed is the number of executing messages that were canceled at the end of the run
+	// while waiting for their receipt, so they may or may not have been included.
+	Unconfirmed uint64 `json:"unconfirmed"`
+}
+
+// MessageLedger counts the executing messages of a run by chain pair on the client side, to
+// reconcile them with the executing messages that the supervisor indexed, see Reconcile.
+type MessageLedger struct {
+	mu    sync.Mutex
+	pairs map[ChainPair]*PairCounts
+	// first and last are the timestamps of the first and last block with a confirmed executing
+	// message.
+	first, last uint64
+}
+
+func NewMessageLedger() *MessageLedger {
+	return &MessageLedger{pairs: make(map[ChainPair]*PairCounts)}
+}
+
+func (l *MessageLedger) pair(source, dest eth.ChainID) *PairCounts {
+	key := ChainPair{Source: source, Destination: dest}
+	counts, ok := l.pairs[key]
+	if !ok {
+		counts = new(PairCounts)
+		l.pairs[key] = counts
+	}
+	return counts
+}
+
+// Sent counts an executing message that is submitted on the destination chain.
+func (l *MessageLedger) Sent(source, dest eth.ChainID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pair(source, dest).Sent++
+}
+
+// Confirmed counts an executing message that was included in a block of the destination chain
+// with the given timestamp.
+func (l *MessageLedger) Confirmed(source, dest eth.ChainID, blockTime uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pair(source, dest).Confirmed++
+	if l.first == 0 || blockTime < l.first {
+		l.first = blockTime
+	}
+	l.last = max(l.last, blockTime)
+}
+
+// Unconfirmed counts an executing message whose inclusion is unknown, as the run ended before
+// its receipt.
+func (l *MessageLedger) Unconfirmed(source, dest eth.ChainID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pair(source, dest).Unconfirmed++
+}
+
+// PairReconciliation is the client-side and the supervisor's count of the executing messages of a
+// chain pair.
+type PairReconciliation struct {
+	ChainPair
+	Client PairCounts `json:"client"`
+	// Supervisor is the number of executing messages of the pair that the supervisor indexed in
+	// the time range of the reconciliation.
+	Supervisor uint64 `json:"supervisor"`
+}
+
+// Matches returns whether the supervisor indexed every confirmed message, and no more than the
+// messages that may have been included.
+func (r PairReconciliation) Matches() bool {
+	return r.Supervisor >= r.Client.Confirmed && r.Supervisor <= r.Client.Confirmed+r.Client.Unconfirmed
+}
+
+// Reconciliation is the outcome of MessageLedger.Reconcile.
+type Reconciliation struct {
+	// FromTimestamp and ToTimestamp are the time range of the blocks whose executing messages were
+	// counted by the supervisor.
+	FromTimestamp uint64               `json:"fromTimestamp"`
+	ToTimestamp   uint64               `json:"toTimestamp"`
+	Pairs         []PairReconciliation `json:"pairs"`
+}
+
+// Discrepancies returns a description of every chain pair whose counts do not match.
+func (r *Reconciliation) Discrepancies() []string {
+	var out []string
+	for _, p := range r.Pairs {
+		if p.Matches() {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%s: supervisor indexed %d executing messages, client confirmed %d and has %d unconfirmed (%d sent)",
+			p.ChainPair, p.Supervisor, p.Client.Confirmed, p.Client.Unconfirmed, p.Client.Sent))
+	}
+	return out
+}
+
+// Reconcile counts the executing messages that the supervisor indexed in the blocks of the chains
+// between the first and the last block with a confirmed executing message, by chain pair, and
+// compares them with the client-side counts. It first waits for the supervisor to index the
+// blocks of the time range on every chain.
+//
+// The supervisor counts every executing message of the chains, so any other interop traffic on
+// the chains during the run shows up as a discrepancy.
+func (l *MessageLedger) Reconcile(ctx context.Context, supervisor apis.SupervisorQueryAPI, chains []eth.ChainID, blockTime time.Duration) (*Reconciliation, error) {
+	l.mu.Lock()
+	rec := &Reconciliation{FromTimestamp: l.first, ToTimestamp: l.last}
+	counts := make(map[ChainPair]PairCounts, len(l.pairs))
+	for pair, c := range l.pairs {
+		counts[pair] = *c
+	}
+	l.mu.Unlock()
+
+	indexed := make(map[ChainPair]uint64)
+	if rec.ToTimestamp != 0 {
+		if err := awaitIndexed(ctx, supervisor, chains, rec.ToTimestamp, blockTime); err != nil {
+			return nil, err
+		}
+		window := max(uint64(supervisorStatsWindowBlocks*blockTime/time.Second), 1)
+		for from := rec.FromTimestamp; from <= rec.ToTimestamp; from += window {
+			to := min(from+window-1, rec.ToTimestamp)
+			graph, err := supervisor.CausalityGraph(ctx, hexutil.Uint64(from), hexutil.Uint64(to))
+			if err != nil {
+				return nil, fmt.Errorf("get messages of the supervisor from %d to %d: %w", from, to, err)
+			}
+			if graph.Truncated {
+				return nil, fmt.Errorf("messages of the supervisor from %d to %d are truncated", from, to)
+			}
+			if err := countMessages(graph, indexed); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	pairs := make(map[ChainPair]struct{}, len(counts)+len(indexed))
+	for pair := range counts {
+		pairs[pair] = struct{}{}
+	}
+	for pair := range indexed {
+		pairs[pair] = struct{}{}
+	}
+	for _, pair := range slices.SortedFunc(maps.Keys(pairs), func(a, b ChainPair) int {
+		if c := a.Source.Cmp(b.Source); c != 0 {
+			return c
+		}
+		return a.Destination.Cmp(b.Destination)
+	}) {
+		rec.Pairs = append(rec.Pairs, PairReconciliation{
+			ChainPair:  pair,
+			Client:     counts[pair],
+			Supervisor: indexed[pair],
+		})
+	}
+	return rec, nil
+}
+
+// awaitIndexed waits until the supervisor indexed the blocks of every chain up to the given
+// timestamp, for at most 10 blocks.
+func awaitIndexed(ctx context.Context, supervisor apis.SupervisorQueryAPI, chains []eth.ChainID, timestamp uint64, blockTime time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*blockTime)
+	defer cancel()
+	for {
+		status, err := supervisor.SyncStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("get sync status of the supervisor: %w", err)
+		}
+		synced := true
+		for _, chain := range chains {
+			if chainStatus, ok := status.Chains[chain]; !ok || chainStatus.LocalUnsafe.Time < timestamp {
+				synced = false
+			}
+		}
+		if synced {
+			return nil
+		}
+		select {
+		case <-time.After(blockTime):
+		case <-ctx.Done():
+			return fmt.Errorf("supervisor did not index the blocks up to timestamp %d: %w", timestamp, ctx.Err())
+		}
+	}
+}
+
+// countMessages adds the message edges of the graph to the counts, by the chains of the
+// initiating and executing blocks.
+func countMessages(graph *suptypes.CausalityGraph, counts map[ChainPair]uint64) error {
+	chainOf := make(map[string]eth.ChainID, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		if node.ChainID != nil {
+			chainOf[node.ID] = *node.ChainID
+		}
+	}
+	for _, edge := range graph.Edges {
+		if edge.Kind != suptypes.MessageEdge {
+			continue
+		}
+		source, ok := chainOf[edge.From]
+		if !ok {
+			return fmt.Errorf("unknown initiating block %s", edge.From)
+		}
+		dest, ok := chainOf[edge.To]
+		if !ok {
+			return fmt.Errorf("unknown executing block %s", edge.To)
+		}
+		counts[ChainPair{Source: source, Destination: dest}]++
+	}
+	return nil
+}
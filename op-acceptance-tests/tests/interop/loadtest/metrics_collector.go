@@ -6,12 +6,16 @@ import (
 	"image/color"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/txinclude"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
@@ -24,6 +28,15 @@ const (
 	targetMessagesPerBlockName  = "target_messages_per_block"
 	messageLatencyName          = "message_latency"
 	txSubmissionStatusCountName = "tx_submission_status_count"
+	gasUsedName                 = "gas_used"
+	executedMessagesName        = "executed_messages"
+	budgetRefillsName           = "budget_refills"
+	propagationLatencyName      = "propagation_latency"
+	daPressureBytesName         = "da_pressure_bytes"
+	safeHeadLagName             = "safe_head_lag"
+	messageErrorsName           = "message_errors"
+	endpointRequestsName        = "endpoint_requests"
+	endpointHeadSkewName        = "endpoint_head_skew"
 )
 
 var (
@@ -36,7 +49,7 @@ var (
 	targetMessagesPerBlock = promauto.NewGauge(prometheus.GaugeOpts{
 		Name:      targetMessagesPerBlockName,
 		Subsystem: subsystemName,
-		Help:      "Current target messages per block from AIMD scheduler",
+		Help:      "Current target messages per block from the scheduler",
 	})
 
 	messageLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
@@ -50,8 +63,109 @@ var (
 		Subsystem: subsystemName,
 		Help:      "Total number of transaction submission attempts by chain and status",
 	}, []string{"chain", "status"})
+
+	gasUsed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      gasUsedName,
+		Subsystem: subsystemName,
+		Help:      "Total gas used by the included transactions, by chain",
+	}, []string{"chain"})
+
+	executedMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      executedMessagesName,
+		Subsystem: subsystemName,
+		Help:      "Total number of executing messages included, by source and destination chain",
+	}, []string{"source", "destination"})
+
+	budgetRefills = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      budgetRefillsName,
+		Subsystem: subsystemName,
+		Help:      "Total number of account budget refills from the faucet, by chain",
+	}, []string{"chain"})
+
+	propagationLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:      propagationLatencyName,
+		Subsystem: subsystemName,
+		Help: "Seconds between the blocks that include the initiating message on the source chain and " +
+			"the executing message on the destination chain, by source and destination chain",
+		// Block timestamps have a resolution of seconds, and differ by multiples of the block time.
+		Buckets: []float64{1, 2, 4, 6, 8, 10, 12, 16, 20, 30, 45, 60, 90, 120, 180, 300},
+	}, []string{"source", "destination"})
+
+	daPressureBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      daPressureBytesName,
+		Subsystem: subsystemName,
+		Help:      "Total bytes of incompressible calldata included to fill the batches, by chain",
+	}, []string{"chain"})
+
+	safeHeadLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      safeHeadLagName,
+		Subsystem: subsystemName,
+		Help:      "Number of blocks between the unsafe and the cross-safe head, by chain",
+	}, []string{"chain"})
+
+	messageErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      messageErrorsName,
+		Subsystem: subsystemName,
+		Help:      "Total number of messages that failed to be included, by stage (init, exec) and error category",
+	}, []string{"stage", "category"})
+
+	endpointRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name:      endpointRequestsName,
+		Subsystem: subsystemName,
+		Help:      "Total number of requests to the pooled EL endpoints, by chain, endpoint and status (success, rejected, failed)",
+	}, []string{"chain", "endpoint", "status"})
+
+	endpointHeadSkew = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name:      endpointHeadSkewName,
+		Subsystem: subsystemName,
+		Help:      "Number of blocks the pooled EL endpoint lags behind the highest endpoint of its chain, by chain and endpoint",
+	}, []string{"chain", "endpoint"})
 )
 
+// propagationLatencies keeps every observation of propagationLatency, to chart the distribution of
+// the latencies in more detail than the histogram buckets.
+var propagationLatencies latencyLog
+
+type latencyLog struct {
+	mu     sync.Mutex
+	values []float64
+}
+
+// Observe observes the latency in both propagationLatency and the log.
+func (l *latencyLog) Observe(source, dest string, latency float64) {
+	propagationLatency.WithLabelValues(source, dest).Observe(latency)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.values = append(l.values, latency)
+}
+
+func (l *latencyLog) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.values)
+}
+
+// Since returns the latencies observed after the first i, sorted in ascending order.
+func (l *latencyLog) Since(i int) []float64 {
+	l.mu.Lock()
+	out := append([]float64(nil), l.values[i:]...)
+	l.mu.Unlock()
+	sort.Float64s(out)
+	return out
+}
+
+// percentile returns the p-th percentile of the sorted values, by the nearest-rank method.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p / 100 * float64(len(sorted)))
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
 var (
 	colors = map[string]color.RGBA{
 		"VividRed":   {R: 242, G: 36, B: 36, A: 255},
@@ -188,35 +302,97 @@ type MetricsCollector struct {
 	samples   map[string]MetricSamples
 	blockTime time.Duration
 	startTime time.Time
+	endTime   time.Time
+	// latencyStart is the number of propagation latencies observed before the collection started,
+	// since the latencies of earlier tests in the same process are still logged.
+	latencyStart int
+	// baseline are the values of the counters when the collection started, since the counters of
+	// earlier tests in the same process are not reset.
+	baseline map[string]counterValue
+	// pusher pushes the metrics to a Prometheus push gateway at every sample, if not nil.
+	pusher *push.Pusher
+	// test and run are the grouping labels of the pushed metrics.
+	test, run string
+}
+
+type MetricsCollectorOption func(*MetricsCollector)
+
+// WithPushGateway pushes the load test metrics to the Prometheus push gateway at the given URL
+// while collecting, grouped by test and run, so the metrics can be followed live on dashboards.
+func WithPushGateway(url, test, run string) MetricsCollectorOption {
+	return func(mc *MetricsCollector) {
+		mc.pusher = push.New(url, subsystemName).
+			Gatherer(loadTestGatherer).
+			Grouping("test", test).
+			Grouping("run", run)
+		mc.test = test
+		mc.run = run
+	}
 }
 
 // NewMetricsCollector creates a new metrics collector with the given sampling interval.
-func NewMetricsCollector(blockTime time.Duration) *MetricsCollector {
-	return &MetricsCollector{
+func NewMetricsCollector(blockTime time.Duration, opts ...MetricsCollectorOption) *MetricsCollector {
+	mc := &MetricsCollector{
 		samples:   make(map[string]MetricSamples),
 		blockTime: blockTime,
 	}
+	for _, opt := range opts {
+		opt(mc)
+	}
+	return mc
 }
 
+// loadTestGatherer gathers the load test metrics only.
+var loadTestGatherer = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*dto.MetricFamily, 0, len(metricFamilies))
+	for _, metricFamily := range metricFamilies {
+		if strings.HasPrefix(metricFamily.GetName(), subsystemName+"_") {
+			out = append(out, metricFamily)
+		}
+	}
+	return out, nil
+})
+
 // Start begins collecting metrics samples.
 func (mc *MetricsCollector) Start(ctx context.Context) error {
 	mc.startTime = time.Now()
+	mc.latencyStart = propagationLatencies.Len()
+	baseline, err := counterTotals()
+	if err != nil {
+		return err
+	}
+	mc.baseline = baseline
 	ticker := time.NewTicker(mc.blockTime)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
+			mc.endTime = time.Now()
+			if mc.pusher != nil {
+				// Push the final values, the test context is already done.
+				pushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				if err := mc.pusher.PushContext(pushCtx); err != nil {
+					return fmt.Errorf("push metrics: %w", err)
+				}
+			}
 			return nil
 		case now := <-ticker.C:
-			metricFamilies, err := prometheus.DefaultGatherer.Gather()
+			if mc.pusher != nil {
+				if err := mc.pusher.PushContext(ctx); err != nil && ctx.Err() == nil {
+					return fmt.Errorf("push metrics: %w", err)
+				}
+			}
+			metricFamilies, err := loadTestGatherer.Gather()
 			if err != nil {
 				return fmt.Errorf("gather metrics: %w", err)
 			}
 			for _, metricFamily := range metricFamilies {
-				name, hasPrefix := strings.CutPrefix(metricFamily.GetName(), subsystemName+"_")
-				if !hasPrefix {
-					continue // Skip metrics we don't care about.
-				}
+				name := strings.TrimPrefix(metricFamily.GetName(), subsystemName+"_")
 				for _, metric := range metricFamily.GetMetric() {
 					var value float64
 					var count uint64
@@ -261,9 +437,35 @@ func (mc *MetricsCollector) SaveGraphs(dir string) error {
 	if err := mc.saveTxSubmissionStatusCountGraphs(dir); err != nil {
 		return fmt.Errorf("save tx submission status count graphs: %w", err)
 	}
+	if err := mc.saveExecutedMessagesGraph(dir); err != nil {
+		return fmt.Errorf("save executed messages graph: %w", err)
+	}
+	if err := mc.savePropagationLatencyGraphs(dir); err != nil {
+		return fmt.Errorf("save propagation latency graphs: %w", err)
+	}
+	if err := mc.saveByChainGraph(dir, daPressureBytesName, "DA Pressure Bytes per Block Time", "Bytes", true); err != nil {
+		return fmt.Errorf("save DA pressure graph: %w", err)
+	}
+	if err := mc.saveByChainGraph(dir, safeHeadLagName, "Cross-Safe Head Lag", "Blocks", false); err != nil {
+		return fmt.Errorf("save safe head lag graph: %w", err)
+	}
 	return nil
 }
 
+// SaveDashboard saves a Grafana dashboard of the metrics that were pushed to the Prometheus push
+// gateway, over the time range of the collection, to import the run into Grafana. It does nothing
+// if the metrics were not pushed.
+func (mc *MetricsCollector) SaveDashboard(dir string) error {
+	if mc.pusher == nil {
+		return nil
+	}
+	end := mc.endTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return NewDashboard(mc.test, mc.run, mc.startTime, end).Save(dir)
+}
+
 func (mc *MetricsCollector) saveInFlightMessagesGraph(dir string) error {
 	p := plot.New()
 	p.Title.Text = "In-Flight Messages"
@@ -381,6 +583,114 @@ func (mc *MetricsCollector) saveTxSubmissionStatusCountGraphs(dir string) error
 	return nil
 }
 
+func (mc *MetricsCollector) saveExecutedMessagesGraph(dir string) error {
+	samples := mc.samples[executedMessagesName]
+	if len(samples) == 0 {
+		return nil // Only the tests with a topology record the routes of the messages.
+	}
+	p := plot.New()
+	p.Title.Text = "Executed Messages per Block Time by Route"
+	p.X.Label.Text = "Time (seconds)"
+	p.Y.Label.Text = "Messages"
+
+	i := 0
+	for _, source := range samples.UniqueLabels(0) {
+		for _, dest := range samples.WithLabels(source).UniqueLabels(1) {
+			var routeSamples MetricSamples
+			for _, sample := range samples {
+				if sample.Labels[0] == source && sample.Labels[1] == dest {
+					routeSamples = append(routeSamples, sample)
+				}
+			}
+			line, err := addLine(p, routeSamples.ToValuePerIntervalPoints(mc.startTime), colors[colorOrder[i%len(colorOrder)]])
+			if err != nil {
+				return fmt.Errorf("%s->%s: %w", source, dest, err)
+			}
+			p.Legend.Add(source+"->"+dest, line)
+			i++
+		}
+	}
+
+	p.Add(plotter.NewGrid())
+	p.Legend.Top = true
+
+	return savePlot(p, dir, executedMessagesName)
+}
+
+// saveByChainGraph saves a graph of a metric with a chain label, with a line per chain. Counters
+// are charted per interval. Only the tests that record the metric save the graph.
+func (mc *MetricsCollector) saveByChainGraph(dir, name, title, unit string, perInterval bool) error {
+	samples := mc.samples[name]
+	if len(samples) == 0 {
+		return nil
+	}
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = "Time (seconds)"
+	p.Y.Label.Text = unit
+
+	for i, chain := range samples.UniqueLabels(0) {
+		chainSamples := samples.WithLabels(chain)
+		points := chainSamples.ToPoints(mc.startTime)
+		if perInterval {
+			points = chainSamples.ToValuePerIntervalPoints(mc.startTime)
+		}
+		line, err := addLine(p, points, colors[colorOrder[i%len(colorOrder)]])
+		if err != nil {
+			return fmt.Errorf("chain %s: %w", chain, err)
+		}
+		p.Legend.Add(chain, line)
+	}
+
+	p.Add(plotter.NewGrid())
+	p.Legend.Top = true
+
+	return savePlot(p, dir, name)
+}
+
+// savePropagationLatencyGraphs saves the distribution of the propagation latencies of the test, as
+// a histogram and as the latency by percentile.
+func (mc *MetricsCollector) savePropagationLatencyGraphs(dir string) error {
+	latencies := propagationLatencies.Since(mc.latencyStart)
+	if len(latencies) == 0 {
+		return nil // No messages were executed.
+	}
+	percentiles := fmt.Sprintf("p50 %.0fs, p90 %.0fs, p99 %.0fs, max %.0fs",
+		percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 99), latencies[len(latencies)-1])
+
+	p := plot.New()
+	p.Title.Text = "Propagation Latency Histogram (" + percentiles + ")"
+	p.X.Label.Text = "Latency (seconds)"
+	p.Y.Label.Text = "Messages"
+	// One bin per second, since the latencies are whole seconds.
+	bins := int(latencies[len(latencies)-1]-latencies[0]) + 1
+	hist, err := plotter.NewHist(plotter.Values(latencies), bins)
+	if err != nil {
+		return fmt.Errorf("create histogram: %w", err)
+	}
+	hist.FillColor = colors[colorOrder[3]]
+	p.Add(hist)
+	p.Add(plotter.NewGrid())
+	if err := savePlot(p, dir, propagationLatencyName+"_histogram"); err != nil {
+		return err
+	}
+
+	p = plot.New()
+	p.Title.Text = "Propagation Latency by Percentile (" + percentiles + ")"
+	p.X.Label.Text = "Percentile"
+	p.Y.Label.Text = "Latency (seconds)"
+	pts := make(plotter.XYs, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		pct := float64(i) / 10
+		pts = append(pts, plotter.XY{X: pct, Y: percentile(latencies, pct)})
+	}
+	if _, err := addLine(p, pts, colors[colorOrder[0]]); err != nil {
+		return err
+	}
+	p.Add(plotter.NewGrid())
+	return savePlot(p, dir, propagationLatencyName+"_percentiles")
+}
+
 func addLine(p *plot.Plot, points plotter.XYs, c color.Color) (*plotter.Line, error) {
 	line, err := plotter.NewLine(points)
 	if err != nil {
@@ -0,0 +1,148 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// RecordedMessage is an initiating message of a recording, and the chains it is executed on.
+type RecordedMessage struct {
+	// Offset is the time since the start of the recording at which the message was sent, in
+	// nanoseconds.
+	Offset time.Duration `json:"offset"`
+	// Source and Destinations are the indices of the chains in the network, see L2.Index.
+	Source       int   `json:"source"`
+	Destinations []int `json:"destinations"`
+	// Sender is the index of the EOA that sent the message on the source chain.
+	Sender uint64 `json:"sender"`
+	// Topics and DataLen are the number of topics and bytes of data of the message payload.
+	Topics  int `json:"topics"`
+	DataLen int `json:"dataLen"`
+}
+
+// newMessage picks the sender and payload of a new initiating message from source to dests, and
+// records it if the source chain is recorded.
+func newMessage(source *L2, dests ...*L2) RecordedMessage {
+	rng := rand.New(rand.NewSource(1234))
+	msg := RecordedMessage{
+		Source:  source.Index,
+		Sender:  source.EOAs.Next(),
+		Topics:  rng.Intn(2),
+		DataLen: rng.Intn(5),
+	}
+	for _, dest := range dests {
+		msg.Destinations = append(msg.Destinations, dest.Index)
+	}
+	source.recorder.Record(&msg)
+	return msg
+}
+
+// Recording is the exact sequence of initiating messages of a load test run, which TestReplay
+// sends again, to compare releases on identical traffic.
+type Recording struct {
+	Test string `json:"test"`
+	// Chains are the chain IDs of the recorded network, by chain index. Replays refer to chains by
+	// index, so they can run against networks with other chain IDs.
+	Chains   []eth.ChainID     `json:"chains"`
+	Messages []RecordedMessage `json:"messages"`
+}
+
+// LoadRecording reads a recording that was saved with Recorder.Save.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read recording: %w", err)
+	}
+	var r Recording
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("decode recording: %w", err)
+	}
+	return &r, nil
+}
+
+// Check returns an error if the recording cannot be replayed on a network of numChains chains.
+func (r *Recording) Check(numChains int) error {
+	if len(r.Chains) > numChains {
+		return fmt.Errorf("recording has %d chains, but the network has %d", len(r.Chains), numChains)
+	}
+	for i, msg := range r.Messages {
+		if msg.Source < 0 || msg.Source >= len(r.Chains) {
+			return fmt.Errorf("message %d: unknown source chain %d", i, msg.Source)
+		}
+		if len(msg.Destinations) == 0 {
+			return fmt.Errorf("message %d: no destination chains", i)
+		}
+		for _, dest := range msg.Destinations {
+			if dest < 0 || dest >= len(r.Chains) || dest == msg.Source {
+				return fmt.Errorf("message %d: invalid destination chain %d", i, dest)
+			}
+		}
+		if msg.Topics < 0 || msg.Topics > 4 || msg.DataLen < 0 {
+			return fmt.Errorf("message %d: invalid payload of %d topics and %d bytes", i, msg.Topics, msg.DataLen)
+		}
+		if i > 0 && msg.Offset < r.Messages[i-1].Offset {
+			return fmt.Errorf("message %d: out of order", i)
+		}
+	}
+	return nil
+}
+
+// Recorder records the initiating messages of a load test run. A nil Recorder records nothing.
+type Recorder struct {
+	test   string
+	chains []eth.ChainID
+	start  time.Time
+
+	mu       sync.Mutex
+	messages []RecordedMessage
+}
+
+// NewRecorder creates a recorder for the given test and network, whose message offsets start now.
+func NewRecorder(test string, chains []eth.ChainID) *Recorder {
+	return &Recorder{
+		test:   test,
+		chains: chains,
+		start:  time.Now(),
+	}
+}
+
+// Record sets the offset of the message to the current time, and records it.
+func (r *Recorder) Record(msg *RecordedMessage) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// The offset is taken while holding the lock, so the messages are recorded in order.
+	msg.Offset = time.Since(r.start)
+	r.messages = append(r.messages, *msg)
+}
+
+// Recording returns the messages recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Recording{
+		Test:     r.test,
+		Chains:   r.chains,
+		Messages: append([]RecordedMessage{}, r.messages...),
+	}
+}
+
+// Save writes the messages recorded so far to the given file, as JSON.
+func (r *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Recording(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode recording: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write recording: %w", err)
+	}
+	return nil
+}
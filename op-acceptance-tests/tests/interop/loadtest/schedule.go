@@ -121,3 +121,69 @@ func (c *AIMD) Adjust(success bool) {
 func (c *AIMD) Ready() <-chan struct{} {
 	return c.ready
 }
+
+// Ramp scheduler. Unlike AIMD, the rate does not depend on the results of the requests: it starts
+// at a base rps and increases by a fixed step every fixed number of slots, so every run follows
+// the same schedule. Each ready signal carries the slot it was sent in, counting from zero, so
+// that results can be attributed to the rate of that slot.
+type Ramp struct {
+	baseRPS   uint64
+	stepDelta uint64
+	stepSlots uint64
+	maxRPS    uint64 // zero for no maximum
+
+	slotTime time.Duration
+	ready    chan uint64
+}
+
+func NewRamp(baseRPS, stepDelta, stepSlots, maxRPS uint64, slotTime time.Duration) *Ramp {
+	return &Ramp{
+		baseRPS:   max(baseRPS, 1),
+		stepDelta: stepDelta,
+		stepSlots: max(stepSlots, 1),
+		maxRPS:    maxRPS,
+		slotTime:  slotTime,
+		ready:     make(chan uint64),
+	}
+}
+
+// RPS returns the rps of the given slot.
+func (r *Ramp) RPS(slot uint64) uint64 {
+	rps := r.baseRPS + r.stepDelta*(slot/r.stepSlots)
+	if r.maxRPS != 0 {
+		rps = min(rps, r.maxRPS)
+	}
+	return rps
+}
+
+func (r *Ramp) Start(ctx context.Context) {
+	defer close(r.ready)
+	start := time.Now()
+	for slot := uint64(0); ; slot++ {
+		rps := r.RPS(slot)
+		targetMessagesPerBlock.Set(float64(rps))
+		slotStart := start.Add(time.Duration(slot) * r.slotTime)
+		// Spread the requests evenly over the slot. Requests that readers are not ready for are
+		// skipped, and the schedule does not drift when readers are slow.
+		for i := uint64(0); i < rps; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(slotStart.Add(r.slotTime * time.Duration(i) / time.Duration(rps)))):
+				select {
+				case r.ready <- slot:
+				default: // Skip if readers are not ready.
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(slotStart.Add(r.slotTime))):
+		}
+	}
+}
+
+func (r *Ramp) Ready() <-chan uint64 {
+	return r.ready
+}
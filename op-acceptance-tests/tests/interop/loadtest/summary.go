@@ -0,0 +1,217 @@
+package loadtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/accounting"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// summaryJSONFile and summaryCSVFile are the names of the summary in the artifacts directory.
+	summaryJSONFile = "summary.json"
+	summaryCSVFile  = "summary.csv"
+
+	// sustainedWindowSlots is the number of consecutive slots over which the throughput must be
+	// sustained to count towards the maximum sustained throughput.
+	sustainedWindowSlots = 10
+)
+
+// Summary is the machine-readable outcome of a load test run.
+type Summary struct {
+	Test            string  `json:"test"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ExecutedMessages is the number of executing messages included during the run, on all chains.
+	ExecutedMessages uint64 `json:"executedMessages"`
+	// MaxSustainedTPS is the highest number of executed messages per second, averaged over
+	// sustainedWindowSlots consecutive slots, or over the whole run if it is shorter.
+	MaxSustainedTPS float64 `json:"maxSustainedTPS"`
+	// MinTPS is the floor of MaxSustainedTPS for the run to pass, if not zero.
+	MinTPS float64 `json:"minTPS,omitempty"`
+	Passed bool    `json:"passed"`
+	// Errors are the failed messages by stage and category, e.g. exec/budget, and the failed
+	// transaction submissions by status, e.g. submission/nonce_too_low.
+	Errors map[string]uint64 `json:"errors"`
+	// Spent is the ETH spent by the accounts of every chain.
+	Spent map[eth.ChainID]eth.ETH `json:"spent"`
+	// Accounting is the reconciliation of the executing messages of the run with the messages that
+	// the supervisor indexed, unless disabled. The run does not pass if any chain pair mismatches.
+	Accounting *Reconciliation `json:"accounting,omitempty"`
+}
+
+// Save writes the summary to summaryJSONFile and summaryCSVFile in the given directory. The CSV
+// file has a metric, label and value column, with a row per value of the summary.
+func (s *Summary) Save(dir string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode summary: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, summaryJSONFile), data, 0644); err != nil {
+		return fmt.Errorf("write summary: %w", err)
+	}
+
+	rows := [][]string{
+		{"metric", "label", "value"},
+		{"duration_seconds", "", strconv.FormatFloat(s.DurationSeconds, 'f', 1, 64)},
+		{"executed_messages", "", strconv.FormatUint(s.ExecutedMessages, 10)},
+		{"max_sustained_tps", "", strconv.FormatFloat(s.MaxSustainedTPS, 'f', 3, 64)},
+		{"min_tps", "", strconv.FormatFloat(s.MinTPS, 'f', 3, 64)},
+		{"passed", "", strconv.FormatBool(s.Passed)},
+	}
+	for _, category := range slices.Sorted(maps.Keys(s.Errors)) {
+		rows = append(rows, []string{"errors", category, strconv.FormatUint(s.Errors[category], 10)})
+	}
+	chains := slices.SortedFunc(maps.Keys(s.Spent), func(a, b eth.ChainID) int { return a.Cmp(b) })
+	for _, chain := range chains {
+		rows = append(rows, []string{"eth_spent", chain.String(), s.Spent[chain].EtherString()})
+	}
+	if s.Accounting != nil {
+		for _, pair := range s.Accounting.Pairs {
+			label := pair.ChainPair.String()
+			rows = append(rows,
+				[]string{"client_sent_messages", label, strconv.FormatUint(pair.Client.Sent, 10)},
+				[]string{"client_confirmed_messages", label, strconv.FormatUint(pair.Client.Confirmed, 10)},
+				[]string{"client_unconfirmed_messages", label, strconv.FormatUint(pair.Client.Unconfirmed, 10)},
+				[]string{"supervisor_messages", label, strconv.FormatUint(pair.Supervisor, 10)},
+			)
+		}
+	}
+	f, err := os.Create(filepath.Join(dir, summaryCSVFile))
+	if err != nil {
+		return fmt.Errorf("create summary CSV: %w", err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("write summary CSV: %w", err)
+	}
+	return nil
+}
+
+// Summary summarizes the run from the collected metrics and the spend of the chains. The run
+// passes if minTPS is zero, or if the maximum sustained throughput reaches it.
+func (mc *MetricsCollector) Summary(test string, spend map[eth.ChainID]*ChainSpend, minTPS float64) (*Summary, error) {
+	end := mc.endTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+	s := &Summary{
+		Test:            test,
+		DurationSeconds: end.Sub(mc.startTime).Seconds(),
+		MaxSustainedTPS: mc.maxSustainedThroughput(),
+		MinTPS:          minTPS,
+		Errors:          make(map[string]uint64),
+		Spent:           make(map[eth.ChainID]eth.ETH, len(spend)),
+	}
+	s.Passed = minTPS == 0 || s.MaxSustainedTPS >= minTPS
+
+	// The counters are shared by the tests of the process, so only count the increase during the run.
+	totals, err := counterTotals()
+	if err != nil {
+		return nil, err
+	}
+	for key, counter := range totals {
+		count := uint64(counter.value - mc.baseline[key].value)
+		if count == 0 {
+			continue
+		}
+		switch counter.name {
+		case executedMessagesName:
+			s.ExecutedMessages += count
+		case messageErrorsName:
+			s.Errors[counter.labels["stage"]+"/"+counter.labels["category"]] += count
+		case txSubmissionStatusCountName:
+			if status := counter.labels["status"]; status != "success" {
+				s.Errors["submission/"+status] += count
+			}
+		}
+	}
+	for chainID, chain := range spend {
+		s.Spent[chainID] = chain.Spent
+	}
+	return s, nil
+}
+
+// maxSustainedThroughput returns the highest number of executed messages per second over
+// sustainedWindowSlots consecutive samples.
+func (mc *MetricsCollector) maxSustainedThroughput() float64 {
+	// Sum the executed messages of every route at every sample.
+	var totals []float64
+	var last time.Time
+	for _, sample := range mc.samples[executedMessagesName] {
+		if !sample.Timestamp.Equal(last) {
+			totals = append(totals, 0)
+			last = sample.Timestamp
+		}
+		totals[len(totals)-1] += sample.Value
+	}
+	window := min(sustainedWindowSlots, len(totals)-1)
+	if window < 1 {
+		return 0
+	}
+	var maxTPS float64
+	for i := window; i < len(totals); i++ {
+		tps := (totals[i] - totals[i-window]) / (time.Duration(window) * mc.blockTime).Seconds()
+		maxTPS = max(maxTPS, tps)
+	}
+	return maxTPS
+}
+
+// counterValue is the value of a counter of the load test metrics, with its name without the
+// subsystem.
+type counterValue struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+// counterTotals returns the current value of every counter of the load test metrics, by name and
+// labels.
+func counterTotals() (map[string]counterValue, error) {
+	metricFamilies, err := loadTestGatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("gather metrics: %w", err)
+	}
+	out := make(map[string]counterValue)
+	for _, metricFamily := range metricFamilies {
+		name := strings.TrimPrefix(metricFamily.GetName(), subsystemName+"_")
+		for _, metric := range metricFamily.GetMetric() {
+			if metric.Counter == nil {
+				continue
+			}
+			counter := counterValue{name: name, labels: make(map[string]string), value: metric.Counter.GetValue()}
+			// The label pairs are sorted by name, so the key is unique.
+			key := name
+			for _, labelPair := range metric.Label {
+				counter.labels[labelPair.GetName()] = labelPair.GetValue()
+				key += "/" + labelPair.GetName() + "=" + labelPair.GetValue()
+			}
+			out[key] = counter
+		}
+	}
+	return out, nil
+}
+
+// observeMessageError counts a message that failed in the given stage, by the category of the
+// error: canceled when the test ended, budget when the sender ran out of budget, or inclusion
+// otherwise.
+func observeMessageError(stage string, err error) {
+	category := "inclusion"
+	var overdraft *accounting.OverdraftError
+	if isBenignCancellationError(err) {
+		category = "canceled"
+	} else if errors.As(err, &overdraft) {
+		category = "budget"
+	}
+	messageErrors.WithLabelValues(stage, category).Inc()
+}
@@ -0,0 +1,233 @@
+package loadtest
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/constants"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-service/txintent"
+	"github.com/ethereum-optimism/optimism/op-service/txplan"
+	suptypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+)
+
+// bridgeTokenInitCode deploys a minimal ERC-7802 token to bridge in TestTokenBridge. It is
+// hand-assembled, since the token is only needed by the load test:
+//
+//	PUSH1 0 CALLDATALOAD PUSH1 0xe0 SHR                    ; selector
+//	  DUP1 PUSH4 0x70a08231 EQ PUSH2 balanceOf JUMPI       ; balanceOf(address)
+//	  DUP1 PUSH4 0x18160ddd EQ PUSH2 totalSupply JUMPI     ; totalSupply()
+//	  DUP1 PUSH4 0x40c10f19 EQ PUSH2 mint JUMPI            ; mint(address,uint256)
+//	  DUP1 PUSH4 0x18bf5077 EQ PUSH2 crosschainMint JUMPI  ; crosschainMint(address,uint256)
+//	  DUP1 PUSH4 0x2b8c49e3 EQ PUSH2 crosschainBurn JUMPI  ; crosschainBurn(address,uint256)
+//	  DUP1 PUSH4 0x01ffc9a7 EQ PUSH2 supports JUMPI        ; supportsInterface(bytes4)
+//	revert:         PUSH1 0 DUP1 REVERT
+//	balanceOf:      PUSH1 4 CALLDATALOAD SLOAD, return it
+//	totalSupply:    PUSH1 1 PUSH1 0xff SHL SLOAD, return it
+//	crosschainMint: CALLER PUSH20 bridge EQ ISZERO PUSH2 revert JUMPI, continue with mint
+//	mint:           balance[to] += amount, supply += amount, STOP
+//	crosschainBurn: CALLER PUSH20 bridge EQ ISZERO PUSH2 revert JUMPI,
+//	                revert if balance[from] < amount, balance[from] -= amount, supply -= amount, STOP
+//	supports:       return interfaceID == 0x33331994 (IERC7802) || interfaceID == 0x01ffc9a7 (IERC165)
+//
+// The balance of an address is stored at the slot of the address, and the total supply at slot
+// 2**255. Anyone can mint, to fund the senders. Only the SuperchainTokenBridge can mint and burn
+// across chains. The token has no transfers and emits no events.
+const bridgeTokenInitCode = "0x6100fc80600c6000396000f360003560e01c806370a082311461004d57806318160ddd1461005a57806340c10f191461008657806318bf5077146100695780632b8c49e31461009f57806301ffc9a7146100de575b600080fd5b6004355460005260206000f35b600160ff1b5460005260206000f35b337342000000000000000000000000000000000000281415610048575b600435602435808254018255600160ff1b805482019055005b33734200000000000000000000000000000000000028141561004857600435602435815481811061004857038155602435600160ff1b80548290039055005b60043560e01c80633333199414906301ffc9a7141760005260206000f3"
+
+const (
+	// bridgeTokenFundingBatch is the number of senders funded per transaction.
+	bridgeTokenFundingBatch = 100
+	// maxBridgeAmount bounds the amount of a transfer. The amounts vary, so that a transfer that
+	// is relayed with the amount of another transfer breaks the supply invariant.
+	maxBridgeAmount = 1000
+)
+
+var (
+	create2Deploy     = w3.MustNewFunc("deploy(uint256 value, bytes32 salt, bytes code)", "")
+	bridgeTokenMint   = w3.MustNewFunc("mint(address to, uint256 amount)", "")
+	bridgeTokenSupply = w3.MustNewFunc("totalSupply()", "uint256")
+	sendERC20         = w3.MustNewFunc("sendERC20(address token, address to, uint256 amount, uint256 chainId)", "bytes32")
+
+	// bridgeTokenFunding is the balance of every sender on every chain, enough to never run out.
+	bridgeTokenFunding = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+// TokenBridge bridges an ERC-7802 token between chains through the SuperchainTokenBridge, and
+// tracks the transfers to check that the token supply across the chains is conserved.
+type TokenBridge struct {
+	// Token is the address of the token, which is the same on every chain.
+	Token  common.Address
+	chains []*L2
+
+	// transfers is the number of transfers so far, to vary the amounts.
+	transfers atomic.Uint64
+
+	mu sync.Mutex
+	// supply is the total supply of the token across the chains after funding.
+	supply *big.Int
+	// sent and relayed are the amounts of the transfers that were burned on the source chain and
+	// minted on the destination chain.
+	sent, relayed *big.Int
+	// unconfirmedSent and unconfirmedRelayed are the amounts of the transactions that failed to
+	// confirm, e.g., because the test ended. They may or may not be included.
+	unconfirmedSent, unconfirmedRelayed *big.Int
+}
+
+// NewTokenBridge deploys the token at the same address on every chain, and funds every sender
+// account of the chains with it.
+func NewTokenBridge(ctx context.Context, t devtest.T, chains []*L2) *TokenBridge {
+	// A new token for every run, so the supply starts at zero.
+	var salt common.Hash
+	_, err := rand.Read(salt[:])
+	t.Require().NoError(err)
+	initCode := common.FromHex(bridgeTokenInitCode)
+	deployData, err := create2Deploy.EncodeArgs(new(big.Int), salt, initCode)
+	t.Require().NoError(err)
+	deployer := constants.Create2Deployer
+	b := &TokenBridge{
+		Token:              crypto.CreateAddress2(deployer, salt, crypto.Keccak256(initCode)),
+		chains:             chains,
+		sent:               new(big.Int),
+		relayed:            new(big.Int),
+		unconfirmedSent:    new(big.Int),
+		unconfirmedRelayed: new(big.Int),
+	}
+	for _, l2 := range chains {
+		_, err := l2.Include(ctx, t, txplan.WithTo(&deployer), txplan.WithData(deployData))
+		t.Require().NoError(err)
+		b.fund(ctx, t, l2)
+	}
+	b.supply = b.totalSupply(ctx, t)
+	return b
+}
+
+// bridgeTokenMintCall mints the token, to batch the funding through the MultiCall3.
+type bridgeTokenMintCall struct {
+	token  common.Address
+	to     common.Address
+	amount *big.Int
+}
+
+var _ txintent.Call = (*bridgeTokenMintCall)(nil)
+
+func (c *bridgeTokenMintCall) To() (*common.Address, error) {
+	return &c.token, nil
+}
+
+func (c *bridgeTokenMintCall) EncodeInput() ([]byte, error) {
+	return bridgeTokenMint.EncodeArgs(c.to, c.amount)
+}
+
+func (c *bridgeTokenMintCall) AccessList() (ethtypes.AccessList, error) {
+	return nil, nil
+}
+
+// fund mints bridgeTokenFunding to every sender account of the chain, in batches through the
+// MultiCall3.
+func (b *TokenBridge) fund(ctx context.Context, t devtest.T, l2 *L2) {
+	for start := 0; start < len(l2.EOAs.items); start += bridgeTokenFundingBatch {
+		var calls []txintent.Call
+		for _, eoa := range l2.EOAs.items[start:min(start+bridgeTokenFundingBatch, len(l2.EOAs.items))] {
+			calls = append(calls, &bridgeTokenMintCall{token: b.Token, to: eoa.Address, amount: bridgeTokenFunding})
+		}
+		_, err := l2.Include(ctx, t, planCall(t, &txintent.MultiTrigger{Emitter: constants.MultiCall3, Calls: calls}))
+		t.Require().NoError(err)
+	}
+}
+
+// totalSupply returns the sum of the total supply of the token on every chain.
+func (b *TokenBridge) totalSupply(ctx context.Context, t devtest.T) *big.Int {
+	data, err := bridgeTokenSupply.EncodeArgs()
+	t.Require().NoError(err)
+	total := new(big.Int)
+	for _, l2 := range b.chains {
+		out, err := l2.EL.Escape().EthClient().Call(ctx, ethereum.CallMsg{To: &b.Token, Data: data})
+		t.Require().NoError(err)
+		var supply *big.Int
+		t.Require().NoError(bridgeTokenSupply.DecodeReturns(out, &supply))
+		total.Add(total, supply)
+	}
+	return total
+}
+
+func (b *TokenBridge) add(amount *big.Int, to *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	to.Add(to, amount)
+}
+
+// Transfer bridges the token from a sender account of the source chain to the same account on
+// the destination chain: the SuperchainTokenBridge burns the token and sends a message on the
+// source chain, and the message is relayed to mint the token on the destination chain.
+func (b *TokenBridge) Transfer(ctx context.Context, t devtest.T, source, dest *L2) error {
+	inFlightMessages.Inc()
+	defer func() {
+		inFlightMessages.Dec()
+	}()
+	startE2E := time.Now()
+
+	sender := source.EOAs.Next()
+	amount := new(big.Int).SetUint64(1 + b.transfers.Add(1)%maxBridgeAmount)
+	data, err := sendERC20.EncodeArgs(b.Token, source.EOAs.At(sender).Address, amount, dest.EL.ChainID().ToBig())
+	t.Require().NoError(err)
+	bridge := constants.SuperchainTokenBridge
+	out, logs, err := includeInit(ctx, t, source, sender, txplan.WithTo(&bridge), txplan.WithData(data))
+	if err != nil {
+		b.add(amount, b.unconfirmedSent)
+		return err
+	}
+	b.add(amount, b.sent)
+
+	// The transaction also has the logs of the token and the bridge: relay the message of the
+	// messenger.
+	var relay *txintent.RelayTrigger
+	for i, msg := range out.Entries {
+		if msg.Identifier.Origin == constants.L2ToL2CrossDomainMessenger {
+			relay = &txintent.RelayTrigger{
+				ExecTrigger: txintent.ExecTrigger{Executor: constants.L2ToL2CrossDomainMessenger, Msg: msg},
+				Payload:     suptypes.LogToMessagePayload(logs[i]),
+			}
+		}
+	}
+	t.Require().NotNil(relay, "sendERC20 must send a message")
+	if err := includeExec(ctx, t, source, dest, relay.Msg, planCall(t, relay)); err != nil {
+		b.add(amount, b.unconfirmedRelayed)
+		return err
+	}
+	b.add(amount, b.relayed)
+	messageLatency.WithLabelValues("e2e").Observe(time.Since(startE2E).Seconds())
+	return nil
+}
+
+// CheckSupply checks that the supply of the token across the chains is conserved: the supply
+// that was burned and not minted again must be the amount of the transfers that were sent and
+// not relayed, allowing for the transactions that failed to confirm. It returns a report of the
+// transfers.
+func (b *TokenBridge) CheckSupply(ctx context.Context, t devtest.T) string {
+	supply := b.totalSupply(ctx, t)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	inFlight := new(big.Int).Sub(b.supply, supply)
+	t.Require().GreaterOrEqual(inFlight.Sign(), 0, "the bridge minted %s more than it burned", new(big.Int).Neg(inFlight))
+	// Unconfirmed relays that were included lower the supply in flight, and unconfirmed sends that
+	// were included raise it.
+	lower := new(big.Int).Sub(b.sent, b.relayed)
+	lower.Sub(lower, b.unconfirmedRelayed)
+	upper := new(big.Int).Sub(b.sent, b.relayed)
+	upper.Add(upper, b.unconfirmedSent)
+	t.Require().Truef(inFlight.Cmp(lower) >= 0 && inFlight.Cmp(upper) <= 0,
+		"the supply in flight is %s, expected between %s and %s", inFlight, lower, upper)
+	return fmt.Sprintf("Token bridge results: sent %s, relayed %s, unconfirmed sends %s and relays %s, supply in flight %s",
+		b.sent, b.relayed, b.unconfirmedSent, b.unconfirmedRelayed, inFlight)
+}
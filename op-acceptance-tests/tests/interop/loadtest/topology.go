@@ -0,0 +1,60 @@
+package loadtest
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topology describes which chains send messages to which other chains.
+type Topology string
+
+const (
+	// TopologyRing sends messages from every chain to the next one, and from the last chain to the
+	// first one.
+	TopologyRing Topology = "ring"
+	// TopologyStar sends messages from the first chain (the hub) to every other chain, and from
+	// every other chain to the hub.
+	TopologyStar Topology = "star"
+	// TopologyMesh sends messages from every chain to every other chain.
+	TopologyMesh Topology = "mesh"
+)
+
+// ParseTopology parses the name of a topology, case-insensitively.
+func ParseTopology(name string) (Topology, error) {
+	switch t := Topology(strings.ToLower(name)); t {
+	case TopologyRing, TopologyStar, TopologyMesh:
+		return t, nil
+	default:
+		return "", fmt.Errorf("unknown topology %q, must be one of %s, %s or %s", name, TopologyRing, TopologyStar, TopologyMesh)
+	}
+}
+
+// Destinations returns the indices of the destination chains of every chain, by the index of the
+// source chain, for a network of n chains. A chain is never its own destination.
+func (t Topology) Destinations(n int) [][]int {
+	out := make([][]int, n)
+	if n < 2 {
+		return out
+	}
+	for source := range out {
+		switch t {
+		case TopologyRing:
+			out[source] = []int{(source + 1) % n}
+		case TopologyStar:
+			if source != 0 {
+				out[source] = []int{0}
+				continue
+			}
+			for dest := 1; dest < n; dest++ {
+				out[source] = append(out[source], dest)
+			}
+		case TopologyMesh:
+			for dest := 0; dest < n; dest++ {
+				if dest != source {
+					out[source] = append(out[source], dest)
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,160 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/contracts/constants"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txintent"
+	"github.com/ethereum-optimism/optimism/op-service/txplan"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+)
+
+// Workload generates the destination-side work of the executing messages. The executing
+// transaction validates the message and then makes the calls of the workload, like an application
+// that acts on the message would.
+type Workload interface {
+	// Deploy prepares the workload on the destination chain, e.g., by deploying contracts.
+	Deploy(ctx context.Context, t devtest.T, dest *L2)
+	// PlanExec plans the executing transaction of the message.
+	PlanExec(t devtest.T, exec *txintent.ExecTrigger) txplan.Option
+}
+
+// NewWorkload returns the workload with the given name, as selected with
+// NAT_INTEROP_LOADTEST_WORKLOAD.
+func NewWorkload(name string) (Workload, error) {
+	switch name {
+	case "", "minimal":
+		return new(minimalWorkload), nil
+	case "erc20":
+		return new(erc20Workload), nil
+	case "storage":
+		return &storageWorkload{slotsPerMessage: 10}, nil
+	default:
+		return nil, fmt.Errorf("unknown workload %q, expected one of minimal, erc20, storage", name)
+	}
+}
+
+// minimalWorkload only validates the message, to measure the overhead of interop itself.
+type minimalWorkload struct{}
+
+var _ Workload = (*minimalWorkload)(nil)
+
+func (w *minimalWorkload) Deploy(context.Context, devtest.T, *L2) {}
+
+func (w *minimalWorkload) PlanExec(t devtest.T, exec *txintent.ExecTrigger) txplan.Option {
+	return planCall(t, exec)
+}
+
+// erc20Workload mints WETH and transfers it to a new recipient for every message, like a token
+// bridge crediting a user. Every transfer writes a new balance to storage.
+type erc20Workload struct {
+	weth common.Address
+	// recipients is the number of recipients so far, to send every transfer to a new recipient
+	recipients atomic.Uint64
+}
+
+var _ Workload = (*erc20Workload)(nil)
+
+var (
+	wethDeposit  = w3.MustNewFunc("deposit()", "")
+	wethTransfer = w3.MustNewFunc("transfer(address dst, uint256 wad)", "bool")
+)
+
+func (w *erc20Workload) Deploy(ctx context.Context, t devtest.T, dest *L2) {
+	tx, err := dest.Include(ctx, t, txplan.WithData(common.FromHex(bindings.WETH9Bin)))
+	t.Require().NoError(err)
+	w.weth = tx.Receipt.ContractAddress
+}
+
+func (w *erc20Workload) PlanExec(t devtest.T, exec *txintent.ExecTrigger) txplan.Option {
+	// Offset the recipients, to not collide with precompiles and predeploys.
+	recipient := common.BigToAddress(new(big.Int).SetUint64(1<<32 + w.recipients.Add(1)))
+	amount := big.NewInt(1)
+	deposit, err := wethDeposit.EncodeArgs()
+	t.Require().NoError(err)
+	transfer, err := wethTransfer.EncodeArgs(recipient, amount)
+	t.Require().NoError(err)
+	return planMulticall(t, exec,
+		multicallCall{Target: w.weth, Value: amount, CallData: deposit},
+		multicallCall{Target: w.weth, CallData: transfer},
+	)
+}
+
+// storageWorkload writes new storage slots for every message, to simulate storage-heavy
+// applications.
+type storageWorkload struct {
+	setter          common.Address
+	slotsPerMessage uint64
+	// slots is the number of slots written so far, to write new slots for every message
+	slots atomic.Uint64
+}
+
+var _ Workload = (*storageWorkload)(nil)
+
+var setBytes32 = w3.MustNewFunc("setBytes32((bytes32 key, bytes32 value)[] slots)", "")
+
+func (w *storageWorkload) Deploy(ctx context.Context, t devtest.T, dest *L2) {
+	tx, err := dest.Include(ctx, t, txplan.WithData(common.FromHex(bindings.StorageSetterBin)))
+	t.Require().NoError(err)
+	w.setter = tx.Receipt.ContractAddress
+}
+
+func (w *storageWorkload) PlanExec(t devtest.T, exec *txintent.ExecTrigger) txplan.Option {
+	type slot struct {
+		Key   [32]byte
+		Value [32]byte
+	}
+	last := w.slots.Add(w.slotsPerMessage)
+	slots := make([]slot, 0, w.slotsPerMessage)
+	for i := last - w.slotsPerMessage; i < last; i++ {
+		slots = append(slots, slot{
+			Key:   common.BigToHash(new(big.Int).SetUint64(i)),
+			Value: exec.Msg.PayloadHash,
+		})
+	}
+	data, err := setBytes32.EncodeArgs(slots)
+	t.Require().NoError(err)
+	return planMulticall(t, exec, multicallCall{Target: w.setter, CallData: data})
+}
+
+// multicallCall is a call of the Multicall3 aggregate3Value method.
+type multicallCall struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+var aggregate3Value = w3.MustNewFunc("aggregate3Value((address target, bool allowFailure, uint256 value, bytes callData)[])", "(bool, bytes)[]")
+
+// planMulticall plans a transaction that executes the message and then makes the calls, through
+// Multicall3.
+func planMulticall(t devtest.T, exec *txintent.ExecTrigger, calls ...multicallCall) txplan.Option {
+	execData, err := exec.EncodeInput()
+	t.Require().NoError(err)
+	accessList, err := exec.AccessList()
+	t.Require().NoError(err)
+	all := append([]multicallCall{{Target: exec.Executor, CallData: execData}}, calls...)
+	value := new(big.Int)
+	for i := range all {
+		if all[i].Value == nil {
+			all[i].Value = new(big.Int)
+		}
+		value.Add(value, all[i].Value)
+	}
+	data, err := aggregate3Value.EncodeArgs(all)
+	t.Require().NoError(err)
+	multicall := constants.MultiCall3
+	return txplan.Combine(
+		txplan.WithTo(&multicall),
+		txplan.WithValue(value),
+		txplan.WithData(data),
+		txplan.WithAccessList(accessList),
+	)
+}
@@ -0,0 +1,397 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/superchain"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// Status is the outcome of a single check.
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusFail    Status = "fail"
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the outcome of a single check against a single contract.
+type Result struct {
+	Check    string `json:"check"`
+	Contract string `json:"contract"`
+	Status   Status `json:"status"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Report is the conformance report of a chain deployment against its registry entry.
+type Report struct {
+	Chain     string   `json:"chain"`
+	ChainID   uint64   `json:"chainId"`
+	Artifacts string   `json:"artifacts,omitempty"`
+	Passed    int      `json:"passed"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped"`
+	Results   []Result `json:"results"`
+}
+
+// OK returns true if none of the checks failed.
+func (r *Report) OK() bool {
+	return r.Failed == 0
+}
+
+func (r *Report) add(res Result) {
+	switch res.Status {
+	case StatusPass:
+		r.Passed++
+	case StatusFail:
+		r.Failed++
+	case StatusSkipped:
+		r.Skipped++
+	}
+	r.Results = append(r.Results, res)
+}
+
+// L1Client is the subset of the L1 RPC that is used to inspect the deployment.
+type L1Client interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// ArtifactSource provides the forge artifacts of the expected contracts release.
+type ArtifactSource interface {
+	ReadArtifact(name string, contract string) (*foundry.Artifact, error)
+}
+
+type Config struct {
+	Log log.Logger
+	L1  L1Client
+	// Artifacts is optional. If nil, the deployed code is not checked.
+	Artifacts ArtifactSource
+	// ArtifactsName describes the artifacts in the report, e.g. the release tag.
+	ArtifactsName string
+}
+
+type proxyKind int
+
+const (
+	notProxy proxyKind = iota
+	// eip1967Proxy covers the regular Proxy, as well as the L1ChugSplashProxy, which uses the same storage slots.
+	eip1967Proxy
+	// resolvedDelegateProxy is the legacy L1CrossDomainMessenger proxy, which resolves the implementation
+	// through the AddressManager.
+	resolvedDelegateProxy
+)
+
+type contract struct {
+	name string
+	addr *common.Address
+	// artifact is the name of the contract that is expected to be deployed (behind the proxy, if any).
+	// Empty if the code is not checked.
+	artifact string
+	proxy    proxyKind
+}
+
+func contracts(addrs *superchain.AddressesConfig) []contract {
+	return []contract{
+		{name: "AddressManager", addr: addrs.AddressManager},
+		{name: "ProxyAdmin", addr: addrs.ProxyAdmin, artifact: "ProxyAdmin"},
+		{name: "L1CrossDomainMessengerProxy", addr: addrs.L1CrossDomainMessengerProxy, artifact: "L1CrossDomainMessenger", proxy: resolvedDelegateProxy},
+		{name: "L1ERC721BridgeProxy", addr: addrs.L1ERC721BridgeProxy, artifact: "L1ERC721Bridge", proxy: eip1967Proxy},
+		{name: "L1StandardBridgeProxy", addr: addrs.L1StandardBridgeProxy, artifact: "L1StandardBridge", proxy: eip1967Proxy},
+		{name: "OptimismMintableERC20FactoryProxy", addr: addrs.OptimismMintableERC20FactoryProxy, artifact: "OptimismMintableERC20Factory", proxy: eip1967Proxy},
+		{name: "OptimismPortalProxy", addr: addrs.OptimismPortalProxy, artifact: "OptimismPortal2", proxy: eip1967Proxy},
+		{name: "SystemConfigProxy", addr: addrs.SystemConfigProxy, artifact: "SystemConfig", proxy: eip1967Proxy},
+		{name: "L2OutputOracleProxy", addr: addrs.L2OutputOracleProxy, artifact: "L2OutputOracle", proxy: eip1967Proxy},
+		{name: "DisputeGameFactoryProxy", addr: addrs.DisputeGameFactoryProxy, artifact: "DisputeGameFactory", proxy: eip1967Proxy},
+		{name: "AnchorStateRegistryProxy", addr: addrs.AnchorStateRegistryProxy, artifact: "AnchorStateRegistry", proxy: eip1967Proxy},
+		{name: "DelayedWETHProxy", addr: addrs.DelayedWETHProxy, artifact: "DelayedWETH", proxy: eip1967Proxy},
+		{name: "FaultDisputeGame", addr: addrs.FaultDisputeGame, artifact: "FaultDisputeGame"},
+		{name: "PermissionedDisputeGame", addr: addrs.PermissionedDisputeGame, artifact: "PermissionedDisputeGame"},
+		{name: "MIPS", addr: addrs.MIPS, artifact: "MIPS64"},
+		{name: "PreimageOracle", addr: addrs.PreimageOracle, artifact: "PreimageOracle"},
+		// The SuperchainConfig is shared between chains, and managed by the superchain ProxyAdmin.
+		{name: "SuperchainConfig", addr: addrs.SuperchainConfig},
+		{name: "DAChallengeAddress", addr: addrs.DAChallengeAddress},
+	}
+}
+
+// Verify checks the deployment of the given chain on L1 against its registry entry:
+// the code and proxy admin of every registered contract, the roles, and key parameters.
+// Failing checks are recorded in the report, the returned error is only used for unexpected failures.
+func Verify(ctx context.Context, cfg *Config, chainCfg *superchain.ChainConfig, sc superchain.Superchain) (*Report, error) {
+	v := &verifier{
+		cfg:    cfg,
+		report: &Report{Chain: chainCfg.Name, ChainID: chainCfg.ChainID, Artifacts: cfg.ArtifactsName},
+	}
+	addrs := &chainCfg.Addresses
+	for _, c := range contracts(addrs) {
+		if c.addr == nil {
+			continue
+		}
+		if err := v.checkContract(ctx, c, addrs); err != nil {
+			return nil, err
+		}
+	}
+	v.checkRoles(ctx, chainCfg)
+	v.checkParameters(ctx, chainCfg, sc)
+	return v.report, nil
+}
+
+type verifier struct {
+	cfg    *Config
+	report *Report
+}
+
+func (v *verifier) pass(check string, contract string, expected string) {
+	v.report.add(Result{Check: check, Contract: contract, Status: StatusPass, Expected: expected, Actual: expected})
+}
+
+func (v *verifier) fail(check string, contract string, expected string, actual string, msg string) {
+	v.cfg.Log.Warn("Check failed", "check", check, "contract", contract, "expected", expected, "actual", actual, "msg", msg)
+	v.report.add(Result{Check: check, Contract: contract, Status: StatusFail, Expected: expected, Actual: actual, Message: msg})
+}
+
+func (v *verifier) skip(check string, contract string, msg string) {
+	v.report.add(Result{Check: check, Contract: contract, Status: StatusSkipped, Message: msg})
+}
+
+func (v *verifier) checkContract(ctx context.Context, c contract, addrs *superchain.AddressesConfig) error {
+	code, err := v.cfg.L1.CodeAt(ctx, *c.addr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get code of %s at %s: %w", c.name, c.addr, err)
+	}
+	if len(code) == 0 {
+		v.fail("code", c.name, "contract code", "no code", fmt.Sprintf("no code at %s", c.addr))
+		return nil
+	}
+	v.pass("code", c.name, c.addr.String())
+
+	impl := *c.addr
+	implCode := code
+	switch c.proxy {
+	case eip1967Proxy:
+		if addrs.ProxyAdmin == nil {
+			v.skip("proxy-admin", c.name, "no ProxyAdmin registered")
+		} else {
+			admin, err := v.storageAddress(ctx, *c.addr, genesis.AdminSlot)
+			if err != nil {
+				return fmt.Errorf("failed to read admin of %s: %w", c.name, err)
+			}
+			v.compareAddress("proxy-admin", c.name, *addrs.ProxyAdmin, admin)
+		}
+		impl, err = v.storageAddress(ctx, *c.addr, genesis.ImplementationSlot)
+		if err != nil {
+			return fmt.Errorf("failed to read implementation of %s: %w", c.name, err)
+		}
+	case resolvedDelegateProxy:
+		if addrs.AddressManager == nil {
+			v.skip("implementation", c.name, "no AddressManager registered")
+			return nil
+		}
+		name := make([]byte, 64)
+		name[31] = 0x20 // offset of the string
+		name[63] = byte(len("OVM_L1CrossDomainMessenger"))
+		name = append(name, common.RightPadBytes([]byte("OVM_L1CrossDomainMessenger"), 32)...)
+		impl, err = v.callAddress(ctx, *addrs.AddressManager, "getAddress(string)", name)
+		if err != nil {
+			v.fail("implementation", c.name, "", "", fmt.Sprintf("failed to resolve implementation: %v", err))
+			return nil
+		}
+	}
+	if c.proxy != notProxy {
+		if impl == (common.Address{}) {
+			v.fail("implementation", c.name, "implementation address", impl.String(), "proxy is not initialized")
+			return nil
+		}
+		v.pass("implementation", c.name, impl.String())
+		implCode, err = v.cfg.L1.CodeAt(ctx, impl, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get code of %s implementation at %s: %w", c.name, impl, err)
+		}
+	}
+
+	if c.artifact == "" {
+		return nil
+	}
+	if v.cfg.Artifacts == nil {
+		v.skip("code-hash", c.name, "no artifacts configured")
+		return nil
+	}
+	artifact, err := v.cfg.Artifacts.ReadArtifact(c.artifact+".sol", c.artifact)
+	if err != nil {
+		v.skip("code-hash", c.name, fmt.Sprintf("no artifact for %s: %v", c.artifact, err))
+		return nil
+	}
+	expected, actual, err := maskImmutables(artifact, implCode)
+	if err != nil {
+		v.fail("code-hash", c.name, "", "", err.Error())
+		return nil
+	}
+	expectedHash, actualHash := crypto.Keccak256Hash(expected), crypto.Keccak256Hash(actual)
+	if expectedHash != actualHash {
+		v.fail("code-hash", c.name, expectedHash.String(), actualHash.String(),
+			fmt.Sprintf("code at %s does not match artifact %s", impl, c.artifact))
+		return nil
+	}
+	v.pass("code-hash", c.name, expectedHash.String())
+	return nil
+}
+
+// maskImmutables zeroes the immutable values in both the code of the artifact and the deployed code,
+// since these are set at deployment time. The remaining code must match exactly.
+func maskImmutables(artifact *foundry.Artifact, deployed []byte) (expected []byte, actual []byte, err error) {
+	expected = bytes.Clone(artifact.DeployedBytecode.Object)
+	actual = bytes.Clone(deployed)
+	if len(expected) != len(actual) {
+		return nil, nil, fmt.Errorf("code size %d does not match artifact code size %d", len(actual), len(expected))
+	}
+	if len(artifact.DeployedBytecode.ImmutableReferences) == 0 {
+		return expected, actual, nil
+	}
+	var refs map[string][]struct {
+		Start  int `json:"start"`
+		Length int `json:"length"`
+	}
+	if err := json.Unmarshal(artifact.DeployedBytecode.ImmutableReferences, &refs); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode immutable references: %w", err)
+	}
+	for _, ranges := range refs {
+		for _, r := range ranges {
+			if r.Start < 0 || r.Length < 0 || r.Start+r.Length > len(expected) {
+				return nil, nil, errors.New("immutable reference out of bounds")
+			}
+			clear(expected[r.Start : r.Start+r.Length])
+			clear(actual[r.Start : r.Start+r.Length])
+		}
+	}
+	return expected, actual, nil
+}
+
+func (v *verifier) checkRoles(ctx context.Context, chainCfg *superchain.ChainConfig) {
+	roles := &chainCfg.Roles
+	addrs := &chainCfg.Addresses
+	v.checkGetter(ctx, "ProxyAdminOwner", "ProxyAdmin", addrs.ProxyAdmin, "owner()", roles.ProxyAdminOwner)
+	v.checkGetter(ctx, "AddressManagerOwner", "AddressManager", addrs.AddressManager, "owner()", addrs.ProxyAdmin)
+	v.checkGetter(ctx, "SystemConfigOwner", "SystemConfigProxy", addrs.SystemConfigProxy, "owner()", roles.SystemConfigOwner)
+	v.checkGetter(ctx, "Guardian", "SuperchainConfig", addrs.SuperchainConfig, "guardian()", roles.Guardian)
+	v.checkGetter(ctx, "Challenger", "PermissionedDisputeGame", addrs.PermissionedDisputeGame, "challenger()", roles.Challenger)
+	v.checkGetter(ctx, "Proposer", "PermissionedDisputeGame", addrs.PermissionedDisputeGame, "proposer()", roles.Proposer)
+	v.checkGetter(ctx, "UnsafeBlockSigner", "SystemConfigProxy", addrs.SystemConfigProxy, "unsafeBlockSigner()", roles.UnsafeBlockSigner)
+
+	const check = "BatchSubmitter"
+	switch {
+	case addrs.SystemConfigProxy == nil || roles.BatchSubmitter == nil:
+		v.skip(check, "SystemConfigProxy", "not registered")
+	default:
+		hash, err := v.call(ctx, *addrs.SystemConfigProxy, "batcherHash()")
+		if err != nil {
+			v.fail(check, "SystemConfigProxy", roles.BatchSubmitter.String(), "", err.Error())
+			return
+		}
+		v.compareAddress(check, "SystemConfigProxy", *roles.BatchSubmitter, common.BytesToAddress(hash))
+	}
+}
+
+func (v *verifier) checkParameters(ctx context.Context, chainCfg *superchain.ChainConfig, sc superchain.Superchain) {
+	addrs := &chainCfg.Addresses
+	batchInbox := chainCfg.BatchInboxAddr
+	v.checkGetter(ctx, "BatchInbox", "SystemConfigProxy", addrs.SystemConfigProxy, "batchInbox()", &batchInbox)
+
+	// The SystemConfig is the entrypoint to the other L1 contracts of the chain.
+	v.checkGetter(ctx, "OptimismPortal", "SystemConfigProxy", addrs.SystemConfigProxy, "optimismPortal()", addrs.OptimismPortalProxy)
+	v.checkGetter(ctx, "L1CrossDomainMessenger", "SystemConfigProxy", addrs.SystemConfigProxy, "l1CrossDomainMessenger()", addrs.L1CrossDomainMessengerProxy)
+	v.checkGetter(ctx, "L1StandardBridge", "SystemConfigProxy", addrs.SystemConfigProxy, "l1StandardBridge()", addrs.L1StandardBridgeProxy)
+	v.checkGetter(ctx, "L1ERC721Bridge", "SystemConfigProxy", addrs.SystemConfigProxy, "l1ERC721Bridge()", addrs.L1ERC721BridgeProxy)
+	v.checkGetter(ctx, "OptimismMintableERC20Factory", "SystemConfigProxy", addrs.SystemConfigProxy, "optimismMintableERC20Factory()", addrs.OptimismMintableERC20FactoryProxy)
+	v.checkGetter(ctx, "DisputeGameFactory", "SystemConfigProxy", addrs.SystemConfigProxy, "disputeGameFactory()", addrs.DisputeGameFactoryProxy)
+
+	// The chain must be part of the superchain of its network.
+	const check = "SuperchainConfig"
+	if addrs.SuperchainConfig == nil {
+		v.skip(check, "registry", "not registered")
+	} else if *addrs.SuperchainConfig != sc.SuperchainConfigAddr {
+		v.fail(check, "registry", sc.SuperchainConfigAddr.String(), addrs.SuperchainConfig.String(),
+			fmt.Sprintf("SuperchainConfig does not match the one of superchain %q", sc.Name))
+	} else {
+		v.pass(check, "registry", sc.SuperchainConfigAddr.String())
+	}
+	superchainConfig := sc.SuperchainConfigAddr
+	v.checkGetter(ctx, check, "OptimismPortalProxy", addrs.OptimismPortalProxy, "superchainConfig()", &superchainConfig)
+
+	// The registered dispute games must be the ones used by the DisputeGameFactory.
+	v.checkGetter(ctx, "GameImpl(0)", "DisputeGameFactoryProxy", addrs.DisputeGameFactoryProxy, "gameImpls(uint32)", addrs.FaultDisputeGame, gameType(0))
+	v.checkGetter(ctx, "GameImpl(1)", "DisputeGameFactoryProxy", addrs.DisputeGameFactoryProxy, "gameImpls(uint32)", addrs.PermissionedDisputeGame, gameType(1))
+}
+
+func gameType(typ uint32) []byte {
+	return common.LeftPadBytes(new(big.Int).SetUint64(uint64(typ)).Bytes(), 32)
+}
+
+// checkGetter checks that the address returned by the getter of the contract matches the expected address.
+// The check is skipped if either the contract or the expected address is not registered.
+func (v *verifier) checkGetter(ctx context.Context, check string, name string, addr *common.Address, sig string, expected *common.Address, args ...[]byte) {
+	if addr == nil || expected == nil {
+		v.skip(check, name, "not registered")
+		return
+	}
+	actual, err := v.callAddress(ctx, *addr, sig, args...)
+	if err != nil {
+		v.fail(check, name, expected.String(), "", fmt.Sprintf("failed to call %s: %v", sig, err))
+		return
+	}
+	v.compareAddress(check, name, *expected, actual)
+}
+
+func (v *verifier) compareAddress(check string, name string, expected common.Address, actual common.Address) {
+	if expected != actual {
+		v.fail(check, name, expected.String(), actual.String(), "")
+		return
+	}
+	v.pass(check, name, expected.String())
+}
+
+func (v *verifier) call(ctx context.Context, to common.Address, sig string, args ...[]byte) ([]byte, error) {
+	data := crypto.Keccak256([]byte(sig))[:4]
+	for _, arg := range args {
+		data = append(data, arg...)
+	}
+	res, err := v.cfg.L1.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 32 {
+		return nil, fmt.Errorf("unexpected result length %d", len(res))
+	}
+	return res[:32], nil
+}
+
+func (v *verifier) callAddress(ctx context.Context, to common.Address, sig string, args ...[]byte) (common.Address, error) {
+	res, err := v.call(ctx, to, sig, args...)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(res), nil
+}
+
+func (v *verifier) storageAddress(ctx context.Context, addr common.Address, slot common.Hash) (common.Address, error) {
+	res, err := v.cfg.L1.StorageAt(ctx, addr, slot, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(res), nil
+}
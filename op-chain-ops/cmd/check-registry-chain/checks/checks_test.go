@@ -0,0 +1,176 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/superchain"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubL1 struct {
+	code    map[common.Address][]byte
+	storage map[common.Address]map[common.Hash]common.Hash
+	// calls maps the contract and 4-byte selector to the call result
+	calls map[common.Address]map[[4]byte][]byte
+}
+
+func newStubL1() *stubL1 {
+	return &stubL1{
+		code:    make(map[common.Address][]byte),
+		storage: make(map[common.Address]map[common.Hash]common.Hash),
+		calls:   make(map[common.Address]map[[4]byte][]byte),
+	}
+}
+
+func (s *stubL1) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return s.code[account], nil
+}
+
+func (s *stubL1) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	v := s.storage[account][key]
+	return v[:], nil
+}
+
+func (s *stubL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	res, ok := s.calls[*call.To][[4]byte(call.Data[:4])]
+	if !ok {
+		return nil, fmt.Errorf("execution reverted")
+	}
+	return res, nil
+}
+
+func (s *stubL1) setStorage(addr common.Address, slot common.Hash, value common.Address) {
+	if s.storage[addr] == nil {
+		s.storage[addr] = make(map[common.Hash]common.Hash)
+	}
+	s.storage[addr][slot] = common.BytesToHash(value[:])
+}
+
+func (s *stubL1) setGetter(addr common.Address, sig string, value common.Address) {
+	if s.calls[addr] == nil {
+		s.calls[addr] = make(map[[4]byte][]byte)
+	}
+	s.calls[addr][[4]byte(crypto.Keccak256([]byte(sig))[:4])] = common.LeftPadBytes(value[:], 32)
+}
+
+type stubArtifacts map[string]*foundry.Artifact
+
+func (s stubArtifacts) ReadArtifact(name string, contract string) (*foundry.Artifact, error) {
+	a, ok := s[contract]
+	if !ok {
+		return nil, fmt.Errorf("artifact %s not found", contract)
+	}
+	return a, nil
+}
+
+func ptr(addr common.Address) *common.Address {
+	return &addr
+}
+
+func findResult(t *testing.T, report *Report, check string, contract string) Result {
+	for _, r := range report.Results {
+		if r.Check == check && r.Contract == contract {
+			return r
+		}
+	}
+	t.Fatalf("no result for check %s of %s", check, contract)
+	return Result{}
+}
+
+func TestMaskImmutables(t *testing.T) {
+	artifact := &foundry.Artifact{}
+	artifact.DeployedBytecode.Object = []byte{0x60, 0x00, 0x00, 0x00, 0x56}
+	artifact.DeployedBytecode.ImmutableReferences = json.RawMessage(`{"10":[{"start":1,"length":3}]}`)
+
+	expected, actual, err := maskImmutables(artifact, []byte{0x60, 0xaa, 0xbb, 0xcc, 0x56})
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	expected, actual, err = maskImmutables(artifact, []byte{0x61, 0xaa, 0xbb, 0xcc, 0x56})
+	require.NoError(t, err)
+	require.NotEqual(t, expected, actual)
+
+	_, _, err = maskImmutables(artifact, []byte{0x60})
+	require.ErrorContains(t, err, "code size")
+}
+
+func TestVerify(t *testing.T) {
+	proxyAdmin := common.Address{0xa0}
+	systemConfig := common.Address{0xa1}
+	systemConfigImpl := common.Address{0xa2}
+	owner := common.Address{0xb0}
+	batcher := common.Address{0xb1}
+	inbox := common.Address{0xff, 0x01}
+
+	l1 := newStubL1()
+	l1.code[proxyAdmin] = []byte{0x01}
+	l1.code[systemConfig] = []byte{0x02}
+	l1.code[systemConfigImpl] = []byte{0x60, 0x11, 0x56}
+	l1.setStorage(systemConfig, genesis.AdminSlot, proxyAdmin)
+	l1.setStorage(systemConfig, genesis.ImplementationSlot, systemConfigImpl)
+	l1.setGetter(proxyAdmin, "owner()", owner)
+	l1.setGetter(systemConfig, "owner()", common.Address{0xde, 0xad}) // wrong owner
+	l1.setGetter(systemConfig, "batcherHash()", batcher)
+	l1.setGetter(systemConfig, "batchInbox()", inbox)
+
+	artifact := &foundry.Artifact{}
+	artifact.DeployedBytecode.Object = []byte{0x60, 0x00, 0x56}
+	artifact.DeployedBytecode.ImmutableReferences = json.RawMessage(`{"1":[{"start":1,"length":1}]}`)
+
+	chainCfg := &superchain.ChainConfig{
+		Name:           "test",
+		ChainID:        901,
+		BatchInboxAddr: inbox,
+		Roles: superchain.RolesConfig{
+			ProxyAdminOwner:   ptr(owner),
+			SystemConfigOwner: ptr(owner),
+			BatchSubmitter:    ptr(batcher),
+			UnsafeBlockSigner: ptr(common.Address{0xb2}),
+		},
+		Addresses: superchain.AddressesConfig{
+			ProxyAdmin:        ptr(proxyAdmin),
+			SystemConfigProxy: ptr(systemConfig),
+		},
+	}
+	cfg := &Config{
+		Log:       testlog.Logger(t, log.LevelInfo),
+		L1:        l1,
+		Artifacts: stubArtifacts{"SystemConfig": artifact},
+	}
+	report, err := Verify(context.Background(), cfg, chainCfg, superchain.Superchain{Name: "test"})
+	require.NoError(t, err)
+
+	require.Equal(t, StatusPass, findResult(t, report, "proxy-admin", "SystemConfigProxy").Status)
+	require.Equal(t, StatusPass, findResult(t, report, "implementation", "SystemConfigProxy").Status)
+	require.Equal(t, StatusPass, findResult(t, report, "code-hash", "SystemConfigProxy").Status)
+	require.Equal(t, StatusSkipped, findResult(t, report, "code-hash", "ProxyAdmin").Status, "no artifact")
+	require.Equal(t, StatusPass, findResult(t, report, "ProxyAdminOwner", "ProxyAdmin").Status)
+	require.Equal(t, StatusPass, findResult(t, report, "BatchSubmitter", "SystemConfigProxy").Status)
+	require.Equal(t, StatusPass, findResult(t, report, "BatchInbox", "SystemConfigProxy").Status)
+	require.Equal(t, StatusSkipped, findResult(t, report, "Guardian", "SuperchainConfig").Status)
+
+	res := findResult(t, report, "SystemConfigOwner", "SystemConfigProxy")
+	require.Equal(t, StatusFail, res.Status)
+	require.Equal(t, owner.String(), res.Expected)
+	require.Equal(t, common.Address{0xde, 0xad}.String(), res.Actual)
+
+	// getters that revert fail the check
+	res = findResult(t, report, "UnsafeBlockSigner", "SystemConfigProxy")
+	require.Equal(t, StatusFail, res.Status)
+	require.Contains(t, res.Message, "execution reverted")
+	require.False(t, report.OK())
+	require.Equal(t, len(report.Results), report.Passed+report.Failed+report.Skipped)
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/superchain"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/cmd/check-registry-chain/checks"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-deployer/pkg/deployer/artifacts"
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const prefix = "CHECK_REGISTRY_CHAIN"
+
+var (
+	ChainFlag = &cli.StringFlag{
+		Name:     "chain",
+		Usage:    "Name of the chain in the superchain-registry, e.g. op-mainnet",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "CHAIN"),
+		Required: true,
+	}
+	L1RPCFlag = &cli.StringFlag{
+		Name:     "l1-rpc-url",
+		Usage:    "L1 execution RPC endpoint of the network the chain is deployed on",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "L1_RPC_URL"),
+		Required: true,
+	}
+	ArtifactsLocatorFlag = &cli.StringFlag{
+		Name: "artifacts-locator",
+		Usage: "Locator of the contract artifacts of the expected release, e.g. tag://op-contracts/v4.0.0 or file:///path/to/forge-artifacts. " +
+			"If not set, the deployed code is not checked.",
+		EnvVars: op_service.PrefixEnvVar(prefix, "ARTIFACTS_LOCATOR"),
+	}
+	CacheDirFlag = &cli.StringFlag{
+		Name:    "cache-dir",
+		Usage:   "Directory to download the artifacts to. A temporary directory is used if not set.",
+		EnvVars: op_service.PrefixEnvVar(prefix, "CACHE_DIR"),
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:    "output",
+		Usage:   "Path to write the JSON conformance report to, or - for stdout",
+		EnvVars: op_service.PrefixEnvVar(prefix, "OUTPUT"),
+		Value:   "-",
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Name = "check-registry-chain"
+	app.Usage = "Verifies the L1 deployment of a chain against its superchain-registry entry."
+	app.Flags = append([]cli.Flag{
+		ChainFlag,
+		L1RPCFlag,
+		ArtifactsLocatorFlag,
+		CacheDirFlag,
+		OutputFlag,
+	}, oplog.CLIFlags(prefix)...)
+	app.Action = run
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("Application failed", "err", err)
+	}
+}
+
+func run(c *cli.Context) error {
+	logger := oplog.NewLogger(os.Stderr, oplog.ReadCLIConfig(c))
+	ctx := ctxinterrupt.WithCancelOnInterrupt(c.Context)
+
+	name := c.String(ChainFlag.Name)
+	chainID, err := superchain.ChainIDByName(name)
+	if err != nil {
+		return fmt.Errorf("unknown chain %q: %w", name, err)
+	}
+	chain, err := superchain.GetChain(chainID)
+	if err != nil {
+		return fmt.Errorf("failed to load chain %q: %w", name, err)
+	}
+	chainCfg, err := chain.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load config of chain %q: %w", name, err)
+	}
+	sc, err := superchain.GetSuperchain(chain.Network)
+	if err != nil {
+		return fmt.Errorf("failed to load superchain %q: %w", chain.Network, err)
+	}
+
+	l1Cl, err := ethclient.DialContext(ctx, c.String(L1RPCFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1Cl.Close()
+	l1ChainID, err := l1Cl.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get L1 chain ID: %w", err)
+	}
+	if l1ChainID.Uint64() != sc.L1.ChainID {
+		return fmt.Errorf("L1 RPC is for chain %d, but chain %q is deployed on L1 chain %d", l1ChainID, name, sc.L1.ChainID)
+	}
+
+	cfg := &checks.Config{
+		Log: logger,
+		L1:  l1Cl,
+	}
+	if locStr := c.String(ArtifactsLocatorFlag.Name); locStr != "" {
+		loc, err := artifacts.NewLocatorFromURL(locStr)
+		if err != nil {
+			return fmt.Errorf("invalid artifacts locator: %w", err)
+		}
+		cacheDir := c.String(CacheDirFlag.Name)
+		if cacheDir == "" {
+			cacheDir, err = os.MkdirTemp("", "check-registry-chain")
+			if err != nil {
+				return fmt.Errorf("failed to create artifacts dir: %w", err)
+			}
+			defer os.RemoveAll(cacheDir)
+		}
+		artifactsFS, err := artifacts.Download(ctx, loc, artifacts.BarProgressor(), cacheDir)
+		if err != nil {
+			return fmt.Errorf("failed to download artifacts: %w", err)
+		}
+		cfg.Artifacts = &foundry.ArtifactsFS{FS: artifactsFS}
+		cfg.ArtifactsName = locStr
+	}
+
+	logger.Info("Verifying chain deployment", "chain", name, "chainID", chainID, "superchain", sc.Name)
+	report, err := checks.Verify(ctx, cfg, chainCfg, sc)
+	if err != nil {
+		return err
+	}
+	if err := jsonutil.WriteJSON(report, ioutil.ToStdOutOrFileOrNoop(c.String(OutputFlag.Name), 0o644)); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	logger.Info("Verification complete", "passed", report.Passed, "failed", report.Failed, "skipped", report.Skipped)
+	if !report.OK() {
+		return fmt.Errorf("chain %q does not conform to its registry entry: %d checks failed", name, report.Failed)
+	}
+	return nil
+}
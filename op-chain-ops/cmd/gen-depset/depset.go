@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/superchain"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+)
+
+// registryChain is a chain of the superchain-registry.
+type registryChain struct {
+	// Name is the name of the chain, including its network, e.g. op-sepolia.
+	Name    string
+	Network string
+	Config  *superchain.ChainConfig
+}
+
+// loadRegistryChain loads a chain by name from the superchain-registry bundled with op-geth.
+func loadRegistryChain(name string) (*registryChain, error) {
+	chainID, err := superchain.ChainIDByName(name)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := superchain.GetChain(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chain %q: %w", name, err)
+	}
+	cfg, err := chain.Config()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config of chain %q: %w", name, err)
+	}
+	if cfg.ChainID != chainID {
+		return nil, fmt.Errorf("chain %q is registered with chain ID %d, but configured with chain ID %d", name, chainID, cfg.ChainID)
+	}
+	return &registryChain{Name: name, Network: chain.Network, Config: cfg}, nil
+}
+
+// generateDependencySet returns the dependency set of the chains, after checking that the chains
+// can form a dependency set: they are distinct, on the same superchain, activate interop at the same time,
+// and the dependencies that the registry declares for them, if any, are the chains of the set.
+func generateDependencySet(chains []*registryChain) (*depset.StaticConfigDependencySet, error) {
+	if len(chains) == 0 {
+		return nil, errors.New("no chains")
+	}
+	deps := make(map[eth.ChainID]*depset.StaticConfigDependency)
+	names := make(map[eth.ChainID]string)
+	for _, c := range chains {
+		id := eth.ChainIDFromUInt64(c.Config.ChainID)
+		if prev, ok := names[id]; ok {
+			return nil, fmt.Errorf("chains %q and %q have the same chain ID %s", prev, c.Name, id)
+		}
+		names[id] = c.Name
+		deps[id] = &depset.StaticConfigDependency{}
+	}
+
+	first := chains[0]
+	if first.Config.Hardforks.InteropTime == nil {
+		return nil, fmt.Errorf("chain %q does not activate interop", first.Name)
+	}
+	for _, c := range chains[1:] {
+		if c.Network != first.Network {
+			return nil, fmt.Errorf("chain %q is on superchain %q, but chain %q is on superchain %q", first.Name, first.Network, c.Name, c.Network)
+		}
+		if c.Config.Hardforks.InteropTime == nil {
+			return nil, fmt.Errorf("chain %q does not activate interop", c.Name)
+		}
+		if *c.Config.Hardforks.InteropTime != *first.Config.Hardforks.InteropTime {
+			return nil, fmt.Errorf("chain %q activates interop at %d, but chain %q activates interop at %d",
+				first.Name, *first.Config.Hardforks.InteropTime, c.Name, *c.Config.Hardforks.InteropTime)
+		}
+	}
+
+	for _, c := range chains {
+		if c.Config.Interop == nil {
+			continue
+		}
+		declared := make([]eth.ChainID, 0, len(c.Config.Interop.Dependencies))
+		for idStr := range c.Config.Interop.Dependencies {
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("chain %q declares invalid dependency chain ID %q", c.Name, idStr)
+			}
+			declared = append(declared, eth.ChainIDFromUInt64(id))
+		}
+		for _, id := range declared {
+			if _, ok := deps[id]; !ok {
+				return nil, fmt.Errorf("chain %q declares a dependency on chain %s, which is not in the dependency set", c.Name, id)
+			}
+		}
+		for id := range deps {
+			if !slices.Contains(declared, id) {
+				return nil, fmt.Errorf("chain %q does not declare a dependency on chain %s (%s)", c.Name, id, names[id])
+			}
+		}
+	}
+	return depset.NewStaticConfigDependencySet(deps)
+}
+
+// writeFlags writes the op-node flags of every chain, and the op-supervisor flags,
+// to use the dependency set at depSetPath.
+func writeFlags(w io.Writer, chains []*registryChain, depSetPath string) error {
+	var b strings.Builder
+	names := make([]string, 0, len(chains))
+	for _, c := range chains {
+		names = append(names, c.Name)
+		interopTime := *c.Config.Hardforks.InteropTime
+		fmt.Fprintf(&b, "# op-node of %s (chain ID %d), interop activates at %d (%s)\n", c.Name, c.Config.ChainID,
+			interopTime, time.Unix(int64(interopTime), 0).UTC().Format(time.RFC3339))
+		fmt.Fprintf(&b, "--network=%s --interop.dependency-set=%s\n\n", c.Name, depSetPath)
+	}
+	fmt.Fprintf(&b, "# op-supervisor\n")
+	fmt.Fprintf(&b, "--networks=%s --dependency-set=%s\n", strings.Join(names, ","), depSetPath)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
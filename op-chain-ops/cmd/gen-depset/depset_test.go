@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/superchain"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func testChain(name string, chainID uint64, interopTime *uint64, deps ...string) *registryChain {
+	cfg := &superchain.ChainConfig{ChainID: chainID}
+	cfg.Hardforks.InteropTime = interopTime
+	if deps != nil {
+		cfg.Interop = &superchain.Interop{Dependencies: make(map[string]superchain.Dependency)}
+		for _, dep := range deps {
+			cfg.Interop.Dependencies[dep] = superchain.Dependency{}
+		}
+	}
+	return &registryChain{Name: name, Network: "sepolia", Config: cfg}
+}
+
+func u64(v uint64) *uint64 {
+	return &v
+}
+
+func TestGenerateDependencySet(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		depSet, err := generateDependencySet([]*registryChain{
+			testChain("a-sepolia", 901, u64(1000)),
+			testChain("b-sepolia", 902, u64(1000), "901", "902"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []eth.ChainID{eth.ChainIDFromUInt64(901), eth.ChainIDFromUInt64(902)}, depSet.Chains())
+	})
+
+	invalid := []struct {
+		name   string
+		chains []*registryChain
+		err    string
+	}{
+		{"no chains", nil, "no chains"},
+		{"duplicate chain ID", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000)),
+			testChain("b-sepolia", 901, u64(1000)),
+		}, `chains "a-sepolia" and "b-sepolia" have the same chain ID 901`},
+		{"other superchain", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000)),
+			{Name: "b-mainnet", Network: "mainnet", Config: testChain("b-mainnet", 902, u64(1000)).Config},
+		}, `chain "b-mainnet" is on superchain "mainnet"`},
+		{"no interop", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000)),
+			testChain("b-sepolia", 902, nil),
+		}, `chain "b-sepolia" does not activate interop`},
+		{"different activation", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000)),
+			testChain("b-sepolia", 902, u64(2000)),
+		}, `chain "b-sepolia" activates interop at 2000`},
+		{"undeclared dependency", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000), "901"),
+			testChain("b-sepolia", 902, u64(1000)),
+		}, `chain "a-sepolia" does not declare a dependency on chain 902 (b-sepolia)`},
+		{"dependency outside the set", []*registryChain{
+			testChain("a-sepolia", 901, u64(1000), "901", "903"),
+		}, `chain "a-sepolia" declares a dependency on chain 903`},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := generateDependencySet(tc.chains)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestWriteFlags(t *testing.T) {
+	var out bytes.Buffer
+	chains := []*registryChain{
+		testChain("a-sepolia", 901, u64(1749150000)),
+		testChain("b-sepolia", 902, u64(1749150000)),
+	}
+	require.NoError(t, writeFlags(&out, chains, "/etc/depset.json"))
+	require.Equal(t, `# op-node of a-sepolia (chain ID 901), interop activates at 1749150000 (2025-06-05T19:00:00Z)
+--network=a-sepolia --interop.dependency-set=/etc/depset.json
+
+# op-node of b-sepolia (chain ID 902), interop activates at 1749150000 (2025-06-05T19:00:00Z)
+--network=b-sepolia --interop.dependency-set=/etc/depset.json
+
+# op-supervisor
+--networks=a-sepolia,b-sepolia --dependency-set=/etc/depset.json
+`, out.String())
+}
+
+func TestLoadRegistryChain(t *testing.T) {
+	chain, err := loadRegistryChain("op-sepolia")
+	require.NoError(t, err)
+	require.Equal(t, uint64(11155420), chain.Config.ChainID)
+	require.Equal(t, "sepolia", chain.Network)
+
+	_, err = loadRegistryChain("unknown-sepolia")
+	require.ErrorIs(t, err, superchain.ErrUnknownChain)
+}
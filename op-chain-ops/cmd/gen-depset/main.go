@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const prefix = "GEN_DEPSET"
+
+var (
+	ChainsFlag = &cli.StringSliceFlag{
+		Name:     "chains",
+		Usage:    "Comma-separated names of the chains of the dependency set in the superchain-registry, e.g. op-sepolia",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "CHAINS"),
+		Required: true,
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:    "output",
+		Usage:   "Path to write the op-supervisor dependency set JSON config to, or - for stdout",
+		EnvVars: op_service.PrefixEnvVar(prefix, "OUTPUT"),
+		Value:   "-",
+	}
+	FlagsOutputFlag = &cli.StringFlag{
+		Name:    "flags-output",
+		Usage:   "Path to write the matching op-node and op-supervisor flags to, or - for stdout. Not written if empty.",
+		EnvVars: op_service.PrefixEnvVar(prefix, "FLAGS_OUTPUT"),
+	}
+	DepSetPathFlag = &cli.StringFlag{
+		Name:    "depset-path",
+		Usage:   "Path of the dependency set config on the hosts of the op-node and op-supervisor, as used by the flags. Defaults to --output.",
+		EnvVars: op_service.PrefixEnvVar(prefix, "DEPSET_PATH"),
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Name = "gen-depset"
+	app.Usage = "Generates an interop dependency set config from chains of the superchain-registry."
+	app.Flags = append([]cli.Flag{
+		ChainsFlag,
+		OutputFlag,
+		FlagsOutputFlag,
+		DepSetPathFlag,
+	}, oplog.CLIFlags(prefix)...)
+	app.Action = run
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("Application failed", "err", err)
+	}
+}
+
+func run(c *cli.Context) error {
+	logger := oplog.NewLogger(os.Stderr, oplog.ReadCLIConfig(c))
+
+	var chains []*registryChain
+	for _, name := range c.StringSlice(ChainsFlag.Name) {
+		chain, err := loadRegistryChain(name)
+		if err != nil {
+			return fmt.Errorf("unknown chain %q: %w", name, err)
+		}
+		chains = append(chains, chain)
+	}
+	depSet, err := generateDependencySet(chains)
+	if err != nil {
+		return fmt.Errorf("invalid dependency set: %w", err)
+	}
+
+	output := c.String(OutputFlag.Name)
+	if err := jsonutil.WriteJSON(depSet, ioutil.ToStdOutOrFileOrNoop(output, 0o644)); err != nil {
+		return fmt.Errorf("failed to write dependency set: %w", err)
+	}
+	depSetPath := c.String(DepSetPathFlag.Name)
+	if depSetPath == "" {
+		depSetPath = output
+	}
+	err = writeOutput(ioutil.ToStdOutOrFileOrNoop(c.String(FlagsOutputFlag.Name), 0o644), func(w io.Writer) error {
+		return writeFlags(w, chains, depSetPath)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write flags: %w", err)
+	}
+	logger.Info("Generated dependency set", "chains", len(chains), "network", chains[0].Network,
+		"interopTime", *chains[0].Config.Hardforks.InteropTime)
+	return nil
+}
+
+func writeOutput(target ioutil.OutputTarget, fn func(w io.Writer) error) error {
+	w, closer, abort, err := target()
+	if err != nil {
+		return err
+	}
+	if w == nil {
+		return nil
+	}
+	if err := fn(w); err != nil {
+		abort()
+		return err
+	}
+	return closer.Close()
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
@@ -153,6 +154,14 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.P
 	if err != nil {
 		return nil, fmt.Errorf("failed to read proof (%v): %w", path, err)
 	}
+	if len(proof.CompactProofData) > 0 {
+		proofData, err := mipsevm.ExpandProofData(proof.CompactProofData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand compact proof data (%v): %w", path, err)
+		}
+		proof.ProofData = proofData
+		proof.CompactProofData = nil
+	}
 	return &proof, nil
 }
 
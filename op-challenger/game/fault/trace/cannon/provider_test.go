@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -130,6 +131,26 @@ func TestGetStepData(t *testing.T) {
 		require.EqualValues(t, expectedData, data)
 	})
 
+	t.Run("GenerateCompactProof", func(t *testing.T) {
+		dataDir, prestate := setupTestData(t)
+		provider, generator := setupWithTestData(t, dataDir, prestate)
+		mem := memory.NewMemory()
+		mem.SetWord(0x1000, 0x1234)
+		memProof := mem.MerkleProof(0x1000)
+		proofData := append([]byte{0xcc}, memProof[:]...)
+		compact, err := mipsevm.CompactProofData(proofData)
+		require.NoError(t, err)
+		generator.proof = &utils.ProofData{
+			ClaimValue:       common.Hash{0xaa},
+			StateData:        []byte{0xbb},
+			CompactProofData: compact,
+		}
+		preimage, proof, _, err := provider.GetStepData(context.Background(), PositionFromTraceIndex(provider, big.NewInt(4)))
+		require.NoError(t, err)
+		require.EqualValues(t, generator.proof.StateData, preimage)
+		require.EqualValues(t, proofData, proof)
+	})
+
 	t.Run("ProofAfterEndOfTrace", func(t *testing.T) {
 		dataDir, prestate := setupTestData(t)
 		provider, generator := setupWithTestData(t, dataDir, prestate)
@@ -19,12 +19,14 @@ const (
 )
 
 type ProofData struct {
-	ClaimValue   common.Hash   `json:"post"`
-	StateData    hexutil.Bytes `json:"state-data"`
-	ProofData    hexutil.Bytes `json:"proof-data"`
-	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
-	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
-	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+	ClaimValue common.Hash   `json:"post"`
+	StateData  hexutil.Bytes `json:"state-data"`
+	ProofData  hexutil.Bytes `json:"proof-data"`
+	// CompactProofData is set instead of ProofData when the VM wrote its proof data in the compact encoding.
+	CompactProofData hexutil.Bytes `json:"compact-proof-data,omitempty"`
+	OracleKey        hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleValue      hexutil.Bytes `json:"oracle-value,omitempty"`
+	OracleOffset     uint32        `json:"oracle-offset,omitempty"`
 }
 
 type ProofGenerator interface {
@@ -0,0 +1,17 @@
+package presets
+
+import (
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysext"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysgo"
+)
+
+// WithResourceProfiles constrains the components of the system to the resource profiles of their kinds.
+// With sysgo the limits are enforced in the test process, and violations fail the test.
+// With sysext the resource settings of the devnet services are required to be within the profiles.
+func WithResourceProfiles(profiles stack.ResourceProfiles) stack.CommonOption {
+	return stack.Combine(
+		stack.MakeCommon(sysgo.WithResourceProfiles(profiles)),
+		stack.MakeCommon(sysext.WithResourceProfiles(profiles)),
+	)
+}
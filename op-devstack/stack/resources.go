@@ -0,0 +1,64 @@
+package stack
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ResourceProfile describes the resources a component requires, and the limits it runs under.
+// Zero values are unset: no requirement, or no limit.
+type ResourceProfile struct {
+	// MinCPU is the CPU the component requires, in millicores.
+	MinCPU uint64
+	// MaxCPU is the CPU limit of the component, in millicores.
+	MaxCPU uint64
+	// MinMemory is the memory the component requires, in bytes.
+	MinMemory uint64
+	// MaxMemory is the memory ceiling of the component, in bytes.
+	// A component that exceeds it is out of memory.
+	MaxMemory uint64
+	// DiskQuota is the disk space the component may use for its data, in bytes.
+	DiskQuota uint64
+}
+
+func (p ResourceProfile) Check() error {
+	if p.MaxCPU != 0 && p.MinCPU > p.MaxCPU {
+		return fmt.Errorf("min CPU %d exceeds max CPU %d", p.MinCPU, p.MaxCPU)
+	}
+	if p.MaxMemory != 0 && p.MinMemory > p.MaxMemory {
+		return fmt.Errorf("min memory %d exceeds max memory %d", p.MinMemory, p.MaxMemory)
+	}
+	return nil
+}
+
+// ResourceProfiles are the resource profiles of the components of a system, by kind of component.
+// Components of kinds without a profile run unconstrained.
+type ResourceProfiles map[Kind]ResourceProfile
+
+func (p ResourceProfiles) Check() error {
+	var result error
+	for kind, profile := range p {
+		if err := profile.Check(); err != nil {
+			result = errors.Join(result, fmt.Errorf("invalid resource profile of %s: %w", kind, err))
+		}
+	}
+	return result
+}
+
+// ResourceViolation is a component exceeding the limits of its resource profile.
+type ResourceViolation struct {
+	// Component is the ID of the component, or empty if the usage is the combined usage of the components.
+	Component string
+	// Resource is the exceeded resource, e.g. "memory" or "disk"
+	Resource string
+	Usage    uint64
+	Limit    uint64
+}
+
+func (v ResourceViolation) String() string {
+	component := v.Component
+	if component == "" {
+		component = "system"
+	}
+	return fmt.Sprintf("%s exceeded %s limit: %d > %d", component, v.Resource, v.Usage, v.Limit)
+}
@@ -0,0 +1,26 @@
+package stack
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceProfilesCheck(t *testing.T) {
+	require.NoError(t, ResourceProfiles{
+		L2ELNodeKind: {MinCPU: 500, MaxCPU: 1000, MinMemory: 1 << 30, MaxMemory: 2 << 30},
+		L2CLNodeKind: {MinCPU: 500, MinMemory: 4 << 30}, // no limits
+	}.Check())
+
+	err := ResourceProfiles{L2ELNodeKind: {MinCPU: 2000, MaxCPU: 1000}}.Check()
+	require.ErrorContains(t, err, "min CPU 2000 exceeds max CPU 1000")
+	err = ResourceProfiles{L2CLNodeKind: {MinMemory: 2, MaxMemory: 1}}.Check()
+	require.ErrorContains(t, err, "min memory 2 exceeds max memory 1")
+}
+
+func TestResourceViolationString(t *testing.T) {
+	require.Equal(t, "system exceeded memory limit: 2 > 1",
+		ResourceViolation{Resource: "memory", Usage: 2, Limit: 1}.String())
+	require.Equal(t, "L2ELNode-a exceeded disk limit: 2 > 1",
+		ResourceViolation{Component: "L2ELNode-a", Resource: "disk", Usage: 2, Limit: 1}.String())
+}
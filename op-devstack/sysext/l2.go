@@ -75,6 +75,7 @@ func (o *Orchestrator) hydrateL2ELCL(node *descriptors.Node, l2Net stack.Extensi
 
 	elService, ok := node.Services[ELServiceName]
 	require.True(ok, "need L2 EL service for chain", l2ID)
+	o.requireResources(l2Net.T(), stack.L2ELNodeKind, elService)
 	elClient := o.rpcClient(l2Net.T(), elService, RPCProtocol, "/")
 	l2EL := shim.NewL2ELNode(shim.L2ELNodeConfig{
 		RollupCfg: l2Net.RollupConfig(),
@@ -95,6 +96,7 @@ func (o *Orchestrator) hydrateL2ELCL(node *descriptors.Node, l2Net stack.Extensi
 
 	clService, ok := node.Services[CLServiceName]
 	require.True(ok, "need L2 CL service for chain", l2ID)
+	o.requireResources(l2Net.T(), stack.L2CLNodeKind, clService)
 
 	clClient := o.rpcClient(l2Net.T(), clService, RPCProtocol, "/")
 	l2CL := shim.NewL2CLNode(shim.L2CLNodeConfig{
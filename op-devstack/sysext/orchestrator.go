@@ -26,6 +26,8 @@ type Orchestrator struct {
 	controlPlane *ControlPlane
 	useDirectCnx bool
 
+	resourceProfiles stack.ResourceProfiles
+
 	// sysHook is called after hydration of a new test-scope system frontend,
 	// essentially a test-case preamble.
 	sysHook stack.SystemHook
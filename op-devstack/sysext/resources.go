@@ -0,0 +1,57 @@
+package sysext
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/devnet-sdk/descriptors"
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+)
+
+// megabyte is the unit of the memory and volume sizes of kurtosis services
+const megabyte = 1 << 20
+
+// WithResourceProfiles requires the services of the devnet to be deployed within the resource profiles of their kinds,
+// with the kurtosis resource settings of the participants (min_cpu, max_cpu, min_mem, max_mem, volume_size).
+// The settings are enforced by kurtosis: the profiles are checked against the deployed settings, not the usage.
+// Only the L2 EL and CL services have resource settings.
+func WithResourceProfiles(profiles stack.ResourceProfiles) stack.Option[*Orchestrator] {
+	return stack.BeforeDeploy(func(orch *Orchestrator) {
+		orch.P().Require().NoError(profiles.Check(), "invalid resource profiles")
+		orch.resourceProfiles = profiles
+	})
+}
+
+// requireResources requires the service to be deployed within the resource profile of the kind of component.
+func (o *Orchestrator) requireResources(t devtest.T, kind stack.Kind, service *descriptors.Service) {
+	profile, ok := o.resourceProfiles[kind]
+	if !ok {
+		return
+	}
+	t.Require().NoError(checkResources(profile, service.Resources),
+		"service %s is not deployed within the resource profile of %s", service.Name, kind)
+}
+
+func checkResources(profile stack.ResourceProfile, res *descriptors.ServiceResources) error {
+	if res == nil {
+		res = &descriptors.ServiceResources{}
+	}
+	var result error
+	if profile.MinCPU != 0 && uint64(res.MinCPU) < profile.MinCPU {
+		result = errors.Join(result, fmt.Errorf("min CPU %d is below the required %d", res.MinCPU, profile.MinCPU))
+	}
+	if profile.MaxCPU != 0 && (res.MaxCPU == 0 || uint64(res.MaxCPU) > profile.MaxCPU) {
+		result = errors.Join(result, fmt.Errorf("max CPU %d exceeds the limit %d", res.MaxCPU, profile.MaxCPU))
+	}
+	if profile.MinMemory != 0 && uint64(res.MinMemory)*megabyte < profile.MinMemory {
+		result = errors.Join(result, fmt.Errorf("min memory %d MB is below the required %d bytes", res.MinMemory, profile.MinMemory))
+	}
+	if profile.MaxMemory != 0 && (res.MaxMemory == 0 || uint64(res.MaxMemory)*megabyte > profile.MaxMemory) {
+		result = errors.Join(result, fmt.Errorf("max memory %d MB exceeds the limit %d bytes", res.MaxMemory, profile.MaxMemory))
+	}
+	if profile.DiskQuota != 0 && (res.VolumeSize == 0 || uint64(res.VolumeSize)*megabyte > profile.DiskQuota) {
+		result = errors.Join(result, fmt.Errorf("volume size %d MB exceeds the disk quota %d bytes", res.VolumeSize, profile.DiskQuota))
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package sysgo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// RPCEndpoint is the user RPC endpoint of a node or service of the system.
+type RPCEndpoint struct {
+	// Component is the ID of the node or service.
+	Component string
+	URL       string
+}
+
+// RPCEndpoints returns the user RPC endpoints of the nodes and services of the system, to inspect
+// the system with other tools while it runs. They are ordered from L1 to L2 and by component ID.
+func (o *Orchestrator) RPCEndpoints() []RPCEndpoint {
+	var out []RPCEndpoint
+	add := func(component fmt.Stringer, url string) {
+		out = append(out, RPCEndpoint{Component: component.String(), URL: url})
+	}
+	group := func(fn func()) {
+		start := len(out)
+		fn()
+		sort.Slice(out[start:], func(i, j int) bool {
+			return out[start+i].Component < out[start+j].Component
+		})
+	}
+	group(func() {
+		for _, n := range o.l1ELs.Values() {
+			add(n.id, n.userRPC)
+		}
+	})
+	group(func() {
+		for _, n := range o.l1CLs.Values() {
+			add(n.id, n.beaconHTTPAddr)
+		}
+	})
+	group(func() {
+		for _, n := range o.l2ELs.Values() {
+			add(n.id, n.userRPC)
+		}
+	})
+	group(func() {
+		for _, n := range o.l2CLs.Values() {
+			n.mu.Lock()
+			add(n.id, n.userRPC)
+			n.mu.Unlock()
+		}
+	})
+	group(func() {
+		for _, s := range o.supervisors.Values() {
+			s.mu.Lock()
+			add(s.id, s.userRPC)
+			s.mu.Unlock()
+		}
+	})
+	group(func() {
+		for _, s := range o.testSequencers.Values() {
+			add(s.id, s.userRPC)
+		}
+	})
+	return out
+}
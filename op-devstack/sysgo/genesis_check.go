@@ -0,0 +1,159 @@
+package sysgo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// verifyGenesis cross-checks the genesis that the deployer produced against a running EL node:
+// the genesis block hash, the chain config, and the code and storage of the given accounts.
+// Every difference is listed in the returned error, so that a mismatch between the deployer output
+// and the running node fails the setup, instead of causing confusing failures further downstream.
+func verifyGenesis(ctx context.Context, cl client.RPC, gen *core.Genesis, genesisHash common.Hash, accounts []common.Address) error {
+	var diffs []string
+
+	var head *types.Header
+	if err := cl.CallContext(ctx, &head, "eth_getBlockByNumber", "0x0", false); err != nil {
+		return fmt.Errorf("failed to fetch genesis block: %w", err)
+	}
+	if head == nil {
+		return errors.New("node has no genesis block")
+	}
+	if h := head.Hash(); h != genesisHash {
+		diffs = append(diffs, fmt.Sprintf("genesis block hash: deployer %s, node %s", genesisHash, h))
+	}
+
+	var nodeCfg json.RawMessage
+	if err := cl.CallContext(ctx, &nodeCfg, "debug_chainConfig"); err != nil {
+		return fmt.Errorf("failed to fetch chain config: %w", err)
+	}
+	cfgDiffs, err := diffChainConfig(gen, nodeCfg)
+	if err != nil {
+		return err
+	}
+	diffs = append(diffs, cfgDiffs...)
+
+	for _, addr := range accounts {
+		accDiffs, err := diffAccount(ctx, cl, addr, gen.Alloc[addr])
+		if err != nil {
+			return err
+		}
+		diffs = append(diffs, accDiffs...)
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("node does not match the deployer genesis of chain %s:\n  %s", gen.Config.ChainID, strings.Join(diffs, "\n  "))
+	}
+	return nil
+}
+
+// diffChainConfig compares the chain config of the genesis with the chain config of the node,
+// field by field in their JSON form, as the node reports it.
+func diffChainConfig(gen *core.Genesis, nodeCfg json.RawMessage) ([]string, error) {
+	expected, err := json.Marshal(gen.Config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deployer chain config: %w", err)
+	}
+	var want, got map[string]any
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return nil, fmt.Errorf("failed to decode deployer chain config: %w", err)
+	}
+	if err := json.Unmarshal(nodeCfg, &got); err != nil {
+		return nil, fmt.Errorf("failed to decode node chain config: %w", err)
+	}
+	keys := slices.Collect(maps.Keys(want))
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	var diffs []string
+	for _, k := range keys {
+		if !reflect.DeepEqual(want[k], got[k]) {
+			diffs = append(diffs, fmt.Sprintf("chain config %q: deployer %v, node %v", k, want[k], got[k]))
+		}
+	}
+	return diffs, nil
+}
+
+// diffAccount compares the code and storage of the genesis account with the account of the node, at the genesis block.
+func diffAccount(ctx context.Context, cl client.RPC, addr common.Address, acc types.Account) ([]string, error) {
+	slots := slices.Collect(maps.Keys(acc.Storage))
+	slices.SortFunc(slots, common.Hash.Cmp)
+
+	var code hexutil.Bytes
+	values := make([]common.Hash, len(slots))
+	batch := make([]rpc.BatchElem, 0, len(slots)+1)
+	batch = append(batch, rpc.BatchElem{Method: "eth_getCode", Args: []any{addr, "0x0"}, Result: &code})
+	for i, slot := range slots {
+		batch = append(batch, rpc.BatchElem{Method: "eth_getStorageAt", Args: []any{addr, slot, "0x0"}, Result: &values[i]})
+	}
+	if err := cl.BatchCallContext(ctx, batch); err != nil {
+		return nil, fmt.Errorf("failed to fetch account %s: %w", addr, err)
+	}
+	for _, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("failed to fetch account %s: %w", addr, elem.Error)
+		}
+	}
+
+	var diffs []string
+	if !bytes.Equal(code, acc.Code) {
+		diffs = append(diffs, fmt.Sprintf("code of %s: deployer %d bytes, node %d bytes", addr, len(acc.Code), len(code)))
+	}
+	for i, slot := range slots {
+		if want := acc.Storage[slot]; values[i] != want {
+			diffs = append(diffs, fmt.Sprintf("storage of %s at %s: deployer %s, node %s", addr, slot, want, values[i]))
+		}
+	}
+	return diffs, nil
+}
+
+// l2PredeployAccounts returns the predeploys of the L2 genesis, and the implementations behind their proxies.
+func l2PredeployAccounts(gen *core.Genesis) []common.Address {
+	var accounts []common.Address
+	for _, p := range predeploys.Predeploys {
+		acc, ok := gen.Alloc[p.Address]
+		if !ok {
+			continue
+		}
+		accounts = append(accounts, p.Address)
+		if impl := common.BytesToAddress(acc.Storage[genesis.ImplementationSlot].Bytes()); impl != (common.Address{}) {
+			if _, ok := gen.Alloc[impl]; ok {
+				accounts = append(accounts, impl)
+			}
+		}
+	}
+	slices.SortFunc(accounts, common.Address.Cmp)
+	return slices.Compact(accounts)
+}
+
+// contractAccounts returns the accounts of the genesis that have code.
+func contractAccounts(gen *core.Genesis) []common.Address {
+	var accounts []common.Address
+	for addr, acc := range gen.Alloc {
+		if len(acc.Code) > 0 {
+			accounts = append(accounts, addr)
+		}
+	}
+	slices.SortFunc(accounts, common.Address.Cmp)
+	return accounts
+}
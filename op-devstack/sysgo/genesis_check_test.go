@@ -0,0 +1,50 @@
+package sysgo
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+func TestDiffChainConfig(t *testing.T) {
+	isthmus := uint64(100)
+	gen := &core.Genesis{Config: &params.ChainConfig{ChainID: big.NewInt(901), IsthmusTime: &isthmus}}
+
+	same, err := json.Marshal(gen.Config)
+	require.NoError(t, err)
+	diffs, err := diffChainConfig(gen, same)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	other := uint64(200)
+	changed, err := json.Marshal(&params.ChainConfig{ChainID: big.NewInt(901), IsthmusTime: &other, InteropTime: &other})
+	require.NoError(t, err)
+	diffs, err = diffChainConfig(gen, changed)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`chain config "interopTime": deployer <nil>, node 200`,
+		`chain config "isthmusTime": deployer 100, node 200`,
+	}, diffs)
+}
+
+func TestL2PredeployAccounts(t *testing.T) {
+	impl := common.HexToAddress("0xc0d3c0d3c0d3c0d3c0d3c0d3c0d3c0d3c0d30016")
+	gen := &core.Genesis{Alloc: types.GenesisAlloc{
+		predeploys.L2ToL1MessagePasserAddr: {Code: []byte{1}, Storage: map[common.Hash]common.Hash{
+			genesis.ImplementationSlot: common.BytesToHash(impl.Bytes()),
+		}},
+		impl:                        {Code: []byte{2}},
+		common.HexToAddress("0x01"): {Balance: big.NewInt(1)},
+	}}
+	require.Equal(t, []common.Address{predeploys.L2ToL1MessagePasserAddr, impl}, l2PredeployAccounts(gen))
+	require.Equal(t, []common.Address{predeploys.L2ToL1MessagePasserAddr, impl}, contractAccounts(gen))
+}
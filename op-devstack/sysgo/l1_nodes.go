@@ -71,6 +71,7 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option[
 		}
 
 		blobPath := clP.TempDir()
+		orch.trackDataDir(l1ELID, blobPath)
 
 		clLogger := clP.Logger()
 		bcn := fakebeacon.NewBeacon(clLogger, e2eutils.NewBlobStore(), l1Net.genesis.Timestamp, blockTimeL1)
@@ -96,6 +97,12 @@ func WithL1Nodes(l1ELID stack.L1ELNodeID, l1CLID stack.L1CLNodeID) stack.Option[
 			_ = l1Geth.Close()
 		})
 
+		rpcCl, err := client.NewRPC(elP.Ctx(), elLogger, l1Geth.Node.HTTPEndpoint())
+		require.NoError(err)
+		defer rpcCl.Close()
+		require.NoError(verifyGenesis(elP.Ctx(), rpcCl, l1Net.genesis, l1Net.genesis.ToBlock().Hash(), contractAccounts(l1Net.genesis)),
+			"L1 geth must run the L1 genesis of the deployer")
+
 		l1ELNode := &L1ELNode{
 			id:       l1ELID,
 			userRPC:  l1Geth.Node.HTTPEndpoint(),
@@ -102,6 +102,7 @@ func WithL2ChallengerPostDeploy(orch *Orchestrator, challengerID stack.L2Challen
 	}
 
 	dir := p.TempDir()
+	orch.trackDataDir(challengerID, dir)
 	var cfg *config.Config
 	// If interop is scheduled, or if we cannot do the pre-interop connection, then set up with supervisor
 	if interopScheduled || l2CLID == nil || useSuperRoots {
@@ -80,6 +80,12 @@ func WithL2ELNode(id stack.L2ELNodeID, supervisorID *stack.SupervisorID) stack.O
 			authRPC: l2Geth.AuthRPC().RPC(),
 			userRPC: l2Geth.UserRPC().RPC(),
 		}
+
+		rpcCl, err := client.NewRPC(p.Ctx(), logger, l2EL.userRPC)
+		require.NoError(err)
+		defer rpcCl.Close()
+		require.NoError(verifyGenesis(p.Ctx(), rpcCl, l2Net.genesis, l2Net.rollupCfg.Genesis.L2.Hash, l2PredeployAccounts(l2Net.genesis)),
+			"op-geth must run the L2 genesis of the deployer")
 		require.True(orch.l2ELs.SetIfMissing(id, l2EL), "must be unique L2 EL node")
 	})
 }
@@ -51,6 +51,11 @@ type Orchestrator struct {
 
 	controlPlane *ControlPlane
 
+	resourceProfiles    stack.ResourceProfiles
+	onResourceViolation ResourceViolationHandler
+	dataDirs            []dataDir
+	dataDirsLock        sync.Mutex
+
 	// sysHook is called after hydration of a new test-scope system frontend,
 	// essentially a test-case preamble.
 	sysHook stack.SystemHook
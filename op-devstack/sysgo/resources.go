@@ -0,0 +1,224 @@
+package sysgo
+
+import (
+	"io/fs"
+	"math"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/stack"
+)
+
+// resourceCheckInterval is the interval at which the resource usage is checked against the resource profiles.
+const resourceCheckInterval = time.Second
+
+// ResourceViolationHandler handles a component exceeding its resource profile,
+// e.g. to test the behavior of the system when a component runs out of memory.
+type ResourceViolationHandler func(v stack.ResourceViolation)
+
+type componentID interface {
+	stack.KindProvider
+	String() string
+}
+
+type dataDir struct {
+	id  componentID
+	dir string
+}
+
+// WithResourceProfiles runs the components under the limits of the resource profiles of their kinds.
+// All components run in the test process, so the process is limited to the combined CPU and memory
+// of the running components: GOMAXPROCS is set to the combined max CPU, and the Go memory limit to the combined
+// max memory. The combined memory usage is checked against the combined max memory,
+// and the data directories of the components against their disk quotas.
+// Violations are handled by the ResourceViolationHandler, which fails the test by default.
+func WithResourceProfiles(profiles stack.ResourceProfiles) stack.Option[*Orchestrator] {
+	return stack.Combine(
+		stack.BeforeDeploy(func(orch *Orchestrator) {
+			orch.P().Require().NoError(profiles.Check(), "invalid resource profiles")
+			orch.resourceProfiles = profiles
+		}),
+		stack.Finally(func(orch *Orchestrator) {
+			orch.enforceResourceProfiles()
+		}),
+	)
+}
+
+// WithResourceViolationHandler replaces the default handling of resource violations, which fails the test.
+func WithResourceViolationHandler(fn ResourceViolationHandler) stack.Option[*Orchestrator] {
+	return stack.BeforeDeploy(func(orch *Orchestrator) {
+		orch.onResourceViolation = fn
+	})
+}
+
+// trackDataDir registers the data directory of a component, to check it against the disk quota of the component.
+func (o *Orchestrator) trackDataDir(id componentID, dir string) {
+	o.dataDirsLock.Lock()
+	defer o.dataDirsLock.Unlock()
+	o.dataDirs = append(o.dataDirs, dataDir{id: id, dir: dir})
+}
+
+// componentCounts returns the number of components of each kind
+func (o *Orchestrator) componentCounts() map[stack.Kind]int {
+	return map[stack.Kind]int{
+		stack.L1ELNodeKind:      o.l1ELs.Len(),
+		stack.L1CLNodeKind:      o.l1CLs.Len(),
+		stack.L2ELNodeKind:      o.l2ELs.Len(),
+		stack.L2CLNodeKind:      o.l2CLs.Len(),
+		stack.SupervisorKind:    o.supervisors.Len(),
+		stack.TestSequencerKind: o.testSequencers.Len(),
+		stack.L2BatcherKind:     o.batchers.Len(),
+		stack.L2ChallengerKind:  o.challengers.Len(),
+		stack.L2ProposerKind:    o.proposers.Len(),
+	}
+}
+
+func (o *Orchestrator) enforceResourceProfiles() {
+	if len(o.resourceProfiles) == 0 {
+		return
+	}
+	logger := o.p.Logger()
+	var maxCPU, maxMemory uint64
+	cpuLimited, memoryLimited := true, true
+	for kind, count := range o.componentCounts() {
+		if count == 0 {
+			continue
+		}
+		profile := o.resourceProfiles[kind]
+		// A single component without limit leaves the process without limit
+		cpuLimited = cpuLimited && profile.MaxCPU != 0
+		memoryLimited = memoryLimited && profile.MaxMemory != 0
+		maxCPU += uint64(count) * profile.MaxCPU
+		maxMemory += uint64(count) * profile.MaxMemory
+	}
+	if cpuLimited {
+		procs := max(1, int((maxCPU+999)/1000))
+		prev := runtime.GOMAXPROCS(procs)
+		logger.Info("Limiting CPU of the system", "maxCPU", maxCPU, "procs", procs)
+		o.p.Cleanup(func() {
+			runtime.GOMAXPROCS(prev)
+		})
+	}
+	if memoryLimited {
+		prev := debug.SetMemoryLimit(int64(min(maxMemory, math.MaxInt64)))
+		logger.Info("Limiting memory of the system", "maxMemory", maxMemory)
+		o.p.Cleanup(func() {
+			debug.SetMemoryLimit(prev)
+		})
+	} else {
+		maxMemory = 0
+	}
+
+	onViolation := o.onResourceViolation
+	if onViolation == nil {
+		onViolation = func(v stack.ResourceViolation) {
+			o.p.Errorf("resource limit exceeded: %s", v)
+		}
+	}
+	o.dataDirsLock.Lock()
+	dirs := append([]dataDir(nil), o.dataDirs...)
+	o.dataDirsLock.Unlock()
+	m := &resourceMonitor{
+		profiles:    o.resourceProfiles,
+		maxMemory:   maxMemory,
+		dataDirs:    dirs,
+		onViolation: onViolation,
+		reported:    make(map[stack.ResourceViolation]struct{}),
+	}
+	m.start(o.p)
+}
+
+// resourceMonitor periodically checks the resource usage of the system against the resource profiles.
+type resourceMonitor struct {
+	profiles stack.ResourceProfiles
+	// maxMemory is the combined max memory of the components, or 0 if the memory is not limited
+	maxMemory   uint64
+	dataDirs    []dataDir
+	onViolation ResourceViolationHandler
+
+	// reported prevents reporting the same violation more than once
+	reported map[stack.ResourceViolation]struct{}
+}
+
+func (m *resourceMonitor) start(p devtest.P) {
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(resourceCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.check()
+			case <-stop:
+				return
+			}
+		}
+	}()
+	p.Cleanup(func() {
+		close(stop)
+		wg.Wait()
+	})
+}
+
+func (m *resourceMonitor) check() {
+	if m.maxMemory != 0 {
+		if usage := memoryUsage(); usage > m.maxMemory {
+			m.report(stack.ResourceViolation{Resource: "memory", Usage: usage, Limit: m.maxMemory})
+		}
+	}
+	for _, d := range m.dataDirs {
+		quota := m.profiles[d.id.Kind()].DiskQuota
+		if quota == 0 {
+			continue
+		}
+		if usage := diskUsage(d.dir); usage > quota {
+			m.report(stack.ResourceViolation{Component: d.id.String(), Resource: "disk", Usage: usage, Limit: quota})
+		}
+	}
+}
+
+func (m *resourceMonitor) report(v stack.ResourceViolation) {
+	// The usage varies between checks, the violation is identified by the component, resource and limit
+	key := v
+	key.Usage = 0
+	if _, ok := m.reported[key]; ok {
+		return
+	}
+	m.reported[key] = struct{}{}
+	m.onViolation(v)
+}
+
+// memoryUsage returns the memory mapped by the Go runtime, and not released to the OS.
+func memoryUsage() uint64 {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
+
+// diskUsage returns the size of the files in the directory.
+func diskUsage(dir string) uint64 {
+	var size uint64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // files may be removed while walking
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				size += uint64(info.Size())
+			}
+		}
+		return nil
+	})
+	return size
+}
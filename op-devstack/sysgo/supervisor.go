@@ -105,6 +105,10 @@ func WithSupervisor(supervisorID stack.SupervisorID, clusterID stack.ClusterID,
 
 		require.NotNil(cluster.cfgset, "need a full config set")
 		require.NoError(cluster.cfgset.CheckChains(), "config set must be valid")
+		// Note: datadir is created here,
+		// persistent across stop/start, for the duration of the package execution.
+		datadir := orch.p.TempDir()
+		orch.trackDataDir(supervisorID, datadir)
 		cfg := &supervisorConfig.Config{
 			MetricsConfig: metrics.CLIConfig{
 				Enabled: false,
@@ -123,11 +127,9 @@ func WithSupervisor(supervisorID stack.SupervisorID, clusterID stack.ClusterID,
 				ListenPort:  0,
 				EnableAdmin: true,
 			},
-			SyncSources: &syncnode.CLISyncNodes{}, // no sync-sources
-			L1RPC:       l1EL.userRPC,
-			// Note: datadir is created here,
-			// persistent across stop/start, for the duration of the package execution.
-			Datadir:               orch.p.TempDir(),
+			SyncSources:           &syncnode.CLISyncNodes{}, // no sync-sources
+			L1RPC:                 l1EL.userRPC,
+			Datadir:               datadir,
 			Version:               "dev",
 			FullConfigSetSource:   cluster.cfgset,
 			MockRun:               false,
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
 	"github.com/ethereum-optimism/optimism/op-devstack/shim"
@@ -43,6 +44,21 @@ func TestSystem(gt *testing.T) {
 	orch := NewOrchestrator(p, stack.Combine[*Orchestrator]())
 	stack.ApplyOptionLifecycle(opt, orch)
 
+	gt.Run("rpc endpoints", func(gt *testing.T) {
+		endpoints := orch.RPCEndpoints()
+		components := make([]string, 0, len(endpoints))
+		for _, e := range endpoints {
+			require.NotEmpty(gt, e.URL, e.Component)
+			components = append(components, e.Component)
+		}
+		require.Equal(gt, []string{
+			ids.L1EL.String(), ids.L1CL.String(),
+			ids.L2AEL.String(), ids.L2BEL.String(),
+			ids.L2ACL.String(), ids.L2BCL.String(),
+			ids.Supervisor.String(), ids.TestSequencer.String(),
+		}, components)
+	})
+
 	// Run two tests in parallel: see if we can share the same orchestrator
 	// between two test scopes, with two different hydrated system frontends.
 	gt.Run("testA", func(gt *testing.T) {
@@ -0,0 +1,37 @@
+// Command quickstart spins up the minimal in-process interop devnet, sends a message from chain A
+// to chain B, waits until both sides of the message are cross-safe, and tears the devnet down
+// again. Every stage is printed with the time it took, along with the RPC endpoints of the nodes
+// and services to inspect them while the devnet runs.
+//
+// The scenario is TestQuickstart, which also runs with the other tests of the repository:
+//
+//	go run ./op-e2e/cmd/quickstart
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const pkg = "github.com/ethereum-optimism/optimism/op-e2e/cmd/quickstart"
+
+func main() {
+	timeout := flag.Duration("timeout", 10*time.Minute, "maximum duration of the scenario")
+	flag.Parse()
+
+	cmd := exec.Command("go", "test", "-count=1", "-v", "-timeout", timeout.String(), "-run", "^TestQuickstart$", pkg)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "failed to run the quickstart scenario: %v\n", err)
+		os.Exit(1)
+	}
+}
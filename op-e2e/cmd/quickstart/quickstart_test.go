@@ -0,0 +1,82 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-devstack/devtest"
+	"github.com/ethereum-optimism/optimism/op-devstack/presets"
+	"github.com/ethereum-optimism/optimism/op-devstack/sysgo"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/log/logfilter"
+	"github.com/ethereum-optimism/optimism/op-service/txintent"
+	stypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestMain(m *testing.M) {
+	// Only warnings of the services are logged, so the trace of the stages stays readable.
+	presets.DoMain(m,
+		presets.WithSimpleInterop(),
+		presets.WithLogFilter(logfilter.DefaultMute(logfilter.Level(slog.LevelWarn).Show())),
+	)
+}
+
+// TestQuickstart sends a message from chain A to chain B of the minimal interop devnet, and
+// traces every stage until both sides of the message are cross-safe.
+func TestQuickstart(gt *testing.T) {
+	t := devtest.SerialT(gt)
+	start := time.Now()
+	stage := 0
+	step := func(format string, args ...any) {
+		stage++
+		gt.Logf("[%2d | %6s] "+format, append([]any{stage, time.Since(start).Round(time.Millisecond)}, args...)...)
+	}
+
+	sys := presets.NewSimpleInterop(t)
+	step("devnet is up: L1 %s, chain A %s, chain B %s", sys.L1Network.ChainID(), sys.L2ChainA.ChainID(), sys.L2ChainB.ChainID())
+	if orch, ok := presets.Orchestrator().(*sysgo.Orchestrator); ok {
+		for _, e := range orch.RPCEndpoints() {
+			gt.Logf("          %-40s %s", e.Component, e.URL)
+		}
+	}
+
+	alice := sys.FunderA.NewFundedEOA(eth.OneEther)
+	bob := sys.FunderB.NewFundedEOA(eth.OneEther)
+	step("funded alice %s on chain A and bob %s on chain B", alice.Address(), bob.Address())
+
+	eventLogger := alice.DeployEventLogger()
+	step("alice deployed an EventLogger at %s to emit the initiating message", eventLogger)
+
+	initIntent, initReceipt := alice.SendInitMessage(&txintent.InitTrigger{
+		Emitter:    eventLogger,
+		Topics:     [][32]byte{crypto.Keccak256Hash([]byte("quickstart"))},
+		OpaqueData: []byte("hello from chain A"),
+	})
+	initResult, err := initIntent.Result.Eval(t.Ctx())
+	t.Require().NoError(err)
+	t.Require().Len(initResult.Entries, 1)
+	id := initResult.Entries[0].Identifier
+	step("initiating message included on chain A in block %d, tx %s: log %d of block %d at time %d",
+		initReceipt.BlockNumber, initReceipt.TxHash, id.LogIndex, id.BlockNumber, id.Timestamp)
+
+	initBlock := eth.BlockID{Number: initReceipt.BlockNumber.Uint64(), Hash: initReceipt.BlockHash}
+	sys.L2CLA.ReachedRef(stypes.CrossUnsafe, initBlock, 30)
+	step("supervisor verified the block of the initiating message as cross-unsafe on chain A")
+
+	sys.L2ChainB.CatchUpTo(sys.L2ChainA)
+	_, execReceipt := bob.SendExecMessage(initIntent, 0)
+	step("executing message included on chain B in block %d, tx %s", execReceipt.BlockNumber, execReceipt.TxHash)
+
+	execBlock := eth.BlockID{Number: execReceipt.BlockNumber.Uint64(), Hash: execReceipt.BlockHash}
+	sys.L2CLB.ReachedRef(stypes.CrossUnsafe, execBlock, 30)
+	step("supervisor verified the executing message against chain A: cross-unsafe on chain B")
+
+	sys.L2CLA.ReachedRef(stypes.CrossSafe, initBlock, 60)
+	sys.L2CLB.ReachedRef(stypes.CrossSafe, execBlock, 60)
+	step("batches of both blocks were derived from L1: cross-safe on chains A and B")
+
+	step("done, tearing down the devnet")
+}
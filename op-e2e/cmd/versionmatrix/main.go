@@ -0,0 +1,90 @@
+// Command versionmatrix runs an e2e scenario across combinations of component versions from a manifest,
+// like op-node N and N-1 with op-geth N and N-1, to check that the components of different releases
+// are compatible. The combinations cover every pair of versions of two components, see versionmatrix.SelectPairwise,
+// or all combinations with -strategy=full.
+//
+// The scenario is the command after the flags. It runs once per combination, with the image and version name of
+// each component in the DEVNET_IMAGE_<COMPONENT> and DEVNET_VERSION_<COMPONENT> environment variables:
+//
+//	go run ./op-e2e/cmd/versionmatrix -manifest versions.json -results results.json -- \
+//	    go test -count=1 -run TestInteropSystem ./op-acceptance-tests/tests/interop/...
+//
+// With -coverage, the pairs of versions that passed in earlier results are not run again.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/versionmatrix"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func main() {
+	manifestPath := flag.String("manifest", "", "path of the manifest JSON with the versions of the components")
+	strategy := flag.String("strategy", "pairwise", "combinations to run: pairwise, to cover every pair of versions of two components, or full")
+	coveragePath := flag.String("coverage", "", "path of the results of earlier runs, to skip the pairs of versions that passed")
+	resultsPath := flag.String("results", "", "path to write the results to")
+	timeout := flag.Duration("timeout", 30*time.Minute, "maximum duration of the scenario with a combination")
+	flag.Parse()
+
+	if err := run(*manifestPath, *strategy, *coveragePath, *resultsPath, *timeout, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(manifestPath, strategy, coveragePath, resultsPath string, timeout time.Duration, command []string) error {
+	logger := log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, true))
+	if manifestPath == "" {
+		return errors.New("missing -manifest")
+	}
+	manifest, err := versionmatrix.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	var combinations []versionmatrix.Combination
+	switch strategy {
+	case "pairwise":
+		covered := make(versionmatrix.Coverage)
+		if coveragePath != "" {
+			results, err := jsonutil.LoadJSON[[]versionmatrix.Result](coveragePath)
+			if err != nil {
+				return fmt.Errorf("failed to load coverage: %w", err)
+			}
+			covered = versionmatrix.CoverageOf(manifest, *results)
+		}
+		combinations = versionmatrix.SelectPairwise(manifest, covered)
+	case "full":
+		combinations = versionmatrix.AllCombinations(manifest)
+	default:
+		return fmt.Errorf("unknown strategy %q", strategy)
+	}
+	logger.Info("Selected combinations", "strategy", strategy, "combinations", len(combinations),
+		"all", len(versionmatrix.AllCombinations(manifest)))
+
+	runner := &versionmatrix.Runner{
+		Log:      logger,
+		Manifest: manifest,
+		Command:  command,
+		Timeout:  timeout,
+		Stdout:   os.Stdout,
+		Stderr:   os.Stderr,
+	}
+	ctx := ctxinterrupt.WithSignalWaiterMain(context.Background())
+	results, runErr := runner.Run(ctx, combinations)
+	if resultsPath != "" {
+		if err := jsonutil.WriteJSON(results, ioutil.ToStdOutOrFileOrNoop(resultsPath, 0o644)); err != nil {
+			return errors.Join(runErr, fmt.Errorf("failed to write results: %w", err))
+		}
+	}
+	return runErr
+}
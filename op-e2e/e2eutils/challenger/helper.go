@@ -86,6 +86,13 @@ func WithPrivKey(key *ecdsa.PrivateKey) Option {
 	}
 }
 
+// WithBondClaimants claims the bonds of the given addresses, in addition to the bonds of the challenger itself.
+func WithBondClaimants(claimants ...common.Address) Option {
+	return func(c *config.Config) {
+		c.AdditionalBondClaimants = append(c.AdditionalBondClaimants, claimants...)
+	}
+}
+
 func WithPollInterval(pollInterval time.Duration) Option {
 	return func(c *config.Config) {
 		c.PollInterval = pollInterval
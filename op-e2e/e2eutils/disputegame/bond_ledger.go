@@ -0,0 +1,156 @@
+package disputegame
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/require"
+)
+
+// GameBonds is the bond accounting of a single dispute game.
+type GameBonds struct {
+	Addr   common.Address
+	Status gameTypes.GameStatus
+	Mode   types.BondDistributionMode
+	// Bonds is the sum of the bonds posted with every claim in the game.
+	Bonds *big.Int
+	// Credits is the sum of the credit of every claimant, as distributed by the game.
+	Credits *big.Int
+	// Balance is the balance of the game in its DelayedWETH contract.
+	Balance *big.Int
+	WETH    common.Address
+}
+
+// Settled returns true when the bonds of the game have been distributed and all credit has been withdrawn.
+func (g *GameBonds) Settled() bool {
+	return g.Mode != types.UndecidedDistributionMode && g.Credits.Sign() == 0 && g.Balance.Sign() == 0
+}
+
+func (g *GameBonds) String() string {
+	return fmt.Sprintf("Game %v - %v - mode: %v, bonds: %v, credits: %v, balance: %v, weth: %v",
+		g.Addr, g.Status, g.Mode, g.Bonds, g.Credits, g.Balance, g.WETH)
+}
+
+// BondLedger reconciles the bonds posted to the games of the dispute game factory
+// with the funds held by their DelayedWETH contracts.
+type BondLedger struct {
+	t       *testing.T
+	require *require.Assertions
+	client  *ethclient.Client
+	factory *bindings.DisputeGameFactory
+	caller  *batching.MultiCaller
+}
+
+func (h *FactoryHelper) BondLedger() *BondLedger {
+	return &BondLedger{
+		t:       h.T,
+		require: h.Require,
+		client:  h.Client,
+		factory: h.Factory,
+		caller:  batching.NewMultiCaller(h.Client.Client(), batching.DefaultBatchSize),
+	}
+}
+
+// Games loads the bond accounting of every game created by the factory.
+func (l *BondLedger) Games(ctx context.Context) []*GameBonds {
+	count, err := l.factory.GameCount(&bind.CallOpts{Context: ctx})
+	l.require.NoError(err, "Failed to load game count")
+	games := make([]*GameBonds, 0, count.Uint64())
+	for i := uint64(0); i < count.Uint64(); i++ {
+		game, err := l.factory.GameAtIndex(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(i))
+		l.require.NoErrorf(err, "Failed to load game %v", i)
+		games = append(games, l.Game(ctx, game.Proxy))
+	}
+	return games
+}
+
+// Game loads the bond accounting of a single game.
+func (l *BondLedger) Game(ctx context.Context, addr common.Address) *GameBonds {
+	game, err := contracts.NewFaultDisputeGameContract(ctx, metrics.NoopContractMetrics, addr, l.caller)
+	l.require.NoErrorf(err, "Failed to bind game %v", addr)
+	claims, err := game.GetAllClaims(ctx, rpcblock.Latest)
+	l.require.NoErrorf(err, "Failed to load claims of game %v", addr)
+	status, err := game.GetStatus(ctx)
+	l.require.NoErrorf(err, "Failed to load status of game %v", addr)
+	mode, err := game.GetBondDistributionMode(ctx, rpcblock.Latest)
+	l.require.NoErrorf(err, "Failed to load bond distribution mode of game %v", addr)
+	_, _, wethAddr, err := game.GetBalanceAndDelay(ctx, rpcblock.Latest)
+	l.require.NoErrorf(err, "Failed to load DelayedWETH of game %v", addr)
+
+	bonds := new(big.Int)
+	var claimants []common.Address
+	seen := make(map[common.Address]bool)
+	for _, claim := range claims {
+		bonds.Add(bonds, claim.Bond)
+		if !seen[claim.Claimant] {
+			seen[claim.Claimant] = true
+			claimants = append(claimants, claim.Claimant)
+		}
+	}
+	credits := new(big.Int)
+	if mode != types.UndecidedDistributionMode {
+		// Bonds are only distributed to the claimants once the bond distribution mode is decided.
+		amounts, err := game.GetCredits(ctx, rpcblock.Latest, claimants...)
+		l.require.NoErrorf(err, "Failed to load credits of game %v", addr)
+		for _, amount := range amounts {
+			credits.Add(credits, amount)
+		}
+	}
+	weth, err := bindings.NewDelayedWETHCaller(wethAddr, l.client)
+	l.require.NoError(err)
+	balance, err := weth.BalanceOf(&bind.CallOpts{Context: ctx}, addr)
+	l.require.NoErrorf(err, "Failed to load DelayedWETH balance of game %v", addr)
+	return &GameBonds{
+		Addr:    addr,
+		Status:  status,
+		Mode:    mode,
+		Bonds:   bonds,
+		Credits: credits,
+		Balance: balance,
+		WETH:    wethAddr,
+	}
+}
+
+// RequireReconciled asserts that no funds leaked from the games of the factory:
+// every game holds exactly the bonds posted to it until they are distributed, and exactly the credit it owes after,
+// and every DelayedWETH contract holds exactly the balances of its games.
+func (l *BondLedger) RequireReconciled(ctx context.Context) []*GameBonds {
+	games := l.Games(ctx)
+	var report strings.Builder
+	for _, game := range games {
+		report.WriteString(game.String() + "\n")
+	}
+	l.t.Logf("Bond ledger:\n%v", report.String())
+
+	held := make(map[common.Address]*big.Int)
+	for _, game := range games {
+		if game.Mode == types.UndecidedDistributionMode {
+			l.require.Zerof(game.Bonds.Cmp(game.Balance), "Undistributed bonds do not match DelayedWETH balance: %v", game)
+		} else {
+			l.require.Zerof(game.Credits.Cmp(game.Balance), "Distributed credit does not match DelayedWETH balance: %v", game)
+		}
+		if _, ok := held[game.WETH]; !ok {
+			held[game.WETH] = new(big.Int)
+		}
+		held[game.WETH].Add(held[game.WETH], game.Balance)
+	}
+	for wethAddr, expected := range held {
+		balance, err := l.client.BalanceAt(ctx, wethAddr, nil)
+		l.require.NoErrorf(err, "Failed to load ETH balance of DelayedWETH %v", wethAddr)
+		l.require.Zerof(expected.Cmp(balance), "DelayedWETH %v holds %v but its games hold %v", wethAddr, balance, expected)
+	}
+	return games
+}
@@ -0,0 +1,125 @@
+// Package versionmatrix runs an e2e scenario across combinations of component versions,
+// to test the compatibility of the components of different releases, instead of only the components of the same commit.
+//
+// The versions of the components are listed in a manifest. The combinations to run are selected to cover
+// every pair of versions of two components, see SelectPairwise. The scenario learns the versions to run
+// from the environment, see Manifest.Env.
+package versionmatrix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var ErrInvalidManifest = errors.New("invalid manifest")
+
+// Version is a version of a component.
+type Version struct {
+	// Name is the name of the version, like "N" for the current release and "N-1" for the previous one.
+	Name  string `json:"name"`
+	Image string `json:"image"`
+}
+
+// Component is a component of the devnet, like op-node or op-geth.
+type Component struct {
+	Name string `json:"name"`
+	// Versions of the component. The first version is the baseline, usually the version of the current commit.
+	Versions []Version `json:"versions"`
+}
+
+// Manifest lists the versions of the components to run the scenario with.
+type Manifest struct {
+	Components []Component `json:"components"`
+}
+
+// Combination holds the index of the version of each component, in the order of the components of the manifest.
+type Combination []int
+
+func LoadManifest(path string) (*Manifest, error) {
+	m, err := jsonutil.LoadJSON[Manifest](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Check checks that the components and their versions are named uniquely, and that every component has a version.
+func (m *Manifest) Check() error {
+	if len(m.Components) == 0 {
+		return fmt.Errorf("%w: no components", ErrInvalidManifest)
+	}
+	components := make(map[string]bool)
+	for _, c := range m.Components {
+		if c.Name == "" {
+			return fmt.Errorf("%w: component without name", ErrInvalidManifest)
+		}
+		if components[c.Name] {
+			return fmt.Errorf("%w: duplicate component %q", ErrInvalidManifest, c.Name)
+		}
+		components[c.Name] = true
+		if len(c.Versions) == 0 {
+			return fmt.Errorf("%w: component %q has no versions", ErrInvalidManifest, c.Name)
+		}
+		versions := make(map[string]bool)
+		for _, v := range c.Versions {
+			if v.Name == "" || v.Image == "" {
+				return fmt.Errorf("%w: version of component %q without name or image", ErrInvalidManifest, c.Name)
+			}
+			if versions[v.Name] {
+				return fmt.Errorf("%w: duplicate version %q of component %q", ErrInvalidManifest, v.Name, c.Name)
+			}
+			versions[v.Name] = true
+		}
+	}
+	return nil
+}
+
+// Versions returns the version name of each component of the combination, by component name.
+func (m *Manifest) Versions(c Combination) map[string]string {
+	out := make(map[string]string, len(m.Components))
+	for i, comp := range m.Components {
+		out[comp.Name] = comp.Versions[c[i]].Name
+	}
+	return out
+}
+
+// Describe returns the versions of the combination, like "op-node=N-1 op-geth=N".
+func (m *Manifest) Describe(c Combination) string {
+	parts := make([]string, len(m.Components))
+	for i, comp := range m.Components {
+		parts[i] = comp.Name + "=" + comp.Versions[c[i]].Name
+	}
+	return strings.Join(parts, " ")
+}
+
+// Env returns the environment variables that pass the versions of the combination to the scenario:
+// DEVNET_IMAGE_<COMPONENT> with the image and DEVNET_VERSION_<COMPONENT> with the version name of each component,
+// where <COMPONENT> is the upper-case component name, with dashes replaced by underscores.
+func (m *Manifest) Env(c Combination) []string {
+	env := make([]string, 0, 2*len(m.Components))
+	for i, comp := range m.Components {
+		v := comp.Versions[c[i]]
+		env = append(env, ImageEnvVar(comp.Name)+"="+v.Image, VersionEnvVar(comp.Name)+"="+v.Name)
+	}
+	return env
+}
+
+// ImageEnvVar returns the environment variable with the image of the component to run.
+func ImageEnvVar(component string) string {
+	return "DEVNET_IMAGE_" + envName(component)
+}
+
+// VersionEnvVar returns the environment variable with the version name of the component to run.
+func VersionEnvVar(component string) string {
+	return "DEVNET_VERSION_" + envName(component)
+}
+
+func envName(component string) string {
+	return strings.ToUpper(strings.ReplaceAll(component, "-", "_"))
+}
@@ -0,0 +1,120 @@
+package versionmatrix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrIncompatible = errors.New("scenario failed with combinations of component versions")
+
+// Result is the result of the scenario with a combination of component versions.
+type Result struct {
+	// Versions is the version name of each component, by component name.
+	Versions map[string]string `json:"versions"`
+	Passed   bool              `json:"passed"`
+	Duration time.Duration     `json:"duration"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// CoverageOf returns the pairs of component versions of the manifest that passed the scenario together.
+// Results with a component or version that is not in the manifest are ignored.
+func CoverageOf(m *Manifest, results []Result) Coverage {
+	cov := make(Coverage)
+	for _, r := range results {
+		if !r.Passed {
+			continue
+		}
+		comb, ok := m.combination(r.Versions)
+		if ok {
+			cov.Add(m, comb)
+		}
+	}
+	return cov
+}
+
+// combination returns the combination of the version names by component name, if all are in the manifest.
+func (m *Manifest) combination(versions map[string]string) (Combination, bool) {
+	comb := make(Combination, len(m.Components))
+	for i, c := range m.Components {
+		found := false
+		for j, v := range c.Versions {
+			if v.Name == versions[c.Name] {
+				comb[i], found = j, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return comb, true
+}
+
+// Runner runs the scenario command with combinations of component versions.
+type Runner struct {
+	Log      log.Logger
+	Manifest *Manifest
+	// Command is the scenario, like a go test command of an e2e test.
+	// It runs with the environment of the runner, and the versions of the combination, see Manifest.Env.
+	Command []string
+	// Timeout limits the duration of the scenario with a combination. No limit if zero.
+	Timeout time.Duration
+	Stdout  io.Writer
+	Stderr  io.Writer
+}
+
+// Run runs the scenario with each of the combinations, and returns the results in the order of the combinations.
+// It returns ErrIncompatible if the scenario failed with any combination, after running all of them.
+func (r *Runner) Run(ctx context.Context, combinations []Combination) ([]Result, error) {
+	if len(r.Command) == 0 {
+		return nil, errors.New("no scenario command")
+	}
+	results := make([]Result, 0, len(combinations))
+	failed := 0
+	for i, comb := range combinations {
+		desc := r.Manifest.Describe(comb)
+		r.Log.Info("Running scenario", "combination", i+1, "of", len(combinations), "versions", desc)
+		start := time.Now()
+		err := r.runCombination(ctx, comb)
+		result := Result{
+			Versions: r.Manifest.Versions(comb),
+			Passed:   err == nil,
+			Duration: time.Since(start),
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return results, ctx.Err()
+			}
+			failed++
+			result.Error = err.Error()
+			r.Log.Error("Scenario failed", "versions", desc, "duration", result.Duration, "err", err)
+		} else {
+			r.Log.Info("Scenario passed", "versions", desc, "duration", result.Duration)
+		}
+		results = append(results, result)
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%w: %d of %d failed", ErrIncompatible, failed, len(combinations))
+	}
+	return results, nil
+}
+
+func (r *Runner) runCombination(ctx context.Context, comb Combination) error {
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, r.Command[0], r.Command[1:]...)
+	cmd.Env = append(os.Environ(), r.Manifest.Env(comb)...)
+	cmd.Stdout = r.Stdout
+	cmd.Stderr = r.Stderr
+	return cmd.Run()
+}
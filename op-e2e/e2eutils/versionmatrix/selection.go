@@ -0,0 +1,85 @@
+package versionmatrix
+
+// pair is a pair of versions of two components, by name, with the components in manifest order.
+type pair struct {
+	a, versionA string
+	b, versionB string
+}
+
+// Coverage is the set of pairs of component versions that were run together.
+type Coverage map[pair]struct{}
+
+// Add adds the pairs of the versions of the combination.
+func (c Coverage) Add(m *Manifest, comb Combination) {
+	for _, p := range pairs(m, comb) {
+		c[p] = struct{}{}
+	}
+}
+
+// pairs returns the pairs of versions of the combination. A single component is paired with no component.
+func pairs(m *Manifest, comb Combination) []pair {
+	if len(m.Components) == 1 {
+		c := m.Components[0]
+		return []pair{{a: c.Name, versionA: c.Versions[comb[0]].Name}}
+	}
+	var out []pair
+	for i := 0; i < len(m.Components); i++ {
+		for j := i + 1; j < len(m.Components); j++ {
+			a, b := m.Components[i], m.Components[j]
+			out = append(out, pair{a: a.Name, versionA: a.Versions[comb[i]].Name, b: b.Name, versionB: b.Versions[comb[j]].Name})
+		}
+	}
+	return out
+}
+
+// AllCombinations returns every combination of the versions of the components, starting with the baseline,
+// the combination of the first version of every component.
+func AllCombinations(m *Manifest) []Combination {
+	out := []Combination{make(Combination, len(m.Components))}
+	for i := len(m.Components) - 1; i >= 0; i-- {
+		var next []Combination
+		for _, comb := range out {
+			for v := range m.Components[i].Versions {
+				c := append(Combination(nil), comb...)
+				c[i] = v
+				next = append(next, c)
+			}
+		}
+		out = next
+	}
+	return out
+}
+
+// SelectPairwise selects combinations that run every pair of versions of two components together,
+// skipping the pairs that are already covered, e.g. by earlier runs.
+// Incompatibilities usually show between two components, like op-node and op-geth, so this covers most
+// of the incompatibilities with a fraction of all the combinations.
+// The combinations are picked greedily, each covering the most pairs that are not covered yet.
+// Without coverage, the baseline is selected first.
+func SelectPairwise(m *Manifest, covered Coverage) []Combination {
+	cov := make(Coverage, len(covered))
+	for p := range covered {
+		cov[p] = struct{}{}
+	}
+	all := AllCombinations(m)
+	var out []Combination
+	for {
+		best, bestNew := -1, 0
+		for i, comb := range all {
+			n := 0
+			for _, p := range pairs(m, comb) {
+				if _, ok := cov[p]; !ok {
+					n++
+				}
+			}
+			if n > bestNew {
+				best, bestNew = i, n
+			}
+		}
+		if best < 0 {
+			return out
+		}
+		out = append(out, all[best])
+		cov.Add(m, all[best])
+	}
+}
@@ -0,0 +1,158 @@
+package versionmatrix
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func testManifest() *Manifest {
+	return &Manifest{Components: []Component{
+		{Name: "op-node", Versions: []Version{{Name: "N", Image: "op-node:n"}, {Name: "N-1", Image: "op-node:n-1"}}},
+		{Name: "op-geth", Versions: []Version{{Name: "N", Image: "op-geth:n"}, {Name: "N-1", Image: "op-geth:n-1"}}},
+		{Name: "op-supervisor", Versions: []Version{{Name: "N", Image: "op-supervisor:n"}, {Name: "N-1", Image: "op-supervisor:n-1"}}},
+	}}
+}
+
+// requireAllPairsCovered checks that every pair of versions of two components is in one of the combinations.
+func requireAllPairsCovered(t *testing.T, m *Manifest, combinations []Combination) {
+	cov := make(Coverage)
+	for _, comb := range combinations {
+		cov.Add(m, comb)
+	}
+	for _, comb := range AllCombinations(m) {
+		for _, p := range pairs(m, comb) {
+			require.Contains(t, cov, p)
+		}
+	}
+}
+
+func TestManifestCheck(t *testing.T) {
+	require.NoError(t, testManifest().Check())
+
+	m := testManifest()
+	m.Components[1].Name = "op-node"
+	require.ErrorIs(t, m.Check(), ErrInvalidManifest)
+
+	m = testManifest()
+	m.Components[1].Versions[1].Name = "N"
+	require.ErrorIs(t, m.Check(), ErrInvalidManifest)
+
+	m = testManifest()
+	m.Components[2].Versions = nil
+	require.ErrorIs(t, m.Check(), ErrInvalidManifest)
+
+	require.ErrorIs(t, new(Manifest).Check(), ErrInvalidManifest)
+}
+
+func TestLoadManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "versions.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"components": [
+		{"name": "op-node", "versions": [{"name": "N", "image": "op-node:n"}, {"name": "N-1", "image": "op-node:n-1"}]}
+	]}`), 0o644))
+	m, err := LoadManifest(path)
+	require.NoError(t, err)
+	require.Len(t, m.Components[0].Versions, 2)
+}
+
+func TestManifestEnv(t *testing.T) {
+	m := testManifest()
+	comb := Combination{1, 0, 0}
+	require.Equal(t, "op-node=N-1 op-geth=N op-supervisor=N", m.Describe(comb))
+	require.Equal(t, []string{
+		"DEVNET_IMAGE_OP_NODE=op-node:n-1", "DEVNET_VERSION_OP_NODE=N-1",
+		"DEVNET_IMAGE_OP_GETH=op-geth:n", "DEVNET_VERSION_OP_GETH=N",
+		"DEVNET_IMAGE_OP_SUPERVISOR=op-supervisor:n", "DEVNET_VERSION_OP_SUPERVISOR=N",
+	}, m.Env(comb))
+}
+
+func TestAllCombinations(t *testing.T) {
+	all := AllCombinations(testManifest())
+	require.Len(t, all, 8)
+	require.Equal(t, Combination{0, 0, 0}, all[0], "baseline first")
+	seen := make(map[string]bool)
+	for _, comb := range all {
+		seen[testManifest().Describe(comb)] = true
+	}
+	require.Len(t, seen, 8)
+}
+
+func TestSelectPairwise(t *testing.T) {
+	t.Run("covers all pairs", func(t *testing.T) {
+		m := testManifest()
+		selected := SelectPairwise(m, nil)
+		require.Equal(t, Combination{0, 0, 0}, selected[0], "baseline first")
+		require.Len(t, selected, 4, "two versions of three components are covered by four combinations")
+		requireAllPairsCovered(t, m, selected)
+	})
+
+	t.Run("uneven versions", func(t *testing.T) {
+		m := testManifest()
+		m.Components[2].Versions = m.Components[2].Versions[:1]
+		m.Components = append(m.Components, Component{Name: "op-batcher", Versions: []Version{
+			{Name: "N", Image: "op-batcher:n"}, {Name: "N-1", Image: "op-batcher:n-1"}, {Name: "N-2", Image: "op-batcher:n-2"},
+		}})
+		selected := SelectPairwise(m, nil)
+		require.Less(t, len(selected), len(AllCombinations(m)))
+		requireAllPairsCovered(t, m, selected)
+	})
+
+	t.Run("single component", func(t *testing.T) {
+		m := &Manifest{Components: testManifest().Components[:1]}
+		require.Equal(t, []Combination{{0}, {1}}, SelectPairwise(m, nil))
+	})
+
+	t.Run("skips covered pairs", func(t *testing.T) {
+		m := testManifest()
+		results := []Result{
+			{Versions: map[string]string{"op-node": "N", "op-geth": "N", "op-supervisor": "N"}, Passed: true},
+			{Versions: map[string]string{"op-node": "N-1", "op-geth": "N-1", "op-supervisor": "N"}, Passed: false},
+			{Versions: map[string]string{"op-node": "N-2", "op-geth": "N", "op-supervisor": "N"}, Passed: true},
+		}
+		cov := CoverageOf(m, results)
+		require.Len(t, cov, 3, "only the passed baseline is covered")
+		selected := SelectPairwise(m, cov)
+		require.NotContains(t, selected, Combination{0, 0, 0})
+		requireAllPairsCovered(t, m, append(selected, Combination{0, 0, 0}))
+
+		for _, comb := range AllCombinations(m) {
+			cov.Add(m, comb)
+		}
+		require.Empty(t, SelectPairwise(m, cov))
+	})
+}
+
+func TestRunner(t *testing.T) {
+	m := &Manifest{Components: testManifest().Components[:2]}
+	var out bytes.Buffer
+	runner := &Runner{
+		Log:      testlog.Logger(t, log.LevelInfo),
+		Manifest: m,
+		// op-node N-1 is incompatible with op-geth N-1
+		Command: []string{"sh", "-c", `echo "$DEVNET_IMAGE_OP_NODE $DEVNET_VERSION_OP_GETH"; [ "$DEVNET_VERSION_OP_NODE$DEVNET_VERSION_OP_GETH" != "N-1N-1" ]`},
+		Stdout:  &out,
+		Stderr:  &out,
+	}
+	results, err := runner.Run(context.Background(), AllCombinations(m))
+	require.ErrorIs(t, err, ErrIncompatible)
+	require.Len(t, results, 4)
+	for _, r := range results {
+		incompatible := r.Versions["op-node"] == "N-1" && r.Versions["op-geth"] == "N-1"
+		require.Equal(t, !incompatible, r.Passed, "versions %v", r.Versions)
+		if incompatible {
+			require.NotEmpty(t, r.Error)
+		}
+	}
+	require.Contains(t, out.String(), "op-node:n-1 N-1\n")
+
+	results, err = runner.Run(context.Background(), []Combination{{0, 0}})
+	require.NoError(t, err)
+	require.True(t, results[0].Passed)
+}
@@ -0,0 +1,157 @@
+package faultproofs
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/challenger"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/disputegame"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-e2e/system/e2esys"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDisputeGameLifecycleSoak runs many cycles of games, proposed by the proposer and by honest and dishonest actors,
+// and disputed by the honest challenger and by dishonest actors.
+// It asserts that the bonds of every game reconcile with the funds held by DelayedWETH through every stage of the
+// game lifecycle, and that no funds are left behind once every game has settled.
+// The number of cycles can be set with OP_E2E_BOND_SOAK_CYCLES.
+func TestDisputeGameLifecycleSoak(t *testing.T) {
+	op_e2e.InitParallel(t, op_e2e.IsSlow)
+	ctx := context.Background()
+	cycles := soakCycles(t)
+	sys, l1Client := StartFaultDisputeSystem(t, WithProposer())
+	t.Cleanup(sys.Close)
+
+	addrs := sys.Cfg.Secrets.Addresses()
+	malloryOpts, err := bind.NewKeyedTransactorWithChainID(sys.Cfg.Secrets.Mallory, sys.Cfg.L1ChainIDBig())
+	require.NoError(t, err)
+
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	// The honest challenger also claims the bonds of every other participant, so all games can settle completely.
+	disputeGameFactory.StartChallenger(ctx, "Challenger",
+		challenger.WithAlphabet(),
+		challenger.WithFastGames(),
+		challenger.WithPrivKey(sys.Cfg.Secrets.Alice),
+		challenger.WithBondClaimants(addrs.Proposer, addrs.Mallory, disputegame.TestAddress))
+	ledger := disputeGameFactory.BondLedger()
+
+	var settleStep time.Duration
+	for cycle := 0; cycle < cycles; cycle++ {
+		t.Logf("Starting soak cycle %v of %v", cycle+1, cycles)
+		l2BlockNum := nextSafeL2Block(t, ctx, sys)
+
+		// A dishonest proposal, which the game creator keeps defending against the honest challenger.
+		invalidGame := disputeGameFactory.StartOutputAlphabetGame(ctx, "sequencer", l2BlockNum, common.Hash{0xba, 0xd0, byte(cycle)})
+		disputeDishonestly(ctx, invalidGame.RootClaim(ctx).WaitForCounterClaim(ctx), disputeGameFactory.Opts)
+
+		// An honest proposal, which Mallory disputes.
+		validGame := disputeGameFactory.StartOutputAlphabetGameWithCorrectRoot(ctx, "sequencer", l2BlockNum)
+		disputeDishonestly(ctx, validGame.RootClaim(ctx), malloryOpts)
+		ledger.RequireReconciled(ctx)
+
+		settleStep = max(validGame.MaxClockDuration(ctx), validGame.CreditUnlockDuration(ctx))
+		sys.TimeTravelClock.AdvanceTime(validGame.MaxClockDuration(ctx))
+		require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+		invalidGame.WaitForGameStatus(ctx, types.GameStatusChallengerWon)
+		validGame.WaitForGameStatus(ctx, types.GameStatusDefenderWon)
+		ledger.RequireReconciled(ctx)
+
+		// Advance past the finalization delay, so the bond distribution mode is decided.
+		sys.TimeTravelClock.AdvanceTime(validGame.CreditUnlockDuration(ctx) * 2)
+		require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+		invalidGame.WaitForBondModeDecided(ctx)
+		validGame.WaitForBondModeDecided(ctx)
+		ledger.RequireReconciled(ctx)
+		// No credit can have been withdrawn before the credit unlock delay passed, so all bonds must be distributed.
+		for _, addr := range []common.Address{invalidGame.Addr, validGame.Addr} {
+			game := ledger.Game(ctx, addr)
+			require.Zerof(t, game.Bonds.Cmp(game.Credits), "Bonds not fully distributed: %v", game)
+		}
+		require.Zero(t, invalidGame.Credit(ctx, disputegame.TestAddress).Sign(), "Dishonest proposer should lose its bonds")
+		require.Zero(t, validGame.Credit(ctx, addrs.Mallory).Sign(), "Dishonest challenger should lose its bonds")
+
+		// Advance past the credit unlock delay, so all credit can be withdrawn.
+		sys.TimeTravelClock.AdvanceTime(validGame.CreditUnlockDuration(ctx))
+		require.NoError(t, wait.ForNextBlock(ctx, l1Client))
+		waitForSettled(t, ctx, ledger, invalidGame.Addr, validGame.Addr)
+		ledger.RequireReconciled(ctx)
+	}
+
+	// Stop proposing, and settle every game, including the ones created by the proposer.
+	require.NoError(t, sys.L2OutputSubmitter.Stop(ctx))
+	settleAll(t, ctx, sys, ledger, settleStep)
+	// With every game settled, reconciling also asserts that DelayedWETH holds no funds at all.
+	ledger.RequireReconciled(ctx)
+}
+
+func soakCycles(t *testing.T) int {
+	cycles := 3
+	if env := os.Getenv("OP_E2E_BOND_SOAK_CYCLES"); env != "" {
+		var err error
+		cycles, err = strconv.Atoi(env)
+		require.NoError(t, err, "invalid OP_E2E_BOND_SOAK_CYCLES")
+		require.Positive(t, cycles, "OP_E2E_BOND_SOAK_CYCLES must be positive")
+	}
+	return cycles
+}
+
+// nextSafeL2Block returns the block after the current safe head, which is beyond the anchor state of every game type.
+func nextSafeL2Block(t *testing.T, ctx context.Context, sys *e2esys.System) uint64 {
+	status, err := sys.RollupClient("sequencer").SyncStatus(ctx)
+	require.NoError(t, err)
+	return status.SafeL2.Number + 1
+}
+
+// disputeDishonestly counters the claims of the honest challenger with invalid claims, starting at the given claim.
+func disputeDishonestly(ctx context.Context, claim *disputegame.ClaimHelper, opts *bind.TransactOpts) {
+	for i := 0; i < 2; i++ {
+		claim = claim.Attack(ctx, common.Hash{0xde, byte(i)}, disputegame.WithTransactOpts(opts))
+		if claim.IsMaxDepth(ctx) {
+			claim.WaitForCountered(ctx)
+			return
+		}
+		claim = claim.WaitForCounterClaim(ctx)
+	}
+}
+
+func waitForSettled(t *testing.T, ctx context.Context, ledger *disputegame.BondLedger, addrs ...common.Address) {
+	timedCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		for _, addr := range addrs {
+			if game := ledger.Game(timedCtx, addr); !game.Settled() {
+				t.Logf("Waiting for game to settle: %v", game)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	require.NoError(t, err, "Games did not settle")
+}
+
+// settleAll advances time until every game is resolved, its bonds distributed and all credit withdrawn.
+func settleAll(t *testing.T, ctx context.Context, sys *e2esys.System, ledger *disputegame.BondLedger, step time.Duration) {
+	timedCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+	err := wait.For(timedCtx, time.Second, func() (bool, error) {
+		for _, game := range ledger.Games(timedCtx) {
+			if !game.Settled() {
+				t.Logf("Waiting for game to settle: %v", game)
+				// Games may be waiting on their clocks, the finalization delay or the credit unlock delay.
+				sys.TimeTravelClock.AdvanceTime(step)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	require.NoError(t, err, "Games did not settle")
+}
@@ -33,6 +33,15 @@ func WithBatcherStopped() faultDisputeConfigOpts {
 	}
 }
 
+// WithProposer runs the proposer, which creates a game for every proposed output.
+func WithProposer() faultDisputeConfigOpts {
+	return func(fdc *faultDisputeConfig) {
+		fdc.cfgModifiers = append(fdc.cfgModifiers, func(cfg *e2esys.SystemConfig) {
+			cfg.DisableProposer = false
+		})
+	}
+}
+
 func WithBlobBatches() faultDisputeConfigOpts {
 	return func(fdc *faultDisputeConfig) {
 		fdc.cfgModifiers = append(fdc.cfgModifiers, func(cfg *e2esys.SystemConfig) {
@@ -0,0 +1,37 @@
+package conformance
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestExternalEndpoint runs the conformance suite against an externally provided node,
+// e.g. an alternative implementation of the managed-mode RPC.
+// The node is configured with the endpoint and the hex-encoded JWT secret.
+func TestExternalEndpoint(t *testing.T) {
+	endpoint := os.Getenv("INTEROP_CONFORMANCE_RPC")
+	if endpoint == "" {
+		t.Skip("INTEROP_CONFORMANCE_RPC not set")
+	}
+	secret, err := hexutil.Decode(os.Getenv("INTEROP_CONFORMANCE_JWT_SECRET"))
+	require.NoError(t, err, "INTEROP_CONFORMANCE_JWT_SECRET must be a hex-encoded 32 byte secret")
+	require.Len(t, secret, 32, "INTEROP_CONFORMANCE_JWT_SECRET must be a hex-encoded 32 byte secret")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cl, err := Dial(ctx, testlog.Logger(t, log.LevelInfo), endpoint, eth.Bytes32(secret))
+	require.NoError(t, err)
+	defer cl.Close()
+
+	Run(t, &Target{Client: cl, EventsTimeout: 10 * time.Second})
+}
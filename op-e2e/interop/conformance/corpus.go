@@ -0,0 +1,262 @@
+// Package conformance implements a conformance suite for the managed-mode interop RPC,
+// the API that a node exposes for the op-supervisor to manage it.
+//
+// The suite runs a canonical corpus of interactions against any endpoint implementing the API,
+// and verifies the responses against the schema and error codes of the spec.
+// The corpus does not change the chain state of the node,
+// but it does consume node events: the node must not be attached to a supervisor while the suite runs.
+// The node must have at least one block after genesis.
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	gn "github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// Error codes of the managed-mode RPC.
+const (
+	BlockNotFoundErrCode    = -39001
+	ConflictingBlockErrCode = -39002
+	InteropInactiveErrCode  = -39003
+)
+
+// Target is the endpoint under test.
+type Target struct {
+	Client client.RPC
+	// ChainID is the expected chain ID of the node. It is not checked if nil.
+	ChainID *eth.ChainID
+	// EventsTimeout is how long to wait for an event on the events subscription.
+	// No event arriving is not a failure, since the node may not produce blocks.
+	EventsTimeout time.Duration
+}
+
+// Case is a single interaction of the conformance corpus.
+type Case struct {
+	Name string
+	Run  func(t *testing.T, ctx context.Context, target *Target)
+}
+
+// Dial connects to a managed-mode RPC endpoint, authenticating with the given JWT secret.
+func Dial(ctx context.Context, logger log.Logger, endpoint string, jwtSecret eth.Bytes32) (client.RPC, error) {
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(jwtSecret))
+	return client.NewRPC(ctx, logger, endpoint, client.WithGethRPCOptions(auth))
+}
+
+// Run runs the full corpus against the target, each case as a sub-test.
+func Run(t *testing.T, target *Target) {
+	for _, c := range Corpus() {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			c.Run(t, ctx, target)
+		})
+	}
+}
+
+// call calls the method, and validates the result against the schema.
+func call(ctx context.Context, target *Target, schema Schema, method string, args ...any) (json.RawMessage, error) {
+	var raw json.RawMessage
+	if err := target.Client.CallContext(ctx, &raw, method, args...); err != nil {
+		return nil, err
+	}
+	if err := CheckJSON(schema, raw); err != nil {
+		return raw, &SchemaError{Method: method, Err: err}
+	}
+	return raw, nil
+}
+
+// SchemaError is returned when a response does not match the schema of the spec.
+type SchemaError struct {
+	Method string
+	Err    error
+}
+
+func (e *SchemaError) Error() string {
+	return "response of " + e.Method + " does not match schema: " + e.Err.Error()
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// mustCall calls the method, and decodes the result into dest, after validating it against the schema.
+func mustCall(t *testing.T, ctx context.Context, target *Target, dest any, schema Schema, method string, args ...any) {
+	raw, err := call(ctx, target, schema, method, args...)
+	require.NoError(t, err, "raw response: %s", raw)
+	require.NoError(t, json.Unmarshal(raw, dest))
+}
+
+// requireRPCError asserts that the call failed with a JSON-RPC error, of the given code if non-zero.
+func requireRPCError(t *testing.T, err error, code int) {
+	require.Error(t, err, "expected call to fail")
+	var schemaErr *SchemaError
+	require.False(t, errors.As(err, &schemaErr), "expected error response, got result: %v", err)
+	var rpcErr rpc.Error
+	require.True(t, errors.As(err, &rpcErr), "expected JSON-RPC error, got %v", err)
+	if code != 0 {
+		require.Equal(t, code, rpcErr.ErrorCode(), "unexpected error code, error: %v", err)
+	}
+}
+
+// unknownHash is a block hash that no chain is expected to have.
+var unknownHash = common.HexToHash("0xbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbadbad0")
+
+// unknownNumber is a block number that no chain is expected to have.
+const unknownNumber = uint64(1) << 60
+
+// firstBlock retrieves the first block after genesis, which the corpus uses as known block.
+func firstBlock(t *testing.T, ctx context.Context, target *Target) eth.BlockRef {
+	var ref eth.BlockRef
+	mustCall(t, ctx, target, &ref, BlockRefSchema, "interop_blockRefByNumber", uint64(1))
+	return ref
+}
+
+// Corpus returns the canonical set of interactions.
+func Corpus() []Case {
+	return []Case{
+		{Name: "chainID", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			var id eth.ChainID
+			mustCall(t, ctx, target, &id, DecimalString, "interop_chainID")
+			if target.ChainID != nil {
+				require.Equal(t, *target.ChainID, id)
+			}
+		}},
+		{Name: "blockRefByNumber/genesis", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			var ref eth.BlockRef
+			mustCall(t, ctx, target, &ref, BlockRefSchema, "interop_blockRefByNumber", uint64(0))
+			require.Zero(t, ref.Number)
+		}},
+		{Name: "blockRefByNumber/child", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			var genesis eth.BlockRef
+			mustCall(t, ctx, target, &genesis, BlockRefSchema, "interop_blockRefByNumber", uint64(0))
+			ref := firstBlock(t, ctx, target)
+			require.Equal(t, uint64(1), ref.Number)
+			require.Equal(t, genesis.Hash, ref.ParentHash)
+			require.Greater(t, ref.Time, genesis.Time)
+		}},
+		{Name: "blockRefByNumber/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			_, err := call(ctx, target, BlockRefSchema, "interop_blockRefByNumber", unknownNumber)
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "fetchReceipts", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			ref := firstBlock(t, ctx, target)
+			var receipts []json.RawMessage
+			mustCall(t, ctx, target, &receipts, Array{Elem: ReceiptSchema}, "interop_fetchReceipts", ref.Hash)
+			// every L2 block starts with the L1 info deposit transaction
+			require.NotEmpty(t, receipts)
+		}},
+		{Name: "fetchReceipts/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			_, err := call(ctx, target, Array{Elem: ReceiptSchema}, "interop_fetchReceipts", unknownHash)
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "l2BlockRefByTimestamp", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			ref := firstBlock(t, ctx, target)
+			var l2Ref eth.L2BlockRef
+			mustCall(t, ctx, target, &l2Ref, L2BlockRefSchema, "interop_l2BlockRefByTimestamp", ref.Time)
+			require.Equal(t, ref.ID(), l2Ref.ID())
+		}},
+		{Name: "l2BlockRefByTimestamp/beforeGenesis", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			_, err := call(ctx, target, L2BlockRefSchema, "interop_l2BlockRefByTimestamp", uint64(0))
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "outputV0AtTimestamp", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			ref := firstBlock(t, ctx, target)
+			var output eth.OutputV0
+			mustCall(t, ctx, target, &output, OutputV0Schema, "interop_outputV0AtTimestamp", ref.Time)
+			require.Equal(t, ref.Hash, output.BlockHash)
+		}},
+		{Name: "pendingOutputV0AtTimestamp", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			ref := firstBlock(t, ctx, target)
+			var output, pending eth.OutputV0
+			mustCall(t, ctx, target, &output, OutputV0Schema, "interop_outputV0AtTimestamp", ref.Time)
+			mustCall(t, ctx, target, &pending, OutputV0Schema, "interop_pendingOutputV0AtTimestamp", ref.Time)
+			// the block was not replaced, so the pending output is the canonical output
+			require.Equal(t, output, pending)
+		}},
+		{Name: "outputV0AtTimestamp/beforeGenesis", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			_, err := call(ctx, target, OutputV0Schema, "interop_outputV0AtTimestamp", uint64(0))
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "anchorPoint", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			raw, err := call(ctx, target, DerivedBlockRefPairSchema, "interop_anchorPoint")
+			var rpcErr rpc.Error
+			if errors.As(err, &rpcErr) {
+				require.Equal(t, InteropInactiveErrCode, rpcErr.ErrorCode(),
+					"anchor point may only be unavailable if interop is inactive at genesis, error: %v", err)
+				return
+			}
+			require.NoError(t, err, "raw response: %s", raw)
+		}},
+		{Name: "reset/conflicting", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			conflict := eth.BlockID{Hash: unknownHash, Number: 0}
+			err := target.Client.CallContext(ctx, nil, "interop_reset", conflict, conflict, conflict, conflict, conflict)
+			requireRPCError(t, err, ConflictingBlockErrCode)
+		}},
+		{Name: "reset/notFound", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			missing := eth.BlockID{Hash: unknownHash, Number: unknownNumber}
+			err := target.Client.CallContext(ctx, nil, "interop_reset", missing, missing, missing, missing, missing)
+			requireRPCError(t, err, BlockNotFoundErrCode)
+		}},
+		{Name: "updateCrossUnsafe/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			err := target.Client.CallContext(ctx, nil, "interop_updateCrossUnsafe", eth.BlockID{Hash: unknownHash, Number: 1})
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "updateCrossSafe/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			id := eth.BlockID{Hash: unknownHash, Number: 1}
+			err := target.Client.CallContext(ctx, nil, "interop_updateCrossSafe", id, id)
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "updateFinalized/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			err := target.Client.CallContext(ctx, nil, "interop_updateFinalized", eth.BlockID{Hash: unknownHash, Number: 1})
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "invalidateBlock/unknown", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			seal := supervisortypes.BlockSeal{Hash: unknownHash, Number: 1, Timestamp: 1}
+			err := target.Client.CallContext(ctx, nil, "interop_invalidateBlock", seal)
+			requireRPCError(t, err, 0)
+		}},
+		{Name: "events", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			ch := make(chan json.RawMessage, 10)
+			sub, err := target.Client.Subscribe(ctx, "interop", ch, "events")
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				t.Skip("subscriptions are not supported by the transport")
+			}
+			require.NoError(t, err)
+			defer sub.Unsubscribe()
+			timeout := time.NewTimer(target.EventsTimeout)
+			defer timeout.Stop()
+			select {
+			case raw := <-ch:
+				require.NoError(t, CheckJSON(EventEntrySchema, raw), "event entry: %s", raw)
+			case err := <-sub.Err():
+				require.NoError(t, err, "subscription failed")
+			case <-timeout.C:
+				t.Log("No event received within timeout")
+			}
+		}},
+		{Name: "pullEvent", Run: func(t *testing.T, ctx context.Context, target *Target) {
+			raw, err := call(ctx, target, ManagedEventSchema, "interop_pullEvent")
+			var rpcErr rpc.Error
+			if errors.As(err, &rpcErr) {
+				require.Equal(t, oprpc.OutOfEventsErrCode, rpcErr.ErrorCode(), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err, "raw response: %s", raw)
+		}},
+	}
+}
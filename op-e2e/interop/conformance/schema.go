@@ -0,0 +1,266 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema validates a JSON value, as decoded by encoding/json with UseNumber enabled.
+type Schema interface {
+	Validate(v any) error
+}
+
+type schemaFunc func(v any) error
+
+func (fn schemaFunc) Validate(v any) error {
+	return fn(v)
+}
+
+// CheckJSON decodes the raw JSON value and validates it against the schema.
+func CheckJSON(s Schema, raw json.RawMessage) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return s.Validate(v)
+}
+
+var (
+	quantityRegex = regexp.MustCompile(`^0x(0|[1-9a-f][0-9a-f]*)$`)
+	dataRegex     = regexp.MustCompile(`^0x([0-9a-f]{2})*$`)
+	decimalRegex  = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+)
+
+func matchString(kind string, re *regexp.Regexp) Schema {
+	return schemaFunc(func(v any) error {
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("expected %s string, got %T", kind, v)
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("invalid %s: %q", kind, s)
+		}
+		return nil
+	})
+}
+
+func hexBytes(kind string, size int) Schema {
+	return matchString(kind, regexp.MustCompile(fmt.Sprintf(`^0x[0-9a-fA-F]{%d}$`, size*2)))
+}
+
+var (
+	// Hash is a 0x-prefixed 32 byte hex string.
+	Hash = hexBytes("hash", 32)
+	// Address is a 0x-prefixed 20 byte hex string, optionally checksummed.
+	Address = hexBytes("address", 20)
+	// Data is a 0x-prefixed hex string of arbitrary byte length.
+	Data = matchString("data", dataRegex)
+	// Quantity is a 0x-prefixed hex number without leading zeroes.
+	Quantity = matchString("quantity", quantityRegex)
+	// DecimalString is a decimal number, encoded as string.
+	DecimalString = matchString("decimal string", decimalRegex)
+
+	// Uint is a non-negative integer JSON number.
+	Uint Schema = schemaFunc(func(v any) error {
+		n, ok := v.(json.Number)
+		if !ok {
+			return fmt.Errorf("expected number, got %T", v)
+		}
+		if !decimalRegex.MatchString(n.String()) {
+			return fmt.Errorf("expected unsigned integer, got %s", n)
+		}
+		return nil
+	})
+	// String is any JSON string.
+	String Schema = schemaFunc(func(v any) error {
+		if _, ok := v.(string); !ok {
+			return fmt.Errorf("expected string, got %T", v)
+		}
+		return nil
+	})
+	// Bool is a JSON boolean.
+	Bool Schema = schemaFunc(func(v any) error {
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		return nil
+	})
+)
+
+// Nullable accepts null, or a value matching the given schema.
+func Nullable(s Schema) Schema {
+	return schemaFunc(func(v any) error {
+		if v == nil {
+			return nil
+		}
+		return s.Validate(v)
+	})
+}
+
+// Array is a JSON array of which each element matches Elem.
+type Array struct {
+	Elem Schema
+}
+
+func (a Array) Validate(v any) error {
+	arr, ok := v.([]any)
+	if !ok {
+		return fmt.Errorf("expected array, got %T", v)
+	}
+	for i, x := range arr {
+		if err := a.Elem.Validate(x); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Object is a JSON object. Unknown fields are allowed, to not break on backwards-compatible spec extensions.
+type Object struct {
+	// Required fields must be present, and not null.
+	Required map[string]Schema
+	// Optional fields may be absent or null.
+	Optional map[string]Schema
+	// ExactlyOneOptional requires exactly one of the optional fields to be set.
+	ExactlyOneOptional bool
+}
+
+func (o Object) Validate(v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected object, got %T", v)
+	}
+	var errs []error
+	for _, k := range sortedKeys(o.Required) {
+		x, ok := obj[k]
+		if !ok || x == nil {
+			errs = append(errs, fmt.Errorf("missing required field %q", k))
+			continue
+		}
+		if err := o.Required[k].Validate(x); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+		}
+	}
+	var set []string
+	for _, k := range sortedKeys(o.Optional) {
+		x, ok := obj[k]
+		if !ok || x == nil {
+			continue
+		}
+		set = append(set, k)
+		if err := o.Optional[k].Validate(x); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+		}
+	}
+	if o.ExactlyOneOptional && len(set) != 1 {
+		errs = append(errs, fmt.Errorf("expected exactly one of %s to be set, got [%s]",
+			strings.Join(sortedKeys(o.Optional), ", "), strings.Join(set, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Schemas of the managed-mode RPC types, see the interop specs.
+var (
+	BlockIDSchema = Object{Required: map[string]Schema{
+		"hash":   Hash,
+		"number": Uint,
+	}}
+
+	BlockRefSchema = Object{Required: map[string]Schema{
+		"hash":       Hash,
+		"number":     Uint,
+		"parentHash": Hash,
+		"timestamp":  Uint,
+	}}
+
+	L2BlockRefSchema = Object{Required: map[string]Schema{
+		"hash":           Hash,
+		"number":         Uint,
+		"parentHash":     Hash,
+		"timestamp":      Uint,
+		"l1origin":       BlockIDSchema,
+		"sequenceNumber": Uint,
+	}}
+
+	DerivedBlockRefPairSchema = Object{Required: map[string]Schema{
+		"source":  BlockRefSchema,
+		"derived": BlockRefSchema,
+	}}
+
+	OutputV0Schema = Object{Required: map[string]Schema{
+		"StateRoot":                Hash,
+		"MessagePasserStorageRoot": Hash,
+		"BlockHash":                Hash,
+	}}
+
+	LogSchema = Object{Required: map[string]Schema{
+		"address":          Address,
+		"topics":           Array{Elem: Hash},
+		"data":             Data,
+		"blockNumber":      Quantity,
+		"transactionHash":  Hash,
+		"transactionIndex": Quantity,
+		"blockHash":        Hash,
+		"logIndex":         Quantity,
+		"removed":          Bool,
+	}}
+
+	ReceiptSchema = Object{
+		Required: map[string]Schema{
+			"type":              Quantity,
+			"cumulativeGasUsed": Quantity,
+			"logsBloom":         Data,
+			"logs":              Array{Elem: LogSchema},
+			"transactionHash":   Hash,
+			"gasUsed":           Quantity,
+			"blockHash":         Hash,
+			"blockNumber":       Quantity,
+			"transactionIndex":  Quantity,
+		},
+		Optional: map[string]Schema{
+			"status":          Quantity,
+			"root":            Data,
+			"contractAddress": Address,
+		},
+	}
+
+	ManagedEventSchema = Object{
+		Optional: map[string]Schema{
+			"reset":            String,
+			"unsafeBlock":      BlockRefSchema,
+			"derivationUpdate": DerivedBlockRefPairSchema,
+			"exhaustL1":        DerivedBlockRefPairSchema,
+			"replaceBlock": Object{Required: map[string]Schema{
+				"replacement": BlockRefSchema,
+				"invalidated": Hash,
+			}},
+			"derivationOriginUpdate": BlockRefSchema,
+		},
+		ExactlyOneOptional: true,
+	}
+
+	// EventEntrySchema is the envelope of each event sent over the events subscription.
+	// The data is null when the server closes the subscription.
+	EventEntrySchema = Object{
+		Optional: map[string]Schema{
+			"data":  ManagedEventSchema,
+			"close": Bool,
+		},
+	}
+)
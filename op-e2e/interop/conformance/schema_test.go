@@ -0,0 +1,92 @@
+package conformance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestSchemaPrimitives(t *testing.T) {
+	require.NoError(t, CheckJSON(Hash, json.RawMessage(`"0x`+common.Hash{0xaa}.Hex()[2:]+`"`)))
+	require.ErrorContains(t, CheckJSON(Hash, json.RawMessage(`"0xaa"`)), "invalid hash")
+	require.ErrorContains(t, CheckJSON(Hash, json.RawMessage(`1`)), "expected hash string")
+
+	require.NoError(t, CheckJSON(Quantity, json.RawMessage(`"0x0"`)))
+	require.NoError(t, CheckJSON(Quantity, json.RawMessage(`"0x1f"`)))
+	require.Error(t, CheckJSON(Quantity, json.RawMessage(`"0x01"`)), "leading zeroes")
+	require.Error(t, CheckJSON(Quantity, json.RawMessage(`"0x"`)))
+
+	require.NoError(t, CheckJSON(Data, json.RawMessage(`"0x"`)))
+	require.NoError(t, CheckJSON(Data, json.RawMessage(`"0x00ff"`)))
+	require.Error(t, CheckJSON(Data, json.RawMessage(`"0x0"`)), "odd length")
+
+	require.NoError(t, CheckJSON(Uint, json.RawMessage(`18446744073709551615`)))
+	require.Error(t, CheckJSON(Uint, json.RawMessage(`-1`)))
+	require.Error(t, CheckJSON(Uint, json.RawMessage(`1.5`)))
+	require.Error(t, CheckJSON(Uint, json.RawMessage(`"0x1"`)))
+
+	require.NoError(t, CheckJSON(DecimalString, json.RawMessage(`"901"`)))
+	require.Error(t, CheckJSON(DecimalString, json.RawMessage(`"0x385"`)))
+
+	require.NoError(t, CheckJSON(Nullable(Hash), json.RawMessage(`null`)))
+	require.Error(t, CheckJSON(Hash, json.RawMessage(`null`)))
+}
+
+func TestSchemaObjects(t *testing.T) {
+	ref := eth.BlockRef{Hash: common.Hash{1}, Number: 10, ParentHash: common.Hash{2}, Time: 1234}
+	raw, err := json.Marshal(ref)
+	require.NoError(t, err)
+	require.NoError(t, CheckJSON(BlockRefSchema, raw))
+
+	raw, err = json.Marshal(eth.L2BlockRef{Hash: common.Hash{1}, L1Origin: eth.BlockID{Hash: common.Hash{3}}})
+	require.NoError(t, err)
+	require.NoError(t, CheckJSON(L2BlockRefSchema, raw))
+
+	raw, err = json.Marshal(&eth.OutputV0{BlockHash: common.Hash{1}})
+	require.NoError(t, err)
+	require.NoError(t, CheckJSON(OutputV0Schema, raw))
+
+	err = CheckJSON(BlockRefSchema, json.RawMessage(`{"hash":"0x01","number":"0xa"}`))
+	require.ErrorContains(t, err, "hash: invalid hash")
+	require.ErrorContains(t, err, "number: expected number")
+	require.ErrorContains(t, err, `missing required field "parentHash"`)
+	require.ErrorContains(t, err, `missing required field "timestamp"`)
+
+	// unknown fields are allowed
+	require.NoError(t, CheckJSON(BlockIDSchema, json.RawMessage(`{"hash":"`+common.Hash{}.Hex()+`","number":0,"extra":true}`)))
+
+	// receipts as encoded by geth
+	raw = json.RawMessage(`[{"type":"0x7e","status":"0x1","cumulativeGasUsed":"0xb0d5","logsBloom":"0x00","logs":[],
+		"transactionHash":"` + common.Hash{4}.Hex() + `","contractAddress":null,"gasUsed":"0xb0d5",
+		"blockHash":"` + common.Hash{5}.Hex() + `","blockNumber":"0x1","transactionIndex":"0x0"}]`)
+	require.NoError(t, CheckJSON(Array{Elem: ReceiptSchema}, raw))
+	require.ErrorContains(t, CheckJSON(Array{Elem: ReceiptSchema}, json.RawMessage(`[{}]`)), "[0]: ")
+}
+
+func TestSchemaManagedEvent(t *testing.T) {
+	ref := eth.BlockRef{Hash: common.Hash{1}, Number: 10, ParentHash: common.Hash{2}, Time: 1234}
+	raw, err := json.Marshal(&supervisortypes.ManagedEvent{UnsafeBlock: &ref})
+	require.NoError(t, err)
+	require.NoError(t, CheckJSON(ManagedEventSchema, raw))
+
+	raw, err = json.Marshal(&supervisortypes.ManagedEvent{ReplaceBlock: &supervisortypes.BlockReplacement{
+		Replacement: ref,
+		Invalidated: common.Hash{3},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, CheckJSON(ManagedEventSchema, raw))
+
+	raw, err = json.Marshal(&supervisortypes.ManagedEvent{UnsafeBlock: &ref, DerivationOriginUpdate: &ref})
+	require.NoError(t, err)
+	require.ErrorContains(t, CheckJSON(ManagedEventSchema, raw), "expected exactly one")
+	require.ErrorContains(t, CheckJSON(ManagedEventSchema, json.RawMessage(`{}`)), "expected exactly one")
+
+	require.NoError(t, CheckJSON(EventEntrySchema, json.RawMessage(`{"data":null,"close":true}`)))
+	require.NoError(t, CheckJSON(EventEntrySchema, json.RawMessage(`{"data":{"reset":"0x01"}}`)))
+}
@@ -0,0 +1,229 @@
+package interop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/contracts/bindings/inbox"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// TestInterop_ExecutingMessageFromAccounts executes an interop message from accounts other than plain EOAs:
+//   - an EOA that is delegated (EIP-7702) to Multicall3, in the same transaction as the delegation, by a sponsor,
+//   - the same delegated EOA, in a later transaction of its own,
+//   - a smart account (Multicall3 itself), calling the CrossL2Inbox on behalf of the EOA.
+//
+// For each, it checks that the access-list matches the executing message,
+// that the supervisor accepts the access-list, and that the executing block is promoted to cross-safe.
+func TestInterop_ExecutingMessageFromAccounts(t *testing.T) {
+	t.Parallel()
+
+	test := func(t *testing.T, s2 SuperSystem) {
+		ids := s2.L2IDs()
+		chainA := ids[0]
+		chainB := ids[1]
+
+		identifier, payloadHash := initiateMessage(t, s2, chainA)
+		msg := types.Message{Identifier: identifier, PayloadHash: payloadHash}
+		accessList := gethTypes.AccessList{{
+			Address:     predeploys.CrossL2InboxAddr,
+			StorageKeys: types.EncodeAccessList([]types.Access{msg.Access()}),
+		}}
+
+		inboxABI, err := inbox.InboxMetaData.GetAbi()
+		require.NoError(t, err)
+		validateData, err := inboxABI.Pack("validateMessage", inbox.Identifier{
+			Origin:      identifier.Origin,
+			BlockNumber: new(big.Int).SetUint64(identifier.BlockNumber),
+			LogIndex:    new(big.Int).SetUint64(uint64(identifier.LogIndex)),
+			Timestamp:   new(big.Int).SetUint64(identifier.Timestamp),
+			ChainId:     identifier.ChainID.ToBig(),
+		}, payloadHash)
+		require.NoError(t, err)
+		multicallABI, err := bindings.MultiCall3MetaData.GetAbi()
+		require.NoError(t, err)
+		aggregateData, err := multicallABI.Pack("aggregate3", []bindings.Multicall3Call3{{
+			Target:   predeploys.CrossL2InboxAddr,
+			CallData: validateData,
+		}})
+		require.NoError(t, err)
+
+		alice := s2.Address(chainB, "Alice")
+		aliceKey := s2.UserKey(chainB, "Alice")
+		bobKey := s2.UserKey(chainB, "Bob")
+		client := s2.L2GethClient(chainB, "sequencer")
+
+		t.Run("sponsored delegation", func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			aliceNonce, err := client.PendingNonceAt(ctx, alice)
+			require.NoError(t, err)
+			auth, err := gethTypes.SignSetCode(&aliceKey, gethTypes.SetCodeAuthorization{
+				ChainID: *uint256.MustFromBig(s2.ChainID(chainB)),
+				Address: predeploys.MultiCall3Addr,
+				Nonce:   aliceNonce,
+			})
+			require.NoError(t, err)
+
+			rec := sendExecutingTx(t, s2, chainB, &bobKey, alice, aggregateData, accessList, []gethTypes.SetCodeAuthorization{auth})
+			code, err := client.CodeAt(ctx, alice, nil)
+			require.NoError(t, err)
+			require.Equal(t, gethTypes.AddressToDelegation(predeploys.MultiCall3Addr), code, "Alice must be delegated to Multicall3")
+			checkExecutingMessage(t, s2, chainB, rec, msg)
+		})
+
+		t.Run("delegated EOA", func(t *testing.T) {
+			rec := sendExecutingTx(t, s2, chainB, &aliceKey, alice, aggregateData, accessList, nil)
+			checkExecutingMessage(t, s2, chainB, rec, msg)
+		})
+
+		t.Run("smart account", func(t *testing.T) {
+			rec := sendExecutingTx(t, s2, chainB, &aliceKey, predeploys.MultiCall3Addr, aggregateData, accessList, nil)
+			checkExecutingMessage(t, s2, chainB, rec, msg)
+		})
+	}
+
+	config := SuperSystemConfig{
+		mempoolFiltering: true,
+	}
+	setupAndRun(t, config, test)
+}
+
+// initiateMessage emits a log on the given chain, and waits for it to be cross-unsafe,
+// to be executed as initiating message.
+func initiateMessage(t *testing.T, s2 SuperSystem, chain string) (types.Identifier, common.Hash) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	s2.DeployEmitterContract(ctx, chain, "Alice")
+	emitRec := s2.EmitData(ctx, chain, "sequencer", "Alice", "hello from a smart account")
+	require.Len(t, emitRec.Logs, 1)
+	ev := emitRec.Logs[0]
+	header, err := s2.L2GethClient(chain, "sequencer").HeaderByHash(ctx, emitRec.BlockHash)
+	require.NoError(t, err)
+	identifier := types.Identifier{
+		Origin:      ev.Address,
+		BlockNumber: ev.BlockNumber,
+		LogIndex:    uint32(ev.Index),
+		Timestamp:   header.Time,
+		ChainID:     eth.ChainIDFromBig(s2.ChainID(chain)),
+	}
+
+	// Wait for the initiating side to become cross-unsafe, and for an additional block to be built,
+	// since geth ingress validates with the head timestamp, not the timestamp of the next block.
+	rollupCl := s2.L2RollupClient(chain, "sequencer")
+	require.Eventually(t, func() bool {
+		status, err := rollupCl.SyncStatus(context.Background())
+		require.NoError(t, err)
+		return status.CrossUnsafeL2.Time > identifier.Timestamp
+	}, time.Second*60, time.Second, "wait for emitted data to become cross-unsafe")
+
+	return identifier, crypto.Keccak256Hash(types.LogToMessagePayload(ev))
+}
+
+// sendExecutingTx sends a transaction with the given access-list, and waits for it to be included.
+// A SetCode transaction is sent if any authorizations are given.
+func sendExecutingTx(t *testing.T, s2 SuperSystem, chain string, key *ecdsa.PrivateKey,
+	to common.Address, data []byte, accessList gethTypes.AccessList, auths []gethTypes.SetCodeAuthorization) *gethTypes.Receipt {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	client := s2.L2GethClient(chain, "sequencer")
+	nonce, err := client.PendingNonceAt(ctx, crypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, err)
+
+	chainID := s2.ChainID(chain)
+	var txData gethTypes.TxData
+	if len(auths) > 0 {
+		txData = &gethTypes.SetCodeTx{
+			ChainID:    uint256.MustFromBig(chainID),
+			Nonce:      nonce,
+			GasTipCap:  uint256.NewInt(1_000_000_000),
+			GasFeeCap:  uint256.NewInt(21_000_000_000),
+			Gas:        1_000_000,
+			To:         to,
+			Data:       data,
+			AccessList: accessList,
+			AuthList:   auths,
+		}
+	} else {
+		txData = &gethTypes.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      nonce,
+			GasTipCap:  big.NewInt(1_000_000_000),
+			GasFeeCap:  big.NewInt(21_000_000_000),
+			Gas:        1_000_000,
+			To:         &to,
+			Data:       data,
+			AccessList: accessList,
+		}
+	}
+	tx := gethTypes.MustSignNewTx(key, gethTypes.LatestSignerForChainID(chainID), txData)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	rec, err := wait.ForReceiptOK(ctx, client, tx.Hash())
+	require.NoError(t, err, "executing tx must be included")
+	return rec
+}
+
+// checkExecutingMessage checks that the receipt contains the executing message, with a matching access-list entry,
+// that the supervisor accepts the access-list, and that the executing block becomes cross-safe.
+func checkExecutingMessage(t *testing.T, s2 SuperSystem, chain string, rec *gethTypes.Receipt, expected types.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
+	defer cancel()
+
+	var execLogs []*gethTypes.Log
+	for _, l := range rec.Logs {
+		if l.Address == predeploys.CrossL2InboxAddr {
+			execLogs = append(execLogs, l)
+		}
+	}
+	require.Len(t, execLogs, 1, "expected a single executing message")
+	var msg types.Message
+	require.NoError(t, msg.DecodeEvent(execLogs[0].Topics, execLogs[0].Data))
+	require.Equal(t, expected, msg)
+
+	client := s2.L2GethClient(chain, "sequencer")
+	tx, _, err := client.TransactionByHash(ctx, rec.TxHash)
+	require.NoError(t, err)
+	var entries []common.Hash
+	for _, tuple := range tx.AccessList() {
+		if tuple.Address == predeploys.CrossL2InboxAddr {
+			entries = append(entries, tuple.StorageKeys...)
+		}
+	}
+	remaining, access, err := types.ParseAccess(entries)
+	require.NoError(t, err)
+	require.Empty(t, remaining, "access-list must only contain the executed message")
+	require.Equal(t, msg.Access(), access, "access-list must match the executing message")
+
+	header, err := client.HeaderByHash(ctx, rec.BlockHash)
+	require.NoError(t, err)
+	execDescriptor := types.ExecutingDescriptor{
+		ChainID:   eth.ChainIDFromBig(s2.ChainID(chain)),
+		Timestamp: header.Time,
+	}
+	require.NoError(t, s2.SupervisorClient().CheckAccessList(ctx, entries, types.CrossUnsafe, execDescriptor))
+
+	rollupCl := s2.L2RollupClient(chain, "sequencer")
+	require.Eventually(t, func() bool {
+		status, err := rollupCl.SyncStatus(ctx)
+		require.NoError(t, err)
+		return status.SafeL2.Number >= rec.BlockNumber.Uint64()
+	}, time.Second*90, time.Second, "wait for executing block to become cross-safe")
+	canonical, err := client.HeaderByNumber(ctx, rec.BlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, rec.BlockHash, canonical.Hash(), "executing block must not be replaced")
+	require.NoError(t, s2.SupervisorClient().CheckAccessList(ctx, entries, types.CrossSafe, execDescriptor))
+}
@@ -0,0 +1,176 @@
+package interop
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	gethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// supervisorEventRecorder records the events of a supervisor events subscription.
+type supervisorEventRecorder struct {
+	mu     sync.Mutex
+	events []types.SupervisorEvent
+}
+
+func (r *supervisorEventRecorder) Events() []types.SupervisorEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]types.SupervisorEvent(nil), r.events...)
+}
+
+// recordSupervisorEvents subscribes to the supervisor events that match the filter, over websocket,
+// and records them until the test ends.
+func recordSupervisorEvents(t *testing.T, s2 SuperSystem, filter types.EventFilter) *supervisorEventRecorder {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	wsEndpoint := "ws://" + strings.TrimPrefix(s2.Supervisor().RPC(), "http://")
+	rpcCl, err := client.NewRPC(ctx, testlog.Logger(t, log.LevelInfo), wsEndpoint)
+	require.NoError(t, err)
+	t.Cleanup(rpcCl.Close)
+
+	events := make(chan types.SupervisorEvent, 100)
+	sub, err := sources.NewSupervisorClient(rpcCl).SubscribeEvents(ctx, filter, events)
+	require.NoError(t, err)
+	t.Cleanup(sub.Unsubscribe)
+
+	r := &supervisorEventRecorder{}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-sub.Err():
+				if err != nil {
+					t.Errorf("supervisor events subscription failed: %v", err)
+				}
+				return
+			case ev := <-events:
+				r.mu.Lock()
+				r.events = append(r.events, ev)
+				r.mu.Unlock()
+			}
+		}
+	}()
+	return r
+}
+
+// TestInterop_SequencerFailover fails over the active sequencer of a chain with op-conductor,
+// while messages are being emitted on that chain, and checks that the supervisor
+// never observes a diverging unsafe chain, and that cross-safety progresses without resets.
+func TestInterop_SequencerFailover(t *testing.T) {
+	t.Parallel()
+	test := func(t *testing.T, s2 SuperSystem) {
+		ids := s2.L2IDs()
+		chainA := ids[0]
+		chainB := ids[1]
+		chainIDA := eth.ChainIDFromBig(s2.ChainID(chainA))
+
+		rewinds := recordSupervisorEvents(t, s2, types.EventFilter{
+			Types: []types.SupervisorEventType{types.EventChainRewound},
+		})
+		crossSafe := recordSupervisorEvents(t, s2, types.EventFilter{
+			ChainIDs: []eth.ChainID{chainIDA},
+			Types:    []types.SupervisorEventType{types.EventCrossSafe},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s2.DeployEmitterContract(ctx, chainA, "Alice")
+
+		initialSequencer := s2.ActiveSequencer(chainA)
+		require.Equal(t, haSequencerNames[0], initialSequencer)
+
+		var receipts []*gethTypes.Receipt
+		emit := func(node string, count int) {
+			for i := 0; i < count; i++ {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				receipts = append(receipts, s2.EmitData(ctx, chainA, node, "Alice", "failover"))
+				cancel()
+			}
+		}
+		emit(initialSequencer, 3)
+
+		// fail over, mid message-flow
+		ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		require.NoError(t, s2.Conductor(chainA, initialSequencer).TransferLeader(ctx))
+		var newSequencer string
+		require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+			leader, err := s2.Conductor(chainA, initialSequencer).LeaderWithID(ctx)
+			if err != nil || leader.ID == initialSequencer {
+				return false, nil
+			}
+			active, err := s2.L2RollupClient(chainA, leader.ID).SequencerActive(ctx)
+			if err != nil || !active {
+				return false, nil
+			}
+			newSequencer = leader.ID
+			return true, nil
+		}), "sequencing must fail over to another sequencer")
+		t.Logf("Failed over from %s to %s", initialSequencer, newSequencer)
+		require.Equal(t, newSequencer, s2.ActiveSequencer(chainA))
+
+		emit(newSequencer, 3)
+
+		// the new sequencer must have built on the blocks of the initial sequencer
+		for _, rec := range receipts {
+			header, err := s2.L2GethClient(chainA, newSequencer).HeaderByNumber(ctx, rec.BlockNumber)
+			require.NoError(t, err)
+			require.Equal(t, rec.BlockHash, header.Hash(), "block %d diverged after failover", rec.BlockNumber)
+		}
+
+		// all messages, from before and after the failover, must become cross-safe in the supervisor
+		var accessEntries []types.Access
+		for _, rec := range receipts {
+			require.Len(t, rec.Logs, 1)
+			ev := rec.Logs[0]
+			header, err := s2.L2GethClient(chainA, newSequencer).HeaderByHash(ctx, rec.BlockHash)
+			require.NoError(t, err)
+			args := types.ChecksumArgs{
+				BlockNumber: ev.BlockNumber,
+				Timestamp:   header.Time,
+				LogIndex:    uint32(ev.Index),
+				ChainID:     chainIDA,
+				LogHash:     types.PayloadHashToLogHash(crypto.Keccak256Hash(types.LogToMessagePayload(ev)), ev.Address),
+			}
+			accessEntries = append(accessEntries, args.Access())
+		}
+		accessList := types.EncodeAccessList(accessEntries)
+		supervisor := s2.SupervisorClient()
+		require.Eventually(t, func() bool {
+			ed := types.ExecutingDescriptor{Timestamp: uint64(time.Now().Unix()), ChainID: eth.ChainIDFromBig(s2.ChainID(chainB))}
+			return supervisor.CheckAccessList(context.Background(), accessList, types.CrossSafe, ed) == nil
+		}, 2*time.Minute, time.Second, "messages must become cross-safe")
+
+		lastMessageBlock := receipts[len(receipts)-1].BlockNumber.Uint64()
+		require.Eventually(t, func() bool {
+			events := crossSafe.Events()
+			return len(events) > 0 && events[len(events)-1].Block.Number >= lastMessageBlock
+		}, time.Minute, time.Second, "cross-safe events must reach the messages")
+		var last uint64
+		for _, ev := range crossSafe.Events() {
+			require.GreaterOrEqual(t, ev.Block.Number, last, "cross-safe head of chain A must not reset")
+			last = ev.Block.Number
+		}
+		require.Empty(t, rewinds.Events(), "supervisor must not rewind any chain")
+	}
+	config := SuperSystemConfig{
+		mempoolFiltering: false,
+		SequencerHA:      true,
+	}
+	setupAndRun(t, config, test)
+}
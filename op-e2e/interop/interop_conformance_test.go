@@ -0,0 +1,53 @@
+package interop
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/interop/conformance"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestInterop_ManagedRPCConformance runs the managed-mode RPC conformance suite against the op-node.
+// The supervisor is stopped before running the suite,
+// so it does not compete with the suite for the events of the node.
+func TestInterop_ManagedRPCConformance(t *testing.T) {
+	t.Parallel()
+	test := func(t *testing.T, s2 SuperSystem) {
+		chainA := s2.L2IDs()[0]
+
+		// the corpus requires a block after genesis
+		rollupClA := s2.L2RollupClient(chainA, "sequencer")
+		require.Eventually(t, func() bool {
+			status, err := rollupClA.SyncStatus(context.Background())
+			require.NoError(t, err)
+			return status.UnsafeL2.Number >= 1
+		}, time.Second*60, time.Second, "wait for first block after genesis")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		require.NoError(t, s2.Supervisor().Stop(ctx))
+
+		rpcEndpoint, secret := s2.L2InteropRPC(chainA, "sequencer")
+		cl, err := conformance.Dial(ctx, testlog.Logger(t, log.LevelInfo), rpcEndpoint, secret)
+		require.NoError(t, err)
+		defer cl.Close()
+
+		chainID := eth.ChainIDFromBig(s2.ChainID(chainA))
+		conformance.Run(t, &conformance.Target{
+			Client:        cl,
+			ChainID:       &chainID,
+			EventsTimeout: 10 * time.Second,
+		})
+	}
+	config := SuperSystemConfig{
+		mempoolFiltering: false,
+	}
+	setupAndRun(t, config, test)
+}
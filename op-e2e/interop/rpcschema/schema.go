@@ -0,0 +1,288 @@
+// Package rpcschema describes the RPC methods of a service, as registered by the geth RPC server,
+// and the JSON encoding of their parameter and result types.
+// The description is a text snapshot, to detect changes to the RPC protocol between services.
+package rpcschema
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	contextType      = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType        = reflect.TypeOf((*error)(nil)).Elem()
+	subscriptionType = reflect.TypeOf((*gethrpc.Subscription)(nil))
+
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// pkgPrefixes shorten the package paths in the snapshot.
+var pkgPrefixes = []struct{ prefix, short string }{
+	{"github.com/ethereum-optimism/optimism/", ""},
+	{"github.com/ethereum/go-ethereum/", "geth/"},
+}
+
+// Method is an RPC method of a service.
+type Method struct {
+	// Name is the RPC method name, including the namespace.
+	Name string
+	// Params are the types of the parameters, excluding the context.
+	Params []reflect.Type
+	// Result is the type of the result, nil if the method only returns an error.
+	Result reflect.Type
+	// Subscription is the name of the subscription, if the method is a subscription.
+	// Subscriptions are served by the <namespace>_subscribe method, with the subscription name as first parameter.
+	Subscription string
+}
+
+// Methods returns the RPC methods of the service, with the same rules as the geth RPC server:
+// every exported method that returns at most a result and an error is a method,
+// and methods that return a subscription are subscriptions.
+func Methods(namespace string, service any) []Method {
+	typ := reflect.TypeOf(service)
+	var out []Method
+	for i := 0; i < typ.NumMethod(); i++ {
+		m := typ.Method(i)
+		if !m.IsExported() {
+			continue
+		}
+		fn := m.Type
+		// skip the receiver, and the context if any
+		firstArg := 1
+		if fn.NumIn() > firstArg && fn.In(firstArg) == contextType {
+			firstArg++
+		}
+		var result reflect.Type
+		switch {
+		case fn.NumOut() == 0:
+		case fn.NumOut() == 1:
+			if fn.Out(0) != errorType {
+				result = fn.Out(0)
+			}
+		case fn.NumOut() == 2:
+			if fn.Out(0) == errorType || fn.Out(1) != errorType {
+				continue // not a valid RPC method
+			}
+			result = fn.Out(0)
+		default:
+			continue // not a valid RPC method
+		}
+		name := formatName(m.Name)
+		method := Method{Name: namespace + "_" + name, Result: result}
+		if firstArg == 2 && fn.NumOut() == 2 && fn.Out(0) == subscriptionType {
+			method.Name = namespace + "_subscribe"
+			method.Subscription = name
+			method.Result = nil
+		}
+		for j := firstArg; j < fn.NumIn(); j++ {
+			method.Params = append(method.Params, fn.In(j))
+		}
+		out = append(out, method)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name != out[j].Name {
+			return out[i].Name < out[j].Name
+		}
+		return out[i].Subscription < out[j].Subscription
+	})
+	return out
+}
+
+// formatName converts the Go method name to the RPC method name, like geth does.
+func formatName(name string) string {
+	ret := []rune(name)
+	if len(ret) > 0 {
+		ret[0] = unicode.ToLower(ret[0])
+	}
+	return string(ret)
+}
+
+// Snapshot describes the RPC methods of the APIs, and the types that they use.
+func Snapshot(apis ...gethrpc.API) string {
+	d := &describer{types: make(map[string]string)}
+	var b strings.Builder
+	b.WriteString("# Methods\n\n")
+	for _, api := range apis {
+		for _, m := range Methods(api.Namespace, api.Service) {
+			params := make([]string, 0, len(m.Params)+1)
+			if m.Subscription != "" {
+				params = append(params, fmt.Sprintf("%q", m.Subscription))
+			}
+			for _, p := range m.Params {
+				params = append(params, d.describe(p))
+			}
+			result := "null"
+			if m.Subscription != "" {
+				result = "subscription"
+			} else if m.Result != nil {
+				result = d.describe(m.Result)
+			}
+			fmt.Fprintf(&b, "%s(%s) -> %s\n", m.Name, strings.Join(params, ", "), result)
+		}
+	}
+	b.WriteString("\n# Types\n")
+	names := make([]string, 0, len(d.types))
+	for name := range d.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\n%s %s\n", name, d.types[name])
+	}
+	return b.String()
+}
+
+type describer struct {
+	// types are the definitions of the named types, by name
+	types map[string]string
+}
+
+// describe returns the name of the type, and records the definitions of the named types that it uses.
+func (d *describer) describe(t reflect.Type) string {
+	if t.Name() != "" && t.PkgPath() != "" {
+		name := typeName(t)
+		if _, ok := d.types[name]; !ok {
+			d.types[name] = "" // placeholder, for recursive types
+			d.types[name] = d.define(t)
+		}
+		return name
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + d.describe(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "[]byte"
+		}
+		return "[]" + d.describe(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), d.describe(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("map[%s]%s", d.describe(t.Key()), d.describe(t.Elem()))
+	case reflect.Struct:
+		return d.defineStruct(t)
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return "any"
+		}
+		return t.String()
+	default:
+		return t.String()
+	}
+}
+
+// define returns the definition of the named type: its custom JSON encoding, or else its underlying type.
+func (d *describer) define(t reflect.Type) string {
+	var custom []string
+	for _, c := range []struct {
+		name  string
+		iface reflect.Type
+	}{
+		{"MarshalJSON", jsonMarshalerType},
+		{"UnmarshalJSON", jsonUnmarshalerType},
+		{"MarshalText", textMarshalerType},
+		{"UnmarshalText", textUnmarshalerType},
+	} {
+		if t.Implements(c.iface) || reflect.PointerTo(t).Implements(c.iface) {
+			custom = append(custom, c.name)
+		}
+	}
+	if len(custom) > 0 {
+		return fmt.Sprintf("%s with custom JSON encoding (%s)", kindName(t), strings.Join(custom, ", "))
+	}
+	if t.Kind() == reflect.Struct {
+		return d.defineStruct(t)
+	}
+	// describe the underlying type, without the name
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "= *" + d.describe(t.Elem())
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "= []byte"
+		}
+		return "= []" + d.describe(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("= [%d]%s", t.Len(), d.describe(t.Elem()))
+	case reflect.Map:
+		return fmt.Sprintf("= map[%s]%s", d.describe(t.Key()), d.describe(t.Elem()))
+	default:
+		return "= " + kindName(t)
+	}
+}
+
+// defineStruct lists the JSON fields of the struct, as encoded by encoding/json.
+func (d *describer) defineStruct(t reflect.Type) string {
+	var fields []string
+	d.structFields(t, &fields)
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{\n\t" + strings.Join(fields, "\n\t") + "\n}"
+}
+
+func (d *describer) structFields(t reflect.Type, fields *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// fields of embedded structs are promoted
+				d.structFields(ft, fields)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		field := fmt.Sprintf("%s: %s", name, d.describe(f.Type))
+		if opts != "" {
+			field += " (" + opts + ")"
+		}
+		*fields = append(*fields, field)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	for _, p := range pkgPrefixes {
+		if strings.HasPrefix(pkg, p.prefix) {
+			pkg = p.short + strings.TrimPrefix(pkg, p.prefix)
+			break
+		}
+	}
+	return pkg + "." + t.Name()
+}
+
+func kindName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), t.Elem().Kind())
+	case reflect.Slice:
+		return "[]" + t.Elem().Kind().String()
+	default:
+		return t.Kind().String()
+	}
+}
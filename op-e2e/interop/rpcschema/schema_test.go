@@ -0,0 +1,105 @@
+package rpcschema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop/managed"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/frontend"
+)
+
+// updateEnv is the environment variable to set to update the snapshots, after an intended change of the RPC methods:
+//
+//	OP_E2E_UPDATE_RPC_SCHEMA=1 go test ./op-e2e/interop/rpcschema/...
+const updateEnv = "OP_E2E_UPDATE_RPC_SCHEMA"
+
+// TestInteropSchema checks the managed-mode RPC of op-node, which op-supervisor calls.
+func TestInteropSchema(t *testing.T) {
+	checkSnapshot(t, "interop.txt", gethrpc.API{Namespace: "interop", Service: &managed.InteropAPI{}})
+}
+
+// TestSupervisorSchema checks the RPC of op-supervisor, which op-node and other services call.
+func TestSupervisorSchema(t *testing.T) {
+	checkSnapshot(t, "supervisor.txt",
+		gethrpc.API{Namespace: "supervisor", Service: &frontend.QueryFrontend{}},
+		gethrpc.API{Namespace: "admin", Service: &frontend.AdminFrontend{}},
+	)
+}
+
+func checkSnapshot(t *testing.T, name string, apis ...gethrpc.API) {
+	path := filepath.Join("testdata", name)
+	got := Snapshot(apis...)
+	if os.Getenv(updateEnv) != "" {
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(want), got,
+		"RPC methods changed, this may break other services. If intended, update the snapshot with %s=1", updateEnv)
+}
+
+type testInner struct {
+	Inner uint64 `json:"inner"`
+}
+
+type testResult struct {
+	testInner
+	Hash    common.Hash `json:"hash"`
+	Skipped string      `json:"-"`
+	Opt     *testResult `json:"opt,omitempty"`
+	NoTag   []byte
+	private int
+}
+
+type testService struct{}
+
+func (s *testService) Get(ctx context.Context, a uint64, b []common.Hash) (*testResult, error) {
+	return nil, nil
+}
+
+func (s *testService) Set(v map[string]bool) error { return nil }
+
+func (s *testService) Events(ctx context.Context) (*gethrpc.Subscription, error) { return nil, nil }
+
+func (s *testService) Invalid() (int, int) { return 0, 0 }
+
+func (s *testService) unexported() error { return nil }
+
+func TestMethods(t *testing.T) {
+	methods := Methods("test", &testService{})
+	require.Equal(t, []Method{
+		{Name: "test_get", Params: []reflect.Type{reflect.TypeOf(uint64(0)), reflect.TypeOf([]common.Hash{})}, Result: reflect.TypeOf(&testResult{})},
+		{Name: "test_set", Params: []reflect.Type{reflect.TypeOf(map[string]bool{})}},
+		{Name: "test_subscribe", Subscription: "events"},
+	}, methods)
+}
+
+func TestSnapshot(t *testing.T) {
+	got := Snapshot(gethrpc.API{Namespace: "test", Service: &testService{}})
+	require.Equal(t, `# Methods
+
+test_get(uint64, []geth/common.Hash) -> *op-e2e/interop/rpcschema.testResult
+test_set(map[string]bool) -> null
+test_subscribe("events") -> subscription
+
+# Types
+
+geth/common.Hash [32]uint8 with custom JSON encoding (UnmarshalJSON, MarshalText, UnmarshalText)
+
+op-e2e/interop/rpcschema.testResult {
+	inner: uint64
+	hash: geth/common.Hash
+	opt: *op-e2e/interop/rpcschema.testResult (omitempty)
+	NoTag: []byte
+}
+`, got)
+}
@@ -24,11 +24,13 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 
 	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
 	"github.com/ethereum-optimism/optimism/op-chain-ops/interopgen"
+	conrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/contracts/bindings/emit"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/contracts/bindings/inbox"
@@ -81,9 +83,15 @@ type SuperSystem interface {
 	L2GethClient(network string, node string) *ethclient.Client
 	L2RollupEndpoint(network string, node string) endpoint.RPC
 	L2RollupClient(network string, node string) *sources.RollupClient
+	// L2InteropRPC returns the managed-mode RPC endpoint of the node, that the supervisor connects to
+	L2InteropRPC(network string, node string) (rpcEndpoint string, jwtSecret eth.Bytes32)
 	SendL2Tx(network string, node string, username string, applyTxOpts helpers.TxOptsFn) *types.Receipt
 	EmitData(ctx context.Context, network string, node string, username string, data string) *types.Receipt
 	AddNode(network string, nodeName string)
+	// Conductor returns the op-conductor API of the sequencer, if the system runs sequencer HA
+	Conductor(network string, node string) conrpc.API
+	// ActiveSequencer returns the name of the sequencer that currently leads the chain, if the system runs sequencer HA
+	ActiveSequencer(network string) string
 
 	// L2 level
 	ChainID(network string) *big.Int
@@ -107,6 +115,9 @@ type SuperSystem interface {
 type SuperSystemConfig struct {
 	mempoolFiltering  bool
 	SupportTimeTravel bool
+	// SequencerHA runs every L2 with three sequencers, that fail over with op-conductor.
+	// The sequencers are named after haSequencerNames, the first one is the initial active sequencer.
+	SequencerHA bool
 }
 
 // NewSuperSystem creates a new SuperSystem from a recipe. It creates an interopE2ESystem.
@@ -136,6 +147,8 @@ type interopE2ESystem struct {
 	superClient  *sources.SupervisorClient
 	supervisor   *supervisor.SupervisorService
 	config       *SuperSystemConfig
+	// p2pNet connects the sequencers of each L2, if the system runs sequencer HA
+	p2pNet mocknet.Mocknet
 }
 
 func (s *interopE2ESystem) L1() *geth.GethInstance {
@@ -359,6 +372,11 @@ func (s *interopE2ESystem) prepare(t *testing.T, w WorldResourcePaths) {
 		}
 	}
 
+	// the sequencers can only become healthy once the supervisor manages them
+	if s.config.SequencerHA {
+		s.startSequencerHA()
+	}
+
 	// Try to close the op-supervisor first
 	s.t.Cleanup(func() {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -0,0 +1,230 @@
+package interop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/devkeys"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/interopgen"
+	con "github.com/ethereum-optimism/optimism/op-conductor/conductor"
+	conrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-e2e/system/e2esys"
+	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+)
+
+// haSequencerNames are the names of the sequencers of an L2 with sequencer HA.
+// The first sequencer bootstraps the conductor cluster, and is the initial active sequencer.
+var haSequencerNames = []string{"sequencer", "sequencer2", "sequencer3"}
+
+type conductor struct {
+	service *con.OpConductor
+	client  conrpc.API
+}
+
+func (c *conductor) ConsensusEndpoint() string {
+	return c.service.ConsensusEndpoint()
+}
+
+func (c *conductor) RPCEndpoint() string {
+	return c.service.HTTPEndpoint()
+}
+
+// conductorEndpoint is the endpoint of a conductor, that becomes available after the op-node of the sequencer started.
+type conductorEndpoint struct {
+	ready    chan struct{}
+	endpoint string
+}
+
+func (c *conductorEndpoint) get(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-c.ready:
+		return c.endpoint, nil
+	}
+}
+
+// newSequencerHAForL2 creates the sequencers of an L2, each with its own op-conductor.
+// The sequencers are connected to each other over P2P, and start stopped, with paused conductors:
+// the conductor cluster is formed by startSequencerHA, once the supervisor manages the sequencers.
+func (s *interopE2ESystem) newSequencerHAForL2(
+	id string,
+	l2Out *interopgen.L2Output,
+	depSet depset.DependencySet,
+	operatorKeys map[devkeys.ChainOperatorRole]ecdsa.PrivateKey,
+) (map[string]*l2Node, map[string]*conductor) {
+	if s.p2pNet == nil {
+		s.p2pNet = mocknet.New()
+		s.t.Cleanup(func() {
+			_ = s.p2pNet.Close()
+		})
+	}
+
+	hosts := make(map[string]*p2p.Prepared)
+	for _, name := range haSequencerNames {
+		h, err := e2esys.NewMockNetPeer(s.p2pNet)
+		require.NoError(s.t, err, "failed to create p2p host of %s", name)
+		hosts[name] = &p2p.Prepared{HostP2P: h}
+	}
+	for i, a := range haSequencerNames {
+		for _, b := range haSequencerNames[i+1:] {
+			_, err := s.p2pNet.LinkPeers(hosts[a].HostP2P.ID(), hosts[b].HostP2P.ID())
+			require.NoError(s.t, err, "failed to link %s and %s", a, b)
+		}
+	}
+
+	nodes := make(map[string]*l2Node)
+	endpoints := make(map[string]*conductorEndpoint)
+	for _, name := range haSequencerNames {
+		endpoint := &conductorEndpoint{ready: make(chan struct{})}
+		endpoints[name] = endpoint
+		l2Geth := s.newGethForL2(id, name, l2Out)
+		opNode := s.newNodeForL2(id, name, l2Out, depSet, operatorKeys, l2Geth, true, func(cfg *node.Config) {
+			cfg.P2P = hosts[name]
+			cfg.Driver.SequencerStopped = true
+			cfg.ConductorEnabled = true
+			cfg.ConductorRpc = endpoint.get
+			cfg.ConductorRpcTimeout = 5 * time.Second
+		})
+		nodes[name] = &l2Node{name: name, opNode: opNode, l2Geth: l2Geth}
+	}
+
+	// Connect the peers after starting the nodes, so the p2p protocols are negotiated correctly.
+	for i, a := range haSequencerNames {
+		for _, b := range haSequencerNames[i+1:] {
+			_, err := s.p2pNet.ConnectPeers(hosts[a].HostP2P.ID(), hosts[b].HostP2P.ID())
+			require.NoError(s.t, err, "failed to connect %s and %s", a, b)
+		}
+	}
+
+	conductors := make(map[string]*conductor)
+	for i, name := range haSequencerNames {
+		conductors[name] = s.newConductorForL2(id, nodes[name], l2Out, i == 0)
+		endpoints[name].endpoint = conductors[name].RPCEndpoint()
+		close(endpoints[name].ready)
+	}
+	return nodes, conductors
+}
+
+// newConductorForL2 creates a paused op-conductor for a sequencer of an L2.
+func (s *interopE2ESystem) newConductorForL2(id string, n *l2Node, l2Out *interopgen.L2Output, bootstrap bool) *conductor {
+	logger := s.logger.New("role", "op-conductor-"+id+"-"+n.name)
+	cfg := con.Config{
+		ConsensusAddr: "127.0.0.1",
+		ConsensusPort: 0, // let the system select a port, avoid conflicts
+
+		RaftServerID:           n.name,
+		RaftStorageDir:         s.t.TempDir(),
+		RaftBootstrap:          bootstrap,
+		RaftSnapshotInterval:   120 * time.Second,
+		RaftSnapshotThreshold:  8192,
+		RaftTrailingLogs:       10240,
+		RaftHeartbeatTimeout:   1000 * time.Millisecond,
+		RaftLeaderLeaseTimeout: 500 * time.Millisecond,
+		NodeRPC:                n.opNode.UserRPC().RPC(),
+		ExecutionRPC:           n.l2Geth.UserRPC().RPC(),
+		SupervisorRPC:          s.supervisor.RPC(),
+		Paused:                 true,
+		HealthCheck: con.HealthCheckConfig{
+			Interval:     1,
+			MinPeerCount: 2, // each sequencer is connected to the other two
+			// the progression of the heads is checked as well, so the intervals can be generous for CI
+			UnsafeInterval: 30,
+			SafeInterval:   30,
+		},
+		RollupCfg:      *l2Out.RollupCfg,
+		RPCEnableProxy: true,
+		LogConfig: oplog.CLIConfig{
+			Level: log.LevelDebug,
+		},
+		RPC: oprpc.CLIConfig{
+			ListenAddr: "127.0.0.1",
+			ListenPort: 0, // let the system select a port
+		},
+	}
+	ctx := context.Background()
+	service, err := con.New(ctx, &cfg, logger, "0.0.1")
+	require.NoError(s.t, err, "failed to create conductor of %s", n.name)
+	require.NoError(s.t, service.Start(ctx), "failed to start conductor of %s", n.name)
+	s.t.Cleanup(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel() // force-quit
+		s.t.Logf("Closing conductor %s of chain %s", n.name, id)
+		_ = service.Stop(ctx)
+	})
+
+	rawClient, err := rpc.DialContext(ctx, service.HTTPEndpoint())
+	require.NoError(s.t, err, "failed to dial conductor of %s", n.name)
+	s.t.Cleanup(rawClient.Close)
+	return &conductor{service: service, client: conrpc.NewAPIClient(rawClient)}
+}
+
+// startSequencerHA forms the conductor cluster of every L2, starts the first sequencer,
+// and resumes the conductors once all sequencers are healthy.
+func (s *interopE2ESystem) startSequencerHA() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	for _, id := range s.L2IDs() {
+		l2 := s.l2s[id]
+		bootstrap := l2.conductors[haSequencerNames[0]]
+		require.NoError(s.t, wait.For(ctx, time.Second, func() (bool, error) {
+			return bootstrap.client.Leader(ctx)
+		}), "bootstrap conductor of chain %s must become leader", id)
+		for _, name := range haSequencerNames[1:] {
+			require.NoError(s.t, bootstrap.client.AddServerAsVoter(ctx, name, l2.conductors[name].ConsensusEndpoint(), 0))
+		}
+		head, err := s.L2GethClient(id, haSequencerNames[0]).BlockByNumber(ctx, nil)
+		require.NoError(s.t, err)
+		require.NoError(s.t, s.L2RollupClient(id, haSequencerNames[0]).StartSequencer(ctx, head.Hash()))
+	}
+	for _, id := range s.L2IDs() {
+		l2 := s.l2s[id]
+		require.NoError(s.t, wait.For(ctx, time.Second, func() (bool, error) {
+			for _, c := range l2.conductors {
+				if healthy, err := c.client.SequencerHealthy(ctx); err != nil || !healthy {
+					return false, nil
+				}
+			}
+			return true, nil
+		}), "sequencers of chain %s must become healthy", id)
+		for _, c := range l2.conductors {
+			require.NoError(s.t, c.client.Resume(ctx))
+		}
+	}
+}
+
+func (s *interopE2ESystem) Conductor(id string, name string) conrpc.API {
+	c, ok := s.l2s[id].conductors[name]
+	require.True(s.t, ok, "no conductor for %s of chain %s", name, id)
+	return c.client
+}
+
+func (s *interopE2ESystem) ActiveSequencer(id string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for _, name := range haSequencerNames {
+		leader, err := s.Conductor(id, name).Leader(ctx)
+		require.NoError(s.t, err)
+		if !leader {
+			continue
+		}
+		active, err := s.L2RollupClient(id, name).SequencerActive(ctx)
+		require.NoError(s.t, err)
+		if active {
+			return name
+		}
+	}
+	require.FailNow(s.t, "no active sequencer", "chain %s", id)
+	return ""
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/endpoint"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -55,6 +56,8 @@ type l2Net struct {
 	proposer *l2os.ProposerService
 	batcher  *bss.BatcherService
 	nodes    map[string]*l2Node
+	// conductors are the op-conductors of the sequencers, by node name, if the chain runs sequencer HA
+	conductors map[string]*conductor
 }
 
 func (s *interopE2ESystem) L2GethEndpoint(id string, name string) endpoint.RPC {
@@ -89,6 +92,12 @@ func (s *interopE2ESystem) L2RollupEndpoint(id string, name string) endpoint.RPC
 	return node.opNode.UserRPC()
 }
 
+func (s *interopE2ESystem) L2InteropRPC(id string, name string) (string, eth.Bytes32) {
+	net := s.l2s[id]
+	node := net.nodes[name]
+	return node.opNode.InteropRPC()
+}
+
 func (s *interopE2ESystem) L2RollupClient(id string, name string) *sources.RollupClient {
 	net := s.l2s[id]
 	node := net.nodes[name]
@@ -110,15 +119,32 @@ func (s *interopE2ESystem) L2RollupClient(id string, name string) *sources.Rollu
 // it returns a l2Set with the resources for the L2
 func (s *interopE2ESystem) newL2(id string, l2Out *interopgen.L2Output, depSet depset.DependencySet) l2Net {
 	operatorKeys := s.newOperatorKeysForL2(l2Out)
-	l2Geth := s.newGethForL2(id, "sequencer", l2Out)
-	opNode := s.newNodeForL2(id, "sequencer", l2Out, depSet, operatorKeys, l2Geth, true)
 	proposer := s.newProposerForL2(id, operatorKeys)
-	batcher := s.newBatcherForL2(id, operatorKeys, l2Geth, opNode)
+
+	var nodes map[string]*l2Node
+	var conductors map[string]*conductor
+	var l2EthRpcs, rollupRpcs []string
+	if s.config.SequencerHA {
+		nodes, conductors = s.newSequencerHAForL2(id, l2Out, depSet, operatorKeys)
+		// the batcher follows the active sequencer, through the RPC proxies of the conductors
+		for _, name := range haSequencerNames {
+			l2EthRpcs = append(l2EthRpcs, conductors[name].RPCEndpoint())
+			rollupRpcs = append(rollupRpcs, conductors[name].RPCEndpoint())
+		}
+	} else {
+		l2Geth := s.newGethForL2(id, "sequencer", l2Out)
+		opNode := s.newNodeForL2(id, "sequencer", l2Out, depSet, operatorKeys, l2Geth, true)
+		nodes = map[string]*l2Node{"sequencer": {name: "sequencer", opNode: opNode, l2Geth: l2Geth}}
+		l2EthRpcs = []string{l2Geth.UserRPC().RPC()}
+		rollupRpcs = []string{opNode.UserRPC().RPC()}
+	}
+	batcher := s.newBatcherForL2(id, operatorKeys, l2EthRpcs, rollupRpcs)
 
 	return l2Net{
 		l2Out:        l2Out,
 		chainID:      l2Out.Genesis.Config.ChainID,
-		nodes:        map[string]*l2Node{"sequencer": {name: "sequencer", opNode: opNode, l2Geth: l2Geth}},
+		nodes:        nodes,
+		conductors:   conductors,
 		proposer:     proposer,
 		batcher:      batcher,
 		operatorKeys: operatorKeys,
@@ -147,6 +173,7 @@ func (s *interopE2ESystem) newNodeForL2(
 	operatorKeys map[devkeys.ChainOperatorRole]ecdsa.PrivateKey,
 	l2Geth *geth.GethInstance,
 	isSequencer bool,
+	opts ...func(cfg *node.Config),
 ) *opnode.Opnode {
 	logger := s.logger.New("role", "op-node-"+id+"-"+name)
 	p2pKey := operatorKeys[devkeys.SequencerP2PRole]
@@ -193,6 +220,9 @@ func (s *interopE2ESystem) newNodeForL2(
 		},
 		ConfigPersistence: node.DisabledConfigPersistence{},
 	}
+	for _, opt := range opts {
+		opt(nodeCfg)
+	}
 	opNode, err := opnode.NewOpnode(logger.New("service", "op-node"),
 		nodeCfg, func(err error) {
 			s.t.Error(err)
@@ -263,15 +293,15 @@ func (s *interopE2ESystem) newProposerForL2(
 func (s *interopE2ESystem) newBatcherForL2(
 	id string,
 	operatorKeys map[devkeys.ChainOperatorRole]ecdsa.PrivateKey,
-	l2Geth *geth.GethInstance,
-	opNode *opnode.Opnode,
+	l2EthRpcs []string,
+	rollupRpcs []string,
 ) *bss.BatcherService {
 	batcherSecret := operatorKeys[devkeys.BatcherRole]
 	logger := s.logger.New("role", "batcher"+id)
 	batcherCLIConfig := &bss.CLIConfig{
 		L1EthRpc:                 s.l1.UserRPC().RPC(),
-		L2EthRpc:                 []string{l2Geth.UserRPC().RPC()},
-		RollupRpc:                []string{opNode.UserRPC().RPC()},
+		L2EthRpc:                 l2EthRpcs,
+		RollupRpc:                rollupRpcs,
 		MaxPendingTransactions:   1,
 		MaxChannelDuration:       1,
 		MaxL1TxSize:              120_000,
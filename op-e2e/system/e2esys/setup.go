@@ -1036,6 +1036,11 @@ var blackholeIP6 = net.ParseIP("100::")
 
 // mocknet doesn't allow us to add a peerstore without fully creating the peer ourselves
 func (sys *System) NewMockNetPeer() (host.Host, error) {
+	return NewMockNetPeer(sys.Mocknet)
+}
+
+// NewMockNetPeer creates a new peer with an extended peerstore in the mocknet.
+func NewMockNetPeer(mn mocknet.Mocknet) (host.Host, error) {
 	sk, _, err := ic.GenerateECDSAKeyPair(rand.Reader)
 	if err != nil {
 		return nil, err
@@ -1072,7 +1077,7 @@ func (sys *System) NewMockNetPeer() (host.Host, error) {
 	if err != nil {
 		return nil, err
 	}
-	return sys.Mocknet.AddPeerWithPeerstore(p, eps)
+	return mn.AddPeerWithPeerstore(p, eps)
 }
 
 func (sys *System) BatcherHelper() *batcher.Helper {
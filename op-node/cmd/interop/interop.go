@@ -300,5 +300,6 @@ var InteropCmd = &cli.Command{
 	Subcommands: cli.Commands{
 		InteropDevSetup,
 		DevKeyCmd,
+		ReplayJournalCmd,
 	},
 }
@@ -0,0 +1,115 @@
+package interop
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop/managed"
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+var (
+	journalFlag = &cli.PathFlag{
+		Name:      "journal",
+		Usage:     "Path of the event journal to replay, as configured with --interop.event-journal.path. Rotated journal files are read too.",
+		EnvVars:   op_service.PrefixEnvVar(EnvPrefix, "JOURNAL"),
+		Required:  true,
+		TakesFile: true,
+	}
+	replayRPCAddrFlag = &cli.StringFlag{
+		Name:    "rpc.addr",
+		Usage:   "Address to serve the replayed events to the supervisor on.",
+		Value:   "127.0.0.1",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "RPC_ADDR"),
+	}
+	replayRPCPortFlag = &cli.IntFlag{
+		Name:    "rpc.port",
+		Usage:   "Port to serve the replayed events to the supervisor on.",
+		Value:   9645,
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "RPC_PORT"),
+	}
+	replayJWTSecretFlag = &cli.PathFlag{
+		Name:      "rpc.jwt-secret",
+		Usage:     "Path to the JWT secret shared with the supervisor. A new secret is generated if the file does not exist.",
+		Value:     "interop-replay-jwt-secret.txt",
+		EnvVars:   op_service.PrefixEnvVar(EnvPrefix, "RPC_JWT_SECRET"),
+		TakesFile: true,
+	}
+	replaySpeedFlag = &cli.Float64Flag{
+		Name:    "speed",
+		Usage:   "Speed of the replay, relative to the time between the events in the journal. The events are replayed without delay if 0.",
+		Value:   1,
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "SPEED"),
+	}
+)
+
+var ReplayJournalCmd = &cli.Command{
+	Name:  "replay-journal",
+	Usage: "Replay the events of a managed node event journal to a supervisor",
+	Description: "Serves the events that the supervisor received from a managed node, as recorded in its event journal, " +
+		"to a supervisor test instance that manages the node at the RPC endpoint of this command. " +
+		"Only the event stream, the protocol and the chain ID of the node are served.",
+	Flags: cliapp.ProtectFlags(append([]cli.Flag{
+		journalFlag,
+		replayRPCAddrFlag,
+		replayRPCPortFlag,
+		replayJWTSecretFlag,
+		replaySpeedFlag,
+	}, oplog.CLIFlags(EnvPrefix)...)),
+	Action: func(cliCtx *cli.Context) error {
+		logCfg := oplog.ReadCLIConfig(cliCtx)
+		logger := oplog.NewLogger(cliCtx.App.Writer, logCfg)
+
+		speed := cliCtx.Float64(replaySpeedFlag.Name)
+		if speed < 0 {
+			return fmt.Errorf("invalid replay speed %v", speed)
+		}
+		entries, err := managed.ReadJournal(cliCtx.Path(journalFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to read event journal: %w", err)
+		}
+		replay, err := managed.NewJournalReplay(logger, entries)
+		if err != nil {
+			return fmt.Errorf("failed to create journal replay: %w", err)
+		}
+		jwtSecret, err := rpc.ObtainJWTSecret(logger, cliCtx.Path(replayJWTSecretFlag.Name), true)
+		if err != nil {
+			return err
+		}
+		srv := rpc.ServerFromConfig(&rpc.ServerConfig{
+			RpcOptions: []rpc.Option{
+				rpc.WithWebsocketEnabled(),
+				rpc.WithLogger(logger),
+				rpc.WithJWTSecret(jwtSecret[:]),
+			},
+			Host:       cliCtx.String(replayRPCAddrFlag.Name),
+			Port:       cliCtx.Int(replayRPCPortFlag.Name),
+			AppVersion: "v0.0.0",
+		})
+		srv.AddAPI(replay.API())
+		if err := srv.Start(); err != nil {
+			return fmt.Errorf("failed to start replay RPC server: %w", err)
+		}
+		defer func() {
+			if err := srv.Stop(); err != nil {
+				logger.Error("Failed to stop replay RPC server", "err", err)
+			}
+		}()
+		logger.Info("Waiting for the supervisor to connect", "endpoint", "ws://"+srv.Endpoint())
+
+		ctx := ctxinterrupt.WithCancelOnInterrupt(cliCtx.Context)
+		n, err := replay.Run(ctx, speed)
+		if err != nil {
+			logger.Warn("Replay interrupted", "events", n, "err", err)
+			return nil
+		}
+		logger.Info("Replayed all events, serving until interrupted", "events", n)
+		<-ctx.Done()
+		return nil
+	},
+}
@@ -427,6 +427,77 @@ var (
 		Destination: new(string),
 		Category:    InteropCategory,
 	}
+	InteropTLSEnabled = &cli.BoolFlag{
+		Name: "interop.tls.enabled",
+		Usage: "Serve the interop RPC over TLS, and require the supervisor to authenticate with a client certificate. " +
+			"Certificates are reloaded when rotated on disk. " +
+			"Applies only to Interop-enabled networks.",
+		EnvVars:  prefixEnvVars("INTEROP_TLS_ENABLED"),
+		Value:    false,
+		Category: InteropCategory,
+	}
+	InteropTLSCert = &cli.StringFlag{
+		Name:     "interop.tls.cert",
+		Usage:    "Interop RPC server TLS certificate path.",
+		EnvVars:  prefixEnvVars("INTEROP_TLS_CERT"),
+		Value:    "",
+		Category: InteropCategory,
+	}
+	InteropTLSKey = &cli.StringFlag{
+		Name:     "interop.tls.key",
+		Usage:    "Interop RPC server TLS key path.",
+		EnvVars:  prefixEnvVars("INTEROP_TLS_KEY"),
+		Value:    "",
+		Category: InteropCategory,
+	}
+	InteropTLSCaCert = &cli.StringFlag{
+		Name:     "interop.tls.ca",
+		Usage:    "Path of the CA certificate(s) that the supervisor client certificate must be issued by.",
+		EnvVars:  prefixEnvVars("INTEROP_TLS_CA"),
+		Value:    "",
+		Category: InteropCategory,
+	}
+	InteropEventJournalPath = &cli.PathFlag{
+		Name: "interop.event-journal.path",
+		Usage: "Path of a journal file to append every event sent to the supervisor to, with its time and delivery status, " +
+			"for post-incident replay with 'op-node interop replay-journal'. The file is rotated by size. " +
+			"Applies only to Interop-enabled networks. Disabled if empty.",
+		EnvVars:   prefixEnvVars("INTEROP_EVENT_JOURNAL_PATH"),
+		TakesFile: true,
+		Category:  InteropCategory,
+	}
+	InteropEventJournalMaxSize = &cli.IntFlag{
+		Name:     "interop.event-journal.max-size",
+		Usage:    "Size in megabytes at which the event journal file is rotated.",
+		EnvVars:  prefixEnvVars("INTEROP_EVENT_JOURNAL_MAX_SIZE"),
+		Value:    100,
+		Category: InteropCategory,
+	}
+	InteropEventJournalMaxBackups = &cli.IntFlag{
+		Name:     "interop.event-journal.max-backups",
+		Usage:    "Number of rotated event journal files to keep. All of them are kept if 0.",
+		EnvVars:  prefixEnvVars("INTEROP_EVENT_JOURNAL_MAX_BACKUPS"),
+		Value:    10,
+		Category: InteropCategory,
+	}
+	InteropGossipPauseBlocks = &cli.Uint64Flag{
+		Name: "interop.gossip-pause.blocks",
+		Usage: "Number of L2 blocks the unsafe chain may run ahead of the cross-safe chain, " +
+			"before the sequencer pauses gossip of new unsafe blocks and stops sequencing until the supervisor catches up. " +
+			"Applies only to Interop-enabled networks. Disabled if 0.",
+		EnvVars:  prefixEnvVars("INTEROP_GOSSIP_PAUSE_BLOCKS"),
+		Value:    0,
+		Category: InteropCategory,
+	}
+	InteropGossipPauseTime = &cli.DurationFlag{
+		Name: "interop.gossip-pause.time",
+		Usage: "Amount of L2 time the unsafe chain may run ahead of the cross-safe chain, " +
+			"before the sequencer pauses gossip of new unsafe blocks and stops sequencing until the supervisor catches up. " +
+			"Applies only to Interop-enabled networks. Disabled if 0.",
+		EnvVars:  prefixEnvVars("INTEROP_GOSSIP_PAUSE_TIME"),
+		Value:    0,
+		Category: InteropCategory,
+	}
 	InteropDependencySet = &cli.PathFlag{
 		Name:      "interop.dependency-set",
 		Usage:     "Dependency-set configuration, point at JSON file.",
@@ -505,7 +576,16 @@ var optionalFlags = []cli.Flag{
 	InteropRPCAddr,
 	InteropRPCPort,
 	InteropJWTSecret,
+	InteropTLSEnabled,
+	InteropTLSCert,
+	InteropTLSKey,
+	InteropTLSCaCert,
 	InteropDependencySet,
+	InteropEventJournalPath,
+	InteropEventJournalMaxSize,
+	InteropEventJournalMaxBackups,
+	InteropGossipPauseBlocks,
+	InteropGossipPauseTime,
 	IgnoreMissingPectraBlobSchedule,
 	ExperimentalOPStackAPI,
 }
@@ -32,6 +32,7 @@ type Metricer interface {
 	RecordUp()
 	SetDerivationIdle(status bool)
 	SetSequencerState(active bool)
+	SetSequencerGossipPaused(paused bool)
 	RecordPipelineReset()
 	RecordSequencingError()
 	RecordPublishingError()
@@ -93,6 +94,8 @@ type Metrics struct {
 	PublishingErrors *metrics.Event
 	SequencerActive  prometheus.Gauge
 
+	SequencerGossipPaused prometheus.Gauge
+
 	*event.EventMetricsTracker
 
 	DerivedBatches metrics.EventVec
@@ -202,6 +205,11 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "sequencer_active",
 			Help:      "1 if sequencer active, 0 otherwise",
 		}),
+		SequencerGossipPaused: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_gossip_paused",
+			Help:      "1 if the sequencer paused gossip because the unsafe chain is too far ahead of cross-safe, 0 otherwise",
+		}),
 		EventMetricsTracker: event.NewMetricsTracker(ns, factory),
 
 		DerivedBatches: metrics.NewEventVec(factory, ns, "", "derived_batches", "derived batches", []string{"type"}),
@@ -444,6 +452,14 @@ func (m *Metrics) SetSequencerState(active bool) {
 	m.SequencerActive.Set(val)
 }
 
+func (m *Metrics) SetSequencerGossipPaused(paused bool) {
+	var val float64
+	if paused {
+		val = 1
+	}
+	m.SequencerGossipPaused.Set(val)
+}
+
 func (m *Metrics) RecordPipelineReset() {
 	m.PipelineResets.Record()
 }
@@ -650,6 +666,9 @@ func (n *noopMetricer) SetDerivationIdle(status bool) {
 func (m *noopMetricer) SetSequencerState(active bool) {
 }
 
+func (m *noopMetricer) SetSequencerGossipPaused(paused bool) {
+}
+
 func (n *noopMetricer) RecordPipelineReset() {
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
@@ -411,7 +412,7 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	}
 
 	managedMode := false
-	sys, err := cfg.InteropConfig.Setup(ctx, n.log, &n.cfg.Rollup, n.l1Source, n.l2Source, n.metrics)
+	sys, err := cfg.InteropConfig.Setup(ctx, n.log, &n.cfg.Rollup, n.cfg.DependencySet, n.l1Source, n.l2Source, n.metrics)
 	if err != nil {
 		return fmt.Errorf("failed to setup interop: %w", err)
 	} else if sys != nil { // we continue with legacy mode if no interop sub-system is set up.
@@ -448,6 +449,15 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 
 	n.l2Driver = driver.NewDriver(n.eventSys, n.eventDrain, &cfg.Driver, &cfg.Rollup, cfg.DependencySet, n.l2Source, n.l1Source,
 		n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA, managedMode)
+
+	if m, ok := n.interopSys.(*managed.ManagedMode); ok {
+		// Replays run on pipelines of their own, with their own alt-DA state, to not affect the derivation of the node.
+		m.SetReplayPipeline(func() managed.ReplayPipeline {
+			replayAltDA := altda.NewAltDA(n.log, cfg.AltDA, rpCfg, &altda.NoopMetrics{})
+			return derive.NewDerivationPipeline(n.log.New("replay", true), &cfg.Rollup, cfg.DependencySet, n.l1Source, n.beacon,
+				replayAltDA, n.l2Source, metrics.NoopMetrics, false)
+		})
+	}
 	return nil
 }
 
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -14,6 +15,8 @@ import (
 type ReceivedBlockEvent struct {
 	From     peer.ID
 	Envelope *eth.ExecutionPayloadEnvelope
+	// ReceivedAt is the time that the block arrived, after passing gossip validation
+	ReceivedAt time.Time
 }
 
 func (ev ReceivedBlockEvent) String() string {
@@ -47,6 +50,6 @@ func (g *BlockReceiver) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg
 		"id", msg.ExecutionPayload.ID(),
 		"peer", from, "txs", len(msg.ExecutionPayload.Transactions))
 	g.metrics.RecordReceivedUnsafePayload(msg)
-	g.emitter.Emit(ReceivedBlockEvent{From: from, Envelope: msg})
+	g.emitter.Emit(ReceivedBlockEvent{From: from, Envelope: msg, ReceivedAt: time.Now()})
 	return nil
 }
@@ -1,5 +1,7 @@
 package driver
 
+import "time"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -21,6 +23,15 @@ type Config struct {
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
 
+	// SequencerGossipPauseBlocks is the number of L2 blocks the unsafe chain may run ahead of the cross-safe chain,
+	// before the sequencer pauses gossip and stops producing blocks. Only applies once interop is active.
+	// Disabled if 0.
+	SequencerGossipPauseBlocks uint64 `json:"sequencer_gossip_pause_blocks"`
+
+	// SequencerGossipPauseTime is like SequencerGossipPauseBlocks, but measured in L2 time.
+	// Disabled if 0.
+	SequencerGossipPauseTime time.Duration `json:"sequencer_gossip_pause_time"`
+
 	// RecoverMode forces the sequencer to select the next L1 Origin exactly, and create an empty block,
 	// to be compatible with verifiers forcefully generating the same block while catching up the sequencing window timeout.
 	RecoverMode bool `json:"recover_mode"`
@@ -403,8 +403,9 @@ func (s *Driver) ResetDerivationPipeline(ctx context.Context) error {
 
 func (s *Driver) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	s.emitter.Emit(p2p.ReceivedBlockEvent{
-		From:     "",
-		Envelope: payload,
+		From:       "",
+		Envelope:   payload,
+		ReceivedAt: time.Now(),
 	})
 	return nil
 }
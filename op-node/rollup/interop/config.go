@@ -3,13 +3,17 @@ package interop
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop/managed"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 )
 
 type Config struct {
@@ -21,18 +25,30 @@ type Config struct {
 	RPCPort int
 	// RPCJwtSecretPath path of JWT secret file to apply authentication to the interop server address.
 	RPCJwtSecretPath string
+	// RPCTLS configures TLS with client-certificate authentication of the interop RPC server.
+	// Optional: the server serves plaintext websockets if TLS is not enabled.
+	RPCTLS optls.CLIConfig
+	// EventJournal configures the journal of the events sent to the supervisor, for post-incident replay.
+	// Optional: the journal is disabled if no path is configured.
+	EventJournal managed.JournalConfig
 }
 
 func (cfg *Config) Check() error {
 	if cfg.RPCAddr != "" && cfg.RPCJwtSecretPath == "" {
 		return errors.New("interop RPC server requires JWT setup, but no JWT path was specified")
 	}
+	if err := cfg.RPCTLS.Check(); err != nil {
+		return fmt.Errorf("invalid interop RPC TLS config: %w", err)
+	}
+	if err := cfg.EventJournal.Check(); err != nil {
+		return fmt.Errorf("invalid interop event journal config: %w", err)
+	}
 	return nil
 }
 
 // Setup creates an interop sub-system. This drives the node syncing.
 // If setup returns a nil system (without error) the node should fall back to legacy mode.
-func (cfg *Config) Setup(ctx context.Context, logger log.Logger, rollupCfg *rollup.Config, l1 L1Source, l2 L2Source, m opmetrics.RPCMetricer) (SubSystem, error) {
+func (cfg *Config) Setup(ctx context.Context, logger log.Logger, rollupCfg *rollup.Config, depSet depset.DependencySet, l1 L1Source, l2 L2Source, m opmetrics.RPCMetricer) (SubSystem, error) {
 	if cfg.RPCAddr == "" {
 		logger.Warn("No interop RPC configured, falling back to legacy sync mode.")
 		return nil, nil // a `nil` system will result in legacy mode.
@@ -43,5 +59,18 @@ func (cfg *Config) Setup(ctx context.Context, logger log.Logger, rollupCfg *roll
 	if err != nil {
 		return nil, err
 	}
-	return managed.NewManagedMode(logger, rollupCfg, cfg.RPCAddr, cfg.RPCPort, jwtSecret, l1, l2, m), nil
+	var tlsCfg *managed.TLSConfig
+	if cfg.RPCTLS.TLSEnabled() {
+		conf, stop, err := optls.NewServerTLSConfig(logger, cfg.RPCTLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup interop RPC TLS: %w", err)
+		}
+		tlsCfg = &managed.TLSConfig{Config: conf, CLIConfig: cfg.RPCTLS, Stop: stop}
+	}
+	mm := managed.NewManagedMode(logger, rollupCfg, depSet, cfg.RPCAddr, cfg.RPCPort, jwtSecret, tlsCfg, l1, l2, m)
+	if cfg.EventJournal.Path != "" {
+		logger.Info("Recording the events sent to the supervisor", "journal", cfg.EventJournal.Path)
+		mm.SetEventJournal(managed.NewEventJournal(logger, eth.ChainIDFromBig(rollupCfg.L2ChainID), cfg.EventJournal))
+	}
+	return mm, nil
 }
@@ -15,6 +15,10 @@ type InteropAPI struct {
 	backend *ManagedMode
 }
 
+func (ib *InteropAPI) Protocol(ctx context.Context) (supervisortypes.ManagedProtocol, error) {
+	return ib.backend.Protocol(ctx)
+}
+
 func (ib *InteropAPI) PullEvent() (*supervisortypes.ManagedEvent, error) {
 	return ib.backend.PullEvent()
 }
@@ -79,3 +83,7 @@ func (ib *InteropAPI) L2BlockRefByTimestamp(ctx context.Context, timestamp uint6
 func (ib *InteropAPI) ProvideL1(ctx context.Context, nextL1 eth.BlockRef) error {
 	return ib.backend.ProvideL1(ctx, nextL1)
 }
+
+func (ib *InteropAPI) ReplayDerivation(ctx context.Context, start supervisortypes.DerivedIDPair, end eth.BlockID) (supervisortypes.DerivedBlockRefPair, error) {
+	return ib.backend.ReplayDerivation(ctx, start, end)
+}
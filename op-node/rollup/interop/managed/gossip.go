@@ -0,0 +1,56 @@
+package managed
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxPendingGossipBlocks is the number of gossip blocks that are remembered while pending processing.
+// Blocks that are never processed, e.g. because they are older than the unsafe head, are forgotten first.
+const maxPendingGossipBlocks = 100
+
+// gossipSource is where, and when, a block was received from via p2p gossip.
+type gossipSource struct {
+	peer       string
+	receivedAt time.Time
+}
+
+// gossipProvenance remembers the source of gossip blocks, by block hash, until the blocks are processed.
+// The zero value is ready to use.
+type gossipProvenance struct {
+	sources map[common.Hash]gossipSource
+	// order is the order in which the blocks were received, to forget the oldest blocks first
+	order []common.Hash
+}
+
+func (g *gossipProvenance) add(hash common.Hash, src gossipSource) {
+	if g.sources == nil {
+		g.sources = make(map[common.Hash]gossipSource)
+	}
+	if _, ok := g.sources[hash]; ok {
+		return // attribute the block to the first peer that delivered it
+	}
+	if len(g.order) >= maxPendingGossipBlocks {
+		delete(g.sources, g.order[0])
+		g.order = g.order[1:]
+	}
+	g.sources[hash] = src
+	g.order = append(g.order, hash)
+}
+
+// take returns and forgets the source of the block, and false if the block was not received via gossip.
+func (g *gossipProvenance) take(hash common.Hash) (gossipSource, bool) {
+	src, ok := g.sources[hash]
+	if !ok {
+		return gossipSource{}, false
+	}
+	delete(g.sources, hash)
+	for i, h := range g.order {
+		if h == hash {
+			g.order = append(g.order[:i], g.order[i+1:]...)
+			break
+		}
+	}
+	return src, true
+}
@@ -0,0 +1,218 @@
+package managed
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// DeliveryServed is an event that the supervisor pulled from the queue of the event stream.
+const DeliveryServed rpc.Delivery = "served"
+
+// JournalConfig configures the event journal of the managed mode.
+type JournalConfig struct {
+	// Path is the path of the journal file. The journal is disabled if empty.
+	Path string
+	// MaxSizeMB is the size in megabytes at which the journal file is rotated.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated journal files to keep. All of them are kept if 0.
+	MaxBackups int
+}
+
+func (c *JournalConfig) Check() error {
+	if c.Path != "" && c.MaxSizeMB <= 0 {
+		return errors.New("event journal max size must be positive")
+	}
+	if c.MaxBackups < 0 {
+		return errors.New("event journal max backups must not be negative")
+	}
+	return nil
+}
+
+// JournalEntry is an event sent to the supervisor, as recorded in the event journal.
+type JournalEntry struct {
+	Time     time.Time                     `json:"time"`
+	ChainID  eth.ChainID                   `json:"chainID"`
+	Delivery rpc.Delivery                  `json:"delivery"`
+	Event    *supervisortypes.ManagedEvent `json:"event"`
+}
+
+// Delivered returns true if the supervisor received the event of the entry,
+// over its subscription or by pulling it from the queue.
+// Queued events are recorded again when they are served.
+func (e *JournalEntry) Delivered() bool {
+	return e.Delivery == rpc.DeliveryNotified || e.Delivery == DeliveryServed
+}
+
+// EventJournal appends every event sent to the supervisor, with its time and delivery status,
+// as a line of JSON to a file that is rotated by size. The journal can be read with ReadJournal,
+// and replayed against a supervisor with JournalReplay, for post-incident analysis.
+type EventJournal struct {
+	log     log.Logger
+	chainID eth.ChainID
+
+	mu  sync.Mutex
+	out io.WriteCloser
+	enc *json.Encoder
+	now func() time.Time
+}
+
+// NewEventJournal creates a journal that writes to the file of the config, rotated when it exceeds the max size.
+func NewEventJournal(log log.Logger, chainID eth.ChainID, cfg JournalConfig) *EventJournal {
+	return newEventJournal(log, chainID, &lumberjack.Logger{
+		Filename:   cfg.Path,
+		MaxSize:    cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+	})
+}
+
+func newEventJournal(log log.Logger, chainID eth.ChainID, out io.WriteCloser) *EventJournal {
+	return &EventJournal{
+		log:     log,
+		chainID: chainID,
+		out:     out,
+		enc:     json.NewEncoder(out),
+		now:     time.Now,
+	}
+}
+
+// Record appends the event to the journal. Failures to write are logged,
+// the journal does not interrupt the event stream.
+func (j *EventJournal) Record(delivery rpc.Delivery, ev *supervisortypes.ManagedEvent) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry := JournalEntry{Time: j.now(), ChainID: j.chainID, Delivery: delivery, Event: ev}
+	if err := j.enc.Encode(&entry); err != nil {
+		j.log.Warn("Failed to record event in journal", "delivery", delivery, "err", err)
+	}
+}
+
+func (j *EventJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.out.Close()
+}
+
+// eventDeliverer is implemented by rpc.Stream, to report how an event was delivered.
+type eventDeliverer interface {
+	Deliver(ev *supervisortypes.ManagedEvent) rpc.Delivery
+}
+
+// journaledStream records the events of the stream in the journal: when they are sent, and when they are served.
+type journaledStream struct {
+	managedEventStream
+	journal *EventJournal
+}
+
+func (s *journaledStream) Send(ev *supervisortypes.ManagedEvent) {
+	delivery := rpc.DeliveryQueued
+	if d, ok := s.managedEventStream.(eventDeliverer); ok {
+		delivery = d.Deliver(ev)
+	} else {
+		s.managedEventStream.Send(ev)
+	}
+	s.journal.Record(delivery, ev)
+}
+
+func (s *journaledStream) Serve() (*supervisortypes.ManagedEvent, error) {
+	ev, err := s.managedEventStream.Serve()
+	if err == nil {
+		s.journal.Record(DeliveryServed, ev)
+	}
+	return ev, err
+}
+
+// SetEventJournal records the events sent to the supervisor in the journal, which is closed when the managed mode stops.
+func (m *ManagedMode) SetEventJournal(j *EventJournal) {
+	m.events = &journaledStream{managedEventStream: m.events, journal: j}
+	m.journal = j
+}
+
+// ReadJournal reads the entries of the journal file at the path, and of its rotated files, oldest first.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	files, err := journalFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []JournalEntry
+	for _, file := range files {
+		fileEntries, err := readJournalFile(file)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fileEntries...)
+	}
+	return entries, nil
+}
+
+// journalFiles returns the rotated journal files, oldest first, followed by the journal file if it exists.
+// Rotated files are named after the journal file, with the time of the rotation before the extension.
+func journalFiles(path string) ([]string, error) {
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(path, ext) + "-"
+	matches, err := filepath.Glob(escapeGlob(prefix) + "*" + escapeGlob(ext))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rotated journal files: %w", err)
+	}
+	// The rotation times sort chronologically
+	slices.Sort(matches)
+	if _, err := os.Stat(path); err == nil {
+		matches = append(matches, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no journal files at %s", path)
+	}
+	return matches, nil
+}
+
+func escapeGlob(s string) string {
+	return strings.NewReplacer(`*`, `\*`, `?`, `\?`, `[`, `\[`, `\`, `\\`).Replace(s)
+}
+
+func readJournalFile(path string) ([]JournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal file: %w", err)
+	}
+	defer f.Close()
+	var entries []JournalEntry
+	// A node that stops abruptly may leave the last entry incomplete, which is skipped.
+	var invalid error
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if invalid != nil {
+			return nil, invalid
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			invalid = fmt.Errorf("invalid journal entry at %s:%d: %w", path, line, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal file %s: %w", path, err)
+	}
+	return entries, nil
+}
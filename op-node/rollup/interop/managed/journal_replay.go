@@ -0,0 +1,128 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// JournalReplay serves the events of an event journal to a supervisor, like a managed node serves its events,
+// to reproduce the interactions of a node with a supervisor test instance after an incident.
+// Only the events that the supervisor received are replayed. The replay serves the event stream,
+// the protocol and the chain ID of the node: other requests of the supervisor fail, as the journal cannot answer them.
+type JournalReplay struct {
+	log     log.Logger
+	chainID eth.ChainID
+	entries []JournalEntry
+	events  *rpc.Stream[supervisortypes.ManagedEvent]
+
+	// connected is closed when the supervisor first subscribes to or pulls the events
+	connected     chan struct{}
+	connectedOnce sync.Once
+}
+
+// NewJournalReplay creates a replay of the delivered events of the journal entries, which must be of a single chain.
+func NewJournalReplay(log log.Logger, entries []JournalEntry) (*JournalReplay, error) {
+	delivered := slices.DeleteFunc(slices.Clone(entries), func(e JournalEntry) bool {
+		return !e.Delivered()
+	})
+	if len(delivered) == 0 {
+		return nil, errors.New("no delivered events in journal")
+	}
+	chainID := delivered[0].ChainID
+	for _, e := range delivered {
+		if e.ChainID != chainID {
+			return nil, fmt.Errorf("journal has events of chain %s and %s", chainID, e.ChainID)
+		}
+	}
+	return &JournalReplay{
+		log:     log,
+		chainID: chainID,
+		entries: delivered,
+		// The supervisor may poll instead of subscribing: all events must fit in the queue.
+		events:    rpc.NewStream[supervisortypes.ManagedEvent](log, len(delivered)),
+		connected: make(chan struct{}),
+	}, nil
+}
+
+// API returns the interop RPC API of the replay, to serve to the supervisor.
+func (r *JournalReplay) API() gethrpc.API {
+	return gethrpc.API{
+		Namespace:     "interop",
+		Service:       &journalReplayAPI{replay: r},
+		Authenticated: true,
+	}
+}
+
+// Run replays the events once the supervisor subscribes to or pulls the events. The events are spaced by
+// the time between them in the journal, divided by speed. The events are replayed without delay if speed is 0.
+// It returns the number of replayed events.
+func (r *JournalReplay) Run(ctx context.Context, speed float64) (int, error) {
+	select {
+	case <-r.connected:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	r.log.Info("Replaying journal", "chainID", r.chainID, "events", len(r.entries),
+		"from", r.entries[0].Time, "to", r.entries[len(r.entries)-1].Time)
+	for i, e := range r.entries {
+		if i > 0 && speed > 0 {
+			delay := time.Duration(float64(e.Time.Sub(r.entries[i-1].Time)) / speed)
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return i, ctx.Err()
+				}
+			}
+		}
+		r.events.Send(e.Event)
+	}
+	return len(r.entries), nil
+}
+
+func (r *JournalReplay) onConnect() {
+	r.connectedOnce.Do(func() {
+		close(r.connected)
+	})
+}
+
+type journalReplayAPI struct {
+	replay *JournalReplay
+}
+
+func (api *journalReplayAPI) Protocol(ctx context.Context) (supervisortypes.ManagedProtocol, error) {
+	return supervisortypes.ManagedProtocol{
+		Version: supervisortypes.ManagedProtocolVersion,
+		Capabilities: slices.DeleteFunc(slices.Clone(supervisortypes.ManagedCapabilities), func(c supervisortypes.ManagedCapability) bool {
+			return c == supervisortypes.CapabilityReplayDerivation
+		}),
+	}, nil
+}
+
+func (api *journalReplayAPI) ChainID(ctx context.Context) (eth.ChainID, error) {
+	return api.replay.chainID, nil
+}
+
+func (api *journalReplayAPI) PullEvent() (*supervisortypes.ManagedEvent, error) {
+	api.replay.onConnect()
+	return api.replay.events.Serve()
+}
+
+func (api *journalReplayAPI) Events(ctx context.Context) (*gethrpc.Subscription, error) {
+	sub, err := api.replay.events.Subscribe(ctx)
+	if err == nil {
+		api.replay.onConnect()
+	}
+	return sub, err
+}
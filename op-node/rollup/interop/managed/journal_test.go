@@ -0,0 +1,152 @@
+package managed
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func unsafeBlockEvent(num uint64) *supervisortypes.ManagedEvent {
+	return &supervisortypes.ManagedEvent{UnsafeBlock: &eth.BlockRef{Hash: common.Hash{byte(num)}, Number: num}}
+}
+
+func TestEventJournal(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	chainID := eth.ChainIDFromUInt64(900)
+	journal := NewEventJournal(logger, chainID, JournalConfig{Path: path, MaxSizeMB: 1})
+	start := time.Unix(1000, 0).UTC()
+	now := start
+	journal.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	mm := &ManagedMode{log: logger, events: rpc.NewStream[supervisortypes.ManagedEvent](logger, 1)}
+	mm.SetEventJournal(journal)
+
+	mm.events.Send(unsafeBlockEvent(1))
+	mm.events.Send(unsafeBlockEvent(2)) // drops the first event from the queue
+	ev, err := mm.PullEvent()
+	require.NoError(t, err)
+	require.Equal(t, unsafeBlockEvent(2), ev)
+	_, err = mm.PullEvent()
+	require.Error(t, err, "out of events")
+	require.NoError(t, mm.journal.Close())
+
+	entries, err := ReadJournal(path)
+	require.NoError(t, err)
+	require.Equal(t, []JournalEntry{
+		{Time: start.Add(1 * time.Second), ChainID: chainID, Delivery: rpc.DeliveryQueued, Event: unsafeBlockEvent(1)},
+		{Time: start.Add(2 * time.Second), ChainID: chainID, Delivery: rpc.DeliveryQueued, Event: unsafeBlockEvent(2)},
+		{Time: start.Add(3 * time.Second), ChainID: chainID, Delivery: DeliveryServed, Event: unsafeBlockEvent(2)},
+	}, entries)
+	require.False(t, entries[0].Delivered())
+	require.True(t, entries[2].Delivered())
+}
+
+func TestReadJournal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	line := func(num uint64) string {
+		data, err := json.Marshal(JournalEntry{ChainID: eth.ChainIDFromUInt64(900), Delivery: rpc.DeliveryNotified, Event: unsafeBlockEvent(num)})
+		require.NoError(t, err)
+		return string(data) + "\n"
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	_, err := ReadJournal(path)
+	require.ErrorContains(t, err, "no journal files")
+
+	write("events-2025-01-02T00-00-00.000.jsonl", line(3))
+	write("events-2025-01-01T00-00-00.000.jsonl", line(1)+line(2))
+	write("other.jsonl", line(9))
+	// The last entry of the current file was not completely written
+	write("events.jsonl", line(4)+line(5)[:20])
+	entries, err := ReadJournal(path)
+	require.NoError(t, err)
+	var nums []uint64
+	for _, e := range entries {
+		nums = append(nums, e.Event.UnsafeBlock.Number)
+	}
+	require.Equal(t, []uint64{1, 2, 3, 4}, nums)
+
+	write("events.jsonl", line(4)[:20]+"\n"+line(5))
+	_, err = ReadJournal(path)
+	require.ErrorContains(t, err, "invalid journal entry at "+path+":1")
+}
+
+func TestJournalReplay(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	chainID := eth.ChainIDFromUInt64(900)
+	start := time.Unix(1000, 0)
+	entries := []JournalEntry{
+		{Time: start, ChainID: chainID, Delivery: rpc.DeliveryNotified, Event: unsafeBlockEvent(1)},
+		{Time: start.Add(time.Second), ChainID: chainID, Delivery: rpc.DeliveryFailed, Event: unsafeBlockEvent(2)},
+		{Time: start.Add(2 * time.Second), ChainID: chainID, Delivery: rpc.DeliveryQueued, Event: unsafeBlockEvent(3)},
+		{Time: start.Add(3 * time.Second), ChainID: chainID, Delivery: DeliveryServed, Event: unsafeBlockEvent(3)},
+	}
+
+	_, err := NewJournalReplay(logger, entries[1:2])
+	require.ErrorContains(t, err, "no delivered events")
+	_, err = NewJournalReplay(logger, append(entries[:1:1], JournalEntry{ChainID: eth.ChainIDFromUInt64(901), Delivery: rpc.DeliveryNotified}))
+	require.ErrorContains(t, err, "events of chain 900 and 901")
+
+	replay, err := NewJournalReplay(logger, entries)
+	require.NoError(t, err)
+	server := gethrpc.NewServer()
+	t.Cleanup(server.Stop)
+	api := replay.API()
+	require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	cl := gethrpc.DialInProc(server)
+	t.Cleanup(cl.Close)
+
+	var id eth.ChainID
+	require.NoError(t, cl.CallContext(ctx, &id, "interop_chainID"))
+	require.Equal(t, chainID, id)
+	var protocol supervisortypes.ManagedProtocol
+	require.NoError(t, cl.CallContext(ctx, &protocol, "interop_protocol"))
+	require.Equal(t, supervisortypes.ManagedProtocolVersion, protocol.Version)
+	require.NotContains(t, protocol.Capabilities, supervisortypes.CapabilityReplayDerivation)
+
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := replay.Run(ctx, 1000)
+		done <- result{n, err}
+	}()
+	dest := make(chan rpc.EventEntry[supervisortypes.ManagedEvent], 10)
+	sub, err := cl.Subscribe(ctx, "interop", dest, "events")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	for _, num := range []uint64{1, 3} {
+		select {
+		case ev := <-dest:
+			require.Equal(t, unsafeBlockEvent(num), ev.Data)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for replayed event")
+		}
+	}
+	res := <-done
+	require.NoError(t, res.err)
+	require.Equal(t, 2, res.n)
+}
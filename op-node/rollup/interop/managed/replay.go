@@ -0,0 +1,158 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// MaxReplayL1Blocks is the maximum number of L1 blocks that a single derivation replay spans.
+const MaxReplayL1Blocks = 1000
+
+// ReplayPipeline is a derivation pipeline, separate from the derivation pipeline of the node,
+// to replay the derivation of L1 ranges with. See derive.DerivationPipeline.
+type ReplayPipeline interface {
+	Reset()
+	ConfirmEngineReset()
+	Origin() eth.L1BlockRef
+	Step(ctx context.Context, pendingSafeHead eth.L2BlockRef) (*derive.AttributesWithParent, error)
+}
+
+// ReplayPipelineFactory creates a fresh ReplayPipeline for every replay.
+type ReplayPipelineFactory func() ReplayPipeline
+
+// SetReplayPipeline enables interop_replayDerivation, with the pipelines created by the factory.
+func (m *ManagedMode) SetReplayPipeline(factory ReplayPipelineFactory) {
+	m.replayPipeline = factory
+}
+
+// ReplayDerivation re-derives the L2 blocks from the L1 blocks after start.Source, up to and including end,
+// starting from the local-safe block start.Derived, and sends the derivation updates of the replay to the supervisor,
+// like the node does when deriving. The derivation of the node is not reset: the replay runs on a separate pipeline,
+// and checks the re-derived blocks against the blocks of the node. It returns the last pair of the replay.
+// The supervisor uses it to fill gaps in its local-safe DB.
+func (m *ManagedMode) ReplayDerivation(ctx context.Context, start supervisortypes.DerivedIDPair, end eth.BlockID) (supervisortypes.DerivedBlockRefPair, error) {
+	if m.replayPipeline == nil {
+		return supervisortypes.DerivedBlockRefPair{}, &gethrpc.JsonError{
+			Code:    ReplayUnavailableRPCErrCode,
+			Message: "derivation replay not available",
+		}
+	}
+	if end.Number < start.Source.Number || end.Number-start.Source.Number > MaxReplayL1Blocks {
+		return supervisortypes.DerivedBlockRefPair{}, fmt.Errorf("cannot replay from L1 block %d to %d, replays span at most %d L1 blocks",
+			start.Source.Number, end.Number, MaxReplayL1Blocks)
+	}
+	if !m.replaying.TryLock() {
+		return supervisortypes.DerivedBlockRefPair{}, errors.New("derivation replay already in progress")
+	}
+	defer m.replaying.Unlock()
+
+	logger := m.log.New("source", start.Source, "derived", start.Derived, "end", end)
+	logger.Info("Replaying derivation")
+	source, err := m.canonicalL1(ctx, start.Source)
+	if err != nil {
+		return supervisortypes.DerivedBlockRefPair{}, err
+	}
+	if _, err := m.canonicalL1(ctx, end); err != nil {
+		return supervisortypes.DerivedBlockRefPair{}, err
+	}
+	derived, err := m.l2.L2BlockRefByNumber(ctx, start.Derived.Number)
+	if err != nil {
+		return supervisortypes.DerivedBlockRefPair{}, fmt.Errorf("failed to get replay start block: %w", err)
+	}
+	if derived.Hash != start.Derived.Hash {
+		return supervisortypes.DerivedBlockRefPair{}, &gethrpc.JsonError{
+			Code:    ConflictingBlockRPCErrCode,
+			Message: "conflicting block",
+			Data:    derived,
+		}
+	}
+
+	last := supervisortypes.DerivedBlockRefPair{Source: source, Derived: derived.BlockRef()}
+	pipeline := m.replayPipeline()
+	pipeline.Reset()
+	// The node is not reset: the pipeline starts from the given local-safe block.
+	pipeline.ConfirmEngineReset()
+	for {
+		if err := ctx.Err(); err != nil {
+			return last, fmt.Errorf("derivation replay interrupted: %w", err)
+		}
+		attrib, stepErr := pipeline.Step(ctx, derived)
+		// The pipeline rewinds to before the start of the replay, to read the batches that continue from it.
+		if origin := pipeline.Origin(); origin.Number > end.Number {
+			break
+		} else if origin.Number > last.Source.Number {
+			last.Source = origin
+			m.events.Send(&supervisortypes.ManagedEvent{
+				DerivationUpdate:       &supervisortypes.DerivedBlockRefPair{Source: last.Source, Derived: last.Derived},
+				DerivationOriginUpdate: &origin,
+			})
+		}
+		if errors.Is(stepErr, io.EOF) {
+			return last, fmt.Errorf("no L1 data to replay after %s", pipeline.Origin())
+		} else if stepErr != nil {
+			// Temporary errors are not retried: the supervisor retries the replay from where it stopped.
+			return last, fmt.Errorf("failed to replay derivation: %w", stepErr)
+		}
+		if attrib == nil {
+			continue
+		}
+		next, err := m.replayedBlock(ctx, derived, attrib)
+		if err != nil {
+			return last, err
+		}
+		derived = next
+		last = supervisortypes.DerivedBlockRefPair{Source: attrib.DerivedFrom, Derived: derived.BlockRef()}
+		m.events.Send(&supervisortypes.ManagedEvent{
+			DerivationUpdate: &supervisortypes.DerivedBlockRefPair{Source: last.Source, Derived: last.Derived},
+		})
+	}
+	logger.Info("Replayed derivation", "lastSource", last.Source, "lastDerived", last.Derived)
+	return last, nil
+}
+
+// replayedBlock returns the block of the node that the replayed attributes derive on top of parent.
+// Replacements of invalidated blocks are kept, as the node continues derivation from them too.
+func (m *ManagedMode) replayedBlock(ctx context.Context, parent eth.L2BlockRef, attrib *derive.AttributesWithParent) (eth.L2BlockRef, error) {
+	ref, err := m.l2.L2BlockRefByNumber(ctx, parent.Number+1)
+	if errors.Is(err, ethereum.NotFound) {
+		return eth.L2BlockRef{}, fmt.Errorf("replayed block %d is not known to the node: %w", parent.Number+1, err)
+	} else if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get replayed block %d: %w", parent.Number+1, err)
+	}
+	envelope, err := m.l2.PayloadByHash(ctx, ref.Hash)
+	if err != nil {
+		return eth.L2BlockRef{}, fmt.Errorf("failed to get replayed block %s: %w", ref, err)
+	}
+	if err := attributes.AttributesMatchBlock(m.cfg, attrib.Attributes, parent.Hash, envelope, m.log); err != nil {
+		if _, replErr := DecodeInvalidatedBlockTxFromReplacement(envelope.ExecutionPayload.Transactions); replErr != nil {
+			return eth.L2BlockRef{}, fmt.Errorf("replayed block %s does not match the block of the node: %w", ref, err)
+		}
+	}
+	return ref, nil
+}
+
+// canonicalL1 returns the L1 block, if it is canonical.
+func (m *ManagedMode) canonicalL1(ctx context.Context, id eth.BlockID) (eth.L1BlockRef, error) {
+	ref, err := m.l1.L1BlockRefByNumber(ctx, id.Number)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to get L1 block %d: %w", id.Number, err)
+	}
+	if ref.Hash != id.Hash {
+		return eth.L1BlockRef{}, &gethrpc.JsonError{
+			Code:    ConflictingBlockRPCErrCode,
+			Message: "conflicting L1 block",
+			Data:    ref,
+		}
+	}
+	return ref, nil
+}
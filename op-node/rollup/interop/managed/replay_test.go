@@ -0,0 +1,186 @@
+package managed
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type replayStep struct {
+	origin  eth.L1BlockRef
+	pending eth.BlockID
+	attrib  *derive.AttributesWithParent
+}
+
+// scriptedPipeline replays a fixed sequence of pipeline steps.
+type scriptedPipeline struct {
+	t      *testing.T
+	steps  []replayStep
+	origin eth.L1BlockRef
+}
+
+func (p *scriptedPipeline) Reset()              {}
+func (p *scriptedPipeline) ConfirmEngineReset() {}
+
+func (p *scriptedPipeline) Origin() eth.L1BlockRef {
+	return p.origin
+}
+
+func (p *scriptedPipeline) Step(ctx context.Context, pendingSafeHead eth.L2BlockRef) (*derive.AttributesWithParent, error) {
+	require.NotEmpty(p.t, p.steps, "no more steps")
+	step := p.steps[0]
+	p.steps = p.steps[1:]
+	require.Equal(p.t, step.pending, pendingSafeHead.ID(), "pending safe head")
+	p.origin = step.origin
+	return step.attrib, nil
+}
+
+func TestManagedMode_ReplayDerivation(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(123)}
+	l1 := func(n uint64) eth.L1BlockRef {
+		return eth.L1BlockRef{Hash: common.Hash{byte(n)}, Number: n}
+	}
+	l2 := func(n uint64) eth.L2BlockRef {
+		return eth.L2BlockRef{Hash: common.Hash{0xaa, byte(n)}, Number: n, ParentHash: common.Hash{0xaa, byte(n - 1)}, Time: n * 2}
+	}
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	attrib := func(parent eth.L2BlockRef, source eth.L1BlockRef) *derive.AttributesWithParent {
+		return &derive.AttributesWithParent{
+			Attributes:  &eth.PayloadAttributes{Timestamp: eth.Uint64Quantity(parent.Time + 2), GasLimit: &gasLimit},
+			Parent:      parent,
+			DerivedFrom: source,
+		}
+	}
+	payload := func(ref eth.L2BlockRef) *eth.ExecutionPayloadEnvelope {
+		return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+			ParentHash:  ref.ParentHash,
+			BlockHash:   ref.Hash,
+			BlockNumber: eth.Uint64Quantity(ref.Number),
+			Timestamp:   eth.Uint64Quantity(ref.Time),
+			GasLimit:    gasLimit,
+		}}
+	}
+	start := supervisortypes.DerivedIDPair{Source: l1(10).ID(), Derived: l2(100).ID()}
+
+	setup := func(t *testing.T, steps []replayStep) (*ManagedMode, *mockEventStream, *testutils.MockL1Source, *testutils.MockL2Client) {
+		l1Source := &testutils.MockL1Source{}
+		l2Source := &testutils.MockL2Client{}
+		events := &mockEventStream{}
+		mm := &ManagedMode{
+			log:    testlog.Logger(t, log.LevelDebug),
+			cfg:    cfg,
+			l1:     l1Source,
+			l2:     l2Source,
+			events: events,
+		}
+		mm.SetReplayPipeline(func() ReplayPipeline {
+			return &scriptedPipeline{t: t, steps: steps}
+		})
+		return mm, events, l1Source, l2Source
+	}
+
+	t.Run("replays range", func(t *testing.T) {
+		mm, events, l1Source, l2Source := setup(t, []replayStep{
+			// the pipeline rewinds to before the start of the replay
+			{origin: l1(8), pending: l2(100).ID()},
+			{origin: l1(10), pending: l2(100).ID()},
+			{origin: l1(11), pending: l2(100).ID(), attrib: attrib(l2(100), l1(11))},
+			{origin: l1(12), pending: l2(101).ID()},
+			{origin: l1(12), pending: l2(101).ID(), attrib: attrib(l2(101), l1(12))},
+			{origin: l1(13), pending: l2(102).ID()},
+		})
+		l1Source.ExpectL1BlockRefByNumber(10, l1(10), nil)
+		l1Source.ExpectL1BlockRefByNumber(12, l1(12), nil)
+		l2Source.ExpectL2BlockRefByNumber(100, l2(100), nil)
+		for _, n := range []uint64{101, 102} {
+			l2Source.ExpectL2BlockRefByNumber(n, l2(n), nil)
+			l2Source.ExpectPayloadByHash(l2(n).Hash, payload(l2(n)), nil)
+		}
+
+		last, err := mm.ReplayDerivation(context.Background(), start, l1(12).ID())
+		require.NoError(t, err)
+		require.Equal(t, supervisortypes.DerivedBlockRefPair{Source: l1(12), Derived: l2(102).BlockRef()}, last)
+
+		origin11, origin12 := l1(11), l1(12)
+		require.Equal(t, []*supervisortypes.ManagedEvent{
+			{
+				DerivationUpdate:       &supervisortypes.DerivedBlockRefPair{Source: l1(11), Derived: l2(100).BlockRef()},
+				DerivationOriginUpdate: &origin11,
+			},
+			{DerivationUpdate: &supervisortypes.DerivedBlockRefPair{Source: l1(11), Derived: l2(101).BlockRef()}},
+			{
+				DerivationUpdate:       &supervisortypes.DerivedBlockRefPair{Source: l1(12), Derived: l2(101).BlockRef()},
+				DerivationOriginUpdate: &origin12,
+			},
+			{DerivationUpdate: &supervisortypes.DerivedBlockRefPair{Source: l1(12), Derived: l2(102).BlockRef()}},
+		}, events.drainEvents())
+		l1Source.AssertExpectations(t)
+		l2Source.AssertExpectations(t)
+	})
+
+	t.Run("mismatching block", func(t *testing.T) {
+		mm, events, l1Source, l2Source := setup(t, []replayStep{
+			{origin: l1(11), pending: l2(100).ID(), attrib: attrib(l2(100), l1(11))},
+		})
+		l1Source.ExpectL1BlockRefByNumber(10, l1(10), nil)
+		l1Source.ExpectL1BlockRefByNumber(12, l1(12), nil)
+		l2Source.ExpectL2BlockRefByNumber(100, l2(100), nil)
+		l2Source.ExpectL2BlockRefByNumber(101, l2(101), nil)
+		other := payload(l2(101))
+		other.ExecutionPayload.Timestamp++
+		l2Source.ExpectPayloadByHash(l2(101).Hash, other, nil)
+
+		last, err := mm.ReplayDerivation(context.Background(), start, l1(12).ID())
+		require.ErrorContains(t, err, "does not match the block of the node")
+		require.Equal(t, supervisortypes.DerivedBlockRefPair{Source: l1(11), Derived: l2(100).BlockRef()}, last)
+		// the traversal of the L1 block was sent before the block failed
+		require.Len(t, events.drainEvents(), 1)
+	})
+
+	t.Run("conflicting start", func(t *testing.T) {
+		mm, _, l1Source, l2Source := setup(t, nil)
+		l1Source.ExpectL1BlockRefByNumber(10, l1(10), nil)
+		l1Source.ExpectL1BlockRefByNumber(12, l1(12), nil)
+		l2Source.ExpectL2BlockRefByNumber(100, l2(99), nil)
+
+		_, err := mm.ReplayDerivation(context.Background(), start, l1(12).ID())
+		var jsonErr *gethrpc.JsonError
+		require.True(t, errors.As(err, &jsonErr))
+		require.Equal(t, ConflictingBlockRPCErrCode, jsonErr.Code)
+	})
+
+	t.Run("range too large", func(t *testing.T) {
+		mm, _, _, _ := setup(t, nil)
+		_, err := mm.ReplayDerivation(context.Background(), start, l1(10+MaxReplayL1Blocks+1).ID())
+		require.ErrorContains(t, err, "replays span at most")
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		mm := &ManagedMode{log: testlog.Logger(t, log.LevelDebug), cfg: cfg}
+		_, err := mm.ReplayDerivation(context.Background(), start, l1(12).ID())
+		var jsonErr *gethrpc.JsonError
+		require.True(t, errors.As(err, &jsonErr))
+		require.Equal(t, ReplayUnavailableRPCErrCode, jsonErr.Code)
+
+		protocol, err := mm.Protocol(context.Background())
+		require.NoError(t, err)
+		require.False(t, protocol.Supports(supervisortypes.CapabilityReplayDerivation))
+		mm.SetReplayPipeline(func() ReplayPipeline { return nil })
+		protocol, err = mm.Protocol(context.Background())
+		require.NoError(t, err)
+		require.True(t, protocol.Supports(supervisortypes.CapabilityReplayDerivation))
+	})
+}
@@ -2,24 +2,32 @@ package managed
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/binary"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 	supervisortypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
@@ -57,6 +65,8 @@ type ManagedMode struct {
 	l2 L2Source
 
 	events managedEventStream
+	// journal records the events sent to the supervisor, nil if the journal is disabled.
+	journal *EventJournal
 
 	// outgoing event timestamp trackers
 	lastReset         eventTimestamp[struct{}]
@@ -66,20 +76,43 @@ type ManagedMode struct {
 	lastExhaustedL1   eventTimestamp[eth.BlockID]
 	lastReplacedBlock eventTimestamp[eth.BlockID]
 
+	// gossipBlocks attributes the unsafe blocks received via p2p gossip to their peer, until processed
+	gossipBlocks gossipProvenance
+
 	cfg *rollup.Config
+	// depSet is the dependency set that the node is configured with, nil if none is configured.
+	depSet depset.DependencySet
 
 	srv       *rpc.Server
 	jwtSecret eth.Bytes32
+	tls       *TLSConfig
+
+	// replayPipeline creates the pipelines of derivation replays, nil if replays are not available.
+	replayPipeline ReplayPipelineFactory
+	// replaying is held while a derivation replay runs, to run one replay at a time.
+	replaying sync.Mutex
+}
+
+// TLSConfig configures TLS of the interop RPC server.
+type TLSConfig struct {
+	Config    *tls.Config
+	CLIConfig optls.CLIConfig
+	// Stop stops the reloading of rotated certificates, and is called when the managed mode stops.
+	Stop func()
 }
 
-func NewManagedMode(log log.Logger, cfg *rollup.Config, addr string, port int, jwtSecret eth.Bytes32, l1 L1Source, l2 L2Source, m opmetrics.RPCMetricer) *ManagedMode {
+// NewManagedMode creates the managed mode, serving the interop RPC to the supervisor.
+// The RPC is served over TLS if tlsCfg is not nil, and over plaintext otherwise.
+func NewManagedMode(log log.Logger, cfg *rollup.Config, depSet depset.DependencySet, addr string, port int, jwtSecret eth.Bytes32, tlsCfg *TLSConfig, l1 L1Source, l2 L2Source, m opmetrics.RPCMetricer) *ManagedMode {
 	log = log.With("mode", "managed", "chainId", cfg.L2ChainID)
 	out := &ManagedMode{
 		log:       log,
 		cfg:       cfg,
+		depSet:    depSet,
 		l1:        l1,
 		l2:        l2,
 		jwtSecret: jwtSecret,
+		tls:       tlsCfg,
 		events:    rpc.NewStream[supervisortypes.ManagedEvent](log, 100),
 
 		lastReset:         newEventTimestamp[struct{}](100 * time.Millisecond),
@@ -90,12 +123,25 @@ func NewManagedMode(log log.Logger, cfg *rollup.Config, addr string, port int, j
 		lastReplacedBlock: newEventTimestamp[eth.BlockID](100 * time.Millisecond),
 	}
 
-	out.srv = rpc.NewServer(addr, port, "v0.0.0",
-		rpc.WithWebsocketEnabled(),
-		rpc.WithLogger(log),
-		rpc.WithJWTSecret(jwtSecret[:]),
-		rpc.WithRPCRecorder(m.NewRecorder("interop_managed")),
-	)
+	var httpOpts []httputil.Option
+	if tlsCfg != nil {
+		httpOpts = append(httpOpts, httputil.WithServerTLS(&httputil.ServerTLSConfig{
+			Config:    tlsCfg.Config,
+			CLIConfig: &tlsCfg.CLIConfig,
+		}))
+	}
+	out.srv = rpc.ServerFromConfig(&rpc.ServerConfig{
+		HttpOptions: httpOpts,
+		RpcOptions: []rpc.Option{
+			rpc.WithWebsocketEnabled(),
+			rpc.WithLogger(log),
+			rpc.WithJWTSecret(jwtSecret[:]),
+			rpc.WithRPCRecorder(m.NewRecorder("interop_managed")),
+		},
+		Host:       addr,
+		Port:       port,
+		AppVersion: "v0.0.0",
+	})
 	out.srv.AddAPI(gethrpc.API{
 		Namespace:     "interop",
 		Service:       &InteropAPI{backend: out},
@@ -127,6 +173,9 @@ func (m *ManagedMode) Start(ctx context.Context) error {
 }
 
 func (m *ManagedMode) WSEndpoint() string {
+	if m.tls != nil {
+		return fmt.Sprintf("wss://%s", m.srv.Endpoint())
+	}
 	return fmt.Sprintf("ws://%s", m.srv.Endpoint())
 }
 
@@ -143,6 +192,14 @@ func (m *ManagedMode) Stop(ctx context.Context) error {
 	if err := m.srv.Stop(); err != nil {
 		return fmt.Errorf("failed to stop interop sub-system RPC server: %w", err)
 	}
+	if m.tls != nil && m.tls.Stop != nil {
+		m.tls.Stop()
+	}
+	if m.journal != nil {
+		if err := m.journal.Close(); err != nil {
+			return fmt.Errorf("failed to close event journal: %w", err)
+		}
+	}
 
 	m.log.Info("Interop sub-system stopped")
 	return nil
@@ -243,12 +300,66 @@ func (m *ManagedMode) OnEvent(ev event.Event) bool {
 			Invalidated: out.BlockHash,
 		}})
 
+	case p2p.ReceivedBlockEvent:
+		// Only blocks received via gossip have a peer. Blocks from other sync methods are not attributed.
+		if x.From == "" || !m.cfg.IsInterop(uint64(x.Envelope.ExecutionPayload.Timestamp)) {
+			return false
+		}
+		m.gossipBlocks.add(x.Envelope.ExecutionPayload.BlockHash, gossipSource{peer: x.From.String(), receivedAt: x.ReceivedAt})
+
+	case engine.PayloadSuccessEvent:
+		return m.sendGossipBlock(x.Envelope, supervisortypes.GossipBlockValid)
+
+	case engine.PayloadInvalidEvent:
+		return m.sendGossipBlock(x.Envelope, supervisortypes.GossipBlockInvalid)
+
 	default:
 		return false
 	}
 	return true
 }
 
+// sendGossipBlock sends the source and processing outcome of the block to the supervisor,
+// if the block was received via p2p gossip.
+func (m *ManagedMode) sendGossipBlock(envelope *eth.ExecutionPayloadEnvelope, outcome supervisortypes.GossipBlockOutcome) bool {
+	if envelope == nil {
+		return false
+	}
+	src, ok := m.gossipBlocks.take(envelope.ExecutionPayload.BlockHash)
+	if !ok {
+		return false
+	}
+	ref := envelope.ExecutionPayload.BlockRef()
+	m.log.Debug("Sending gossip block to supervisor", "block", ref, "peer", src.peer, "outcome", outcome)
+	m.events.Send(&supervisortypes.ManagedEvent{GossipBlock: &supervisortypes.GossipBlock{
+		Block:      ref,
+		Peer:       src.peer,
+		ReceivedAt: hexutil.Uint64(src.receivedAt.UnixMilli()),
+		Outcome:    outcome,
+	}})
+	return true
+}
+
+// Protocol reports the version of the managed-mode protocol and the capabilities of the node,
+// for the supervisor to adapt to the node when it connects, and the hash of its dependency set,
+// for the supervisor to check that it is the same as its own.
+func (m *ManagedMode) Protocol(ctx context.Context) (supervisortypes.ManagedProtocol, error) {
+	protocol := supervisortypes.ManagedProtocol{
+		Version:      supervisortypes.ManagedProtocolVersion,
+		Capabilities: supervisortypes.ManagedCapabilities,
+	}
+	if m.replayPipeline == nil {
+		protocol.Capabilities = slices.DeleteFunc(slices.Clone(protocol.Capabilities), func(c supervisortypes.ManagedCapability) bool {
+			return c == supervisortypes.CapabilityReplayDerivation
+		})
+	}
+	if m.depSet != nil {
+		hash := depset.Hash(m.depSet)
+		protocol.DependencySetHash = &hash
+	}
+	return protocol, nil
+}
+
 func (m *ManagedMode) PullEvent() (*supervisortypes.ManagedEvent, error) {
 	return m.events.Serve()
 }
@@ -354,10 +465,11 @@ func (m *ManagedMode) AnchorPoint(ctx context.Context) (supervisortypes.DerivedB
 }
 
 const (
-	InternalErrorRPCErrcode    = -32603
-	BlockNotFoundRPCErrCode    = -39001
-	ConflictingBlockRPCErrCode = -39002
-	InteropInactiveRPCErrCode  = -39003
+	InternalErrorRPCErrcode     = -32603
+	BlockNotFoundRPCErrCode     = -39001
+	ConflictingBlockRPCErrCode  = -39002
+	InteropInactiveRPCErrCode   = -39003
+	ReplayUnavailableRPCErrCode = -39004
 )
 
 // TODO: add ResetPreInterop, called by supervisor if bisection went pre-Interop. Emit ResetEngineRequestEvent.
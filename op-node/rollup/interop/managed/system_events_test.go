@@ -8,10 +8,13 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
@@ -378,3 +381,87 @@ func TestManagedMode_OnEvent_Deduplication(t *testing.T) {
 		require.Len(t, events, 0, "Pre-Interop events should not be sent")
 	})
 }
+
+func TestManagedMode_OnEvent_GossipBlocks(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	cfg := &rollup.Config{
+		L2ChainID:   big.NewInt(123),
+		InteropTime: new(uint64), // Interop active from genesis
+	}
+	mockStream := &mockEventStream{}
+	mm := &ManagedMode{
+		log:    logger,
+		cfg:    cfg,
+		events: mockStream,
+	}
+
+	envelope := func(n uint64) *eth.ExecutionPayloadEnvelope {
+		return &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+			BlockHash:   common.Hash{byte(n)},
+			BlockNumber: eth.Uint64Quantity(n),
+			Timestamp:   eth.Uint64Quantity(1000 + n),
+		}}
+	}
+	receivedAt := time.UnixMilli(1_000_500)
+
+	t.Run("Valid", func(t *testing.T) {
+		env := envelope(1)
+		mm.OnEvent(p2p.ReceivedBlockEvent{From: peer.ID("peer-a"), Envelope: env, ReceivedAt: receivedAt})
+		require.Empty(t, mockStream.drainEvents(), "nothing is sent before the block is processed")
+
+		require.True(t, mm.OnEvent(engine.PayloadSuccessEvent{Envelope: env}))
+		events := mockStream.drainEvents()
+		require.Len(t, events, 1)
+		require.Equal(t, &supervisortypes.GossipBlock{
+			Block:      env.ExecutionPayload.BlockRef(),
+			Peer:       peer.ID("peer-a").String(),
+			ReceivedAt: hexutil.Uint64(receivedAt.UnixMilli()),
+			Outcome:    supervisortypes.GossipBlockValid,
+		}, events[0].GossipBlock)
+
+		// the block is only reported once
+		mm.OnEvent(engine.PayloadSuccessEvent{Envelope: env})
+		require.Empty(t, mockStream.drainEvents())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		env := envelope(2)
+		mm.OnEvent(p2p.ReceivedBlockEvent{From: peer.ID("peer-b"), Envelope: env, ReceivedAt: receivedAt})
+		mm.OnEvent(engine.PayloadInvalidEvent{Envelope: env, Err: errors.New("test invalid payload")})
+		events := mockStream.drainEvents()
+		require.Len(t, events, 1)
+		require.Equal(t, peer.ID("peer-b").String(), events[0].GossipBlock.Peer)
+		require.Equal(t, supervisortypes.GossipBlockInvalid, events[0].GossipBlock.Outcome)
+	})
+
+	t.Run("NotFromGossip", func(t *testing.T) {
+		env := envelope(3)
+		mm.OnEvent(p2p.ReceivedBlockEvent{Envelope: env, ReceivedAt: receivedAt})
+		mm.OnEvent(engine.PayloadSuccessEvent{Envelope: env})
+		require.Empty(t, mockStream.drainEvents())
+	})
+
+	t.Run("PreInterop", func(t *testing.T) {
+		interopTime := uint64(2000)
+		mm.cfg = &rollup.Config{L2ChainID: big.NewInt(123), InteropTime: &interopTime}
+		t.Cleanup(func() { mm.cfg = cfg })
+		env := envelope(4)
+		mm.OnEvent(p2p.ReceivedBlockEvent{From: peer.ID("peer-a"), Envelope: env, ReceivedAt: receivedAt})
+		mm.OnEvent(engine.PayloadSuccessEvent{Envelope: env})
+		require.Empty(t, mockStream.drainEvents())
+	})
+
+	t.Run("Bounded", func(t *testing.T) {
+		for i := uint64(0); i < maxPendingGossipBlocks+10; i++ {
+			env := envelope(10 + i)
+			env.ExecutionPayload.BlockHash = common.Hash{0xff, byte(i)}
+			mm.OnEvent(p2p.ReceivedBlockEvent{From: peer.ID("peer-a"), Envelope: env, ReceivedAt: receivedAt})
+		}
+		require.Len(t, mm.gossipBlocks.sources, maxPendingGossipBlocks)
+		require.Len(t, mm.gossipBlocks.order, maxPendingGossipBlocks)
+		_, ok := mm.gossipBlocks.take(common.Hash{0xff, 0})
+		require.False(t, ok, "oldest block should be forgotten")
+		_, ok = mm.gossipBlocks.take(common.Hash{0xff, maxPendingGossipBlocks + 9})
+		require.True(t, ok)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"time"
 
@@ -38,6 +39,7 @@ type Metrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordSequencingError()
+	SetSequencerGossipPaused(paused bool)
 }
 
 type SequencerStateListener interface {
@@ -86,6 +88,16 @@ type Sequencer struct {
 
 	maxSafeLag atomic.Uint64
 
+	// gossipPauseBlocks and gossipPauseTime are the thresholds of how far the unsafe chain may run
+	// ahead of the cross-safe chain, before gossip of new unsafe blocks is paused. Disabled if 0.
+	// These only apply once interop is active, when the safe chain is maintained by the supervisor.
+	gossipPauseBlocks uint64
+	gossipPauseTime   time.Duration
+	// gossipPaused is true while gossip is paused, and no new blocks are built.
+	gossipPaused bool
+	// heldBlocks are the blocks sealed while gossip was paused, in order. They are gossiped once gossip resumes.
+	heldBlocks []*eth.ExecutionPayloadEnvelope
+
 	recoverMode atomic.Bool
 
 	// active identifies whether the sequencer is running.
@@ -279,10 +291,16 @@ func (d *Sequencer) onBuildSealed(x engine.BuildSealedEvent) {
 		return
 	}
 
-	// begin gossiping as soon as possible
-	// asyncGossip.Clear() will be called later if an non-temporary error is found,
-	// or if the payload is successfully inserted
-	d.asyncGossip.Gossip(x.Envelope)
+	if d.gossipPaused {
+		d.log.Warn("Holding back gossip of sealed block, unsafe chain is too far ahead of cross-safe chain",
+			"block", x.Envelope.ExecutionPayload.ID())
+		d.heldBlocks = append(d.heldBlocks, x.Envelope)
+	} else {
+		// begin gossiping as soon as possible
+		// asyncGossip.Clear() will be called later if an non-temporary error is found,
+		// or if the payload is successfully inserted
+		d.asyncGossip.Gossip(x.Envelope)
+	}
 	// Now after having gossiped the block, try to put it in our own canonical chain
 	d.emitter.Emit(engine.PayloadProcessEvent{
 		Concluding:   x.Concluding,
@@ -321,6 +339,10 @@ func (d *Sequencer) onPayloadInvalid(x engine.PayloadInvalidEvent) {
 	}
 	d.log.Error("Sequencer could not insert payload",
 		"block", x.Envelope.ExecutionPayload.ID(), "err", x.Err)
+	// Never gossip a block that we could not insert ourselves.
+	d.heldBlocks = slices.DeleteFunc(d.heldBlocks, func(env *eth.ExecutionPayloadEnvelope) bool {
+		return env.ExecutionPayload.BlockHash == x.Envelope.ExecutionPayload.BlockHash
+	})
 	d.handleInvalid()
 }
 
@@ -417,6 +439,8 @@ func (d *Sequencer) onReset(x rollup.ResetEvent) {
 		d.emitter.Emit(engine.BuildCancelEvent{Info: d.latest.Info})
 	}
 	d.latest = BuildingState{}
+	// held blocks may not be canonical anymore after the reset
+	d.heldBlocks = nil
 	// no action to perform until we get a reset-confirmation
 	d.nextActionOK = false
 }
@@ -465,9 +489,52 @@ func (d *Sequencer) onForkchoiceUpdate(x engine.ForkchoiceUpdateEvent) {
 			d.nextAction = now
 		}
 	}
+	d.updateGossipPause(x.UnsafeL2Head, x.SafeL2Head)
+	if d.gossipPaused {
+		// Backpressure: do not build on top of blocks we could not gossip.
+		d.nextActionOK = false
+	}
 	d.setLatestHead(x.UnsafeL2Head)
 }
 
+// updateGossipPause pauses gossip of new unsafe blocks if the unsafe chain runs too far ahead of the cross-safe chain,
+// e.g. when the supervisor stalled, and resumes gossip (and sequencing) once the cross-safe chain catches up again.
+func (d *Sequencer) updateGossipPause(unsafe eth.L2BlockRef, crossSafe eth.L2BlockRef) {
+	exceeded := false
+	if d.rollupCfg.IsInterop(unsafe.Time) {
+		if d.gossipPauseBlocks > 0 && crossSafe.Number+d.gossipPauseBlocks <= unsafe.Number {
+			exceeded = true
+		}
+		if d.gossipPauseTime > 0 && unsafe.Time > crossSafe.Time &&
+			time.Duration(unsafe.Time-crossSafe.Time)*time.Second >= d.gossipPauseTime {
+			exceeded = true
+		}
+	}
+	if exceeded == d.gossipPaused {
+		return
+	}
+	d.gossipPaused = exceeded
+	d.metrics.SetSequencerGossipPaused(exceeded)
+	if exceeded {
+		d.log.Warn("Unsafe chain is too far ahead of cross-safe chain, pausing gossip and sequencing",
+			"unsafe", unsafe, "crossSafe", crossSafe,
+			"max_blocks", d.gossipPauseBlocks, "max_time", d.gossipPauseTime)
+	} else {
+		d.log.Info("Cross-safe chain caught up, resuming gossip and sequencing",
+			"unsafe", unsafe, "crossSafe", crossSafe, "held", len(d.heldBlocks))
+		for _, env := range d.heldBlocks {
+			d.asyncGossip.Gossip(env)
+		}
+		// Blocks that we already inserted do not need to stay in the async-gossip buffer for reuse.
+		if n := len(d.heldBlocks); n > 0 && uint64(d.heldBlocks[n-1].ExecutionPayload.BlockNumber) <= unsafe.Number {
+			d.asyncGossip.Clear()
+		}
+		d.heldBlocks = nil
+		d.nextActionOK = true
+		d.nextAction = d.timeNow()
+	}
+}
+
 func (d *Sequencer) setLatestHead(head eth.L2BlockRef) {
 	d.latestHead = head
 	if d.latestHeadSet != nil {
@@ -745,6 +812,15 @@ func (d *Sequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	return nil
 }
 
+// SetGossipPauseThreshold configures how far the unsafe chain may run ahead of the cross-safe chain,
+// in number of blocks and in time, before gossip of new unsafe blocks is paused. A zero value disables a threshold.
+func (d *Sequencer) SetGossipPauseThreshold(blocks uint64, lag time.Duration) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.gossipPauseBlocks = blocks
+	d.gossipPauseTime = lag
+}
+
 func (d *Sequencer) OverrideLeader(ctx context.Context) error {
 	return d.conductor.OverrideLeader(ctx)
 }
@@ -671,6 +671,67 @@ func TestSequencerL1TemporaryErrorEvent(t *testing.T) {
 	require.True(t, ok1 == ok2 && sealTargetTime2.After(sealTargetTime1))
 }
 
+func TestSequencerGossipPause(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	deps.cfg.InteropTime = new(uint64)
+	seq.SetGossipPauseThreshold(10, 60*time.Second)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	blockRef := func(num uint64) eth.L2BlockRef {
+		return eth.L2BlockRef{
+			Hash:   common.Hash{0x22, byte(num)},
+			Number: num,
+			Time:   30000 - (100-num)*deps.cfg.BlockTime,
+		}
+	}
+
+	// within the thresholds
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blockRef(100), SafeL2Head: blockRef(91)})
+	_, ok := seq.NextAction()
+	require.True(t, ok)
+	require.False(t, seq.gossipPaused)
+
+	// too many blocks ahead of cross-safe
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blockRef(100), SafeL2Head: blockRef(90)})
+	_, ok = seq.NextAction()
+	require.False(t, ok, "sequencing is paused")
+	require.True(t, seq.gossipPaused)
+
+	// sealed blocks are still processed, but held back from gossip
+	emitter.ExpectOnceType("PayloadProcessEvent")
+	seq.latest = BuildingState{Info: eth.PayloadInfo{ID: eth.PayloadID{0x42}}}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 101}}
+	seq.OnEvent(engine.BuildSealedEvent{Info: eth.PayloadInfo{ID: eth.PayloadID{0x42}}, Envelope: envelope})
+	emitter.AssertExpectations(t)
+	require.Nil(t, deps.asyncGossip.Get())
+	require.Len(t, seq.heldBlocks, 1)
+
+	// cross-safe catching up resumes sequencing, and gossips the held block
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blockRef(100), SafeL2Head: blockRef(95)})
+	next, ok := seq.NextAction()
+	require.True(t, ok)
+	require.Equal(t, testClock.Now(), next)
+	require.False(t, seq.gossipPaused)
+	require.Equal(t, envelope, deps.asyncGossip.Get(), "held block must be gossiped on resume")
+	require.Empty(t, seq.heldBlocks)
+
+	// the time threshold applies as well
+	seq.SetGossipPauseThreshold(0, 10*time.Second)
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blockRef(100), SafeL2Head: blockRef(95)})
+	_, ok = seq.NextAction()
+	require.False(t, ok)
+	require.True(t, seq.gossipPaused)
+}
+
 type sequencerTestDeps struct {
 	cfg              *rollup.Config
 	attribBuilder    *FakeAttributesBuilder
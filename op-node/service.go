@@ -22,11 +22,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop/managed"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 // NewConfig creates a Config from the provided flags or environment variables.
@@ -149,6 +151,17 @@ func NewSupervisorEndpointConfig(ctx *cli.Context) *interop.Config {
 		RPCAddr:          ctx.String(flags.InteropRPCAddr.Name),
 		RPCPort:          ctx.Int(flags.InteropRPCPort.Name),
 		RPCJwtSecretPath: ctx.String(flags.InteropJWTSecret.Name),
+		RPCTLS: optls.CLIConfig{
+			TLSCaCert: ctx.String(flags.InteropTLSCaCert.Name),
+			TLSCert:   ctx.String(flags.InteropTLSCert.Name),
+			TLSKey:    ctx.String(flags.InteropTLSKey.Name),
+			Enabled:   ctx.Bool(flags.InteropTLSEnabled.Name),
+		},
+		EventJournal: managed.JournalConfig{
+			Path:       ctx.Path(flags.InteropEventJournalPath.Name),
+			MaxSizeMB:  ctx.Int(flags.InteropEventJournalMaxSize.Name),
+			MaxBackups: ctx.Int(flags.InteropEventJournalMaxBackups.Name),
+		},
 	}
 }
 
@@ -206,6 +219,9 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
 		RecoverMode:         ctx.Bool(flags.SequencerRecoverMode.Name),
+
+		SequencerGossipPauseBlocks: ctx.Uint64(flags.InteropGossipPauseBlocks.Name),
+		SequencerGossipPauseTime:   ctx.Duration(flags.InteropGossipPauseTime.Name),
 	}
 }
 
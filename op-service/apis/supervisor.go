@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
@@ -20,11 +21,32 @@ type SupervisorAdminAPI interface {
 	Stop(ctx context.Context) error
 	AddL2RPC(ctx context.Context, rpc string, jwtSecret eth.Bytes32) error
 	Rewind(ctx context.Context, chain eth.ChainID, block eth.BlockID) error
+	RebuildLogIndex(ctx context.Context, chain eth.ChainID) error
+	CircuitBreakerStatus(ctx context.Context) ([]types.CircuitBreakerStatus, error)
+	AcknowledgeCircuitBreaker(ctx context.Context, chain eth.ChainID) error
+	PinL1(ctx context.Context, blockHash common.Hash) (eth.L1BlockRef, error)
+	UnpinL1(ctx context.Context) error
+	L1PinStatus(ctx context.Context) (types.L1PinStatus, error)
+}
+
+// SupervisorLoggingAPI changes the logging of the supervisor at runtime.
+type SupervisorLoggingAPI interface {
+	// SetLogLevel sets the log level of the given subsystem, or the global log level if no subsystem is given.
+	SetLogLevel(ctx context.Context, lvl string, subsystem *string) error
+	ListLoggers(ctx context.Context) (LoggersInfo, error)
+}
+
+// LoggersInfo describes the global log level, and the log level of each subsystem.
+type LoggersInfo struct {
+	Level      string                 `json:"level"`
+	Subsystems []oplog.SubsystemLevel `json:"subsystems"`
 }
 
 type SupervisorQueryAPI interface {
 	CheckAccessList(ctx context.Context, inboxEntries []common.Hash,
 		minSafety types.SafetyLevel, executingDescriptor types.ExecutingDescriptor) error
+	// CheckAccessListAt checks the access-list as it was valid in the given block of the executing chain.
+	CheckAccessListAt(ctx context.Context, inboxEntries []common.Hash, chainID eth.ChainID, blockNumber hexutil.Uint64) error
 	CrossDerivedToSource(ctx context.Context, chainID eth.ChainID, derived eth.BlockID) (derivedFrom eth.BlockRef, err error)
 	LocalUnsafe(ctx context.Context, chainID eth.ChainID) (eth.BlockID, error)
 	LocalSafe(ctx context.Context, chainID eth.ChainID) (result types.DerivedIDPair, err error)
@@ -32,6 +54,25 @@ type SupervisorQueryAPI interface {
 	Finalized(ctx context.Context, chainID eth.ChainID) (eth.BlockID, error)
 	FinalizedL1(ctx context.Context) (eth.BlockRef, error)
 	SuperRootAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (eth.SuperRootResponse, error)
+	SuperRootRecordAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (types.SuperRootRecord, error)
+	LatestSuperRootRecord(ctx context.Context) (types.SuperRootRecord, error)
 	SyncStatus(ctx context.Context) (eth.SupervisorSyncStatus, error)
+	// Snapshot returns the heads and pending message counts of all chains, as of a single point in time,
+	// unlike separate queries of each chain, which may observe different points of the processing.
+	Snapshot(ctx context.Context) (types.SupervisorSnapshot, error)
+	CrossSafeConstraints(ctx context.Context) (map[eth.ChainID]types.CrossSafeConstraint, error)
+	ExecutingMessages(ctx context.Context, checksum types.MessageChecksum) ([]types.LogLocation, error)
+	// ChecksumPreimage returns the components that the given message checksum is computed from,
+	// so the checksum can be re-derived without indexing the chains.
+	ChecksumPreimage(ctx context.Context, checksum types.MessageChecksum) (types.ChecksumPreimage, error)
 	AllSafeDerivedAt(ctx context.Context, derivedFrom eth.BlockID) (derived map[eth.ChainID]eth.BlockID, err error)
+	// CausalityGraph returns the graph of the blocks of all chains with a timestamp in [from, to],
+	// with the derivation and message dependencies between them.
+	CausalityGraph(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64) (*types.CausalityGraph, error)
+	// LogProof returns a proof of the log with the given index in the given block, bound to the cross-safe head of the chain.
+	LogProof(ctx context.Context, chainID eth.ChainID, blockNum hexutil.Uint64, logIdx uint32) (*types.LogProof, error)
+	// SubmitAttestation verifies and stores a signed attestation of an L2 block by an authorized external attestor.
+	SubmitAttestation(ctx context.Context, att types.SignedAttestation) (types.AttestationRecord, error)
+	// Attestations returns the stored attestations of the given L2 block.
+	Attestations(ctx context.Context, chainID eth.ChainID, block eth.BlockID) ([]types.AttestationRecord, error)
 }
@@ -1,5 +1,7 @@
 package eth
 
+import "github.com/ethereum/go-ethereum/common"
+
 type SupervisorSyncStatus struct {
 	// MinSyncedL1 is the highest L1 block that has been processed by all supervisor nodes.
 	// This is not the same as the latest L1 block known to the supervisor,
@@ -9,6 +11,9 @@ type SupervisorSyncStatus struct {
 	SafeTimestamp      uint64                                 `json:"safeTimestamp"`
 	FinalizedTimestamp uint64                                 `json:"finalizedTimestamp"`
 	Chains             map[ChainID]*SupervisorChainSyncStatus `json:"chains"`
+	// DependencySetHash is the hash of the dependency set of the supervisor,
+	// that the managed nodes are expected to be configured with.
+	DependencySetHash common.Hash `json:"dependencySetHash"`
 }
 
 // SupervisorChainSyncStatus is the status of a chain as seen by the supervisor.
@@ -20,4 +25,36 @@ type SupervisorChainSyncStatus struct {
 	// Some fault-proof releases may already depend on `safe`, so we keep JSON field name as `safe`.
 	CrossSafe BlockID `json:"safe"`
 	Finalized BlockID `json:"finalized"`
+	// Sequencer is the sequencer leadership of the chain, if tracked through op-conductor.
+	Sequencer *SupervisorSequencerStatus `json:"sequencer,omitempty"`
+	// Nodes are the managed nodes of the chain, with the managed-mode protocol of each node.
+	Nodes []SupervisorManagedNodeStatus `json:"nodes,omitempty"`
+}
+
+// SupervisorManagedNodeStatus is the managed-mode protocol of a node, as reported by the node to the supervisor.
+type SupervisorManagedNodeStatus struct {
+	Endpoint        string `json:"endpoint"`
+	ProtocolVersion uint64 `json:"protocolVersion"`
+	// Capabilities are the optional features of the managed-mode protocol that the node supports.
+	Capabilities []string `json:"capabilities"`
+	// MissingCapabilities are the features known to the supervisor that the node does not support.
+	// The supervisor works around these, e.g. by polling events instead of subscribing to them.
+	MissingCapabilities []string `json:"missingCapabilities,omitempty"`
+	// DependencySetHash is the hash of the dependency set of the node, nil if the node did not report it.
+	DependencySetHash *common.Hash `json:"dependencySetHash,omitempty"`
+	// DependencySetMismatch is true if the node reported a different dependency set than the supervisor.
+	// The supervisor only manages such nodes if explicitly allowed.
+	DependencySetMismatch bool `json:"dependencySetMismatch,omitempty"`
+}
+
+// SupervisorSequencerStatus is the sequencer leadership of a chain, as observed by the supervisor through op-conductor.
+type SupervisorSequencerStatus struct {
+	// LeaderID and LeaderAddr identify the conductor server of the active sequencer.
+	LeaderID   string `json:"leaderID"`
+	LeaderAddr string `json:"leaderAddr"`
+	// ChangedAt is the unix timestamp at which the supervisor observed the leader.
+	ChangedAt uint64 `json:"changedAt"`
+	// FailoverUntil is the unix timestamp until which unsafe forks of the chain are tolerated,
+	// after a leadership change. Equal to ChangedAt if the leader was not preceded by another leader.
+	FailoverUntil uint64 `json:"failoverUntil"`
 }
@@ -2,7 +2,13 @@ package log
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
 )
 
 type LvlSetter interface {
@@ -14,12 +20,17 @@ type LvlSetter interface {
 type DynamicLogHandler struct {
 	h      slog.Handler
 	minLvl *slog.Level // shared with derived dynamic handlers
+
+	subsystems *subsystems // shared with derived dynamic handlers
+	// subsystem is the log level of the subsystem this handler logs for, nil for the root logger.
+	subsystem *subsystemLevel
 }
 
 func NewDynamicLogHandler(lvl slog.Level, h slog.Handler) *DynamicLogHandler {
 	return &DynamicLogHandler{
-		h:      h,
-		minLvl: &lvl,
+		h:          h,
+		minLvl:     &lvl,
+		subsystems: &subsystems{levels: make(map[string]*subsystemLevel)},
 	}
 }
 
@@ -27,12 +38,23 @@ func (d *DynamicLogHandler) SetLogLevel(lvl slog.Level) {
 	*d.minLvl = lvl
 }
 
+// LogLevel returns the root log level.
+func (d *DynamicLogHandler) LogLevel() slog.Level {
+	return *d.minLvl
+}
+
 func (d *DynamicLogHandler) Unwrap() slog.Handler {
 	return d.h
 }
 
 func (d *DynamicLogHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
-	return (lvl >= *d.minLvl) && d.h.Enabled(ctx, lvl)
+	minLvl := *d.minLvl
+	if d.subsystem != nil {
+		if override := d.subsystem.lvl.Load(); override != nil {
+			minLvl = *override
+		}
+	}
+	return (lvl >= minLvl) && d.h.Enabled(ctx, lvl)
 }
 
 func (d *DynamicLogHandler) Handle(ctx context.Context, record slog.Record) error {
@@ -41,14 +63,105 @@ func (d *DynamicLogHandler) Handle(ctx context.Context, record slog.Record) erro
 
 func (d *DynamicLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	return &DynamicLogHandler{
-		h:      d.h.WithAttrs(attrs),
-		minLvl: d.minLvl,
+		h:          d.h.WithAttrs(attrs),
+		minLvl:     d.minLvl,
+		subsystems: d.subsystems,
+		subsystem:  d.subsystem,
 	}
 }
 
 func (d *DynamicLogHandler) WithGroup(name string) slog.Handler {
 	return &DynamicLogHandler{
-		h:      d.h.WithGroup(name),
-		minLvl: d.minLvl,
+		h:          d.h.WithGroup(name),
+		minLvl:     d.minLvl,
+		subsystems: d.subsystems,
+		subsystem:  d.subsystem,
+	}
+}
+
+// SubsystemHandler returns a handler for the named subsystem, derived from this handler.
+// The subsystem logs at the root log level, unless a log level is set for the subsystem with SetSubsystemLogLevel.
+func (d *DynamicLogHandler) SubsystemHandler(name string) *DynamicLogHandler {
+	return &DynamicLogHandler{
+		h:          d.h.WithAttrs([]slog.Attr{slog.String("subsystem", name)}),
+		minLvl:     d.minLvl,
+		subsystems: d.subsystems,
+		subsystem:  d.subsystems.get(name),
+	}
+}
+
+// SetSubsystemLogLevel sets the log level of the named subsystem.
+// If lvl is nil, the subsystem follows the root log level again.
+func (d *DynamicLogHandler) SetSubsystemLogLevel(name string, lvl *slog.Level) error {
+	d.subsystems.mu.Lock()
+	defer d.subsystems.mu.Unlock()
+	s, ok := d.subsystems.levels[name]
+	if !ok {
+		return fmt.Errorf("unknown log subsystem %q", name)
+	}
+	if lvl != nil {
+		v := *lvl
+		lvl = &v
+	}
+	s.lvl.Store(lvl)
+	return nil
+}
+
+// SubsystemLevel describes the current log level of a subsystem.
+type SubsystemLevel struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+	// Overridden is true if the subsystem has its own log level, instead of following the root log level.
+	Overridden bool `json:"overridden"`
+}
+
+// SubsystemLevels lists the known subsystems, sorted by name.
+func (d *DynamicLogHandler) SubsystemLevels() []SubsystemLevel {
+	d.subsystems.mu.Lock()
+	defer d.subsystems.mu.Unlock()
+	out := make([]SubsystemLevel, 0, len(d.subsystems.levels))
+	for name, s := range d.subsystems.levels {
+		lvl := *d.minLvl
+		override := s.lvl.Load()
+		if override != nil {
+			lvl = *override
+		}
+		out = append(out, SubsystemLevel{Name: name, Level: log.LevelString(lvl), Overridden: override != nil})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+type subsystems struct {
+	mu     sync.Mutex
+	levels map[string]*subsystemLevel
+}
+
+func (s *subsystems) get(name string) *subsystemLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lvl, ok := s.levels[name]
+	if !ok {
+		lvl = new(subsystemLevel)
+		s.levels[name] = lvl
+	}
+	return lvl
+}
+
+type subsystemLevel struct {
+	// lvl is nil if the subsystem follows the root log level
+	lvl atomic.Pointer[slog.Level]
+}
+
+// SubsystemLogger derives a logger for the named subsystem.
+// If the logger uses a DynamicLogHandler, the log level of the subsystem can be changed at runtime,
+// independently of the root log level. Otherwise, the logger is returned as-is, with the subsystem attribute added.
+func SubsystemLogger(logger log.Logger, name string) log.Logger {
+	d, ok := logger.Handler().(*DynamicLogHandler)
+	if !ok {
+		return logger.New("subsystem", name)
 	}
+	return log.NewLogger(d.SubsystemHandler(name))
 }
@@ -82,3 +82,45 @@ func (r *testRecorder) Handle(_ context.Context, rec slog.Record) error {
 
 func (r *testRecorder) WithAttrs([]slog.Attr) slog.Handler { return r }
 func (r *testRecorder) WithGroup(string) slog.Handler      { return r }
+
+func TestDynamicLogHandler_Subsystems(t *testing.T) {
+	h := new(testRecorder)
+	d := NewDynamicLogHandler(log.LevelInfo, h)
+	logger := log.NewLogger(d)
+	subA := SubsystemLogger(logger, "a")
+	subB := SubsystemLogger(logger.New("x", 1), "b")
+
+	subA.Debug("debugA0") // n
+	subB.Debug("debugB0") // n
+
+	// increase log level of a single subsystem
+	lvl := log.LevelDebug
+	require.NoError(t, d.SetSubsystemLogLevel("a", &lvl))
+	subA.Debug("debugA1")                  // y
+	subA.New("y", 2).Debug("debugA1-with") // y
+	subB.Debug("debugB1")                  // n
+	logger.Debug("debugRoot1")             // n
+
+	require.Equal(t, []SubsystemLevel{
+		{Name: "a", Level: "debug", Overridden: true},
+		{Name: "b", Level: "info", Overridden: false},
+	}, d.SubsystemLevels())
+
+	// subsystems without their own log level follow the root log level
+	d.SetLogLevel(log.LevelWarn)
+	subA.Info("infoA2") // y
+	subB.Info("infoB2") // n
+
+	// and reset to follow the root log level again
+	require.NoError(t, d.SetSubsystemLogLevel("a", nil))
+	subA.Info("infoA3") // n
+	subA.Warn("warnA3") // y
+
+	require.ErrorContains(t, d.SetSubsystemLogLevel("unknown", &lvl), "unknown log subsystem")
+
+	require.Len(t, h.records, 4)
+	require.Equal(t, h.records[0].Message, "debugA1")
+	require.Equal(t, h.records[1].Message, "debugA1-with")
+	require.Equal(t, h.records[2].Message, "infoA2")
+	require.Equal(t, h.records[3].Message, "warnA3")
+}
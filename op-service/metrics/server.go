@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"net"
+	"net/http"
 	"strconv"
 
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -9,10 +10,30 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func StartServer(r *prometheus.Registry, hostname string, port int) (*httputil.HTTPServer, error) {
+// ServerOption configures the handler of a metrics server.
+type ServerOption func(mux *http.ServeMux)
+
+// WithRegistry serves the metrics of an additional registry on the given path.
+// The metrics are served in the OpenMetrics format to scrapers that accept it.
+func WithRegistry(path string, r *prometheus.Registry) ServerOption {
+	return func(mux *http.ServeMux) {
+		mux.Handle(path, promhttp.HandlerFor(r, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+	}
+}
+
+func StartServer(r *prometheus.Registry, hostname string, port int, opts ...ServerOption) (*httputil.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
-	h := promhttp.InstrumentMetricHandler(
+	var h http.Handler = promhttp.InstrumentMetricHandler(
 		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
 	)
+	if len(opts) > 0 {
+		mux := http.NewServeMux()
+		// All other paths keep serving the metrics of the main registry
+		mux.Handle("/", h)
+		for _, opt := range opts {
+			opt(mux)
+		}
+		h = mux
+	}
 	return httputil.StartHTTPServer(addr, h)
 }
@@ -254,20 +254,42 @@ func (evs *Stream[E]) Serve() (*E, error) {
 	return item, nil
 }
 
+// Delivery is how a Stream delivered an event.
+type Delivery string
+
+const (
+	// DeliveryNotified is an event sent to the active subscription.
+	DeliveryNotified Delivery = "notified"
+	// DeliveryFailed is an event that failed to be sent to the active subscription.
+	// The event is lost, and the subscription is dropped.
+	DeliveryFailed Delivery = "failed"
+	// DeliveryQueued is an event enqueued for later retrieval, as there is no active subscription.
+	DeliveryQueued Delivery = "queued"
+)
+
 // Send will send an event, either by enqueuing it for later retrieval,
 // or by directly sending it to an active subscription.
 func (evs *Stream[E]) Send(ev *E) {
+	evs.Deliver(ev)
+}
+
+// Deliver sends an event like Send, and returns how the event was delivered.
+func (evs *Stream[E]) Deliver(ev *E) Delivery {
 	evs.mu.Lock()
 	defer evs.mu.Unlock()
 	if evs.sub != nil {
 		evs.notify(EventEntry[E]{
 			Data: ev,
 		})
-		return
+		if evs.sub == nil {
+			return DeliveryFailed
+		}
+		return DeliveryNotified
 	}
 	evs.queue = append(evs.queue, ev)
 	if overflow := len(evs.queue) - evs.maxQueueSize; overflow > 0 {
 		evs.log.Warn("Event queue filled up, dropping oldest events", "overflow", overflow)
 		evs.queue = slices.Delete(evs.queue, 0, overflow)
 	}
+	return DeliveryQueued
 }
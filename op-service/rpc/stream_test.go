@@ -78,7 +78,7 @@ func TestStream_Polling(t *testing.T) {
 	x = nil
 
 	// can send more, while not everything has been read yet.
-	api.events.Send(&Foo{Message: "hello charlie"})
+	require.Equal(t, DeliveryQueued, api.events.Deliver(&Foo{Message: "hello charlie"}))
 
 	require.NoError(t, cl.Call(&x, "custom_pullFoo"))
 	require.Equal(t, "hello bob", x.Message)
@@ -136,7 +136,7 @@ func TestStream_Subscription(t *testing.T) {
 		"custom", &ClientWrapper{cl: cl}, dest, "foo")
 	require.NoError(t, err)
 
-	api.events.Send(&Foo{Message: "hello alice"})
+	require.Equal(t, DeliveryNotified, api.events.Deliver(&Foo{Message: "hello alice"}))
 	api.events.Send(&Foo{Message: "hello bob"})
 	select {
 	case x := <-dest:
@@ -166,7 +166,7 @@ func TestStream_Subscription(t *testing.T) {
 	require.False(t, ok, "dest is closed")
 
 	// Send another event. This one will be buffered, because the subscription was stopped.
-	api.events.Send(&Foo{Message: "hello charlie"})
+	require.Equal(t, DeliveryQueued, api.events.Deliver(&Foo{Message: "hello charlie"}))
 
 	require.NoError(t, cl.Call(&x, "custom_pullFoo"))
 	require.Equal(t, "hello charlie", x.Message)
@@ -9,6 +9,12 @@ import (
 )
 
 func SubscribeRPC[T any](ctx context.Context, logger log.Logger, feed *event.FeedOf[T]) (*gethrpc.Subscription, error) {
+	return SubscribeRPCFiltered(ctx, logger, feed, nil)
+}
+
+// SubscribeRPCFiltered is like SubscribeRPC, but only notifies the subscriber of the values that match.
+// A nil match function matches every value.
+func SubscribeRPCFiltered[T any](ctx context.Context, logger log.Logger, feed *event.FeedOf[T], match func(T) bool) (*gethrpc.Subscription, error) {
 	notifier, supported := gethrpc.NotifierFromContext(ctx)
 	if !supported {
 		return &gethrpc.Subscription{}, gethrpc.ErrNotificationsUnsupported
@@ -26,6 +32,9 @@ func SubscribeRPC[T any](ctx context.Context, logger log.Logger, feed *event.Fee
 		for {
 			select {
 			case v := <-ch:
+				if match != nil && !match(v) {
+					continue
+				}
 				if err := notifier.Notify(rpcSub.ID, v); err != nil {
 					logger.Warn("Failed to notify RPC subscription", "err", err)
 					return
@@ -3,11 +3,13 @@ package sources
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -56,11 +58,67 @@ func (cl *SupervisorClient) Rewind(ctx context.Context, chain eth.ChainID, block
 	return cl.client.CallContext(ctx, nil, "admin_rewind", chain, block)
 }
 
+// RebuildLogIndex clears the log index of the given chain, which the supervisor then rebuilds in the background.
+func (cl *SupervisorClient) RebuildLogIndex(ctx context.Context, chain eth.ChainID) error {
+	return cl.client.CallContext(ctx, nil, "admin_rebuildLogIndex", chain)
+}
+
+// CircuitBreakerStatus returns the circuit breaker status of every chain.
+func (cl *SupervisorClient) CircuitBreakerStatus(ctx context.Context) ([]types.CircuitBreakerStatus, error) {
+	var result []types.CircuitBreakerStatus
+	err := cl.client.CallContext(ctx, &result, "admin_circuitBreakerStatus")
+	return result, err
+}
+
+// AcknowledgeCircuitBreaker resumes the cross-safe promotion of a chain that was paused by the circuit breaker.
+func (cl *SupervisorClient) AcknowledgeCircuitBreaker(ctx context.Context, chain eth.ChainID) error {
+	return cl.client.CallContext(ctx, nil, "admin_acknowledgeCircuitBreaker", chain)
+}
+
+// PinL1 freezes the view of the supervisor on the L1 chain at the given block, until unpinned.
+func (cl *SupervisorClient) PinL1(ctx context.Context, blockHash common.Hash) (eth.L1BlockRef, error) {
+	var result eth.L1BlockRef
+	err := cl.client.CallContext(ctx, &result, "admin_pinL1", blockHash)
+	return result, err
+}
+
+// UnpinL1 resumes the view of the supervisor on the L1 chain beyond the pinned block.
+func (cl *SupervisorClient) UnpinL1(ctx context.Context) error {
+	return cl.client.CallContext(ctx, nil, "admin_unpinL1")
+}
+
+// L1PinStatus returns whether the view of the supervisor on the L1 chain is pinned, and to which block.
+func (cl *SupervisorClient) L1PinStatus(ctx context.Context) (types.L1PinStatus, error) {
+	var result types.L1PinStatus
+	err := cl.client.CallContext(ctx, &result, "admin_l1PinStatus")
+	return result, err
+}
+
+func (cl *SupervisorClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
+	return cl.client.CallContext(ctx, nil, "admin_setLogLevel", log.LevelString(lvl))
+}
+
+func (cl *SupervisorClient) SetSubsystemLogLevel(ctx context.Context, subsystem string, lvl slog.Level) error {
+	return cl.client.CallContext(ctx, nil, "admin_setLogLevel", log.LevelString(lvl), subsystem)
+}
+
+func (cl *SupervisorClient) ListLoggers(ctx context.Context) (apis.LoggersInfo, error) {
+	var result apis.LoggersInfo
+	err := cl.client.CallContext(ctx, &result, "admin_listLoggers")
+	return result, err
+}
+
 func (cl *SupervisorClient) CheckAccessList(ctx context.Context, inboxEntries []common.Hash,
 	minSafety types.SafetyLevel, executingDescriptor types.ExecutingDescriptor) error {
 	return cl.client.CallContext(ctx, nil, "supervisor_checkAccessList", inboxEntries, minSafety, executingDescriptor)
 }
 
+// CheckAccessListAt checks the access-list as it was valid in the given block of the executing chain.
+func (cl *SupervisorClient) CheckAccessListAt(ctx context.Context, inboxEntries []common.Hash,
+	chainID eth.ChainID, blockNumber hexutil.Uint64) error {
+	return cl.client.CallContext(ctx, nil, "supervisor_checkAccessListAt", inboxEntries, chainID, blockNumber)
+}
+
 func (cl *SupervisorClient) CrossDerivedToSource(ctx context.Context, chainID eth.ChainID, derived eth.BlockID) (derivedFrom eth.BlockRef, err error) {
 	err = cl.client.CallContext(ctx, &derivedFrom, "supervisor_crossDerivedToSource", chainID, derived)
 	return derivedFrom, err
@@ -113,6 +171,39 @@ func (cl *SupervisorClient) SuperRootAtTimestamp(ctx context.Context, timestamp
 	return result, err
 }
 
+// SuperRootRecordAtTimestamp returns the super root that the supervisor stored at exactly the specified timestamp.
+// Returns ethereum.NotFound if no super root was stored at the timestamp.
+func (cl *SupervisorClient) SuperRootRecordAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (result types.SuperRootRecord, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_superRootRecordAtTimestamp", timestamp)
+	if isNotFound(err) {
+		err = fmt.Errorf("%w: %v", ethereum.NotFound, err.Error())
+	}
+	return result, err
+}
+
+// LatestSuperRootRecord returns the last super root that the supervisor stored.
+// Returns ethereum.NotFound if no super root was stored yet.
+func (cl *SupervisorClient) LatestSuperRootRecord(ctx context.Context) (result types.SuperRootRecord, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_latestSuperRootRecord")
+	if isNotFound(err) {
+		err = fmt.Errorf("%w: %v", ethereum.NotFound, err.Error())
+	}
+	return result, err
+}
+
+// SubscribeSuperRoots subscribes to every super root that the supervisor stores.
+// This requires a websocket connection to the supervisor.
+func (cl *SupervisorClient) SubscribeSuperRoots(ctx context.Context, dest chan<- types.SuperRootRecord) (ethereum.Subscription, error) {
+	return cl.client.Subscribe(ctx, "supervisor", dest, "superRoots")
+}
+
+// SubscribeEvents subscribes to the supervisor events that match the filter.
+// The filter is evaluated by the supervisor, and an invalid filter is rejected when subscribing.
+// This requires a websocket connection to the supervisor.
+func (cl *SupervisorClient) SubscribeEvents(ctx context.Context, filter types.EventFilter, dest chan<- types.SupervisorEvent) (ethereum.Subscription, error) {
+	return cl.client.Subscribe(ctx, "supervisor", dest, "events", filter)
+}
+
 func (cl *SupervisorClient) AllSafeDerivedAt(ctx context.Context, derivedFrom eth.BlockID) (result map[eth.ChainID]eth.BlockID, err error) {
 	err = cl.client.CallContext(ctx, &result, "supervisor_allSafeDerivedAt", derivedFrom)
 	return result, err
@@ -123,6 +214,56 @@ func (cl *SupervisorClient) SyncStatus(ctx context.Context) (result eth.Supervis
 	return result, err
 }
 
+// CrossSafeConstraints returns, for each chain, which dependency is holding back its cross-safe progress, and by how much.
+func (cl *SupervisorClient) CrossSafeConstraints(ctx context.Context) (result map[eth.ChainID]types.CrossSafeConstraint, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_crossSafeConstraints")
+	return result, err
+}
+
+// ExecutingMessages returns the logs, across all chains, that execute the message with the given checksum.
+func (cl *SupervisorClient) ExecutingMessages(ctx context.Context, checksum types.MessageChecksum) (result []types.LogLocation, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_executingMessages", checksum)
+	return result, err
+}
+
+// Snapshot returns the heads and pending message counts of all chains, as of a single point in time.
+func (cl *SupervisorClient) Snapshot(ctx context.Context) (result types.SupervisorSnapshot, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_snapshot")
+	return result, err
+}
+
+// ChecksumPreimage returns the components that the given message checksum is computed from.
+func (cl *SupervisorClient) ChecksumPreimage(ctx context.Context, checksum types.MessageChecksum) (result types.ChecksumPreimage, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_checksumPreimage", checksum)
+	return result, err
+}
+
+// CausalityGraph returns the graph of the blocks of all chains with a timestamp in [from, to],
+// with the derivation and message dependencies between them.
+func (cl *SupervisorClient) CausalityGraph(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64) (result *types.CausalityGraph, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_causalityGraph", from, to)
+	return result, err
+}
+
+// LogProof returns a proof of the log with the given index in the given block, bound to the cross-safe head of the chain.
+// The proof is not verified, see types.LogProof.Verify.
+func (cl *SupervisorClient) LogProof(ctx context.Context, chainID eth.ChainID, blockNum hexutil.Uint64, logIdx uint32) (result *types.LogProof, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_logProof", chainID, blockNum, logIdx)
+	return result, err
+}
+
+// SubmitAttestation verifies and stores a signed attestation of an L2 block by an authorized external attestor.
+func (cl *SupervisorClient) SubmitAttestation(ctx context.Context, att types.SignedAttestation) (result types.AttestationRecord, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_submitAttestation", att)
+	return result, err
+}
+
+// Attestations returns the stored attestations of the given L2 block.
+func (cl *SupervisorClient) Attestations(ctx context.Context, chainID eth.ChainID, block eth.BlockID) (result []types.AttestationRecord, err error) {
+	err = cl.client.CallContext(ctx, &result, "supervisor_attestations", chainID, block)
+	return result, err
+}
+
 func (cl *SupervisorClient) Close() {
 	cl.client.Close()
 }
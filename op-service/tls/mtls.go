@@ -0,0 +1,139 @@
+package tls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+)
+
+// NewServerTLSConfig creates the TLS config of a server that requires clients to authenticate
+// with a certificate issued by the CA of the given config.
+// The server certificate and key, as well as the CA certificate, are reloaded when they are rotated on disk.
+// The returned stop function stops watching the certificate files.
+func NewServerTLSConfig(logger log.Logger, cfg CLIConfig) (*tls.Config, func(), error) {
+	cm, err := watchKeyPair(logger, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	ca := &caPool{log: logger, path: cfg.TLSCaCert}
+	if _, err := ca.get(); err != nil {
+		cm.Stop()
+		return nil, nil, err
+	}
+	base := &tls.Config{
+		MinVersion:     tls.VersionTLS13,
+		GetCertificate: cm.GetCertificate,
+		ClientAuth:     tls.RequireAndVerifyClientCert,
+	}
+	out := base.Clone()
+	// The client CAs are resolved for every handshake, so a rotated CA takes effect without a restart.
+	out.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		pool, err := ca.get()
+		if err != nil {
+			return nil, err
+		}
+		conf := base.Clone()
+		conf.ClientCAs = pool
+		return conf, nil
+	}
+	return out, cm.Stop, nil
+}
+
+// NewClientTLSConfig creates the TLS config of a client that authenticates with a certificate,
+// and that verifies the server certificate against the CA of the given config.
+// The client certificate and key are reloaded when they are rotated on disk. The CA certificate is read once.
+// The returned stop function stops watching the certificate files.
+func NewClientTLSConfig(logger log.Logger, cfg CLIConfig) (*tls.Config, func(), error) {
+	cm, err := watchKeyPair(logger, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	ca := &caPool{log: logger, path: cfg.TLSCaCert}
+	pool, err := ca.get()
+	if err != nil {
+		cm.Stop()
+		return nil, nil, err
+	}
+	return &tls.Config{
+		MinVersion:           tls.VersionTLS13,
+		RootCAs:              pool,
+		GetClientCertificate: cm.GetClientCertificate,
+	}, cm.Stop, nil
+}
+
+// watchKeyPair checks that the certificate and key of the config can be loaded, and then watches them for changes.
+func watchKeyPair(logger log.Logger, cfg CLIConfig) (*certman.CertMan, error) {
+	if cfg.TLSCert == "" || cfg.TLSKey == "" || cfg.TLSCaCert == "" {
+		return nil, errors.New("tls cert, key and ca must all be set")
+	}
+	// certman only logs a failure to load the files, so check them first.
+	if _, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey); err != nil {
+		return nil, fmt.Errorf("failed to load tls cert and key: %w", err)
+	}
+	cm, err := certman.New(logger, cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tls cert manager: %w", err)
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, fmt.Errorf("failed to watch tls cert and key: %w", err)
+	}
+	return cm, nil
+}
+
+// caPool is a CA certificate pool that is reloaded from disk when the file is modified.
+type caPool struct {
+	log  log.Logger
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	pool    *x509.CertPool
+}
+
+// get returns the CA certificate pool, reloading it first if the file was modified.
+// If a modified file cannot be loaded, the previously loaded pool continues to be used.
+func (c *caPool) get() (*x509.CertPool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pool, modTime, err := c.load()
+	if err != nil {
+		if c.pool == nil {
+			return nil, err
+		}
+		c.log.Error("Failed to reload tls ca, continuing with previous ca", "path", c.path, "err", err)
+		return c.pool, nil
+	}
+	if pool != nil {
+		c.pool, c.modTime = pool, modTime
+		c.log.Info("Loaded tls ca", "path", c.path)
+	}
+	return c.pool, nil
+}
+
+// load reads the CA certificates, or returns a nil pool if the file did not change since it was last loaded.
+func (c *caPool) load() (*x509.CertPool, time.Time, error) {
+	info, err := os.Stat(c.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to stat tls ca: %w", err)
+	}
+	if c.pool != nil && info.ModTime().Equal(c.modTime) {
+		return nil, time.Time{}, nil
+	}
+	data, err := os.ReadFile(c.path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read tls ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, time.Time{}, fmt.Errorf("no certificates found in tls ca %q", c.path)
+	}
+	return pool, info.ModTime(), nil
+}
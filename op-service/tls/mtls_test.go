@@ -0,0 +1,187 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate and key, signed by the CA, to the given directory.
+func (ca *testCA) issue(t *testing.T, dir string, name string, usage x509.ExtKeyUsage) (certPath, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPath = filepath.Join(dir, name+".crt")
+	keyPath = filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certPath, keyPath
+}
+
+// serve accepts TLS connections and completes the handshake of each of them.
+func serve(t *testing.T, cfg *tls.Config) string {
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// connect returns the error of the handshake, including a rejection of the client certificate by the server.
+func connect(addr string, cfg *tls.Config) error {
+	conn, err := tls.Dial("tcp", addr, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// With TLS 1.3 a rejected client certificate is only reported when reading from the connection.
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	logger := log.NewLogger(log.DiscardHandler())
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca")
+	caPath := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caPath, ca.pem, 0o600))
+	serverCert, serverKey := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "client", x509.ExtKeyUsageClientAuth)
+
+	serverCfg, stopServer, err := NewServerTLSConfig(logger, CLIConfig{TLSCaCert: caPath, TLSCert: serverCert, TLSKey: serverKey, Enabled: true})
+	require.NoError(t, err)
+	t.Cleanup(stopServer)
+	addr := serve(t, serverCfg)
+
+	clientCfg, stopClient, err := NewClientTLSConfig(logger, CLIConfig{TLSCaCert: caPath, TLSCert: clientCert, TLSKey: clientKey, Enabled: true})
+	require.NoError(t, err)
+	t.Cleanup(stopClient)
+	require.NoError(t, connect(addr, clientCfg))
+
+	t.Run("without client certificate", func(t *testing.T) {
+		cfg := clientCfg.Clone()
+		cfg.GetClientCertificate = nil
+		require.Error(t, connect(addr, cfg))
+	})
+
+	t.Run("client certificate of another CA", func(t *testing.T) {
+		other := newTestCA(t, "other")
+		otherDir := t.TempDir()
+		otherCert, otherKey := other.issue(t, otherDir, "client", x509.ExtKeyUsageClientAuth)
+		pair, err := tls.LoadX509KeyPair(otherCert, otherKey)
+		require.NoError(t, err)
+		cfg := clientCfg.Clone()
+		cfg.GetClientCertificate = nil
+		cfg.Certificates = []tls.Certificate{pair}
+		require.Error(t, connect(addr, cfg))
+	})
+
+	t.Run("rotated CA", func(t *testing.T) {
+		rotated := newTestCA(t, "rotated")
+		rotatedDir := t.TempDir()
+		rotatedCert, rotatedKey := rotated.issue(t, rotatedDir, "client", x509.ExtKeyUsageClientAuth)
+		pair, err := tls.LoadX509KeyPair(rotatedCert, rotatedKey)
+		require.NoError(t, err)
+		cfg := clientCfg.Clone()
+		cfg.GetClientCertificate = nil
+		cfg.Certificates = []tls.Certificate{pair}
+		require.Error(t, connect(addr, cfg))
+
+		// Trust both CAs, and make sure the modification is noticed regardless of the file system time resolution.
+		require.NoError(t, os.WriteFile(caPath, append(append([]byte{}, ca.pem...), rotated.pem...), 0o600))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(caPath, later, later))
+		require.NoError(t, connect(addr, cfg))
+		require.NoError(t, connect(addr, clientCfg))
+
+		// An invalid CA file is ignored, the previous CA continues to be used.
+		require.NoError(t, os.WriteFile(caPath, []byte("invalid"), 0o600))
+		later = later.Add(time.Minute)
+		require.NoError(t, os.Chtimes(caPath, later, later))
+		require.NoError(t, connect(addr, cfg))
+	})
+}
+
+func TestMutualTLSInvalidFiles(t *testing.T) {
+	logger := log.NewLogger(log.DiscardHandler())
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca")
+	caPath := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caPath, ca.pem, 0o600))
+	cert, key := ca.issue(t, dir, "server", x509.ExtKeyUsageServerAuth)
+
+	_, _, err := NewServerTLSConfig(logger, CLIConfig{TLSCaCert: caPath, TLSCert: cert, Enabled: true})
+	require.Error(t, err)
+	_, _, err = NewServerTLSConfig(logger, CLIConfig{TLSCaCert: caPath, TLSCert: cert, TLSKey: filepath.Join(dir, "missing.key"), Enabled: true})
+	require.Error(t, err)
+	_, _, err = NewServerTLSConfig(logger, CLIConfig{TLSCaCert: cert + ".missing", TLSCert: cert, TLSKey: key, Enabled: true})
+	require.Error(t, err)
+	_, _, err = NewClientTLSConfig(logger, CLIConfig{TLSCaCert: key, TLSCert: cert, TLSKey: key, Enabled: true})
+	require.Error(t, err)
+}
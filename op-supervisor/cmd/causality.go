@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var (
+	causalityRPCFlag = &cli.StringFlag{
+		Name:  "rpc",
+		Usage: "RPC endpoint of the supervisor to export the graph from",
+		Value: "http://localhost:8545",
+	}
+	causalityFromFlag = &cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "First L2 block timestamp of the time range",
+		Required: true,
+	}
+	causalityToFlag = &cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "Last L2 block timestamp of the time range",
+		Required: true,
+	}
+	causalityFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format, one of: json, dot (GraphViz)",
+		Value: "dot",
+	}
+	causalityOutFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write the graph to, stdout if empty",
+	}
+)
+
+var causalityGraphCommand = &cli.Command{
+	Name:  "causality-graph",
+	Usage: "Exports the causality graph of the blocks in a time range from a running supervisor",
+	Description: "Exports the blocks of all chains with a timestamp in the time range, " +
+		"with edges from the L1 blocks they were derived from, and from the blocks of the messages they execute. " +
+		"Render the dot format with e.g. `dot -Tsvg`.",
+	Flags: []cli.Flag{causalityRPCFlag, causalityFromFlag, causalityToFlag, causalityFormatFlag, causalityOutFlag},
+	Action: func(ctx *cli.Context) error {
+		format := ctx.String(causalityFormatFlag.Name)
+		if format != "json" && format != "dot" {
+			return fmt.Errorf("unknown format %q", format)
+		}
+		logger := oplog.NewLogger(os.Stderr, oplog.DefaultCLIConfig())
+		rpc, err := client.NewRPC(ctx.Context, logger, ctx.String(causalityRPCFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial supervisor: %w", err)
+		}
+		defer rpc.Close()
+		graph, err := sources.NewSupervisorClient(rpc).CausalityGraph(ctx.Context,
+			hexutil.Uint64(ctx.Uint64(causalityFromFlag.Name)), hexutil.Uint64(ctx.Uint64(causalityToFlag.Name)))
+		if err != nil {
+			return fmt.Errorf("failed to get causality graph: %w", err)
+		}
+		if graph.Truncated {
+			logger.Warn("The time range has too many blocks, only the oldest blocks of some chains are included")
+		}
+
+		var out io.Writer = os.Stdout
+		if path := ctx.String(causalityOutFlag.Name); path != "" {
+			f, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		return writeCausalityGraph(out, graph, format)
+	},
+}
+
+func writeCausalityGraph(out io.Writer, graph *types.CausalityGraph, format string) error {
+	if format == "dot" {
+		_, err := io.WriteString(out, graph.DOT())
+		return err
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(graph)
+}
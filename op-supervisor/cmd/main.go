@@ -48,6 +48,8 @@ func run(ctx context.Context, args []string, fn supervisor.MainFn) error {
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		causalityGraphCommand,
+		migrateCommand,
 	}
 	return app.RunContext(ctx, args)
 }
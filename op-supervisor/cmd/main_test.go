@@ -6,14 +6,18 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/superchain"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
 )
@@ -84,6 +88,129 @@ func TestMockRun(t *testing.T) {
 	})
 }
 
+func TestCacheSizes(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultCacheConfig(), cfg.Caches)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--cache.receipts=5", "--cache.block-refs=0", "--cache.access-checks=7",
+			"--cache.chain-sizes=10:receipts=1000;access_checks=1,8453:block_refs=3"))
+		require.Equal(t, config.CacheSizes{Receipts: 5, BlockRefs: 0, AccessChecks: 7}, cfg.Caches.Default)
+		require.Equal(t, config.CacheSizes{Receipts: 1000, BlockRefs: 0, AccessChecks: 1},
+			cfg.Caches.ForChain(eth.ChainIDFromUInt64(10)))
+		require.Equal(t, config.CacheSizes{Receipts: 5, BlockRefs: 3, AccessChecks: 7},
+			cfg.Caches.ForChain(eth.ChainIDFromUInt64(8453)))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid cache.chain-sizes", addRequiredArgs("--cache.chain-sizes=10:headers=1"))
+		verifyArgsInvalid(t, "cache size must not be negative", addRequiredArgs("--cache.receipts=-1"))
+	})
+}
+
+func TestLogDBCompression(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultLogDBCompressionConfig(), cfg.LogDBCompression)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--logdb.compression=zstd", "--logdb.chain-compression=10:snappy,8453:none"))
+		require.Equal(t, entrydb.CompressionZstd, cfg.LogDBCompression.Default)
+		require.Equal(t, entrydb.CompressionSnappy, cfg.LogDBCompression.ForChain(eth.ChainIDFromUInt64(10)))
+		require.Equal(t, entrydb.CompressionNone, cfg.LogDBCompression.ForChain(eth.ChainIDFromUInt64(8453)))
+		require.Equal(t, entrydb.CompressionZstd, cfg.LogDBCompression.ForChain(eth.ChainIDFromUInt64(11)))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown compression", addRequiredArgs("--logdb.compression=lz4"))
+		verifyArgsInvalid(t, "invalid logdb.chain-compression", addRequiredArgs("--logdb.chain-compression=10:lz4"))
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultCircuitBreakerConfig(), cfg.CircuitBreaker)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--circuit-breaker.enabled", "--circuit-breaker.window=1h", "--circuit-breaker.max-invalidations=3",
+			"--circuit-breaker.max-replacements=0", "--circuit-breaker.max-checksum-failure-rate=0.2",
+			"--circuit-breaker.min-checksum-checks=50"))
+		require.Equal(t, config.CircuitBreakerConfig{
+			Enabled:                true,
+			Window:                 time.Hour,
+			MaxInvalidations:       3,
+			MaxReplacements:        0,
+			MaxChecksumFailureRate: 0.2,
+			MinChecksumChecks:      50,
+		}, cfg.CircuitBreaker)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "circuit breaker window must be positive",
+			addRequiredArgs("--circuit-breaker.enabled", "--circuit-breaker.window=0s"))
+	})
+}
+
+func TestConductor(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultConductorConfig(), cfg.Conductor)
+		require.False(t, cfg.Conductor.Enabled())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--conductor.rpcs=10=http://conductor-a:8547,11=http://conductor-b:8547",
+			"--conductor.poll-interval=500ms", "--conductor.failover-window=1m"))
+		require.Equal(t, config.ConductorConfig{
+			Endpoints: map[eth.ChainID]string{
+				eth.ChainIDFromUInt64(10): "http://conductor-a:8547",
+				eth.ChainIDFromUInt64(11): "http://conductor-b:8547",
+			},
+			PollInterval:   500 * time.Millisecond,
+			FailoverWindow: time.Minute,
+		}, cfg.Conductor)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid conductor.rpcs", addRequiredArgs("--conductor.rpcs=http://conductor:8547"))
+		verifyArgsInvalid(t, "conductor poll interval must be positive",
+			addRequiredArgs("--conductor.rpcs=10=http://conductor:8547", "--conductor.poll-interval=0s"))
+	})
+}
+
+func TestAttestations(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultAttestationConfig(), cfg.Attestations)
+		require.False(t, cfg.Attestations.Enabled())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(
+			"--attestation.attestors=0x0000000000000000000000000000000000000001,0x0000000000000000000000000000000000000002",
+			"--attestation.quorum=2", "--attestation.retention=100"))
+		require.Equal(t, config.AttestationConfig{
+			Attestors: []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")},
+			Quorum:    2,
+			Retention: 100,
+		}, cfg.Attestations)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid attestation.attestors", addRequiredArgs("--attestation.attestors=0x01"))
+		verifyArgsInvalid(t, "attestation quorum must be between 0 and the number of attestors",
+			addRequiredArgs("--attestation.attestors=0x0000000000000000000000000000000000000001", "--attestation.quorum=2"))
+	})
+}
+
 func TestConfig(t *testing.T) {
 	t.Run("SingleNetwork", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExceptConfig(
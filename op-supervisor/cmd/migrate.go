@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-supervisor/flags"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/schema"
+)
+
+var migrateDryRunFlag = &cli.BoolFlag{
+	Name:  "dry-run",
+	Usage: "Apply and validate the pending migrations on a copy of the data, without replacing the data",
+}
+
+var migrateCommand = &cli.Command{
+	Name:  "migrate",
+	Usage: "Migrates the databases of a data directory to the current schema version",
+	Description: "The supervisor migrates its data directory on startup. " +
+		"This command migrates it ahead of a restart, or validates the migrations with --dry-run. " +
+		"The data before the migration is kept in the backups directory of the data directory.",
+	Flags: []cli.Flag{flags.DataDirFlag, migrateDryRunFlag},
+	Action: func(ctx *cli.Context) error {
+		dir := ctx.Path(flags.DataDirFlag.Name)
+		if dir == "" {
+			return fmt.Errorf("missing --%s", flags.DataDirFlag.Name)
+		}
+		logger := oplog.NewLogger(os.Stderr, oplog.DefaultCLIConfig())
+		migrator, err := schema.NewMigrator(logger, dir, schema.Migrations)
+		if err != nil {
+			return err
+		}
+		result, err := migrator.Migrate(ctx.Context, ctx.Bool(migrateDryRunFlag.Name))
+		if err != nil {
+			return err
+		}
+		if result.From == result.To {
+			logger.Info("Data directory is up to date", "version", result.To)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrInvalidAttestor             = errors.New("invalid attestor address")
+	ErrInvalidAttestationQuorum    = errors.New("attestation quorum must be between 0 and the number of attestors")
+	ErrInvalidAttestationRetention = errors.New("attestation retention must be positive")
+)
+
+// AttestationConfig configures the ingestion of signed attestations of L2 blocks from external attestors,
+// such as alt-DA attestors or sequencer committees.
+// Ingestion is disabled if there are no attestors.
+type AttestationConfig struct {
+	// Attestors are the addresses of the authorized attestors. Attestations signed by other keys are rejected.
+	Attestors []common.Address
+
+	// Quorum is the number of distinct attestors that must attest a local-safe block,
+	// before it can be promoted to cross-safe. Attestations are only stored if 0.
+	Quorum int
+
+	// Retention is the number of attested blocks of each chain to keep attestations of.
+	Retention int
+}
+
+func DefaultAttestationConfig() AttestationConfig {
+	return AttestationConfig{
+		Quorum:    0,
+		Retention: 10_000,
+	}
+}
+
+// Enabled returns whether attestations are ingested.
+func (c *AttestationConfig) Enabled() bool {
+	return len(c.Attestors) > 0
+}
+
+func (c *AttestationConfig) Check() error {
+	if !c.Enabled() {
+		if c.Quorum != 0 {
+			return ErrInvalidAttestationQuorum
+		}
+		return nil
+	}
+	var result error
+	seen := make(map[common.Address]struct{}, len(c.Attestors))
+	for _, addr := range c.Attestors {
+		if addr == (common.Address{}) {
+			result = errors.Join(result, fmt.Errorf("%w: zero address", ErrInvalidAttestor))
+		}
+		if _, ok := seen[addr]; ok {
+			result = errors.Join(result, fmt.Errorf("%w: duplicate %s", ErrInvalidAttestor, addr))
+		}
+		seen[addr] = struct{}{}
+	}
+	if c.Quorum < 0 || c.Quorum > len(c.Attestors) {
+		result = errors.Join(result, ErrInvalidAttestationQuorum)
+	}
+	if c.Retention <= 0 {
+		result = errors.Join(result, ErrInvalidAttestationRetention)
+	}
+	return result
+}
+
+// ParseAttestors parses the hex addresses of attestors.
+func ParseAttestors(addrs []string) ([]common.Address, error) {
+	var out []common.Address
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidAttestor, addr)
+		}
+		out = append(out, common.HexToAddress(addr))
+	}
+	return out, nil
+}
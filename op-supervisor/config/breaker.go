@@ -0,0 +1,63 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidBreakerWindow    = errors.New("circuit breaker window must be positive")
+	ErrInvalidBreakerThreshold = errors.New("circuit breaker threshold must not be negative")
+	ErrInvalidBreakerRate      = errors.New("circuit breaker checksum failure rate must be between 0 and 1")
+)
+
+// CircuitBreakerConfig configures the per-chain circuit breaker,
+// which pauses the cross-safe promotion of a chain when anomalies are detected,
+// until the operator acknowledges the anomaly.
+// Each anomaly detector is disabled if its threshold is 0.
+type CircuitBreakerConfig struct {
+	Enabled bool
+
+	// Window is the duration over which anomalies are counted.
+	Window time.Duration
+
+	// MaxInvalidations is the number of local-safe invalidations of a chain allowed within the window.
+	MaxInvalidations int
+	// MaxReplacements is the number of block replacements of a chain allowed within the window.
+	MaxReplacements int
+
+	// MaxChecksumFailureRate is the fraction of RPC-verified access checks of a chain that may fail within the window.
+	// Only applies if RPC verification of access checks is enabled.
+	MaxChecksumFailureRate float64
+	// MinChecksumChecks is the number of RPC-verified access checks within the window,
+	// before the checksum failure rate is considered.
+	MinChecksumChecks int
+}
+
+func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
+	return CircuitBreakerConfig{
+		Enabled:                false,
+		Window:                 10 * time.Minute,
+		MaxInvalidations:       5,
+		MaxReplacements:        5,
+		MaxChecksumFailureRate: 0.1,
+		MinChecksumChecks:      20,
+	}
+}
+
+func (c *CircuitBreakerConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	var result error
+	if c.Window <= 0 {
+		result = errors.Join(result, ErrInvalidBreakerWindow)
+	}
+	if c.MaxInvalidations < 0 || c.MaxReplacements < 0 || c.MinChecksumChecks < 0 {
+		result = errors.Join(result, ErrInvalidBreakerThreshold)
+	}
+	if c.MaxChecksumFailureRate < 0 || c.MaxChecksumFailureRate > 1 {
+		result = errors.Join(result, ErrInvalidBreakerRate)
+	}
+	return result
+}
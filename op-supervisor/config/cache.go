@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrInvalidCacheSize  = errors.New("cache size must not be negative")
+	ErrInvalidCacheSpec  = errors.New("invalid per-chain cache size")
+	ErrUnknownCacheLabel = errors.New("unknown cache")
+)
+
+// Cache labels, as used in the per-chain cache configuration and the cache metrics.
+const (
+	ReceiptsCache     = "receipts"
+	BlockRefsCache    = "block_refs"
+	AccessChecksCache = "access_checks"
+)
+
+// CacheSizes configures the capacity, in number of entries, of the in-memory caches kept for a chain.
+// A size of 0 disables the cache.
+type CacheSizes struct {
+	// Receipts caches the receipts of blocks, by block hash.
+	Receipts int
+	// BlockRefs caches the canonical block refs, by block number.
+	BlockRefs int
+	// AccessChecks caches the block that includes an initiating message, as found when checking access-lists.
+	AccessChecks int
+}
+
+func DefaultCacheSizes() CacheSizes {
+	return CacheSizes{
+		Receipts:     100,
+		BlockRefs:    1000,
+		AccessChecks: 10_000,
+	}
+}
+
+func (c CacheSizes) Check() error {
+	if c.Receipts < 0 || c.BlockRefs < 0 || c.AccessChecks < 0 {
+		return ErrInvalidCacheSize
+	}
+	return nil
+}
+
+// set sets the size of the cache with the given label.
+func (c *CacheSizes) set(label string, size int) error {
+	switch label {
+	case ReceiptsCache:
+		c.Receipts = size
+	case BlockRefsCache:
+		c.BlockRefs = size
+	case AccessChecksCache:
+		c.AccessChecks = size
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownCacheLabel, label)
+	}
+	return nil
+}
+
+// CacheConfig configures the cache sizes of each chain.
+type CacheConfig struct {
+	// Default applies to chains without overrides.
+	Default CacheSizes
+	// Chains overrides the cache sizes of specific chains.
+	Chains map[eth.ChainID]CacheSizes
+}
+
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{Default: DefaultCacheSizes()}
+}
+
+// ForChain returns the cache sizes to use for the given chain.
+func (c *CacheConfig) ForChain(chainID eth.ChainID) CacheSizes {
+	if sizes, ok := c.Chains[chainID]; ok {
+		return sizes
+	}
+	return c.Default
+}
+
+func (c *CacheConfig) Check() error {
+	var result error
+	if err := c.Default.Check(); err != nil {
+		result = errors.Join(result, fmt.Errorf("default: %w", err))
+	}
+	for chainID, sizes := range c.Chains {
+		if err := sizes.Check(); err != nil {
+			result = errors.Join(result, fmt.Errorf("chain %s: %w", chainID, err))
+		}
+	}
+	return result
+}
+
+// ParseChainCacheSizes parses per-chain cache size overrides,
+// each of the form <chainID>:<cache>=<size>[;<cache>=<size>...], e.g. "10:receipts=1000;block_refs=5000".
+// The caches of a chain are separated by semicolons, as commas separate the values of list flags.
+// Caches that are not specified for a chain use the default size.
+// The result is nil if there are no overrides.
+func ParseChainCacheSizes(defaults CacheSizes, specs []string) (map[eth.ChainID]CacheSizes, error) {
+	var out map[eth.ChainID]CacheSizes
+	for _, spec := range specs {
+		idStr, sizesStr, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, expected <chainID>:<cache>=<size>", ErrInvalidCacheSpec, spec)
+		}
+		var chainID eth.ChainID
+		if err := chainID.UnmarshalText([]byte(idStr)); err != nil {
+			return nil, fmt.Errorf("%w: %q: invalid chain ID: %w", ErrInvalidCacheSpec, spec, err)
+		}
+		sizes, ok := out[chainID]
+		if !ok {
+			sizes = defaults
+		}
+		for _, entry := range strings.Split(sizesStr, ";") {
+			label, sizeStr, ok := strings.Cut(entry, "=")
+			if !ok {
+				return nil, fmt.Errorf("%w: %q, expected <cache>=<size>", ErrInvalidCacheSpec, entry)
+			}
+			size, err := strconv.Atoi(sizeStr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %w", ErrInvalidCacheSpec, entry, err)
+			}
+			if err := sizes.set(label, size); err != nil {
+				return nil, err
+			}
+		}
+		if out == nil {
+			out = make(map[eth.ChainID]CacheSizes)
+		}
+		out[chainID] = sizes
+	}
+	return out, nil
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+)
+
+var ErrInvalidCompressionSpec = errors.New("invalid per-chain log DB compression")
+
+// LogDBCompressionConfig configures the compression of the log DB of each chain.
+// The compression applies to new log DBs: an existing log DB keeps the compression it was created with.
+type LogDBCompressionConfig struct {
+	// Default applies to chains without overrides.
+	Default entrydb.Compression
+	// Chains overrides the compression of specific chains.
+	Chains map[eth.ChainID]entrydb.Compression
+}
+
+func DefaultLogDBCompressionConfig() LogDBCompressionConfig {
+	return LogDBCompressionConfig{Default: entrydb.CompressionNone}
+}
+
+// ForChain returns the compression to use for the log DB of the given chain.
+func (c *LogDBCompressionConfig) ForChain(chainID eth.ChainID) entrydb.Compression {
+	if compression, ok := c.Chains[chainID]; ok {
+		return compression
+	}
+	return c.Default
+}
+
+func (c *LogDBCompressionConfig) Check() error {
+	var result error
+	if err := c.Default.Check(); err != nil {
+		result = errors.Join(result, fmt.Errorf("default: %w", err))
+	}
+	for chainID, compression := range c.Chains {
+		if err := compression.Check(); err != nil {
+			result = errors.Join(result, fmt.Errorf("chain %s: %w", chainID, err))
+		}
+	}
+	return result
+}
+
+// ParseChainCompressions parses per-chain log DB compression overrides, each of the form <chainID>:<compression>,
+// e.g. "10:zstd". The result is nil if there are no overrides.
+func ParseChainCompressions(specs []string) (map[eth.ChainID]entrydb.Compression, error) {
+	var out map[eth.ChainID]entrydb.Compression
+	for _, spec := range specs {
+		idStr, compressionStr, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, expected <chainID>:<compression>", ErrInvalidCompressionSpec, spec)
+		}
+		var chainID eth.ChainID
+		if err := chainID.UnmarshalText([]byte(idStr)); err != nil {
+			return nil, fmt.Errorf("%w: %q: invalid chain ID: %w", ErrInvalidCompressionSpec, spec, err)
+		}
+		compression := entrydb.Compression(compressionStr)
+		if err := compression.Check(); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrInvalidCompressionSpec, spec, err)
+		}
+		if out == nil {
+			out = make(map[eth.ChainID]entrydb.Compression)
+		}
+		out[chainID] = compression
+	}
+	return out, nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrInvalidConductorSpec     = errors.New("invalid conductor endpoint")
+	ErrInvalidConductorInterval = errors.New("conductor poll interval must be positive")
+	ErrInvalidFailoverWindow    = errors.New("conductor failover window must not be negative")
+)
+
+// ConductorConfig configures the tracking of the sequencer leadership of chains, as elected by op-conductor.
+// After a leadership change, a chain is in a failover window, during which brief unsafe forks are expected:
+// these are tolerated and tagged with the new sequencer, instead of being reported as errors.
+// The integration is optional: chains without a conductor endpoint are not tracked.
+type ConductorConfig struct {
+	// Endpoints are the RPC endpoints of the op-conductor of each chain.
+	Endpoints map[eth.ChainID]string
+
+	// PollInterval is the interval at which the leader of each chain is polled.
+	PollInterval time.Duration
+	// FailoverWindow is the duration after a leadership change during which unsafe forks are tolerated.
+	FailoverWindow time.Duration
+}
+
+func DefaultConductorConfig() ConductorConfig {
+	return ConductorConfig{
+		PollInterval:   time.Second,
+		FailoverWindow: 30 * time.Second,
+	}
+}
+
+// Enabled returns whether the leadership of any chain is tracked.
+func (c *ConductorConfig) Enabled() bool {
+	return len(c.Endpoints) > 0
+}
+
+func (c *ConductorConfig) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	var result error
+	if c.PollInterval <= 0 {
+		result = errors.Join(result, ErrInvalidConductorInterval)
+	}
+	if c.FailoverWindow < 0 {
+		result = errors.Join(result, ErrInvalidFailoverWindow)
+	}
+	for chainID, endpoint := range c.Endpoints {
+		if endpoint == "" {
+			result = errors.Join(result, fmt.Errorf("%w: chain %s has no endpoint", ErrInvalidConductorSpec, chainID))
+		}
+	}
+	return result
+}
+
+// ParseConductorEndpoints parses the conductor endpoints of chains,
+// each of the form <chainID>=<endpoint>, e.g. "10=http://conductor:8547".
+// The result is nil if there are no endpoints.
+func ParseConductorEndpoints(specs []string) (map[eth.ChainID]string, error) {
+	var out map[eth.ChainID]string
+	for _, spec := range specs {
+		idStr, endpoint, ok := strings.Cut(spec, "=")
+		if !ok || endpoint == "" {
+			return nil, fmt.Errorf("%w: %q, expected <chainID>=<endpoint>", ErrInvalidConductorSpec, spec)
+		}
+		var chainID eth.ChainID
+		if err := chainID.UnmarshalText([]byte(idStr)); err != nil {
+			return nil, fmt.Errorf("%w: %q: invalid chain ID: %w", ErrInvalidConductorSpec, spec, err)
+		}
+		if _, ok := out[chainID]; ok {
+			return nil, fmt.Errorf("%w: %q: duplicate chain %s", ErrInvalidConductorSpec, spec, chainID)
+		}
+		if out == nil {
+			out = make(map[eth.ChainID]string)
+		}
+		out[chainID] = endpoint
+	}
+	return out, nil
+}
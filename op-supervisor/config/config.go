@@ -7,6 +7,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/cross"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
 )
@@ -44,6 +45,33 @@ type Config struct {
 
 	// RPCVerificationWarnings enables asynchronous RPC verification of DB checkAccess call in the CheckAccessList endpoint, indicating warnings as a metric
 	RPCVerificationWarnings bool
+
+	// QueryProofs enables the supervisor_logProof RPC, which proves logs against the cross-safe head of their chain
+	QueryProofs bool
+
+	// AllowDependencySetMismatch manages nodes that are configured with a different dependency set than the supervisor,
+	// with a warning, instead of refusing to manage them.
+	AllowDependencySetMismatch bool
+
+	// Caches configures the sizes of the in-memory caches of each chain
+	Caches CacheConfig
+
+	// LogDBCompression configures the compression of the log DB of each chain
+	LogDBCompression LogDBCompressionConfig
+
+	// ShadowCrossChecker is the name of a cross-safety checker to run in shadow mode, alongside the active checker.
+	// Divergences from the active checker are reported as metrics and logs, but are never acted on.
+	// Optional, shadow mode is disabled if empty.
+	ShadowCrossChecker string
+
+	// CircuitBreaker configures the pausing of cross-safe promotion of a chain on anomalies
+	CircuitBreaker CircuitBreakerConfig
+
+	// Conductor configures the tracking of the sequencer leadership of chains, optional
+	Conductor ConductorConfig
+
+	// Attestations configures the ingestion of signed attestations from external attestors, optional
+	Attestations AttestationConfig
 }
 
 func (c *Config) Check() error {
@@ -51,12 +79,22 @@ func (c *Config) Check() error {
 	result = errors.Join(result, c.MetricsConfig.Check())
 	result = errors.Join(result, c.PprofConfig.Check())
 	result = errors.Join(result, c.RPC.Check())
+	result = errors.Join(result, c.Caches.Check())
+	result = errors.Join(result, c.LogDBCompression.Check())
+	result = errors.Join(result, c.CircuitBreaker.Check())
+	result = errors.Join(result, c.Conductor.Check())
+	result = errors.Join(result, c.Attestations.Check())
 	if c.FullConfigSetSource == nil {
 		result = errors.Join(result, ErrMissingFullConfigSet)
 	}
 	if c.Datadir == "" {
 		result = errors.Join(result, ErrMissingDatadir)
 	}
+	if c.ShadowCrossChecker != "" {
+		if _, err := cross.LookupChecker(c.ShadowCrossChecker); err != nil {
+			result = errors.Join(result, err)
+		}
+	}
 	if c.SyncSources == nil {
 		result = errors.Join(result, ErrMissingSyncSources)
 	} else {
@@ -78,5 +116,10 @@ func NewConfig(l1RPC string, syncSrcs syncnode.SyncNodeCollection, fullCfgSet de
 		L1RPC:               l1RPC,
 		SyncSources:         syncSrcs,
 		Datadir:             datadir,
+		Caches:              DefaultCacheConfig(),
+		LogDBCompression:    DefaultLogDBCompressionConfig(),
+		CircuitBreaker:      DefaultCircuitBreakerConfig(),
+		Conductor:           DefaultConductorConfig(),
+		Attestations:        DefaultAttestationConfig(),
 	}
 }
@@ -3,11 +3,14 @@ package config
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
 )
@@ -55,6 +58,166 @@ func TestValidateRPCConfig(t *testing.T) {
 	require.ErrorIs(t, cfg.Check(), rpc.ErrInvalidPort)
 }
 
+func TestValidateCacheConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.Caches.Chains = map[eth.ChainID]CacheSizes{
+		eth.ChainIDFromUInt64(10): {Receipts: -1},
+	}
+	require.ErrorIs(t, cfg.Check(), ErrInvalidCacheSize)
+}
+
+func TestValidateCircuitBreakerConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.CircuitBreaker.Window = 0
+	require.NoError(t, cfg.Check(), "disabled circuit breaker is not validated")
+	cfg.CircuitBreaker.Enabled = true
+	require.ErrorIs(t, cfg.Check(), ErrInvalidBreakerWindow)
+
+	cfg = validConfig()
+	cfg.CircuitBreaker.Enabled = true
+	require.NoError(t, cfg.Check())
+	cfg.CircuitBreaker.MaxChecksumFailureRate = 1.5
+	require.ErrorIs(t, cfg.Check(), ErrInvalidBreakerRate)
+	cfg.CircuitBreaker.MaxChecksumFailureRate = 0.5
+	cfg.CircuitBreaker.MaxReplacements = -1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidBreakerThreshold)
+}
+
+func TestParseChainCacheSizes(t *testing.T) {
+	defaults := DefaultCacheSizes()
+	chains, err := ParseChainCacheSizes(defaults, []string{
+		"10:receipts=1000;block_refs=0",
+		"0x2105:access_checks=5",
+		"10:access_checks=7",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[eth.ChainID]CacheSizes{
+		eth.ChainIDFromUInt64(10):   {Receipts: 1000, BlockRefs: 0, AccessChecks: 7},
+		eth.ChainIDFromUInt64(8453): {Receipts: defaults.Receipts, BlockRefs: defaults.BlockRefs, AccessChecks: 5},
+	}, chains)
+
+	cfg := CacheConfig{Default: defaults, Chains: chains}
+	require.Equal(t, 1000, cfg.ForChain(eth.ChainIDFromUInt64(10)).Receipts)
+	require.Equal(t, defaults, cfg.ForChain(eth.ChainIDFromUInt64(11)))
+
+	_, err = ParseChainCacheSizes(defaults, []string{"10"})
+	require.ErrorIs(t, err, ErrInvalidCacheSpec)
+	_, err = ParseChainCacheSizes(defaults, []string{"abc:receipts=1"})
+	require.ErrorIs(t, err, ErrInvalidCacheSpec)
+	_, err = ParseChainCacheSizes(defaults, []string{"10:receipts"})
+	require.ErrorIs(t, err, ErrInvalidCacheSpec)
+	_, err = ParseChainCacheSizes(defaults, []string{"10:receipts=many"})
+	require.ErrorIs(t, err, ErrInvalidCacheSpec)
+	_, err = ParseChainCacheSizes(defaults, []string{"10:headers=1"})
+	require.ErrorIs(t, err, ErrUnknownCacheLabel)
+}
+
+func TestValidateLogDBCompressionConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.LogDBCompression.Default = "lz4"
+	require.ErrorIs(t, cfg.Check(), entrydb.ErrUnknownCompression)
+
+	cfg = validConfig()
+	cfg.LogDBCompression.Chains = map[eth.ChainID]entrydb.Compression{
+		eth.ChainIDFromUInt64(10): "lz4",
+	}
+	require.ErrorIs(t, cfg.Check(), entrydb.ErrUnknownCompression)
+}
+
+func TestParseChainCompressions(t *testing.T) {
+	chains, err := ParseChainCompressions(nil)
+	require.NoError(t, err)
+	require.Nil(t, chains)
+
+	chains, err = ParseChainCompressions([]string{"10:zstd", "0x2105:snappy", "10:none"})
+	require.NoError(t, err)
+	require.Equal(t, map[eth.ChainID]entrydb.Compression{
+		eth.ChainIDFromUInt64(10):   entrydb.CompressionNone,
+		eth.ChainIDFromUInt64(8453): entrydb.CompressionSnappy,
+	}, chains)
+
+	cfg := LogDBCompressionConfig{Default: entrydb.CompressionZstd, Chains: chains}
+	require.Equal(t, entrydb.CompressionNone, cfg.ForChain(eth.ChainIDFromUInt64(10)))
+	require.Equal(t, entrydb.CompressionZstd, cfg.ForChain(eth.ChainIDFromUInt64(11)))
+
+	_, err = ParseChainCompressions([]string{"10"})
+	require.ErrorIs(t, err, ErrInvalidCompressionSpec)
+	_, err = ParseChainCompressions([]string{"abc:zstd"})
+	require.ErrorIs(t, err, ErrInvalidCompressionSpec)
+	_, err = ParseChainCompressions([]string{"10:lz4"})
+	require.ErrorIs(t, err, entrydb.ErrUnknownCompression)
+}
+
+func TestValidateConductorConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.Conductor.PollInterval = 0
+	require.NoError(t, cfg.Check(), "conductor config without endpoints is not validated")
+	cfg.Conductor.Endpoints = map[eth.ChainID]string{eth.ChainIDFromUInt64(10): "http://localhost:8547"}
+	require.ErrorIs(t, cfg.Check(), ErrInvalidConductorInterval)
+
+	cfg = validConfig()
+	cfg.Conductor.Endpoints = map[eth.ChainID]string{eth.ChainIDFromUInt64(10): "http://localhost:8547"}
+	require.NoError(t, cfg.Check())
+	cfg.Conductor.FailoverWindow = -1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidFailoverWindow)
+}
+
+func TestParseConductorEndpoints(t *testing.T) {
+	endpoints, err := ParseConductorEndpoints(nil)
+	require.NoError(t, err)
+	require.Nil(t, endpoints)
+
+	endpoints, err = ParseConductorEndpoints([]string{"10=http://conductor-a:8547", "0x2105=ws://conductor-b:8547"})
+	require.NoError(t, err)
+	require.Equal(t, map[eth.ChainID]string{
+		eth.ChainIDFromUInt64(10):   "http://conductor-a:8547",
+		eth.ChainIDFromUInt64(8453): "ws://conductor-b:8547",
+	}, endpoints)
+
+	_, err = ParseConductorEndpoints([]string{"10"})
+	require.ErrorIs(t, err, ErrInvalidConductorSpec)
+	_, err = ParseConductorEndpoints([]string{"10="})
+	require.ErrorIs(t, err, ErrInvalidConductorSpec)
+	_, err = ParseConductorEndpoints([]string{"abc=http://conductor:8547"})
+	require.ErrorIs(t, err, ErrInvalidConductorSpec)
+	_, err = ParseConductorEndpoints([]string{"10=http://a:8547", "10=http://b:8547"})
+	require.ErrorIs(t, err, ErrInvalidConductorSpec)
+}
+
+func TestValidateAttestationConfig(t *testing.T) {
+	cfg := validConfig()
+	cfg.Attestations.Retention = 0
+	require.NoError(t, cfg.Check(), "attestation config without attestors is not validated")
+	cfg.Attestations.Quorum = 1
+	require.ErrorIs(t, cfg.Check(), ErrInvalidAttestationQuorum, "quorum without attestors")
+
+	cfg = validConfig()
+	cfg.Attestations.Attestors = []common.Address{{0x01}, {0x02}}
+	cfg.Attestations.Quorum = 2
+	require.NoError(t, cfg.Check())
+	cfg.Attestations.Quorum = 3
+	require.ErrorIs(t, cfg.Check(), ErrInvalidAttestationQuorum)
+	cfg.Attestations.Quorum = 1
+	cfg.Attestations.Retention = 0
+	require.ErrorIs(t, cfg.Check(), ErrInvalidAttestationRetention)
+	cfg.Attestations.Retention = 10
+	cfg.Attestations.Attestors = []common.Address{{0x01}, {0x01}}
+	require.ErrorIs(t, cfg.Check(), ErrInvalidAttestor)
+}
+
+func TestParseAttestors(t *testing.T) {
+	attestors, err := ParseAttestors(nil)
+	require.NoError(t, err)
+	require.Nil(t, attestors)
+
+	attestors, err = ParseAttestors([]string{"0x0000000000000000000000000000000000000001", "0000000000000000000000000000000000000002"})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}, attestors)
+
+	_, err = ParseAttestors([]string{"0x01"})
+	require.ErrorIs(t, err, ErrInvalidAttestor)
+}
+
 func validConfig() *Config {
 	// Should be valid using only the required arguments passed in via the constructor.
 	return NewConfig("http://localhost:8545", &syncnode.CLISyncNodes{}, &depset.FullConfigSetSourceMerged{}, "./supervisor_testdir")
@@ -12,7 +12,10 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/cross"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
 )
@@ -47,6 +50,30 @@ var (
 		Value:     cli.NewStringSlice(),
 		TakesFile: true,
 	}
+	L2ConsensusTLSEnabled = &cli.BoolFlag{
+		Name:    "l2-consensus.tls.enabled",
+		Usage:   "Dial the L2 Consensus rollup nodes over TLS, authenticating with a client certificate.",
+		EnvVars: prefixEnvVars("L2_CONSENSUS_TLS_ENABLED"),
+		Value:   false,
+	}
+	L2ConsensusTLSCert = &cli.StringFlag{
+		Name:      "l2-consensus.tls.cert",
+		Usage:     "Path of the client certificate to authenticate with to the L2 Consensus rollup nodes.",
+		EnvVars:   prefixEnvVars("L2_CONSENSUS_TLS_CERT"),
+		TakesFile: true,
+	}
+	L2ConsensusTLSKey = &cli.StringFlag{
+		Name:      "l2-consensus.tls.key",
+		Usage:     "Path of the client key to authenticate with to the L2 Consensus rollup nodes.",
+		EnvVars:   prefixEnvVars("L2_CONSENSUS_TLS_KEY"),
+		TakesFile: true,
+	}
+	L2ConsensusTLSCaCert = &cli.StringFlag{
+		Name:      "l2-consensus.tls.ca",
+		Usage:     "Path of the CA certificate(s) to verify the L2 Consensus rollup node certificates with.",
+		EnvVars:   prefixEnvVars("L2_CONSENSUS_TLS_CA"),
+		TakesFile: true,
+	}
 	DataDirFlag = &cli.PathFlag{
 		Name:    "datadir",
 		Usage:   "Directory to store data generated as part of responding to games",
@@ -69,6 +96,13 @@ var (
 		EnvVars:   prefixEnvVars("DEPENDENCY_SET"),
 		TakesFile: true,
 	}
+	DependencySetAllowMismatchFlag = &cli.BoolFlag{
+		Name: "dependency-set.allow-mismatch",
+		Usage: "Manage nodes that report a different dependency set than the supervisor, with a warning, " +
+			"instead of refusing to manage them.",
+		EnvVars: prefixEnvVars("DEPENDENCY_SET_ALLOW_MISMATCH"),
+		Value:   false,
+	}
 	RollupConfigPathsFlag = &cli.StringFlag{
 		Name: "rollup-config-paths",
 		Usage: "Path pattern to op-node rollup.json configs to load as a rollup config set. " +
@@ -95,6 +129,133 @@ var (
 		EnvVars: prefixEnvVars("RPC_VERIFICATION_WARNINGS"),
 		Value:   false,
 	}
+	QueryProofsFlag = &cli.BoolFlag{
+		Name: "query-proofs",
+		Usage: "Enable the supervisor_logProof RPC, which serves merkle proofs binding logs to the cross-safe head of their chain, " +
+			"so clients do not have to fully trust the supervisor",
+		EnvVars: prefixEnvVars("QUERY_PROOFS"),
+		Value:   false,
+	}
+	ShadowCrossCheckerFlag = &cli.StringFlag{
+		Name: "shadow-cross-checker",
+		Usage: "Name of a cross-safety checker version to run in shadow mode, alongside the active checker. " +
+			"Divergences are reported as metrics and logs, without affecting the databases. " +
+			fmt.Sprintf("Available checkers: %s. Disabled if empty.", strings.Join(cross.CheckerNames(), ", ")),
+		EnvVars: prefixEnvVars("SHADOW_CROSS_CHECKER"),
+		Value:   "",
+	}
+	CacheReceiptsFlag = &cli.IntFlag{
+		Name:    "cache.receipts",
+		Usage:   "Number of blocks to cache the receipts of, per chain. 0 disables the cache.",
+		EnvVars: prefixEnvVars("CACHE_RECEIPTS"),
+		Value:   config.DefaultCacheSizes().Receipts,
+	}
+	CacheBlockRefsFlag = &cli.IntFlag{
+		Name:    "cache.block-refs",
+		Usage:   "Number of block refs to cache, per chain. 0 disables the cache.",
+		EnvVars: prefixEnvVars("CACHE_BLOCK_REFS"),
+		Value:   config.DefaultCacheSizes().BlockRefs,
+	}
+	CacheAccessChecksFlag = &cli.IntFlag{
+		Name:    "cache.access-checks",
+		Usage:   "Number of access-list check results to cache, per chain. 0 disables the cache.",
+		EnvVars: prefixEnvVars("CACHE_ACCESS_CHECKS"),
+		Value:   config.DefaultCacheSizes().AccessChecks,
+	}
+	CacheChainSizesFlag = &cli.StringSliceFlag{
+		Name: "cache.chain-sizes",
+		Usage: "Per-chain cache size overrides, of the form <chainID>:<cache>=<size>[;<cache>=<size>...], " +
+			"e.g. 10:receipts=1000;block_refs=5000. Caches: " + strings.Join([]string{config.ReceiptsCache, config.BlockRefsCache, config.AccessChecksCache}, ", "),
+		EnvVars: prefixEnvVars("CACHE_CHAIN_SIZES"),
+	}
+	LogDBCompressionFlag = &cli.StringFlag{
+		Name: "logdb.compression",
+		Usage: "Compression of new log databases, per chain: " + compressionNames() + ". " +
+			"Existing log databases keep the compression they were created with.",
+		EnvVars: prefixEnvVars("LOGDB_COMPRESSION"),
+		Value:   config.DefaultLogDBCompressionConfig().Default.String(),
+	}
+	LogDBChainCompressionFlag = &cli.StringSliceFlag{
+		Name:    "logdb.chain-compression",
+		Usage:   "Per-chain log database compression overrides, of the form <chainID>:<compression>, e.g. 10:zstd",
+		EnvVars: prefixEnvVars("LOGDB_CHAIN_COMPRESSION"),
+	}
+	CircuitBreakerEnabledFlag = &cli.BoolFlag{
+		Name: "circuit-breaker.enabled",
+		Usage: "Pause the cross-safe promotion of a chain when anomalies are detected, " +
+			"until acknowledged with the admin_acknowledgeCircuitBreaker RPC",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_ENABLED"),
+		Value:   config.DefaultCircuitBreakerConfig().Enabled,
+	}
+	CircuitBreakerWindowFlag = &cli.DurationFlag{
+		Name:    "circuit-breaker.window",
+		Usage:   "Duration over which the anomalies of a chain are counted by the circuit breaker",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_WINDOW"),
+		Value:   config.DefaultCircuitBreakerConfig().Window,
+	}
+	CircuitBreakerMaxInvalidationsFlag = &cli.IntFlag{
+		Name:    "circuit-breaker.max-invalidations",
+		Usage:   "Number of local-safe invalidations of a chain allowed within the window. 0 disables the check.",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_MAX_INVALIDATIONS"),
+		Value:   config.DefaultCircuitBreakerConfig().MaxInvalidations,
+	}
+	CircuitBreakerMaxReplacementsFlag = &cli.IntFlag{
+		Name:    "circuit-breaker.max-replacements",
+		Usage:   "Number of block replacements of a chain allowed within the window. 0 disables the check.",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_MAX_REPLACEMENTS"),
+		Value:   config.DefaultCircuitBreakerConfig().MaxReplacements,
+	}
+	CircuitBreakerMaxChecksumFailureRateFlag = &cli.Float64Flag{
+		Name: "circuit-breaker.max-checksum-failure-rate",
+		Usage: "Fraction of the access checks of a chain that may fail RPC verification within the window. " +
+			"Requires --" + RPCVerificationWarningsFlag.Name,
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_MAX_CHECKSUM_FAILURE_RATE"),
+		Value:   config.DefaultCircuitBreakerConfig().MaxChecksumFailureRate,
+	}
+	CircuitBreakerMinChecksumChecksFlag = &cli.IntFlag{
+		Name:    "circuit-breaker.min-checksum-checks",
+		Usage:   "Number of RPC-verified access checks of a chain within the window, before the checksum failure rate is considered. 0 disables the check.",
+		EnvVars: prefixEnvVars("CIRCUIT_BREAKER_MIN_CHECKSUM_CHECKS"),
+		Value:   config.DefaultCircuitBreakerConfig().MinChecksumChecks,
+	}
+	ConductorRPCsFlag = &cli.StringSliceFlag{
+		Name: "conductor.rpcs",
+		Usage: "RPC endpoints of the op-conductor of chains, of the form <chainID>=<endpoint>, e.g. 10=http://conductor:8547. " +
+			"The sequencer leadership of these chains is tracked, and unsafe forks are tolerated during failovers.",
+		EnvVars: prefixEnvVars("CONDUCTOR_RPCS"),
+	}
+	ConductorPollIntervalFlag = &cli.DurationFlag{
+		Name:    "conductor.poll-interval",
+		Usage:   "Interval at which the sequencer leader of each chain is polled from its op-conductor",
+		EnvVars: prefixEnvVars("CONDUCTOR_POLL_INTERVAL"),
+		Value:   config.DefaultConductorConfig().PollInterval,
+	}
+	ConductorFailoverWindowFlag = &cli.DurationFlag{
+		Name:    "conductor.failover-window",
+		Usage:   "Duration after a sequencer leadership change during which unsafe forks of the chain are tolerated",
+		EnvVars: prefixEnvVars("CONDUCTOR_FAILOVER_WINDOW"),
+		Value:   config.DefaultConductorConfig().FailoverWindow,
+	}
+	AttestorsFlag = &cli.StringSliceFlag{
+		Name: "attestation.attestors",
+		Usage: "Addresses of the external attestors, e.g. alt-DA attestors or sequencer committees, " +
+			"whose signed attestations of L2 blocks are accepted with the supervisor_submitAttestation RPC. " +
+			"Attestation ingestion is disabled if empty.",
+		EnvVars: prefixEnvVars("ATTESTATION_ATTESTORS"),
+	}
+	AttestationQuorumFlag = &cli.IntFlag{
+		Name: "attestation.quorum",
+		Usage: "Number of distinct attestors that must attest a local-safe block before it is promoted to cross-safe. " +
+			"0 only stores the attestations.",
+		EnvVars: prefixEnvVars("ATTESTATION_QUORUM"),
+		Value:   config.DefaultAttestationConfig().Quorum,
+	}
+	AttestationRetentionFlag = &cli.IntFlag{
+		Name:    "attestation.retention",
+		Usage:   "Number of attested blocks of each chain to keep the attestations of",
+		EnvVars: prefixEnvVars("ATTESTATION_RETENTION"),
+		Value:   config.DefaultAttestationConfig().Retention,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -105,13 +266,38 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L2ConsensusTLSEnabled,
+	L2ConsensusTLSCert,
+	L2ConsensusTLSKey,
+	L2ConsensusTLSCaCert,
 	NetworkFlag,
 	MockRunFlag,
 	DataDirSyncEndpointFlag,
 	RPCVerificationWarningsFlag,
+	QueryProofsFlag,
+	ShadowCrossCheckerFlag,
 	DependencySetFlag,
+	DependencySetAllowMismatchFlag,
 	RollupConfigPathsFlag,
 	RollupConfigSetFlag,
+	CacheReceiptsFlag,
+	CacheBlockRefsFlag,
+	CacheAccessChecksFlag,
+	CacheChainSizesFlag,
+	LogDBCompressionFlag,
+	LogDBChainCompressionFlag,
+	CircuitBreakerEnabledFlag,
+	CircuitBreakerWindowFlag,
+	CircuitBreakerMaxInvalidationsFlag,
+	CircuitBreakerMaxReplacementsFlag,
+	CircuitBreakerMaxChecksumFailureRateFlag,
+	CircuitBreakerMinChecksumChecksFlag,
+	ConductorRPCsFlag,
+	ConductorPollIntervalFlag,
+	ConductorFailoverWindowFlag,
+	AttestorsFlag,
+	AttestationQuorumFlag,
+	AttestationRetentionFlag,
 }
 
 func init() {
@@ -181,17 +367,56 @@ func ConfigFromCLI(ctx *cli.Context, version string) (*config.Config, error) {
 		return nil, err
 	}
 	c := &config.Config{
-		Version:                 version,
-		LogConfig:               oplog.ReadCLIConfig(ctx),
-		MetricsConfig:           opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:             oppprof.ReadCLIConfig(ctx),
-		RPC:                     oprpc.ReadCLIConfig(ctx),
-		MockRun:                 ctx.Bool(MockRunFlag.Name),
-		RPCVerificationWarnings: ctx.Bool(RPCVerificationWarningsFlag.Name),
-		L1RPC:                   ctx.String(L1RPCFlag.Name),
-		SyncSources:             syncSourceSetups(ctx),
-		Datadir:                 ctx.Path(DataDirFlag.Name),
-		DatadirSyncEndpoint:     ctx.Path(DataDirSyncEndpointFlag.Name),
+		Version:                    version,
+		LogConfig:                  oplog.ReadCLIConfig(ctx),
+		MetricsConfig:              opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:                oppprof.ReadCLIConfig(ctx),
+		RPC:                        oprpc.ReadCLIConfig(ctx),
+		MockRun:                    ctx.Bool(MockRunFlag.Name),
+		RPCVerificationWarnings:    ctx.Bool(RPCVerificationWarningsFlag.Name),
+		QueryProofs:                ctx.Bool(QueryProofsFlag.Name),
+		AllowDependencySetMismatch: ctx.Bool(DependencySetAllowMismatchFlag.Name),
+		ShadowCrossChecker:         ctx.String(ShadowCrossCheckerFlag.Name),
+		L1RPC:                      ctx.String(L1RPCFlag.Name),
+		SyncSources:                syncSourceSetups(ctx),
+		Datadir:                    ctx.Path(DataDirFlag.Name),
+		DatadirSyncEndpoint:        ctx.Path(DataDirSyncEndpointFlag.Name),
+		CircuitBreaker: config.CircuitBreakerConfig{
+			Enabled:                ctx.Bool(CircuitBreakerEnabledFlag.Name),
+			Window:                 ctx.Duration(CircuitBreakerWindowFlag.Name),
+			MaxInvalidations:       ctx.Int(CircuitBreakerMaxInvalidationsFlag.Name),
+			MaxReplacements:        ctx.Int(CircuitBreakerMaxReplacementsFlag.Name),
+			MaxChecksumFailureRate: ctx.Float64(CircuitBreakerMaxChecksumFailureRateFlag.Name),
+			MinChecksumChecks:      ctx.Int(CircuitBreakerMinChecksumChecksFlag.Name),
+		},
+	}
+	caches, err := cacheConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.Caches = caches
+	compression, err := logDBCompressionConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.LogDBCompression = compression
+	conductors, err := config.ParseConductorEndpoints(filterEmpty(ctx.StringSlice(ConductorRPCsFlag.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ConductorRPCsFlag.Name, err)
+	}
+	c.Conductor = config.ConductorConfig{
+		Endpoints:      conductors,
+		PollInterval:   ctx.Duration(ConductorPollIntervalFlag.Name),
+		FailoverWindow: ctx.Duration(ConductorFailoverWindowFlag.Name),
+	}
+	attestors, err := config.ParseAttestors(filterEmpty(ctx.StringSlice(AttestorsFlag.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AttestorsFlag.Name, err)
+	}
+	c.Attestations = config.AttestationConfig{
+		Attestors: attestors,
+		Quorum:    ctx.Int(AttestationQuorumFlag.Name),
+		Retention: ctx.Int(AttestationRetentionFlag.Name),
 	}
 	if ctx.IsSet(RollupConfigSetFlag.Name) {
 		c.FullConfigSetSource = &depset.FullConfigSetSourceMerged{
@@ -220,12 +445,52 @@ func ConfigFromCLI(ctx *cli.Context, version string) (*config.Config, error) {
 	return c, nil
 }
 
+// cacheConfig creates the per-chain cache configuration, from CLI arguments.
+func cacheConfig(ctx *cli.Context) (config.CacheConfig, error) {
+	defaults := config.CacheSizes{
+		Receipts:     ctx.Int(CacheReceiptsFlag.Name),
+		BlockRefs:    ctx.Int(CacheBlockRefsFlag.Name),
+		AccessChecks: ctx.Int(CacheAccessChecksFlag.Name),
+	}
+	chains, err := config.ParseChainCacheSizes(defaults, filterEmpty(ctx.StringSlice(CacheChainSizesFlag.Name)))
+	if err != nil {
+		return config.CacheConfig{}, fmt.Errorf("invalid %s: %w", CacheChainSizesFlag.Name, err)
+	}
+	return config.CacheConfig{Default: defaults, Chains: chains}, nil
+}
+
+// logDBCompressionConfig creates the per-chain log DB compression configuration, from CLI arguments.
+func logDBCompressionConfig(ctx *cli.Context) (config.LogDBCompressionConfig, error) {
+	chains, err := config.ParseChainCompressions(filterEmpty(ctx.StringSlice(LogDBChainCompressionFlag.Name)))
+	if err != nil {
+		return config.LogDBCompressionConfig{}, fmt.Errorf("invalid %s: %w", LogDBChainCompressionFlag.Name, err)
+	}
+	return config.LogDBCompressionConfig{
+		Default: entrydb.Compression(ctx.String(LogDBCompressionFlag.Name)),
+		Chains:  chains,
+	}, nil
+}
+
+func compressionNames() string {
+	names := make([]string, len(entrydb.Compressions))
+	for i, c := range entrydb.Compressions {
+		names[i] = c.String()
+	}
+	return strings.Join(names, ", ")
+}
+
 // syncSourceSetups creates a sync source collection, from CLI arguments.
 // These sources can share JWT secret configuration.
 func syncSourceSetups(ctx *cli.Context) syncnode.SyncNodeCollection {
 	return &syncnode.CLISyncNodes{
 		Endpoints:      filterEmpty(ctx.StringSlice(L2ConsensusNodesFlag.Name)),
 		JWTSecretPaths: filterEmpty(ctx.StringSlice(L2ConsensusJWTSecret.Name)),
+		TLS: optls.CLIConfig{
+			TLSCaCert: ctx.String(L2ConsensusTLSCaCert.Name),
+			TLSCert:   ctx.String(L2ConsensusTLSCert.Name),
+			TLSKey:    ctx.String(L2ConsensusTLSKey.Name),
+			Enabled:   ctx.Bool(L2ConsensusTLSEnabled.Name),
+		},
 	}
 }
 
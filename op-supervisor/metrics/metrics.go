@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -22,9 +24,22 @@ type Metricer interface {
 
 	RecordDBEntryCount(chainID eth.ChainID, kind string, count int64)
 	RecordDBSearchEntriesRead(chainID eth.ChainID, count int64)
+	RecordDBCompressedSize(chainID eth.ChainID, rawBytes int64, compressedBytes int64)
+	RecordDBDecompression(chainID eth.ChainID, d time.Duration)
 
 	RecordAccessListVerifyFailure(chainID eth.ChainID)
 
+	RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool)
+
+	RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool)
+
+	RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool)
+
+	RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration)
+	RecordInvalidGossipInteropBlock(chainID eth.ChainID)
+
+	SetProgressSource(source ProgressSource)
+
 	Document() []opmetrics.DocumentedMetric
 
 	event.Metrics
@@ -46,9 +61,25 @@ type Metrics struct {
 
 	DBEntryCountVec        *prometheus.GaugeVec
 	DBSearchEntriesReadVec *prometheus.HistogramVec
+	DBCompressionRatioVec  *prometheus.GaugeVec
+	DBDecompressionVec     *prometheus.HistogramVec
 
 	AccessListVerifyFailureVec *prometheus.CounterVec
 
+	DependencyLagVec     *prometheus.GaugeVec
+	DependencyBindingVec *prometheus.GaugeVec
+
+	CrossShadowChecksVec *prometheus.CounterVec
+
+	CircuitBreakerPausedVec *prometheus.GaugeVec
+
+	GossipBlocksVec              *prometheus.CounterVec
+	GossipBlockLatencyVec        *prometheus.HistogramVec
+	InvalidGossipInteropBlockVec *prometheus.CounterVec
+
+	sloRegistry *prometheus.Registry
+	slo         *SLOCollector
+
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 }
@@ -68,11 +99,18 @@ func NewMetrics(procName string) *Metrics {
 	registry := opmetrics.NewRegistry()
 	factory := opmetrics.With(registry)
 
+	slo := NewSLOCollector()
+	sloRegistry := prometheus.NewRegistry()
+	sloRegistry.MustRegister(slo)
+
 	return &Metrics{
 		ns:       ns,
 		registry: registry,
 		factory:  factory,
 
+		sloRegistry: sloRegistry,
+		slo:         slo,
+
 		EventMetricsTracker: event.NewMetricsTracker(ns, factory),
 		RPCMetrics:          opmetrics.MakeRPCMetrics(ns, factory),
 		RefMetrics:          opmetrics.MakeRefMetricsWithChainID(ns, factory),
@@ -133,6 +171,21 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"chain",
 		}),
+		DBCompressionRatioVec: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "logdb_compression_ratio",
+			Help:      "Size of the compressed blocks of the log database before compression, divided by the size after compression",
+		}, []string{
+			"chain",
+		}),
+		DBDecompressionVec: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "logdb_decompression_seconds",
+			Help:      "Seconds spent to decompress a block of the log database, to read entries from it",
+			Buckets:   []float64{0.00001, 0.00005, 0.0001, 0.0005, 0.001, 0.005, 0.01},
+		}, []string{
+			"chain",
+		}),
 		AccessListVerifyFailureVec: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Name:      "access_list_verify_failure",
@@ -140,6 +193,61 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"chain",
 		}),
+		DependencyLagVec: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "dependency_lag_seconds",
+			Help:      "Seconds that the local-safe timestamp of the dependency is behind that of the chain, holding back the cross-safe progress of the chain",
+		}, []string{
+			"chain",
+			"dependency",
+		}),
+		DependencyBindingVec: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "dependency_binding",
+			Help:      "1 if the dependency is the binding constraint on the cross-safe progress of the chain, 0 otherwise",
+		}, []string{
+			"chain",
+			"dependency",
+		}),
+		CrossShadowChecksVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "cross_shadow_checks",
+			Help:      "Number of candidates checked by the shadow cross-safety checker, by kind (safe/unsafe) and whether the decision diverged from the active checker",
+		}, []string{
+			"chain",
+			"kind",
+			"diverged",
+		}),
+		CircuitBreakerPausedVec: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "circuit_breaker_paused",
+			Help:      "1 if the circuit breaker paused the cross-safe promotion of the chain, until acknowledged by the operator, 0 otherwise",
+		}, []string{
+			"chain",
+		}),
+		GossipBlocksVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "gossip_blocks",
+			Help:      "Number of unsafe blocks that the managed nodes received via p2p gossip, by processing outcome (valid/invalid)",
+		}, []string{
+			"chain",
+			"outcome",
+		}),
+		GossipBlockLatencyVec: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "gossip_block_latency_seconds",
+			Help:      "Seconds between the block timestamp and the arrival of the block at the managed node via p2p gossip",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16, 32},
+		}, []string{
+			"chain",
+		}),
+		InvalidGossipInteropBlockVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "gossip_invalid_interop_blocks",
+			Help:      "Number of blocks received via p2p gossip that were invalidated by the cross-chain checks of the supervisor",
+		}, []string{
+			"chain",
+		}),
 	}
 }
 
@@ -147,6 +255,11 @@ func (m *Metrics) Registry() *prometheus.Registry {
 	return m.registry
 }
 
+// SLORegistry returns the registry of the SLO metrics, to be served separately from the internal metrics.
+func (m *Metrics) SLORegistry() *prometheus.Registry {
+	return m.sloRegistry
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -196,6 +309,17 @@ func (m *Metrics) RecordDBSearchEntriesRead(chainID eth.ChainID, count int64) {
 	m.DBSearchEntriesReadVec.WithLabelValues(chainIDLabel(chainID)).Observe(float64(count))
 }
 
+func (m *Metrics) RecordDBCompressedSize(chainID eth.ChainID, rawBytes int64, compressedBytes int64) {
+	if compressedBytes == 0 {
+		return
+	}
+	m.DBCompressionRatioVec.WithLabelValues(chainIDLabel(chainID)).Set(float64(rawBytes) / float64(compressedBytes))
+}
+
+func (m *Metrics) RecordDBDecompression(chainID eth.ChainID, d time.Duration) {
+	m.DBDecompressionVec.WithLabelValues(chainIDLabel(chainID)).Observe(d.Seconds())
+}
+
 func chainIDLabel(chainID eth.ChainID) string {
 	return chainID.String()
 }
@@ -203,3 +327,43 @@ func chainIDLabel(chainID eth.ChainID) string {
 func (m *Metrics) RecordAccessListVerifyFailure(chainID eth.ChainID) {
 	m.AccessListVerifyFailureVec.WithLabelValues(chainIDLabel(chainID)).Inc()
 }
+
+func (m *Metrics) RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool) {
+	chain, dep := chainIDLabel(chainID), chainIDLabel(dependency)
+	m.DependencyLagVec.WithLabelValues(chain, dep).Set(float64(lag))
+	if binding {
+		m.DependencyBindingVec.WithLabelValues(chain, dep).Set(1)
+	} else {
+		m.DependencyBindingVec.WithLabelValues(chain, dep).Set(0)
+	}
+}
+
+func (m *Metrics) RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool) {
+	if diverged {
+		m.CrossShadowChecksVec.WithLabelValues(chainIDLabel(chainID), kind, "true").Inc()
+	} else {
+		m.CrossShadowChecksVec.WithLabelValues(chainIDLabel(chainID), kind, "false").Inc()
+	}
+}
+
+func (m *Metrics) RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool) {
+	if paused {
+		m.CircuitBreakerPausedVec.WithLabelValues(chainIDLabel(chainID)).Set(1)
+	} else {
+		m.CircuitBreakerPausedVec.WithLabelValues(chainIDLabel(chainID)).Set(0)
+	}
+}
+
+func (m *Metrics) RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration) {
+	chain := chainIDLabel(chainID)
+	m.GossipBlocksVec.WithLabelValues(chain, outcome).Inc()
+	m.GossipBlockLatencyVec.WithLabelValues(chain).Observe(latency.Seconds())
+}
+
+func (m *Metrics) RecordInvalidGossipInteropBlock(chainID eth.ChainID) {
+	m.InvalidGossipInteropBlockVec.WithLabelValues(chainIDLabel(chainID)).Inc()
+}
+
+func (m *Metrics) SetProgressSource(source ProgressSource) {
+	m.slo.SetSource(source)
+}
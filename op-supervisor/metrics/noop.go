@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -24,7 +26,21 @@ func (m *noopMetrics) RecordCrossSafeRef(_ eth.ChainID, _ eth.BlockRef)   {}
 func (m *noopMetrics) CacheAdd(_ eth.ChainID, _ string, _ int, _ bool) {}
 func (m *noopMetrics) CacheGet(_ eth.ChainID, _ string, _ bool)        {}
 
-func (m *noopMetrics) RecordDBEntryCount(_ eth.ChainID, _ string, _ int64) {}
-func (m *noopMetrics) RecordDBSearchEntriesRead(_ eth.ChainID, _ int64)    {}
+func (m *noopMetrics) RecordDBEntryCount(_ eth.ChainID, _ string, _ int64)    {}
+func (m *noopMetrics) RecordDBSearchEntriesRead(_ eth.ChainID, _ int64)       {}
+func (m *noopMetrics) RecordDBCompressedSize(_ eth.ChainID, _ int64, _ int64) {}
+func (m *noopMetrics) RecordDBDecompression(_ eth.ChainID, _ time.Duration)   {}
 
 func (m *noopMetrics) RecordAccessListVerifyFailure(_ eth.ChainID) {}
+
+func (m *noopMetrics) RecordDependencyLag(_ eth.ChainID, _ eth.ChainID, _ uint64, _ bool) {}
+
+func (m *noopMetrics) RecordCrossShadowCheck(_ eth.ChainID, _ string, _ bool) {}
+
+func (m *noopMetrics) RecordCircuitBreakerPaused(_ eth.ChainID, _ bool) {}
+
+func (m *noopMetrics) RecordGossipBlock(_ eth.ChainID, _ string, _ time.Duration) {}
+
+func (m *noopMetrics) RecordInvalidGossipInteropBlock(_ eth.ChainID) {}
+
+func (m *noopMetrics) SetProgressSource(_ ProgressSource) {}
@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// The SLO metrics are a stable API for external SLO tooling, served separately from the internal metrics.
+// Unlike the internal metrics, their names do not include the process name,
+// and their names, labels and meaning are not changed by internal refactors.
+// Changes to them must be backwards compatible, or be announced as breaking changes.
+//
+// Every metric is labeled with the decimal chain ID in the "chain" label.
+// Chains without a known status are omitted, and so are heads that are not known yet.
+const (
+	// SLONamespace prefixes the name of every SLO metric.
+	SLONamespace = "op_supervisor_slo"
+
+	// SLOHeadTimestampName is the timestamp, in unix seconds, of the L2 block at a head of the chain.
+	// The "head" label is one of the SLOHead values.
+	SLOHeadTimestampName = SLONamespace + "_head_timestamp_seconds"
+	// SLOL1OriginLagName is the number of seconds that the L1 block the derivation of the chain has reached
+	// is behind the wall clock at scrape time.
+	SLOL1OriginLagName = SLONamespace + "_l1_origin_lag_seconds"
+	// SLOPendingMessagesName is the number of executing messages in local-unsafe blocks that are not cross-unsafe yet.
+	// At most 256 blocks after the cross-unsafe head are counted.
+	SLOPendingMessagesName = SLONamespace + "_pending_messages"
+)
+
+// The values of the "head" label of SLOHeadTimestampName.
+const (
+	SLOHeadLocalUnsafe = "local_unsafe"
+	SLOHeadCrossUnsafe = "cross_unsafe"
+	SLOHeadLocalSafe   = "local_safe"
+	SLOHeadCrossSafe   = "cross_safe"
+	SLOHeadFinalized   = "finalized"
+)
+
+// ProgressSource provides the derivation progress of every chain, read at scrape time.
+type ProgressSource interface {
+	ChainProgress() map[eth.ChainID]types.ChainProgress
+}
+
+// SLOCollector collects the SLO metrics from a ProgressSource at scrape time.
+// Nothing is collected until a source is set.
+type SLOCollector struct {
+	mu     sync.Mutex
+	source ProgressSource
+	now    func() time.Time
+
+	headTimestamp   *prometheus.Desc
+	l1OriginLag     *prometheus.Desc
+	pendingMessages *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*SLOCollector)(nil)
+
+func NewSLOCollector() *SLOCollector {
+	return &SLOCollector{
+		now: time.Now,
+		headTimestamp: prometheus.NewDesc(SLOHeadTimestampName,
+			"Timestamp of the L2 block at the head of the chain, by head",
+			[]string{"chain", "head"}, nil),
+		l1OriginLag: prometheus.NewDesc(SLOL1OriginLagName,
+			"Seconds that the L1 block the derivation of the chain has reached is behind the wall clock",
+			[]string{"chain"}, nil),
+		pendingMessages: prometheus.NewDesc(SLOPendingMessagesName,
+			"Executing messages in local-unsafe blocks that are not cross-unsafe yet",
+			[]string{"chain"}, nil),
+	}
+}
+
+// SetSource sets the source that the metrics are collected from.
+func (c *SLOCollector) SetSource(source ProgressSource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.source = source
+}
+
+func (c *SLOCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.headTimestamp
+	ch <- c.l1OriginLag
+	ch <- c.pendingMessages
+}
+
+func (c *SLOCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	source := c.source
+	c.mu.Unlock()
+	if source == nil {
+		return
+	}
+	now := uint64(c.now().Unix())
+	for chainID, p := range source.ChainProgress() {
+		chain := chainIDLabel(chainID)
+		for _, head := range []struct {
+			name      string
+			timestamp uint64
+		}{
+			{SLOHeadLocalUnsafe, p.LocalUnsafe},
+			{SLOHeadCrossUnsafe, p.CrossUnsafe},
+			{SLOHeadLocalSafe, p.LocalSafe},
+			{SLOHeadCrossSafe, p.CrossSafe},
+			{SLOHeadFinalized, p.Finalized},
+		} {
+			if head.timestamp == 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(c.headTimestamp, prometheus.GaugeValue, float64(head.timestamp), chain, head.name)
+		}
+		if p.L1Origin != 0 {
+			var lag uint64
+			if now > p.L1Origin {
+				lag = now - p.L1Origin
+			}
+			ch <- prometheus.MustNewConstMetric(c.l1OriginLag, prometheus.GaugeValue, float64(lag), chain)
+		}
+		ch <- prometheus.MustNewConstMetric(c.pendingMessages, prometheus.GaugeValue, float64(p.PendingMessages), chain)
+	}
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubProgressSource map[eth.ChainID]types.ChainProgress
+
+func (s stubProgressSource) ChainProgress() map[eth.ChainID]types.ChainProgress {
+	return s
+}
+
+func TestSLOCollector(t *testing.T) {
+	c := NewSLOCollector()
+	c.now = func() time.Time { return time.Unix(1100, 0) }
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(c)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Empty(t, families, "nothing is collected without a source")
+
+	c.SetSource(stubProgressSource{
+		eth.ChainIDFromUInt64(900): {LocalUnsafe: 2040, CrossUnsafe: 2000, L1Origin: 1000, PendingMessages: 3},
+	})
+	families, err = registry.Gather()
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			values[family.GetName()+labelString(m)] = m.GetGauge().GetValue()
+		}
+	}
+	// The names and labels of the SLO metrics are a stable API, and must not change.
+	require.Equal(t, map[string]float64{
+		`op_supervisor_slo_head_timestamp_seconds{chain="900",head="local_unsafe"}`: 2040,
+		`op_supervisor_slo_head_timestamp_seconds{chain="900",head="cross_unsafe"}`: 2000,
+		`op_supervisor_slo_l1_origin_lag_seconds{chain="900"}`:                      100,
+		`op_supervisor_slo_pending_messages{chain="900"}`:                           3,
+	}, values)
+}
+
+func labelString(m *dto.Metric) string {
+	out := "{"
+	for i, l := range m.GetLabel() {
+		if i > 0 {
+			out += ","
+		}
+		out += l.GetName() + `="` + l.GetValue() + `"`
+	}
+	return out + "}"
+}
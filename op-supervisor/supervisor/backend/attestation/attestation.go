@@ -0,0 +1,143 @@
+package attestation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/cross"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var ErrDisabled = errors.New("attestation ingestion is disabled")
+
+type chainAttestations struct {
+	// byNumber holds the attestations of each attested block number, of any block hash.
+	byNumber map[uint64][]types.AttestationRecord
+	// numbers are the attested block numbers, in ascending order.
+	numbers []uint64
+}
+
+// Attestations ingests signed attestations of L2 blocks from external attestors,
+// and keeps the verified attestations of the most recently attested blocks of each chain.
+// With a quorum, it is the cross-safe policy that holds back blocks without enough attestations.
+// Attestations are kept in memory: attestors have to submit them again after a restart of the supervisor.
+type Attestations struct {
+	log       log.Logger
+	verifier  Verifier
+	quorum    int
+	retention int
+
+	// now is the clock of the ingestion times, replaced in tests.
+	now func() time.Time
+
+	mu     sync.Mutex
+	chains map[eth.ChainID]*chainAttestations
+}
+
+var _ cross.SafePolicy = (*Attestations)(nil)
+
+// New creates an attestation store of the given chains.
+// Ingestion is disabled if the verifier is nil.
+// Blocks need attestations of quorum distinct attestors to be promoted to cross-safe, if the quorum is positive.
+func New(log log.Logger, verifier Verifier, quorum int, retention int, chains []eth.ChainID) *Attestations {
+	states := make(map[eth.ChainID]*chainAttestations, len(chains))
+	for _, id := range chains {
+		states[id] = &chainAttestations{byNumber: make(map[uint64][]types.AttestationRecord)}
+	}
+	return &Attestations{
+		log:       log.New("component", "attestations"),
+		verifier:  verifier,
+		quorum:    quorum,
+		retention: retention,
+		now:       time.Now,
+		chains:    states,
+	}
+}
+
+// Submit verifies the attestation, and stores it.
+// An attestor that attests the same block with the same kind again replaces its previous attestation.
+func (a *Attestations) Submit(att *types.SignedAttestation) (types.AttestationRecord, error) {
+	if a.verifier == nil {
+		return types.AttestationRecord{}, ErrDisabled
+	}
+	signer, err := a.verifier.Verify(att)
+	if err != nil {
+		return types.AttestationRecord{}, err
+	}
+	rec := types.AttestationRecord{
+		Attestation: att.Attestation,
+		Signer:      signer,
+		ReceivedAt:  hexutil.Uint64(a.now().Unix()),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	st, ok := a.chains[att.ChainID]
+	if !ok {
+		return types.AttestationRecord{}, fmt.Errorf("%w: %s", types.ErrUnknownChain, att.ChainID)
+	}
+	num := att.Block.Number
+	records, ok := st.byNumber[num]
+	if !ok {
+		if len(st.numbers) >= a.retention && num < st.numbers[0] {
+			return types.AttestationRecord{}, fmt.Errorf("block %d of chain %s is older than the retained attestations", num, att.ChainID)
+		}
+		i, _ := slices.BinarySearch(st.numbers, num)
+		st.numbers = slices.Insert(st.numbers, i, num)
+		if len(st.numbers) > a.retention {
+			delete(st.byNumber, st.numbers[0])
+			st.numbers = st.numbers[1:]
+		}
+	}
+	records = slices.DeleteFunc(records, func(r types.AttestationRecord) bool {
+		return r.Signer == signer && r.Block == att.Block && r.Kind == att.Kind
+	})
+	st.byNumber[num] = append(records, rec)
+	a.log.Debug("Stored attestation", "chain", att.ChainID, "block", att.Block, "kind", att.Kind, "signer", signer)
+	return rec, nil
+}
+
+// Get returns the attestations of the given block.
+func (a *Attestations) Get(chainID eth.ChainID, block eth.BlockID) ([]types.AttestationRecord, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	st, ok := a.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrUnknownChain, chainID)
+	}
+	out := make([]types.AttestationRecord, 0)
+	for _, rec := range st.byNumber[block.Number] {
+		if rec.Block.Hash == block.Hash {
+			out = append(out, rec)
+		}
+	}
+	return out, nil
+}
+
+// CheckCrossSafe holds back the candidate until quorum distinct attestors attested it.
+func (a *Attestations) CheckCrossSafe(chainID eth.ChainID, candidate types.BlockSeal) error {
+	if a.quorum <= 0 {
+		return nil
+	}
+	records, err := a.Get(chainID, candidate.ID())
+	if err != nil {
+		return err
+	}
+	signers := make(map[common.Address]struct{}, len(records))
+	for _, rec := range records {
+		signers[rec.Signer] = struct{}{}
+	}
+	if len(signers) < a.quorum {
+		return fmt.Errorf("%w: block %s of chain %s has attestations of %d of %d attestors",
+			types.ErrFuture, candidate, chainID, len(signers), a.quorum)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var (
+	chainA = eth.ChainIDFromUInt64(900)
+	chainB = eth.ChainIDFromUInt64(901)
+)
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, att types.Attestation) *types.SignedAttestation {
+	sig, err := crypto.Sign(att.SigningHash().Bytes(), key)
+	require.NoError(t, err)
+	return &types.SignedAttestation{Attestation: att, Signature: sig}
+}
+
+func attestation(chainID eth.ChainID, num uint64) types.Attestation {
+	return types.Attestation{
+		ChainID: chainID,
+		Block:   eth.BlockID{Hash: common.Hash{byte(num)}, Number: num},
+		Kind:    "alt-da",
+		Data:    []byte{0x01},
+	}
+}
+
+func setup(t *testing.T, quorum int, retention int, attestors ...*ecdsa.PrivateKey) *Attestations {
+	signers := make([]common.Address, 0, len(attestors))
+	for _, key := range attestors {
+		signers = append(signers, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	a := New(testlog.Logger(t, log.LevelInfo), NewSignerSet(signers), quorum, retention, []eth.ChainID{chainA, chainB})
+	a.now = func() time.Time { return time.Unix(1000, 0) }
+	return a
+}
+
+func TestSignerSet(t *testing.T) {
+	key, other := newKey(t), newKey(t)
+	set := NewSignerSet([]common.Address{crypto.PubkeyToAddress(key.PublicKey)})
+	att := attestation(chainA, 1)
+
+	signer, err := set.Verify(sign(t, key, att))
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+
+	t.Run("27/28 recovery ID", func(t *testing.T) {
+		signed := sign(t, key, att)
+		signed.Signature[crypto.RecoveryIDOffset] += 27
+		signer, err := set.Verify(signed)
+		require.NoError(t, err)
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		_, err := set.Verify(sign(t, other, att))
+		require.ErrorIs(t, err, ErrUnauthorizedSigner)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		signed := sign(t, key, att)
+		signed.Kind = "other"
+		_, err := set.Verify(signed)
+		require.ErrorIs(t, err, ErrUnauthorizedSigner, "recovers a different signer")
+	})
+	t.Run("malformed", func(t *testing.T) {
+		signed := sign(t, key, att)
+		signed.Signature = signed.Signature[:64]
+		_, err := set.Verify(signed)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+}
+
+func TestSigningHashCoversChain(t *testing.T) {
+	a, b := attestation(chainA, 1), attestation(chainB, 1)
+	require.NotEqual(t, a.SigningHash(), b.SigningHash())
+}
+
+func TestSubmit(t *testing.T) {
+	key := newKey(t)
+	a := setup(t, 0, 10, key)
+	att := attestation(chainA, 1)
+
+	rec, err := a.Submit(sign(t, key, att))
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), rec.Signer)
+	require.EqualValues(t, 1000, rec.ReceivedAt)
+
+	// submitting again replaces the attestation
+	_, err = a.Submit(sign(t, key, att))
+	require.NoError(t, err)
+	records, err := a.Get(chainA, att.Block)
+	require.NoError(t, err)
+	require.Equal(t, []types.AttestationRecord{rec}, records)
+
+	// attestations of another block at the same height are kept apart
+	records, err = a.Get(chainA, eth.BlockID{Hash: common.Hash{0xff}, Number: 1})
+	require.NoError(t, err)
+	require.Empty(t, records)
+
+	_, err = a.Submit(sign(t, key, attestation(eth.ChainIDFromUInt64(1), 1)))
+	require.ErrorIs(t, err, types.ErrUnknownChain)
+	_, err = a.Get(eth.ChainIDFromUInt64(1), att.Block)
+	require.ErrorIs(t, err, types.ErrUnknownChain)
+}
+
+func TestSubmitDisabled(t *testing.T) {
+	a := New(testlog.Logger(t, log.LevelInfo), nil, 0, 10, []eth.ChainID{chainA})
+	_, err := a.Submit(sign(t, newKey(t), attestation(chainA, 1)))
+	require.ErrorIs(t, err, ErrDisabled)
+}
+
+func TestRetention(t *testing.T) {
+	key := newKey(t)
+	a := setup(t, 0, 2, key)
+	for _, num := range []uint64{2, 1, 3} {
+		_, err := a.Submit(sign(t, key, attestation(chainA, num)))
+		require.NoError(t, err)
+	}
+	// the oldest block is evicted
+	records, err := a.Get(chainA, attestation(chainA, 1).Block)
+	require.NoError(t, err)
+	require.Empty(t, records)
+	for _, num := range []uint64{2, 3} {
+		records, err := a.Get(chainA, attestation(chainA, num).Block)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+	}
+	// blocks older than the retained ones are rejected
+	_, err = a.Submit(sign(t, key, attestation(chainA, 1)))
+	require.ErrorContains(t, err, "older than the retained attestations")
+	// other chains are retained separately
+	_, err = a.Submit(sign(t, key, attestation(chainB, 1)))
+	require.NoError(t, err)
+}
+
+func TestCheckCrossSafe(t *testing.T) {
+	keyA, keyB := newKey(t), newKey(t)
+	a := setup(t, 2, 10, keyA, keyB)
+	att := attestation(chainA, 1)
+	candidate := types.BlockSeal{Hash: att.Block.Hash, Number: att.Block.Number}
+
+	require.ErrorIs(t, a.CheckCrossSafe(chainA, candidate), types.ErrFuture)
+	_, err := a.Submit(sign(t, keyA, att))
+	require.NoError(t, err)
+	// another kind of the same attestor does not count twice
+	other := att
+	other.Kind = "committee"
+	_, err = a.Submit(sign(t, keyA, other))
+	require.NoError(t, err)
+	require.ErrorIs(t, a.CheckCrossSafe(chainA, candidate), types.ErrFuture)
+
+	_, err = a.Submit(sign(t, keyB, att))
+	require.NoError(t, err)
+	require.NoError(t, a.CheckCrossSafe(chainA, candidate))
+	require.ErrorIs(t, a.CheckCrossSafe(chainB, candidate), types.ErrFuture, "attestations are per chain")
+
+	t.Run("no quorum", func(t *testing.T) {
+		a := setup(t, 0, 10, keyA)
+		require.NoError(t, a.CheckCrossSafe(chainA, candidate))
+	})
+}
@@ -0,0 +1,57 @@
+package attestation
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var (
+	ErrInvalidSignature   = errors.New("invalid attestation signature")
+	ErrUnauthorizedSigner = errors.New("attestation signer is not an authorized attestor")
+)
+
+// Verifier verifies the signature of an attestation, and returns the attestor that signed it.
+// Alternative trust models, e.g. threshold signatures of a committee, plug in as a Verifier.
+type Verifier interface {
+	Verify(att *types.SignedAttestation) (common.Address, error)
+}
+
+// SignerSet is a Verifier that accepts secp256k1 signatures of a fixed set of attestors.
+type SignerSet struct {
+	signers map[common.Address]struct{}
+}
+
+var _ Verifier = (*SignerSet)(nil)
+
+func NewSignerSet(signers []common.Address) *SignerSet {
+	set := &SignerSet{signers: make(map[common.Address]struct{}, len(signers))}
+	for _, addr := range signers {
+		set.signers[addr] = struct{}{}
+	}
+	return set
+}
+
+func (s *SignerSet) Verify(att *types.SignedAttestation) (common.Address, error) {
+	if len(att.Signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidSignature, crypto.SignatureLength, len(att.Signature))
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, att.Signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(att.SigningHash().Bytes(), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	signer := crypto.PubkeyToAddress(*pub)
+	if _, ok := s.signers[signer]; !ok {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrUnauthorizedSigner, signer)
+	}
+	return signer, nil
+}
@@ -11,24 +11,38 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/locks"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/safemath"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/attestation"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/breaker"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/causality"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/cross"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logindex"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/schema"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/superroots"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/sync"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/firehose"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/gossip"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/l1access"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/leadership"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/logindexer"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/processors"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/proofs"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/rewinder"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/status"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superindexer"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/frontend"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
@@ -68,6 +82,30 @@ type SupervisorBackend struct {
 	// statusTracker tracks the sync status of the supervisor
 	statusTracker *status.StatusTracker
 
+	// superRootsDB stores the super roots computed by the superIndexer
+	superRootsDB *superroots.DB
+
+	// superIndexer computes a super root every time all chains advance their cross-safe head
+	superIndexer *superindexer.Indexer
+
+	// firehose publishes the events of the events subscription
+	firehose *firehose.Firehose
+
+	// breaker pauses the cross-safe promotion of a chain on anomalies, until acknowledged by the operator
+	breaker *breaker.Breaker
+
+	// attestations stores the signed attestations of external attestors, and may hold back cross-safe promotion
+	attestations *attestation.Attestations
+
+	// leadership tracks the sequencer leadership of chains through op-conductor, nil if not configured
+	leadership *leadership.Tracker
+
+	// logIndexes index the events DB of each chain by log hash and by executing message checksum
+	logIndexes locks.RWMap[eth.ChainID, *logindex.DB]
+
+	// logIndexers keep the logIndexes in sync with the events DBs
+	logIndexers locks.RWMap[eth.ChainID, *logindexer.Indexer]
+
 	// synchronousProcessors disables background-workers,
 	// requiring manual triggers for the backend to process l2 data.
 	synchronousProcessors bool
@@ -76,6 +114,13 @@ type SupervisorBackend struct {
 	// they are reused for processors and databases of the same chain
 	chainMetrics locks.RWMap[eth.ChainID, *chainMetrics]
 
+	// chainCaches are the in-memory caches of each chain, sized by cacheConfig
+	chainCaches locks.RWMap[eth.ChainID, *chainCaches]
+	cacheConfig config.CacheConfig
+
+	// logDBCompression is the compression of new log DBs of each chain
+	logDBCompression config.LogDBCompressionConfig
+
 	emitter event.Emitter
 
 	// Rewinder for handling reorgs
@@ -83,6 +128,14 @@ type SupervisorBackend struct {
 
 	// rpcVerificationWarnings enables asynchronous RPC verification of DB checkAccess call in the CheckAccessList endpoint, indicating warnings as a metric
 	rpcVerificationWarnings bool
+
+	// queryProofs enables the LogProof endpoint
+	queryProofs bool
+
+	// depSetHash is the hash of the dependency set, that managed nodes must be configured with
+	depSetHash common.Hash
+	// allowDepSetMismatch manages nodes with a different dependency set, instead of refusing them
+	allowDepSetMismatch bool
 }
 
 var (
@@ -95,6 +148,7 @@ var (
 	errAlreadyStarted        = errors.New("already started")
 	errAttachProcessorSource = errors.New("cannot attach RPC to processor")
 	errAttachSyncSource      = errors.New("cannot attach RPC to sync source")
+	errQueryProofsDisabled   = errors.New("query proofs are disabled")
 
 	ErrUnexpectedMinSafetyLevel = errors.New("unexpected min-safety level")
 	ErrInternalBackendError     = errors.New("internal backend error")
@@ -129,6 +183,16 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger,
 		}
 	}
 
+	// Migrate the databases to the current schema before any of them is opened.
+	// This runs after the sync, so that synced databases are migrated too, instead of being stamped as current.
+	migrator, err := schema.NewMigrator(logger, cfg.Datadir, schema.Migrations)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrator.Migrate(ctx, false); err != nil {
+		return nil, fmt.Errorf("failed to migrate data directory: %w", err)
+	}
+
 	eventSys := event.NewSystem(logger, eventExec)
 	eventSys.AddTracer(event.NewMetricsTracer(m))
 
@@ -159,7 +223,15 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger,
 		rewinder: rewinder.New(logger, chainsDBs, l1Accessor),
 
 		rpcVerificationWarnings: cfg.RPCVerificationWarnings,
+		queryProofs:             cfg.QueryProofs,
+
+		depSetHash:          depset.Hash(cfgSet),
+		allowDepSetMismatch: cfg.AllowDependencySetMismatch,
+
+		cacheConfig:      cfg.Caches,
+		logDBCompression: cfg.LogDBCompression,
 	}
+	logger.Info("Loaded dependency set", "chains", len(cfgSet.Chains()), "hash", super.depSetHash)
 	eventSys.Register("backend", super)
 	eventSys.Register("rewinder", super.rewinder)
 
@@ -168,7 +240,7 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger,
 	eventSys.Register("sync-controller", super.syncNodesController)
 
 	// create status tracker
-	super.statusTracker = status.NewStatusTracker(cfgSet.Chains())
+	super.statusTracker = status.NewStatusTracker(cfgSet.Chains(), m)
 	eventSys.Register("status", super.statusTracker)
 
 	// Initialize the resources of the supervisor backend.
@@ -222,19 +294,32 @@ func (su *SupervisorBackend) OnEvent(ev event.Event) bool {
 			ChainID: x.ChainID,
 			Target:  x.NewLocalSafe.Derived.Number,
 		})
-		su.emitter.Emit(superevents.UpdateCrossSafeRequestEvent{
-			ChainID: x.ChainID,
-		})
+		su.requestCrossSafeUpdate(x.ChainID)
 	case superevents.CrossSafeUpdateEvent:
-		su.emitter.Emit(superevents.UpdateCrossSafeRequestEvent{
-			ChainID: x.ChainID,
-		})
+		su.requestCrossSafeUpdate(x.ChainID)
+	case superevents.ChainRewoundEvent:
+		su.purgeChainCaches(x.ChainID)
+	case superevents.InvalidateLocalSafeEvent:
+		su.purgeChainCaches(x.ChainID)
+	case superevents.ReplaceBlockEvent:
+		su.purgeChainCaches(x.ChainID)
 	default:
 		return false
 	}
 	return true
 }
 
+// requestCrossSafeUpdate requests the cross-safe promotion of the chain, unless paused by the circuit breaker.
+func (su *SupervisorBackend) requestCrossSafeUpdate(chainID eth.ChainID) {
+	if su.breaker.Paused(chainID) {
+		su.logger.Debug("Cross-safe promotion is paused by the circuit breaker", "chain", chainID)
+		return
+	}
+	su.emitter.Emit(superevents.UpdateCrossSafeRequestEvent{
+		ChainID: chainID,
+	})
+}
+
 func (su *SupervisorBackend) AttachEmitter(em event.Emitter) {
 	su.emitter = em
 }
@@ -252,21 +337,65 @@ func (su *SupervisorBackend) initResources(ctx context.Context, cfg *config.Conf
 		}
 	}
 
+	superRootsDB, err := db.OpenSuperRootsDB(su.logger, cfg.Datadir)
+	if err != nil {
+		return fmt.Errorf("failed to open super-roots DB: %w", err)
+	}
+	su.superRootsDB = superRootsDB
+	su.superIndexer = superindexer.New(su.logger, chains, su, superRootsDB)
+	su.eventSys.Register("super-indexer", su.superIndexer)
+	su.firehose = firehose.New(su.logger, su.chainDBs)
+	su.eventSys.Register("firehose", su.firehose)
+	su.breaker = breaker.New(su.logger, cfg.CircuitBreaker, chains, su.m)
+	su.eventSys.Register("circuit-breaker", su.breaker)
+	if cfg.CircuitBreaker.Enabled {
+		su.logger.Info("Circuit breaker enabled", "window", cfg.CircuitBreaker.Window)
+	}
+	su.eventSys.Register("gossip-tracker", gossip.New(su.logger, su.m))
+	var verifier attestation.Verifier
+	if cfg.Attestations.Enabled() {
+		verifier = attestation.NewSignerSet(cfg.Attestations.Attestors)
+		su.logger.Info("Attestation ingestion enabled", "attestors", len(cfg.Attestations.Attestors), "quorum", cfg.Attestations.Quorum)
+	}
+	su.attestations = attestation.New(su.logger, verifier, cfg.Attestations.Quorum, cfg.Attestations.Retention, chains)
+	if cfg.Conductor.Enabled() {
+		if err := su.initLeadership(ctx, cfg.Conductor); err != nil {
+			return fmt.Errorf("failed to set up sequencer leadership tracking: %w", err)
+		}
+	}
+
+	var shadow *cross.Shadow
+	if cfg.ShadowCrossChecker != "" {
+		shadow, err = cross.NewShadow(cfg.ShadowCrossChecker, su.m)
+		if err != nil {
+			return fmt.Errorf("failed to setup shadow cross-safety checker: %w", err)
+		}
+		su.logger.Info("Running cross-safety checker in shadow mode", "active", cross.ActiveChecker, "shadow", cfg.ShadowCrossChecker)
+	}
 	// initialize all cross-unsafe processors
 	for _, chainID := range chains {
-		worker := cross.NewCrossUnsafeWorker(su.logger, chainID, su.chainDBs, su.linker)
+		worker := cross.NewCrossUnsafeWorker(oplog.SubsystemLogger(su.logger, fmt.Sprintf("cross-unsafe-%s", chainID)), chainID, su.chainDBs, su.linker, shadow)
 		su.eventSys.Register(fmt.Sprintf("cross-unsafe-%s", chainID), worker)
 	}
+	var policy cross.SafePolicy
+	if cfg.Attestations.Quorum > 0 {
+		policy = su.attestations
+	}
 	// initialize all cross-safe processors
 	for _, chainID := range chains {
-		worker := cross.NewCrossSafeWorker(su.logger, chainID, su.chainDBs, su.linker)
+		worker := cross.NewCrossSafeWorker(oplog.SubsystemLogger(su.logger, fmt.Sprintf("cross-safe-%s", chainID)), chainID, su.chainDBs, su.linker, shadow, policy)
 		su.eventSys.Register(fmt.Sprintf("cross-safe-%s", chainID), worker)
 	}
 	// For each chain initialize a chain processor service,
 	// after cross-unsafe workers are ready to receive updates
 	for _, chainID := range chains {
 		logProcessor := processors.NewLogProcessor(chainID, su.chainDBs)
-		chainProcessor := processors.NewChainProcessor(su.sysContext, su.logger, chainID, logProcessor, su.chainDBs)
+		caches, _ := su.chainCaches.Get(chainID)
+		rewinder := &purgingRewinder{DatabaseRewinder: su.chainDBs, caches: caches}
+		chainProcessor := processors.NewChainProcessor(su.sysContext, oplog.SubsystemLogger(su.logger, fmt.Sprintf("chain-processor-%s", chainID)), chainID, logProcessor, rewinder)
+		if su.leadership != nil {
+			chainProcessor.SetLeadership(su.leadership)
+		}
 		su.eventSys.Register(fmt.Sprintf("events-%s", chainID), chainProcessor)
 		su.chainProcessors.Set(chainID, chainProcessor)
 	}
@@ -300,19 +429,49 @@ func (su *SupervisorBackend) initResources(ctx context.Context, cfg *config.Conf
 	return nil
 }
 
+// initLeadership connects to the op-conductor of each configured chain, to track the sequencer leadership.
+// It is a sub-task of initResources.
+func (su *SupervisorBackend) initLeadership(ctx context.Context, cfg config.ConductorConfig) error {
+	conductors := make(map[eth.ChainID]leadership.Conductor, len(cfg.Endpoints))
+	for chainID, endpoint := range cfg.Endpoints {
+		if !su.cfgSet.HasChain(chainID) {
+			return fmt.Errorf("conductor configured for chain %s, which is not in the dependency set: %w", chainID, types.ErrUnknownChain)
+		}
+		c, err := leadership.DialConductor(ctx, su.logger, endpoint, su.m)
+		if err != nil {
+			return fmt.Errorf("failed to set up conductor of chain %s: %w", chainID, err)
+		}
+		conductors[chainID] = c
+	}
+	su.leadership = leadership.New(su.logger, cfg, conductors)
+	su.eventSys.Register("leadership", su.leadership)
+	su.logger.Info("Tracking sequencer leadership", "chains", len(conductors), "failoverWindow", cfg.FailoverWindow)
+	return nil
+}
+
 // openChainDBs initializes all the DB resources of a specific chain.
 // It is a sub-task of initResources.
 func (su *SupervisorBackend) openChainDBs(chainID eth.ChainID) error {
 	cm := newChainMetrics(chainID, su.m)
 	// create metrics and a logdb for the chain
 	su.chainMetrics.Set(chainID, cm)
+	su.chainCaches.Set(chainID, newChainCaches(cm, su.cacheConfig.ForChain(chainID)))
 
-	logDB, err := db.OpenLogDB(su.logger, chainID, su.dataDir, cm)
+	logDB, err := db.OpenLogDB(su.logger, chainID, su.dataDir, su.logDBCompression.ForChain(chainID), cm)
 	if err != nil {
 		return fmt.Errorf("failed to open logDB of chain %s: %w", chainID, err)
 	}
 	su.chainDBs.AddLogDB(chainID, logDB)
 
+	logIndex, err := db.OpenLogIndexDB(su.logger.New("db-kind", "log-index", "chainID", chainID), chainID, su.dataDir)
+	if err != nil {
+		return fmt.Errorf("failed to open log index of chain %s: %w", chainID, err)
+	}
+	su.logIndexes.Set(chainID, logIndex)
+	logIndexer := logindexer.New(su.logger, chainID, logDB, logIndex)
+	su.eventSys.Register(fmt.Sprintf("log-indexer-%s", chainID), logIndexer)
+	su.logIndexers.Set(chainID, logIndexer)
+
 	localDB, err := db.OpenLocalDerivationDB(su.logger.New("db-kind", "local-db", "chainID", chainID), chainID, su.dataDir, cm)
 	if err != nil {
 		return fmt.Errorf("failed to open local derived-from DB of chain %s: %w", chainID, err)
@@ -356,15 +515,52 @@ func (su *SupervisorBackend) AttachSyncNode(ctx context.Context, src syncnode.Sy
 	if !su.cfgSet.HasChain(chainID) {
 		return nil, fmt.Errorf("chain %s is not part of the interop dependency set: %w", chainID, types.ErrUnknownChain)
 	}
-	err = su.AttachProcessorSource(chainID, src)
+	protocol, err := src.Protocol(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managed-mode protocol of sync source: %w", err)
+	}
+	if err := protocol.Check(); err != nil {
+		return nil, fmt.Errorf("cannot manage sync source %s: %w", src, err)
+	}
+	if err := protocol.CheckDependencySet(su.depSetHash); err != nil {
+		if !su.allowDepSetMismatch {
+			return nil, fmt.Errorf("cannot manage sync source %s: %w", src, err)
+		}
+		su.logger.Warn("Managing sync source with a different dependency set", "source", src, "err", err)
+	} else if protocol.DependencySetHash == nil {
+		su.logger.Warn("Sync source did not report its dependency set, cannot check it", "source", src)
+	}
+	// The processor and RPC verification read through the chain caches,
+	// the node controller always needs the latest data of the node itself.
+	cachedSrc := su.cachingSource(chainID, src)
+	err = su.AttachProcessorSource(chainID, cachedSrc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach sync source to processor: %w", err)
 	}
-	err = su.AttachSyncSource(chainID, src)
+	err = su.AttachSyncSource(chainID, cachedSrc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach sync source to node: %w", err)
 	}
-	return su.syncNodesController.AttachNodeController(chainID, src, noSubscribe)
+	return su.syncNodesController.AttachNodeController(chainID, src, protocol, noSubscribe)
+}
+
+// cachingSource wraps the sync source, to read through the caches of the chain.
+func (su *SupervisorBackend) cachingSource(chainID eth.ChainID, src syncnode.SyncSource) syncnode.SyncSource {
+	caches, ok := su.chainCaches.Get(chainID)
+	if !ok {
+		return src
+	}
+	return &cachingSyncSource{SyncSource: src, caches: caches}
+}
+
+// purgeChainCaches purges the cached data of the chain that depends on the canonical chain,
+// after the chain data of the supervisor changed.
+func (su *SupervisorBackend) purgeChainCaches(chainID eth.ChainID) {
+	caches, ok := su.chainCaches.Get(chainID)
+	if !ok {
+		return
+	}
+	caches.purgeCanonical()
 }
 
 func (su *SupervisorBackend) AttachProcessorSource(chainID eth.ChainID, src processors.Source) error {
@@ -425,6 +621,16 @@ func (su *SupervisorBackend) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to resume chains db: %w", err)
 	}
 
+	su.superIndexer.Start()
+	su.firehose.Start()
+	if su.leadership != nil {
+		su.leadership.Start()
+	}
+	su.logIndexers.Range(func(_ eth.ChainID, ix *logindexer.Indexer) bool {
+		ix.Start()
+		return true
+	})
+
 	return nil
 }
 
@@ -444,8 +650,25 @@ func (su *SupervisorBackend) Stop(ctx context.Context) error {
 
 	su.syncNodesController.Close()
 
+	su.superIndexer.Stop()
+	su.firehose.Stop()
+	if su.leadership != nil {
+		su.leadership.Stop()
+	}
+	su.logIndexers.Range(func(_ eth.ChainID, ix *logindexer.Indexer) bool {
+		ix.Stop()
+		return true
+	})
+
 	// close the databases
-	return su.chainDBs.Close()
+	result := errors.Join(su.chainDBs.Close(), su.superRootsDB.Close())
+	su.logIndexes.Range(func(id eth.ChainID, index *logindex.DB) bool {
+		if err := index.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close log index of chain %s: %w", id, err))
+		}
+		return true
+	})
+	return result
 }
 
 // AddL2RPC attaches an RPC as the RPC for the given chain, overriding the previous RPC source, if any.
@@ -492,18 +715,44 @@ func (su *SupervisorBackend) asyncVerifyAccessWithRPC(ctx context.Context, acc t
 	timeoutCtx, cancel := context.WithTimeout(ctx, verifyAccessWithRPCTimeout)
 	defer cancel()
 	msgBlockFromRPC, err := su.checkAccessWithRPC(timeoutCtx, acc)
+	checksumFailed := false
 	if errors.Is(err, types.ErrConflict) {
 		su.logger.Error("RPC access checksum failed", "err", err, "access", acc)
 		su.m.RecordAccessListVerifyFailure(acc.ChainID)
+		checksumFailed = true
 	} else {
 		su.logger.Error("RPC access check failed mechanically", "err", err, "access", acc)
 	}
 	if msgBlockFromDB != msgBlockFromRPC {
 		su.logger.Error("RPC access check failed, DB access check result did not match rpc access check result", "db_block", msgBlockFromDB, "rpc_block", msgBlockFromRPC, "access", acc)
 		su.m.RecordAccessListVerifyFailure(acc.ChainID)
+		checksumFailed = true
+	}
+	// mechanical failures say nothing about the data, and are not counted by the circuit breaker
+	if checksumFailed || err == nil {
+		su.breaker.RecordChecksumCheck(acc.ChainID, checksumFailed)
 	}
 }
 
+// checkAccessWithCache is checkAccessWithDB, with the results cached per chain.
+// Only successful checks are cached, since the initiating message may still be added to the DB.
+func (su *SupervisorBackend) checkAccessWithCache(acc types.Access) (eth.BlockID, error) {
+	caches, ok := su.chainCaches.Get(acc.ChainID)
+	if !ok {
+		return su.checkAccessWithDB(acc)
+	}
+	if includedIn, ok := caches.getAccessCheck(acc); ok {
+		return includedIn, nil
+	}
+	generation := caches.currentGeneration()
+	includedIn, err := su.checkAccessWithDB(acc)
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	caches.addAccessCheck(generation, acc, includedIn)
+	return includedIn, nil
+}
+
 // checkAccessWithRPC verifies if the initiating log exists by RPC call. Returns
 // an AccessListCheckError if the check succeeds "mechanically" (block header is
 // fetched, receipts are fetched, log exists) but the log checksum does not
@@ -594,7 +843,7 @@ func (su *SupervisorBackend) CheckAccessList(ctx context.Context, inboxEntries [
 			}
 		}
 
-		msgBlockFromDB, err := su.checkAccessWithDB(acc)
+		msgBlockFromDB, err := su.checkAccessWithCache(acc)
 		if err != nil {
 			su.logger.Debug("Access-list inclusion check failed", "err", err)
 			return types.ErrConflict
@@ -613,6 +862,45 @@ func (su *SupervisorBackend) CheckAccessList(ctx context.Context, inboxEntries [
 	return h.Err()
 }
 
+// CheckAccessListAt checks the access-list as it was valid in the block of the given number of the executing chain,
+// rather than at the current head: the messages are linked with the timestamp of that block, and no safety level is required.
+// This is used to reconstruct whether a block was valid at the time it was built.
+func (su *SupervisorBackend) CheckAccessListAt(ctx context.Context, inboxEntries []common.Hash,
+	chainID eth.ChainID, blockNumber hexutil.Uint64) error {
+	h := su.chainDBs.AcquireHandle()
+	defer h.Release()
+
+	execBlock, err := su.chainDBs.FindSealedBlock(chainID, uint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("failed to find executing block %d of chain %s: %w", blockNumber, chainID, err)
+	}
+	su.logger.Debug("Checking historical access-list", "chain", chainID, "block", execBlock, "length", len(inboxEntries))
+
+	entries := inboxEntries
+	for len(entries) > 0 {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("stopped access-list check early: %w", err)
+		}
+		remaining, acc, err := types.ParseAccess(entries)
+		if err != nil {
+			return fmt.Errorf("failed to read data: %w", err)
+		}
+		entries = remaining
+
+		h.DependOnDerivedTime(acc.Timestamp)
+
+		if !su.linker.CanExecute(chainID, execBlock.Timestamp, acc.ChainID, acc.Timestamp) {
+			su.logger.Debug("Historical access-list link check failed", "initChain", acc.ChainID, "initTimestamp", acc.Timestamp)
+			return types.ErrConflict
+		}
+		if _, err := su.checkAccessWithCache(acc); err != nil {
+			su.logger.Debug("Historical access-list inclusion check failed", "err", err)
+			return types.ErrConflict
+		}
+	}
+	return h.Err()
+}
+
 func (su *SupervisorBackend) CrossSafe(ctx context.Context, chainID eth.ChainID) (types.DerivedIDPair, error) {
 	p, err := su.chainDBs.CrossSafe(chainID)
 	if err != nil {
@@ -747,6 +1035,10 @@ func (su *SupervisorBackend) SuperRootAtTimestamp(ctx context.Context, timestamp
 			su.logger.Error("bug: unknown chain %s, cannot get sync source", chainID)
 			return eth.SuperRootResponse{}, fmt.Errorf("unknown chain %s, cannot get sync source: %w", chainID, ErrInternalBackendError)
 		}
+		if src == nil {
+			// The super root indexer may run before the sync sources are attached
+			return eth.SuperRootResponse{}, fmt.Errorf("no sync source attached for chain %s", chainID)
+		}
 		output, err := src.OutputV0AtTimestamp(ctx, uint64(timestamp))
 		if err != nil {
 			return eth.SuperRootResponse{}, err
@@ -797,8 +1089,315 @@ func (su *SupervisorBackend) SuperRootAtTimestamp(ctx context.Context, timestamp
 	}, nil
 }
 
+// SuperRootRecordAtTimestamp returns the super root that was stored at exactly the given timestamp.
+func (su *SupervisorBackend) SuperRootRecordAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (types.SuperRootRecord, error) {
+	record, err := su.superRootsDB.AtTimestamp(uint64(timestamp))
+	if err != nil {
+		// Transform error to ethereum.NotFound at RPC boundary, like SuperRootAtTimestamp
+		if errors.Is(err, types.ErrFuture) || errors.Is(err, types.ErrSkipped) {
+			err = errors.Join(err, ethereum.NotFound)
+		}
+		return types.SuperRootRecord{}, fmt.Errorf("failed to get super root at %d: %w", timestamp, err)
+	}
+	return record, nil
+}
+
+// LatestSuperRootRecord returns the last stored super root.
+func (su *SupervisorBackend) LatestSuperRootRecord(ctx context.Context) (types.SuperRootRecord, error) {
+	record, err := su.superRootsDB.Latest()
+	if err != nil {
+		if errors.Is(err, types.ErrFuture) {
+			err = errors.Join(err, ethereum.NotFound)
+		}
+		return types.SuperRootRecord{}, fmt.Errorf("failed to get latest super root: %w", err)
+	}
+	return record, nil
+}
+
+// SuperRootsFeed returns the feed that every newly stored super root is sent to.
+func (su *SupervisorBackend) SuperRootsFeed() *gethevent.FeedOf[types.SuperRootRecord] {
+	return su.superIndexer.Feed()
+}
+
+// EventsFeed returns the feed that every event of the events subscription is sent to.
+func (su *SupervisorBackend) EventsFeed() *gethevent.FeedOf[types.SupervisorEvent] {
+	return su.firehose.Feed()
+}
+
+// Chains returns the chains of the dependency set.
+func (su *SupervisorBackend) Chains() []eth.ChainID {
+	return su.cfgSet.Chains()
+}
+
 func (su *SupervisorBackend) SyncStatus(ctx context.Context) (eth.SupervisorSyncStatus, error) {
-	return su.statusTracker.SyncStatus()
+	status, err := su.statusTracker.SyncStatus()
+	if err != nil {
+		return status, err
+	}
+	status.DependencySetHash = su.depSetHash
+	for _, chain := range status.Chains {
+		for i, node := range chain.Nodes {
+			chain.Nodes[i].DependencySetMismatch = node.DependencySetHash != nil && *node.DependencySetHash != su.depSetHash
+		}
+	}
+	return status, nil
+}
+
+func (su *SupervisorBackend) CrossSafeConstraints(ctx context.Context) (map[eth.ChainID]types.CrossSafeConstraint, error) {
+	return su.statusTracker.CrossSafeConstraints()
+}
+
+// maxPendingMessagesScan bounds the number of blocks that are opened to count the messages pending verification,
+// so a cross-unsafe head that is far behind does not make every metrics scrape expensive.
+const maxPendingMessagesScan = 256
+
+// ChainProgress returns the derivation progress of every chain, including the number of messages pending verification.
+// If the cross-unsafe head is more than maxPendingMessagesScan blocks behind,
+// only the messages in the blocks right after it are counted.
+func (su *SupervisorBackend) ChainProgress() map[eth.ChainID]types.ChainProgress {
+	progress := su.statusTracker.ChainProgress()
+	for chainID, p := range progress {
+		pending, err := su.pendingMessages(chainID)
+		if err != nil {
+			su.logger.Debug("Failed to count pending messages", "chain", chainID, "err", err)
+			continue
+		}
+		p.PendingMessages = pending
+		progress[chainID] = p
+	}
+	return progress
+}
+
+// pendingMessages counts the executing messages in the local-unsafe blocks that are not cross-unsafe yet.
+func (su *SupervisorBackend) pendingMessages(chainID eth.ChainID) (uint64, error) {
+	localUnsafe, err := su.chainDBs.LocalUnsafe(chainID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get local-unsafe head: %w", err)
+	}
+	crossUnsafe, err := su.chainDBs.CrossUnsafe(chainID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cross-unsafe head: %w", err)
+	}
+	end := min(localUnsafe.Number, crossUnsafe.Number+maxPendingMessagesScan)
+	var count uint64
+	for num := crossUnsafe.Number + 1; num <= end; num++ {
+		_, _, execMsgs, err := su.chainDBs.OpenBlock(chainID, num)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open block %d: %w", num, err)
+		}
+		count += uint64(len(execMsgs))
+	}
+	return count, nil
+}
+
+// Snapshot returns the heads and pending message counts of all chains, as of a single point in time.
+// The view is read again if the chain databases were written to while reading it.
+func (su *SupervisorBackend) Snapshot(ctx context.Context) (types.SupervisorSnapshot, error) {
+	var snapshot types.SupervisorSnapshot
+	err := su.chainDBs.ConsistentRead(ctx, func() error {
+		snapshot = types.SupervisorSnapshot{
+			FinalizedL1: su.chainDBs.FinalizedL1(),
+			Chains:      make(map[eth.ChainID]types.ChainSnapshot),
+		}
+		for _, chainID := range su.cfgSet.Chains() {
+			chain, err := su.chainSnapshot(chainID)
+			if err != nil {
+				return fmt.Errorf("failed to read chain %s: %w", chainID, err)
+			}
+			snapshot.Chains[chainID] = chain
+		}
+		return nil
+	})
+	if err != nil {
+		return types.SupervisorSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (su *SupervisorBackend) chainSnapshot(chainID eth.ChainID) (types.ChainSnapshot, error) {
+	var out types.ChainSnapshot
+	var err error
+	// Heads that are not known yet are left zero
+	ignoreFuture := func(err error) error {
+		if errors.Is(err, types.ErrFuture) {
+			return nil
+		}
+		return err
+	}
+	if out.LocalUnsafe, err = su.chainDBs.LocalUnsafe(chainID); ignoreFuture(err) != nil {
+		return out, fmt.Errorf("failed to get local-unsafe head: %w", err)
+	}
+	if out.CrossUnsafe, err = su.chainDBs.CrossUnsafe(chainID); ignoreFuture(err) != nil {
+		return out, fmt.Errorf("failed to get cross-unsafe head: %w", err)
+	}
+	if out.LocalSafe, err = su.chainDBs.LocalSafe(chainID); ignoreFuture(err) != nil {
+		return out, fmt.Errorf("failed to get local-safe head: %w", err)
+	}
+	if out.CrossSafe, err = su.chainDBs.CrossSafe(chainID); ignoreFuture(err) != nil {
+		return out, fmt.Errorf("failed to get cross-safe head: %w", err)
+	}
+	if out.Finalized, err = su.chainDBs.Finalized(chainID); ignoreFuture(err) != nil {
+		return out, fmt.Errorf("failed to get finalized head: %w", err)
+	}
+	if out.LocalUnsafe != (types.BlockSeal{}) && out.CrossUnsafe != (types.BlockSeal{}) {
+		if out.PendingMessages, err = su.pendingMessages(chainID); err != nil {
+			return out, fmt.Errorf("failed to count pending messages: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// maxCausalityGraphBlocks bounds the number of blocks of each chain in a causality graph,
+// so a large time range does not make the supervisor open an unbounded number of blocks.
+const maxCausalityGraphBlocks = 1024
+
+// CausalityGraph returns the graph of the blocks of all chains with a timestamp in [from, to],
+// with edges from the L1 blocks they were derived from, and from the blocks of the messages they execute.
+func (su *SupervisorBackend) CausalityGraph(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64) (*types.CausalityGraph, error) {
+	return causality.Build(su.chainDBs, su.cfgSet.Chains(), uint64(from), uint64(to), maxCausalityGraphBlocks)
+}
+
+const (
+	// logProofSegment aligns the first block of the blocks tree of a log proof,
+	// so proofs of logs of nearby blocks share the same blocks root.
+	logProofSegment = 256
+	// maxLogProofBlocks bounds the number of blocks of the blocks tree of a log proof.
+	maxLogProofBlocks = 4096
+)
+
+// LogProof returns the merkle proof of a log, bound to the current cross-safe head of its chain.
+func (su *SupervisorBackend) LogProof(ctx context.Context, chainID eth.ChainID, blockNum hexutil.Uint64, logIdx uint32) (*types.LogProof, error) {
+	if !su.queryProofs {
+		return nil, errQueryProofsDisabled
+	}
+	return proofs.Build(su.chainDBs, chainID, uint64(blockNum), logIdx, logProofSegment, maxLogProofBlocks)
+}
+
+// ExecutingMessages returns the logs, across all chains, that execute the message with the given checksum.
+// This is served from the log indexes, which may lag slightly behind the events DBs.
+func (su *SupervisorBackend) ExecutingMessages(ctx context.Context, checksum types.MessageChecksum) ([]types.LogLocation, error) {
+	result := make([]types.LogLocation, 0)
+	for _, chainID := range su.cfgSet.Chains() {
+		index, ok := su.logIndexes.Get(chainID)
+		if !ok {
+			continue
+		}
+		for _, loc := range index.ExecutingMessages(checksum) {
+			seal, err := su.chainDBs.FindSealedBlock(chainID, loc.BlockNum)
+			if errors.Is(err, types.ErrFuture) {
+				continue // the events DB was rewound, and the index did not catch up yet
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to find block %d of chain %s: %w", loc.BlockNum, chainID, err)
+			}
+			result = append(result, types.LogLocation{
+				ChainID:     chainID,
+				BlockHash:   seal.Hash,
+				BlockNumber: seal.Number,
+				LogIndex:    loc.LogIdx,
+			})
+		}
+	}
+	return result, nil
+}
+
+// ChecksumPreimage returns the components that the given message checksum is computed from.
+// The message is found through the executing messages of the log indexes, and the log hash of the initiating message
+// is read from the events DB of the initiating chain. ErrFuture is returned if no indexed executing message, with
+// a known initiating message, has the checksum.
+func (su *SupervisorBackend) ChecksumPreimage(ctx context.Context, checksum types.MessageChecksum) (types.ChecksumPreimage, error) {
+	for _, chainID := range su.cfgSet.Chains() {
+		index, ok := su.logIndexes.Get(chainID)
+		if !ok {
+			continue
+		}
+		for _, loc := range index.ExecutingMessages(checksum) {
+			_, _, execMsgs, err := su.chainDBs.OpenBlock(chainID, loc.BlockNum)
+			if errors.Is(err, types.ErrFuture) {
+				continue // the events DB was rewound, and the index did not catch up yet
+			} else if err != nil {
+				return types.ChecksumPreimage{}, fmt.Errorf("failed to open block %d of chain %s: %w", loc.BlockNum, chainID, err)
+			}
+			msg, ok := execMsgs[loc.LogIdx]
+			if !ok || msg.Checksum != checksum {
+				continue // the block was replaced, and the index did not catch up yet
+			}
+			logHash, err := su.chainDBs.LogHash(msg.ChainID, msg.BlockNum, msg.LogIdx)
+			if errors.Is(err, types.ErrFuture) || errors.Is(err, types.ErrConflict) || errors.Is(err, types.ErrUnknownChain) {
+				continue // the initiating message is not known (yet), try the next executing message
+			} else if err != nil {
+				return types.ChecksumPreimage{}, fmt.Errorf("failed to read log %d of block %d of chain %s: %w", msg.LogIdx, msg.BlockNum, msg.ChainID, err)
+			}
+			preimage := types.ChecksumPreimage{
+				Checksum:    checksum,
+				ChainID:     msg.ChainID,
+				BlockNumber: msg.BlockNum,
+				LogIndex:    msg.LogIdx,
+				Timestamp:   msg.Timestamp,
+				LogHash:     logHash,
+			}
+			if preimage.ChecksumArgs().Checksum() != checksum {
+				continue // the executing message does not match the initiating message, it is invalid
+			}
+			return preimage, nil
+		}
+	}
+	return types.ChecksumPreimage{}, fmt.Errorf("no known message with checksum %s: %w", checksum, types.ErrFuture)
+}
+
+// RebuildLogIndex clears the log index of the given chain, and rebuilds it from the events DB in the background.
+func (su *SupervisorBackend) RebuildLogIndex(ctx context.Context, chain eth.ChainID) error {
+	ix, ok := su.logIndexers.Get(chain)
+	if !ok {
+		return fmt.Errorf("cannot rebuild log index: %w: %s", types.ErrUnknownChain, chain)
+	}
+	ix.Rebuild()
+	return nil
+}
+
+// CircuitBreakerStatus returns the circuit breaker status of every chain.
+func (su *SupervisorBackend) CircuitBreakerStatus(ctx context.Context) ([]types.CircuitBreakerStatus, error) {
+	return su.breaker.Status(), nil
+}
+
+// AcknowledgeCircuitBreaker resumes the cross-safe promotion of a chain that was paused by the circuit breaker.
+func (su *SupervisorBackend) AcknowledgeCircuitBreaker(ctx context.Context, chain eth.ChainID) error {
+	if err := su.breaker.Acknowledge(chain); err != nil {
+		return err
+	}
+	su.requestCrossSafeUpdate(chain)
+	return nil
+}
+
+// SubmitAttestation verifies and stores a signed attestation of an L2 block by an external attestor.
+// The cross-safe update of the attested chain is requested, as the attestation may complete a quorum.
+func (su *SupervisorBackend) SubmitAttestation(ctx context.Context, att types.SignedAttestation) (types.AttestationRecord, error) {
+	rec, err := su.attestations.Submit(&att)
+	if err != nil {
+		return types.AttestationRecord{}, err
+	}
+	su.requestCrossSafeUpdate(att.ChainID)
+	return rec, nil
+}
+
+// Attestations returns the stored attestations of the given L2 block.
+func (su *SupervisorBackend) Attestations(ctx context.Context, chainID eth.ChainID, block eth.BlockID) ([]types.AttestationRecord, error) {
+	return su.attestations.Get(chainID, block)
+}
+
+// PinL1 pins the view of the supervisor on the L1 chain to the given block, until unpinned.
+func (su *SupervisorBackend) PinL1(ctx context.Context, blockHash common.Hash) (eth.L1BlockRef, error) {
+	return su.l1Accessor.Pin(ctx, blockHash)
+}
+
+// UnpinL1 resumes the view of the supervisor on the L1 chain beyond the pinned block.
+func (su *SupervisorBackend) UnpinL1(ctx context.Context) error {
+	su.l1Accessor.Unpin()
+	return nil
+}
+
+// L1PinStatus returns whether the view of the supervisor on the L1 chain is pinned, and to which block.
+func (su *SupervisorBackend) L1PinStatus(ctx context.Context) (types.L1PinStatus, error) {
+	return su.l1Accessor.PinStatus(), nil
 }
 
 // PullLatestL1 makes the supervisor aware of the latest L1 block. Exposed for testing purposes.
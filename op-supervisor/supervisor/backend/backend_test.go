@@ -5,11 +5,13 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	types2 "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -147,6 +149,11 @@ func TestBackendLifetime_InteropAtGenesis(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, anchor.ID(), xsafe.Derived)
 
+	// Historical access-list checks need the executing block
+	require.NoError(t, b.CheckAccessListAt(context.Background(), nil, chainA, hexutil.Uint64(blockX.Number)))
+	err = b.CheckAccessListAt(context.Background(), nil, chainA, hexutil.Uint64(blockY.Number+1))
+	require.ErrorIs(t, err, types.ErrFuture)
+
 	// Revert cross-unafe back to block X
 	err = b.chainDBs.UpdateCrossUnsafe(chainA, types.BlockSealFromRef(blockX))
 	require.NoError(t, err)
@@ -155,6 +162,15 @@ func TestBackendLifetime_InteropAtGenesis(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, blockX.ID(), xunsafe)
 
+	snapshot, err := b.Snapshot(context.Background())
+	require.NoError(t, err)
+	require.Len(t, snapshot.Chains, 2)
+	require.Equal(t, types.BlockSealFromRef(blockY), snapshot.Chains[chainA].LocalUnsafe)
+	require.Equal(t, types.BlockSealFromRef(blockX), snapshot.Chains[chainA].CrossUnsafe)
+	require.Equal(t, anchor.ID(), snapshot.Chains[chainA].CrossSafe.Derived.ID())
+	require.Zero(t, snapshot.Chains[chainA].PendingMessages)
+	require.Equal(t, types.ChainSnapshot{}, snapshot.Chains[eth.ChainIDFromUInt64(testChainIDOffset+1)], "uninitialized chain")
+
 	// Receive derived block X from node
 
 	b.emitter.Emit(superevents.LocalDerivedEvent{
@@ -427,10 +443,38 @@ func (m *MockMetrics) RecordDBSearchEntriesRead(chainID eth.ChainID, count int64
 	m.Mock.Called(chainID, count)
 }
 
+func (m *MockMetrics) RecordDBCompressedSize(chainID eth.ChainID, rawBytes int64, compressedBytes int64) {
+	m.Mock.Called(chainID, rawBytes, compressedBytes)
+}
+
+func (m *MockMetrics) RecordDBDecompression(chainID eth.ChainID, d time.Duration) {
+	m.Mock.Called(chainID, d)
+}
+
 func (m *MockMetrics) RecordAccessListVerifyFailure(chainID eth.ChainID) {
 	m.Mock.Called(chainID)
 }
 
+func (m *MockMetrics) RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool) {
+	m.Mock.Called(chainID, dependency, lag, binding)
+}
+
+func (m *MockMetrics) RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool) {
+	m.Mock.Called(chainID, kind, diverged)
+}
+
+func (m *MockMetrics) RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool) {
+	m.Mock.Called(chainID, paused)
+}
+
+func (m *MockMetrics) RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration) {
+	m.Mock.Called(chainID, outcome, latency)
+}
+
+func (m *MockMetrics) RecordInvalidGossipInteropBlock(chainID eth.ChainID) {
+	m.Mock.Called(chainID)
+}
+
 type MockProcessorSource struct {
 	mock.Mock
 }
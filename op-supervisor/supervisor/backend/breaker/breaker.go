@@ -0,0 +1,212 @@
+package breaker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type Metrics interface {
+	RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool)
+}
+
+// checksumCheck is the outcome of an RPC-verified access check.
+type checksumCheck struct {
+	at     time.Time
+	failed bool
+}
+
+type chainState struct {
+	invalidations []time.Time
+	replacements  []time.Time
+	checks        []checksumCheck
+
+	// status is the status of the tripped breaker, nil if not tripped.
+	status *types.CircuitBreakerStatus
+}
+
+// Breaker is a per-chain circuit breaker: it watches each chain for anomalies,
+// and pauses the cross-safe promotion of a chain when one is detected.
+// The chain remains paused until the operator acknowledges the anomaly.
+// Detection is a safety brake for systemic failures, not a replacement for the cross-safety checks.
+type Breaker struct {
+	log log.Logger
+	cfg config.CircuitBreakerConfig
+	m   Metrics
+
+	// now is the clock of the anomaly windows, replaced in tests.
+	now func() time.Time
+
+	mu     sync.Mutex
+	chains []eth.ChainID
+	states map[eth.ChainID]*chainState
+}
+
+var _ event.Deriver = (*Breaker)(nil)
+
+func New(log log.Logger, cfg config.CircuitBreakerConfig, chains []eth.ChainID, m Metrics) *Breaker {
+	states := make(map[eth.ChainID]*chainState, len(chains))
+	for _, id := range chains {
+		states[id] = &chainState{}
+	}
+	return &Breaker{
+		log:    log.New("component", "circuit-breaker"),
+		cfg:    cfg,
+		m:      m,
+		now:    time.Now,
+		chains: chains,
+		states: states,
+	}
+}
+
+func (b *Breaker) OnEvent(ev event.Event) bool {
+	if !b.cfg.Enabled {
+		return false
+	}
+	switch x := ev.(type) {
+	case superevents.InvalidateLocalSafeEvent:
+		b.recordInvalidation(x.ChainID)
+	case superevents.ReplaceBlockEvent:
+		b.recordReplacement(x.ChainID)
+	default:
+		return false
+	}
+	return true
+}
+
+func (b *Breaker) recordInvalidation(chainID eth.ChainID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.states[chainID]
+	if !ok || st.status != nil {
+		return
+	}
+	now := b.now()
+	st.invalidations = append(pruneTimes(st.invalidations, now.Add(-b.cfg.Window)), now)
+	if b.cfg.MaxInvalidations > 0 && len(st.invalidations) > b.cfg.MaxInvalidations {
+		b.trip(chainID, st, types.AnomalyMassInvalidation,
+			fmt.Sprintf("%d local-safe invalidations within %s", len(st.invalidations), b.cfg.Window))
+	}
+}
+
+func (b *Breaker) recordReplacement(chainID eth.ChainID) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.states[chainID]
+	if !ok || st.status != nil {
+		return
+	}
+	now := b.now()
+	st.replacements = append(pruneTimes(st.replacements, now.Add(-b.cfg.Window)), now)
+	if b.cfg.MaxReplacements > 0 && len(st.replacements) > b.cfg.MaxReplacements {
+		b.trip(chainID, st, types.AnomalyReplacementStorm,
+			fmt.Sprintf("%d block replacements within %s", len(st.replacements), b.cfg.Window))
+	}
+}
+
+// RecordChecksumCheck records the outcome of the RPC verification of an access check of the given chain.
+func (b *Breaker) RecordChecksumCheck(chainID eth.ChainID, failed bool) {
+	if !b.cfg.Enabled {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.states[chainID]
+	if !ok || st.status != nil {
+		return
+	}
+	now := b.now()
+	cutoff := now.Add(-b.cfg.Window)
+	i := 0
+	for i < len(st.checks) && st.checks[i].at.Before(cutoff) {
+		i++
+	}
+	st.checks = append(st.checks[i:], checksumCheck{at: now, failed: failed})
+	if b.cfg.MinChecksumChecks == 0 || len(st.checks) < b.cfg.MinChecksumChecks {
+		return
+	}
+	failures := 0
+	for _, c := range st.checks {
+		if c.failed {
+			failures++
+		}
+	}
+	rate := float64(failures) / float64(len(st.checks))
+	if rate > b.cfg.MaxChecksumFailureRate {
+		b.trip(chainID, st, types.AnomalyChecksumFailures,
+			fmt.Sprintf("%d of %d access checks failed RPC verification within %s", failures, len(st.checks), b.cfg.Window))
+	}
+}
+
+func (b *Breaker) trip(chainID eth.ChainID, st *chainState, anomaly types.CircuitBreakerAnomaly, reason string) {
+	st.status = &types.CircuitBreakerStatus{
+		ChainID:   chainID,
+		Paused:    true,
+		Anomaly:   anomaly,
+		Reason:    reason,
+		TrippedAt: hexutil.Uint64(b.now().Unix()),
+	}
+	b.log.Error("Circuit breaker tripped, pausing cross-safe promotion until acknowledged",
+		"chain", chainID, "anomaly", anomaly, "reason", reason)
+	b.m.RecordCircuitBreakerPaused(chainID, true)
+}
+
+// Paused returns true if the cross-safe promotion of the chain is paused.
+func (b *Breaker) Paused(chainID eth.ChainID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.states[chainID]
+	return ok && st.status != nil
+}
+
+// Status returns the circuit breaker status of every chain.
+func (b *Breaker) Status() []types.CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make([]types.CircuitBreakerStatus, 0, len(b.chains))
+	for _, id := range b.chains {
+		if st := b.states[id]; st.status != nil {
+			out = append(out, *st.status)
+		} else {
+			out = append(out, types.CircuitBreakerStatus{ChainID: id})
+		}
+	}
+	return out
+}
+
+// Acknowledge resumes the cross-safe promotion of the chain, and resets its anomaly counters.
+// Acknowledging a chain that is not paused has no effect.
+func (b *Breaker) Acknowledge(chainID eth.ChainID) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.states[chainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", types.ErrUnknownChain, chainID)
+	}
+	if st.status == nil {
+		return nil
+	}
+	b.log.Warn("Circuit breaker acknowledged, resuming cross-safe promotion",
+		"chain", chainID, "anomaly", st.status.Anomaly, "reason", st.status.Reason)
+	b.states[chainID] = &chainState{}
+	b.m.RecordCircuitBreakerPaused(chainID, false)
+	return nil
+}
+
+// pruneTimes removes the times before the cutoff, from the start of the ordered times.
+func pruneTimes(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
@@ -0,0 +1,145 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubMetrics struct {
+	paused map[eth.ChainID]bool
+}
+
+func (s *stubMetrics) RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool) {
+	s.paused[chainID] = paused
+}
+
+var (
+	chainA = eth.ChainIDFromUInt64(900)
+	chainB = eth.ChainIDFromUInt64(901)
+)
+
+func setup(t *testing.T, cfg config.CircuitBreakerConfig) (*Breaker, *stubMetrics, *time.Time) {
+	m := &stubMetrics{paused: make(map[eth.ChainID]bool)}
+	b := New(testlog.Logger(t, log.LevelInfo), cfg, []eth.ChainID{chainA, chainB}, m)
+	now := time.Unix(1000, 0)
+	b.now = func() time.Time { return now }
+	return b, m, &now
+}
+
+func testConfig() config.CircuitBreakerConfig {
+	cfg := config.DefaultCircuitBreakerConfig()
+	cfg.Enabled = true
+	cfg.Window = time.Minute
+	cfg.MaxInvalidations = 2
+	cfg.MaxReplacements = 2
+	cfg.MaxChecksumFailureRate = 0.5
+	cfg.MinChecksumChecks = 4
+	return cfg
+}
+
+func TestBreakerMassInvalidation(t *testing.T) {
+	b, m, now := setup(t, testConfig())
+	invalidate := func(chainID eth.ChainID) {
+		require.True(t, b.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainID}))
+	}
+
+	invalidate(chainA)
+	invalidate(chainA)
+	require.False(t, b.Paused(chainA), "invalidations within the limit")
+
+	// older invalidations leave the window
+	*now = now.Add(2 * time.Minute)
+	invalidate(chainA)
+	invalidate(chainA)
+	require.False(t, b.Paused(chainA))
+
+	invalidate(chainA)
+	require.True(t, b.Paused(chainA))
+	require.False(t, b.Paused(chainB), "breaker is per chain")
+	require.True(t, m.paused[chainA])
+
+	status := b.Status()
+	require.Len(t, status, 2)
+	require.Equal(t, chainA, status[0].ChainID)
+	require.True(t, status[0].Paused)
+	require.Equal(t, types.AnomalyMassInvalidation, status[0].Anomaly)
+	require.EqualValues(t, now.Unix(), status[0].TrippedAt)
+	require.Equal(t, types.CircuitBreakerStatus{ChainID: chainB}, status[1])
+
+	// remains paused, even after the window passed
+	*now = now.Add(time.Hour)
+	require.True(t, b.Paused(chainA))
+
+	require.NoError(t, b.Acknowledge(chainA))
+	require.False(t, b.Paused(chainA))
+	require.False(t, m.paused[chainA])
+
+	// counters are reset by the acknowledgment
+	invalidate(chainA)
+	invalidate(chainA)
+	require.False(t, b.Paused(chainA))
+}
+
+func TestBreakerReplacementStorm(t *testing.T) {
+	b, _, _ := setup(t, testConfig())
+	for i := 0; i < 3; i++ {
+		b.OnEvent(superevents.ReplaceBlockEvent{ChainID: chainB})
+	}
+	require.True(t, b.Paused(chainB))
+	require.Equal(t, types.AnomalyReplacementStorm, b.Status()[1].Anomaly)
+	require.False(t, b.Paused(chainA))
+}
+
+func TestBreakerChecksumFailures(t *testing.T) {
+	b, _, _ := setup(t, testConfig())
+	b.RecordChecksumCheck(chainA, true)
+	b.RecordChecksumCheck(chainA, true)
+	b.RecordChecksumCheck(chainA, true)
+	require.False(t, b.Paused(chainA), "not enough checks to consider the rate")
+	b.RecordChecksumCheck(chainA, false)
+	require.True(t, b.Paused(chainA), "3 of 4 checks failed")
+	require.Equal(t, types.AnomalyChecksumFailures, b.Status()[0].Anomaly)
+
+	b.RecordChecksumCheck(chainB, true)
+	b.RecordChecksumCheck(chainB, true)
+	b.RecordChecksumCheck(chainB, false)
+	b.RecordChecksumCheck(chainB, false)
+	require.False(t, b.Paused(chainB), "2 of 4 checks failed, within the rate")
+}
+
+func TestBreakerDisabled(t *testing.T) {
+	cfg := testConfig()
+	cfg.Enabled = false
+	b, _, _ := setup(t, cfg)
+	for i := 0; i < 10; i++ {
+		require.False(t, b.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainA}))
+		b.RecordChecksumCheck(chainA, true)
+	}
+	require.False(t, b.Paused(chainA))
+}
+
+func TestBreakerDisabledDetector(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxInvalidations = 0
+	b, _, _ := setup(t, cfg)
+	for i := 0; i < 10; i++ {
+		b.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainA})
+	}
+	require.False(t, b.Paused(chainA))
+}
+
+func TestBreakerAcknowledgeUnknownChain(t *testing.T) {
+	b, _, _ := setup(t, testConfig())
+	require.ErrorIs(t, b.Acknowledge(eth.ChainIDFromUInt64(123)), types.ErrUnknownChain)
+	require.NoError(t, b.Acknowledge(chainA), "acknowledging an unpaused chain has no effect")
+}
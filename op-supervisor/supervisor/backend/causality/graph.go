@@ -0,0 +1,234 @@
+package causality
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// ChainsDB is the subset of the chains database that the causality graph is built from.
+type ChainsDB interface {
+	FindSealedBlock(chain eth.ChainID, number uint64) (types.BlockSeal, error)
+	LatestBlockNum(chain eth.ChainID) (num uint64, ok bool)
+	OpenBlock(chainID eth.ChainID, blockNum uint64) (ref eth.BlockRef, logCount uint32, execMsgs map[uint32]*types.ExecutingMessage, err error)
+	LocalDerivedToSource(chain eth.ChainID, derived eth.BlockID) (source types.BlockSeal, err error)
+
+	CrossUnsafe(chainID eth.ChainID) (types.BlockSeal, error)
+	LocalSafe(chainID eth.ChainID) (types.DerivedBlockSealPair, error)
+	CrossSafe(chainID eth.ChainID) (types.DerivedBlockSealPair, error)
+	Finalized(chainID eth.ChainID) (types.BlockSeal, error)
+}
+
+// head is the block number of the head of a chain at a safety level.
+type head struct {
+	level  types.SafetyLevel
+	number uint64
+}
+
+// heads are the heads of a chain, from the safest to the least safe.
+// Heads that are not known yet are omitted.
+type heads []head
+
+// safety returns the safety level of the block with the given number.
+func (h heads) safety(number uint64) types.SafetyLevel {
+	for _, hd := range h {
+		if number <= hd.number {
+			return hd.level
+		}
+	}
+	return types.LocalUnsafe
+}
+
+// builder accumulates the nodes and edges of a graph, without duplicate nodes.
+type builder struct {
+	db    ChainsDB
+	heads map[eth.ChainID]heads
+	graph *types.CausalityGraph
+	seen  map[string]struct{}
+}
+
+// Build returns the causality graph of the blocks of the chains with a timestamp in [from, to].
+// At most maxBlocks blocks of each chain are included, after which the graph is marked as truncated.
+func Build(db ChainsDB, chains []eth.ChainID, from uint64, to uint64, maxBlocks uint64) (*types.CausalityGraph, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid time range: from %d is after to %d", from, to)
+	}
+	b := &builder{
+		db:    db,
+		heads: make(map[eth.ChainID]heads, len(chains)),
+		graph: &types.CausalityGraph{
+			FromTimestamp: from,
+			ToTimestamp:   to,
+			Nodes:         []types.CausalityNode{},
+			Edges:         []types.CausalityEdge{},
+		},
+		seen: make(map[string]struct{}),
+	}
+	for _, chainID := range chains {
+		h, err := chainHeads(db, chainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get heads of chain %s: %w", chainID, err)
+		}
+		b.heads[chainID] = h
+	}
+	for _, chainID := range chains {
+		if err := b.addChain(chainID, from, to, maxBlocks); err != nil {
+			return nil, fmt.Errorf("failed to add blocks of chain %s: %w", chainID, err)
+		}
+	}
+	return b.graph, nil
+}
+
+func chainHeads(db ChainsDB, chainID eth.ChainID) (heads, error) {
+	var out heads
+	add := func(level types.SafetyLevel, seal types.BlockSeal, err error) error {
+		if errors.Is(err, types.ErrFuture) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to get %s head: %w", level, err)
+		}
+		out = append(out, head{level: level, number: seal.Number})
+		return nil
+	}
+	finalized, err := db.Finalized(chainID)
+	if err := add(types.Finalized, finalized, err); err != nil {
+		return nil, err
+	}
+	crossSafe, err := db.CrossSafe(chainID)
+	if err := add(types.CrossSafe, crossSafe.Derived, err); err != nil {
+		return nil, err
+	}
+	localSafe, err := db.LocalSafe(chainID)
+	if err := add(types.LocalSafe, localSafe.Derived, err); err != nil {
+		return nil, err
+	}
+	crossUnsafe, err := db.CrossUnsafe(chainID)
+	if err := add(types.CrossUnsafe, crossUnsafe, err); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// localSafe returns the number of the local-safe head of the chain, if known.
+func (h heads) localSafe() (uint64, bool) {
+	for _, hd := range h {
+		if hd.level == types.LocalSafe {
+			return hd.number, true
+		}
+	}
+	return 0, false
+}
+
+func (b *builder) addChain(chainID eth.ChainID, from uint64, to uint64, maxBlocks uint64) error {
+	latest, ok := b.db.LatestBlockNum(chainID)
+	if !ok {
+		return nil // nothing indexed yet
+	}
+	first, err := b.firstBlockAtOrAfter(chainID, from, latest)
+	if err != nil {
+		return err
+	}
+	localSafe, hasLocalSafe := b.heads[chainID].localSafe()
+	for num := first; num <= latest; num++ {
+		if num-first >= maxBlocks {
+			b.graph.Truncated = true
+			return nil
+		}
+		ref, _, execMsgs, err := b.db.OpenBlock(chainID, num)
+		if err != nil {
+			return fmt.Errorf("failed to open block %d: %w", num, err)
+		}
+		if ref.Time > to {
+			return nil
+		}
+		id := types.L2CausalityNodeID(chainID, num)
+		b.addNode(types.CausalityNode{
+			ID:      id,
+			ChainID: &chainID,
+			Block:   types.BlockSeal{Hash: ref.Hash, Number: ref.Number, Timestamp: ref.Time},
+			Safety:  b.heads[chainID].safety(num),
+		})
+
+		if hasLocalSafe && num <= localSafe {
+			source, err := b.db.LocalDerivedToSource(chainID, ref.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get source of block %d: %w", num, err)
+			}
+			sourceID := types.L1CausalityNodeID(source.Number)
+			b.addNode(types.CausalityNode{ID: sourceID, Block: source})
+			b.graph.Edges = append(b.graph.Edges, types.CausalityEdge{Kind: types.DerivationEdge, From: sourceID, To: id})
+		}
+
+		for _, logIdx := range slices.Sorted(maps.Keys(execMsgs)) {
+			msg := execMsgs[logIdx]
+			initID, err := b.addInitiatingBlock(msg, from, to)
+			if err != nil {
+				return fmt.Errorf("failed to add initiating block of log %d of block %d: %w", logIdx, num, err)
+			}
+			b.graph.Edges = append(b.graph.Edges, types.CausalityEdge{
+				Kind:         types.MessageEdge,
+				From:         initID,
+				To:           id,
+				InitLogIndex: msg.LogIdx,
+				ExecLogIndex: logIdx,
+			})
+		}
+	}
+	return nil
+}
+
+// firstBlockAtOrAfter returns the number of the first block of the chain with a timestamp at or after the given time.
+// This is latest+1 if there is no such block. Blocks that are pruned or skipped are considered to be before the time.
+func (b *builder) firstBlockAtOrAfter(chainID eth.ChainID, timestamp uint64, latest uint64) (uint64, error) {
+	var searchErr error
+	n := sort.Search(int(latest+1), func(i int) bool {
+		seal, err := b.db.FindSealedBlock(chainID, uint64(i))
+		if err != nil {
+			if !errors.Is(err, types.ErrSkipped) && !errors.Is(err, types.ErrFuture) && searchErr == nil {
+				searchErr = fmt.Errorf("failed to find block %d: %w", i, err)
+			}
+			return false
+		}
+		return seal.Timestamp >= timestamp
+	})
+	return uint64(n), searchErr
+}
+
+// addInitiatingBlock adds the node of the block that contains the initiating message, and returns its ID.
+// The block is added as missing if the supervisor did not index it.
+func (b *builder) addInitiatingBlock(msg *types.ExecutingMessage, from uint64, to uint64) (string, error) {
+	chainID := msg.ChainID
+	id := types.L2CausalityNodeID(chainID, msg.BlockNum)
+	if _, ok := b.seen[id]; ok {
+		return id, nil
+	}
+	node := types.CausalityNode{ID: id, ChainID: &chainID}
+	seal, err := b.db.FindSealedBlock(chainID, msg.BlockNum)
+	switch {
+	case errors.Is(err, types.ErrFuture) || errors.Is(err, types.ErrSkipped) || errors.Is(err, types.ErrUnknownChain):
+		node.Block = types.BlockSeal{Number: msg.BlockNum, Timestamp: msg.Timestamp}
+		node.Missing = true
+		node.External = msg.Timestamp < from || msg.Timestamp > to
+	case err != nil:
+		return "", err
+	default:
+		node.Block = seal
+		node.Safety = b.heads[chainID].safety(seal.Number)
+		node.External = seal.Timestamp < from || seal.Timestamp > to
+	}
+	b.addNode(node)
+	return id, nil
+}
+
+func (b *builder) addNode(node types.CausalityNode) {
+	if _, ok := b.seen[node.ID]; ok {
+		return
+	}
+	b.seen[node.ID] = struct{}{}
+	b.graph.Nodes = append(b.graph.Nodes, node)
+}
@@ -0,0 +1,245 @@
+package causality
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type mockChain struct {
+	blocks      []eth.BlockRef
+	execMsgs    map[uint64]map[uint32]*types.ExecutingMessage
+	sources     map[uint64]types.BlockSeal
+	crossUnsafe uint64
+	localSafe   uint64
+	crossSafe   uint64
+}
+
+type mockChainsDB struct {
+	chains map[eth.ChainID]*mockChain
+}
+
+var _ ChainsDB = (*mockChainsDB)(nil)
+
+func (m *mockChainsDB) chain(id eth.ChainID) (*mockChain, error) {
+	c, ok := m.chains[id]
+	if !ok {
+		return nil, types.ErrUnknownChain
+	}
+	return c, nil
+}
+
+func (m *mockChainsDB) FindSealedBlock(chain eth.ChainID, number uint64) (types.BlockSeal, error) {
+	c, err := m.chain(chain)
+	if err != nil {
+		return types.BlockSeal{}, err
+	}
+	if number >= uint64(len(c.blocks)) {
+		return types.BlockSeal{}, types.ErrFuture
+	}
+	return types.BlockSealFromRef(c.blocks[number]), nil
+}
+
+func (m *mockChainsDB) LatestBlockNum(chain eth.ChainID) (uint64, bool) {
+	c, err := m.chain(chain)
+	if err != nil || len(c.blocks) == 0 {
+		return 0, false
+	}
+	return uint64(len(c.blocks) - 1), true
+}
+
+func (m *mockChainsDB) OpenBlock(chainID eth.ChainID, blockNum uint64) (eth.BlockRef, uint32, map[uint32]*types.ExecutingMessage, error) {
+	c, err := m.chain(chainID)
+	if err != nil {
+		return eth.BlockRef{}, 0, nil, err
+	}
+	if blockNum >= uint64(len(c.blocks)) {
+		return eth.BlockRef{}, 0, nil, types.ErrFuture
+	}
+	return c.blocks[blockNum], 0, c.execMsgs[blockNum], nil
+}
+
+func (m *mockChainsDB) LocalDerivedToSource(chain eth.ChainID, derived eth.BlockID) (types.BlockSeal, error) {
+	c, err := m.chain(chain)
+	if err != nil {
+		return types.BlockSeal{}, err
+	}
+	source, ok := c.sources[derived.Number]
+	if !ok {
+		return types.BlockSeal{}, types.ErrFuture
+	}
+	return source, nil
+}
+
+func (m *mockChainsDB) CrossUnsafe(chainID eth.ChainID) (types.BlockSeal, error) {
+	c, err := m.chain(chainID)
+	if err != nil {
+		return types.BlockSeal{}, err
+	}
+	return types.BlockSealFromRef(c.blocks[c.crossUnsafe]), nil
+}
+
+func (m *mockChainsDB) LocalSafe(chainID eth.ChainID) (types.DerivedBlockSealPair, error) {
+	c, err := m.chain(chainID)
+	if err != nil {
+		return types.DerivedBlockSealPair{}, err
+	}
+	return types.DerivedBlockSealPair{Derived: types.BlockSealFromRef(c.blocks[c.localSafe])}, nil
+}
+
+func (m *mockChainsDB) CrossSafe(chainID eth.ChainID) (types.DerivedBlockSealPair, error) {
+	c, err := m.chain(chainID)
+	if err != nil {
+		return types.DerivedBlockSealPair{}, err
+	}
+	return types.DerivedBlockSealPair{Derived: types.BlockSealFromRef(c.blocks[c.crossSafe])}, nil
+}
+
+func (m *mockChainsDB) Finalized(chainID eth.ChainID) (types.BlockSeal, error) {
+	return types.BlockSeal{}, types.ErrFuture
+}
+
+// newMockChain creates a chain of n blocks, with a block every 2 seconds from genesis at the given time.
+func newMockChain(chainID eth.ChainID, n int, genesisTime uint64) *mockChain {
+	c := &mockChain{
+		execMsgs: make(map[uint64]map[uint32]*types.ExecutingMessage),
+		sources:  make(map[uint64]types.BlockSeal),
+	}
+	for i := 0; i < n; i++ {
+		c.blocks = append(c.blocks, eth.BlockRef{
+			Hash:   common.Hash{byte(chainID.ToBig().Uint64()), byte(i)},
+			Number: uint64(i),
+			Time:   genesisTime + 2*uint64(i),
+		})
+	}
+	return c
+}
+
+func TestBuild(t *testing.T) {
+	chainA := eth.ChainIDFromUInt64(900)
+	chainB := eth.ChainIDFromUInt64(901)
+	unknownChain := eth.ChainIDFromUInt64(902)
+
+	a := newMockChain(chainA, 10, 1000)
+	a.localSafe, a.crossSafe, a.crossUnsafe = 6, 4, 7
+	for i := uint64(0); i <= a.localSafe; i++ {
+		a.sources[i] = types.BlockSeal{Hash: common.Hash{0xaa, byte(i / 2)}, Number: 100 + i/2, Timestamp: 990 + i}
+	}
+	b := newMockChain(chainB, 10, 1001)
+	b.localSafe, b.crossSafe, b.crossUnsafe = 3, 3, 3
+	for i := uint64(0); i <= b.localSafe; i++ {
+		b.sources[i] = types.BlockSeal{Hash: common.Hash{0xaa, byte(i / 2)}, Number: 100 + i/2, Timestamp: 990 + i}
+	}
+	// block 5 of A executes a message of block 4 of B, which is not local-safe yet
+	a.execMsgs[5] = map[uint32]*types.ExecutingMessage{
+		2: {ChainID: chainB, BlockNum: 4, LogIdx: 1, Timestamp: 1009},
+	}
+	// block 6 of B executes a message of block 1 of A, outside the time range,
+	// and a message of a block of a chain that is not indexed
+	b.execMsgs[6] = map[uint32]*types.ExecutingMessage{
+		0: {ChainID: chainA, BlockNum: 1, LogIdx: 3, Timestamp: 1002},
+		1: {ChainID: unknownChain, BlockNum: 7, LogIdx: 0, Timestamp: 1012},
+	}
+	db := &mockChainsDB{chains: map[eth.ChainID]*mockChain{chainA: a, chainB: b}}
+
+	t.Run("time range", func(t *testing.T) {
+		graph, err := Build(db, []eth.ChainID{chainA, chainB}, 1008, 1013, 100)
+		require.NoError(t, err)
+		require.False(t, graph.Truncated)
+
+		nodes := make(map[string]types.CausalityNode)
+		for _, n := range graph.Nodes {
+			nodes[n.ID] = n
+		}
+		// blocks 4-6 of A, and blocks 4-6 of B are in the time range
+		for _, num := range []uint64{4, 5, 6} {
+			require.Contains(t, nodes, types.L2CausalityNodeID(chainA, num))
+			require.Contains(t, nodes, types.L2CausalityNodeID(chainB, num))
+		}
+		require.NotContains(t, nodes, types.L2CausalityNodeID(chainA, 3))
+		require.NotContains(t, nodes, types.L2CausalityNodeID(chainA, 7))
+		require.NotContains(t, nodes, types.L2CausalityNodeID(chainB, 7))
+
+		require.Equal(t, types.CrossSafe, nodes[types.L2CausalityNodeID(chainA, 4)].Safety)
+		require.Equal(t, types.LocalSafe, nodes[types.L2CausalityNodeID(chainA, 5)].Safety)
+		require.Equal(t, types.LocalUnsafe, nodes[types.L2CausalityNodeID(chainB, 4)].Safety)
+
+		external := nodes[types.L2CausalityNodeID(chainA, 1)]
+		require.True(t, external.External)
+		require.False(t, external.Missing)
+		require.Equal(t, a.blocks[1].Hash, external.Block.Hash)
+
+		missing := nodes[types.L2CausalityNodeID(unknownChain, 7)]
+		require.True(t, missing.Missing)
+		require.Equal(t, uint64(7), missing.Block.Number)
+
+		require.Contains(t, graph.Edges, types.CausalityEdge{
+			Kind: types.MessageEdge,
+			From: types.L2CausalityNodeID(chainB, 4),
+			To:   types.L2CausalityNodeID(chainA, 5),
+
+			InitLogIndex: 1,
+			ExecLogIndex: 2,
+		})
+		require.Contains(t, graph.Edges, types.CausalityEdge{
+			Kind: types.MessageEdge,
+			From: types.L2CausalityNodeID(chainA, 1),
+			To:   types.L2CausalityNodeID(chainB, 6),
+
+			InitLogIndex: 3,
+		})
+		// only local-safe blocks have a derivation edge, and L1 blocks are shared across chains
+		require.Contains(t, graph.Edges, types.CausalityEdge{
+			Kind: types.DerivationEdge,
+			From: types.L1CausalityNodeID(103),
+			To:   types.L2CausalityNodeID(chainA, 6),
+		})
+		require.Equal(t, types.BlockSeal{Hash: common.Hash{0xaa, 2}, Number: 102, Timestamp: 994}, nodes[types.L1CausalityNodeID(102)].Block)
+		for _, e := range graph.Edges {
+			if e.Kind == types.DerivationEdge {
+				require.NotEqual(t, types.L2CausalityNodeID(chainB, 4), e.To)
+			}
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		graph, err := Build(db, []eth.ChainID{chainA}, 1000, 2000, 3)
+		require.NoError(t, err)
+		require.True(t, graph.Truncated)
+		var blocks []string
+		for _, n := range graph.Nodes {
+			if n.ChainID != nil {
+				blocks = append(blocks, n.ID)
+			}
+		}
+		require.Equal(t, []string{"900:0", "900:1", "900:2"}, blocks)
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		graph, err := Build(db, []eth.ChainID{chainA, chainB}, 5000, 6000, 100)
+		require.NoError(t, err)
+		require.Empty(t, graph.Nodes)
+		require.Empty(t, graph.Edges)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := Build(db, []eth.ChainID{chainA}, 20, 10, 100)
+		require.ErrorContains(t, err, "invalid time range")
+	})
+
+	t.Run("dot", func(t *testing.T) {
+		graph, err := Build(db, []eth.ChainID{chainA, chainB}, 1008, 1013, 100)
+		require.NoError(t, err)
+		dot := graph.DOT()
+		require.Contains(t, dot, "digraph causality {")
+		require.Contains(t, dot, `label="chain 900";`)
+		require.Contains(t, dot, `"901:4" -> "900:5" [label="log 1 -> 2"];`)
+		require.Contains(t, dot, `"l1:103" -> "900:6" [style=dashed, color=grey];`)
+		require.Contains(t, dot, `"902:7" [label="#7\nmissing", style=dashed];`)
+	})
+}
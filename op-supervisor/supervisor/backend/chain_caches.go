@@ -0,0 +1,152 @@
+package backend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/processors"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/syncnode"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// chainCaches holds the in-memory caches of a single chain.
+// The caches are shared by all sync sources of the chain.
+// Cache hits, misses and evictions are tracked with the chain metrics.
+type chainCaches struct {
+	// receipts by block hash, these are immutable
+	receipts *caching.LRUCache[common.Hash, gethtypes.Receipts]
+
+	// blockRefs by number, and accessChecks, are only valid for the current canonical chain.
+	// These are purged when the chain is rewound.
+	blockRefs    *caching.LRUCache[uint64, eth.BlockRef]
+	accessChecks *caching.LRUCache[types.Access, eth.BlockID]
+
+	// mu protects against entries, that were looked up before a purge, being added after the purge
+	mu sync.RWMutex
+	// generation is incremented with every purge
+	generation uint64
+}
+
+// newCache creates a LRU cache, or returns nil if the cache is disabled.
+func newCache[K comparable, V any](m caching.Metrics, label string, size int) *caching.LRUCache[K, V] {
+	if size <= 0 {
+		return nil
+	}
+	return caching.NewLRUCache[K, V](m, label, size)
+}
+
+func newChainCaches(m caching.Metrics, sizes config.CacheSizes) *chainCaches {
+	return &chainCaches{
+		receipts:     newCache[common.Hash, gethtypes.Receipts](m, config.ReceiptsCache, sizes.Receipts),
+		blockRefs:    newCache[uint64, eth.BlockRef](m, config.BlockRefsCache, sizes.BlockRefs),
+		accessChecks: newCache[types.Access, eth.BlockID](m, config.AccessChecksCache, sizes.AccessChecks),
+	}
+}
+
+func (c *chainCaches) currentGeneration() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.generation
+}
+
+// addIfCurrent runs the add function, if no purge happened since the given generation.
+func (c *chainCaches) addIfCurrent(generation uint64, add func()) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.generation == generation {
+		add()
+	}
+}
+
+// purgeCanonical purges the caches that depend on the canonical chain.
+func (c *chainCaches) purgeCanonical() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if c.blockRefs != nil {
+		c.blockRefs.Purge()
+	}
+	if c.accessChecks != nil {
+		c.accessChecks.Purge()
+	}
+}
+
+func (c *chainCaches) getAccessCheck(acc types.Access) (eth.BlockID, bool) {
+	if c.accessChecks == nil {
+		return eth.BlockID{}, false
+	}
+	return c.accessChecks.Get(acc)
+}
+
+func (c *chainCaches) addAccessCheck(generation uint64, acc types.Access, includedIn eth.BlockID) {
+	if c.accessChecks == nil {
+		return
+	}
+	c.addIfCurrent(generation, func() {
+		c.accessChecks.Add(acc, includedIn)
+	})
+}
+
+// cachingSyncSource wraps a sync source, to serve block and receipts data from the chain caches.
+type cachingSyncSource struct {
+	syncnode.SyncSource
+	caches *chainCaches
+}
+
+var _ syncnode.SyncSource = (*cachingSyncSource)(nil)
+
+func (s *cachingSyncSource) BlockRefByNumber(ctx context.Context, number uint64) (eth.BlockRef, error) {
+	if s.caches.blockRefs == nil {
+		return s.SyncSource.BlockRefByNumber(ctx, number)
+	}
+	if ref, ok := s.caches.blockRefs.Get(number); ok {
+		return ref, nil
+	}
+	generation := s.caches.currentGeneration()
+	ref, err := s.SyncSource.BlockRefByNumber(ctx, number)
+	if err != nil {
+		return eth.BlockRef{}, err
+	}
+	s.caches.addIfCurrent(generation, func() {
+		s.caches.blockRefs.Add(number, ref)
+	})
+	return ref, nil
+}
+
+func (s *cachingSyncSource) FetchReceipts(ctx context.Context, blockHash common.Hash) (gethtypes.Receipts, error) {
+	if s.caches.receipts == nil {
+		return s.SyncSource.FetchReceipts(ctx, blockHash)
+	}
+	if receipts, ok := s.caches.receipts.Get(blockHash); ok {
+		return receipts, nil
+	}
+	receipts, err := s.SyncSource.FetchReceipts(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	s.caches.receipts.Add(blockHash, receipts)
+	return receipts, nil
+}
+
+// Contains checks the query against the cached block and receipts data,
+// instead of letting the wrapped source fetch the data without caching.
+func (s *cachingSyncSource) Contains(ctx context.Context, query types.ContainsQuery) (types.BlockSeal, error) {
+	return syncnode.ContainsLog(ctx, s, query)
+}
+
+// purgingRewinder purges the canonical-chain caches whenever the chain processor rewinds the chain.
+type purgingRewinder struct {
+	processors.DatabaseRewinder
+	caches *chainCaches
+}
+
+func (r *purgingRewinder) Rewind(chain eth.ChainID, headBlock eth.BlockID) error {
+	r.caches.purgeCanonical()
+	return r.DatabaseRewinder.Rewind(chain, headBlock)
+}
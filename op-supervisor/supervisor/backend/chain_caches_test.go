@@ -0,0 +1,131 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// countingSyncSource serves a fixed chain, and counts the calls to it.
+type countingSyncSource struct {
+	fakeSyncSource
+	refs          map[uint64]eth.BlockRef
+	refCalls      int
+	receiptsCalls int
+}
+
+func (s *countingSyncSource) BlockRefByNumber(_ context.Context, num uint64) (eth.BlockRef, error) {
+	s.refCalls++
+	return s.refs[num], nil
+}
+
+func (s *countingSyncSource) FetchReceipts(_ context.Context, _ common.Hash) (gethtypes.Receipts, error) {
+	s.receiptsCalls++
+	return gethtypes.Receipts{{Status: 1}}, nil
+}
+
+type cacheMetrics struct {
+	hits, misses, evictions map[string]int
+}
+
+func newCacheMetrics() *cacheMetrics {
+	return &cacheMetrics{hits: map[string]int{}, misses: map[string]int{}, evictions: map[string]int{}}
+}
+
+func (m *cacheMetrics) CacheAdd(label string, cacheSize int, evicted bool) {
+	if evicted {
+		m.evictions[label]++
+	}
+}
+
+func (m *cacheMetrics) CacheGet(label string, hit bool) {
+	if hit {
+		m.hits[label]++
+	} else {
+		m.misses[label]++
+	}
+}
+
+func TestCachingSyncSource(t *testing.T) {
+	ctx := context.Background()
+	src := &countingSyncSource{refs: map[uint64]eth.BlockRef{
+		1: {Hash: common.Hash{1}, Number: 1},
+		2: {Hash: common.Hash{2}, Number: 2},
+	}}
+	m := newCacheMetrics()
+	caches := newChainCaches(m, config.CacheSizes{Receipts: 1, BlockRefs: 10, AccessChecks: 10})
+	cached := &cachingSyncSource{SyncSource: src, caches: caches}
+
+	for i := 0; i < 3; i++ {
+		ref, err := cached.BlockRefByNumber(ctx, 1)
+		require.NoError(t, err)
+		require.Equal(t, src.refs[1], ref)
+	}
+	require.Equal(t, 1, src.refCalls)
+	require.Equal(t, 2, m.hits[config.BlockRefsCache])
+	require.Equal(t, 1, m.misses[config.BlockRefsCache])
+
+	// the receipts cache only fits a single block, so alternating blocks evicts each time
+	for _, h := range []common.Hash{{1}, {1}, {2}, {1}} {
+		_, err := cached.FetchReceipts(ctx, h)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, src.receiptsCalls)
+	require.Equal(t, 2, m.evictions[config.ReceiptsCache])
+
+	// a rewind purges the block refs, the receipts are kept since they are keyed by block hash
+	src.refs[1] = eth.BlockRef{Hash: common.Hash{0xaa}, Number: 1}
+	caches.purgeCanonical()
+	ref, err := cached.BlockRefByNumber(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, ref.Hash)
+	require.Equal(t, 2, src.refCalls)
+	_, err = cached.FetchReceipts(ctx, common.Hash{1})
+	require.NoError(t, err)
+	require.Equal(t, 3, src.receiptsCalls)
+}
+
+func TestCachingSyncSourceDisabled(t *testing.T) {
+	ctx := context.Background()
+	src := &countingSyncSource{refs: map[uint64]eth.BlockRef{1: {Hash: common.Hash{1}, Number: 1}}}
+	caches := newChainCaches(nil, config.CacheSizes{})
+	cached := &cachingSyncSource{SyncSource: src, caches: caches}
+	for i := 0; i < 2; i++ {
+		_, err := cached.BlockRefByNumber(ctx, 1)
+		require.NoError(t, err)
+		_, err = cached.FetchReceipts(ctx, common.Hash{1})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, src.refCalls)
+	require.Equal(t, 2, src.receiptsCalls)
+
+	caches.addAccessCheck(caches.currentGeneration(), types.Access{BlockNumber: 1}, eth.BlockID{Number: 1})
+	_, ok := caches.getAccessCheck(types.Access{BlockNumber: 1})
+	require.False(t, ok)
+	caches.purgeCanonical()
+}
+
+func TestChainCachesStaleAdd(t *testing.T) {
+	caches := newChainCaches(nil, config.DefaultCacheSizes())
+	acc := types.Access{BlockNumber: 1, LogIndex: 2}
+
+	// an access check that started before a purge is not cached after the purge
+	generation := caches.currentGeneration()
+	caches.purgeCanonical()
+	caches.addAccessCheck(generation, acc, eth.BlockID{Number: 1})
+	_, ok := caches.getAccessCheck(acc)
+	require.False(t, ok)
+
+	caches.addAccessCheck(caches.currentGeneration(), acc, eth.BlockID{Number: 1})
+	includedIn, ok := caches.getAccessCheck(acc)
+	require.True(t, ok)
+	require.Equal(t, eth.BlockID{Number: 1}, includedIn)
+}
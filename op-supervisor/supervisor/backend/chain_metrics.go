@@ -1,10 +1,13 @@
 package backend
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
 )
 
@@ -17,9 +20,20 @@ type Metrics interface {
 
 	RecordDBEntryCount(chainID eth.ChainID, kind string, count int64)
 	RecordDBSearchEntriesRead(chainID eth.ChainID, count int64)
+	RecordDBCompressedSize(chainID eth.ChainID, rawBytes int64, compressedBytes int64)
+	RecordDBDecompression(chainID eth.ChainID, d time.Duration)
 
 	RecordAccessListVerifyFailure(chainID eth.ChainID)
 
+	RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool)
+
+	RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool)
+
+	RecordCircuitBreakerPaused(chainID eth.ChainID, paused bool)
+
+	RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration)
+	RecordInvalidGossipInteropBlock(chainID eth.ChainID)
+
 	opmetrics.RPCMetricer
 	event.Metrics
 }
@@ -62,9 +76,18 @@ func (c *chainMetrics) RecordDBSearchEntriesRead(count int64) {
 	c.delegate.RecordDBSearchEntriesRead(c.chainID, count)
 }
 
+func (c *chainMetrics) RecordDBCompressedSize(rawBytes int64, compressedBytes int64) {
+	c.delegate.RecordDBCompressedSize(c.chainID, rawBytes, compressedBytes)
+}
+
+func (c *chainMetrics) RecordDBDecompression(d time.Duration) {
+	c.delegate.RecordDBDecompression(c.chainID, d)
+}
+
 func (c *chainMetrics) RecordAccessListVerifyFailure() {
 	c.delegate.RecordAccessListVerifyFailure(c.chainID)
 }
 
 var _ caching.Metrics = (*chainMetrics)(nil)
 var _ logs.Metrics = (*chainMetrics)(nil)
+var _ entrydb.CompressionMetrics = (*chainMetrics)(nil)
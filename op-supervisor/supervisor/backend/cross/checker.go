@@ -0,0 +1,96 @@
+package cross
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// SafeCheckDeps is the read-only data a cross-safe check may use.
+type SafeCheckDeps interface {
+	SafeStartDeps
+	SafeFrontierCheckDeps
+	CycleCheckDeps
+}
+
+// UnsafeCheckDeps is the read-only data a cross-unsafe check may use.
+type UnsafeCheckDeps interface {
+	UnsafeStartDeps
+	UnsafeFrontierCheckDeps
+	CycleCheckDeps
+}
+
+// SafeCheck verifies that the candidate, derived from the given L1 source, can be promoted to cross-safe.
+type SafeCheck func(d SafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, inL1Source eth.BlockID, candidate types.BlockSeal) error
+
+// UnsafeCheck verifies that the candidate can be promoted to cross-unsafe.
+type UnsafeCheck func(d UnsafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, candidate types.BlockSeal) error
+
+// Checker is a version of the cross-safety verification pipeline.
+type Checker struct {
+	Safe   SafeCheck
+	Unsafe UnsafeCheck
+}
+
+// ActiveChecker is the name of the checker that decides which blocks are promoted.
+const ActiveChecker = "v1"
+
+// checkers are the known versions of the verification pipeline.
+// A new version is registered here, to run in shadow mode, before it replaces the active version.
+var checkers = map[string]Checker{
+	ActiveChecker: {Safe: CheckCrossSafe, Unsafe: CheckCrossUnsafe},
+}
+
+// CheckerNames returns the names of the known checkers, sorted.
+func CheckerNames() []string {
+	names := make([]string, 0, len(checkers))
+	for name := range checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupChecker returns the checker with the given name.
+func LookupChecker(name string) (Checker, error) {
+	c, ok := checkers[name]
+	if !ok {
+		return Checker{}, fmt.Errorf("unknown cross-safety checker %q, expected one of %v", name, CheckerNames())
+	}
+	return c, nil
+}
+
+// CheckCrossSafe runs the hazard, frontier and cycle checks of a cross-safe candidate.
+func CheckCrossSafe(d SafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, inL1Source eth.BlockID, candidate types.BlockSeal) error {
+	hazards, err := CrossSafeHazards(d, linker, logger, chainID, inL1Source, candidate)
+	if err != nil {
+		return fmt.Errorf("failed to determine dependencies of cross-safe candidate %s: %w", candidate, err)
+	}
+	if err := HazardSafeFrontierChecks(d, inL1Source, hazards); err != nil {
+		return fmt.Errorf("failed to verify block %s in cross-safe frontier: %w", candidate, err)
+	}
+	if err := HazardCycleChecks(d, candidate.Timestamp, hazards); err != nil {
+		return fmt.Errorf("failed to verify block %s in cross-safe check for cycle hazards: %w", candidate, err)
+	}
+	return nil
+}
+
+// CheckCrossUnsafe runs the hazard, frontier and cycle checks of a cross-unsafe candidate.
+func CheckCrossUnsafe(d UnsafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, candidate types.BlockSeal) error {
+	hazards, err := CrossUnsafeHazards(d, linker, logger, chainID, candidate)
+	if err != nil {
+		return fmt.Errorf("failed to check for cross-chain hazards: %w", err)
+	}
+	if err := HazardUnsafeFrontierChecks(d, hazards); err != nil {
+		return fmt.Errorf("failed to verify block %s in cross-unsafe frontier: %w", candidate, err)
+	}
+	if err := HazardCycleChecks(d, candidate.Timestamp, hazards); err != nil {
+		return fmt.Errorf("failed to verify block %s in cross-unsafe check for cycle hazards: %w", candidate, err)
+	}
+	return nil
+}
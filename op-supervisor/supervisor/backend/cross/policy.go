@@ -0,0 +1,16 @@
+package cross
+
+import (
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// SafePolicy is an additional condition for the promotion of local-safe blocks to cross-safe,
+// on top of the cross-safety checks, e.g. based on attestations of external parties.
+// A policy can only hold back a candidate, it can never make an invalid candidate cross-safe.
+type SafePolicy interface {
+	// CheckCrossSafe is called with a candidate that passed the cross-safety checks.
+	// It returns an error wrapping types.ErrFuture if the candidate is not ready to be promoted yet.
+	// The cross-safe update of the chain has to be requested again when the policy may accept the candidate.
+	CheckCrossSafe(chainID eth.ChainID, candidate types.BlockSeal) error
+}
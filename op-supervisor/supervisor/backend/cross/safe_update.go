@@ -39,10 +39,16 @@ type CrossSafeDeps interface {
 }
 
 func CrossSafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker) error {
+	return crossSafeUpdate(logger, chainID, d, linker, nil, nil)
+}
+
+// crossSafeUpdate is CrossSafeUpdate, with an optional shadow checker to compare the cross-safe checks with,
+// and an optional policy that may hold back the promotion of candidates.
+func crossSafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker, shadow *Shadow, policy SafePolicy) error {
 	h := d.AcquireHandle()
 	defer h.Release()
 	logger.Debug("Cross-safe update call")
-	candidate, err := scopedCrossSafeUpdate(h, logger, chainID, d, linker)
+	candidate, err := scopedCrossSafeUpdate(h, logger, chainID, d, linker, shadow, policy)
 	if err == nil {
 		// if we made progress, and no errors, then there is no need to bump the L1 scope yet.
 		return h.Err() // make sure the read-consistency is still translated into an error
@@ -105,7 +111,7 @@ func CrossSafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, li
 // If no L2 cross-safe progress can be made without additional L1 input data,
 // then a types.ErrOutOfScope error is returned,
 // with the current scope that will need to be expanded for further progress.
-func scopedCrossSafeUpdate(h reads.Handle, logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker) (update types.DerivedBlockRefPair, err error) {
+func scopedCrossSafeUpdate(h reads.Handle, logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker, shadow *Shadow, policy SafePolicy) (update types.DerivedBlockRefPair, err error) {
 	candidate, err := d.CandidateCrossSafe(chainID)
 	if err != nil {
 		return candidate, fmt.Errorf("failed to determine candidate block for cross-safe: %w", err)
@@ -114,15 +120,22 @@ func scopedCrossSafeUpdate(h reads.Handle, logger log.Logger, chainID eth.ChainI
 	h.DependOnDerivedTime(candidate.Derived.Time)
 	logger.Debug("Candidate cross-safe", "scope", candidate.Source, "candidate", candidate.Derived)
 
-	hazards, err := CrossSafeHazards(d, linker, logger, chainID, candidate.Source.ID(), types.BlockSealFromRef(candidate.Derived))
-	if err != nil {
-		return candidate, fmt.Errorf("failed to determine dependencies of cross-safe candidate %s: %w", candidate.Derived, err)
+	seal := types.BlockSealFromRef(candidate.Derived)
+	checkErr := CheckCrossSafe(d, linker, logger, chainID, candidate.Source.ID(), seal)
+	if shadow != nil {
+		shadowErr := shadow.checkSafe(d, linker, logger, chainID, candidate.Source.ID(), seal)
+		// Decisions based on inconsistent reads may differ for reasons other than the checkers.
+		if h.IsValid() {
+			shadow.report(logger, chainID, "safe", seal, checkErr, shadowErr)
+		}
 	}
-	if err := HazardSafeFrontierChecks(d, candidate.Source.ID(), hazards); err != nil {
-		return candidate, fmt.Errorf("failed to verify block %s in cross-safe frontier: %w", candidate.Derived, err)
+	if checkErr != nil {
+		return candidate, checkErr
 	}
-	if err := HazardCycleChecks(d, candidate.Derived.Time, hazards); err != nil {
-		return candidate, fmt.Errorf("failed to verify block %s in cross-safe check for cycle hazards: %w", candidate, err)
+	if policy != nil {
+		if err := policy.CheckCrossSafe(chainID, seal); err != nil {
+			return candidate, fmt.Errorf("cross-safe policy holds back %s: %w", seal, err)
+		}
 	}
 	// If any of the reads were inconsistent, don't continue with updating.
 	if !h.IsValid() {
@@ -141,12 +154,14 @@ type CrossSafeWorker struct {
 	chainID eth.ChainID
 	d       CrossSafeDeps
 	linker  depset.LinkChecker
+	shadow  *Shadow
+	policy  SafePolicy
 }
 
 func (c *CrossSafeWorker) OnEvent(ev event.Event) bool {
 	switch ev.(type) {
 	case superevents.UpdateCrossSafeRequestEvent:
-		if err := CrossSafeUpdate(c.logger, c.chainID, c.d, c.linker); err != nil {
+		if err := crossSafeUpdate(c.logger, c.chainID, c.d, c.linker, c.shadow, c.policy); err != nil {
 			if errors.Is(err, types.ErrFuture) {
 				c.logger.Debug("Worker awaits additional blocks", "err", err)
 			} else {
@@ -161,12 +176,17 @@ func (c *CrossSafeWorker) OnEvent(ev event.Event) bool {
 
 var _ event.Deriver = (*CrossUnsafeWorker)(nil)
 
-func NewCrossSafeWorker(logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker) *CrossSafeWorker {
+// NewCrossSafeWorker creates a worker that promotes local-safe blocks to cross-safe.
+// The shadow checker is optional, and is run alongside the active checker if not nil.
+// The policy is optional, and may hold back candidates that passed the checks if not nil.
+func NewCrossSafeWorker(logger log.Logger, chainID eth.ChainID, d CrossSafeDeps, linker depset.LinkChecker, shadow *Shadow, policy SafePolicy) *CrossSafeWorker {
 	logger = logger.New("chain", chainID, "worker", "cross-safe")
 	return &CrossSafeWorker{
 		logger:  logger,
 		chainID: chainID,
 		d:       d,
 		linker:  linker,
+		shadow:  shadow,
+		policy:  policy,
 	}
 }
@@ -236,7 +236,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when CandidateCrossSafe returns an error,
 		// the error is returned
-		candidate, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		candidate, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "some error")
 		require.Equal(t, eth.BlockRef{}, candidate.Source)
 	})
@@ -249,7 +249,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when OpenBlock returns an error,
 		// the error is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "some error")
 		require.Equal(t, eth.BlockRef{}, pair.Source)
 	})
@@ -270,7 +270,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when OpenBlock and CandidateCrossSafe return different blocks,
 		// an ErrConflict is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorIs(t, err, types.ErrConflict)
 		require.Equal(t, eth.BlockRef{}, pair.Source)
 	})
@@ -296,7 +296,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when CrossSafeHazards returns an error,
 		// the error is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "some error")
 		require.ErrorContains(t, err, "dependencies of cross-safe candidate")
 		require.Equal(t, eth.BlockRef{}, pair.Source)
@@ -326,7 +326,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when CrossSafeHazards returns an error,
 		// the error is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "some error")
 		require.ErrorContains(t, err, "failed to build hazard set")
 		require.Equal(t, eth.BlockRef{}, pair.Source)
@@ -354,7 +354,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 
 		// HazardCycleChecks returns an error with appropriate wrapping
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "cycle detected")
 		require.ErrorContains(t, err, "failed to verify block")
 		require.Equal(t, eth.BlockRef{Number: 2}, pair.Source)
@@ -384,7 +384,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when UpdateCrossSafe returns an error,
 		// the error is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.ErrorContains(t, err, "some error")
 		require.ErrorContains(t, err, "failed to update")
 		require.Equal(t, eth.BlockRef{Number: 2}, pair.Source)
@@ -411,7 +411,7 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		}
 		// when OpenBlock and CandidateCrossSafe return different blocks,
 		// an ErrConflict is returned
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerNone{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerNone{}, nil, nil)
 		require.ErrorIs(t, err, types.ErrConflict)
 		require.Equal(t, eth.BlockRef{}, pair.Source)
 	})
@@ -447,13 +447,60 @@ func TestScopedCrossSafeUpdate(t *testing.T) {
 		csd.checkFn = func(chainID eth.ChainID, blockNum uint64, logIdx uint32, checksum types.MessageChecksum) (types.BlockSeal, error) {
 			return types.BlockSeal{Number: 1, Timestamp: 1}, nil
 		}
-		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{})
+		pair, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, nil)
 		require.Equal(t, chainID, updatingChain)
 		require.Equal(t, candidateScope, updatingCandidateScope)
 		require.Equal(t, candidate, updatingCandidate)
 		require.Equal(t, candidateScope, pair.Source)
 		require.NoError(t, err)
 	})
+	t.Run("policy holds back candidate", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelDebug)
+		chainID := eth.ChainIDFromUInt64(123)
+		csd := &mockCrossSafeDeps{}
+		candidate := eth.BlockRef{Number: 1, Time: 1}
+		csd.candidateCrossSafeFn = func() (pair types.DerivedBlockRefPair, err error) {
+			return types.DerivedBlockRefPair{
+				Source:  eth.BlockRef{Number: 2},
+				Derived: candidate,
+			}, nil
+		}
+		csd.openBlockFn = func(chainID eth.ChainID, blockNum uint64) (ref eth.BlockRef, logCount uint32, execMsgs map[uint32]*types.ExecutingMessage, err error) {
+			return candidate, 0, nil, nil
+		}
+		updated := false
+		csd.updateCrossSafeFn = func(chain eth.ChainID, l1View eth.BlockRef, lastCrossDerived eth.BlockRef) error {
+			updated = true
+			return nil
+		}
+		policy := &mockSafePolicy{err: types.ErrFuture}
+		// when the policy rejects the candidate, the candidate is not promoted,
+		// and the error of the policy is returned
+		_, err := scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, policy)
+		require.ErrorIs(t, err, types.ErrFuture)
+		require.False(t, updated)
+		require.Equal(t, chainID, policy.chainID)
+		require.Equal(t, types.BlockSealFromRef(candidate), policy.candidate)
+
+		// when the policy accepts the candidate, it is promoted
+		policy.err = nil
+		_, err = scopedCrossSafeUpdate(reads.NoopHandle{}, logger, chainID, csd, linkerAny{}, nil, policy)
+		require.NoError(t, err)
+		require.True(t, updated)
+	})
+}
+
+type mockSafePolicy struct {
+	err error
+
+	chainID   eth.ChainID
+	candidate types.BlockSeal
+}
+
+func (m *mockSafePolicy) CheckCrossSafe(chainID eth.ChainID, candidate types.BlockSeal) error {
+	m.chainID = chainID
+	m.candidate = candidate
+	return m.err
 }
 
 type mockCrossSafeDeps struct {
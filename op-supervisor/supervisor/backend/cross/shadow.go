@@ -0,0 +1,83 @@
+package cross
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type ShadowMetrics interface {
+	RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool)
+}
+
+// Shadow runs a checker alongside the active checker, on the same candidates,
+// and reports the candidates where the decisions of the two diverge.
+// The decisions of the shadow checker are never acted on: only the active checker affects the databases.
+type Shadow struct {
+	name    string
+	checker Checker
+	m       ShadowMetrics
+}
+
+func NewShadow(name string, m ShadowMetrics) (*Shadow, error) {
+	checker, err := LookupChecker(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Shadow{name: name, checker: checker, m: m}, nil
+}
+
+// checkSafe runs the cross-safe check of the shadow checker.
+func (s *Shadow) checkSafe(d SafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, inL1Source eth.BlockID, candidate types.BlockSeal) (err error) {
+	defer s.recoverPanic(&err)
+	return s.checker.Safe(d, linker, logger, chainID, inL1Source, candidate)
+}
+
+// checkUnsafe runs the cross-unsafe check of the shadow checker.
+func (s *Shadow) checkUnsafe(d UnsafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, candidate types.BlockSeal) (err error) {
+	defer s.recoverPanic(&err)
+	return s.checker.Unsafe(d, linker, logger, chainID, candidate)
+}
+
+// recoverPanic turns a panic of the shadow checker into an error, so a faulty shadow checker cannot take down the supervisor.
+func (s *Shadow) recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("shadow checker %q panicked: %v", s.name, r)
+	}
+}
+
+// report compares the results of the active and the shadow checker of a candidate.
+func (s *Shadow) report(logger log.Logger, chainID eth.ChainID, kind string, candidate types.BlockSeal, activeErr, shadowErr error) {
+	active, shadow := verdict(activeErr), verdict(shadowErr)
+	diverged := active != shadow
+	s.m.RecordCrossShadowCheck(chainID, kind, diverged)
+	if diverged {
+		logger.Warn("Shadow cross-safety checker diverged from active checker",
+			"shadow", s.name, "kind", kind, "candidate", candidate,
+			"activeVerdict", active, "shadowVerdict", shadow, "activeErr", activeErr, "shadowErr", shadowErr)
+	} else {
+		logger.Debug("Shadow cross-safety checker matched active checker",
+			"shadow", s.name, "kind", kind, "candidate", candidate, "verdict", active)
+	}
+}
+
+// verdict classifies the result of a check into the decision that is made based on it.
+func verdict(err error) string {
+	switch {
+	case err == nil:
+		return "valid"
+	case errors.Is(err, types.ErrConflict):
+		return "conflict"
+	case errors.Is(err, types.ErrOutOfScope):
+		return "out-of-scope"
+	case errors.Is(err, types.ErrFuture):
+		return "future"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,106 @@
+package cross
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type shadowCheckRecord struct {
+	kind     string
+	diverged bool
+}
+
+type mockShadowMetrics struct {
+	checks []shadowCheckRecord
+}
+
+func (m *mockShadowMetrics) RecordCrossShadowCheck(chainID eth.ChainID, kind string, diverged bool) {
+	m.checks = append(m.checks, shadowCheckRecord{kind: kind, diverged: diverged})
+}
+
+// registerChecker registers a checker for the duration of the test.
+func registerChecker(t *testing.T, name string, c Checker) {
+	checkers[name] = c
+	t.Cleanup(func() { delete(checkers, name) })
+}
+
+func TestShadowCrossUnsafe(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(123)
+	setup := func(t *testing.T, shadowName string) (*mockCrossUnsafeDeps, *mockShadowMetrics, *Shadow, *[]types.BlockSeal) {
+		usd := &mockCrossUnsafeDeps{}
+		usd.crossUnsafeFn = func(chainID eth.ChainID) (types.BlockSeal, error) {
+			return types.BlockSeal{Hash: common.Hash{0x01}, Number: 1}, nil
+		}
+		usd.openBlockFn = func(chainID eth.ChainID, blockNum uint64) (eth.BlockRef, uint32, map[uint32]*types.ExecutingMessage, error) {
+			return eth.BlockRef{Hash: common.Hash{0x02}, Number: 2, ParentHash: common.Hash{0x01}}, 0, nil, nil
+		}
+		var updates []types.BlockSeal
+		usd.updateCrossUnsafeFn = func(chain eth.ChainID, crossUnsafe types.BlockSeal) error {
+			updates = append(updates, crossUnsafe)
+			return nil
+		}
+		m := &mockShadowMetrics{}
+		shadow, err := NewShadow(shadowName, m)
+		require.NoError(t, err)
+		return usd, m, shadow, &updates
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		logger := testlog.Logger(t, log.LevelDebug)
+		usd, m, shadow, updates := setup(t, ActiveChecker)
+		require.NoError(t, crossUnsafeUpdate(logger, chainID, usd, linkerAny{}, shadow))
+		require.Equal(t, []shadowCheckRecord{{kind: "unsafe", diverged: false}}, m.checks)
+		require.Len(t, *updates, 1)
+	})
+	t.Run("diverging", func(t *testing.T) {
+		registerChecker(t, "test-conflict", Checker{
+			Unsafe: func(d UnsafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, candidate types.BlockSeal) error {
+				return types.ErrConflict
+			},
+		})
+		logger := testlog.Logger(t, log.LevelDebug)
+		usd, m, shadow, updates := setup(t, "test-conflict")
+		require.NoError(t, crossUnsafeUpdate(logger, chainID, usd, linkerAny{}, shadow))
+		require.Equal(t, []shadowCheckRecord{{kind: "unsafe", diverged: true}}, m.checks)
+		// the decision of the active checker is applied, regardless of the shadow checker
+		require.Len(t, *updates, 1)
+	})
+	t.Run("panicking", func(t *testing.T) {
+		registerChecker(t, "test-panic", Checker{
+			Unsafe: func(d UnsafeCheckDeps, linker depset.LinkChecker, logger log.Logger, chainID eth.ChainID, candidate types.BlockSeal) error {
+				panic("boom")
+			},
+		})
+		logger := testlog.Logger(t, log.LevelDebug)
+		usd, m, shadow, updates := setup(t, "test-panic")
+		require.NoError(t, crossUnsafeUpdate(logger, chainID, usd, linkerAny{}, shadow))
+		require.Equal(t, []shadowCheckRecord{{kind: "unsafe", diverged: true}}, m.checks)
+		require.Len(t, *updates, 1)
+	})
+}
+
+func TestShadowVerdict(t *testing.T) {
+	require.Equal(t, "valid", verdict(nil))
+	require.Equal(t, "conflict", verdict(types.ErrConflict))
+	require.Equal(t, "out-of-scope", verdict(types.ErrOutOfScope))
+	require.Equal(t, "future", verdict(types.ErrFuture))
+	require.Equal(t, "error", verdict(errors.New("some error")))
+}
+
+func TestLookupChecker(t *testing.T) {
+	_, err := LookupChecker(ActiveChecker)
+	require.NoError(t, err)
+	_, err = NewShadow("unknown", &mockShadowMetrics{})
+	require.ErrorContains(t, err, "unknown cross-safety checker")
+	require.Contains(t, CheckerNames(), ActiveChecker)
+}
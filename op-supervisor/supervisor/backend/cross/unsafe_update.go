@@ -28,6 +28,11 @@ type CrossUnsafeDeps interface {
 }
 
 func CrossUnsafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossUnsafeDeps, linker depset.LinkChecker) error {
+	return crossUnsafeUpdate(logger, chainID, d, linker, nil)
+}
+
+// crossUnsafeUpdate is CrossUnsafeUpdate, with an optional shadow checker to compare the cross-unsafe checks with.
+func crossUnsafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossUnsafeDeps, linker depset.LinkChecker, shadow *Shadow) error {
 	h := d.AcquireHandle()
 	defer h.Release()
 
@@ -56,16 +61,16 @@ func CrossUnsafeUpdate(logger log.Logger, chainID eth.ChainID, d CrossUnsafeDeps
 	}
 	h.DependOnDerivedTime(candidate.Timestamp)
 
-	hazards, err := CrossUnsafeHazards(d, linker, logger, chainID, candidate)
-	if err != nil {
-		return fmt.Errorf("failed to check for cross-chain hazards: %w", err)
-	}
-
-	if err := HazardUnsafeFrontierChecks(d, hazards); err != nil {
-		return fmt.Errorf("failed to verify block %s in cross-unsafe frontier: %w", candidate, err)
+	checkErr := CheckCrossUnsafe(d, linker, logger, chainID, candidate)
+	if shadow != nil {
+		shadowErr := shadow.checkUnsafe(d, linker, logger, chainID, candidate)
+		// Decisions based on inconsistent reads may differ for reasons other than the checkers.
+		if h.IsValid() {
+			shadow.report(logger, chainID, "unsafe", candidate, checkErr, shadowErr)
+		}
 	}
-	if err := HazardCycleChecks(d, candidate.Timestamp, hazards); err != nil {
-		return fmt.Errorf("failed to verify block %s in cross-unsafe check for cycle hazards: %w", candidate, err)
+	if checkErr != nil {
+		return checkErr
 	}
 
 	if !h.IsValid() {
@@ -85,12 +90,13 @@ type CrossUnsafeWorker struct {
 	chainID eth.ChainID
 	d       CrossUnsafeDeps
 	linker  depset.LinkChecker
+	shadow  *Shadow
 }
 
 func (c *CrossUnsafeWorker) OnEvent(ev event.Event) bool {
 	switch ev.(type) {
 	case superevents.UpdateCrossUnsafeRequestEvent:
-		if err := CrossUnsafeUpdate(c.logger, c.chainID, c.d, c.linker); err != nil {
+		if err := crossUnsafeUpdate(c.logger, c.chainID, c.d, c.linker, c.shadow); err != nil {
 			if errors.Is(err, types.ErrFuture) {
 				c.logger.Debug("Worker awaits additional blocks", "err", err)
 			} else {
@@ -105,12 +111,15 @@ func (c *CrossUnsafeWorker) OnEvent(ev event.Event) bool {
 
 var _ event.Deriver = (*CrossUnsafeWorker)(nil)
 
-func NewCrossUnsafeWorker(logger log.Logger, chainID eth.ChainID, d CrossUnsafeDeps, linker depset.LinkChecker) *CrossUnsafeWorker {
+// NewCrossUnsafeWorker creates a worker that promotes local-unsafe blocks to cross-unsafe.
+// The shadow checker is optional, and is run alongside the active checker if not nil.
+func NewCrossUnsafeWorker(logger log.Logger, chainID eth.ChainID, d CrossUnsafeDeps, linker depset.LinkChecker, shadow *Shadow) *CrossUnsafeWorker {
 	logger = logger.New("chain", chainID, "worker", "cross-unsafe")
 	return &CrossUnsafeWorker{
 		logger:  logger,
 		chainID: chainID,
 		d:       d,
 		linker:  linker,
+		shadow:  shadow,
 	}
 }
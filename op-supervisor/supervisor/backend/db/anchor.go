@@ -20,6 +20,7 @@ func (db *ChainsDB) isInitialized(id eth.ChainID) bool {
 }
 
 func (db *ChainsDB) initFromAnchor(id eth.ChainID, anchor types.DerivedBlockRefPair) {
+	defer db.beginWrite()()
 	// Check if the chain database is already initialized
 	if db.isInitialized(id) {
 		db.logger.Debug("chain database already initialized")
@@ -47,6 +48,7 @@ func (db *ChainsDB) initFromAnchor(id eth.ChainID, anchor types.DerivedBlockRefP
 // it checks if the Local Safe database is empty, and loads both the Local and Cross Safe databases
 // with the anchor point if they are empty.
 func (db *ChainsDB) maybeInitSafeDB(id eth.ChainID, anchor types.DerivedBlockRefPair) error {
+	defer db.beginWrite()()
 	logger := db.logger.New("chain", id, "derived", anchor.Derived, "source", anchor.Source)
 	localDB, ok := db.localDBs.Get(id)
 	if !ok {
@@ -76,6 +78,7 @@ func (db *ChainsDB) maybeInitSafeDB(id eth.ChainID, anchor types.DerivedBlockRef
 }
 
 func (db *ChainsDB) maybeInitFromUnsafe(id eth.ChainID, anchor eth.BlockRef) error {
+	defer db.beginWrite()()
 	logger := db.logger.New("chain", id, "anchor", anchor)
 	seal, err := db.FindSealedBlock(id, anchor.Number)
 	if errors.Is(err, types.ErrFuture) {
@@ -32,7 +32,8 @@ type LogStorage interface {
 
 	Rewind(inv reads.Invalidator, newHead eth.BlockID) error
 
-	// FirstSealedBlock() (block types.BlockSeal, err error)
+	// FirstSealedBlock returns the first block seal in the DB, if any.
+	FirstSealedBlock() (block types.BlockSeal, err error)
 	LatestSealedBlock() (id eth.BlockID, ok bool)
 
 	// FindSealedBlock finds the requested block by number, to check if it exists,
@@ -51,6 +52,9 @@ type LogStorage interface {
 
 	IteratorStartingAt(sealedNum uint64, logsSince uint32) (logs.Iterator, error)
 
+	// LogHash returns the hash of the log at the given block number and log index.
+	LogHash(blockNum uint64, logIdx uint32) (common.Hash, error)
+
 	// OpenBlock accumulates the ExecutingMessage events for a block and returns them
 	OpenBlock(blockNum uint64) (ref eth.BlockRef, logCount uint32, execMsgs map[uint32]*types.ExecutingMessage, err error)
 }
@@ -131,6 +135,9 @@ type ChainsDB struct {
 	// an error until it has this L1 finality to work with.
 	finalizedL1 locks.RWValue[eth.L1BlockRef]
 
+	// writes tracks the writes to the databases of all chains, for reads that need a consistent view across chains.
+	writes writeVersion
+
 	// readRegistry tracks what is actively being read,
 	// so we can invalidate reads that are affected by rewinds/reorgs.
 	readRegistry *reads.Registry
@@ -0,0 +1,549 @@
+package entrydb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// CompressedBlockEntries is the number of entries of the blocks of new compressed entry DBs.
+const CompressedBlockEntries = 256
+
+// compressedMagic starts the files of compressed entry DBs.
+// Uncompressed entry DBs start with the type of their first entry, a small number, so the formats do not collide.
+var compressedMagic = [4]byte{'o', 'p', 'c', 'z'}
+
+const (
+	compressedVersion    = 1
+	compressedHeaderSize = 12 // magic, version, compression, entry size (uint16), block entries (uint32)
+	blockHeaderSize      = 8  // compressed size (uint32), crc32 of the compressed data (uint32)
+	tailHeaderSize       = 8  // index of the first entry in the tail file (uint64)
+)
+
+var (
+	ErrInvalidCompressedDB = errors.New("invalid compressed entry db")
+	ErrCorruptBlock        = errors.New("corrupt compressed block")
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// CompressedEntryDB is an EntryStore that compresses its entries in blocks of a fixed number of entries.
+//
+// The sealed blocks are appended to the main file, after a header with the compression and the size of the blocks.
+// The entries of the block that is not full yet are appended uncompressed to a tail file, next to the main file,
+// after a header with the index of the first entry of the tail. Once the tail has a full block of entries,
+// the block is compressed and appended to the main file, and the tail is replaced with the remaining entries.
+//
+// Reading an entry of a sealed block decompresses the block. The last decompressed block is kept,
+// as entries are mostly read in sequence.
+//
+// Truncating into a sealed block replaces the tail with the retained entries of the block before
+// the main file is truncated, so the entries are never lost by an interrupted truncation.
+type CompressedEntryDB[T EntryType, E Entry[T], B Binary[T, E]] struct {
+	log  log.Logger
+	m    CompressionMetrics
+	path string
+	b    B
+
+	compression  Compression
+	codec        codec
+	blockEntries int64
+
+	data blockDataAccess
+	// blockOffsets are the offsets of the sealed blocks in the main file, followed by the end of the last block.
+	blockOffsets []int64
+
+	tail      *os.File
+	tailStart EntryIdx
+	tailSize  int64 // number of entries in the tail
+
+	// cached is the last decompressed block. Reads may race, so the cache is replaced atomically.
+	cached atomic.Pointer[cachedBlock]
+
+	// raw and compressed sizes of the sealed blocks
+	rawBytes        int64
+	compressedBytes int64
+
+	cleanupFailedWrite bool
+}
+
+// blockDataAccess is the subset of the os.File API used to access the sealed blocks in the main file.
+type blockDataAccess interface {
+	io.ReaderAt
+	io.WriterAt
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+// cachedBlock is an immutable decompressed block.
+type cachedBlock struct {
+	idx  int64
+	data []byte
+}
+
+// NewCompressedEntryDB opens a compressed entry DB at the path, and creates it with the compression if it does
+// not exist. The compression of an existing DB is read from its header.
+func NewCompressedEntryDB[T EntryType, E Entry[T], B Binary[T, E]](logger log.Logger, path string, compression Compression, m CompressionMetrics) (*CompressedEntryDB[T, E, B], error) {
+	if m == nil {
+		m = noopCompressionMetrics{}
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database at %v: %w", path, err)
+	}
+	db := &CompressedEntryDB[T, E, B]{
+		log:  logger,
+		m:    m,
+		path: path,
+		data: file,
+	}
+	if err := db.init(file, compression); err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to open compressed database at %v: %w", path, err), db.Close())
+	}
+	logger.Info("Opened compressed entry database", "path", path, "compression", db.compression,
+		"blocks", db.sealedBlocks(), "entries", db.Size())
+	return db, nil
+}
+
+func (e *CompressedEntryDB[T, E, B]) init(file *os.File, compression Compression) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat: %w", err)
+	}
+	if info.Size() == 0 {
+		if err := e.writeHeader(file, compression); err != nil {
+			return err
+		}
+	} else if err := e.readHeader(file); err != nil {
+		return err
+	}
+	e.codec, err = newCodec(e.compression)
+	if err != nil {
+		return err
+	}
+	if err := e.scanBlocks(info.Size()); err != nil {
+		return err
+	}
+	return e.openTail()
+}
+
+func (e *CompressedEntryDB[T, E, B]) writeHeader(file *os.File, compression Compression) error {
+	id, err := compression.id()
+	if err != nil {
+		return err
+	}
+	if compression.isNone() {
+		return fmt.Errorf("%w: compressed database requires a compression", ErrUnknownCompression)
+	}
+	var header [compressedHeaderSize]byte
+	copy(header[:4], compressedMagic[:])
+	header[4] = compressedVersion
+	header[5] = id
+	binary.BigEndian.PutUint16(header[6:8], uint16(e.b.EntrySize()))
+	binary.BigEndian.PutUint32(header[8:12], CompressedBlockEntries)
+	if _, err := file.WriteAt(header[:], 0); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	e.compression = compression
+	e.blockEntries = CompressedBlockEntries
+	return nil
+}
+
+func (e *CompressedEntryDB[T, E, B]) readHeader(file *os.File) error {
+	var header [compressedHeaderSize]byte
+	if _, err := file.ReadAt(header[:], 0); err != nil {
+		return fmt.Errorf("%w: failed to read header: %w", ErrInvalidCompressedDB, err)
+	}
+	if !bytes.Equal(header[:4], compressedMagic[:]) {
+		return fmt.Errorf("%w: not a compressed database", ErrInvalidCompressedDB)
+	}
+	if header[4] != compressedVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidCompressedDB, header[4])
+	}
+	compression, err := compressionFromID(header[5])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCompressedDB, err)
+	}
+	if size := binary.BigEndian.Uint16(header[6:8]); int(size) != e.b.EntrySize() {
+		return fmt.Errorf("%w: entry size %d, expected %d", ErrInvalidCompressedDB, size, e.b.EntrySize())
+	}
+	e.compression = compression
+	e.blockEntries = int64(binary.BigEndian.Uint32(header[8:12]))
+	if e.blockEntries == 0 {
+		return fmt.Errorf("%w: empty blocks", ErrInvalidCompressedDB)
+	}
+	return nil
+}
+
+// scanBlocks finds the offsets of the sealed blocks, and truncates a partially written last block.
+func (e *CompressedEntryDB[T, E, B]) scanBlocks(fileSize int64) error {
+	offset := int64(compressedHeaderSize)
+	e.blockOffsets = []int64{offset}
+	var header [blockHeaderSize]byte
+	for offset < fileSize {
+		if offset+blockHeaderSize > fileSize {
+			break
+		}
+		if _, err := e.data.ReadAt(header[:], offset); err != nil {
+			return fmt.Errorf("failed to read header of block %d: %w", len(e.blockOffsets)-1, err)
+		}
+		end := offset + blockHeaderSize + int64(binary.BigEndian.Uint32(header[:4]))
+		if end > fileSize {
+			break
+		}
+		e.compressedBytes += end - offset
+		offset = end
+		e.blockOffsets = append(e.blockOffsets, offset)
+	}
+	if offset != fileSize {
+		e.log.Warn("Truncating partially written block", "path", e.path, "block", e.sealedBlocks(), "size", fileSize-offset)
+		if err := e.data.Truncate(offset); err != nil {
+			return fmt.Errorf("failed to truncate partially written block: %w", err)
+		}
+	}
+	e.rawBytes = e.sealedBlocks() * e.blockEntries * int64(e.b.EntrySize())
+	e.m.RecordDBCompressedSize(e.rawBytes, e.compressedBytes)
+	return nil
+}
+
+// openTail opens the tail file, and repairs it if a write to the main file or the tail was interrupted.
+func (e *CompressedEntryDB[T, E, B]) openTail() error {
+	tail, err := os.OpenFile(e.tailPath(), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open tail: %w", err)
+	}
+	e.tail = tail
+	info, err := tail.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat tail: %w", err)
+	}
+	sealed := e.sealedEntries()
+	if info.Size() < tailHeaderSize {
+		return e.writeTail(sealed, nil)
+	}
+	var header [tailHeaderSize]byte
+	if _, err := tail.ReadAt(header[:], 0); err != nil {
+		return fmt.Errorf("failed to read tail header: %w", err)
+	}
+	e.tailStart = EntryIdx(binary.BigEndian.Uint64(header[:]))
+	entrySize := int64(e.b.EntrySize())
+	e.tailSize = (info.Size() - tailHeaderSize) / entrySize
+	if e.tailStart > sealed {
+		// The sealed blocks end before the tail starts, so the tail does not continue them.
+		e.log.Warn("Dropping tail that does not continue the sealed blocks", "path", e.path,
+			"sealed", sealed, "tailStart", e.tailStart, "tailSize", e.tailSize)
+		return e.writeTail(sealed, nil)
+	}
+	if e.tailStart < sealed && int64(sealed-e.tailStart) > e.tailSize && int64(e.tailStart)%e.blockEntries == 0 {
+		// The tail was replaced with the retained entries of a truncated block, but the main file was not truncated.
+		blockIdx := int64(e.tailStart) / e.blockEntries
+		e.log.Warn("Completing interrupted truncation", "path", e.path,
+			"sealed", sealed, "tailStart", e.tailStart, "tailSize", e.tailSize)
+		if err := e.truncateBlocks(blockIdx); err != nil {
+			return err
+		}
+		sealed = e.sealedEntries()
+	}
+	if e.tailStart < sealed {
+		// A block was sealed, but the tail was not replaced with the remaining entries.
+		skip := min(int64(sealed-e.tailStart), e.tailSize)
+		rest, err := e.readTail(skip, e.tailSize-skip)
+		if err != nil {
+			return err
+		}
+		if err := e.writeTail(sealed, rest); err != nil {
+			return err
+		}
+	} else if tailHeaderSize+e.tailSize*entrySize != info.Size() {
+		e.log.Warn("Tail size is not a multiple of entry size. Truncating to last complete entry", "path", e.path, "size", info.Size())
+		if err := e.tail.Truncate(tailHeaderSize + e.tailSize*entrySize); err != nil {
+			return fmt.Errorf("failed to truncate trailing partial entries of tail: %w", err)
+		}
+	}
+	return e.sealFullBlocks()
+}
+
+func (e *CompressedEntryDB[T, E, B]) tailPath() string {
+	return e.path + ".tail"
+}
+
+// readTail reads count entries of the tail, starting at the entry at offset i in the tail.
+func (e *CompressedEntryDB[T, E, B]) readTail(i int64, count int64) ([]byte, error) {
+	entrySize := int64(e.b.EntrySize())
+	out := make([]byte, count*entrySize)
+	if _, err := e.tail.ReadAt(out, tailHeaderSize+i*entrySize); err != nil && !(errors.Is(err, io.EOF) && count == 0) {
+		return nil, fmt.Errorf("failed to read tail: %w", err)
+	}
+	return out, nil
+}
+
+// writeTail replaces the tail with the entries, with start as index of the first entry.
+// The new tail is written and synced to a temporary file that replaces the tail, so the tail is always complete.
+func (e *CompressedEntryDB[T, E, B]) writeTail(start EntryIdx, entries []byte) error {
+	tmpPath := e.tailPath() + ".tmp"
+	data := binary.BigEndian.AppendUint64(make([]byte, 0, tailHeaderSize+len(entries)), uint64(start))
+	data = append(data, entries...)
+	if err := writeFileSync(tmpPath, data); err != nil {
+		return fmt.Errorf("failed to write tail: %w", err)
+	}
+	if err := os.Rename(tmpPath, e.tailPath()); err != nil {
+		return fmt.Errorf("failed to replace tail: %w", err)
+	}
+	if err := syncDir(filepath.Dir(e.tailPath())); err != nil {
+		return fmt.Errorf("failed to sync replaced tail: %w", err)
+	}
+	tail, err := os.OpenFile(e.tailPath(), os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open tail: %w", err)
+	}
+	if e.tail != nil {
+		_ = e.tail.Close()
+	}
+	e.tail = tail
+	e.tailStart = start
+	e.tailSize = int64(len(entries) / e.b.EntrySize())
+	return nil
+}
+
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
+
+// sealFullBlocks compresses the full blocks of entries in the tail, and appends them to the main file.
+func (e *CompressedEntryDB[T, E, B]) sealFullBlocks() error {
+	for e.tailSize >= e.blockEntries {
+		block, err := e.readTail(0, e.blockEntries)
+		if err != nil {
+			return err
+		}
+		compressed := e.codec.Encode(nil, block)
+		record := make([]byte, blockHeaderSize, blockHeaderSize+len(compressed))
+		binary.BigEndian.PutUint32(record[:4], uint32(len(compressed)))
+		binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(compressed, crcTable))
+		record = append(record, compressed...)
+		end := e.blockOffsets[len(e.blockOffsets)-1]
+		if _, err := e.data.WriteAt(record, end); err != nil {
+			return errors.Join(fmt.Errorf("failed to write block %d: %w", e.sealedBlocks(), err), e.data.Truncate(end))
+		}
+		// The block must be durable before the tail drops its entries, or a crash could lose both.
+		if err := e.data.Sync(); err != nil {
+			return errors.Join(fmt.Errorf("failed to sync block %d: %w", e.sealedBlocks(), err), e.data.Truncate(end))
+		}
+		e.blockOffsets = append(e.blockOffsets, end+int64(len(record)))
+		e.rawBytes += int64(len(block))
+		e.compressedBytes += int64(len(record))
+		e.m.RecordDBCompressedSize(e.rawBytes, e.compressedBytes)
+
+		rest, err := e.readTail(e.blockEntries, e.tailSize-e.blockEntries)
+		if err != nil {
+			return err
+		}
+		if err := e.writeTail(e.tailStart+EntryIdx(e.blockEntries), rest); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *CompressedEntryDB[T, E, B]) sealedBlocks() int64 {
+	return int64(len(e.blockOffsets) - 1)
+}
+
+func (e *CompressedEntryDB[T, E, B]) sealedEntries() EntryIdx {
+	return EntryIdx(e.sealedBlocks() * e.blockEntries)
+}
+
+// Compression returns the compression of the DB.
+func (e *CompressedEntryDB[T, E, B]) Compression() Compression {
+	return e.compression
+}
+
+func (e *CompressedEntryDB[T, E, B]) Size() int64 {
+	return int64(e.tailStart) + e.tailSize
+}
+
+// LastEntryIdx returns the index of the last entry in the DB.
+// This returns -1 if the DB is empty.
+func (e *CompressedEntryDB[T, E, B]) LastEntryIdx() EntryIdx {
+	return EntryIdx(e.Size() - 1)
+}
+
+// Read an entry from the database by index. Returns io.EOF iff idx is after the last entry.
+func (e *CompressedEntryDB[T, E, B]) Read(idx EntryIdx) (E, error) {
+	var out E
+	if idx > e.LastEntryIdx() {
+		return out, io.EOF
+	}
+	entrySize := int64(e.b.EntrySize())
+	var r io.ReaderAt
+	var at int64
+	if idx >= e.tailStart {
+		r, at = e.tail, tailHeaderSize+int64(idx-e.tailStart)*entrySize
+	} else {
+		block, err := e.block(int64(idx) / e.blockEntries)
+		if err != nil {
+			return out, fmt.Errorf("failed to read entry %v: %w", idx, err)
+		}
+		r, at = bytes.NewReader(block), (int64(idx)%e.blockEntries)*entrySize
+	}
+	read, err := e.b.ReadAt(&out, r, at)
+	// Ignore io.EOF if we read the entire last entry as ReadAt may return io.EOF or nil when it reads the last byte
+	if err != nil && !(errors.Is(err, io.EOF) && read == e.b.EntrySize()) {
+		return out, fmt.Errorf("failed to read entry %v: %w", idx, err)
+	}
+	return out, nil
+}
+
+// block returns the decompressed entries of the sealed block.
+func (e *CompressedEntryDB[T, E, B]) block(i int64) ([]byte, error) {
+	if cached := e.cached.Load(); cached != nil && cached.idx == i {
+		return cached.data, nil
+	}
+	start := time.Now()
+	offset, end := e.blockOffsets[i], e.blockOffsets[i+1]
+	record := make([]byte, end-offset)
+	if _, err := e.data.ReadAt(record, offset); err != nil {
+		return nil, fmt.Errorf("failed to read block %d: %w", i, err)
+	}
+	compressed := record[blockHeaderSize:]
+	if crc32.Checksum(compressed, crcTable) != binary.BigEndian.Uint32(record[4:8]) {
+		return nil, fmt.Errorf("%w: checksum mismatch of block %d", ErrCorruptBlock, i)
+	}
+	blockSize := e.blockEntries * int64(e.b.EntrySize())
+	data, err := e.codec.Decode(make([]byte, 0, blockSize), compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decompress block %d: %w", ErrCorruptBlock, i, err)
+	}
+	if int64(len(data)) != blockSize {
+		return nil, fmt.Errorf("%w: block %d has %d bytes, expected %d", ErrCorruptBlock, i, len(data), blockSize)
+	}
+	e.m.RecordDBDecompression(time.Since(start))
+	e.cached.Store(&cachedBlock{idx: i, data: data})
+	return data, nil
+}
+
+// Append entries to the database.
+// The entries are appended to the tail in a single write, and full blocks are then compressed.
+// If the write fails, it will attempt to truncate any partially written data.
+// Subsequent writes to this instance will fail until partially written data is truncated.
+// Failing to compress full blocks does not fail the append, as the entries are stored in the tail,
+// and is retried on the next append.
+func (e *CompressedEntryDB[T, E, B]) Append(entries ...E) error {
+	if e.cleanupFailedWrite {
+		// Try to rollback partially written data from a previous Append
+		if truncateErr := e.Truncate(e.LastEntryIdx()); truncateErr != nil {
+			return fmt.Errorf("failed to recover from previous write error: %w", truncateErr)
+		}
+	}
+	data := make([]byte, 0, len(entries)*e.b.EntrySize())
+	for i := range entries {
+		data = e.b.Append(data, &entries[i])
+	}
+	end := tailHeaderSize + e.tailSize*int64(e.b.EntrySize())
+	if n, err := e.tail.WriteAt(data, end); err != nil {
+		if n == 0 {
+			// Didn't write any data, so no recovery required
+			return err
+		}
+		// Try to rollback the partially written data
+		if truncateErr := e.tail.Truncate(end); truncateErr != nil {
+			// Failed to rollback, set a flag to attempt the clean up on the next write
+			e.cleanupFailedWrite = true
+			return errors.Join(err, fmt.Errorf("failed to remove partially written data: %w", truncateErr))
+		}
+		// Successfully rolled back the changes, still report the failed write
+		return err
+	}
+	e.tailSize += int64(len(entries))
+	if err := e.sealFullBlocks(); err != nil {
+		e.log.Warn("Failed to compress full blocks, retrying on the next append", "path", e.path, "err", err)
+	}
+	return nil
+}
+
+// Truncate the database so that the last retained entry is idx. Any entries after idx are deleted.
+// Truncating into a sealed block moves the retained entries of the block back to the tail.
+func (e *CompressedEntryDB[T, E, B]) Truncate(idx EntryIdx) error {
+	size := int64(idx) + 1
+	if size >= int64(e.tailStart) {
+		if err := e.tail.Truncate(tailHeaderSize + (size-int64(e.tailStart))*int64(e.b.EntrySize())); err != nil {
+			return fmt.Errorf("failed to truncate to entry %v: %w", idx, err)
+		}
+		e.tailSize = size - int64(e.tailStart)
+		e.cleanupFailedWrite = false
+		return nil
+	}
+	blockIdx := size / e.blockEntries
+	var retained []byte
+	if keep := size % e.blockEntries; keep > 0 {
+		block, err := e.block(blockIdx)
+		if err != nil {
+			return fmt.Errorf("failed to truncate to entry %v: %w", idx, err)
+		}
+		retained = bytes.Clone(block[:keep*int64(e.b.EntrySize())])
+	}
+	// The tail is replaced first: if the main file is not truncated, the truncation is completed when opening
+	// the DB, as the tail then starts before the end of the sealed blocks without containing them.
+	if err := e.writeTail(EntryIdx(blockIdx*e.blockEntries), retained); err != nil {
+		return fmt.Errorf("failed to truncate to entry %v: %w", idx, err)
+	}
+	if err := e.truncateBlocks(blockIdx); err != nil {
+		return fmt.Errorf("failed to truncate to entry %v: %w", idx, err)
+	}
+	e.cleanupFailedWrite = false
+	return nil
+}
+
+// truncateBlocks truncates the main file to the first blockIdx sealed blocks.
+func (e *CompressedEntryDB[T, E, B]) truncateBlocks(blockIdx int64) error {
+	if err := e.data.Truncate(e.blockOffsets[blockIdx]); err != nil {
+		return fmt.Errorf("failed to truncate blocks: %w", err)
+	}
+	e.compressedBytes -= e.blockOffsets[len(e.blockOffsets)-1] - e.blockOffsets[blockIdx]
+	e.blockOffsets = e.blockOffsets[:blockIdx+1]
+	e.rawBytes = e.sealedBlocks() * e.blockEntries * int64(e.b.EntrySize())
+	e.m.RecordDBCompressedSize(e.rawBytes, e.compressedBytes)
+	e.cached.Store(nil)
+	return nil
+}
+
+func (e *CompressedEntryDB[T, E, B]) Close() error {
+	var result error
+	if e.codec != nil {
+		e.codec.Close()
+	}
+	if e.tail != nil {
+		result = errors.Join(result, e.tail.Close())
+	}
+	return errors.Join(result, e.data.Close())
+}
@@ -0,0 +1,380 @@
+package entrydb
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type TestCompressedEntryDB = CompressedEntryDB[TestEntryType, TestEntry, TestEntryBinary]
+
+func TestCompressedReadWrite(t *testing.T) {
+	for _, compression := range []Compression{CompressionSnappy, CompressionZstd} {
+		t.Run(compression.String(), func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "entries.db")
+			m := &stubCompressionMetrics{}
+			db := openCompressedDB(t, path, compression, m)
+			require.EqualValues(t, 0, db.Size())
+			require.EqualValues(t, -1, db.LastEntryIdx())
+			_, err := db.Read(0)
+			require.ErrorIs(t, err, io.EOF)
+
+			const count = 2*CompressedBlockEntries + 10
+			appendEntries(t, db, 0, count)
+			require.EqualValues(t, count, db.Size())
+			require.EqualValues(t, 2, db.sealedBlocks())
+			requireEntries(t, db, count)
+			_, err = db.Read(count)
+			require.ErrorIs(t, err, io.EOF)
+
+			require.Greater(t, m.compressedBytes, int64(0))
+			require.Less(t, m.compressedBytes, m.rawBytes, "entries should compress")
+			require.EqualValues(t, 2*CompressedBlockEntries*TestEntrySize, m.rawBytes)
+			require.NotZero(t, m.decompressions)
+
+			require.NoError(t, db.Close())
+			db = openCompressedDB(t, path, CompressionNone, nil)
+			require.Equal(t, compression, db.Compression(), "compression is read from the header")
+			require.EqualValues(t, count, db.Size())
+			requireEntries(t, db, count)
+		})
+	}
+}
+
+func TestCompressedTruncate(t *testing.T) {
+	t.Run("InTail", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+10)
+		require.NoError(t, db.Truncate(CompressedBlockEntries+4))
+		require.EqualValues(t, CompressedBlockEntries+5, db.Size())
+		requireEntries(t, db, CompressedBlockEntries+5)
+
+		appendEntries(t, db, CompressedBlockEntries+5, 2)
+		requireEntries(t, db, CompressedBlockEntries+7)
+	})
+
+	t.Run("InSealedBlock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionZstd, nil)
+		appendEntries(t, db, 0, 3*CompressedBlockEntries+10)
+		require.EqualValues(t, 3, db.sealedBlocks())
+
+		require.NoError(t, db.Truncate(CompressedBlockEntries+19))
+		require.EqualValues(t, 1, db.sealedBlocks())
+		require.EqualValues(t, CompressedBlockEntries+20, db.Size())
+		requireEntries(t, db, CompressedBlockEntries+20)
+
+		// The retained entries of the block are sealed again once the block is full
+		appendEntries(t, db, CompressedBlockEntries+20, CompressedBlockEntries)
+		require.EqualValues(t, 2, db.sealedBlocks())
+		requireEntries(t, db, 2*CompressedBlockEntries+20)
+
+		require.NoError(t, db.Close())
+		db = openCompressedDB(t, path, CompressionZstd, nil)
+		requireEntries(t, db, 2*CompressedBlockEntries+20)
+	})
+
+	t.Run("AtBlockBoundary", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, 2*CompressedBlockEntries+10)
+		require.NoError(t, db.Truncate(CompressedBlockEntries-1))
+		require.EqualValues(t, 1, db.sealedBlocks())
+		require.EqualValues(t, CompressedBlockEntries, db.Size())
+		requireEntries(t, db, CompressedBlockEntries)
+	})
+
+	t.Run("All", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+10)
+		require.NoError(t, db.Truncate(-1))
+		require.EqualValues(t, 0, db.Size())
+		appendEntries(t, db, 0, 3)
+		requireEntries(t, db, 3)
+	})
+}
+
+func TestCompressedConcurrentReads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entries.db")
+	db := openCompressedDB(t, path, CompressionSnappy, nil)
+	const count = 4 * CompressedBlockEntries
+	appendEntries(t, db, 0, count)
+	var wg sync.WaitGroup
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			for i := 0; i < count; i++ {
+				// Each reader walks the blocks in a different order, so the cached block keeps changing
+				idx := (i + r*CompressedBlockEntries) % count
+				actual, err := db.Read(EntryIdx(idx))
+				if !assert.NoError(t, err) || !assert.Equal(t, testEntry(idx), actual, "entry %d", idx) {
+					return
+				}
+			}
+		}(r)
+	}
+	wg.Wait()
+}
+
+func TestCompressedRecovery(t *testing.T) {
+	t.Run("PartiallyWrittenBlock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+10)
+		require.NoError(t, db.Close())
+		// The block was sealed, but only part of it was written before a crash
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(path, info.Size()-5))
+		writeTail(t, path, 0, 0, CompressedBlockEntries+10)
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 1, db.sealedBlocks(), "block is sealed again from the tail")
+		requireEntries(t, db, CompressedBlockEntries+10)
+	})
+
+	t.Run("LostBlockWrite", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+10)
+		data := &unsyncedDataAccess{blockDataAccess: db.data, synced: db.blockOffsets[1]}
+		db.data = data
+		appendEntries(t, db, CompressedBlockEntries+10, CompressedBlockEntries)
+		require.EqualValues(t, 2, db.sealedBlocks())
+		require.NoError(t, db.Close())
+		// Writes to the main file that were not synced are lost in a crash
+		require.NoError(t, os.Truncate(path, data.synced))
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 2, db.sealedBlocks())
+		requireEntries(t, db, 2*CompressedBlockEntries+10)
+	})
+
+	t.Run("SealedBlockInTail", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+10)
+		require.NoError(t, db.Close())
+		// The block was sealed, but the tail was not replaced with the remaining entries before a crash
+		writeTail(t, path, 0, 0, CompressedBlockEntries+10)
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 1, db.sealedBlocks())
+		require.EqualValues(t, CompressedBlockEntries+10, db.Size())
+		requireEntries(t, db, CompressedBlockEntries+10)
+	})
+
+	t.Run("TruncatedBlocks", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, 2*CompressedBlockEntries+10)
+		// The main file was truncated into the first block, but the tail was not replaced before a crash
+		truncateAt := db.blockOffsets[0]
+		require.NoError(t, db.Close())
+		require.NoError(t, os.Truncate(path, truncateAt))
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 0, db.Size(), "tail that does not continue the blocks is dropped")
+		appendEntries(t, db, 0, 3)
+		requireEntries(t, db, 3)
+	})
+
+	t.Run("InterruptedTruncation", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, 2*CompressedBlockEntries+10)
+		require.NoError(t, db.Close())
+		// The tail was replaced with the retained entries of the first block, but the main file was not truncated
+		writeTail(t, path, 0, 0, 5)
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 0, db.sealedBlocks(), "truncation of the main file is completed")
+		requireEntries(t, db, 5)
+		appendEntries(t, db, 5, CompressedBlockEntries)
+		requireEntries(t, db, CompressedBlockEntries+5)
+	})
+
+	t.Run("PartialTailEntry", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, 5)
+		require.NoError(t, db.Close())
+		f, err := os.OpenFile(path+".tail", os.O_WRONLY|os.O_APPEND, 0o644)
+		require.NoError(t, err)
+		_, err = f.Write([]byte{1, 2, 3})
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		require.EqualValues(t, 5, db.Size())
+		appendEntries(t, db, 5, 1)
+		requireEntries(t, db, 6)
+	})
+
+	t.Run("CorruptBlock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		db := openCompressedDB(t, path, CompressionSnappy, nil)
+		appendEntries(t, db, 0, CompressedBlockEntries+1)
+		offset := db.blockOffsets[0] + blockHeaderSize + 2
+		require.NoError(t, db.Close())
+		f, err := os.OpenFile(path, os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = f.WriteAt([]byte{0xff, 0xff}, offset)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		db = openCompressedDB(t, path, CompressionSnappy, nil)
+		_, err = db.Read(0)
+		require.ErrorIs(t, err, ErrCorruptBlock)
+		entry, err := db.Read(CompressedBlockEntries)
+		require.NoError(t, err, "entries of the tail are not affected")
+		require.Equal(t, testEntry(CompressedBlockEntries), entry)
+	})
+}
+
+func TestOpenEntryStore(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	open := func(t *testing.T, path string, compression Compression) EntryStore[TestEntryType, TestEntry] {
+		store, err := OpenEntryStore[TestEntryType, TestEntry, TestEntryBinary](logger, path, compression, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = store.Close()
+		})
+		return store
+	}
+
+	t.Run("NewCompressed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		store := open(t, path, CompressionZstd)
+		require.IsType(t, &TestCompressedEntryDB{}, store)
+		appendEntries(t, store, 0, 3)
+		require.NoError(t, store.Close())
+
+		store = open(t, path, CompressionNone)
+		require.IsType(t, &TestCompressedEntryDB{}, store, "existing compressed DB is read transparently")
+		requireEntries(t, store, 3)
+	})
+
+	t.Run("NewUncompressed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		store := open(t, path, CompressionNone)
+		require.IsType(t, &TestEntryDB{}, store)
+	})
+
+	t.Run("ExistingUncompressed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		store := open(t, path, CompressionNone)
+		appendEntries(t, store, 0, 3)
+		require.NoError(t, store.Close())
+
+		store = open(t, path, CompressionSnappy)
+		require.IsType(t, &TestEntryDB{}, store, "existing uncompressed DB is kept uncompressed")
+		requireEntries(t, store, 3)
+	})
+
+	t.Run("EmptyFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "entries.db")
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+		store := open(t, path, CompressionSnappy)
+		require.IsType(t, &TestCompressedEntryDB{}, store)
+	})
+
+	t.Run("UnknownCompression", func(t *testing.T) {
+		_, err := OpenEntryStore[TestEntryType, TestEntry, TestEntryBinary](logger, filepath.Join(t.TempDir(), "entries.db"), "lz4", nil)
+		require.ErrorIs(t, err, ErrUnknownCompression)
+	})
+}
+
+func openCompressedDB(t *testing.T, path string, compression Compression, m CompressionMetrics) *TestCompressedEntryDB {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewCompressedEntryDB[TestEntryType, TestEntry, TestEntryBinary](logger, path, compression, m)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	return db
+}
+
+// testEntry creates an entry that is unique to i, and compresses well.
+func testEntry(i int) TestEntry {
+	var out TestEntry
+	binary.BigEndian.PutUint32(out[:4], uint32(i))
+	return out
+}
+
+func appendEntries(t *testing.T, db EntryStore[TestEntryType, TestEntry], start int, count int) {
+	for i := start; i < start+count; i++ {
+		require.NoError(t, db.Append(testEntry(i)))
+	}
+}
+
+func requireEntries(t *testing.T, db EntryStore[TestEntryType, TestEntry], count int) {
+	require.EqualValues(t, count, db.Size())
+	for i := 0; i < count; i++ {
+		actual, err := db.Read(EntryIdx(i))
+		require.NoError(t, err)
+		require.Equal(t, testEntry(i), actual, "entry %d", i)
+	}
+}
+
+// writeTail replaces the tail file of the DB at the path with the entries from first to first+count.
+func writeTail(t *testing.T, path string, start EntryIdx, first int, count int) {
+	data := binary.BigEndian.AppendUint64(nil, uint64(start))
+	for i := first; i < first+count; i++ {
+		entry := testEntry(i)
+		data = append(data, entry[:]...)
+	}
+	require.NoError(t, os.WriteFile(path+".tail", data, 0o644))
+}
+
+type stubCompressionMetrics struct {
+	rawBytes        int64
+	compressedBytes int64
+	decompressions  int
+}
+
+func (s *stubCompressionMetrics) RecordDBCompressedSize(rawBytes int64, compressedBytes int64) {
+	s.rawBytes = rawBytes
+	s.compressedBytes = compressedBytes
+}
+
+func (s *stubCompressionMetrics) RecordDBDecompression(time.Duration) {
+	s.decompressions++
+}
+
+// unsyncedDataAccess tracks the size of the main file when it was last synced.
+type unsyncedDataAccess struct {
+	blockDataAccess
+	synced int64
+	size   int64
+}
+
+func (u *unsyncedDataAccess) WriteAt(p []byte, off int64) (int, error) {
+	n, err := u.blockDataAccess.WriteAt(p, off)
+	u.size = max(u.size, off+int64(n))
+	return n, err
+}
+
+func (u *unsyncedDataAccess) Truncate(size int64) error {
+	u.size = size
+	u.synced = min(u.synced, size)
+	return u.blockDataAccess.Truncate(size)
+}
+
+func (u *unsyncedDataAccess) Sync() error {
+	u.synced = max(u.synced, u.size)
+	return u.blockDataAccess.Sync()
+}
@@ -0,0 +1,131 @@
+package entrydb
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+var ErrUnknownCompression = errors.New("unknown compression")
+
+// Compression is the algorithm to compress the blocks of entries of an entry DB with.
+// The zero value is equivalent to CompressionNone.
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionSnappy Compression = "snappy"
+	CompressionZstd   Compression = "zstd"
+)
+
+var Compressions = []Compression{CompressionNone, CompressionSnappy, CompressionZstd}
+
+func (c Compression) String() string {
+	return string(c)
+}
+
+func (c Compression) Check() error {
+	if _, err := c.id(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c Compression) isNone() bool {
+	return c == CompressionNone || c == ""
+}
+
+// id is the identifier of the compression in the header of compressed files.
+func (c Compression) id() (byte, error) {
+	switch c {
+	case CompressionSnappy:
+		return 1, nil
+	case CompressionZstd:
+		return 2, nil
+	case CompressionNone, "":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownCompression, string(c))
+	}
+}
+
+func compressionFromID(id byte) (Compression, error) {
+	for _, c := range Compressions {
+		if cid, _ := c.id(); cid == id && !c.isNone() {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("%w: id %d", ErrUnknownCompression, id)
+}
+
+// CompressionMetrics records the effect of the compression of an entry DB.
+type CompressionMetrics interface {
+	// RecordDBCompressedSize records the size of the compressed blocks of entries, before and after compression.
+	RecordDBCompressedSize(rawBytes int64, compressedBytes int64)
+	// RecordDBDecompression records the time spent to decompress a block of entries, to read an entry from it.
+	RecordDBDecompression(d time.Duration)
+}
+
+type noopCompressionMetrics struct{}
+
+func (noopCompressionMetrics) RecordDBCompressedSize(int64, int64) {}
+func (noopCompressionMetrics) RecordDBDecompression(time.Duration) {}
+
+// codec compresses and decompresses blocks of entries.
+type codec interface {
+	Encode(dst []byte, src []byte) []byte
+	Decode(dst []byte, src []byte) ([]byte, error)
+	Close()
+}
+
+func newCodec(c Compression) (codec, error) {
+	switch c {
+	case CompressionSnappy:
+		return snappyCodec{}, nil
+	case CompressionZstd:
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+		}
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+		return &zstdCodec{enc: enc, dec: dec}, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot compress with %q", ErrUnknownCompression, string(c))
+	}
+}
+
+type snappyCodec struct{}
+
+func (snappyCodec) Encode(dst []byte, src []byte) []byte {
+	return snappy.Encode(dst[:cap(dst)], src)
+}
+
+func (snappyCodec) Decode(dst []byte, src []byte) ([]byte, error) {
+	return snappy.Decode(dst[:cap(dst)], src)
+}
+
+func (snappyCodec) Close() {}
+
+type zstdCodec struct {
+	enc *zstd.Encoder
+	dec *zstd.Decoder
+}
+
+func (c *zstdCodec) Encode(dst []byte, src []byte) []byte {
+	return c.enc.EncodeAll(src, dst[:0])
+}
+
+func (c *zstdCodec) Decode(dst []byte, src []byte) ([]byte, error) {
+	return c.dec.DecodeAll(src, dst[:0])
+}
+
+func (c *zstdCodec) Close() {
+	_ = c.enc.Close()
+	c.dec.Close()
+}
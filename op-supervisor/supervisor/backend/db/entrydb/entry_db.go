@@ -166,3 +166,42 @@ func (e *EntryDB[T, E, B]) recover() error {
 func (e *EntryDB[T, E, B]) Close() error {
 	return e.data.Close()
 }
+
+// OpenEntryStore opens the entry DB at the path, compressed or not, as detected from the existing file.
+// A new DB is created with the given compression, or uncompressed with CompressionNone.
+// The format of an existing DB is kept, regardless of the given compression.
+func OpenEntryStore[T EntryType, E Entry[T], B Binary[T, E]](logger log.Logger, path string, compression Compression, m CompressionMetrics) (EntryStore[T, E], error) {
+	if err := compression.Check(); err != nil {
+		return nil, err
+	}
+	compressed, exists, err := isCompressed(path)
+	if err != nil {
+		return nil, err
+	}
+	if exists && !compressed && !compression.isNone() {
+		logger.Warn("Existing database is not compressed, keeping it uncompressed", "path", path, "compression", compression)
+	}
+	if compressed || (!exists && !compression.isNone()) {
+		return NewCompressedEntryDB[T, E, B](logger, path, compression, m)
+	}
+	return NewEntryDB[T, E, B](logger, path)
+}
+
+// isCompressed returns whether the file at the path is a compressed entry DB, and whether it has any data.
+func isCompressed(path string) (compressed bool, exists bool, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, false, nil
+	} else if err != nil {
+		return false, false, fmt.Errorf("failed to open database at %v: %w", path, err)
+	}
+	defer f.Close()
+	var magic [len(compressedMagic)]byte
+	n, err := io.ReadFull(f, magic[:])
+	if n == 0 && errors.Is(err, io.EOF) {
+		return false, false, nil
+	} else if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, false, fmt.Errorf("failed to read database at %v: %w", path, err)
+	}
+	return magic == compressedMagic, true, nil
+}
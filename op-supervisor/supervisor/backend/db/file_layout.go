@@ -32,6 +32,21 @@ func prepLogDBPath(chainID eth.ChainID, datadir string) (string, error) {
 	return filepath.Join(dir, "log.db"), nil
 }
 
+func prepLogIndexDBPath(chainID eth.ChainID, datadir string) (string, error) {
+	dir, err := prepChainDir(chainID, datadir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "log_index.db"), nil
+}
+
+func prepSuperRootsDBPath(datadir string) (string, error) {
+	if err := PrepDataDir(datadir); err != nil {
+		return "", err
+	}
+	return filepath.Join(datadir, "super_roots.db"), nil
+}
+
 func prepChainDir(chainID eth.ChainID, datadir string) (string, error) {
 	dir := filepath.Join(datadir, chainID.String())
 	if err := os.MkdirAll(dir, 0755); err != nil {
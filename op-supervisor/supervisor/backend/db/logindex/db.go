@@ -0,0 +1,295 @@
+package logindex
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type EntryStore interface {
+	Size() int64
+	LastEntryIdx() entrydb.EntryIdx
+	Read(idx entrydb.EntryIdx) (Entry, error)
+	Append(entries ...Entry) error
+	Truncate(idx entrydb.EntryIdx) error
+	Close() error
+}
+
+// Location identifies a log within a chain.
+type Location struct {
+	BlockNum uint64
+	LogIdx   uint32
+}
+
+// Log is a log of an indexed block, as recorded in the events DB.
+type Log struct {
+	Index uint32
+	// Hash is the log hash, see types.PayloadHashToLogHash.
+	Hash common.Hash
+	// ExecMsg is the executing message of the log, if any.
+	ExecMsg *types.ExecutingMessage
+}
+
+// DB is a side index of the events DB of a single chain.
+// It maps log hashes to the initiating logs, and message checksums to the executing messages,
+// so these can be found without a linear scan of the events DB.
+//
+// Entries are persisted append-only, separate from the events DB, in block order,
+// with a seal entry after the entries of every indexed block.
+// Seal entries of blocks without any logs are compacted: only the latest such seal is retained.
+// The lookup tables are kept in memory, and are loaded from the persisted entries when opening the DB.
+//
+// The index can always be rebuilt from the events DB, and is thus safe to Clear.
+type DB struct {
+	log    log.Logger
+	store  EntryStore
+	rwLock sync.RWMutex
+
+	head    eth.BlockID
+	hasHead bool
+
+	byLogHash  map[common.Hash][]Location
+	byChecksum map[types.MessageChecksum][]Location
+}
+
+func NewFromFile(logger log.Logger, path string) (*DB, error) {
+	store, err := entrydb.NewEntryDB[EntryType, Entry, EntryBinary](logger, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DB: %w", err)
+	}
+	return NewFromEntryStore(logger, store)
+}
+
+func NewFromEntryStore(logger log.Logger, store EntryStore) (*DB, error) {
+	db := &DB{
+		log:        logger,
+		store:      store,
+		byLogHash:  make(map[common.Hash][]Location),
+		byChecksum: make(map[types.MessageChecksum][]Location),
+	}
+	if err := db.init(); err != nil {
+		return nil, fmt.Errorf("failed to init log index: %w", err)
+	}
+	return db, nil
+}
+
+// init trims any entries of a block that was not fully written, and loads the lookup tables.
+func (db *DB) init() error {
+	lastSeal := db.store.LastEntryIdx()
+	for ; lastSeal >= 0; lastSeal-- {
+		e, err := db.store.Read(lastSeal)
+		if err != nil {
+			return fmt.Errorf("failed to read entry %d: %w", lastSeal, err)
+		}
+		if e.Type() == TypeSeal {
+			break
+		}
+	}
+	if lastSeal < db.store.LastEntryIdx() {
+		db.log.Warn("Truncating unsealed trailing log index entries", "prev", db.store.LastEntryIdx(), "new", lastSeal)
+		if err := db.store.Truncate(lastSeal); err != nil {
+			return fmt.Errorf("failed to truncate trailing entries: %w", err)
+		}
+	}
+	for i := entrydb.EntryIdx(0); i <= lastSeal; i++ {
+		r, err := db.readAt(i)
+		if err != nil {
+			return err
+		}
+		db.apply(r)
+	}
+	return nil
+}
+
+// Head returns the last indexed block, or ok=false if nothing was indexed yet.
+func (db *DB) Head() (head eth.BlockID, ok bool) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	return db.head, db.hasHead
+}
+
+// InitiatingLogs returns the locations of the logs with the given log hash, in block order.
+// The log hash commits to both the address and the payload (topics and data) of the log.
+func (db *DB) InitiatingLogs(logHash common.Hash) []Location {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	return append([]Location(nil), db.byLogHash[logHash]...)
+}
+
+// ExecutingMessages returns the locations of the logs that execute the message with the given checksum, in block order.
+func (db *DB) ExecutingMessages(checksum types.MessageChecksum) []Location {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	return append([]Location(nil), db.byChecksum[checksum]...)
+}
+
+// AddBlock indexes the logs of the given block. The block must be the next block after the current head.
+func (db *DB) AddBlock(block eth.BlockID, logs []Log) error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	if db.hasHead && block.Number != db.head.Number+1 {
+		return fmt.Errorf("cannot index block %s after %s: %w", block, db.head, types.ErrOutOfOrder)
+	}
+	records := make([]record, 0, len(logs)+1)
+	for _, l := range logs {
+		records = append(records, record{typ: TypeLog, blockNum: block.Number, logIdx: l.Index, key: l.Hash})
+		if l.ExecMsg != nil {
+			records = append(records, record{typ: TypeExec, blockNum: block.Number, logIdx: l.Index, key: common.Hash(l.ExecMsg.Checksum)})
+		}
+	}
+	records = append(records, record{typ: TypeSeal, blockNum: block.Number, key: block.Hash})
+	entries := make([]Entry, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, encodeRecord(r))
+	}
+	if len(logs) == 0 {
+		compact, err := db.emptyHeadSeal()
+		if err != nil {
+			return err
+		}
+		if compact {
+			// The seal of the previous block is not needed anymore: replace it.
+			if err := db.store.Truncate(db.store.LastEntryIdx() - 1); err != nil {
+				return fmt.Errorf("failed to compact seal of %s: %w", db.head, err)
+			}
+		}
+	}
+	if err := db.store.Append(entries...); err != nil {
+		return fmt.Errorf("failed to append entries of block %s: %w", block, err)
+	}
+	for _, r := range records {
+		db.apply(r)
+	}
+	return nil
+}
+
+// emptyHeadSeal checks if the last entry is the seal of a block without logs,
+// that is not the only seal that is left.
+func (db *DB) emptyHeadSeal() (bool, error) {
+	last := db.store.LastEntryIdx()
+	if last < 1 {
+		return false, nil
+	}
+	prev, err := db.store.Read(last - 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to read entry %d: %w", last-1, err)
+	}
+	return prev.Type() == TypeSeal, nil
+}
+
+// RewindTo removes all indexed data of blocks past the given block number.
+// Because of compaction, the head after rewinding may be older than the given block number.
+func (db *DB) RewindTo(blockNum uint64) error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	var searchErr error
+	i := sort.Search(int(db.store.Size()), func(i int) bool {
+		r, err := db.readAt(entrydb.EntryIdx(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return r.blockNum > blockNum
+	})
+	if searchErr != nil {
+		return fmt.Errorf("failed to search for block %d: %w", blockNum, searchErr)
+	}
+	return db.truncate(entrydb.EntryIdx(i) - 1)
+}
+
+// Clear removes all indexed data, so the index can be rebuilt from scratch.
+func (db *DB) Clear() error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	return db.truncate(-1)
+}
+
+// truncate removes all entries past the given index, and removes them from the lookup tables.
+func (db *DB) truncate(idx entrydb.EntryIdx) error {
+	last := db.store.LastEntryIdx()
+	if idx >= last {
+		return nil // nothing to remove
+	}
+	for i := last; i > idx; i-- {
+		r, err := db.readAt(i)
+		if err != nil {
+			return err
+		}
+		db.revert(r)
+	}
+	if err := db.store.Truncate(idx); err != nil {
+		return fmt.Errorf("failed to truncate log index to entry %d: %w", idx, err)
+	}
+	db.hasHead = false
+	db.head = eth.BlockID{}
+	if idx >= 0 {
+		r, err := db.readAt(idx)
+		if err != nil {
+			return err
+		}
+		if r.typ != TypeSeal {
+			return fmt.Errorf("%w: expected seal entry at %d, got %s", types.ErrDataCorruption, idx, r.typ)
+		}
+		db.apply(r)
+	}
+	return nil
+}
+
+// apply adds a record, that was read or written in block order, to the lookup tables.
+func (db *DB) apply(r record) {
+	switch r.typ {
+	case TypeSeal:
+		db.head = eth.BlockID{Hash: r.key, Number: r.blockNum}
+		db.hasHead = true
+	case TypeLog:
+		db.byLogHash[r.key] = append(db.byLogHash[r.key], r.location())
+	case TypeExec:
+		k := types.MessageChecksum(r.key)
+		db.byChecksum[k] = append(db.byChecksum[k], r.location())
+	}
+}
+
+// revert removes a record, that is removed in reverse block order, from the lookup tables.
+func (db *DB) revert(r record) {
+	switch r.typ {
+	case TypeLog:
+		db.byLogHash[r.key] = popLocation(db.byLogHash[r.key], r.location())
+		if len(db.byLogHash[r.key]) == 0 {
+			delete(db.byLogHash, r.key)
+		}
+	case TypeExec:
+		k := types.MessageChecksum(r.key)
+		db.byChecksum[k] = popLocation(db.byChecksum[k], r.location())
+		if len(db.byChecksum[k]) == 0 {
+			delete(db.byChecksum, k)
+		}
+	}
+}
+
+func popLocation(locs []Location, loc Location) []Location {
+	if n := len(locs); n > 0 && locs[n-1] == loc {
+		return locs[:n-1]
+	}
+	return locs
+}
+
+func (db *DB) readAt(i entrydb.EntryIdx) (record, error) {
+	entry, err := db.store.Read(i)
+	if err != nil {
+		return record{}, fmt.Errorf("failed to read entry %d: %w", i, err)
+	}
+	return decodeRecord(entry)
+}
+
+func (db *DB) Close() error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	return db.store.Close()
+}
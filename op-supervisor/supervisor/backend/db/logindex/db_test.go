@@ -0,0 +1,145 @@
+package logindex
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func mockBlock(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0xbb, byte(num)}, Number: num}
+}
+
+func createDB(t *testing.T, path string) *DB {
+	logger := testlog.Logger(t, log.LvlTrace)
+	db, err := NewFromFile(logger, path)
+	require.NoError(t, err)
+	return db
+}
+
+func TestEncodeDecode(t *testing.T) {
+	r := record{typ: TypeExec, blockNum: 1234, logIdx: 5, key: common.Hash{0xaa}}
+	got, err := decodeRecord(encodeRecord(r))
+	require.NoError(t, err)
+	require.Equal(t, r, got)
+
+	corrupt := encodeRecord(r)
+	corrupt[2] = 1
+	_, err = decodeRecord(corrupt)
+	require.ErrorIs(t, err, types.ErrDataCorruption)
+
+	corrupt = encodeRecord(r)
+	corrupt[0] = 42
+	_, err = decodeRecord(corrupt)
+	require.ErrorIs(t, err, types.ErrDataCorruption)
+}
+
+func TestEmptyDB(t *testing.T) {
+	db := createDB(t, filepath.Join(t.TempDir(), "test.db"))
+	_, ok := db.Head()
+	require.False(t, ok)
+	require.Empty(t, db.InitiatingLogs(common.Hash{0xaa}))
+	require.Empty(t, db.ExecutingMessages(types.MessageChecksum{0xcc}))
+	require.NoError(t, db.RewindTo(0))
+	require.NoError(t, db.Clear())
+	require.NoError(t, db.Close())
+}
+
+func TestIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := createDB(t, path)
+
+	logA := common.Hash{0xaa}
+	logB := common.Hash{0xab}
+	checksum := types.MessageChecksum{0xcc}
+	execMsg := &types.ExecutingMessage{Checksum: checksum}
+
+	require.NoError(t, db.AddBlock(mockBlock(10), nil))
+	require.NoError(t, db.AddBlock(mockBlock(11), []Log{
+		{Index: 0, Hash: logA},
+		{Index: 1, Hash: logB, ExecMsg: execMsg},
+	}))
+	require.NoError(t, db.AddBlock(mockBlock(12), nil))
+	require.NoError(t, db.AddBlock(mockBlock(13), []Log{
+		{Index: 0, Hash: logA, ExecMsg: execMsg},
+	}))
+	require.ErrorIs(t, db.AddBlock(mockBlock(13), nil), types.ErrOutOfOrder)
+	require.ErrorIs(t, db.AddBlock(mockBlock(15), nil), types.ErrOutOfOrder)
+
+	check := func(db *DB) {
+		head, ok := db.Head()
+		require.True(t, ok)
+		require.Equal(t, mockBlock(13), head)
+		require.Equal(t, []Location{{BlockNum: 11, LogIdx: 0}, {BlockNum: 13, LogIdx: 0}}, db.InitiatingLogs(logA))
+		require.Equal(t, []Location{{BlockNum: 11, LogIdx: 1}}, db.InitiatingLogs(logB))
+		require.Equal(t, []Location{{BlockNum: 11, LogIdx: 1}, {BlockNum: 13, LogIdx: 0}}, db.ExecutingMessages(checksum))
+	}
+	check(db)
+
+	// The index is loaded again after a restart
+	require.NoError(t, db.Close())
+	db = createDB(t, path)
+	check(db)
+
+	require.NoError(t, db.RewindTo(12))
+	head, ok := db.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(12), head)
+	require.Equal(t, []Location{{BlockNum: 11, LogIdx: 0}}, db.InitiatingLogs(logA))
+	require.Equal(t, []Location{{BlockNum: 11, LogIdx: 1}}, db.ExecutingMessages(checksum))
+
+	require.NoError(t, db.Clear())
+	_, ok = db.Head()
+	require.False(t, ok)
+	require.Empty(t, db.InitiatingLogs(logA))
+	require.Empty(t, db.ExecutingMessages(checksum))
+	require.NoError(t, db.Close())
+}
+
+func TestCompaction(t *testing.T) {
+	db := createDB(t, filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, db.AddBlock(mockBlock(0), nil))
+	require.NoError(t, db.AddBlock(mockBlock(1), []Log{{Index: 0, Hash: common.Hash{0xaa}}}))
+	for i := uint64(2); i <= 100; i++ {
+		require.NoError(t, db.AddBlock(mockBlock(i), nil))
+	}
+	// seal of 0, log and seal of 1, and the latest seal that replaced the seals of 2-99
+	require.Equal(t, int64(4), db.store.Size())
+	head, ok := db.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(100), head)
+
+	// Rewinding into the compacted range falls back to the last retained seal
+	require.NoError(t, db.RewindTo(50))
+	head, ok = db.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(1), head)
+	require.Equal(t, []Location{{BlockNum: 1, LogIdx: 0}}, db.InitiatingLogs(common.Hash{0xaa}))
+	require.NoError(t, db.AddBlock(mockBlock(2), nil))
+	require.NoError(t, db.Close())
+}
+
+func TestTrimUnsealed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := createDB(t, path)
+	require.NoError(t, db.AddBlock(mockBlock(10), nil))
+	// Entries of a block that was not fully written
+	require.NoError(t, db.store.Append(encodeRecord(record{typ: TypeLog, blockNum: 11, key: common.Hash{0xaa}})))
+	require.NoError(t, db.Close())
+
+	db = createDB(t, path)
+	require.Equal(t, int64(1), db.store.Size())
+	head, ok := db.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(10), head)
+	require.Empty(t, db.InitiatingLogs(common.Hash{0xaa}))
+	require.NoError(t, db.Close())
+}
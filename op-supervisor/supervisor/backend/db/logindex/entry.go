@@ -0,0 +1,98 @@
+package logindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// EntrySize is the size of the DB entry.
+// 1+3+4+8+32=48
+const EntrySize = 48
+
+type Entry [EntrySize]byte
+
+func (e Entry) Type() EntryType {
+	return EntryType(e[0])
+}
+
+type EntryType uint8
+
+const (
+	// TypeSeal marks the end of the entries of an indexed block. The key is the block hash.
+	TypeSeal EntryType = 0
+	// TypeLog indexes an initiating log. The key is the log hash.
+	TypeLog EntryType = 1
+	// TypeExec indexes an executing message. The key is the checksum of the executed message.
+	TypeExec EntryType = 2
+)
+
+func (s EntryType) String() string {
+	switch s {
+	case TypeSeal:
+		return "seal"
+	case TypeLog:
+		return "log"
+	case TypeExec:
+		return "exec"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+type EntryBinary struct{}
+
+func (EntryBinary) Append(dest []byte, e *Entry) []byte {
+	return append(dest, e[:]...)
+}
+
+func (EntryBinary) ReadAt(dest *Entry, r io.ReaderAt, at int64) (n int, err error) {
+	return r.ReadAt(dest[:], at)
+}
+
+func (EntryBinary) EntrySize() int {
+	return EntrySize
+}
+
+// record is the decoded form of an entry.
+type record struct {
+	typ      EntryType
+	blockNum uint64
+	logIdx   uint32
+	key      common.Hash
+}
+
+func (r record) location() Location {
+	return Location{BlockNum: r.blockNum, LogIdx: r.logIdx}
+}
+
+func decodeRecord(e Entry) (record, error) {
+	switch t := e.Type(); t {
+	case TypeSeal, TypeLog, TypeExec:
+	default:
+		return record{}, fmt.Errorf("%w: unexpected entry type: %s", types.ErrDataCorruption, t)
+	}
+	if [3]byte(e[1:4]) != ([3]byte{}) {
+		return record{}, fmt.Errorf("%w: expected empty data, to pad entry size to round number: %x", types.ErrDataCorruption, e[1:4])
+	}
+	// Format:
+	// type(1) padding(3) log-index(4) block-number(8) key(32)
+	r := record{typ: e.Type()}
+	r.logIdx = binary.BigEndian.Uint32(e[4:8])
+	r.blockNum = binary.BigEndian.Uint64(e[8:16])
+	copy(r.key[:], e[16:48])
+	return r, nil
+}
+
+func encodeRecord(r record) Entry {
+	var out Entry
+	out[0] = uint8(r.typ)
+	binary.BigEndian.PutUint32(out[4:8], r.logIdx)
+	binary.BigEndian.PutUint64(out[8:16], r.blockNum)
+	copy(out[16:48], r.key[:])
+	return out
+}
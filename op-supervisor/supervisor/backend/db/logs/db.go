@@ -49,8 +49,10 @@ type DB struct {
 	lastEntryContext logContext
 }
 
+// NewFromFile opens the DB at the path, creating an uncompressed DB if it does not exist.
+// Existing compressed DBs are read and written compressed.
 func NewFromFile(logger log.Logger, m Metrics, chainID eth.ChainID, path string, trimToLastSealed bool) (*DB, error) {
-	store, err := entrydb.NewEntryDB[EntryType, Entry, EntryBinary](logger, path)
+	store, err := entrydb.OpenEntryStore[EntryType, Entry, EntryBinary](logger, path, entrydb.CompressionNone, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open DB: %w", err)
 	}
@@ -366,6 +368,15 @@ func (db *DB) Contains(query types.ContainsQuery) (types.BlockSeal, error) {
 	return types.BlockSeal{}, err
 }
 
+// LogHash returns the hash of the log at the given block number and log index, see types.PayloadHashToLogHash.
+// It returns ErrFuture if the log is not known yet, and ErrConflict if the block does not have as many logs.
+func (db *DB) LogHash(blockNum uint64, logIdx uint32) (common.Hash, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	logHash, _, err := db.findLogInfo(blockNum, logIdx)
+	return logHash, err
+}
+
 // findLogInfo returns the hash of the log at the specified block number and log index.
 // If a log isn't found at the index we return an ErrFuture, even if the block is complete.
 func (db *DB) findLogInfo(blockNum uint64, logIdx uint32) (common.Hash, Iterator, error) {
@@ -808,6 +808,38 @@ func TestContainsOutOfRangeLogIndex(t *testing.T) {
 		})
 }
 
+func TestLogHash(t *testing.T) {
+	runDBTest(t,
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			bl10 := eth.BlockID{Hash: createHash(10), Number: 10}
+			require.NoError(t, db.lastEntryContext.forceBlock(bl10, 5000))
+			require.NoError(t, db.AddLog(createHash(1), bl10, 0, nil))
+			require.NoError(t, db.AddLog(createHash(2), bl10, 1, nil))
+			bl11 := eth.BlockID{Hash: createHash(11), Number: 11}
+			require.NoError(t, db.SealBlock(bl10.Hash, bl11, 5001))
+			require.NoError(t, db.AddLog(createHash(3), bl11, 0, nil))
+		},
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			logHash, err := db.LogHash(11, 0)
+			require.NoError(t, err)
+			require.Equal(t, createHash(1), logHash)
+			logHash, err = db.LogHash(11, 1)
+			require.NoError(t, err)
+			require.Equal(t, createHash(2), logHash)
+
+			// Block 11 only contained 2 logs
+			_, err = db.LogHash(11, 2)
+			require.ErrorIs(t, err, types.ErrConflict)
+
+			// The log of the unsealed block 12 is readable, later logs are not known yet
+			logHash, err = db.LogHash(12, 0)
+			require.NoError(t, err)
+			require.Equal(t, createHash(3), logHash)
+			_, err = db.LogHash(12, 1)
+			require.ErrorIs(t, err, types.ErrFuture)
+		})
+}
+
 func TestExecutes(t *testing.T) {
 	execMsg1 := types.ExecutingMessage{
 		ChainID:   eth.ChainIDFromUInt64(33),
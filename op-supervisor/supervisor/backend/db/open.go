@@ -6,22 +6,48 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/fromda"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logindex"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/superroots"
 )
 
-func OpenLogDB(logger log.Logger, chainID eth.ChainID, dataDir string, m logs.Metrics) (*logs.DB, error) {
+type LogDBMetrics interface {
+	logs.Metrics
+	entrydb.CompressionMetrics
+}
+
+// OpenLogDB opens the log DB of the chain. A new log DB is created with the compression,
+// an existing log DB keeps the compression it was created with.
+func OpenLogDB(logger log.Logger, chainID eth.ChainID, dataDir string, compression entrydb.Compression, m LogDBMetrics) (*logs.DB, error) {
 	path, err := prepLogDBPath(chainID, dataDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create datadir for chain %s: %w", chainID, err)
 	}
-	logDB, err := logs.NewFromFile(logger, m, chainID, path, true)
+	store, err := entrydb.OpenEntryStore[logs.EntryType, logs.Entry, logs.EntryBinary](logger, path, compression, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open logdb store for chain %s at %v: %w", chainID, path, err)
+	}
+	logDB, err := logs.NewFromEntryStore(logger, m, chainID, store, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create logdb for chain %s at %v: %w", chainID, path, err)
 	}
 	return logDB, nil
 }
 
+func OpenLogIndexDB(logger log.Logger, chainID eth.ChainID, dataDir string) (*logindex.DB, error) {
+	path, err := prepLogIndexDBPath(chainID, dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare datadir for chain %s: %w", chainID, err)
+	}
+	db, err := logindex.NewFromFile(logger, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create log index for chain %s at %q: %w", chainID, path, err)
+	}
+	return db, nil
+}
+
 func OpenLocalDerivationDB(logger log.Logger, chainID eth.ChainID, dataDir string, m fromda.ChainMetrics) (*fromda.DB, error) {
 	path, err := prepLocalDerivationDBPath(chainID, dataDir)
 	if err != nil {
@@ -45,3 +71,15 @@ func OpenCrossDerivationDB(logger log.Logger, chainID eth.ChainID, dataDir strin
 	}
 	return db, nil
 }
+
+func OpenSuperRootsDB(logger log.Logger, dataDir string) (*superroots.DB, error) {
+	path, err := prepSuperRootsDBPath(dataDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare datadir: %w", err)
+	}
+	db, err := superroots.NewFromFile(logger, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create super-roots DB at %q: %w", path, err)
+	}
+	return db, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
@@ -17,6 +19,15 @@ func (db *ChainsDB) FindSealedBlock(chain eth.ChainID, number uint64) (seal type
 	return logDB.FindSealedBlock(number)
 }
 
+// FirstSealedBlock returns the first block that has been recorded to the logs db for the given chain.
+func (db *ChainsDB) FirstSealedBlock(chain eth.ChainID) (seal types.BlockSeal, err error) {
+	logDB, ok := db.logDBs.Get(chain)
+	if !ok {
+		return types.BlockSeal{}, fmt.Errorf("%w: %v", types.ErrUnknownChain, chain)
+	}
+	return logDB.FirstSealedBlock()
+}
+
 func (db *ChainsDB) FindBlockID(chain eth.ChainID, number uint64) (id eth.BlockID, err error) {
 	sealed, err := db.FindSealedBlock(chain, number)
 	if err != nil {
@@ -300,6 +311,16 @@ func (db *ChainsDB) Contains(chain eth.ChainID, q types.ContainsQuery) (included
 	return logDB.Contains(q)
 }
 
+// LogHash returns the hash of the log at the given block number and log index on the given chain.
+// it routes the request to the appropriate logDB.
+func (db *ChainsDB) LogHash(chain eth.ChainID, blockNum uint64, logIdx uint32) (common.Hash, error) {
+	logDB, ok := db.logDBs.Get(chain)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("%w: %v", types.ErrUnknownChain, chain)
+	}
+	return logDB.LogHash(blockNum, logIdx)
+}
+
 // OpenBlock returns the Executing Messages for the block at the given number on the given chain.
 // it routes the request to the appropriate logDB.
 func (db *ChainsDB) OpenBlock(chainID eth.ChainID, blockNum uint64) (seal eth.BlockRef, logCount uint32, execMsgs map[uint32]*types.ExecutingMessage, err error) {
@@ -0,0 +1,298 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// BackupsDir is the directory of the data directory with the backups of the data before each migration.
+	BackupsDir = "backups"
+
+	stagingDir  = ".migration-staging"
+	journalFile = ".migration-journal.json"
+)
+
+// Result describes the migration of a data directory.
+type Result struct {
+	// From is the schema version of the data directory before the migration.
+	From Version
+	// To is the schema version of the data directory after the migration.
+	To Version
+	// Backup is the directory with the data of the data directory before the migration, empty if nothing was migrated.
+	Backup string
+	// DryRun is set if the migrations were applied to a copy of the data only.
+	DryRun bool
+}
+
+// Migrator migrates a data directory to the current schema version.
+//
+// The pending migrations are applied to a copy of the data and validated, before the data is replaced,
+// so a failed migration leaves the data directory untouched. The data before the migration is kept in
+// a backup of BackupsDir. A migration that is interrupted while the data is replaced is rolled back
+// when the data directory is migrated again.
+type Migrator struct {
+	log        log.Logger
+	dir        string
+	migrations []Migration
+}
+
+func NewMigrator(logger log.Logger, dir string, migrations []Migration) (*Migrator, error) {
+	if err := checkMigrations(migrations); err != nil {
+		return nil, err
+	}
+	return &Migrator{log: logger, dir: dir, migrations: migrations}, nil
+}
+
+// Migrate migrates the data directory to the current schema version.
+// A data directory without data is marked with the current version, one with data but no version
+// has the LegacyVersion. Data directories of a newer version are refused with ErrDowngrade,
+// as older versions of the supervisor cannot tell which of their data they can still read.
+// With dryRun, the migrations are applied and validated on a copy of the data, which is then discarded.
+func (m *Migrator) Migrate(ctx context.Context, dryRun bool) (*Result, error) {
+	if err := m.recover(); err != nil {
+		return nil, fmt.Errorf("failed to recover interrupted migration: %w", err)
+	}
+	target := CurrentVersion(m.migrations)
+	from, ok, err := ReadVersion(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		entries, err := m.dataEntries(m.dir)
+		if err != nil {
+			return nil, err
+		}
+		from = LegacyVersion
+		if len(entries) == 0 {
+			from = target
+		}
+	}
+	if from > target {
+		return nil, fmt.Errorf("%w: version %d, supported version %d. Run a newer version of the supervisor, or restore a backup of %s",
+			ErrDowngrade, from, target, BackupsDir)
+	}
+	result := &Result{From: from, To: target, DryRun: dryRun}
+	pending := m.migrations[from-LegacyVersion:]
+	if len(pending) == 0 {
+		// Nothing to apply, so the data is not copied. An unversioned data directory is marked with its version.
+		if !ok && !dryRun {
+			if err := WriteVersion(m.dir, target); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	staging := filepath.Join(m.dir, stagingDir)
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, fmt.Errorf("failed to remove previous staging directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(staging); err != nil {
+			m.log.Warn("Failed to remove migration staging directory", "dir", staging, "err", err)
+		}
+	}()
+	live, err := m.dataEntries(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Mkdir(staging, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	for _, name := range live {
+		if err := copyTree(filepath.Join(m.dir, name), filepath.Join(staging, name)); err != nil {
+			return nil, fmt.Errorf("failed to copy %s to staging directory: %w", name, err)
+		}
+	}
+	for _, migration := range pending {
+		m.log.Info("Applying schema migration", "version", migration.Version, "description", migration.Description, "dryRun", dryRun)
+		if err := migration.Apply(ctx, m.log, staging); err != nil {
+			return nil, fmt.Errorf("failed to apply migration to version %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}
+	for _, migration := range pending {
+		if migration.Validate == nil {
+			continue
+		}
+		if err := migration.Validate(ctx, m.log, staging); err != nil {
+			return nil, fmt.Errorf("failed to validate migration to version %d (%s): %w", migration.Version, migration.Description, err)
+		}
+	}
+	if dryRun {
+		m.log.Info("Validated schema migrations", "from", from, "to", target)
+		return result, nil
+	}
+
+	staged, err := m.dataEntries(staging)
+	if err != nil {
+		return nil, err
+	}
+	backup := filepath.Join(BackupsDir, fmt.Sprintf("schema-v%d-%d", from, time.Now().Unix()))
+	if err := m.swap(journal{From: from, To: target, Backup: backup, Live: live, Staged: staged}); err != nil {
+		return nil, err
+	}
+	result.Backup = filepath.Join(m.dir, backup)
+	m.log.Info("Migrated data directory", "from", from, "to", target, "backup", result.Backup)
+	return result, nil
+}
+
+// journal records the replacement of the data with the migrated data, to roll it back if it is interrupted.
+type journal struct {
+	From   Version  `json:"from"`
+	To     Version  `json:"to"`
+	Backup string   `json:"backup"`
+	Live   []string `json:"live"`
+	Staged []string `json:"staged"`
+}
+
+// swap moves the data to the backup, and the migrated data from the staging directory in its place.
+// The schema version is updated last, and marks the swap as complete.
+func (m *Migrator) swap(j journal) error {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(m.dir, journalFile), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write migration journal: %w", err)
+	}
+	backup := filepath.Join(m.dir, j.Backup)
+	if err := os.MkdirAll(backup, 0o755); err != nil {
+		return fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	for _, name := range j.Live {
+		if err := os.Rename(filepath.Join(m.dir, name), filepath.Join(backup, name)); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", name, err)
+		}
+	}
+	for _, name := range j.Staged {
+		if err := os.Rename(filepath.Join(m.dir, stagingDir, name), filepath.Join(m.dir, name)); err != nil {
+			return fmt.Errorf("failed to move migrated %s: %w", name, err)
+		}
+	}
+	if err := WriteVersion(m.dir, j.To); err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(m.dir, journalFile)); err != nil {
+		return fmt.Errorf("failed to remove migration journal: %w", err)
+	}
+	return nil
+}
+
+// recover rolls back a swap that was interrupted before the schema version was updated.
+func (m *Migrator) recover() error {
+	data, err := os.ReadFile(filepath.Join(m.dir, journalFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var j journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("failed to decode migration journal: %w", err)
+	}
+	version, ok, err := ReadVersion(m.dir)
+	if err != nil {
+		return err
+	}
+	if !ok || version != j.To {
+		m.log.Warn("Rolling back interrupted schema migration", "from", j.From, "to", j.To)
+		backup := filepath.Join(m.dir, j.Backup)
+		for _, name := range j.Staged {
+			if !slices.Contains(j.Live, name) {
+				if err := os.RemoveAll(filepath.Join(m.dir, name)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, name := range j.Live {
+			if _, err := os.Stat(filepath.Join(backup, name)); errors.Is(err, os.ErrNotExist) {
+				continue // not moved to the backup yet
+			}
+			if err := os.RemoveAll(filepath.Join(m.dir, name)); err != nil {
+				return err
+			}
+			if err := os.Rename(filepath.Join(backup, name), filepath.Join(m.dir, name)); err != nil {
+				return err
+			}
+		}
+		if err := os.RemoveAll(backup); err != nil {
+			return err
+		}
+	}
+	if err := os.RemoveAll(filepath.Join(m.dir, stagingDir)); err != nil {
+		return err
+	}
+	return os.Remove(filepath.Join(m.dir, journalFile))
+}
+
+// dataEntries returns the names of the entries of the directory with data,
+// all but the schema version and the files of the migrations.
+func (m *Migrator) dataEntries(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory: %w", err)
+	}
+	var out []string
+	for _, e := range entries {
+		switch e.Name() {
+		case VersionFile, VersionFile + ".tmp", BackupsDir, stagingDir, journalFile:
+			continue
+		}
+		out = append(out, e.Name())
+	}
+	return out, nil
+}
+
+// copyTree copies the file or directory src to dst.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm())
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
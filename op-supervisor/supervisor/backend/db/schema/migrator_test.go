@@ -0,0 +1,205 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestMigrator(t *testing.T) {
+	// appendMigration appends a line to the log.db file of every chain directory
+	appendMigration := func(version Version, line string) Migration {
+		return Migration{
+			Version:     version,
+			Description: "append " + line,
+			Apply: func(ctx context.Context, logger log.Logger, dir string) error {
+				paths, err := filepath.Glob(filepath.Join(dir, "*", "log.db"))
+				if err != nil {
+					return err
+				}
+				for _, path := range paths {
+					data, err := os.ReadFile(path)
+					if err != nil {
+						return err
+					}
+					if err := os.WriteFile(path, append(data, line...), 0o644); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}
+	}
+	migrations := []Migration{appendMigration(2, "b"), appendMigration(3, "c")}
+
+	setup := func(t *testing.T, migrations []Migration) (*Migrator, string) {
+		dir := t.TempDir()
+		m, err := NewMigrator(testlog.Logger(t, log.LevelInfo), dir, migrations)
+		require.NoError(t, err)
+		return m, dir
+	}
+	writeLegacyData := func(t *testing.T, dir string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "900"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "900", "log.db"), []byte("a"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "super_roots.db"), []byte("roots"), 0o644))
+	}
+	requireLog := func(t *testing.T, dir string, expected string) {
+		data, err := os.ReadFile(filepath.Join(dir, "900", "log.db"))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	requireVersion := func(t *testing.T, dir string, expected Version) {
+		v, ok, err := ReadVersion(dir)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, expected, v)
+	}
+	requireNoMigrationFiles := func(t *testing.T, dir string) {
+		require.NoDirExists(t, filepath.Join(dir, stagingDir))
+		require.NoFileExists(t, filepath.Join(dir, journalFile))
+	}
+
+	t.Run("new data directory", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		result, err := m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, &Result{From: 3, To: 3}, result)
+		requireVersion(t, dir, 3)
+	})
+
+	t.Run("legacy data directory", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		writeLegacyData(t, dir)
+		result, err := m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, Version(1), result.From)
+		require.Equal(t, Version(3), result.To)
+		requireVersion(t, dir, 3)
+		requireLog(t, dir, "abc")
+		requireNoMigrationFiles(t, dir)
+
+		// the data before the migration is backed up
+		requireLog(t, result.Backup, "a")
+		require.FileExists(t, filepath.Join(result.Backup, "super_roots.db"))
+
+		// migrating again is a noop
+		result, err = m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, &Result{From: 3, To: 3}, result)
+		requireLog(t, dir, "abc")
+	})
+
+	t.Run("nothing to migrate", func(t *testing.T) {
+		m, dir := setup(t, nil)
+		writeLegacyData(t, dir)
+		result, err := m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, &Result{From: 1, To: 1}, result)
+		requireVersion(t, dir, 1)
+		requireLog(t, dir, "a")
+		requireNoMigrationFiles(t, dir)
+		require.NoDirExists(t, filepath.Join(dir, BackupsDir), "data is not copied")
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		writeLegacyData(t, dir)
+		require.NoError(t, WriteVersion(dir, 2))
+		result, err := m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		require.Equal(t, Version(2), result.From)
+		requireLog(t, dir, "ac")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		writeLegacyData(t, dir)
+		result, err := m.Migrate(context.Background(), true)
+		require.NoError(t, err)
+		require.Equal(t, &Result{From: 1, To: 3, DryRun: true}, result)
+		requireLog(t, dir, "a")
+		_, ok, err := ReadVersion(dir)
+		require.NoError(t, err)
+		require.False(t, ok)
+		requireNoMigrationFiles(t, dir)
+		require.NoDirExists(t, filepath.Join(dir, BackupsDir))
+	})
+
+	t.Run("failed migration", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		failing := Migration{Version: 4, Apply: func(ctx context.Context, logger log.Logger, dir string) error {
+			return errFailed
+		}}
+		m, dir := setup(t, append(migrations[:2:2], failing))
+		writeLegacyData(t, dir)
+		_, err := m.Migrate(context.Background(), false)
+		require.ErrorIs(t, err, errFailed)
+		requireLog(t, dir, "a")
+		requireNoMigrationFiles(t, dir)
+	})
+
+	t.Run("failed validation", func(t *testing.T) {
+		validated := migrations[1]
+		validated.Validate = func(ctx context.Context, logger log.Logger, dir string) error {
+			data, err := os.ReadFile(filepath.Join(dir, "900", "log.db"))
+			require.NoError(t, err)
+			require.Equal(t, "abc", string(data), "validates the migrated data")
+			return errors.New("invalid")
+		}
+		m, dir := setup(t, []Migration{migrations[0], validated})
+		writeLegacyData(t, dir)
+		_, err := m.Migrate(context.Background(), false)
+		require.ErrorContains(t, err, "failed to validate migration to version 3")
+		requireLog(t, dir, "a")
+		requireNoMigrationFiles(t, dir)
+	})
+
+	t.Run("downgrade", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		writeLegacyData(t, dir)
+		require.NoError(t, WriteVersion(dir, 4))
+		_, err := m.Migrate(context.Background(), false)
+		require.ErrorIs(t, err, ErrDowngrade)
+		requireLog(t, dir, "a")
+	})
+
+	t.Run("interrupted swap", func(t *testing.T) {
+		m, dir := setup(t, migrations)
+		writeLegacyData(t, dir)
+		// the chain directory was swapped, the super-roots DB was not
+		backup := filepath.Join(BackupsDir, "schema-v1-0")
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, backup), 0o755))
+		require.NoError(t, os.Rename(filepath.Join(dir, "900"), filepath.Join(dir, backup, "900")))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "900"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "900", "log.db"), []byte("abc"), 0o644))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, stagingDir), 0o755))
+		data, err := json.Marshal(journal{From: 1, To: 3, Backup: backup, Live: []string{"900", "super_roots.db"}, Staged: []string{"900", "super_roots.db"}})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, journalFile), data, 0o644))
+
+		_, err = m.Migrate(context.Background(), true)
+		require.NoError(t, err)
+		requireLog(t, dir, "a")
+		require.NoDirExists(t, filepath.Join(dir, backup))
+		requireNoMigrationFiles(t, dir)
+
+		_, err = m.Migrate(context.Background(), false)
+		require.NoError(t, err)
+		requireLog(t, dir, "abc")
+	})
+
+	t.Run("invalid migrations", func(t *testing.T) {
+		_, err := NewMigrator(testlog.Logger(t, log.LevelInfo), t.TempDir(), []Migration{migrations[1]})
+		require.ErrorIs(t, err, ErrInvalidMigrations)
+		_, err = NewMigrator(testlog.Logger(t, log.LevelInfo), t.TempDir(), []Migration{{Version: 2}})
+		require.ErrorIs(t, err, ErrInvalidMigrations)
+	})
+}
@@ -0,0 +1,107 @@
+// Package schema versions the on-disk format of the databases of the supervisor data directory,
+// and migrates data directories of older versions on startup, instead of requiring a wipe and backfill.
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Version is the version of the schema of all the databases of a data directory.
+type Version uint64
+
+const (
+	// LegacyVersion is the version of data directories that predate schema versioning.
+	LegacyVersion Version = 1
+
+	// VersionFile is the file of the data directory that records its schema version.
+	VersionFile = "schema_version.json"
+)
+
+var (
+	ErrDowngrade         = errors.New("data directory has a newer schema version than supported")
+	ErrInvalidMigrations = errors.New("invalid migrations")
+)
+
+// Migration migrates the databases of a data directory from the previous schema version to Version.
+type Migration struct {
+	Version     Version
+	Description string
+	// Apply migrates the data directory dir in place. It runs on a copy of the data directory,
+	// so it may leave the directory in any state on error.
+	Apply func(ctx context.Context, logger log.Logger, dir string) error
+	// Validate checks the migrated data directory dir, after all migrations are applied. Optional.
+	Validate func(ctx context.Context, logger log.Logger, dir string) error
+}
+
+// Migrations are the migrations of the schema of the data directory, in order of version,
+// starting at the version after LegacyVersion. A change of the format of any database must add a migration.
+var Migrations []Migration
+
+// CurrentVersion returns the schema version that the supervisor reads and writes,
+// which is the version of the last of the migrations.
+func CurrentVersion(migrations []Migration) Version {
+	if len(migrations) == 0 {
+		return LegacyVersion
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+func checkMigrations(migrations []Migration) error {
+	prev := LegacyVersion
+	for i, m := range migrations {
+		if m.Version != prev+1 {
+			return fmt.Errorf("%w: migration %d is to version %d, expected version %d", ErrInvalidMigrations, i, m.Version, prev+1)
+		}
+		if m.Apply == nil {
+			return fmt.Errorf("%w: migration to version %d cannot be applied", ErrInvalidMigrations, m.Version)
+		}
+		prev = m.Version
+	}
+	return nil
+}
+
+type versionFile struct {
+	Version Version `json:"version"`
+}
+
+// ReadVersion returns the schema version of the data directory.
+// It returns false if the data directory has no version, as it is new or predates schema versioning.
+func ReadVersion(dir string) (Version, bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, VersionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	var v versionFile
+	if err := json.Unmarshal(data, &v); err != nil {
+		return 0, false, fmt.Errorf("failed to decode schema version: %w", err)
+	}
+	if v.Version < LegacyVersion {
+		return 0, false, fmt.Errorf("invalid schema version %d", v.Version)
+	}
+	return v.Version, true, nil
+}
+
+// WriteVersion records the schema version of the data directory, atomically.
+func WriteVersion(dir string, version Version) error {
+	data, err := json.Marshal(versionFile{Version: version})
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, VersionFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write schema version: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, VersionFile)); err != nil {
+		return fmt.Errorf("failed to replace schema version: %w", err)
+	}
+	return nil
+}
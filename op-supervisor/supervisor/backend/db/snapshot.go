@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+const (
+	// maxConsistentReadAttempts bounds the number of times a read is retried when writes interleave with it.
+	maxConsistentReadAttempts = 100
+	// consistentReadRetryDelay is the delay before a read is retried, to let the interleaving writes finish.
+	consistentReadRetryDelay = time.Millisecond
+)
+
+// writeVersion tracks the writes to the databases of all chains, so a read across the chains can detect
+// that a write interleaved with it, without blocking the writers: the read is retried instead.
+type writeVersion struct {
+	// inflight is the number of writes in progress. Writes may be nested.
+	inflight atomic.Int64
+	// version is incremented at the end of every write, before the write is no longer in progress.
+	version atomic.Uint64
+}
+
+// stable returns the version of the databases, and whether no write is in progress.
+func (w *writeVersion) stable() (uint64, bool) {
+	if w.inflight.Load() != 0 {
+		return 0, false
+	}
+	return w.version.Load(), true
+}
+
+// beginWrite marks the start of a write to the databases, and returns the function that marks the end of it:
+//
+//	defer db.beginWrite()()
+func (db *ChainsDB) beginWrite() func() {
+	db.writes.inflight.Add(1)
+	return func() {
+		db.writes.version.Add(1)
+		db.writes.inflight.Add(-1)
+	}
+}
+
+// ConsistentRead runs the read until no write to the databases of any chain interleaved with it,
+// so the read observes a consistent view across all chains, as of a single point in time.
+// The read may run multiple times, and must not have side effects other than capturing its results.
+// An error of the read is returned as-is, unless a write interleaved with it, in which case the read is retried.
+// This returns ErrInconsistentRead if writes kept interleaving with the read.
+func (db *ChainsDB) ConsistentRead(ctx context.Context, read func() error) error {
+	for i := 0; i < maxConsistentReadAttempts; i++ {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(consistentReadRetryDelay):
+			}
+		}
+		before, ok := db.writes.stable()
+		if !ok {
+			continue
+		}
+		err := read()
+		if after, ok := db.writes.stable(); ok && after == before {
+			return err
+		}
+	}
+	return types.ErrInconsistentRead
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestConsistentRead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("without writes", func(t *testing.T) {
+		db := &ChainsDB{}
+		reads := 0
+		require.NoError(t, db.ConsistentRead(ctx, func() error {
+			reads++
+			return nil
+		}))
+		require.Equal(t, 1, reads)
+	})
+
+	t.Run("retried after interleaving write", func(t *testing.T) {
+		db := &ChainsDB{}
+		reads := 0
+		require.NoError(t, db.ConsistentRead(ctx, func() error {
+			reads++
+			if reads == 1 {
+				db.beginWrite()()
+			}
+			return nil
+		}))
+		require.Equal(t, 2, reads)
+	})
+
+	t.Run("waits for write in progress", func(t *testing.T) {
+		db := &ChainsDB{}
+		end := db.beginWrite()
+		go func() {
+			time.Sleep(10 * consistentReadRetryDelay)
+			end()
+		}()
+		reads := 0
+		require.NoError(t, db.ConsistentRead(ctx, func() error {
+			reads++
+			return nil
+		}))
+		require.Equal(t, 1, reads, "not read while the write is in progress")
+	})
+
+	t.Run("nested writes", func(t *testing.T) {
+		db := &ChainsDB{}
+		endOuter := db.beginWrite()
+		db.beginWrite()()
+		_, ok := db.writes.stable()
+		require.False(t, ok, "outer write still in progress")
+		endOuter()
+		_, ok = db.writes.stable()
+		require.True(t, ok)
+	})
+
+	t.Run("error of consistent read", func(t *testing.T) {
+		db := &ChainsDB{}
+		errRead := errors.New("read failed")
+		require.ErrorIs(t, db.ConsistentRead(ctx, func() error {
+			return errRead
+		}), errRead)
+	})
+
+	t.Run("error of inconsistent read is retried", func(t *testing.T) {
+		db := &ChainsDB{}
+		reads := 0
+		require.NoError(t, db.ConsistentRead(ctx, func() error {
+			reads++
+			if reads == 1 {
+				db.beginWrite()()
+				return types.ErrFuture
+			}
+			return nil
+		}))
+		require.Equal(t, 2, reads)
+	})
+
+	t.Run("writes keep interleaving", func(t *testing.T) {
+		db := &ChainsDB{}
+		require.ErrorIs(t, db.ConsistentRead(ctx, func() error {
+			db.beginWrite()()
+			return nil
+		}), types.ErrInconsistentRead)
+	})
+}
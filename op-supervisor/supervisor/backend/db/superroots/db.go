@@ -0,0 +1,180 @@
+package superroots
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type EntryStore interface {
+	Size() int64
+	LastEntryIdx() entrydb.EntryIdx
+	Read(idx entrydb.EntryIdx) (Entry, error)
+	Append(entries ...Entry) error
+	Truncate(idx entrydb.EntryIdx) error
+	Close() error
+}
+
+// DB is an append-only database of super roots, one fixed-size entry per recorded timestamp.
+// Timestamps are strictly increasing, so the DB can be binary searched by timestamp.
+// When the cross-safe view of the chains reorgs, the DB is rewound to before the reorg.
+type DB struct {
+	log    log.Logger
+	store  EntryStore
+	rwLock sync.RWMutex
+}
+
+func NewFromFile(logger log.Logger, path string) (*DB, error) {
+	store, err := entrydb.NewEntryDB[EntryType, Entry, EntryBinary](logger, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open DB: %w", err)
+	}
+	return NewFromEntryStore(logger, store), nil
+}
+
+func NewFromEntryStore(logger log.Logger, store EntryStore) *DB {
+	return &DB{
+		log:   logger,
+		store: store,
+	}
+}
+
+// Latest returns the last recorded super root.
+// This returns types.ErrFuture if no super root has been recorded yet.
+func (db *DB) Latest() (types.SuperRootRecord, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	return db.latest()
+}
+
+// latest is like Latest, but without lock, for internal use.
+func (db *DB) latest() (types.SuperRootRecord, error) {
+	lastIndex := db.store.LastEntryIdx()
+	if lastIndex < 0 {
+		return types.SuperRootRecord{}, types.ErrFuture
+	}
+	return db.readAt(lastIndex)
+}
+
+// AtTimestamp returns the super root recorded at exactly the given timestamp.
+// This returns types.ErrFuture if the timestamp is past the latest record,
+// and types.ErrSkipped if no super root was recorded at the given timestamp.
+func (db *DB) AtTimestamp(timestamp uint64) (types.SuperRootRecord, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	idx, err := db.search(timestamp)
+	if err != nil {
+		return types.SuperRootRecord{}, err
+	}
+	r, err := db.readAt(idx)
+	if err != nil {
+		return types.SuperRootRecord{}, err
+	}
+	if r.Timestamp != timestamp {
+		return types.SuperRootRecord{}, fmt.Errorf("no super root recorded at timestamp %d: %w", timestamp, types.ErrSkipped)
+	}
+	return r, nil
+}
+
+// Add appends a new super root record.
+// The record must have a timestamp past the latest record, or types.ErrOutOfOrder is returned.
+func (db *DB) Add(r types.SuperRootRecord) error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	last, err := db.latest()
+	if err == nil {
+		if r.Timestamp <= last.Timestamp {
+			return fmt.Errorf("cannot add %s after %s: %w", r, last, types.ErrOutOfOrder)
+		}
+	} else if !errors.Is(err, types.ErrFuture) {
+		return fmt.Errorf("failed to read latest super root: %w", err)
+	}
+	if err := db.store.Append(encodeRecord(r)); err != nil {
+		return fmt.Errorf("failed to append super root: %w", err)
+	}
+	return nil
+}
+
+// RewindTo removes all records with a timestamp past the given timestamp.
+func (db *DB) RewindTo(timestamp uint64) error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	i, err := db.firstAfter(timestamp)
+	if err != nil {
+		return err
+	}
+	if int64(i) == db.store.Size() {
+		return nil // nothing to rewind
+	}
+	if err := db.store.Truncate(i - 1); err != nil {
+		return fmt.Errorf("failed to rewind to timestamp %d: %w", timestamp, err)
+	}
+	return nil
+}
+
+// Clear removes all records.
+func (db *DB) Clear() error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	if err := db.store.Truncate(-1); err != nil {
+		return fmt.Errorf("failed to clear super roots: %w", err)
+	}
+	return nil
+}
+
+// search finds the index of the last entry with a timestamp at or before the given timestamp.
+func (db *DB) search(timestamp uint64) (entrydb.EntryIdx, error) {
+	last, err := db.latest()
+	if err != nil {
+		return 0, err
+	}
+	if last.Timestamp < timestamp {
+		return 0, fmt.Errorf("timestamp %d is past latest super root %d: %w", timestamp, last.Timestamp, types.ErrFuture)
+	}
+	i, err := db.firstAfter(timestamp)
+	if err != nil {
+		return 0, err
+	}
+	if i == 0 {
+		return 0, fmt.Errorf("timestamp %d is before first super root: %w", timestamp, types.ErrSkipped)
+	}
+	return i - 1, nil
+}
+
+// firstAfter finds the index of the first entry with a timestamp past the given timestamp.
+// If there is no such entry, the entry count is returned.
+func (db *DB) firstAfter(timestamp uint64) (entrydb.EntryIdx, error) {
+	var searchErr error
+	i := sort.Search(int(db.store.Size()), func(i int) bool {
+		r, err := db.readAt(entrydb.EntryIdx(i))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+		return r.Timestamp > timestamp
+	})
+	if searchErr != nil {
+		return 0, fmt.Errorf("failed to search for timestamp %d: %w", timestamp, searchErr)
+	}
+	return entrydb.EntryIdx(i), nil
+}
+
+func (db *DB) readAt(i entrydb.EntryIdx) (types.SuperRootRecord, error) {
+	entry, err := db.store.Read(i)
+	if err != nil {
+		return types.SuperRootRecord{}, err
+	}
+	return decodeRecord(entry)
+}
+
+func (db *DB) Close() error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	return db.store.Close()
+}
@@ -0,0 +1,134 @@
+package superroots
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func mockRecord(timestamp uint64) types.SuperRootRecord {
+	return types.SuperRootRecord{
+		Timestamp: timestamp,
+		SuperRoot: eth.Bytes32(common.Hash{0xaa, byte(timestamp)}),
+		CrossSafeDerivedFrom: eth.BlockID{
+			Hash:   common.Hash{0xbb, byte(timestamp)},
+			Number: timestamp / 12,
+		},
+	}
+}
+
+func createDB(t *testing.T, path string) *DB {
+	logger := testlog.Logger(t, log.LvlTrace)
+	db, err := NewFromFile(logger, path)
+	require.NoError(t, err)
+	return db
+}
+
+func TestEncodeDecode(t *testing.T) {
+	r := mockRecord(1234)
+	got, err := decodeRecord(encodeRecord(r))
+	require.NoError(t, err)
+	require.Equal(t, r, got)
+
+	corrupt := encodeRecord(r)
+	corrupt[3] = 1
+	_, err = decodeRecord(corrupt)
+	require.ErrorIs(t, err, types.ErrDataCorruption)
+
+	corrupt = encodeRecord(r)
+	corrupt[0] = 42
+	_, err = decodeRecord(corrupt)
+	require.ErrorIs(t, err, types.ErrDataCorruption)
+}
+
+func TestEmptyDB(t *testing.T) {
+	db := createDB(t, filepath.Join(t.TempDir(), "test.db"))
+	_, err := db.Latest()
+	require.ErrorIs(t, err, types.ErrFuture)
+	_, err = db.AtTimestamp(100)
+	require.ErrorIs(t, err, types.ErrFuture)
+	require.NoError(t, db.RewindTo(0))
+	require.NoError(t, db.Close())
+}
+
+func TestAddAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.db")
+	db := createDB(t, path)
+	for _, ts := range []uint64{100, 102, 106} {
+		require.NoError(t, db.Add(mockRecord(ts)))
+	}
+	require.ErrorIs(t, db.Add(mockRecord(106)), types.ErrOutOfOrder)
+	require.ErrorIs(t, db.Add(mockRecord(104)), types.ErrOutOfOrder)
+
+	check := func(db *DB) {
+		latest, err := db.Latest()
+		require.NoError(t, err)
+		require.Equal(t, mockRecord(106), latest)
+
+		for _, ts := range []uint64{100, 102, 106} {
+			r, err := db.AtTimestamp(ts)
+			require.NoError(t, err)
+			require.Equal(t, mockRecord(ts), r)
+		}
+		_, err = db.AtTimestamp(99)
+		require.ErrorIs(t, err, types.ErrSkipped)
+		_, err = db.AtTimestamp(104)
+		require.ErrorIs(t, err, types.ErrSkipped)
+		_, err = db.AtTimestamp(107)
+		require.ErrorIs(t, err, types.ErrFuture)
+	}
+	check(db)
+
+	// records persist across a restart
+	require.NoError(t, db.Close())
+	db = createDB(t, path)
+	check(db)
+	require.NoError(t, db.Close())
+}
+
+func TestRewindTo(t *testing.T) {
+	db := createDB(t, filepath.Join(t.TempDir(), "test.db"))
+	for _, ts := range []uint64{100, 102, 104, 106} {
+		require.NoError(t, db.Add(mockRecord(ts)))
+	}
+
+	// rewinding to a future timestamp is a no-op
+	require.NoError(t, db.RewindTo(200))
+	latest, err := db.Latest()
+	require.NoError(t, err)
+	require.Equal(t, uint64(106), latest.Timestamp)
+
+	// rewinding in between records keeps the record before it
+	require.NoError(t, db.RewindTo(103))
+	latest, err = db.Latest()
+	require.NoError(t, err)
+	require.Equal(t, uint64(102), latest.Timestamp)
+	_, err = db.AtTimestamp(104)
+	require.ErrorIs(t, err, types.ErrFuture)
+
+	// new records can be added after a rewind
+	require.NoError(t, db.Add(mockRecord(104)))
+	latest, err = db.Latest()
+	require.NoError(t, err)
+	require.Equal(t, mockRecord(104), latest)
+
+	// rewinding before the first record empties the DB
+	require.NoError(t, db.RewindTo(50))
+	_, err = db.Latest()
+	require.ErrorIs(t, err, types.ErrFuture)
+
+	// clearing removes all records, including the one at timestamp 0
+	require.NoError(t, db.Add(mockRecord(0)))
+	require.NoError(t, db.Clear())
+	_, err = db.Latest()
+	require.ErrorIs(t, err, types.ErrFuture)
+	require.NoError(t, db.Close())
+}
@@ -0,0 +1,84 @@
+package superroots
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// EntrySize is the size of the DB entry.
+// 1+7+8+8+32+32+8=96
+const EntrySize = 96
+
+type Entry [EntrySize]byte
+
+func (e Entry) Type() EntryType {
+	return EntryType(e[0])
+}
+
+type EntryType uint8
+
+const (
+	SuperRootV0 EntryType = 0
+)
+
+func (s EntryType) String() string {
+	switch s {
+	case SuperRootV0:
+		return "superRootV0"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+type EntryBinary struct{}
+
+func (EntryBinary) Append(dest []byte, e *Entry) []byte {
+	return append(dest, e[:]...)
+}
+
+func (EntryBinary) ReadAt(dest *Entry, r io.ReaderAt, at int64) (n int, err error) {
+	return r.ReadAt(dest[:], at)
+}
+
+func (EntryBinary) EntrySize() int {
+	return EntrySize
+}
+
+func decodeRecord(e Entry) (types.SuperRootRecord, error) {
+	if t := e.Type(); t != SuperRootV0 {
+		return types.SuperRootRecord{}, fmt.Errorf("%w: unexpected entry type: %s", types.ErrDataCorruption, t)
+	}
+	if [7]byte(e[1:8]) != ([7]byte{}) {
+		return types.SuperRootRecord{}, fmt.Errorf("%w: expected empty data, to pad entry size to round number: %x", types.ErrDataCorruption, e[1:8])
+	}
+	// Format:
+	// type(1) padding(7) timestamp(8) l1-number(8) super-root(32) l1-hash(32) padding(8)
+	// Note: the timestamp comes first, so entries sort lexically in chronological order.
+	var r types.SuperRootRecord
+	offset := 8
+	r.Timestamp = binary.BigEndian.Uint64(e[offset : offset+8])
+	offset += 8
+	r.CrossSafeDerivedFrom.Number = binary.BigEndian.Uint64(e[offset : offset+8])
+	offset += 8
+	copy(r.SuperRoot[:], e[offset:offset+32])
+	offset += 32
+	copy(r.CrossSafeDerivedFrom.Hash[:], e[offset:offset+32])
+	return r, nil
+}
+
+func encodeRecord(r types.SuperRootRecord) Entry {
+	var out Entry
+	out[0] = uint8(SuperRootV0)
+	offset := 8
+	binary.BigEndian.PutUint64(out[offset:offset+8], r.Timestamp)
+	offset += 8
+	binary.BigEndian.PutUint64(out[offset:offset+8], r.CrossSafeDerivedFrom.Number)
+	offset += 8
+	copy(out[offset:offset+32], r.SuperRoot[:])
+	offset += 32
+	copy(out[offset:offset+32], r.CrossSafeDerivedFrom.Hash[:])
+	return out
+}
@@ -18,6 +18,7 @@ func (db *ChainsDB) AddLog(
 	logIdx uint32,
 	execMsg *types.ExecutingMessage,
 ) error {
+	defer db.beginWrite()()
 	logDB, ok := db.logDBs.Get(chain)
 	if !ok {
 		return fmt.Errorf("cannot AddLog: %w: %v", types.ErrUnknownChain, chain)
@@ -32,6 +33,7 @@ func (db *ChainsDB) SealBlock(chain eth.ChainID, block eth.BlockRef) error {
 }
 
 func (db *ChainsDB) sealBlock(chain eth.ChainID, block eth.BlockRef, mayInit bool) error {
+	defer db.beginWrite()()
 	logDB, ok := db.logDBs.Get(chain)
 	if !ok {
 		return fmt.Errorf("cannot SealBlock: %w: %v", types.ErrUnknownChain, chain)
@@ -52,6 +54,7 @@ func (db *ChainsDB) sealBlock(chain eth.ChainID, block eth.BlockRef, mayInit boo
 }
 
 func (db *ChainsDB) Rewind(chain eth.ChainID, headBlock eth.BlockID) error {
+	defer db.beginWrite()()
 	// Rewind the logDB
 	logDB, ok := db.logDBs.Get(chain)
 	if !ok {
@@ -97,6 +100,7 @@ func (db *ChainsDB) UpdateLocalSafe(chain eth.ChainID, source eth.BlockRef, last
 }
 
 func (db *ChainsDB) initializedUpdateLocalSafe(chain eth.ChainID, source eth.BlockRef, lastDerived eth.BlockRef, nodeId string) {
+	defer db.beginWrite()()
 	logger := db.logger.New("chain", chain, "source", source, "lastDerived", lastDerived, "nodeId", nodeId)
 	localDB, ok := db.localDBs.Get(chain)
 	if !ok {
@@ -132,6 +136,7 @@ func (db *ChainsDB) initializedUpdateLocalSafe(chain eth.ChainID, source eth.Blo
 }
 
 func (db *ChainsDB) UpdateCrossUnsafe(chain eth.ChainID, crossUnsafe types.BlockSeal) error {
+	defer db.beginWrite()()
 	v, ok := db.crossUnsafe.Get(chain)
 	if !ok {
 		return fmt.Errorf("cannot UpdateCrossUnsafe: %w: %s", types.ErrUnknownChain, chain)
@@ -161,6 +166,7 @@ func (db *ChainsDB) UpdateCrossSafe(chain eth.ChainID, l1View eth.BlockRef, last
 }
 
 func (db *ChainsDB) initializedUpdateCrossSafe(chain eth.ChainID, l1View eth.BlockRef, lastCrossDerived eth.BlockRef) error {
+	defer db.beginWrite()()
 	crossDB, ok := db.crossDBs.Get(chain)
 	if !ok {
 		return fmt.Errorf("cannot UpdateCrossSafe, no cross-safe DB: %w: %s", types.ErrUnknownChain, chain)
@@ -209,6 +215,7 @@ func (db *ChainsDB) initializedUpdateCrossSafe(chain eth.ChainID, l1View eth.Blo
 }
 
 func (db *ChainsDB) onFinalizedL1(finalized eth.BlockRef) {
+	defer db.beginWrite()()
 	// Lock, so we avoid race-conditions in-between getting (for comparison) and setting.
 	// Unlock is managed explicitly, in this function so we can call NotifyL2Finalized after releasing the lock.
 	db.finalizedL1.Lock()
@@ -242,6 +249,7 @@ func (db *ChainsDB) onFinalizedL1(finalized eth.BlockRef) {
 }
 
 func (db *ChainsDB) InvalidateLocalSafe(chainID eth.ChainID, candidate types.DerivedBlockRefPair) error {
+	defer db.beginWrite()()
 	// Get databases to invalidate data in.
 	eventsDB, ok := db.logDBs.Get(chainID)
 	if !ok {
@@ -284,6 +292,7 @@ func (db *ChainsDB) InvalidateLocalSafe(chainID eth.ChainID, candidate types.Der
 // This returns ErrFuture if the block is newer than the last known block.
 // This returns ErrConflict if a different block at the given height is known.
 func (db *ChainsDB) RewindLocalSafe(chainID eth.ChainID, scope eth.BlockID) error {
+	defer db.beginWrite()()
 	localSafeDB, ok := db.localDBs.Get(chainID)
 	if !ok {
 		return fmt.Errorf("cannot find local-safe DB of chain %s for invalidation: %w", chainID, types.ErrUnknownChain)
@@ -299,6 +308,7 @@ func (db *ChainsDB) RewindLocalSafe(chainID eth.ChainID, scope eth.BlockID) erro
 // This returns ErrFuture if the block is newer than the last known block.
 // This returns ErrConflict if a different block at the given height is known.
 func (db *ChainsDB) RewindCrossSafe(chainID eth.ChainID, scope eth.BlockID) error {
+	defer db.beginWrite()()
 	crossSafeDB, ok := db.crossDBs.Get(chainID)
 	if !ok {
 		return fmt.Errorf("cannot find cross-safe DB of chain %s for invalidation: %w", chainID, types.ErrUnknownChain)
@@ -311,6 +321,7 @@ func (db *ChainsDB) RewindCrossSafe(chainID eth.ChainID, scope eth.BlockID) erro
 }
 
 func (db *ChainsDB) RewindLogs(chainID eth.ChainID, newHead types.BlockSeal) error {
+	defer db.beginWrite()()
 	eventsDB, ok := db.logDBs.Get(chainID)
 	if !ok {
 		return fmt.Errorf("cannot find events DB of chain %s for invalidation: %w", chainID, types.ErrUnknownChain)
@@ -323,6 +334,7 @@ func (db *ChainsDB) RewindLogs(chainID eth.ChainID, newHead types.BlockSeal) err
 }
 
 func (db *ChainsDB) ResetCrossUnsafeIfNewerThan(chainID eth.ChainID, number uint64) error {
+	defer db.beginWrite()()
 	crossUnsafe, ok := db.crossUnsafe.Get(chainID)
 	if !ok {
 		return nil
@@ -350,6 +362,7 @@ func (db *ChainsDB) ResetCrossUnsafeIfNewerThan(chainID eth.ChainID, number uint
 }
 
 func (db *ChainsDB) onReplaceBlock(chainID eth.ChainID, replacement eth.BlockRef, invalidated common.Hash) {
+	defer db.beginWrite()()
 	localSafeDB, ok := db.localDBs.Get(chainID)
 	if !ok {
 		db.logger.Error("Cannot find DB for replacement block", "chain", chainID)
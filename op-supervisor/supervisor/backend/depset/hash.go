@@ -0,0 +1,31 @@
+package depset
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// hashVersion is the version of the encoding of the dependency set that is hashed by Hash.
+const hashVersion = 0
+
+// Hash returns a canonical hash of the dependency set, to detect that the supervisor and the nodes
+// it manages were configured with different dependency sets. The hash commits to the sorted chain IDs
+// and the message expiry window, and does not depend on how the dependency set was loaded.
+func Hash(ds DependencySet) common.Hash {
+	chainIDs := ds.Chains()
+	slices.SortFunc(chainIDs, func(a, b eth.ChainID) int { return a.Cmp(b) })
+	data := make([]byte, 0, 1+8+8+32*len(chainIDs))
+	data = append(data, hashVersion)
+	data = binary.BigEndian.AppendUint64(data, ds.MessageExpiryWindow())
+	data = binary.BigEndian.AppendUint64(data, uint64(len(chainIDs)))
+	for _, id := range chainIDs {
+		b := id.Bytes32()
+		data = append(data, b[:]...)
+	}
+	return crypto.Keccak256Hash(data)
+}
@@ -0,0 +1,39 @@
+package depset
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestHash(t *testing.T) {
+	newSet := func(t *testing.T, expiryWindow uint64, chainIDs ...uint64) *StaticConfigDependencySet {
+		deps := make(map[eth.ChainID]*StaticConfigDependency)
+		for _, id := range chainIDs {
+			deps[eth.ChainIDFromUInt64(id)] = &StaticConfigDependency{}
+		}
+		ds, err := NewStaticConfigDependencySetWithMessageExpiryOverride(deps, expiryWindow)
+		require.NoError(t, err)
+		return ds
+	}
+
+	hash := Hash(newSet(t, 0, 900, 901))
+	// The hash is part of the protocol between the supervisor and the nodes, it must not change by accident.
+	require.Equal(t, common.HexToHash("0xc5fe76aa0cbf9645f086493e4b00854e4a23027dc1ac7f5b8b68423fb37770f3"), hash)
+	require.Equal(t, hash, Hash(newSet(t, 0, 901, 900)), "independent of the order of the chains")
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		data, err := newSet(t, 0, 900, 901).MarshalJSON()
+		require.NoError(t, err)
+		var ds StaticConfigDependencySet
+		require.NoError(t, ds.UnmarshalJSON(data))
+		require.Equal(t, hash, Hash(&ds))
+	})
+
+	require.NotEqual(t, hash, Hash(newSet(t, 0, 900)), "different chains")
+	require.NotEqual(t, hash, Hash(newSet(t, 0, 900, 901, 902)), "additional chain")
+	require.NotEqual(t, hash, Hash(newSet(t, 100, 900, 901)), "different message expiry window")
+}
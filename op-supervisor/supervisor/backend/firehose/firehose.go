@@ -0,0 +1,124 @@
+package firehose
+
+import (
+	"context"
+	"sync"
+
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// BlockOpener opens a block of the events DB, to find the executing messages in it.
+type BlockOpener interface {
+	OpenBlock(chainID eth.ChainID, blockNum uint64) (ref eth.BlockRef, logCount uint32, execMsgs map[uint32]*types.ExecutingMessage, err error)
+}
+
+// Firehose publishes the head updates of every chain, and the executing messages in every new local-unsafe block,
+// as events of the supervisor events subscription.
+// Subscribers filter the events themselves, see types.EventFilter.
+type Firehose struct {
+	log    log.Logger
+	blocks BlockOpener
+
+	feed  gethevent.FeedOf[types.SupervisorEvent]
+	queue chan types.SupervisorEvent
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// queueSize is the number of events that are buffered for slow subscribers.
+const queueSize = 1024
+
+var _ event.Deriver = (*Firehose)(nil)
+
+func New(log log.Logger, blocks BlockOpener) *Firehose {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Firehose{
+		log:    log.New("component", "firehose"),
+		blocks: blocks,
+		queue:  make(chan types.SupervisorEvent, queueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Feed returns the feed that every event is sent to.
+func (f *Firehose) Feed() *gethevent.FeedOf[types.SupervisorEvent] {
+	return &f.feed
+}
+
+func (f *Firehose) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case superevents.LocalUnsafeUpdateEvent:
+		f.send(types.SupervisorEvent{Type: types.EventLocalUnsafe, ChainID: x.ChainID, Block: x.NewLocalUnsafe.ID()})
+		f.sendExecutingMessages(x.ChainID, x.NewLocalUnsafe.ID())
+	case superevents.CrossUnsafeUpdateEvent:
+		f.send(types.SupervisorEvent{Type: types.EventCrossUnsafe, ChainID: x.ChainID, Block: x.NewCrossUnsafe.ID()})
+	case superevents.LocalSafeUpdateEvent:
+		source := x.NewLocalSafe.Source.ID()
+		f.send(types.SupervisorEvent{Type: types.EventLocalSafe, ChainID: x.ChainID, Block: x.NewLocalSafe.Derived.ID(), Source: &source})
+	case superevents.CrossSafeUpdateEvent:
+		source := x.NewCrossSafe.Source.ID()
+		f.send(types.SupervisorEvent{Type: types.EventCrossSafe, ChainID: x.ChainID, Block: x.NewCrossSafe.Derived.ID(), Source: &source})
+	case superevents.FinalizedL2UpdateEvent:
+		f.send(types.SupervisorEvent{Type: types.EventFinalized, ChainID: x.ChainID, Block: x.FinalizedL2.ID()})
+	case superevents.ChainRewoundEvent:
+		f.send(types.SupervisorEvent{Type: types.EventChainRewound, ChainID: x.ChainID})
+	default:
+		return false
+	}
+	return true
+}
+
+// sendExecutingMessages sends an event for every executing message in the block, in log order.
+func (f *Firehose) sendExecutingMessages(chainID eth.ChainID, block eth.BlockID) {
+	_, logCount, execMsgs, err := f.blocks.OpenBlock(chainID, block.Number)
+	if err != nil {
+		f.log.Warn("Failed to open block for executing messages", "chain", chainID, "block", block, "err", err)
+		return
+	}
+	for i := uint32(0); i < logCount; i++ {
+		if msg, ok := execMsgs[i]; ok {
+			f.send(types.SupervisorEvent{Type: types.EventExecutingMessage, ChainID: chainID, Block: block, Message: msg})
+		}
+	}
+}
+
+// send queues the event for the subscribers, without blocking event processing.
+// Events are dropped if the subscribers fall behind by more than queueSize events.
+func (f *Firehose) send(ev types.SupervisorEvent) {
+	select {
+	case f.queue <- ev:
+	default:
+		f.log.Warn("Dropping event, subscribers are falling behind", "type", ev.Type, "chain", ev.ChainID, "block", ev.Block)
+	}
+}
+
+func (f *Firehose) Start() {
+	f.wg.Add(1)
+	go f.loop()
+}
+
+func (f *Firehose) Stop() {
+	f.cancel()
+	f.wg.Wait()
+}
+
+func (f *Firehose) loop() {
+	defer f.wg.Done()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case ev := <-f.queue:
+			f.feed.Send(ev)
+		}
+	}
+}
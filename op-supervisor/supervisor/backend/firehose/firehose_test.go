@@ -0,0 +1,66 @@
+package firehose
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubBlocks struct {
+	logCount uint32
+	execMsgs map[uint32]*types.ExecutingMessage
+}
+
+func (s *stubBlocks) OpenBlock(chainID eth.ChainID, blockNum uint64) (eth.BlockRef, uint32, map[uint32]*types.ExecutingMessage, error) {
+	return eth.BlockRef{Number: blockNum}, s.logCount, s.execMsgs, nil
+}
+
+func TestFirehose(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(900)
+	msgA := &types.ExecutingMessage{Checksum: types.MessageChecksum{1}}
+	msgB := &types.ExecutingMessage{Checksum: types.MessageChecksum{2}}
+	blocks := &stubBlocks{logCount: 4, execMsgs: map[uint32]*types.ExecutingMessage{3: msgB, 1: msgA}}
+	f := New(testlog.Logger(t, log.LevelInfo), blocks)
+	f.Start()
+	t.Cleanup(f.Stop)
+
+	ch := make(chan types.SupervisorEvent, 10)
+	sub := f.Feed().Subscribe(ch)
+	t.Cleanup(sub.Unsubscribe)
+
+	block := eth.BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	l1 := eth.BlockRef{Hash: common.Hash{0xbb}, Number: 100}
+	require.True(t, f.OnEvent(superevents.LocalUnsafeUpdateEvent{ChainID: chainID, NewLocalUnsafe: eth.BlockRef{Hash: block.Hash, Number: block.Number}}))
+	require.True(t, f.OnEvent(superevents.CrossSafeUpdateEvent{ChainID: chainID, NewCrossSafe: types.DerivedBlockSealPair{
+		Source:  types.BlockSeal{Hash: l1.Hash, Number: l1.Number},
+		Derived: types.BlockSeal{Hash: block.Hash, Number: block.Number},
+	}}))
+	require.True(t, f.OnEvent(superevents.ChainRewoundEvent{ChainID: chainID}))
+	require.False(t, f.OnEvent(superevents.UpdateCrossUnsafeRequestEvent{ChainID: chainID}))
+
+	source := l1.ID()
+	expected := []types.SupervisorEvent{
+		{Type: types.EventLocalUnsafe, ChainID: chainID, Block: block.ID()},
+		{Type: types.EventExecutingMessage, ChainID: chainID, Block: block.ID(), Message: msgA},
+		{Type: types.EventExecutingMessage, ChainID: chainID, Block: block.ID(), Message: msgB},
+		{Type: types.EventCrossSafe, ChainID: chainID, Block: block.ID(), Source: &source},
+		{Type: types.EventChainRewound, ChainID: chainID},
+	}
+	for _, ev := range expected {
+		select {
+		case got := <-ch:
+			require.Equal(t, ev, got)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+}
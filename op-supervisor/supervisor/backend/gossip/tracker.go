@@ -0,0 +1,102 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// blocksPerChain is the number of gossip blocks per chain that are remembered,
+// to attribute blocks that are invalidated later to the peer that distributed them.
+const blocksPerChain = 1000
+
+type Metrics interface {
+	RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration)
+	RecordInvalidGossipInteropBlock(chainID eth.ChainID)
+}
+
+// Tracker tracks the unsafe blocks that the managed nodes received via p2p gossip.
+// It measures the propagation latency of the blocks, and attributes the blocks that fail
+// the cross-chain checks to the peer that distributed them.
+type Tracker struct {
+	log log.Logger
+	m   Metrics
+
+	mu sync.Mutex
+	// peers is the peer that distributed each block, per chain
+	peers map[eth.ChainID]*lru.Cache[common.Hash, string]
+}
+
+var _ event.Deriver = (*Tracker)(nil)
+
+func New(log log.Logger, m Metrics) *Tracker {
+	return &Tracker{
+		log:   log.New("component", "gossip-tracker"),
+		m:     m,
+		peers: make(map[eth.ChainID]*lru.Cache[common.Hash, string]),
+	}
+}
+
+func (t *Tracker) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case superevents.GossipBlockEvent:
+		t.onGossipBlock(x.ChainID, x.Block)
+	case superevents.InvalidateLocalSafeEvent:
+		t.onInvalidated(x.ChainID, x.Candidate.Derived)
+	case superevents.ReplaceBlockEvent:
+		t.onInvalidated(x.ChainID, eth.BlockRef{Hash: x.Replacement.Invalidated, Number: x.Replacement.Replacement.Number})
+	default:
+		return false
+	}
+	return true
+}
+
+func (t *Tracker) onGossipBlock(chainID eth.ChainID, block types.GossipBlock) {
+	blockTime := time.Unix(int64(block.Block.Time), 0)
+	receivedAt := time.UnixMilli(int64(block.ReceivedAt))
+	// clocks of the sequencer and the node may differ, negative latencies are not meaningful
+	latency := max(receivedAt.Sub(blockTime), 0)
+	t.m.RecordGossipBlock(chainID, string(block.Outcome), latency)
+
+	if block.Outcome == types.GossipBlockInvalid {
+		t.log.Warn("Managed node received invalid block via gossip",
+			"chain", chainID, "block", block.Block, "peer", block.Peer)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	peers, ok := t.peers[chainID]
+	if !ok {
+		peers, _ = lru.New[common.Hash, string](blocksPerChain)
+		t.peers[chainID] = peers
+	}
+	peers.ContainsOrAdd(block.Block.Hash, block.Peer)
+}
+
+func (t *Tracker) onInvalidated(chainID eth.ChainID, block eth.BlockRef) {
+	t.mu.Lock()
+	peers, ok := t.peers[chainID]
+	var peer string
+	if ok {
+		peer, ok = peers.Peek(block.Hash)
+		// the block is invalidated once, but may be reported by both the invalidation and the replacement
+		peers.Remove(block.Hash)
+	}
+	t.mu.Unlock()
+	if !ok {
+		return // not received via gossip, or not anymore remembered
+	}
+	t.log.Warn("Peer distributed interop block that failed the cross-chain checks",
+		"chain", chainID, "block", block.ID(), "peer", peer)
+	t.m.RecordInvalidGossipInteropBlock(chainID)
+}
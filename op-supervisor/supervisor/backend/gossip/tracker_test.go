@@ -0,0 +1,103 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type gossipRecord struct {
+	outcome string
+	latency time.Duration
+}
+
+type stubMetrics struct {
+	blocks  map[eth.ChainID][]gossipRecord
+	invalid map[eth.ChainID]int
+}
+
+func (s *stubMetrics) RecordGossipBlock(chainID eth.ChainID, outcome string, latency time.Duration) {
+	s.blocks[chainID] = append(s.blocks[chainID], gossipRecord{outcome: outcome, latency: latency})
+}
+
+func (s *stubMetrics) RecordInvalidGossipInteropBlock(chainID eth.ChainID) {
+	s.invalid[chainID]++
+}
+
+var (
+	chainA = eth.ChainIDFromUInt64(900)
+	chainB = eth.ChainIDFromUInt64(901)
+)
+
+func setup(t *testing.T) (*Tracker, *stubMetrics) {
+	m := &stubMetrics{blocks: make(map[eth.ChainID][]gossipRecord), invalid: make(map[eth.ChainID]int)}
+	return New(testlog.Logger(t, log.LevelInfo), m), m
+}
+
+func gossipBlock(n uint64, peer string, outcome types.GossipBlockOutcome) types.GossipBlock {
+	return types.GossipBlock{
+		Block:      eth.BlockRef{Hash: common.Hash{byte(n)}, Number: n, Time: 1000 + n},
+		Peer:       peer,
+		ReceivedAt: hexutil.Uint64((1000+n)*1000 + 250),
+		Outcome:    outcome,
+	}
+}
+
+func TestTrackerLatency(t *testing.T) {
+	tr, m := setup(t)
+	require.True(t, tr.OnEvent(superevents.GossipBlockEvent{ChainID: chainA, Block: gossipBlock(1, "peer-a", types.GossipBlockValid)}))
+	require.True(t, tr.OnEvent(superevents.GossipBlockEvent{ChainID: chainA, Block: gossipBlock(2, "peer-b", types.GossipBlockInvalid)}))
+
+	// a block that arrived before its timestamp, due to clock differences
+	early := gossipBlock(3, "peer-a", types.GossipBlockValid)
+	early.ReceivedAt = hexutil.Uint64(1000 * 1000)
+	require.True(t, tr.OnEvent(superevents.GossipBlockEvent{ChainID: chainA, Block: early}))
+
+	require.Equal(t, []gossipRecord{
+		{outcome: "valid", latency: 250 * time.Millisecond},
+		{outcome: "invalid", latency: 250 * time.Millisecond},
+		{outcome: "valid", latency: 0},
+	}, m.blocks[chainA])
+	require.Empty(t, m.blocks[chainB])
+}
+
+func TestTrackerInvalidatedBlocks(t *testing.T) {
+	tr, m := setup(t)
+	block := gossipBlock(1, "peer-a", types.GossipBlockValid)
+	tr.OnEvent(superevents.GossipBlockEvent{ChainID: chainA, Block: block})
+
+	// a block of another chain with the same hash is not attributed
+	tr.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainB, Candidate: types.DerivedBlockRefPair{Derived: block.Block}})
+	require.Zero(t, m.invalid[chainB])
+
+	tr.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainA, Candidate: types.DerivedBlockRefPair{Derived: block.Block}})
+	require.Equal(t, 1, m.invalid[chainA])
+
+	// the replacement of the same block is not counted again
+	tr.OnEvent(superevents.ReplaceBlockEvent{ChainID: chainA, Replacement: types.BlockReplacement{
+		Replacement: eth.BlockRef{Hash: common.Hash{0xaa}, Number: block.Block.Number},
+		Invalidated: block.Block.Hash,
+	}})
+	require.Equal(t, 1, m.invalid[chainA])
+
+	// blocks that were not received via gossip are not attributed
+	tr.OnEvent(superevents.InvalidateLocalSafeEvent{ChainID: chainA, Candidate: types.DerivedBlockRefPair{
+		Derived: eth.BlockRef{Hash: common.Hash{0xbb}, Number: 5},
+	}})
+	require.Equal(t, 1, m.invalid[chainA])
+}
+
+func TestTrackerIgnoresOtherEvents(t *testing.T) {
+	tr, _ := setup(t)
+	require.False(t, tr.OnEvent(superevents.ChainRewoundEvent{ChainID: chainA}))
+}
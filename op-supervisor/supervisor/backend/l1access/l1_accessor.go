@@ -8,11 +8,14 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
 const reqTimeout = time.Second * 10
@@ -22,6 +25,7 @@ var errNoL1Source = errors.New("no L1 source configured")
 type L1Source interface {
 	L1BlockRefByNumber(ctx context.Context, number uint64) (eth.L1BlockRef, error)
 	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error)
 }
 
 // L1Accessor provides access to the L1 chain.
@@ -31,6 +35,9 @@ type L1Source interface {
 // and the latest block subscription is used to monitor the tip height of the L1 chain.
 // L1Accessor has the concept of confirmation depth, which is used to block access to requests to blocks which are too recent.
 // When requests for blocks are more recent than the tip minus the confirmation depth, a NotFound error is returned.
+// The view of the L1 chain can be pinned to a block, to replay derivation deterministically:
+// while pinned, requests for blocks after the pinned block return a NotFound error as well,
+// and finality signals beyond the pinned block are ignored.
 type L1Accessor struct {
 	log log.Logger
 
@@ -47,6 +54,11 @@ type L1Accessor struct {
 	latestSub ethereum.Subscription
 	confDepth uint64
 
+	// pin is the block that the L1 view is pinned to, nil if not pinned
+	pin      *eth.L1BlockRef
+	pinnedAt time.Time
+	pinMu    sync.RWMutex
+
 	// to interrupt requests, so the system can shut down quickly
 	sysCtx context.Context
 }
@@ -165,6 +177,10 @@ func (p *L1Accessor) PullLatest() error {
 }
 
 func (p *L1Accessor) onFinalized(ctx context.Context, ref eth.L1BlockRef) {
+	if pin, ok := p.pinned(); ok && ref.Number > pin.Number {
+		p.log.Debug("Ignoring finalized L1 block beyond the pinned L1 block", "ref", ref, "pin", pin)
+		return
+	}
 	p.emitter.Emit(superevents.FinalizedL1RequestEvent{FinalizedL1: ref})
 }
 
@@ -202,5 +218,70 @@ func (p *L1Accessor) L1BlockRefByNumber(ctx context.Context, number uint64) (eth
 	if number > p.tip.Number-p.confDepth {
 		return eth.L1BlockRef{}, ethereum.NotFound
 	}
+	if pin, ok := p.pinned(); ok {
+		// block access to requests beyond the pinned block
+		if number > pin.Number {
+			return eth.L1BlockRef{}, ethereum.NotFound
+		}
+		if number == pin.Number {
+			return pin, nil
+		}
+	}
 	return p.client.L1BlockRefByNumber(ctx, number)
 }
+
+// Pin pins the L1 view to the block with the given hash, until unpinned.
+// A previous pin is replaced. The pinned block must be known to the L1 source.
+func (p *L1Accessor) Pin(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	p.clientMu.RLock()
+	defer p.clientMu.RUnlock()
+	if p.client == nil {
+		return eth.L1BlockRef{}, errNoL1Source
+	}
+	ref, err := p.client.L1BlockRefByHash(ctx, hash)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 block to pin %s: %w", hash, err)
+	}
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+	p.pin = &ref
+	p.pinnedAt = time.Now()
+	p.log.Warn("Pinned L1 view, L1 blocks beyond the pin are not accessible until unpinned", "pin", ref)
+	return ref, nil
+}
+
+// Unpin resumes access to the L1 chain beyond the pinned block. It is a no-op if the L1 view is not pinned.
+func (p *L1Accessor) Unpin() {
+	p.pinMu.Lock()
+	defer p.pinMu.Unlock()
+	if p.pin == nil {
+		return
+	}
+	p.log.Warn("Unpinned L1 view", "pin", *p.pin, "pinnedFor", time.Since(p.pinnedAt))
+	p.pin = nil
+	p.pinnedAt = time.Time{}
+}
+
+// PinStatus returns whether the L1 view is pinned, and to which block.
+func (p *L1Accessor) PinStatus() types.L1PinStatus {
+	p.pinMu.RLock()
+	defer p.pinMu.RUnlock()
+	if p.pin == nil {
+		return types.L1PinStatus{}
+	}
+	pin := *p.pin
+	return types.L1PinStatus{
+		Pinned:   true,
+		Block:    &pin,
+		PinnedAt: hexutil.Uint64(p.pinnedAt.Unix()),
+	}
+}
+
+func (p *L1Accessor) pinned() (eth.L1BlockRef, bool) {
+	p.pinMu.RLock()
+	defer p.pinMu.RUnlock()
+	if p.pin == nil {
+		return eth.L1BlockRef{}, false
+	}
+	return *p.pin, true
+}
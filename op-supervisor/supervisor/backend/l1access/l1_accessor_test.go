@@ -7,13 +7,19 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
 )
 
 type mockL1Source struct {
 	l1BlockRefByNumberFn func(context.Context, uint64) (eth.L1BlockRef, error)
 	l1BlockRefByLabelFn  func(context.Context, eth.BlockLabel) (eth.L1BlockRef, error)
+	l1BlockRefByHashFn   func(context.Context, common.Hash) (eth.L1BlockRef, error)
 }
 
 func (m *mockL1Source) L1BlockRefByNumber(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
@@ -30,6 +36,13 @@ func (m *mockL1Source) L1BlockRefByLabel(ctx context.Context, label eth.BlockLab
 	return eth.L1BlockRef{}, nil
 }
 
+func (m *mockL1Source) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	if m.l1BlockRefByHashFn != nil {
+		return m.l1BlockRefByHashFn(ctx, hash)
+	}
+	return eth.L1BlockRef{}, nil
+}
+
 // TestL1Accessor tests the L1Accessor
 // confirming that it can fetch L1BlockRefs by number
 // and the confirmation depth is respected
@@ -59,3 +72,67 @@ func TestL1Accessor(t *testing.T) {
 	require.Equal(t, source2, accessor.client)
 
 }
+
+type captureEmitter struct {
+	events []event.Event
+}
+
+func (c *captureEmitter) Emit(ev event.Event) {
+	c.events = append(c.events, ev)
+}
+
+// TestL1AccessorPin tests that the L1 view can be pinned to a block,
+// refusing access to L1 blocks beyond the pin until unpinned.
+func TestL1AccessorPin(t *testing.T) {
+	log := testlog.Logger(t, slog.LevelDebug)
+	pinned := eth.L1BlockRef{Hash: common.Hash{0xaa}, Number: 5}
+	source := &mockL1Source{}
+	source.l1BlockRefByNumberFn = func(ctx context.Context, number uint64) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: common.Hash{byte(number)}, Number: number}, nil
+	}
+	source.l1BlockRefByHashFn = func(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+		if hash == pinned.Hash {
+			return pinned, nil
+		}
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	accessor := NewL1Accessor(context.Background(), log, source)
+	emitter := &captureEmitter{}
+	accessor.AttachEmitter(emitter)
+	accessor.tip = eth.BlockID{Number: 10}
+	require.False(t, accessor.PinStatus().Pinned)
+
+	_, err := accessor.Pin(context.Background(), common.Hash{0xbb})
+	require.ErrorIs(t, err, ethereum.NotFound, "unknown blocks cannot be pinned")
+	require.False(t, accessor.PinStatus().Pinned)
+
+	ref, err := accessor.Pin(context.Background(), pinned.Hash)
+	require.NoError(t, err)
+	require.Equal(t, pinned, ref)
+	status := accessor.PinStatus()
+	require.True(t, status.Pinned)
+	require.Equal(t, pinned, *status.Block)
+	require.NotZero(t, status.PinnedAt)
+
+	// blocks up to the pin are accessible, the pinned block itself is served from the pin
+	ref, err = accessor.L1BlockRefByNumber(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), ref.Number)
+	ref, err = accessor.L1BlockRefByNumber(context.Background(), 5)
+	require.NoError(t, err)
+	require.Equal(t, pinned, ref)
+	_, err = accessor.L1BlockRefByNumber(context.Background(), 6)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	// finality beyond the pin is ignored
+	accessor.onFinalized(context.Background(), eth.L1BlockRef{Number: 7})
+	require.Empty(t, emitter.events)
+	accessor.onFinalized(context.Background(), eth.L1BlockRef{Number: 3})
+	require.Equal(t, []event.Event{superevents.FinalizedL1RequestEvent{FinalizedL1: eth.L1BlockRef{Number: 3}}}, emitter.events)
+
+	accessor.Unpin()
+	require.False(t, accessor.PinStatus().Pinned)
+	ref, err = accessor.L1BlockRefByNumber(context.Background(), 6)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), ref.Number)
+}
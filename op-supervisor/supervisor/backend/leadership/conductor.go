@@ -0,0 +1,54 @@
+package leadership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// Leader identifies the conductor server of the sequencer that is the leader of a chain.
+type Leader struct {
+	ID   string `json:"id"`
+	Addr string `json:"addr"`
+}
+
+// Conductor is the part of the op-conductor API that the tracker uses.
+type Conductor interface {
+	LeaderWithID(ctx context.Context) (Leader, error)
+}
+
+// RPCConductor is a Conductor served over RPC.
+type RPCConductor struct {
+	cl client.RPC
+}
+
+var _ Conductor = (*RPCConductor)(nil)
+
+func NewRPCConductor(cl client.RPC) *RPCConductor {
+	return &RPCConductor{cl: cl}
+}
+
+// DialConductor connects to the op-conductor at the given endpoint.
+func DialConductor(ctx context.Context, logger log.Logger, endpoint string, m opmetrics.RPCMetricer) (*RPCConductor, error) {
+	cl, err := client.NewRPC(ctx, logger, endpoint,
+		client.WithDialAttempts(10),
+		client.WithRPCRecorder(m.NewRecorder("conductor")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial conductor %s: %w", endpoint, err)
+	}
+	return NewRPCConductor(cl), nil
+}
+
+func (c *RPCConductor) LeaderWithID(ctx context.Context) (Leader, error) {
+	var leader Leader
+	err := c.cl.CallContext(ctx, &leader, "conductor_leaderWithID")
+	return leader, err
+}
+
+func (c *RPCConductor) Close() {
+	c.cl.Close()
+}
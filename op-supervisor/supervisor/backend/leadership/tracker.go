@@ -0,0 +1,175 @@
+package leadership
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+)
+
+const reqTimeout = time.Second * 10
+
+type chainLeader struct {
+	leader        Leader
+	changedAt     time.Time
+	failoverUntil time.Time
+}
+
+func (c *chainLeader) status() eth.SupervisorSequencerStatus {
+	return eth.SupervisorSequencerStatus{
+		LeaderID:      c.leader.ID,
+		LeaderAddr:    c.leader.Addr,
+		ChangedAt:     uint64(c.changedAt.Unix()),
+		FailoverUntil: uint64(c.failoverUntil.Unix()),
+	}
+}
+
+// Tracker tracks the sequencer leadership of chains, by polling the op-conductor of each chain.
+// When the leader of a chain changes, the chain enters a failover window,
+// during which unsafe forks are expected, as the new leader may not have built on the last unsafe block of the previous leader.
+// Leadership changes are emitted as events, so that the status of the supervisor includes the active sequencer.
+type Tracker struct {
+	log     log.Logger
+	cfg     config.ConductorConfig
+	emitter event.Emitter
+
+	// now is the clock of the failover windows, replaced in tests.
+	now func() time.Time
+
+	conductors map[eth.ChainID]Conductor
+
+	mu      sync.RWMutex
+	leaders map[eth.ChainID]*chainLeader
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ event.AttachEmitter = (*Tracker)(nil)
+
+func New(log log.Logger, cfg config.ConductorConfig, conductors map[eth.ChainID]Conductor) *Tracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Tracker{
+		log:        log.New("component", "leadership"),
+		cfg:        cfg,
+		now:        time.Now,
+		conductors: conductors,
+		leaders:    make(map[eth.ChainID]*chainLeader),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (t *Tracker) AttachEmitter(em event.Emitter) {
+	t.emitter = em
+}
+
+func (t *Tracker) OnEvent(ev event.Event) bool {
+	return false
+}
+
+// Start polls the conductor of each chain in the background, until stopped.
+func (t *Tracker) Start() {
+	for chainID, c := range t.conductors {
+		t.wg.Add(1)
+		go t.loop(chainID, c)
+	}
+}
+
+func (t *Tracker) Stop() {
+	t.cancel()
+	t.wg.Wait()
+	for _, c := range t.conductors {
+		if cl, ok := c.(interface{ Close() }); ok {
+			cl.Close()
+		}
+	}
+}
+
+func (t *Tracker) loop(chainID eth.ChainID, c Conductor) {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		t.poll(t.ctx, chainID, c)
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) poll(ctx context.Context, chainID eth.ChainID, c Conductor) {
+	ctx, cancel := context.WithTimeout(ctx, reqTimeout)
+	defer cancel()
+	leader, err := c.LeaderWithID(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			t.log.Warn("Failed to fetch sequencer leader", "chain", chainID, "err", err)
+		}
+		return
+	}
+	if leader.ID == "" {
+		// no leader while an election is in progress: the leadership changes once the election completes
+		t.log.Debug("Conductor has no sequencer leader", "chain", chainID)
+		return
+	}
+	t.update(chainID, leader)
+}
+
+// update records the leader of the chain, and emits an event if it is a different leader.
+func (t *Tracker) update(chainID eth.ChainID, leader Leader) {
+	t.mu.Lock()
+	prev, known := t.leaders[chainID]
+	if known && prev.leader == leader {
+		t.mu.Unlock()
+		return
+	}
+	now := t.now()
+	next := &chainLeader{leader: leader, changedAt: now, failoverUntil: now}
+	if known {
+		next.failoverUntil = now.Add(t.cfg.FailoverWindow)
+		t.log.Warn("Sequencer leadership changed", "chain", chainID,
+			"prevLeader", prev.leader.ID, "leader", leader.ID, "addr", leader.Addr, "failoverUntil", next.failoverUntil)
+	} else {
+		t.log.Info("Sequencer leader detected", "chain", chainID, "leader", leader.ID, "addr", leader.Addr)
+	}
+	t.leaders[chainID] = next
+	t.mu.Unlock()
+
+	t.emitter.Emit(superevents.SequencerLeaderChangedEvent{
+		ChainID:   chainID,
+		Sequencer: next.status(),
+	})
+}
+
+// Failover returns the ID of the leader of the chain, and whether the chain is in the failover window of a leadership change.
+// The leader is empty if the leadership of the chain is not tracked, or not known yet.
+func (t *Tracker) Failover(chainID eth.ChainID) (leader string, inFailover bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	st, ok := t.leaders[chainID]
+	if !ok {
+		return "", false
+	}
+	return st.leader.ID, t.now().Before(st.failoverUntil)
+}
+
+// Status returns the sequencer leadership of the chain, and false if it is not known.
+func (t *Tracker) Status(chainID eth.ChainID) (eth.SupervisorSequencerStatus, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	st, ok := t.leaders[chainID]
+	if !ok {
+		return eth.SupervisorSequencerStatus{}, false
+	}
+	return st.status(), true
+}
@@ -0,0 +1,143 @@
+package leadership
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/config"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+)
+
+type stubConductor struct {
+	mu     sync.Mutex
+	leader Leader
+	err    error
+}
+
+func (s *stubConductor) LeaderWithID(ctx context.Context) (Leader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader, s.err
+}
+
+func (s *stubConductor) set(leader Leader, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.leader, s.err = leader, err
+}
+
+var (
+	chainA = eth.ChainIDFromUInt64(900)
+	chainB = eth.ChainIDFromUInt64(901)
+)
+
+func setup(t *testing.T) (*Tracker, *stubConductor, *[]superevents.SequencerLeaderChangedEvent, *time.Time) {
+	cfg := config.DefaultConductorConfig()
+	cfg.FailoverWindow = 30 * time.Second
+	c := &stubConductor{}
+	tr := New(testlog.Logger(t, log.LevelInfo), cfg, map[eth.ChainID]Conductor{chainA: c})
+	now := time.Unix(1000, 0)
+	tr.now = func() time.Time { return now }
+	var events []superevents.SequencerLeaderChangedEvent
+	tr.AttachEmitter(event.EmitterFunc(func(ev event.Event) {
+		events = append(events, ev.(superevents.SequencerLeaderChangedEvent))
+	}))
+	return tr, c, &events, &now
+}
+
+func TestTrackerLeadershipChange(t *testing.T) {
+	tr, c, events, now := setup(t)
+	ctx := context.Background()
+
+	leader, inFailover := tr.Failover(chainA)
+	require.Empty(t, leader, "leader is not known yet")
+	require.False(t, inFailover)
+
+	// the first leader is not a failover
+	c.set(Leader{ID: "seq-a", Addr: "seq-a:50050"}, nil)
+	tr.poll(ctx, chainA, c)
+	require.Equal(t, []superevents.SequencerLeaderChangedEvent{{
+		ChainID: chainA,
+		Sequencer: eth.SupervisorSequencerStatus{
+			LeaderID: "seq-a", LeaderAddr: "seq-a:50050", ChangedAt: 1000, FailoverUntil: 1000,
+		},
+	}}, *events)
+	leader, inFailover = tr.Failover(chainA)
+	require.Equal(t, "seq-a", leader)
+	require.False(t, inFailover)
+
+	// the same leader is not emitted again
+	*now = now.Add(5 * time.Second)
+	tr.poll(ctx, chainA, c)
+	require.Len(t, *events, 1)
+
+	// no leader during the election, and failed polls, keep the last known leader
+	c.set(Leader{}, nil)
+	tr.poll(ctx, chainA, c)
+	c.set(Leader{}, errors.New("connection refused"))
+	tr.poll(ctx, chainA, c)
+	require.Len(t, *events, 1)
+	leader, _ = tr.Failover(chainA)
+	require.Equal(t, "seq-a", leader)
+
+	// a new leader starts the failover window
+	c.set(Leader{ID: "seq-b", Addr: "seq-b:50050"}, nil)
+	tr.poll(ctx, chainA, c)
+	require.Len(t, *events, 2)
+	require.Equal(t, eth.SupervisorSequencerStatus{
+		LeaderID: "seq-b", LeaderAddr: "seq-b:50050", ChangedAt: 1005, FailoverUntil: 1035,
+	}, (*events)[1].Sequencer)
+	leader, inFailover = tr.Failover(chainA)
+	require.Equal(t, "seq-b", leader)
+	require.True(t, inFailover)
+
+	*now = now.Add(29 * time.Second)
+	_, inFailover = tr.Failover(chainA)
+	require.True(t, inFailover)
+	*now = now.Add(time.Second)
+	_, inFailover = tr.Failover(chainA)
+	require.False(t, inFailover, "failover window ended")
+
+	status, ok := tr.Status(chainA)
+	require.True(t, ok)
+	require.Equal(t, "seq-b", status.LeaderID)
+	_, ok = tr.Status(chainB)
+	require.False(t, ok, "leadership of chain is not tracked")
+}
+
+func TestTrackerStartStop(t *testing.T) {
+	tr, c, _, _ := setup(t)
+	tr.cfg.PollInterval = time.Millisecond
+	var mu sync.Mutex
+	var seen []string
+	tr.AttachEmitter(event.EmitterFunc(func(ev event.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, ev.(superevents.SequencerLeaderChangedEvent).Sequencer.LeaderID)
+	}))
+	c.set(Leader{ID: "seq-a"}, nil)
+	tr.Start()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) == 1
+	}, 10*time.Second, time.Millisecond)
+	c.set(Leader{ID: "seq-b"}, nil)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seen) == 2
+	}, 10*time.Second, time.Millisecond)
+	tr.Stop()
+	require.Equal(t, []string{"seq-a", "seq-b"}, seen)
+}
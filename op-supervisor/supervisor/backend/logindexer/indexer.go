@@ -0,0 +1,248 @@
+package logindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logindex"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// retryDelay is how long to wait before retrying, after a failure to update the index.
+var retryDelay = 2 * time.Second
+
+// maxBlocksPerUpdate limits how many blocks are indexed at once,
+// so a large backlog does not hold up a shutdown or a rebuild request.
+var maxBlocksPerUpdate = 1000
+
+// Source is the events DB of the chain that is indexed.
+type Source interface {
+	FirstSealedBlock() (types.BlockSeal, error)
+	FindSealedBlock(number uint64) (types.BlockSeal, error)
+	IteratorStartingAt(sealedNum uint64, logsSince uint32) (logs.Iterator, error)
+}
+
+// DB stores the index.
+type DB interface {
+	Head() (eth.BlockID, bool)
+	AddBlock(block eth.BlockID, logs []logindex.Log) error
+	RewindTo(blockNum uint64) error
+	Clear() error
+}
+
+// Indexer keeps the log index of a chain in sync with the events DB of the chain.
+//
+// The indexing happens in a background routine, woken up by new local-unsafe blocks and rewinds,
+// so the events DB writes are not held up by the index.
+// Before indexing, the head of the index is checked against the events DB,
+// and the index is rewound if the indexed blocks were reorged out.
+type Indexer struct {
+	log     log.Logger
+	chainID eth.ChainID
+	src     Source
+	db      DB
+
+	mu sync.Mutex
+	// rebuild is set when the index is requested to be rebuilt from scratch
+	rebuild bool
+
+	wake chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ event.Deriver = (*Indexer)(nil)
+
+func New(log log.Logger, chainID eth.ChainID, src Source, db DB) *Indexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Indexer{
+		log:     log.New("component", "log-indexer", "chain", chainID),
+		chainID: chainID,
+		src:     src,
+		db:      db,
+		wake:    make(chan struct{}, 1),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (ix *Indexer) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case superevents.LocalUnsafeUpdateEvent:
+		if x.ChainID != ix.chainID {
+			return false
+		}
+		ix.signal()
+	case superevents.ChainRewoundEvent:
+		if x.ChainID != ix.chainID {
+			return false
+		}
+		ix.signal()
+	default:
+		return false
+	}
+	return true
+}
+
+// Rebuild schedules the index to be cleared and rebuilt from the events DB, in the background.
+func (ix *Indexer) Rebuild() {
+	ix.mu.Lock()
+	ix.rebuild = true
+	ix.mu.Unlock()
+	ix.signal()
+}
+
+// signal wakes up the background routine, without blocking.
+func (ix *Indexer) signal() {
+	select {
+	case ix.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (ix *Indexer) Start() {
+	ix.wg.Add(1)
+	go ix.loop()
+	ix.signal() // catch up with what was added to the events DB while not running
+}
+
+func (ix *Indexer) Stop() {
+	ix.cancel()
+	ix.wg.Wait()
+}
+
+func (ix *Indexer) loop() {
+	defer ix.wg.Done()
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ix.ctx.Done():
+			return
+		case <-ix.wake:
+		case <-retry:
+		}
+		retry = nil
+		more, err := ix.update()
+		if err != nil {
+			ix.log.Warn("Failed to update log index", "err", err)
+			retry = time.After(retryDelay)
+		} else if more {
+			ix.signal()
+		}
+	}
+}
+
+// update brings the index closer to the events DB.
+// It returns true if there are more blocks left to index.
+func (ix *Indexer) update() (more bool, err error) {
+	ix.mu.Lock()
+	rebuild := ix.rebuild
+	ix.rebuild = false
+	ix.mu.Unlock()
+
+	if rebuild {
+		ix.log.Info("Rebuilding log index")
+		if err := ix.db.Clear(); err != nil {
+			ix.mu.Lock()
+			ix.rebuild = true // try again next time
+			ix.mu.Unlock()
+			return false, fmt.Errorf("failed to clear log index: %w", err)
+		}
+	}
+	head, ok, err := ix.verifiedHead()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		first, err := ix.src.FirstSealedBlock()
+		if errors.Is(err, types.ErrFuture) {
+			return false, nil // nothing to index yet
+		} else if err != nil {
+			return false, fmt.Errorf("failed to read first sealed block: %w", err)
+		}
+		// Logs are always recorded after a block seal, so the first block has no logs to index.
+		if err := ix.db.AddBlock(first.ID(), nil); err != nil {
+			return false, fmt.Errorf("failed to index first block %s: %w", first, err)
+		}
+		head = first.ID()
+	}
+	return ix.indexBlocks(head)
+}
+
+// verifiedHead returns the head of the index, after rewinding the index until the head is canonical.
+func (ix *Indexer) verifiedHead() (eth.BlockID, bool, error) {
+	for {
+		head, ok := ix.db.Head()
+		if !ok {
+			return eth.BlockID{}, false, nil
+		}
+		seal, err := ix.src.FindSealedBlock(head.Number)
+		if err == nil && seal.Hash == head.Hash {
+			return head, true, nil
+		}
+		if err != nil && !errors.Is(err, types.ErrFuture) && !errors.Is(err, types.ErrSkipped) {
+			return eth.BlockID{}, false, fmt.Errorf("failed to verify indexed block %s: %w", head, err)
+		}
+		ix.log.Warn("Indexed block is no longer canonical, rewinding log index", "head", head)
+		if head.Number == 0 {
+			err = ix.db.Clear()
+		} else {
+			err = ix.db.RewindTo(head.Number - 1)
+		}
+		if err != nil {
+			return eth.BlockID{}, false, fmt.Errorf("failed to rewind log index from %s: %w", head, err)
+		}
+	}
+}
+
+// indexBlocks indexes the sealed blocks after the given head.
+func (ix *Indexer) indexBlocks(head eth.BlockID) (more bool, err error) {
+	iter, err := ix.src.IteratorStartingAt(head.Number, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to open events DB after %s: %w", head, err)
+	}
+	var pending []logindex.Log
+	count := 0
+	err = iter.TraverseConditional(func(state logs.IteratorState) error {
+		if logHash, logIdx, ok := state.InitMessage(); ok {
+			if logIdx == uint32(len(pending)) {
+				pending = append(pending, logindex.Log{Index: logIdx, Hash: logHash, ExecMsg: state.ExecMessage()})
+			}
+			return nil
+		}
+		hash, num, ok := state.SealedBlock()
+		if !ok || num <= head.Number {
+			return nil
+		}
+		block := eth.BlockID{Hash: hash, Number: num}
+		if err := ix.db.AddBlock(block, pending); err != nil {
+			return fmt.Errorf("failed to index block %s: %w", block, err)
+		}
+		head = block
+		pending = nil
+		count++
+		if count >= maxBlocksPerUpdate {
+			more = true
+			return types.ErrStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, types.ErrStop) && !errors.Is(err, types.ErrFuture) {
+		return false, err
+	}
+	if count > 0 {
+		ix.log.Debug("Indexed blocks", "count", count, "head", head)
+	}
+	return more, nil
+}
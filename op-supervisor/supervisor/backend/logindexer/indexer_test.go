@@ -0,0 +1,151 @@
+package logindexer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logindex"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/reads"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubMetrics struct{}
+
+func (s *stubMetrics) RecordDBEntryCount(kind string, count int64) {}
+
+func (s *stubMetrics) RecordDBSearchEntriesRead(count int64) {}
+
+var chainID = eth.ChainIDFromUInt64(900)
+
+func mockBlock(num uint64, fork byte) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{fork, byte(num)}, Number: num}
+}
+
+func mockLogHash(num uint64, logIdx uint32) common.Hash {
+	return common.Hash{0xaa, byte(num), byte(logIdx)}
+}
+
+// addBlock seals the given block on top of the parent, after adding the given number of logs.
+// Every log with an odd index executes the message with the given checksum.
+func addBlock(t *testing.T, db *logs.DB, parent eth.BlockID, block eth.BlockID, logCount uint32, checksum types.MessageChecksum) {
+	for i := uint32(0); i < logCount; i++ {
+		var execMsg *types.ExecutingMessage
+		if i%2 == 1 {
+			execMsg = &types.ExecutingMessage{
+				ChainID:   eth.ChainIDFromUInt64(901),
+				BlockNum:  1,
+				LogIdx:    0,
+				Timestamp: 1,
+				Checksum:  checksum,
+			}
+		}
+		require.NoError(t, db.AddLog(mockLogHash(block.Number, i), parent, i, execMsg))
+	}
+	require.NoError(t, db.SealBlock(parent.Hash, block, block.Number*2))
+}
+
+func setup(t *testing.T) (*Indexer, *logs.DB, *logindex.DB) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	logDB, err := logs.NewFromFile(logger, &stubMetrics{}, chainID, filepath.Join(dir, "log.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logDB.Close() })
+	index, err := logindex.NewFromFile(logger, filepath.Join(dir, "log_index.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = index.Close() })
+	return New(logger, chainID, logDB, index), logDB, index
+}
+
+func requireUpToDate(t *testing.T, ix *Indexer) {
+	more, err := ix.update()
+	require.NoError(t, err)
+	require.False(t, more)
+}
+
+func TestIndexer(t *testing.T) {
+	ix, logDB, index := setup(t)
+	checksum := types.MessageChecksum{0xcc}
+
+	// nothing to index yet
+	requireUpToDate(t, ix)
+	_, ok := index.Head()
+	require.False(t, ok)
+
+	require.NoError(t, logDB.SealBlock(common.Hash{}, mockBlock(10, 0), 20))
+	addBlock(t, logDB, mockBlock(10, 0), mockBlock(11, 0), 3, checksum)
+	addBlock(t, logDB, mockBlock(11, 0), mockBlock(12, 0), 0, checksum)
+	addBlock(t, logDB, mockBlock(12, 0), mockBlock(13, 0), 2, checksum)
+	// logs of a block that is not sealed yet are not indexed
+	require.NoError(t, logDB.AddLog(mockLogHash(14, 0), mockBlock(13, 0), 0, nil))
+
+	requireUpToDate(t, ix)
+	head, ok := index.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(13, 0), head)
+	require.Equal(t, []logindex.Location{{BlockNum: 11, LogIdx: 2}}, index.InitiatingLogs(mockLogHash(11, 2)))
+	require.Empty(t, index.InitiatingLogs(mockLogHash(14, 0)))
+	require.Equal(t, []logindex.Location{{BlockNum: 11, LogIdx: 1}, {BlockNum: 13, LogIdx: 1}}, index.ExecutingMessages(checksum))
+
+	// reorg of block 13
+	require.NoError(t, logDB.Rewind(&reads.TestInvalidator{}, mockBlock(12, 0)))
+	addBlock(t, logDB, mockBlock(12, 0), mockBlock(13, 1), 1, checksum)
+	requireUpToDate(t, ix)
+	head, ok = index.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(13, 1), head)
+	require.Equal(t, []logindex.Location{{BlockNum: 11, LogIdx: 1}}, index.ExecutingMessages(checksum))
+	require.Empty(t, index.InitiatingLogs(mockLogHash(13, 1)))
+	require.Equal(t, []logindex.Location{{BlockNum: 13, LogIdx: 0}}, index.InitiatingLogs(mockLogHash(13, 0)))
+
+	// rebuild from scratch
+	ix.Rebuild()
+	requireUpToDate(t, ix)
+	head, ok = index.Head()
+	require.True(t, ok)
+	require.Equal(t, mockBlock(13, 1), head)
+	require.Equal(t, []logindex.Location{{BlockNum: 11, LogIdx: 1}}, index.ExecutingMessages(checksum))
+}
+
+func TestIndexerBatches(t *testing.T) {
+	prev := maxBlocksPerUpdate
+	maxBlocksPerUpdate = 2
+	t.Cleanup(func() { maxBlocksPerUpdate = prev })
+
+	ix, logDB, index := setup(t)
+	require.NoError(t, logDB.SealBlock(common.Hash{}, mockBlock(10, 0), 20))
+	for i := uint64(11); i <= 15; i++ {
+		addBlock(t, logDB, mockBlock(i-1, 0), mockBlock(i, 0), 2, types.MessageChecksum{0xcc})
+	}
+	more, err := ix.update()
+	require.NoError(t, err)
+	require.True(t, more)
+	head, _ := index.Head()
+	require.Equal(t, uint64(12), head.Number)
+
+	more, err = ix.update()
+	require.NoError(t, err)
+	require.True(t, more)
+	more, err = ix.update()
+	require.NoError(t, err)
+	require.False(t, more)
+	head, _ = index.Head()
+	require.Equal(t, mockBlock(15, 0), head)
+	require.Len(t, index.ExecutingMessages(types.MessageChecksum{0xcc}), 5)
+}
+
+func TestIndexerEvents(t *testing.T) {
+	ix, _, _ := setup(t)
+	require.True(t, ix.OnEvent(superevents.LocalUnsafeUpdateEvent{ChainID: chainID}))
+	require.True(t, ix.OnEvent(superevents.ChainRewoundEvent{ChainID: chainID}))
+	require.False(t, ix.OnEvent(superevents.LocalUnsafeUpdateEvent{ChainID: eth.ChainIDFromUInt64(901)}))
+	require.False(t, ix.OnEvent(superevents.ChainRewoundEvent{ChainID: eth.ChainIDFromUInt64(901)}))
+}
@@ -10,10 +10,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
 )
 
 type MockBackend struct {
-	started atomic.Bool
+	started    atomic.Bool
+	superRoots gethevent.FeedOf[types.SuperRootRecord]
+	events     gethevent.FeedOf[types.SupervisorEvent]
 }
 
 var _ frontend.Backend = (*MockBackend)(nil)
@@ -51,6 +54,11 @@ func (m *MockBackend) CheckAccessList(ctx context.Context, inboxEntries []common
 	return nil
 }
 
+func (m *MockBackend) CheckAccessListAt(ctx context.Context, inboxEntries []common.Hash,
+	chainID eth.ChainID, blockNumber hexutil.Uint64) error {
+	return nil
+}
+
 func (m *MockBackend) LocalUnsafe(ctx context.Context, chainID eth.ChainID) (eth.BlockID, error) {
 	return eth.BlockID{}, nil
 }
@@ -79,14 +87,90 @@ func (m *MockBackend) SuperRootAtTimestamp(ctx context.Context, timestamp hexuti
 	return eth.SuperRootResponse{}, nil
 }
 
+func (m *MockBackend) SuperRootRecordAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (types.SuperRootRecord, error) {
+	return types.SuperRootRecord{}, nil
+}
+
+func (m *MockBackend) LatestSuperRootRecord(ctx context.Context) (types.SuperRootRecord, error) {
+	return types.SuperRootRecord{}, nil
+}
+
+func (m *MockBackend) SuperRootsFeed() *gethevent.FeedOf[types.SuperRootRecord] {
+	return &m.superRoots
+}
+
+func (m *MockBackend) EventsFeed() *gethevent.FeedOf[types.SupervisorEvent] {
+	return &m.events
+}
+
+func (m *MockBackend) Chains() []eth.ChainID {
+	return nil
+}
+
 func (m *MockBackend) SyncStatus(ctx context.Context) (eth.SupervisorSyncStatus, error) {
 	return eth.SupervisorSyncStatus{}, nil
 }
 
+func (m *MockBackend) CrossSafeConstraints(ctx context.Context) (map[eth.ChainID]types.CrossSafeConstraint, error) {
+	return map[eth.ChainID]types.CrossSafeConstraint{}, nil
+}
+
+func (m *MockBackend) CausalityGraph(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64) (*types.CausalityGraph, error) {
+	return &types.CausalityGraph{FromTimestamp: uint64(from), ToTimestamp: uint64(to)}, nil
+}
+
+func (m *MockBackend) LogProof(ctx context.Context, chainID eth.ChainID, blockNum hexutil.Uint64, logIdx uint32) (*types.LogProof, error) {
+	return &types.LogProof{ChainID: chainID}, nil
+}
+
+func (m *MockBackend) ExecutingMessages(ctx context.Context, checksum types.MessageChecksum) ([]types.LogLocation, error) {
+	return []types.LogLocation{}, nil
+}
+
+func (m *MockBackend) Snapshot(ctx context.Context) (types.SupervisorSnapshot, error) {
+	return types.SupervisorSnapshot{Chains: map[eth.ChainID]types.ChainSnapshot{}}, nil
+}
+
+func (m *MockBackend) ChecksumPreimage(ctx context.Context, checksum types.MessageChecksum) (types.ChecksumPreimage, error) {
+	return types.ChecksumPreimage{Checksum: checksum}, nil
+}
+
 func (m *MockBackend) Rewind(ctx context.Context, chain eth.ChainID, block eth.BlockID) error {
 	return nil
 }
 
+func (m *MockBackend) RebuildLogIndex(ctx context.Context, chain eth.ChainID) error {
+	return nil
+}
+
+func (m *MockBackend) SubmitAttestation(ctx context.Context, att types.SignedAttestation) (types.AttestationRecord, error) {
+	return types.AttestationRecord{}, nil
+}
+
+func (m *MockBackend) Attestations(ctx context.Context, chainID eth.ChainID, block eth.BlockID) ([]types.AttestationRecord, error) {
+	return []types.AttestationRecord{}, nil
+}
+
+func (m *MockBackend) CircuitBreakerStatus(ctx context.Context) ([]types.CircuitBreakerStatus, error) {
+	return []types.CircuitBreakerStatus{}, nil
+}
+
+func (m *MockBackend) AcknowledgeCircuitBreaker(ctx context.Context, chain eth.ChainID) error {
+	return nil
+}
+
+func (m *MockBackend) PinL1(ctx context.Context, blockHash common.Hash) (eth.L1BlockRef, error) {
+	return eth.L1BlockRef{}, nil
+}
+
+func (m *MockBackend) UnpinL1(ctx context.Context) error {
+	return nil
+}
+
+func (m *MockBackend) L1PinStatus(ctx context.Context) (types.L1PinStatus, error) {
+	return types.L1PinStatus{}, nil
+}
+
 func (m *MockBackend) Close() error {
 	return nil
 }
@@ -35,6 +35,12 @@ type DatabaseRewinder interface {
 	AcceptedBlock(chainID eth.ChainID, id eth.BlockID) error
 }
 
+// Leadership reports the sequencer leadership of a chain.
+// During the failover window of a leadership change, unsafe forks are expected and tolerated.
+type Leadership interface {
+	Failover(chainID eth.ChainID) (leader string, inFailover bool)
+}
+
 type BlockProcessorFn func(ctx context.Context, block eth.BlockRef) error
 
 func (fn BlockProcessorFn) ProcessBlock(ctx context.Context, block eth.BlockRef) error {
@@ -64,6 +70,9 @@ type ChainProcessor struct {
 
 	emitter event.Emitter
 
+	// leadership is the sequencer leadership of the chain, optional
+	leadership Leadership
+
 	maxFetcherThreads int
 }
 
@@ -88,6 +97,20 @@ func (s *ChainProcessor) AttachEmitter(em event.Emitter) {
 	s.emitter = em
 }
 
+// SetLeadership sets the sequencer leadership of the chain,
+// to tolerate unsafe forks during sequencer failovers and tag the indexed blocks with the sequencer.
+func (s *ChainProcessor) SetLeadership(l Leadership) {
+	s.leadership = l
+}
+
+// failover returns the sequencer leader of the chain, and whether the chain is in a sequencer failover window.
+func (s *ChainProcessor) failover() (leader string, inFailover bool) {
+	if s.leadership == nil {
+		return "", false
+	}
+	return s.leadership.Failover(s.chain)
+}
+
 func (s *ChainProcessor) AddSource(cl Source) {
 	s.clientLock.Lock()
 	defer s.clientLock.Unlock()
@@ -162,6 +185,8 @@ func (s *ChainProcessor) index() {
 			s.log.Debug("indexer cannot find next block yet", "target", target, "err", err)
 		} else if errors.Is(err, types.ErrNoRPCSource) {
 			s.log.Warn("No RPC source configured, cannot process new blocks")
+		} else if leader, ok := s.failover(); ok && errors.Is(err, types.ErrConflict) {
+			s.log.Info("Tolerating unsafe fork during sequencer failover", "sequencer", leader, "err", err)
 		} else {
 			s.log.Error("Failed to index blocks", "err", err)
 		}
@@ -268,7 +293,11 @@ func (s *ChainProcessor) rangeUpdate(target uint64) (int, error) {
 			return
 		}
 		if err := s.rewinder.AcceptedBlock(s.chain, next.ID()); err != nil {
-			s.log.Warn("Cannot accept next block into events DB", "next", next.ID(), "err", err)
+			if leader, ok := s.failover(); ok {
+				s.log.Info("Cannot accept next block into events DB during sequencer failover", "next", next.ID(), "sequencer", leader, "err", err)
+			} else {
+				s.log.Warn("Cannot accept next block into events DB", "next", next.ID(), "err", err)
+			}
 			result.err = err
 			return
 		}
@@ -325,8 +354,14 @@ func (s *ChainProcessor) rangeUpdate(target uint64) (int, error) {
 }
 
 func (s *ChainProcessor) process(ctx context.Context, next eth.BlockRef, receipts gethtypes.Receipts) error {
+	leader, inFailover := s.failover()
 	if err := s.processor.ProcessLogs(ctx, next, receipts); err != nil {
-		s.log.Error("Failed to process block", "block", next, "err", err)
+		if inFailover && errors.Is(err, types.ErrConflict) {
+			// the new sequencer may not have built on the last unsafe block of the previous sequencer
+			s.log.Info("Unsafe fork during sequencer failover", "block", next, "sequencer", leader, "err", err)
+		} else {
+			s.log.Error("Failed to process block", "block", next, "err", err)
+		}
 
 		if next.Number == 0 { // cannot rewind genesis
 			return nil
@@ -342,6 +377,10 @@ func (s *ChainProcessor) process(ctx context.Context, next eth.BlockRef, receipt
 		}
 		return err
 	}
-	s.log.Info("Indexed block events", "block", next, "txs", len(receipts))
+	if leader != "" {
+		s.log.Info("Indexed block events", "block", next, "txs", len(receipts), "sequencer", leader)
+	} else {
+		s.log.Info("Indexed block events", "block", next, "txs", len(receipts))
+	}
 	return nil
 }
@@ -0,0 +1,154 @@
+package proofs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// ChainsDB is the subset of the chains database that log proofs are built from.
+type ChainsDB interface {
+	CrossSafe(chainID eth.ChainID) (types.DerivedBlockSealPair, error)
+	FirstSealedBlock(chain eth.ChainID) (types.BlockSeal, error)
+	IteratorStartingAt(chain eth.ChainID, sealedNum uint64, logIndex uint32) (logs.Iterator, error)
+}
+
+// blockLogs is a block of the blocks tree, with the log hashes of the block.
+type blockLogs struct {
+	seal      types.BlockSeal
+	logHashes []common.Hash
+}
+
+func (b *blockLogs) leaves() []common.Hash {
+	out := make([]common.Hash, len(b.logHashes))
+	for i, h := range b.logHashes {
+		out[i] = types.LogLeaf(h)
+	}
+	return out
+}
+
+func (b *blockLogs) leaf() common.Hash {
+	return types.BlockLeaf(b.seal, uint32(len(b.logHashes)), types.MerkleRoot(b.leaves()))
+}
+
+// Build returns the proof of the log with the given index in the given block, bound to the current cross-safe head.
+//
+// The blocks tree starts at the block number of the log rounded down to a multiple of segment,
+// so proofs of logs of nearby blocks share the same blocks root for the same cross-safe head.
+// Logs of blocks that are not cross-safe yet, or that would need a blocks tree of more than maxBlocks blocks,
+// are not proven.
+func Build(db ChainsDB, chainID eth.ChainID, blockNum uint64, logIdx uint32, segment uint64, maxBlocks uint64) (*types.LogProof, error) {
+	crossSafe, err := db.CrossSafe(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cross-safe head: %w", err)
+	}
+	head := crossSafe.Derived
+	if blockNum > head.Number {
+		return nil, fmt.Errorf("block %d is not cross-safe yet, cross-safe head is %d: %w", blockNum, head.Number, types.ErrFuture)
+	}
+	dbFirst, err := db.FirstSealedBlock(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get first block: %w", err)
+	}
+	if blockNum < dbFirst.Number {
+		return nil, fmt.Errorf("block %d is before the first block %d: %w", blockNum, dbFirst.Number, types.ErrSkipped)
+	}
+	first := max(blockNum-blockNum%segment, dbFirst.Number)
+	if head.Number-first+1 > maxBlocks {
+		return nil, fmt.Errorf("block %d is too far behind the cross-safe head %d to prove, at most %d blocks are proven: %w",
+			blockNum, head.Number, maxBlocks, types.ErrSkipped)
+	}
+
+	blocks, err := readBlocks(db, chainID, first, dbFirst, head.Number)
+	if err != nil {
+		return nil, err
+	}
+	if last := blocks[len(blocks)-1].seal; last.Hash != head.Hash {
+		return nil, fmt.Errorf("cross-safe head %s does not match block %s of the events DB: %w", head, last, types.ErrConflict)
+	}
+	target := &blocks[blockNum-first]
+	if logIdx >= uint32(len(target.logHashes)) {
+		return nil, fmt.Errorf("block %d has %d logs, log %d does not exist: %w", blockNum, len(target.logHashes), logIdx, types.ErrConflict)
+	}
+	leaves := make([]common.Hash, len(blocks))
+	for i := range blocks {
+		leaves[i] = blocks[i].leaf()
+	}
+	headBlock := &blocks[len(blocks)-1]
+	logLeaves := target.leaves()
+	headLeaves := headBlock.leaves()
+	return &types.LogProof{
+		ChainID:           chainID,
+		Block:             target.seal,
+		LogIndex:          logIdx,
+		LogHash:           target.logHashes[logIdx],
+		LogCount:          uint32(len(target.logHashes)),
+		LogsRoot:          types.MerkleRoot(logLeaves),
+		LogPath:           types.MerkleProof(logLeaves, int(logIdx)),
+		FirstBlock:        first,
+		BlocksRoot:        types.MerkleRoot(leaves),
+		BlockPath:         types.MerkleProof(leaves, int(blockNum-first)),
+		CrossSafe:         headBlock.seal,
+		CrossSafeLogCount: uint32(len(headBlock.logHashes)),
+		CrossSafeLogsRoot: types.MerkleRoot(headLeaves),
+		CrossSafePath:     types.MerkleProof(leaves, len(leaves)-1),
+	}, nil
+}
+
+// readBlocks reads the blocks in [first, last] with their log hashes from the events DB.
+// The logs of a block are stored after the seal of its parent, so the first block of the DB has no logs.
+func readBlocks(db ChainsDB, chainID eth.ChainID, first uint64, dbFirst types.BlockSeal, last uint64) ([]blockLogs, error) {
+	blocks := make([]blockLogs, 0, last-first+1)
+	start := first - 1
+	if first == dbFirst.Number {
+		blocks = append(blocks, blockLogs{seal: dbFirst})
+		start = first
+	}
+	if start == last {
+		return blocks, nil
+	}
+	iter, err := db.IteratorStartingAt(chainID, start, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events DB from block %d: %w", start, err)
+	}
+	var logHashes []common.Hash
+	lastSealed := start
+	err = iter.TraverseConditional(func(state logs.IteratorState) error {
+		// The same log may be seen again after a search checkpoint within the block.
+		if h, idx, ok := state.InitMessage(); ok && idx == uint32(len(logHashes)) {
+			logHashes = append(logHashes, h)
+		}
+		h, n, ok := state.SealedBlock()
+		if !ok || n <= lastSealed {
+			return nil
+		}
+		timestamp, _ := state.SealedTimestamp()
+		blocks = append(blocks, blockLogs{
+			seal:      types.BlockSeal{Hash: h, Number: n, Timestamp: timestamp},
+			logHashes: logHashes,
+		})
+		logHashes = nil
+		lastSealed = n
+		if n >= last {
+			return types.ErrStop
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, types.ErrStop) {
+		return nil, fmt.Errorf("failed to read events DB up to block %d: %w", last, err)
+	}
+	if got := uint64(len(blocks)); got != last-first+1 {
+		return nil, fmt.Errorf("read %d blocks from the events DB, expected %d: %w", got, last-first+1, types.ErrDataCorruption)
+	}
+	for i := range blocks {
+		if blocks[i].seal.Number != first+uint64(i) {
+			return nil, fmt.Errorf("expected block %d, read block %d: %w", first+uint64(i), blocks[i].seal.Number, types.ErrDataCorruption)
+		}
+	}
+	return blocks, nil
+}
@@ -0,0 +1,169 @@
+package proofs
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubMetrics struct{}
+
+func (s *stubMetrics) RecordDBEntryCount(kind string, count int64) {}
+
+func (s *stubMetrics) RecordDBSearchEntriesRead(count int64) {}
+
+var (
+	chainID = eth.ChainIDFromUInt64(900)
+	origin  = common.Address{0xee}
+)
+
+type stubChainsDB struct {
+	logDB     *logs.DB
+	crossSafe types.BlockSeal
+}
+
+var _ ChainsDB = (*stubChainsDB)(nil)
+
+func (s *stubChainsDB) CrossSafe(chain eth.ChainID) (types.DerivedBlockSealPair, error) {
+	if chain != chainID {
+		return types.DerivedBlockSealPair{}, types.ErrUnknownChain
+	}
+	return types.DerivedBlockSealPair{Derived: s.crossSafe}, nil
+}
+
+func (s *stubChainsDB) FirstSealedBlock(chain eth.ChainID) (types.BlockSeal, error) {
+	if chain != chainID {
+		return types.BlockSeal{}, types.ErrUnknownChain
+	}
+	return s.logDB.FirstSealedBlock()
+}
+
+func (s *stubChainsDB) IteratorStartingAt(chain eth.ChainID, sealedNum uint64, logIndex uint32) (logs.Iterator, error) {
+	if chain != chainID {
+		return nil, types.ErrUnknownChain
+	}
+	return s.logDB.IteratorStartingAt(sealedNum, logIndex)
+}
+
+func mockBlock(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0xbb, byte(num >> 8), byte(num)}, Number: num}
+}
+
+func mockPayloadHash(num uint64, logIdx uint32) common.Hash {
+	return common.Hash{0xaa, byte(num >> 8), byte(num), byte(logIdx)}
+}
+
+// setup creates an events DB with the blocks [first, last], where block n has n%8 logs,
+// except for the first block which has no logs.
+func setup(t *testing.T, first uint64, last uint64) *stubChainsDB {
+	logDB, err := logs.NewFromFile(testlog.Logger(t, log.LevelInfo), &stubMetrics{}, chainID,
+		filepath.Join(t.TempDir(), "log.db"), true)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logDB.Close() })
+
+	require.NoError(t, logDB.SealBlock(common.Hash{}, mockBlock(first), first*2))
+	for n := first + 1; n <= last; n++ {
+		parent := mockBlock(n - 1)
+		for i := uint32(0); i < uint32(n%8); i++ {
+			logHash := types.PayloadHashToLogHash(mockPayloadHash(n, i), origin)
+			require.NoError(t, logDB.AddLog(logHash, parent, i, nil))
+		}
+		require.NoError(t, logDB.SealBlock(parent.Hash, mockBlock(n), n*2))
+	}
+	head := mockBlock(last)
+	return &stubChainsDB{logDB: logDB, crossSafe: types.BlockSeal{Hash: head.Hash, Number: head.Number, Timestamp: last * 2}}
+}
+
+func message(num uint64, logIdx uint32) types.Message {
+	return types.Message{
+		Identifier: types.Identifier{
+			Origin:      origin,
+			BlockNumber: num,
+			LogIndex:    logIdx,
+			Timestamp:   num * 2,
+			ChainID:     chainID,
+		},
+		PayloadHash: mockPayloadHash(num, logIdx),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	db := setup(t, 250, 300)
+
+	t.Run("proves logs of every block", func(t *testing.T) {
+		for n := uint64(251); n <= 300; n++ {
+			for i := uint32(0); i < uint32(n%8); i++ {
+				proof, err := Build(db, chainID, n, i, 256, 4096)
+				require.NoError(t, err)
+				require.NoError(t, proof.VerifyMessage(message(n, i)), "log %d of block %d", i, n)
+				require.Equal(t, db.crossSafe, proof.CrossSafe)
+				require.Equal(t, uint64(max(n-n%256, 250)), proof.FirstBlock)
+			}
+		}
+	})
+
+	t.Run("shared blocks root within a segment", func(t *testing.T) {
+		a, err := Build(db, chainID, 257, 0, 256, 4096)
+		require.NoError(t, err)
+		b, err := Build(db, chainID, 299, 2, 256, 4096)
+		require.NoError(t, err)
+		require.Equal(t, a.BlocksRoot, b.BlocksRoot)
+		c, err := Build(db, chainID, 255, 0, 256, 4096)
+		require.NoError(t, err)
+		require.NotEqual(t, a.BlocksRoot, c.BlocksRoot)
+	})
+
+	t.Run("bound to the cross-safe head", func(t *testing.T) {
+		db := *db
+		head := mockBlock(280)
+		db.crossSafe = types.BlockSeal{Hash: head.Hash, Number: head.Number, Timestamp: 560}
+		proof, err := Build(&db, chainID, 270, 3, 256, 4096)
+		require.NoError(t, err)
+		require.NoError(t, proof.VerifyMessage(message(270, 3)))
+		require.Equal(t, uint64(280), proof.CrossSafe.Number)
+
+		_, err = Build(&db, chainID, 281, 0, 256, 4096)
+		require.ErrorIs(t, err, types.ErrFuture)
+
+		db.crossSafe.Hash = common.Hash{0xff}
+		_, err = Build(&db, chainID, 270, 3, 256, 4096)
+		require.ErrorIs(t, err, types.ErrConflict)
+	})
+
+	t.Run("does not prove the wrong message", func(t *testing.T) {
+		proof, err := Build(db, chainID, 262, 1, 256, 4096)
+		require.NoError(t, err)
+		require.ErrorIs(t, proof.VerifyMessage(message(262, 0)), types.ErrInvalidProof)
+		msg := message(262, 1)
+		msg.PayloadHash = common.Hash{0x01}
+		require.ErrorIs(t, proof.VerifyMessage(msg), types.ErrInvalidProof)
+	})
+
+	t.Run("missing logs", func(t *testing.T) {
+		_, err := Build(db, chainID, 250, 0, 256, 4096)
+		require.ErrorIs(t, err, types.ErrConflict)
+		_, err = Build(db, chainID, 264, 0, 256, 4096)
+		require.ErrorIs(t, err, types.ErrConflict)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := Build(db, chainID, 249, 0, 256, 4096)
+		require.ErrorIs(t, err, types.ErrSkipped)
+		_, err = Build(db, chainID, 257, 0, 256, 40)
+		require.ErrorIs(t, err, types.ErrSkipped)
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		_, err := Build(db, eth.ChainIDFromUInt64(901), 260, 0, 256, 4096)
+		require.ErrorIs(t, err, types.ErrUnknownChain)
+	})
+}
@@ -3,6 +3,8 @@ package status
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -18,9 +20,19 @@ var (
 	ErrMinSyncedL1Mismatch   = errors.New("min synced L1 mismatch")
 )
 
+type Metrics interface {
+	RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool)
+}
+
 type StatusTracker struct {
 	statuses map[eth.ChainID]*NodeSyncStatus
-	mu       sync.RWMutex
+	// sequencers is the sequencer leadership of the chains that are tracked through op-conductor
+	sequencers map[eth.ChainID]eth.SupervisorSequencerStatus
+	// nodes is the managed-mode protocol of the managed nodes of each chain, by endpoint
+	nodes map[eth.ChainID]map[string]types.ManagedProtocol
+	mu    sync.RWMutex
+
+	m Metrics
 }
 
 type NodeSyncStatus struct {
@@ -32,13 +44,16 @@ type NodeSyncStatus struct {
 	Finalized   types.BlockSeal
 }
 
-func NewStatusTracker(chains []eth.ChainID) *StatusTracker {
+func NewStatusTracker(chains []eth.ChainID, m Metrics) *StatusTracker {
 	statuses := make(map[eth.ChainID]*NodeSyncStatus)
 	for _, chain := range chains {
 		statuses[chain] = new(NodeSyncStatus)
 	}
 	return &StatusTracker{
-		statuses: statuses,
+		statuses:   statuses,
+		sequencers: make(map[eth.ChainID]eth.SupervisorSequencerStatus),
+		nodes:      make(map[eth.ChainID]map[string]types.ManagedProtocol),
+		m:          m,
 	}
 }
 
@@ -64,6 +79,7 @@ func (su *StatusTracker) OnEvent(ev event.Event) bool {
 	case superevents.LocalSafeUpdateEvent:
 		status := loadStatusRef(x.ChainID)
 		status.LocalSafe = x.NewLocalSafe.Derived
+		su.recordDependencyLags()
 	case superevents.CrossUnsafeUpdateEvent:
 		status := loadStatusRef(x.ChainID)
 		status.CrossUnsafe = x.NewCrossUnsafe
@@ -73,6 +89,13 @@ func (su *StatusTracker) OnEvent(ev event.Event) bool {
 	case superevents.FinalizedL2UpdateEvent:
 		status := loadStatusRef(x.ChainID)
 		status.Finalized = x.FinalizedL2
+	case superevents.SequencerLeaderChangedEvent:
+		su.sequencers[x.ChainID] = x.Sequencer
+	case superevents.ManagedNodeProtocolEvent:
+		if su.nodes[x.ChainID] == nil {
+			su.nodes[x.ChainID] = make(map[string]types.ManagedProtocol)
+		}
+		su.nodes[x.ChainID][x.Endpoint] = x.Protocol
 	case superevents.FinalizedL1UpdateEvent:
 		log.Debug("Updated finalized L1", "finalizedL1", x.FinalizedL1)
 	default:
@@ -137,7 +160,122 @@ func (su *StatusTracker) SyncStatus() (eth.SupervisorSyncStatus, error) {
 			CrossSafe:   nodeStatus.CrossSafe.ID(),
 			Finalized:   nodeStatus.Finalized.ID(),
 		}
+		if seq, ok := su.sequencers[chainID]; ok {
+			supervisorStatus.Chains[chainID].Sequencer = &seq
+		}
+		supervisorStatus.Chains[chainID].Nodes = nodeStatuses(su.nodes[chainID])
 		firstChain = false
 	}
 	return supervisorStatus, nil
 }
+
+// nodeStatuses returns the managed-mode protocols of the nodes of a chain, ordered by endpoint.
+func nodeStatuses(nodes map[string]types.ManagedProtocol) []eth.SupervisorManagedNodeStatus {
+	var out []eth.SupervisorManagedNodeStatus
+	for endpoint, protocol := range nodes {
+		status := eth.SupervisorManagedNodeStatus{
+			Endpoint:          endpoint,
+			ProtocolVersion:   protocol.Version,
+			Capabilities:      make([]string, 0, len(protocol.Capabilities)),
+			DependencySetHash: protocol.DependencySetHash,
+		}
+		for _, c := range protocol.Capabilities {
+			status.Capabilities = append(status.Capabilities, string(c))
+		}
+		for _, c := range types.ManagedCapabilities {
+			if !protocol.Supports(c) {
+				status.MissingCapabilities = append(status.MissingCapabilities, string(c))
+			}
+		}
+		out = append(out, status)
+	}
+	slices.SortFunc(out, func(a, b eth.SupervisorManagedNodeStatus) int {
+		return strings.Compare(a.Endpoint, b.Endpoint)
+	})
+	return out
+}
+
+// CrossSafeConstraints returns, for each chain with a known local-safe block,
+// which dependency is the binding constraint on its cross-safe progress, and by how much.
+func (su *StatusTracker) CrossSafeConstraints() (map[eth.ChainID]types.CrossSafeConstraint, error) {
+	su.mu.RLock()
+	defer su.mu.RUnlock()
+
+	if !su.HasInitializedStatuses() {
+		return nil, ErrStatusTrackerNotReady
+	}
+	return crossSafeConstraints(su.statuses), nil
+}
+
+// ChainProgress returns the heads and L1 origin of every chain with a known status.
+// The number of pending messages is not tracked here, and is left zero.
+func (su *StatusTracker) ChainProgress() map[eth.ChainID]types.ChainProgress {
+	su.mu.RLock()
+	defer su.mu.RUnlock()
+
+	out := make(map[eth.ChainID]types.ChainProgress)
+	for chainID, status := range su.statuses {
+		if status == nil || *status == (NodeSyncStatus{}) {
+			continue
+		}
+		out[chainID] = types.ChainProgress{
+			LocalUnsafe: status.LocalUnsafe.Time,
+			CrossUnsafe: status.CrossUnsafe.Timestamp,
+			LocalSafe:   status.LocalSafe.Timestamp,
+			CrossSafe:   status.CrossSafe.Timestamp,
+			Finalized:   status.Finalized.Timestamp,
+			L1Origin:    status.CurrentL1.Time,
+		}
+	}
+	return out
+}
+
+// recordDependencyLags records the lag of every chain-pair. The caller must hold the lock.
+func (su *StatusTracker) recordDependencyLags() {
+	if su.m == nil {
+		return
+	}
+	for chainID, constraint := range crossSafeConstraints(su.statuses) {
+		for dep := range su.statuses {
+			if dep == chainID {
+				continue
+			}
+			binding := constraint.BindingDependency != nil && *constraint.BindingDependency == dep
+			su.m.RecordDependencyLag(chainID, dep, constraint.DependencyLags[dep], binding)
+		}
+	}
+}
+
+// crossSafeConstraints computes the cross-safe constraint of each chain.
+// Every other chain is considered a dependency, as chains in the dependency set may all message each other.
+// Chains without a known local-safe block are skipped, both as constrained chain and as dependency.
+func crossSafeConstraints(statuses map[eth.ChainID]*NodeSyncStatus) map[eth.ChainID]types.CrossSafeConstraint {
+	out := make(map[eth.ChainID]types.CrossSafeConstraint)
+	for chainID, status := range statuses {
+		if status == nil || status.LocalSafe == (types.BlockSeal{}) {
+			continue
+		}
+		constraint := types.CrossSafeConstraint{
+			LocalSafe:      status.LocalSafe,
+			CrossSafe:      status.CrossSafe,
+			DependencyLags: make(map[eth.ChainID]uint64),
+		}
+		for depID, dep := range statuses {
+			if depID == chainID || dep == nil || dep.LocalSafe == (types.BlockSeal{}) {
+				continue
+			}
+			if dep.LocalSafe.Timestamp >= status.LocalSafe.Timestamp {
+				continue
+			}
+			lag := status.LocalSafe.Timestamp - dep.LocalSafe.Timestamp
+			constraint.DependencyLags[depID] = lag
+			// ties are broken by chain ID, to be deterministic
+			if lag > constraint.Lag || (lag == constraint.Lag && depID.Cmp(*constraint.BindingDependency) < 0) {
+				constraint.BindingDependency = &depID
+				constraint.Lag = lag
+			}
+		}
+		out[chainID] = constraint
+	}
+	return out
+}
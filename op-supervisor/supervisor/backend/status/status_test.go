@@ -12,7 +12,7 @@ import (
 
 func TestInitialSyncStatus(t *testing.T) {
 	chains := []eth.ChainID{eth.ChainIDFromUInt64(1), eth.ChainIDFromUInt64(2)}
-	tracker := NewStatusTracker(chains)
+	tracker := NewStatusTracker(chains, nil)
 	_, err := tracker.SyncStatus()
 	require.Error(t, ErrStatusTrackerNotReady, err)
 }
@@ -21,7 +21,7 @@ func TestUpdateMinSyncedL1(t *testing.T) {
 	chain1 := eth.ChainIDFromUInt64(1)
 	chain2 := eth.ChainIDFromUInt64(2)
 	chains := []eth.ChainID{chain1, chain2}
-	tracker := NewStatusTracker(chains)
+	tracker := NewStatusTracker(chains, nil)
 	minL1 := eth.BlockRef{Number: 204, Hash: common.Hash{0xaa}}
 	tracker.OnEvent(superevents.LocalDerivedOriginUpdateEvent{
 		ChainID: chain1,
@@ -40,7 +40,7 @@ func TestUpdateLocalUnsafe(t *testing.T) {
 	chain1 := eth.ChainIDFromUInt64(1)
 	chain2 := eth.ChainIDFromUInt64(2)
 	chains := []eth.ChainID{chain1, chain2}
-	tracker := NewStatusTracker(chains)
+	tracker := NewStatusTracker(chains, nil)
 	chain1Unsafe := eth.BlockRef{Number: 204, Hash: common.Hash{0xaa}}
 	chain2Unsafe := eth.BlockRef{Number: 228, Hash: common.Hash{0xbb}}
 	tracker.OnEvent(superevents.LocalUnsafeUpdateEvent{
@@ -57,11 +57,73 @@ func TestUpdateLocalUnsafe(t *testing.T) {
 	require.Equal(t, chain2Unsafe, status.Chains[chain2].LocalUnsafe)
 }
 
+func TestSequencerLeadership(t *testing.T) {
+	chain1 := eth.ChainIDFromUInt64(1)
+	chain2 := eth.ChainIDFromUInt64(2)
+	chains := []eth.ChainID{chain1, chain2}
+	tracker := NewStatusTracker(chains, nil)
+	for _, chain := range chains {
+		tracker.OnEvent(superevents.LocalUnsafeUpdateEvent{
+			ChainID:        chain,
+			NewLocalUnsafe: eth.BlockRef{Number: 1},
+		})
+	}
+	seq := eth.SupervisorSequencerStatus{LeaderID: "seq-b", LeaderAddr: "seq-b:50050", ChangedAt: 1000, FailoverUntil: 1030}
+	require.True(t, tracker.OnEvent(superevents.SequencerLeaderChangedEvent{
+		ChainID:   chain1,
+		Sequencer: seq,
+	}))
+	status, err := tracker.SyncStatus()
+	require.NoError(t, err)
+	require.Equal(t, &seq, status.Chains[chain1].Sequencer)
+	require.Nil(t, status.Chains[chain2].Sequencer, "leadership of chain is not tracked")
+}
+
+func TestManagedNodeProtocols(t *testing.T) {
+	chain1 := eth.ChainIDFromUInt64(1)
+	chain2 := eth.ChainIDFromUInt64(2)
+	chains := []eth.ChainID{chain1, chain2}
+	tracker := NewStatusTracker(chains, nil)
+	for _, chain := range chains {
+		tracker.OnEvent(superevents.LocalUnsafeUpdateEvent{
+			ChainID:        chain,
+			NewLocalUnsafe: eth.BlockRef{Number: 1},
+		})
+	}
+	require.True(t, tracker.OnEvent(superevents.ManagedNodeProtocolEvent{
+		ChainID:  chain1,
+		Endpoint: "ws://node-b",
+		Protocol: types.ManagedProtocol{Version: 1, Capabilities: []types.ManagedCapability{types.CapabilityResetPreInterop}},
+	}))
+	require.True(t, tracker.OnEvent(superevents.ManagedNodeProtocolEvent{
+		ChainID:  chain1,
+		Endpoint: "ws://node-a",
+		Protocol: types.LegacyManagedProtocol,
+	}))
+	status, err := tracker.SyncStatus()
+	require.NoError(t, err)
+	require.Equal(t, []eth.SupervisorManagedNodeStatus{
+		{
+			Endpoint:            "ws://node-a",
+			ProtocolVersion:     0,
+			Capabilities:        []string{"events-subscription", "reset-pre-interop"},
+			MissingCapabilities: []string{"replay-derivation"},
+		},
+		{
+			Endpoint:            "ws://node-b",
+			ProtocolVersion:     1,
+			Capabilities:        []string{"reset-pre-interop"},
+			MissingCapabilities: []string{"events-subscription", "replay-derivation"},
+		},
+	}, status.Chains[chain1].Nodes)
+	require.Empty(t, status.Chains[chain2].Nodes)
+}
+
 func TestUpdateCrossSafe(t *testing.T) {
 	chain1 := eth.ChainIDFromUInt64(1)
 	chain2 := eth.ChainIDFromUInt64(2)
 	chains := []eth.ChainID{chain1, chain2}
-	tracker := NewStatusTracker(chains)
+	tracker := NewStatusTracker(chains, nil)
 	chain1Safe := types.DerivedBlockSealPair{
 		Derived: types.BlockSeal{
 			Number:    204,
@@ -95,7 +157,7 @@ func TestUpdateFinalized(t *testing.T) {
 	chain1 := eth.ChainIDFromUInt64(1)
 	chain2 := eth.ChainIDFromUInt64(2)
 	chains := []eth.ChainID{chain1, chain2}
-	tracker := NewStatusTracker(chains)
+	tracker := NewStatusTracker(chains, nil)
 	chain1Finalized := types.BlockSeal{
 		Number:    204,
 		Hash:      common.Hash{0xaa},
@@ -120,3 +182,127 @@ func TestUpdateFinalized(t *testing.T) {
 	require.Equal(t, chain1Finalized.ID(), status.Chains[chain1].Finalized)
 	require.Equal(t, chain2Finalized.ID(), status.Chains[chain2].Finalized)
 }
+
+type lagRecord struct {
+	lag     uint64
+	binding bool
+}
+
+type testLagMetrics map[[2]eth.ChainID]lagRecord
+
+func (m testLagMetrics) RecordDependencyLag(chainID eth.ChainID, dependency eth.ChainID, lag uint64, binding bool) {
+	m[[2]eth.ChainID{chainID, dependency}] = lagRecord{lag: lag, binding: binding}
+}
+
+func TestCrossSafeConstraints(t *testing.T) {
+	chain1 := eth.ChainIDFromUInt64(1)
+	chain2 := eth.ChainIDFromUInt64(2)
+	chain3 := eth.ChainIDFromUInt64(3)
+	chain4 := eth.ChainIDFromUInt64(4)
+	chains := []eth.ChainID{chain1, chain2, chain3, chain4}
+	m := make(testLagMetrics)
+	tracker := NewStatusTracker(chains, m)
+
+	_, err := tracker.CrossSafeConstraints()
+	require.ErrorIs(t, err, ErrStatusTrackerNotReady)
+
+	localSafe := func(chainID eth.ChainID, timestamp uint64) types.BlockSeal {
+		seal := types.BlockSeal{Number: timestamp / 2, Hash: common.Hash{byte(timestamp)}, Timestamp: timestamp}
+		tracker.OnEvent(superevents.LocalSafeUpdateEvent{
+			ChainID:      chainID,
+			NewLocalSafe: types.DerivedBlockSealPair{Derived: seal},
+		})
+		return seal
+	}
+	chain1Safe := localSafe(chain1, 120)
+	localSafe(chain2, 100)
+	localSafe(chain3, 110)
+	// chain4 has no local-safe block yet, and is not considered
+	crossSafe := types.BlockSeal{Number: 49, Hash: common.Hash{0x49}, Timestamp: 98}
+	tracker.OnEvent(superevents.CrossSafeUpdateEvent{
+		ChainID:      chain1,
+		NewCrossSafe: types.DerivedBlockSealPair{Derived: crossSafe},
+	})
+
+	constraints, err := tracker.CrossSafeConstraints()
+	require.NoError(t, err)
+	require.Len(t, constraints, 3)
+
+	// chain2 is the slowest, and binds both chain1 and chain3
+	require.Equal(t, types.CrossSafeConstraint{
+		LocalSafe:         chain1Safe,
+		CrossSafe:         crossSafe,
+		BindingDependency: &chain2,
+		Lag:               20,
+		DependencyLags:    map[eth.ChainID]uint64{chain2: 20, chain3: 10},
+	}, constraints[chain1])
+	require.Equal(t, &chain2, constraints[chain3].BindingDependency)
+	require.Equal(t, uint64(10), constraints[chain3].Lag)
+
+	// chain2 is only bound by its own progress
+	require.Nil(t, constraints[chain2].BindingDependency)
+	require.Zero(t, constraints[chain2].Lag)
+	require.Empty(t, constraints[chain2].DependencyLags)
+
+	require.Equal(t, lagRecord{lag: 20, binding: true}, m[[2]eth.ChainID{chain1, chain2}])
+	require.Equal(t, lagRecord{lag: 10, binding: false}, m[[2]eth.ChainID{chain1, chain3}])
+	require.Equal(t, lagRecord{lag: 0, binding: false}, m[[2]eth.ChainID{chain1, chain4}])
+	require.Equal(t, lagRecord{lag: 0, binding: false}, m[[2]eth.ChainID{chain2, chain1}])
+
+	// once chain2 catches up, chain3 becomes the binding constraint of chain1
+	localSafe(chain2, 130)
+	constraints, err = tracker.CrossSafeConstraints()
+	require.NoError(t, err)
+	require.Equal(t, &chain3, constraints[chain1].BindingDependency)
+	require.Equal(t, uint64(10), constraints[chain1].Lag)
+	require.Equal(t, lagRecord{lag: 0, binding: false}, m[[2]eth.ChainID{chain1, chain2}])
+	require.Equal(t, lagRecord{lag: 10, binding: true}, m[[2]eth.ChainID{chain1, chain3}])
+	require.Equal(t, lagRecord{lag: 20, binding: true}, m[[2]eth.ChainID{chain2, chain3}])
+}
+
+func TestCrossSafeConstraintsTie(t *testing.T) {
+	chain1 := eth.ChainIDFromUInt64(1)
+	chain2 := eth.ChainIDFromUInt64(2)
+	chain3 := eth.ChainIDFromUInt64(3)
+	tracker := NewStatusTracker([]eth.ChainID{chain1, chain2, chain3}, nil)
+	for chainID, timestamp := range map[eth.ChainID]uint64{chain1: 100, chain2: 100, chain3: 120} {
+		tracker.OnEvent(superevents.LocalSafeUpdateEvent{
+			ChainID:      chainID,
+			NewLocalSafe: types.DerivedBlockSealPair{Derived: types.BlockSeal{Number: 1, Timestamp: timestamp}},
+		})
+	}
+	constraints, err := tracker.CrossSafeConstraints()
+	require.NoError(t, err)
+	require.Equal(t, &chain1, constraints[chain3].BindingDependency, "ties are broken by the lowest chain ID")
+	require.Equal(t, uint64(20), constraints[chain3].Lag)
+}
+
+func TestChainProgress(t *testing.T) {
+	chain1 := eth.ChainIDFromUInt64(1)
+	chain2 := eth.ChainIDFromUInt64(2)
+	tracker := NewStatusTracker([]eth.ChainID{chain1, chain2}, nil)
+	require.Empty(t, tracker.ChainProgress())
+
+	tracker.OnEvent(superevents.LocalDerivedOriginUpdateEvent{
+		ChainID: chain1,
+		Origin:  eth.BlockRef{Number: 10, Time: 1000},
+	})
+	tracker.OnEvent(superevents.LocalUnsafeUpdateEvent{
+		ChainID:        chain1,
+		NewLocalUnsafe: eth.BlockRef{Number: 204, Time: 2040},
+	})
+	tracker.OnEvent(superevents.CrossUnsafeUpdateEvent{
+		ChainID:        chain1,
+		NewCrossUnsafe: types.BlockSeal{Number: 200, Timestamp: 2000},
+	})
+	tracker.OnEvent(superevents.CrossSafeUpdateEvent{
+		ChainID: chain1,
+		NewCrossSafe: types.DerivedBlockSealPair{
+			Derived: types.BlockSeal{Number: 100, Timestamp: 1000},
+		},
+	})
+	progress := tracker.ChainProgress()
+	require.Equal(t, map[eth.ChainID]types.ChainProgress{
+		chain1: {LocalUnsafe: 2040, CrossUnsafe: 2000, CrossSafe: 1000, L1Origin: 1000},
+	}, progress, "chains without status are omitted")
+}
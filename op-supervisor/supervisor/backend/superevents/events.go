@@ -127,6 +127,18 @@ func (ev ResetPreInteropRequestEvent) String() string {
 	return "reset-pre-interop-request"
 }
 
+// ReplayDerivationRequestEvent requests the node of the chain to replay the derivation of the L1 blocks
+// after Start.Source up to End, to fill a gap in the local-safe DB from Start onwards.
+type ReplayDerivationRequestEvent struct {
+	ChainID eth.ChainID
+	Start   types.DerivedIDPair
+	End     eth.BlockID
+}
+
+func (ev ReplayDerivationRequestEvent) String() string {
+	return "replay-derivation-request"
+}
+
 type UnsafeActivationBlockEvent struct {
 	Unsafe  eth.BlockRef
 	ChainID eth.ChainID
@@ -171,6 +183,17 @@ func (ev ReplaceBlockEvent) String() string {
 	return "replace-block-event"
 }
 
+// GossipBlockEvent is the source and processing outcome of an unsafe block
+// that a managed node received via p2p gossip.
+type GossipBlockEvent struct {
+	ChainID eth.ChainID
+	Block   types.GossipBlock
+}
+
+func (ev GossipBlockEvent) String() string {
+	return "gossip-block"
+}
+
 type ChainRewoundEvent struct {
 	ChainID eth.ChainID
 }
@@ -196,3 +219,25 @@ type ChainIndexingContinueEvent struct {
 func (ev ChainIndexingContinueEvent) String() string {
 	return "chain-indexing-continue"
 }
+
+// SequencerLeaderChangedEvent signals that a different sequencer of the chain became the leader, as elected by op-conductor.
+type SequencerLeaderChangedEvent struct {
+	ChainID   eth.ChainID
+	Sequencer eth.SupervisorSequencerStatus
+}
+
+func (ev SequencerLeaderChangedEvent) String() string {
+	return "sequencer-leader-changed"
+}
+
+// ManagedNodeProtocolEvent signals the managed-mode protocol that a managed node reported,
+// when the supervisor connects to the node.
+type ManagedNodeProtocolEvent struct {
+	ChainID  eth.ChainID
+	Endpoint string
+	Protocol types.ManagedProtocol
+}
+
+func (ev ManagedNodeProtocolEvent) String() string {
+	return "managed-node-protocol"
+}
@@ -0,0 +1,250 @@
+package superindexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// retryDelay is how long to wait before retrying, after a failure to compute or store a super root.
+var retryDelay = 2 * time.Second
+
+// Source computes super roots on demand.
+type Source interface {
+	SuperRootAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (eth.SuperRootResponse, error)
+}
+
+// DB stores the computed super roots.
+type DB interface {
+	Latest() (types.SuperRootRecord, error)
+	Add(r types.SuperRootRecord) error
+	RewindTo(timestamp uint64) error
+	Clear() error
+}
+
+// Indexer computes and stores the super root at each timestamp where all chains have advanced their cross-safe head,
+// and publishes every newly stored super root to subscribers.
+//
+// The cross-safe heads are tracked from events. The computation itself happens in a background routine,
+// since computing a super root requires RPC work with the managed nodes, which must not block event processing.
+type Indexer struct {
+	log    log.Logger
+	chains []eth.ChainID
+	src    Source
+	db     DB
+
+	feed gethevent.FeedOf[types.SuperRootRecord]
+
+	mu sync.Mutex
+	// crossSafe is the latest cross-safe timestamp per chain
+	crossSafe map[eth.ChainID]uint64
+	// target is the timestamp all chains are cross-safe at, if hasTarget
+	target    uint64
+	hasTarget bool
+	// verify is set when a chain was rewound, and the latest stored super root may no longer be canonical
+	verify bool
+
+	wake chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ event.Deriver = (*Indexer)(nil)
+
+func New(log log.Logger, chains []eth.ChainID, src Source, db DB) *Indexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Indexer{
+		log:       log.New("component", "super-indexer"),
+		chains:    chains,
+		src:       src,
+		db:        db,
+		crossSafe: make(map[eth.ChainID]uint64),
+		wake:      make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func (ix *Indexer) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case superevents.CrossSafeUpdateEvent:
+		ix.onCrossSafe(x.ChainID, x.NewCrossSafe.Derived.Timestamp)
+	case superevents.ChainRewoundEvent:
+		ix.mu.Lock()
+		ix.verify = true
+		ix.mu.Unlock()
+		ix.signal()
+	default:
+		return false
+	}
+	return true
+}
+
+func (ix *Indexer) onCrossSafe(chainID eth.ChainID, timestamp uint64) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.crossSafe[chainID] = timestamp
+	target := uint64(0)
+	for i, id := range ix.chains {
+		v, ok := ix.crossSafe[id]
+		if !ok {
+			return // not all chains are known yet
+		}
+		if i == 0 || v < target {
+			target = v
+		}
+	}
+	if ix.hasTarget && ix.target == target {
+		return
+	}
+	ix.target = target
+	ix.hasTarget = true
+	ix.signal()
+}
+
+// signal wakes up the background routine, without blocking.
+func (ix *Indexer) signal() {
+	select {
+	case ix.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Feed returns the feed that every newly stored super root is sent to.
+func (ix *Indexer) Feed() *gethevent.FeedOf[types.SuperRootRecord] {
+	return &ix.feed
+}
+
+func (ix *Indexer) Start() {
+	ix.wg.Add(1)
+	go ix.loop()
+}
+
+func (ix *Indexer) Stop() {
+	ix.cancel()
+	ix.wg.Wait()
+}
+
+func (ix *Indexer) loop() {
+	defer ix.wg.Done()
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ix.ctx.Done():
+			return
+		case <-ix.wake:
+		case <-retry:
+		}
+		retry = nil
+		if err := ix.update(ix.ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			ix.log.Warn("Failed to update super roots", "err", err)
+			retry = time.After(retryDelay)
+		}
+	}
+}
+
+// update brings the stored super roots in line with the current cross-safe target.
+func (ix *Indexer) update(ctx context.Context) error {
+	ix.mu.Lock()
+	target, hasTarget, verify := ix.target, ix.hasTarget, ix.verify
+	ix.verify = false
+	ix.mu.Unlock()
+
+	if verify {
+		if err := ix.verifyLatest(ctx); err != nil {
+			ix.mu.Lock()
+			ix.verify = true // try again next time
+			ix.mu.Unlock()
+			return err
+		}
+	}
+	if !hasTarget {
+		return nil
+	}
+	latest, err := ix.db.Latest()
+	if err != nil && !errors.Is(err, types.ErrFuture) {
+		return fmt.Errorf("failed to read latest super root: %w", err)
+	}
+	if err == nil {
+		if latest.Timestamp == target {
+			return nil
+		}
+		if latest.Timestamp > target {
+			// Cross-safe went back: what we stored past (and at) the target may have been reorged out.
+			ix.log.Warn("Cross-safe target went back, rewinding super roots", "latest", latest.Timestamp, "target", target)
+			if err := ix.rewindBefore(target); err != nil {
+				return err
+			}
+		}
+	}
+	resp, err := ix.src.SuperRootAtTimestamp(ctx, hexutil.Uint64(target))
+	if err != nil {
+		return fmt.Errorf("failed to compute super root at %d: %w", target, err)
+	}
+	record := types.SuperRootRecord{
+		Timestamp:            resp.Timestamp,
+		SuperRoot:            resp.SuperRoot,
+		CrossSafeDerivedFrom: resp.CrossSafeDerivedFrom,
+	}
+	if err := ix.db.Add(record); err != nil {
+		return fmt.Errorf("failed to store super root %s: %w", record, err)
+	}
+	ix.log.Info("Stored new super root", "timestamp", record.Timestamp, "superRoot", record.SuperRoot)
+	ix.feed.Send(record)
+	return nil
+}
+
+// verifyLatest recomputes the latest stored super roots, and drops them until one matches again.
+func (ix *Indexer) verifyLatest(ctx context.Context) error {
+	for {
+		latest, err := ix.db.Latest()
+		if errors.Is(err, types.ErrFuture) {
+			return nil // nothing stored, nothing to verify
+		} else if err != nil {
+			return fmt.Errorf("failed to read latest super root: %w", err)
+		}
+		resp, err := ix.src.SuperRootAtTimestamp(ctx, hexutil.Uint64(latest.Timestamp))
+		if err == nil && resp.SuperRoot == latest.SuperRoot {
+			return nil
+		}
+		if err != nil && !errors.Is(err, ethereum.NotFound) && !errors.Is(err, types.ErrFuture) {
+			return fmt.Errorf("failed to verify super root at %d: %w", latest.Timestamp, err)
+		}
+		ix.log.Warn("Dropping non-canonical super root", "timestamp", latest.Timestamp, "superRoot", latest.SuperRoot)
+		if err := ix.rewindBefore(latest.Timestamp); err != nil {
+			return err
+		}
+	}
+}
+
+// rewindBefore removes the stored super roots at and after the timestamp.
+func (ix *Indexer) rewindBefore(timestamp uint64) error {
+	if timestamp == 0 {
+		// There is no timestamp before the first one to rewind to.
+		if err := ix.db.Clear(); err != nil {
+			return fmt.Errorf("failed to clear super roots: %w", err)
+		}
+		return nil
+	}
+	if err := ix.db.RewindTo(timestamp - 1); err != nil {
+		return fmt.Errorf("failed to rewind super roots to %d: %w", timestamp-1, err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package superindexer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type stubSource struct {
+	// roots maps timestamps to super roots, missing entries are not found
+	roots map[uint64]eth.Bytes32
+}
+
+func (s *stubSource) SuperRootAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (eth.SuperRootResponse, error) {
+	root, ok := s.roots[uint64(timestamp)]
+	if !ok {
+		return eth.SuperRootResponse{}, fmt.Errorf("no super root at %d: %w", timestamp, ethereum.NotFound)
+	}
+	return eth.SuperRootResponse{Timestamp: uint64(timestamp), SuperRoot: root}, nil
+}
+
+type stubDB struct {
+	records []types.SuperRootRecord
+}
+
+func (s *stubDB) Latest() (types.SuperRootRecord, error) {
+	if len(s.records) == 0 {
+		return types.SuperRootRecord{}, types.ErrFuture
+	}
+	return s.records[len(s.records)-1], nil
+}
+
+func (s *stubDB) Add(r types.SuperRootRecord) error {
+	if len(s.records) > 0 && s.records[len(s.records)-1].Timestamp >= r.Timestamp {
+		return types.ErrOutOfOrder
+	}
+	s.records = append(s.records, r)
+	return nil
+}
+
+func (s *stubDB) RewindTo(timestamp uint64) error {
+	for len(s.records) > 0 && s.records[len(s.records)-1].Timestamp > timestamp {
+		s.records = s.records[:len(s.records)-1]
+	}
+	return nil
+}
+
+func (s *stubDB) Clear() error {
+	s.records = nil
+	return nil
+}
+
+func crossSafeEvent(chainID eth.ChainID, timestamp uint64) superevents.CrossSafeUpdateEvent {
+	return superevents.CrossSafeUpdateEvent{
+		ChainID: chainID,
+		NewCrossSafe: types.DerivedBlockSealPair{
+			Derived: types.BlockSeal{Timestamp: timestamp},
+		},
+	}
+}
+
+func setup(t *testing.T) (*Indexer, *stubSource, *stubDB, chan types.SuperRootRecord) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	src := &stubSource{roots: make(map[uint64]eth.Bytes32)}
+	db := &stubDB{}
+	ix := New(logger, []eth.ChainID{eth.ChainIDFromUInt64(900), eth.ChainIDFromUInt64(901)}, src, db)
+	ch := make(chan types.SuperRootRecord, 10)
+	sub := ix.Feed().Subscribe(ch)
+	t.Cleanup(sub.Unsubscribe)
+	return ix, src, db, ch
+}
+
+func TestIndexer(t *testing.T) {
+	chainA, chainB := eth.ChainIDFromUInt64(900), eth.ChainIDFromUInt64(901)
+
+	t.Run("waits for all chains", func(t *testing.T) {
+		ix, src, db, ch := setup(t)
+		src.roots[100] = eth.Bytes32{1}
+		require.True(t, ix.OnEvent(crossSafeEvent(chainA, 100)))
+		require.NoError(t, ix.update(context.Background()))
+		require.Empty(t, db.records)
+		require.Empty(t, ch)
+
+		require.True(t, ix.OnEvent(crossSafeEvent(chainB, 102)))
+		require.NoError(t, ix.update(context.Background()))
+		expected := types.SuperRootRecord{Timestamp: 100, SuperRoot: eth.Bytes32{1}}
+		require.Equal(t, []types.SuperRootRecord{expected}, db.records)
+		require.Equal(t, expected, <-ch)
+
+		// nothing changes if the minimum timestamp did not advance
+		require.True(t, ix.OnEvent(crossSafeEvent(chainB, 104)))
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 1)
+		require.Empty(t, ch)
+	})
+
+	t.Run("advances", func(t *testing.T) {
+		ix, src, db, ch := setup(t)
+		src.roots[100] = eth.Bytes32{1}
+		src.roots[104] = eth.Bytes32{2}
+		ix.OnEvent(crossSafeEvent(chainA, 100))
+		ix.OnEvent(crossSafeEvent(chainB, 100))
+		require.NoError(t, ix.update(context.Background()))
+		ix.OnEvent(crossSafeEvent(chainA, 104))
+		ix.OnEvent(crossSafeEvent(chainB, 106))
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 2)
+		require.Equal(t, uint64(100), (<-ch).Timestamp)
+		require.Equal(t, uint64(104), (<-ch).Timestamp)
+	})
+
+	t.Run("retries after failure", func(t *testing.T) {
+		ix, src, db, _ := setup(t)
+		ix.OnEvent(crossSafeEvent(chainA, 100))
+		ix.OnEvent(crossSafeEvent(chainB, 100))
+		require.ErrorIs(t, ix.update(context.Background()), ethereum.NotFound)
+		require.Empty(t, db.records)
+		src.roots[100] = eth.Bytes32{1}
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 1)
+	})
+
+	t.Run("rewinds when cross-safe goes back", func(t *testing.T) {
+		ix, src, db, _ := setup(t)
+		src.roots[100] = eth.Bytes32{1}
+		src.roots[104] = eth.Bytes32{2}
+		ix.OnEvent(crossSafeEvent(chainA, 104))
+		ix.OnEvent(crossSafeEvent(chainB, 104))
+		require.NoError(t, ix.update(context.Background()))
+		require.Equal(t, uint64(104), db.records[0].Timestamp)
+
+		ix.OnEvent(crossSafeEvent(chainA, 100))
+		require.NoError(t, ix.update(context.Background()))
+		require.Equal(t, []types.SuperRootRecord{{Timestamp: 100, SuperRoot: eth.Bytes32{1}}}, db.records)
+	})
+
+	t.Run("rewinds to genesis", func(t *testing.T) {
+		ix, src, db, _ := setup(t)
+		src.roots[0] = eth.Bytes32{1}
+		ix.OnEvent(crossSafeEvent(chainA, 0))
+		ix.OnEvent(crossSafeEvent(chainB, 0))
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 1)
+
+		// the super root at genesis got replaced, and is dropped without underflowing the timestamp
+		src.roots[0] = eth.Bytes32{2}
+		require.True(t, ix.OnEvent(superevents.ChainRewoundEvent{ChainID: chainA}))
+		require.NoError(t, ix.update(context.Background()))
+		require.Equal(t, []types.SuperRootRecord{{Timestamp: 0, SuperRoot: eth.Bytes32{2}}}, db.records)
+	})
+
+	t.Run("verifies after chain rewind", func(t *testing.T) {
+		ix, src, db, _ := setup(t)
+		src.roots[100] = eth.Bytes32{1}
+		src.roots[104] = eth.Bytes32{2}
+		ix.OnEvent(crossSafeEvent(chainA, 100))
+		ix.OnEvent(crossSafeEvent(chainB, 100))
+		require.NoError(t, ix.update(context.Background()))
+		ix.OnEvent(crossSafeEvent(chainA, 104))
+		ix.OnEvent(crossSafeEvent(chainB, 104))
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 2)
+
+		// the latest super root got reorged out, and was replaced by a different one
+		src.roots[104] = eth.Bytes32(common.Hash{0xff})
+		require.True(t, ix.OnEvent(superevents.ChainRewoundEvent{ChainID: chainA}))
+		require.NoError(t, ix.update(context.Background()))
+		require.Len(t, db.records, 2)
+		require.Equal(t, eth.Bytes32(common.Hash{0xff}), db.records[1].SuperRoot)
+	})
+}
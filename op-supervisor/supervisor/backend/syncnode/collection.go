@@ -2,6 +2,7 @@ package syncnode
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
 )
 
 var (
@@ -19,6 +21,9 @@ var (
 type CLISyncNodes struct {
 	Endpoints      []string
 	JWTSecretPaths []string
+	// TLS configures the client certificate to authenticate with, and the CA to verify the nodes with.
+	// All endpoints share the same TLS configuration.
+	TLS optls.CLIConfig
 }
 
 var _ SyncNodeCollection = (*CLISyncNodes)(nil)
@@ -42,6 +47,15 @@ func (p *CLISyncNodes) Load(ctx context.Context, logger log.Logger) ([]SyncNodeS
 		}
 		secrets = append(secrets, secret)
 	}
+	var tlsCfg *tls.Config
+	if p.TLS.TLSEnabled() {
+		// The client certificate is watched for rotation for as long as the process runs.
+		conf, _, err := optls.NewClientTLSConfig(logger, p.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to setup sync-sources TLS: %w", err)
+		}
+		tlsCfg = conf
+	}
 	setups := make([]SyncNodeSetup, 0, len(p.Endpoints))
 	for i, endpoint := range p.Endpoints {
 		var secret eth.Bytes32
@@ -53,12 +67,16 @@ func (p *CLISyncNodes) Load(ctx context.Context, logger log.Logger) ([]SyncNodeS
 		setups = append(setups, &RPCDialSetup{
 			JWTSecret: secret,
 			Endpoint:  endpoint,
+			TLSConfig: tlsCfg,
 		})
 	}
 	return setups, nil
 }
 
 func (p *CLISyncNodes) Check() error {
+	if err := p.TLS.Check(); err != nil {
+		return fmt.Errorf("%w: %w", errSyncNodeCheck, err)
+	}
 	if len(p.Endpoints) == len(p.JWTSecretPaths) {
 		return nil
 	}
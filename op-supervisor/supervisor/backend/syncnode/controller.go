@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/locks"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/depset"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
@@ -63,8 +64,9 @@ func (snc *SyncNodesController) Close() error {
 }
 
 // AttachNodeController attaches a node to be managed by the supervisor.
+// The node is managed according to the managed-mode protocol that it reported.
 // If noSubscribe, the node is not actively polled/subscribed to, and requires manual ManagedNode.PullEvents calls.
-func (snc *SyncNodesController) AttachNodeController(chainID eth.ChainID, ctrl SyncControl, noSubscribe bool) (Node, error) {
+func (snc *SyncNodesController) AttachNodeController(chainID eth.ChainID, ctrl SyncControl, protocol types.ManagedProtocol, noSubscribe bool) (Node, error) {
 	if !snc.depSet.HasChain(chainID) {
 		return nil, fmt.Errorf("chain %v not in dependency set: %w", chainID, types.ErrUnknownChain)
 	}
@@ -78,12 +80,17 @@ func (snc *SyncNodesController) AttachNodeController(chainID eth.ChainID, ctrl S
 	name := fmt.Sprintf("syncnode-%s-%d", chainID, nodeID)
 	logger := snc.logger.New("syncnode", name, "endpoint", ctrl.String())
 
-	logger.Info("Attaching node", "chain", chainID, "passive", noSubscribe)
+	logger.Info("Attaching node", "chain", chainID, "passive", noSubscribe, "protocol", protocol)
 
 	// create the managed node, register and return
-	node := NewManagedNode(logger, chainID, ctrl, snc.backend, noSubscribe)
+	node := NewManagedNode(logger, chainID, ctrl, protocol, snc.backend, noSubscribe)
 	snc.eventSys.Register(name, node)
 	controllersForChain.Set(node, struct{}{})
+	snc.emitter.Emit(superevents.ManagedNodeProtocolEvent{
+		ChainID:  chainID,
+		Endpoint: ctrl.String(),
+		Protocol: protocol,
+	})
 	node.Start()
 	return node, nil
 }
@@ -28,10 +28,18 @@ type mockSyncControl struct {
 	updateFinalizedFn   func(ctx context.Context, id eth.BlockID) error
 	pullEventFn         func(ctx context.Context) (*types.ManagedEvent, error)
 	blockRefByNumFn     func(ctx context.Context, number uint64) (eth.BlockRef, error)
+	protocolFn          func(ctx context.Context) (types.ManagedProtocol, error)
 
 	subscribeEvents gethevent.FeedOf[*types.ManagedEvent]
 }
 
+func (m *mockSyncControl) Protocol(ctx context.Context) (types.ManagedProtocol, error) {
+	if m.protocolFn != nil {
+		return m.protocolFn(ctx)
+	}
+	return types.ManagedProtocol{Version: types.ManagedProtocolVersion, Capabilities: types.ManagedCapabilities}, nil
+}
+
 func (m *mockSyncControl) InvalidateBlock(ctx context.Context, seal types.BlockSeal) error {
 	return nil
 }
@@ -57,6 +65,10 @@ func (m *mockSyncControl) Reset(ctx context.Context, lUnsafe, xUnsafe, lSafe, xS
 	return nil
 }
 
+func (m *mockSyncControl) ReplayDerivation(ctx context.Context, start types.DerivedIDPair, end eth.BlockID) (types.DerivedBlockRefPair, error) {
+	return types.DerivedBlockRefPair{}, nil
+}
+
 func (m *mockSyncControl) ResetPreInterop(ctx context.Context) error {
 	if m.resetPreInteropFn != nil {
 		return m.resetPreInteropFn(ctx)
@@ -241,21 +253,21 @@ func TestAttachNodeController(t *testing.T) {
 
 	// Attach a controller for chain 900
 	ctrl := mockSyncControl{}
-	_, err := controller.AttachNodeController(eth.ChainIDFromUInt64(900), &ctrl, false)
+	_, err := controller.AttachNodeController(eth.ChainIDFromUInt64(900), &ctrl, types.LegacyManagedProtocol, false)
 	require.NoError(t, err)
 
 	require.Equal(t, 1, controller.controllers.Len(), "controllers should have 1 entry")
 
 	// Attach a controller for chain 901
 	ctrl2 := mockSyncControl{}
-	_, err = controller.AttachNodeController(eth.ChainIDFromUInt64(901), &ctrl2, false)
+	_, err = controller.AttachNodeController(eth.ChainIDFromUInt64(901), &ctrl2, types.LegacyManagedProtocol, false)
 	require.NoError(t, err)
 
 	require.Equal(t, 2, controller.controllers.Len(), "controllers should have 2 entries")
 
 	// Attach a controller for chain 902 (which is not in the dependency set)
 	ctrl3 := mockSyncControl{}
-	_, err = controller.AttachNodeController(eth.ChainIDFromUInt64(902), &ctrl3, false)
+	_, err = controller.AttachNodeController(eth.ChainIDFromUInt64(902), &ctrl3, types.LegacyManagedProtocol, false)
 	require.Error(t, err)
 	require.Equal(t, 2, controller.controllers.Len(), "controllers should still have 2 entries")
 }
@@ -2,9 +2,13 @@ package syncnode
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/ethereum/go-ethereum/log"
 	gn "github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -17,6 +21,8 @@ import (
 type RPCDialSetup struct {
 	JWTSecret eth.Bytes32
 	Endpoint  string
+	// TLSConfig is used to dial a wss:// endpoint with a client certificate. Optional.
+	TLSConfig *tls.Config
 }
 
 var _ SyncNodeSetup = (*RPCDialSetup)(nil)
@@ -31,6 +37,14 @@ func (r *RPCDialSetup) Setup(ctx context.Context, logger log.Logger, m opmetrics
 		client.WithDialAttempts(10),
 		client.WithRPCRecorder(m.NewRecorder("syncnode")),
 	}
+	if r.TLSConfig != nil {
+		opts = append(opts, client.WithGethRPCOptions(rpc.WithWebsocketDialer(websocket.Dialer{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: r.TLSConfig,
+		})))
+	}
 	rpcCl, err := client.NewRPC(ctx, logger, r.Endpoint, opts...)
 	if err != nil {
 		return nil, err
@@ -36,6 +36,9 @@ type SyncSource interface {
 }
 
 type SyncControl interface {
+	// Protocol returns the managed-mode protocol version and capabilities of the node.
+	Protocol(ctx context.Context) (types.ManagedProtocol, error)
+
 	SubscribeEvents(ctx context.Context, c chan *types.ManagedEvent) (ethereum.Subscription, error)
 	PullEvent(ctx context.Context) (*types.ManagedEvent, error)
 	BlockRefByNumber(ctx context.Context, number uint64) (eth.BlockRef, error)
@@ -48,6 +51,9 @@ type SyncControl interface {
 
 	Reset(ctx context.Context, lUnsafe, xUnsafe, lSafe, xSafe, finalized eth.BlockID) error
 	ResetPreInterop(ctx context.Context) error
+	// ReplayDerivation re-derives the blocks from the L1 blocks after start.Source up to end,
+	// starting from start.Derived, and sends their derivation updates as events.
+	ReplayDerivation(ctx context.Context, start types.DerivedIDPair, end eth.BlockID) (types.DerivedBlockRefPair, error)
 	ProvideL1(ctx context.Context, nextL1 eth.BlockRef) error
 	AnchorPoint(ctx context.Context) (types.DerivedBlockRefPair, error)
 
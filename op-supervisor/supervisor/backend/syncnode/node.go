@@ -16,6 +16,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/locks"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 	gethevent "github.com/ethereum/go-ethereum/event"
@@ -41,6 +42,7 @@ type backend interface {
 const (
 	internalTimeout     = time.Second * 30
 	nodeTimeout         = time.Second * 10
+	replayTimeout       = time.Minute * 5
 	maxWalkBackAttempts = 300
 )
 
@@ -66,6 +68,10 @@ type ManagedNode struct {
 	lastNodeLocalUnsafe eth.BlockID
 	lastNodeLocalSafe   eth.BlockID
 
+	// protocol is the managed-mode protocol of the node, to adapt to the capabilities of the node.
+	// Renegotiated when the RPC connection is reopened, since the node may have been upgraded.
+	protocol locks.RWValue[types.ManagedProtocol]
+
 	resetMu      sync.Mutex
 	resetCancel  context.CancelFunc
 	resetTracker *resetTracker
@@ -76,7 +82,7 @@ var (
 	_ event.Deriver       = (*ManagedNode)(nil)
 )
 
-func NewManagedNode(log log.Logger, id eth.ChainID, node SyncControl, backend backend, noSubscribe bool) *ManagedNode {
+func NewManagedNode(log log.Logger, id eth.ChainID, node SyncControl, protocol types.ManagedProtocol, backend backend, noSubscribe bool) *ManagedNode {
 	ctx, cancel := context.WithCancel(context.Background())
 	m := &ManagedNode{
 		log:     log.New("chain", id),
@@ -86,6 +92,7 @@ func NewManagedNode(log log.Logger, id eth.ChainID, node SyncControl, backend ba
 		ctx:     ctx,
 		cancel:  cancel,
 	}
+	m.protocol.Set(protocol)
 	m.resetTracker = newResetTracker(
 		m.log.New("component", "resetTracker"),
 		m.resetBackend())
@@ -152,6 +159,11 @@ func (m *ManagedNode) OnEvent(ev event.Event) bool {
 			return false
 		}
 		m.onResetPreInteropRequest()
+	case superevents.ReplayDerivationRequestEvent:
+		if x.ChainID != m.chainID {
+			return false
+		}
+		m.onReplayDerivationRequest(x.Start, x.End)
 	default:
 		return false
 	}
@@ -175,23 +187,31 @@ func (m *ManagedNode) SubscribeToNodeEvents() {
 						m.log.Warn("RPC websocket reconnection failed", "err", err)
 					} else {
 						m.log.Info("RPC websocket connection reopened")
+						m.renegotiateProtocol()
 					}
 				}
 				// When the subscription fails, the channel may have been immediately closed
 				m.nodeEvents = make(chan *types.ManagedEvent, 10)
 			}
+			pollEvents := func() (gethevent.Subscription, error) {
+				sub, err := rpc.StreamFallback(
+					m.Node.PullEvent, time.Millisecond*100, m.nodeEvents)
+				if err != nil {
+					m.log.Error("Failed to start RPC stream fallback", "err", err)
+					return nil, err
+				}
+				return sub, err
+			}
+			if !m.protocol.Get().Supports(types.CapabilityEventsSubscription) {
+				m.log.Info("Node does not support event subscriptions, polling events")
+				return pollEvents()
+			}
 			sub, err := m.Node.SubscribeEvents(ctx, m.nodeEvents)
 			if err != nil {
 				if errors.Is(err, gethrpc.ErrNotificationsUnsupported) {
 					m.log.Warn("No RPC notification support detected, falling back to polling")
 					// fallback to polling if subscriptions are not supported.
-					sub, err := rpc.StreamFallback(
-						m.Node.PullEvent, time.Millisecond*100, m.nodeEvents)
-					if err != nil {
-						m.log.Error("Failed to start RPC stream fallback", "err", err)
-						return nil, err
-					}
-					return sub, err
+					return pollEvents()
 				}
 				return nil, err
 			}
@@ -199,6 +219,30 @@ func (m *ManagedNode) SubscribeToNodeEvents() {
 		}))
 }
 
+// renegotiateProtocol updates the managed-mode protocol of the node,
+// which may have changed if the node was restarted with a different version.
+func (m *ManagedNode) renegotiateProtocol() {
+	ctx, cancel := context.WithTimeout(m.ctx, nodeTimeout)
+	defer cancel()
+	protocol, err := m.Node.Protocol(ctx)
+	if err != nil {
+		m.log.Warn("Failed to renegotiate managed-mode protocol, keeping previous capabilities", "err", err)
+		return
+	}
+	if err := protocol.Check(); err != nil {
+		m.log.Error("Node switched to an incompatible managed-mode protocol", "protocol", protocol, "err", err)
+	}
+	if prev := m.protocol.Get(); prev.String() != protocol.String() {
+		m.log.Info("Node changed managed-mode protocol", "prev", prev, "protocol", protocol)
+	}
+	m.protocol.Set(protocol)
+	m.emitter.Emit(superevents.ManagedNodeProtocolEvent{
+		ChainID:  m.chainID,
+		Endpoint: m.Node.String(),
+		Protocol: protocol,
+	})
+}
+
 func (m *ManagedNode) WatchSubscriptionErrors() {
 	watchSub := func(sub ethereum.Subscription) {
 		defer m.wg.Done()
@@ -283,6 +327,9 @@ func (m *ManagedNode) onNodeEvent(ev *types.ManagedEvent) {
 	if ev.DerivationOriginUpdate != nil {
 		m.onDerivationOriginUpdate(*ev.DerivationOriginUpdate)
 	}
+	if ev.GossipBlock != nil {
+		m.onGossipBlock(*ev.GossipBlock)
+	}
 }
 
 // onResetEvent handles a reset event from the node
@@ -339,6 +386,10 @@ func (m *ManagedNode) onFinalizedL2(seal types.BlockSeal) {
 }
 
 func (m *ManagedNode) onResetPreInteropRequest() {
+	if !m.protocol.Get().Supports(types.CapabilityResetPreInterop) {
+		m.log.Warn("Node does not support pre-Interop resets, not resetting")
+		return
+	}
 	m.log.Info("Requesting node to reset pre-Interop")
 	ctx, cancel := context.WithTimeout(m.ctx, nodeTimeout)
 	defer cancel()
@@ -348,6 +399,29 @@ func (m *ManagedNode) onResetPreInteropRequest() {
 	}
 }
 
+func (m *ManagedNode) onReplayDerivationRequest(start types.DerivedIDPair, end eth.BlockID) {
+	logger := m.log.New("start", start, "end", end)
+	if !m.protocol.Get().Supports(types.CapabilityReplayDerivation) {
+		logger.Warn("Node does not support derivation replays, not replaying")
+		return
+	}
+	logger.Info("Requesting node to replay derivation")
+	// The replayed derivation updates are received as events, while the node replays.
+	// Replays take longer than other requests, so they do not block the handling of events.
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ctx, cancel := context.WithTimeout(m.ctx, replayTimeout)
+		defer cancel()
+		last, err := m.Node.ReplayDerivation(ctx, start, end)
+		if err != nil {
+			logger.Error("Node failed to replay derivation", "last", last, "err", err)
+			return
+		}
+		logger.Info("Node replayed derivation", "last", last)
+	}()
+}
+
 func (m *ManagedNode) onUnsafeBlock(unsafeRef eth.BlockRef) {
 	m.log.Info("Node has new unsafe block", "unsafeBlock", unsafeRef)
 	m.emitter.Emit(superevents.LocalUnsafeReceivedEvent{
@@ -432,6 +506,15 @@ func (m *ManagedNode) onReplaceBlock(replacement types.BlockReplacement) {
 	m.resetIfInconsistent()
 }
 
+func (m *ManagedNode) onGossipBlock(block types.GossipBlock) {
+	m.log.Debug("Node received block via gossip",
+		"block", block.Block, "peer", block.Peer, "outcome", block.Outcome)
+	m.emitter.Emit(superevents.GossipBlockEvent{
+		ChainID: m.chainID,
+		Block:   block,
+	})
+}
+
 func (m *ManagedNode) Close() error {
 	m.cancel()
 	m.wg.Wait() // wait for work to complete
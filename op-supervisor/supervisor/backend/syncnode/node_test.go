@@ -7,10 +7,12 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/superevents"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,7 +28,7 @@ func TestEventResponse(t *testing.T) {
 	mon := &eventMonitor{}
 	eventSys.Register("monitor", mon)
 
-	node := NewManagedNode(logger, chainID, syncCtrl, backend, false)
+	node := NewManagedNode(logger, chainID, syncCtrl, types.LegacyManagedProtocol, backend, false)
 	eventSys.Register("node", node)
 
 	emitter := eventSys.Register("test", nil)
@@ -88,3 +90,62 @@ func TestEventResponse(t *testing.T) {
 			mon.localDerivedOriginUpdate >= 1
 	}, 4*time.Second, 250*time.Millisecond)
 }
+
+// TestProtocolCapabilities tests that the node is managed according to the capabilities of its managed-mode protocol.
+func TestProtocolCapabilities(t *testing.T) {
+	chainID := eth.ChainIDFromUInt64(1)
+	logger := testlog.Logger(t, log.LvlInfo)
+
+	t.Run("full", func(t *testing.T) {
+		syncCtrl := &mockSyncControl{}
+		resets := 0
+		syncCtrl.resetPreInteropFn = func(ctx context.Context) error {
+			resets++
+			return nil
+		}
+		ex := event.NewGlobalSynchronous(context.Background())
+		eventSys := event.NewSystem(logger, ex)
+		protocol := types.ManagedProtocol{Version: types.ManagedProtocolVersion, Capabilities: types.ManagedCapabilities}
+		node := NewManagedNode(logger, chainID, syncCtrl, protocol, &mockBackend{}, false)
+		t.Cleanup(func() { require.NoError(t, node.Close()) })
+		eventSys.Register("node", node)
+
+		eventSys.Register("test", nil).Emit(superevents.ResetPreInteropRequestEvent{ChainID: chainID})
+		require.NoError(t, ex.Drain())
+		require.Equal(t, 1, resets)
+	})
+
+	t.Run("minimal", func(t *testing.T) {
+		syncCtrl := &mockSyncControl{}
+		resets := 0
+		syncCtrl.resetPreInteropFn = func(ctx context.Context) error {
+			resets++
+			return nil
+		}
+		pulled := make(chan struct{}, 1)
+		syncCtrl.pullEventFn = func(ctx context.Context) (*types.ManagedEvent, error) {
+			select {
+			case pulled <- struct{}{}:
+			default:
+			}
+			return nil, &gethrpc.JsonError{Code: rpc.OutOfEventsErrCode, Message: "out of events"}
+		}
+		ex := event.NewGlobalSynchronous(context.Background())
+		eventSys := event.NewSystem(logger, ex)
+		node := NewManagedNode(logger, chainID, syncCtrl, types.ManagedProtocol{Version: 1}, &mockBackend{}, false)
+		t.Cleanup(func() { require.NoError(t, node.Close()) })
+		eventSys.Register("node", node)
+
+		// events are polled, instead of subscribed to
+		select {
+		case <-pulled:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected events to be polled")
+		}
+
+		// the node is not asked to reset pre-Interop
+		eventSys.Register("test", nil).Emit(superevents.ResetPreInteropRequestEvent{ChainID: chainID})
+		require.NoError(t, ex.Drain())
+		require.Zero(t, resets)
+	})
+}
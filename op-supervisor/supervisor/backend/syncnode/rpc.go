@@ -115,6 +115,20 @@ func (rs *RPCSyncNode) String() string {
 	return rs.name
 }
 
+// Protocol returns the managed-mode protocol of the node.
+// Nodes that predate the protocol handshake are assumed to speak the legacy protocol.
+func (rs *RPCSyncNode) Protocol(ctx context.Context) (types.ManagedProtocol, error) {
+	var (
+		out     types.ManagedProtocol
+		jsonErr gethrpc.Error
+	)
+	err := rs.cl.CallContext(ctx, &out, "interop_protocol")
+	if errors.As(err, &jsonErr) && jsonErr.ErrorCode() == int(eth.MethodNotFound) {
+		return types.LegacyManagedProtocol, nil
+	}
+	return out, err
+}
+
 func (rs *RPCSyncNode) SubscribeEvents(ctx context.Context, dest chan *types.ManagedEvent) (ethereum.Subscription, error) {
 	return rpc.SubscribeStream(ctx, "interop", rs.cl, dest, "events")
 }
@@ -158,6 +172,12 @@ func (rs *RPCSyncNode) ResetPreInterop(ctx context.Context) error {
 	return rs.cl.CallContext(ctx, nil, "interop_resetPreInterop")
 }
 
+func (rs *RPCSyncNode) ReplayDerivation(ctx context.Context, start types.DerivedIDPair, end eth.BlockID) (types.DerivedBlockRefPair, error) {
+	var out types.DerivedBlockRefPair
+	err := rs.cl.CallContext(ctx, &out, "interop_replayDerivation", start, end)
+	return out, err
+}
+
 func (rs *RPCSyncNode) ProvideL1(ctx context.Context, nextL1 eth.BlockRef) error {
 	return rs.cl.CallContext(ctx, nil, "interop_provideL1", nextL1)
 }
@@ -183,17 +203,23 @@ func (rs *RPCSyncNode) AnchorPoint(ctx context.Context) (types.DerivedBlockRefPa
 // This can be used to check the validity of cross-chain interop events.
 // The block-seal of the blockNum block that the log was included in is returned.
 func (rs *RPCSyncNode) Contains(ctx context.Context, query types.ContainsQuery) (types.BlockSeal, error) {
-	chainID, err := rs.ChainID(ctx)
+	return ContainsLog(ctx, rs, query)
+}
+
+// ContainsLog verifies, with the block and receipts data of the source,
+// that the log of the query exists in the canonical chain of the source.
+func ContainsLog(ctx context.Context, src SyncSource, query types.ContainsQuery) (types.BlockSeal, error) {
+	chainID, err := src.ChainID(ctx)
 	if err != nil {
 		return types.BlockSeal{}, fmt.Errorf("failed to get chain ID for verifying access with RPC: %w", err)
 	}
 
-	blockRef, err := rs.BlockRefByNumber(ctx, query.BlockNum)
+	blockRef, err := src.BlockRefByNumber(ctx, query.BlockNum)
 	if err != nil {
 		return types.BlockSeal{}, types.ErrFuture
 	}
 
-	log, err := rs.getLogAtIndex(ctx, blockRef.Hash, query.LogIdx)
+	log, err := getLogAtIndex(ctx, src, blockRef.Hash, query.LogIdx)
 	if err != nil {
 		return types.BlockSeal{}, types.ErrConflict
 	}
@@ -217,8 +243,8 @@ func (rs *RPCSyncNode) Contains(ctx context.Context, query types.ContainsQuery)
 	}, nil
 }
 
-func (rs *RPCSyncNode) getLogAtIndex(ctx context.Context, blockHash common.Hash, logIndex uint32) (*gethtypes.Log, error) {
-	receipts, err := rs.FetchReceipts(ctx, blockHash)
+func getLogAtIndex(ctx context.Context, src SyncSource, blockHash common.Hash, logIndex uint32) (*gethtypes.Log, error) {
+	receipts, err := src.FetchReceipts(ctx, blockHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch receipts for verifying access with RPC: %w", err)
 	}
@@ -2,22 +2,43 @@ package frontend
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/apis"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/logmods"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
 type Backend interface {
 	apis.SupervisorAdminAPI
 	apis.SupervisorQueryAPI
+	SuperRootsFeed() *gethevent.FeedOf[types.SuperRootRecord]
+	EventsFeed() *gethevent.FeedOf[types.SupervisorEvent]
+	Chains() []eth.ChainID
 }
 
 type QueryFrontend struct {
 	Supervisor apis.SupervisorQueryAPI
+
+	// Log and SuperRootsFeed are used to serve the super-roots subscription, if SuperRootsFeed is set.
+	Log            log.Logger
+	SuperRootsFeed *gethevent.FeedOf[types.SuperRootRecord]
+
+	// EventsFeed is used to serve the events subscription, if set.
+	// Chains are the chains that event filters may select.
+	EventsFeed *gethevent.FeedOf[types.SupervisorEvent]
+	Chains     []eth.ChainID
 }
 
 var _ apis.SupervisorQueryAPI = (*QueryFrontend)(nil)
@@ -27,6 +48,12 @@ func (q *QueryFrontend) CheckAccessList(ctx context.Context, inboxEntries []comm
 	return q.Supervisor.CheckAccessList(ctx, inboxEntries, minSafety, executingDescriptor)
 }
 
+// CheckAccessListAt checks the access-list as it was valid in the given block of the executing chain.
+func (q *QueryFrontend) CheckAccessListAt(ctx context.Context, inboxEntries []common.Hash,
+	chainID eth.ChainID, blockNumber hexutil.Uint64) error {
+	return q.Supervisor.CheckAccessListAt(ctx, inboxEntries, chainID, blockNumber)
+}
+
 func (q *QueryFrontend) LocalUnsafe(ctx context.Context, chainID eth.ChainID) (eth.BlockID, error) {
 	return q.Supervisor.LocalUnsafe(ctx, chainID)
 }
@@ -55,6 +82,35 @@ func (q *QueryFrontend) SuperRootAtTimestamp(ctx context.Context, timestamp hexu
 	return q.Supervisor.SuperRootAtTimestamp(ctx, timestamp)
 }
 
+func (q *QueryFrontend) SuperRootRecordAtTimestamp(ctx context.Context, timestamp hexutil.Uint64) (types.SuperRootRecord, error) {
+	return q.Supervisor.SuperRootRecordAtTimestamp(ctx, timestamp)
+}
+
+func (q *QueryFrontend) LatestSuperRootRecord(ctx context.Context) (types.SuperRootRecord, error) {
+	return q.Supervisor.LatestSuperRootRecord(ctx)
+}
+
+// SuperRoots subscribes to every super root that the supervisor stores from now on.
+func (q *QueryFrontend) SuperRoots(ctx context.Context) (*gethrpc.Subscription, error) {
+	if q.SuperRootsFeed == nil {
+		return &gethrpc.Subscription{}, gethrpc.ErrNotificationsUnsupported
+	}
+	return oprpc.SubscribeRPC(ctx, q.Log, q.SuperRootsFeed)
+}
+
+// Events subscribes to the supervisor events that match the filter, from now on.
+// The filter is evaluated server-side. An invalid filter is rejected when subscribing.
+func (q *QueryFrontend) Events(ctx context.Context, filter types.EventFilter) (*gethrpc.Subscription, error) {
+	if q.EventsFeed == nil {
+		return &gethrpc.Subscription{}, gethrpc.ErrNotificationsUnsupported
+	}
+	compiled, err := filter.Compile(q.Chains)
+	if err != nil {
+		return &gethrpc.Subscription{}, fmt.Errorf("invalid event filter: %w", err)
+	}
+	return oprpc.SubscribeRPCFiltered(ctx, q.Log, q.EventsFeed, compiled.Match)
+}
+
 func (q *QueryFrontend) AllSafeDerivedAt(ctx context.Context, derivedFrom eth.BlockID) (derived map[eth.ChainID]eth.BlockID, err error) {
 	return q.Supervisor.AllSafeDerivedAt(ctx, derivedFrom)
 }
@@ -63,11 +119,58 @@ func (q *QueryFrontend) SyncStatus(ctx context.Context) (eth.SupervisorSyncStatu
 	return q.Supervisor.SyncStatus(ctx)
 }
 
+// CrossSafeConstraints returns, for each chain, which dependency is holding back its cross-safe progress, and by how much.
+func (q *QueryFrontend) CrossSafeConstraints(ctx context.Context) (map[eth.ChainID]types.CrossSafeConstraint, error) {
+	return q.Supervisor.CrossSafeConstraints(ctx)
+}
+
+// ExecutingMessages returns the logs, across all chains, that execute the message with the given checksum.
+func (q *QueryFrontend) ExecutingMessages(ctx context.Context, checksum types.MessageChecksum) ([]types.LogLocation, error) {
+	return q.Supervisor.ExecutingMessages(ctx, checksum)
+}
+
+// Snapshot returns the heads and pending message counts of all chains, as of a single point in time.
+func (q *QueryFrontend) Snapshot(ctx context.Context) (types.SupervisorSnapshot, error) {
+	return q.Supervisor.Snapshot(ctx)
+}
+
+// ChecksumPreimage returns the components that the given message checksum is computed from.
+func (q *QueryFrontend) ChecksumPreimage(ctx context.Context, checksum types.MessageChecksum) (types.ChecksumPreimage, error) {
+	return q.Supervisor.ChecksumPreimage(ctx, checksum)
+}
+
+// CausalityGraph returns the graph of the blocks of all chains with a timestamp in [from, to],
+// with the derivation and message dependencies that their cross-safe promotion waits on.
+func (q *QueryFrontend) CausalityGraph(ctx context.Context, from hexutil.Uint64, to hexutil.Uint64) (*types.CausalityGraph, error) {
+	return q.Supervisor.CausalityGraph(ctx, from, to)
+}
+
+// LogProof returns a proof of the log with the given index in the given block, bound to the cross-safe head of the chain.
+func (q *QueryFrontend) LogProof(ctx context.Context, chainID eth.ChainID, blockNum hexutil.Uint64, logIdx uint32) (*types.LogProof, error) {
+	return q.Supervisor.LogProof(ctx, chainID, blockNum, logIdx)
+}
+
+// SubmitAttestation verifies and stores a signed attestation of an L2 block by an authorized external attestor.
+func (q *QueryFrontend) SubmitAttestation(ctx context.Context, att types.SignedAttestation) (types.AttestationRecord, error) {
+	return q.Supervisor.SubmitAttestation(ctx, att)
+}
+
+// Attestations returns the stored attestations of the given L2 block.
+func (q *QueryFrontend) Attestations(ctx context.Context, chainID eth.ChainID, block eth.BlockID) ([]types.AttestationRecord, error) {
+	return q.Supervisor.Attestations(ctx, chainID, block)
+}
+
 type AdminFrontend struct {
 	Supervisor Backend
+
+	// Log is the logger of which the log level can be changed at runtime.
+	Log log.Logger
 }
 
 var _ apis.SupervisorAdminAPI = (*AdminFrontend)(nil)
+var _ apis.SupervisorLoggingAPI = (*AdminFrontend)(nil)
+
+var errNoDynamicLogLevel = errors.New("log level cannot be changed at runtime")
 
 // Start starts the service, if it was previously stopped.
 func (a *AdminFrontend) Start(ctx context.Context) error {
@@ -89,3 +192,88 @@ func (a *AdminFrontend) Rewind(ctx context.Context, chain eth.ChainID, block eth
 	// TODO(#15665) add logging here to track when rewinds are requested
 	return a.Supervisor.Rewind(ctx, chain, block)
 }
+
+// RebuildLogIndex clears the log index of the given chain, and rebuilds it from the events DB in the background.
+func (a *AdminFrontend) RebuildLogIndex(ctx context.Context, chain eth.ChainID) error {
+	return a.Supervisor.RebuildLogIndex(ctx, chain)
+}
+
+// CircuitBreakerStatus returns the circuit breaker status of every chain.
+func (a *AdminFrontend) CircuitBreakerStatus(ctx context.Context) ([]types.CircuitBreakerStatus, error) {
+	return a.Supervisor.CircuitBreakerStatus(ctx)
+}
+
+// AcknowledgeCircuitBreaker acknowledges the anomaly that paused the cross-safe promotion of the given chain,
+// and resumes the cross-safe promotion.
+func (a *AdminFrontend) AcknowledgeCircuitBreaker(ctx context.Context, chain eth.ChainID) error {
+	return a.Supervisor.AcknowledgeCircuitBreaker(ctx, chain)
+}
+
+// PinL1 freezes the view of the supervisor on the L1 chain at the given block, to replay derivation deterministically.
+// L1 blocks beyond the pinned block are not accessed until unpinned.
+func (a *AdminFrontend) PinL1(ctx context.Context, blockHash common.Hash) (eth.L1BlockRef, error) {
+	return a.Supervisor.PinL1(ctx, blockHash)
+}
+
+// UnpinL1 resumes the view of the supervisor on the L1 chain beyond the pinned block.
+func (a *AdminFrontend) UnpinL1(ctx context.Context) error {
+	return a.Supervisor.UnpinL1(ctx)
+}
+
+// L1PinStatus returns whether the view of the supervisor on the L1 chain is pinned, and to which block.
+func (a *AdminFrontend) L1PinStatus(ctx context.Context) (types.L1PinStatus, error) {
+	return a.Supervisor.L1PinStatus(ctx)
+}
+
+// SetLogLevel changes the log level at runtime.
+// If a subsystem is given, only the log level of that subsystem changes, see ListLoggers for the known subsystems.
+// An empty log level resets the subsystem to the global log level.
+// Without subsystem, the global log level changes, which applies to all subsystems without their own log level.
+func (a *AdminFrontend) SetLogLevel(ctx context.Context, lvlStr string, subsystem *string) error {
+	h, err := a.logHandler()
+	if err != nil {
+		return err
+	}
+	if subsystem == nil || *subsystem == "" {
+		lvl, err := oplog.LevelFromString(lvlStr)
+		if err != nil {
+			return err
+		}
+		a.Log.Info("Changing global log level", "level", lvlStr)
+		h.SetLogLevel(lvl)
+		return nil
+	}
+	var lvl *slog.Level
+	if lvlStr != "" {
+		v, err := oplog.LevelFromString(lvlStr)
+		if err != nil {
+			return err
+		}
+		lvl = &v
+	}
+	a.Log.Info("Changing subsystem log level", "subsystem", *subsystem, "level", lvlStr)
+	return h.SetSubsystemLogLevel(*subsystem, lvl)
+}
+
+// ListLoggers returns the global log level, and the log level of each subsystem.
+func (a *AdminFrontend) ListLoggers(ctx context.Context) (apis.LoggersInfo, error) {
+	h, err := a.logHandler()
+	if err != nil {
+		return apis.LoggersInfo{}, err
+	}
+	return apis.LoggersInfo{
+		Level:      log.LevelString(h.LogLevel()),
+		Subsystems: h.SubsystemLevels(),
+	}, nil
+}
+
+func (a *AdminFrontend) logHandler() (*oplog.DynamicLogHandler, error) {
+	if a.Log == nil {
+		return nil, errNoDynamicLogLevel
+	}
+	h, ok := logmods.FindHandler[*oplog.DynamicLogHandler](a.Log.Handler())
+	if !ok {
+		return nil, fmt.Errorf("%w: log handler type %T", errNoDynamicLogLevel, a.Log.Handler())
+	}
+	return h, nil
+}
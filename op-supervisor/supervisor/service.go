@@ -9,11 +9,13 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
@@ -99,6 +101,7 @@ func (su *SupervisorService) initBackend(ctx context.Context, cfg *config.Config
 		return fmt.Errorf("failed to create supervisor backend: %w", err)
 	}
 	su.backend = be
+	su.metrics.SetProgressSource(be)
 	return nil
 }
 
@@ -129,6 +132,13 @@ func (su *SupervisorService) initPProf(cfg *config.Config) error {
 	return nil
 }
 
+// sloMetricsPath is the path of the metrics server that the SLO metrics are served on, in the OpenMetrics format.
+const sloMetricsPath = "/metrics/slo"
+
+type sloRegistryMetricer interface {
+	SLORegistry() *prometheus.Registry
+}
+
 func (su *SupervisorService) initMetricsServer(cfg *config.Config) error {
 	if !cfg.MetricsConfig.Enabled {
 		su.log.Info("Metrics disabled")
@@ -139,7 +149,11 @@ func (su *SupervisorService) initMetricsServer(cfg *config.Config) error {
 		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server: %w", su.metrics, errInvalidMetricer)
 	}
 	su.log.Debug("Starting metrics server", "addr", cfg.MetricsConfig.ListenAddr, "port", cfg.MetricsConfig.ListenPort)
-	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort)
+	var opts []opmetrics.ServerOption
+	if slo, ok := su.metrics.(sloRegistryMetricer); ok {
+		opts = append(opts, opmetrics.WithRegistry(sloMetricsPath, slo.SLORegistry()))
+	}
+	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.MetricsConfig.ListenAddr, cfg.MetricsConfig.ListenPort, opts...)
 	if err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -153,8 +167,9 @@ func (su *SupervisorService) initRPCServer(cfg *config.Config) error {
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		cfg.Version,
-		oprpc.WithLogger(su.log),
+		oprpc.WithLogger(oplog.SubsystemLogger(su.log, "rpc")),
 		oprpc.WithRPCRecorder(su.metrics.NewRecorder("main")),
+		oprpc.WithWebsocketEnabled(),
 	)
 	RegisterRPCs(su.log, cfg, server, su.backend, su.metrics)
 	su.rpcServer = server
@@ -170,13 +185,19 @@ func RegisterRPCs(logger log.Logger, cfg *config.Config, server RpcServer, backe
 		logger.Info("Admin RPC enabled")
 		server.AddAPI(rpc.API{
 			Namespace:     "admin",
-			Service:       &frontend.AdminFrontend{Supervisor: backend},
+			Service:       &frontend.AdminFrontend{Supervisor: backend, Log: logger},
 			Authenticated: true, // TODO(protocol-quest#286): enforce auth on this or not?
 		})
 	}
 	server.AddAPI(rpc.API{
-		Namespace:     "supervisor",
-		Service:       &frontend.QueryFrontend{Supervisor: backend},
+		Namespace: "supervisor",
+		Service: &frontend.QueryFrontend{
+			Supervisor:     backend,
+			Log:            oplog.SubsystemLogger(logger, "rpc"),
+			SuperRootsFeed: backend.SuperRootsFeed(),
+			EventsFeed:     backend.EventsFeed(),
+			Chains:         backend.Chains(),
+		},
 		Authenticated: false,
 	})
 }
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// attestationDomain separates the signing hash of attestations from other signed data.
+var attestationDomain = crypto.Keccak256Hash([]byte("op-supervisor attestation v0"))
+
+// Attestation is a statement of an external attestor, e.g. an alt-DA attestor or a sequencer committee,
+// about an L2 block. The supervisor does not interpret the kind and data,
+// it only verifies the signer, and makes the attestations available to the cross-safe policy.
+type Attestation struct {
+	ChainID eth.ChainID `json:"chainID"`
+	Block   eth.BlockID `json:"block"`
+	// Kind identifies what is attested, e.g. "alt-da".
+	Kind string `json:"kind"`
+	// Data is the optional payload of the statement.
+	Data hexutil.Bytes `json:"data,omitempty"`
+}
+
+// SigningHash returns the hash that attestors sign:
+// keccak256(domain ++ chainID ++ blockHash ++ uint64(blockNumber) ++ keccak256(kind) ++ keccak256(data)).
+func (a *Attestation) SigningHash() common.Hash {
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], a.Block.Number)
+	chainID := a.ChainID.Bytes32()
+	return crypto.Keccak256Hash(
+		attestationDomain[:],
+		chainID[:],
+		a.Block.Hash[:],
+		num[:],
+		crypto.Keccak256([]byte(a.Kind)),
+		crypto.Keccak256(a.Data),
+	)
+}
+
+// SignedAttestation is an attestation with the secp256k1 signature of its signing hash,
+// in the 65-byte [R || S || V] format, with V either 0/1 or 27/28.
+type SignedAttestation struct {
+	Attestation
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// AttestationRecord is a verified attestation, as stored by the supervisor.
+type AttestationRecord struct {
+	Attestation
+	Signer common.Address `json:"signer"`
+	// ReceivedAt is the unix timestamp, in seconds, of when the attestation was ingested.
+	ReceivedAt hexutil.Uint64 `json:"receivedAt"`
+}
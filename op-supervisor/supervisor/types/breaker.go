@@ -0,0 +1,31 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// CircuitBreakerAnomaly is an anomaly that trips the circuit breaker of a chain.
+type CircuitBreakerAnomaly string
+
+const (
+	// AnomalyMassInvalidation is a sudden burst of local-safe invalidations.
+	AnomalyMassInvalidation CircuitBreakerAnomaly = "mass-invalidation"
+	// AnomalyReplacementStorm is a sudden burst of block replacements.
+	AnomalyReplacementStorm CircuitBreakerAnomaly = "replacement-storm"
+	// AnomalyChecksumFailures is a spike in the rate of access checks that fail RPC verification.
+	AnomalyChecksumFailures CircuitBreakerAnomaly = "checksum-failures"
+)
+
+// CircuitBreakerStatus is the state of the circuit breaker of a chain.
+type CircuitBreakerStatus struct {
+	ChainID eth.ChainID `json:"chainID"`
+	// Paused is true if the cross-safe promotion of the chain is paused, until acknowledged by the operator.
+	Paused bool `json:"paused"`
+	// Anomaly, Reason and TrippedAt describe what tripped the breaker. Empty if not paused.
+	Anomaly CircuitBreakerAnomaly `json:"anomaly,omitempty"`
+	Reason  string                `json:"reason,omitempty"`
+	// TrippedAt is the unix timestamp, in seconds, of when the breaker tripped.
+	TrippedAt hexutil.Uint64 `json:"trippedAt,omitempty"`
+}
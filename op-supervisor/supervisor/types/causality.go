@@ -0,0 +1,136 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// CausalityEdgeKind is the kind of dependency that an edge of a causality graph describes.
+type CausalityEdgeKind string
+
+const (
+	// DerivationEdge links an L1 block to an L2 block that was derived from it.
+	DerivationEdge CausalityEdgeKind = "derivation"
+	// MessageEdge links the L2 block of an initiating message to the L2 block that executes it.
+	MessageEdge CausalityEdgeKind = "message"
+)
+
+// CausalityNode is a block in a causality graph.
+type CausalityNode struct {
+	// ID identifies the node in the graph: "l1:<number>" for L1 blocks, and "<chainID>:<number>" for L2 blocks.
+	ID string `json:"id"`
+	// ChainID is the chain of an L2 block, nil for L1 blocks.
+	ChainID *eth.ChainID `json:"chainID,omitempty"`
+	Block   BlockSeal    `json:"block"`
+	// Safety is the safety level of an L2 block, empty for L1 blocks and missing blocks.
+	Safety SafetyLevel `json:"safety,omitempty"`
+	// External is set for L2 blocks outside the time range of the graph,
+	// that are included because a block in the time range executes one of their messages.
+	External bool `json:"external,omitempty"`
+	// Missing is set for L2 blocks that are not indexed by the supervisor,
+	// e.g. because they are not synced yet. Only the number of a missing block is known.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// CausalityEdge is a dependency between two blocks of a causality graph.
+// The To block can only be promoted to cross-safe once the From block is.
+type CausalityEdge struct {
+	Kind CausalityEdgeKind `json:"kind"`
+	From string            `json:"from"`
+	To   string            `json:"to"`
+	// InitLogIndex and ExecLogIndex are the indexes of the initiating and executing logs of a message edge.
+	InitLogIndex uint32 `json:"initLogIndex,omitempty"`
+	ExecLogIndex uint32 `json:"execLogIndex,omitempty"`
+}
+
+// CausalityGraph is the graph of the L2 blocks with a timestamp in a time range, across all chains,
+// with the L1 blocks they were derived from, and the L2 blocks of the messages they execute.
+type CausalityGraph struct {
+	FromTimestamp uint64          `json:"fromTimestamp"`
+	ToTimestamp   uint64          `json:"toTimestamp"`
+	Nodes         []CausalityNode `json:"nodes"`
+	Edges         []CausalityEdge `json:"edges"`
+	// Truncated is set if the time range contains more blocks of a chain than the graph can include.
+	// The graph then only includes the oldest blocks of the chain in the time range.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// L1CausalityNodeID returns the ID of the node of the L1 block with the given number.
+func L1CausalityNodeID(number uint64) string {
+	return fmt.Sprintf("l1:%d", number)
+}
+
+// L2CausalityNodeID returns the ID of the node of the L2 block of the chain with the given number.
+func L2CausalityNodeID(chainID eth.ChainID, number uint64) string {
+	return fmt.Sprintf("%s:%d", chainID, number)
+}
+
+// causalityNodeColors are the fill colors of the L2 blocks in the GraphViz rendering, by safety level.
+var causalityNodeColors = map[SafetyLevel]string{
+	Finalized:   "darkolivegreen3",
+	CrossSafe:   "palegreen",
+	LocalSafe:   "khaki",
+	CrossUnsafe: "lightblue",
+	LocalUnsafe: "lightgrey",
+}
+
+// DOT renders the graph in the GraphViz DOT language, with a cluster of blocks per chain.
+// Derivation edges are dashed, and message edges are labeled with their log indexes.
+func (g *CausalityGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph causality {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=filled, fillcolor=white];\n")
+
+	// group the nodes by chain, keeping the order of the graph
+	var clusters []string
+	nodes := make(map[string][]CausalityNode)
+	for _, n := range g.Nodes {
+		cluster := "L1"
+		if n.ChainID != nil {
+			cluster = "chain " + n.ChainID.String()
+		}
+		if _, ok := nodes[cluster]; !ok {
+			clusters = append(clusters, cluster)
+		}
+		nodes[cluster] = append(nodes[cluster], n)
+	}
+	for i, cluster := range clusters {
+		fmt.Fprintf(&b, "  subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "    label=%q;\n", cluster)
+		for _, n := range nodes[cluster] {
+			fmt.Fprintf(&b, "    %q [%s];\n", n.ID, causalityNodeAttrs(n))
+		}
+		b.WriteString("  }\n")
+	}
+	for _, e := range g.Edges {
+		switch e.Kind {
+		case DerivationEdge:
+			fmt.Fprintf(&b, "  %q -> %q [style=dashed, color=grey];\n", e.From, e.To)
+		default:
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", e.From, e.To, fmt.Sprintf("log %d -> %d", e.InitLogIndex, e.ExecLogIndex))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func causalityNodeAttrs(n CausalityNode) string {
+	if n.Missing {
+		return fmt.Sprintf("label=%q, style=dashed", fmt.Sprintf("#%d\nmissing", n.Block.Number))
+	}
+	label := fmt.Sprintf("#%d\n%s\nt=%d", n.Block.Number, n.Block.Hash.TerminalString(), n.Block.Timestamp)
+	if n.Safety != "" {
+		label += "\n" + n.Safety.String()
+	}
+	attrs := fmt.Sprintf("label=%q", label)
+	if color, ok := causalityNodeColors[n.Safety]; ok {
+		attrs += fmt.Sprintf(", fillcolor=%q", color)
+	}
+	if n.External {
+		attrs += ", penwidth=2, color=grey"
+	}
+	return attrs
+}
@@ -44,4 +44,13 @@ var (
 	ErrNoRPCSource = errors.New("no RPC client configured")
 	// ErrUninitialized happens when a chain database is not initialized yet
 	ErrUninitialized = errors.New("uninitialized chain database")
+	// ErrIncompatibleProtocol happens when a managed node speaks a version of the managed-mode protocol
+	// that the supervisor cannot manage.
+	ErrIncompatibleProtocol = errors.New("incompatible managed-mode protocol")
+	// ErrInconsistentRead happens when a consistent view across the chain databases cannot be read,
+	// because writes kept interleaving with the read.
+	ErrInconsistentRead = errors.New("inconsistent read")
+	// ErrDependencySetMismatch happens when a managed node is configured with a different dependency set
+	// than the supervisor.
+	ErrDependencySetMismatch = errors.New("dependency set mismatch")
 )
@@ -0,0 +1,155 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SupervisorEventType is the type of an event of the supervisor events subscription.
+type SupervisorEventType string
+
+const (
+	EventLocalUnsafe      SupervisorEventType = "local-unsafe"
+	EventCrossUnsafe      SupervisorEventType = "cross-unsafe"
+	EventLocalSafe        SupervisorEventType = "local-safe"
+	EventCrossSafe        SupervisorEventType = "cross-safe"
+	EventFinalized        SupervisorEventType = "finalized"
+	EventChainRewound     SupervisorEventType = "chain-rewound"
+	EventExecutingMessage SupervisorEventType = "executing-message"
+)
+
+var SupervisorEventTypes = []SupervisorEventType{
+	EventLocalUnsafe,
+	EventCrossUnsafe,
+	EventLocalSafe,
+	EventCrossSafe,
+	EventFinalized,
+	EventChainRewound,
+	EventExecutingMessage,
+}
+
+// SupervisorEvent is an event of the supervisor events subscription.
+type SupervisorEvent struct {
+	Type    SupervisorEventType `json:"type"`
+	ChainID eth.ChainID         `json:"chainID"`
+	// Block is the block that the event is about. Empty for chain-rewound events.
+	Block eth.BlockID `json:"block"`
+	// Source is the L1 block that Block was derived from, for local-safe and cross-safe events.
+	Source *eth.BlockID `json:"source,omitempty"`
+	// Message is the executing message, for executing-message events.
+	// The message is executed by a log in Block.
+	Message *ExecutingMessage `json:"message,omitempty"`
+}
+
+var (
+	ErrUnknownEventType   = errors.New("unknown event type")
+	ErrInvalidBlockRange  = errors.New("invalid block range")
+	ErrUnknownEventChain  = errors.New("unknown chain")
+	ErrUnmatchableMessage = errors.New("message filter can not match the filtered event types")
+)
+
+// EventFilter selects the events of the supervisor events subscription.
+// Every non-empty criterion must match for an event to be sent. An empty filter matches every event.
+type EventFilter struct {
+	// ChainIDs matches events of any of the given chains.
+	ChainIDs []eth.ChainID `json:"chainIDs,omitempty"`
+	// Types matches events of any of the given types.
+	Types []SupervisorEventType `json:"types,omitempty"`
+	// FromBlock and ToBlock match events with a block number in the inclusive range.
+	// Chain-rewound events are not about a block, and are not filtered out by a block range.
+	FromBlock *hexutil.Uint64 `json:"fromBlock,omitempty"`
+	ToBlock   *hexutil.Uint64 `json:"toBlock,omitempty"`
+	// Messages matches executing-message events that execute any of the messages with the given checksums.
+	Messages []MessageChecksum `json:"messages,omitempty"`
+}
+
+// CompiledEventFilter is a validated EventFilter, to match events with.
+type CompiledEventFilter struct {
+	chains   map[eth.ChainID]struct{}
+	types    map[SupervisorEventType]struct{}
+	from, to *uint64
+	messages map[MessageChecksum]struct{}
+}
+
+// Compile validates the filter, and prepares it for matching.
+// Chains are validated against the given known chains.
+func (f EventFilter) Compile(knownChains []eth.ChainID) (*CompiledEventFilter, error) {
+	c := &CompiledEventFilter{}
+	if len(f.ChainIDs) > 0 {
+		c.chains = make(map[eth.ChainID]struct{})
+		for _, id := range f.ChainIDs {
+			if !slices.Contains(knownChains, id) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownEventChain, id)
+			}
+			c.chains[id] = struct{}{}
+		}
+	}
+	if len(f.Types) > 0 {
+		c.types = make(map[SupervisorEventType]struct{})
+		for _, typ := range f.Types {
+			if !slices.Contains(SupervisorEventTypes, typ) {
+				return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, typ)
+			}
+			c.types[typ] = struct{}{}
+		}
+	}
+	if f.FromBlock != nil && f.ToBlock != nil && *f.FromBlock > *f.ToBlock {
+		return nil, fmt.Errorf("%w: from block %d is after to block %d", ErrInvalidBlockRange, *f.FromBlock, *f.ToBlock)
+	}
+	if f.FromBlock != nil {
+		from := uint64(*f.FromBlock)
+		c.from = &from
+	}
+	if f.ToBlock != nil {
+		to := uint64(*f.ToBlock)
+		c.to = &to
+	}
+	if len(f.Messages) > 0 {
+		if c.types != nil {
+			if _, ok := c.types[EventExecutingMessage]; !ok {
+				return nil, ErrUnmatchableMessage
+			}
+		}
+		c.messages = make(map[MessageChecksum]struct{})
+		for _, m := range f.Messages {
+			c.messages[m] = struct{}{}
+		}
+	}
+	return c, nil
+}
+
+// Match returns true if the event matches the filter.
+func (c *CompiledEventFilter) Match(ev SupervisorEvent) bool {
+	if c.chains != nil {
+		if _, ok := c.chains[ev.ChainID]; !ok {
+			return false
+		}
+	}
+	if c.types != nil {
+		if _, ok := c.types[ev.Type]; !ok {
+			return false
+		}
+	}
+	if (c.from != nil || c.to != nil) && ev.Type != EventChainRewound {
+		if c.from != nil && ev.Block.Number < *c.from {
+			return false
+		}
+		if c.to != nil && ev.Block.Number > *c.to {
+			return false
+		}
+	}
+	if c.messages != nil {
+		if ev.Message == nil {
+			return false
+		}
+		if _, ok := c.messages[ev.Message.Checksum]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestEventFilterCompile(t *testing.T) {
+	chainA := eth.ChainIDFromUInt64(900)
+	chainB := eth.ChainIDFromUInt64(901)
+	known := []eth.ChainID{chainA, chainB}
+	u64 := func(v uint64) *hexutil.Uint64 {
+		x := hexutil.Uint64(v)
+		return &x
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := EventFilter{}.Compile(known)
+		require.NoError(t, err)
+	})
+	t.Run("unknown chain", func(t *testing.T) {
+		_, err := EventFilter{ChainIDs: []eth.ChainID{eth.ChainIDFromUInt64(1)}}.Compile(known)
+		require.ErrorIs(t, err, ErrUnknownEventChain)
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := EventFilter{Types: []SupervisorEventType{"unsafe"}}.Compile(known)
+		require.ErrorIs(t, err, ErrUnknownEventType)
+	})
+	t.Run("invalid block range", func(t *testing.T) {
+		_, err := EventFilter{FromBlock: u64(10), ToBlock: u64(9)}.Compile(known)
+		require.ErrorIs(t, err, ErrInvalidBlockRange)
+		_, err = EventFilter{FromBlock: u64(10), ToBlock: u64(10)}.Compile(known)
+		require.NoError(t, err)
+	})
+	t.Run("unmatchable message", func(t *testing.T) {
+		filter := EventFilter{Types: []SupervisorEventType{EventCrossSafe}, Messages: []MessageChecksum{{1}}}
+		_, err := filter.Compile(known)
+		require.ErrorIs(t, err, ErrUnmatchableMessage)
+		filter.Types = append(filter.Types, EventExecutingMessage)
+		_, err = filter.Compile(known)
+		require.NoError(t, err)
+	})
+}
+
+func TestEventFilterMatch(t *testing.T) {
+	chainA := eth.ChainIDFromUInt64(900)
+	chainB := eth.ChainIDFromUInt64(901)
+	known := []eth.ChainID{chainA, chainB}
+	from, to := hexutil.Uint64(10), hexutil.Uint64(20)
+	msg := &ExecutingMessage{Checksum: MessageChecksum{1}}
+	otherMsg := &ExecutingMessage{Checksum: MessageChecksum{2}}
+
+	testCases := []struct {
+		name   string
+		filter EventFilter
+		ev     SupervisorEvent
+		match  bool
+	}{
+		{"empty", EventFilter{}, SupervisorEvent{Type: EventLocalUnsafe, ChainID: chainA}, true},
+		{"chain", EventFilter{ChainIDs: []eth.ChainID{chainA}}, SupervisorEvent{Type: EventLocalUnsafe, ChainID: chainA}, true},
+		{"other chain", EventFilter{ChainIDs: []eth.ChainID{chainA}}, SupervisorEvent{Type: EventLocalUnsafe, ChainID: chainB}, false},
+		{"type", EventFilter{Types: []SupervisorEventType{EventCrossSafe}}, SupervisorEvent{Type: EventCrossSafe, ChainID: chainA}, true},
+		{"other type", EventFilter{Types: []SupervisorEventType{EventCrossSafe}}, SupervisorEvent{Type: EventLocalSafe, ChainID: chainA}, false},
+		{"in range", EventFilter{FromBlock: &from, ToBlock: &to}, SupervisorEvent{Type: EventLocalUnsafe, Block: eth.BlockID{Number: 10}}, true},
+		{"end of range", EventFilter{FromBlock: &from, ToBlock: &to}, SupervisorEvent{Type: EventLocalUnsafe, Block: eth.BlockID{Number: 20}}, true},
+		{"before range", EventFilter{FromBlock: &from}, SupervisorEvent{Type: EventLocalUnsafe, Block: eth.BlockID{Number: 9}}, false},
+		{"after range", EventFilter{ToBlock: &to}, SupervisorEvent{Type: EventLocalUnsafe, Block: eth.BlockID{Number: 21}}, false},
+		{"rewound out of range", EventFilter{FromBlock: &from}, SupervisorEvent{Type: EventChainRewound}, true},
+		{"message", EventFilter{Messages: []MessageChecksum{{1}}}, SupervisorEvent{Type: EventExecutingMessage, Message: msg}, true},
+		{"other message", EventFilter{Messages: []MessageChecksum{{1}}}, SupervisorEvent{Type: EventExecutingMessage, Message: otherMsg}, false},
+		{"no message", EventFilter{Messages: []MessageChecksum{{1}}}, SupervisorEvent{Type: EventLocalUnsafe}, false},
+		{"all criteria", EventFilter{
+			ChainIDs:  []eth.ChainID{chainB},
+			Types:     []SupervisorEventType{EventExecutingMessage},
+			FromBlock: &from,
+			Messages:  []MessageChecksum{{1}},
+		}, SupervisorEvent{Type: EventExecutingMessage, ChainID: chainB, Block: eth.BlockID{Number: 15}, Message: msg}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			compiled, err := tc.filter.Compile(known)
+			require.NoError(t, err)
+			require.Equal(t, tc.match, compiled.Match(tc.ev))
+		})
+	}
+}
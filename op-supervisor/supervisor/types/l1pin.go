@@ -0,0 +1,17 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// L1PinStatus is the status of the pin of the L1 view of the supervisor.
+// While pinned, the supervisor does not access L1 blocks beyond the pinned block.
+type L1PinStatus struct {
+	Pinned bool `json:"pinned"`
+	// Block is the L1 block that the view is pinned to. Nil if not pinned.
+	Block *eth.L1BlockRef `json:"block,omitempty"`
+	// PinnedAt is the unix timestamp, in seconds, of when the view was pinned.
+	PinnedAt hexutil.Uint64 `json:"pinnedAt,omitempty"`
+}
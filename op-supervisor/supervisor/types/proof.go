@@ -0,0 +1,176 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// The trees of a LogProof are binary merkle trees as specified in RFC 6962, with keccak256 as hash function:
+// leaf hashes are prefixed with 0x00, and inner nodes with 0x01, so a leaf cannot be mistaken for a node.
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// LogLeaf returns the leaf hash of a log in the logs tree of a block.
+func LogLeaf(logHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte{merkleLeafPrefix}, logHash[:])
+}
+
+// BlockLeaf returns the leaf hash of a block in the blocks tree of a chain,
+// which commits to the block and to the tree of its logs.
+func BlockLeaf(block BlockSeal, logCount uint32, logsRoot common.Hash) common.Hash {
+	var data [1 + 8 + 8 + 32 + 4 + 32]byte
+	data[0] = merkleLeafPrefix
+	binary.BigEndian.PutUint64(data[1:9], block.Number)
+	binary.BigEndian.PutUint64(data[9:17], block.Timestamp)
+	copy(data[17:49], block.Hash[:])
+	binary.BigEndian.PutUint32(data[49:53], logCount)
+	copy(data[53:85], logsRoot[:])
+	return crypto.Keccak256Hash(data[:])
+}
+
+func merkleNode(left, right common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte{merkleNodePrefix}, left[:], right[:])
+}
+
+// merkleSplit returns the largest power of two smaller than n, for n > 1.
+func merkleSplit(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+// MerkleRoot returns the root of the tree of the given leaf hashes.
+// The root of an empty tree is the hash of empty input.
+func MerkleRoot(leaves []common.Hash) common.Hash {
+	switch len(leaves) {
+	case 0:
+		return crypto.Keccak256Hash()
+	case 1:
+		return leaves[0]
+	}
+	k := merkleSplit(len(leaves))
+	return merkleNode(MerkleRoot(leaves[:k]), MerkleRoot(leaves[k:]))
+}
+
+// MerkleProof returns the inclusion path of the leaf at the given index, from the leaf up to the root.
+func MerkleProof(leaves []common.Hash, index int) []common.Hash {
+	if len(leaves) <= 1 {
+		return []common.Hash{}
+	}
+	k := merkleSplit(len(leaves))
+	if index < k {
+		return append(MerkleProof(leaves[:k], index), MerkleRoot(leaves[k:]))
+	}
+	return append(MerkleProof(leaves[k:], index-k), MerkleRoot(leaves[:k]))
+}
+
+// VerifyMerkleProof checks the inclusion path of the leaf at the given index of a tree of the given size.
+func VerifyMerkleProof(leaf common.Hash, index uint64, size uint64, path []common.Hash, root common.Hash) error {
+	if index >= size {
+		return fmt.Errorf("%w: leaf %d is not in a tree of %d leaves", ErrInvalidProof, index, size)
+	}
+	fn, sn, r := index, size-1, leaf
+	for _, p := range path {
+		if sn == 0 {
+			return fmt.Errorf("%w: path is too long", ErrInvalidProof)
+		}
+		if fn&1 == 1 || fn == sn {
+			r = merkleNode(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = merkleNode(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return fmt.Errorf("%w: path is too short", ErrInvalidProof)
+	}
+	if r != root {
+		return fmt.Errorf("%w: computed root %s does not match %s", ErrInvalidProof, r, root)
+	}
+	return nil
+}
+
+// LogProof proves that a log is included in a block of a chain, at or before the cross-safe head of the chain.
+//
+// The blocks tree has a leaf for every block from FirstBlock up to and including the cross-safe head.
+// Its root commits to the cross-safe head, and to the blocks and logs before it:
+// clients can compare it with the root served by other supervisors, or by the same supervisor later on,
+// instead of trusting a single supervisor instance.
+type LogProof struct {
+	ChainID eth.ChainID `json:"chainID"`
+
+	// Block is the block that includes the log.
+	Block    BlockSeal   `json:"block"`
+	LogIndex uint32      `json:"logIndex"`
+	LogHash  common.Hash `json:"logHash"`
+	// LogCount is the number of logs of the block. LogsRoot is the root of the tree of their leaves,
+	// and LogPath the inclusion path of the log in it.
+	LogCount uint32        `json:"logCount"`
+	LogsRoot common.Hash   `json:"logsRoot"`
+	LogPath  []common.Hash `json:"logPath"`
+
+	FirstBlock uint64      `json:"firstBlock"`
+	BlocksRoot common.Hash `json:"blocksRoot"`
+	// BlockPath is the inclusion path of the block in the blocks tree.
+	BlockPath []common.Hash `json:"blockPath"`
+
+	// CrossSafe is the cross-safe head that the proof is bound to, the last leaf of the blocks tree.
+	CrossSafe         BlockSeal     `json:"crossSafe"`
+	CrossSafeLogCount uint32        `json:"crossSafeLogCount"`
+	CrossSafeLogsRoot common.Hash   `json:"crossSafeLogsRoot"`
+	CrossSafePath     []common.Hash `json:"crossSafePath"`
+}
+
+// Verify checks that the proof is consistent: the log is included in the block,
+// and the block and the cross-safe head are included in the blocks tree.
+func (p *LogProof) Verify() error {
+	if p.LogIndex >= p.LogCount {
+		return fmt.Errorf("%w: log %d is not in a block with %d logs", ErrInvalidProof, p.LogIndex, p.LogCount)
+	}
+	if err := VerifyMerkleProof(LogLeaf(p.LogHash), uint64(p.LogIndex), uint64(p.LogCount), p.LogPath, p.LogsRoot); err != nil {
+		return fmt.Errorf("log is not included in block %s: %w", p.Block, err)
+	}
+	if p.Block.Number < p.FirstBlock || p.Block.Number > p.CrossSafe.Number {
+		return fmt.Errorf("%w: block %d is outside of the blocks tree [%d, %d]", ErrInvalidProof, p.Block.Number, p.FirstBlock, p.CrossSafe.Number)
+	}
+	size := p.CrossSafe.Number - p.FirstBlock + 1
+	blockLeaf := BlockLeaf(p.Block, p.LogCount, p.LogsRoot)
+	if err := VerifyMerkleProof(blockLeaf, p.Block.Number-p.FirstBlock, size, p.BlockPath, p.BlocksRoot); err != nil {
+		return fmt.Errorf("block %s is not included in the blocks tree: %w", p.Block, err)
+	}
+	headLeaf := BlockLeaf(p.CrossSafe, p.CrossSafeLogCount, p.CrossSafeLogsRoot)
+	if err := VerifyMerkleProof(headLeaf, size-1, size, p.CrossSafePath, p.BlocksRoot); err != nil {
+		return fmt.Errorf("cross-safe head %s is not included in the blocks tree: %w", p.CrossSafe, err)
+	}
+	return nil
+}
+
+// VerifyMessage checks that the proof is consistent, and that it proves the given message.
+func (p *LogProof) VerifyMessage(msg Message) error {
+	id := msg.Identifier
+	if id.ChainID != p.ChainID || id.BlockNumber != p.Block.Number || id.LogIndex != p.LogIndex {
+		return fmt.Errorf("%w: proof of log %d of block %d of chain %s does not match the message identifier",
+			ErrInvalidProof, p.LogIndex, p.Block.Number, p.ChainID)
+	}
+	if id.Timestamp != p.Block.Timestamp {
+		return fmt.Errorf("%w: message timestamp %d does not match block timestamp %d", ErrInvalidProof, id.Timestamp, p.Block.Timestamp)
+	}
+	if logHash := PayloadHashToLogHash(msg.PayloadHash, id.Origin); logHash != p.LogHash {
+		return fmt.Errorf("%w: message log hash %s does not match proven log hash %s", ErrInvalidProof, logHash, p.LogHash)
+	}
+	return p.Verify()
+}
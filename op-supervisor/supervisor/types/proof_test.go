@@ -0,0 +1,124 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testLeaves(n int) []common.Hash {
+	out := make([]common.Hash, n)
+	for i := range out {
+		out[i] = LogLeaf(common.Hash{byte(i), byte(i >> 8)})
+	}
+	return out
+}
+
+func TestMerkleRoot(t *testing.T) {
+	require.Equal(t, crypto.Keccak256Hash(), MerkleRoot(nil))
+	leaves := testLeaves(3)
+	require.Equal(t, leaves[0], MerkleRoot(leaves[:1]))
+	require.Equal(t, merkleNode(leaves[0], leaves[1]), MerkleRoot(leaves[:2]))
+	require.Equal(t, merkleNode(merkleNode(leaves[0], leaves[1]), leaves[2]), MerkleRoot(leaves))
+	// leaves and nodes are domain separated
+	require.NotEqual(t, merkleNode(leaves[0], leaves[1]), crypto.Keccak256Hash(leaves[0][:], leaves[1][:]))
+}
+
+func TestMerkleProof(t *testing.T) {
+	for size := 1; size <= 33; size++ {
+		leaves := testLeaves(size)
+		root := MerkleRoot(leaves)
+		for i := range leaves {
+			path := MerkleProof(leaves, i)
+			require.NoError(t, VerifyMerkleProof(leaves[i], uint64(i), uint64(size), path, root), "leaf %d of %d", i, size)
+
+			if size > 1 {
+				other := (i + 1) % size
+				require.ErrorIs(t, VerifyMerkleProof(leaves[other], uint64(i), uint64(size), path, root), ErrInvalidProof)
+				require.ErrorIs(t, VerifyMerkleProof(leaves[i], uint64(i), uint64(size), path[:len(path)-1], root), ErrInvalidProof)
+				tampered := append([]common.Hash{}, path...)
+				tampered[0][0] ^= 1
+				require.ErrorIs(t, VerifyMerkleProof(leaves[i], uint64(i), uint64(size), tampered, root), ErrInvalidProof)
+			}
+			require.ErrorIs(t, VerifyMerkleProof(leaves[i], uint64(i), uint64(size), append(path, root), root), ErrInvalidProof)
+		}
+		require.ErrorIs(t, VerifyMerkleProof(leaves[0], uint64(size), uint64(size), nil, root), ErrInvalidProof)
+	}
+}
+
+func TestLogProofVerify(t *testing.T) {
+	origin := common.Address{0xee}
+	payloadHash := common.Hash{0xaa}
+	block := BlockSeal{Hash: common.Hash{0xbb}, Number: 12, Timestamp: 24}
+	head := BlockSeal{Hash: common.Hash{0xcc}, Number: 14, Timestamp: 28}
+
+	logLeaves := testLeaves(3)
+	logLeaves[1] = LogLeaf(PayloadHashToLogHash(payloadHash, origin))
+	blockLeaves := testLeaves(5)
+	blockLeaves[2] = BlockLeaf(block, 3, MerkleRoot(logLeaves))
+	blockLeaves[4] = BlockLeaf(head, 0, MerkleRoot(nil))
+
+	proof := &LogProof{
+		Block:             block,
+		LogIndex:          1,
+		LogHash:           PayloadHashToLogHash(payloadHash, origin),
+		LogCount:          3,
+		LogsRoot:          MerkleRoot(logLeaves),
+		LogPath:           MerkleProof(logLeaves, 1),
+		FirstBlock:        10,
+		BlocksRoot:        MerkleRoot(blockLeaves),
+		BlockPath:         MerkleProof(blockLeaves, 2),
+		CrossSafe:         head,
+		CrossSafeLogCount: 0,
+		CrossSafeLogsRoot: MerkleRoot(nil),
+		CrossSafePath:     MerkleProof(blockLeaves, 4),
+	}
+	msg := Message{
+		Identifier: Identifier{
+			Origin:      origin,
+			BlockNumber: 12,
+			LogIndex:    1,
+			Timestamp:   24,
+			ChainID:     proof.ChainID,
+		},
+		PayloadHash: payloadHash,
+	}
+	require.NoError(t, proof.Verify())
+	require.NoError(t, proof.VerifyMessage(msg))
+
+	t.Run("wrong message", func(t *testing.T) {
+		wrongTime := msg
+		wrongTime.Identifier.Timestamp = 25
+		require.ErrorIs(t, proof.VerifyMessage(wrongTime), ErrInvalidProof)
+		wrongOrigin := msg
+		wrongOrigin.Identifier.Origin = common.Address{0xef}
+		require.ErrorIs(t, proof.VerifyMessage(wrongOrigin), ErrInvalidProof)
+	})
+
+	t.Run("wrong block", func(t *testing.T) {
+		p := *proof
+		p.Block.Hash = common.Hash{0xbc}
+		require.ErrorIs(t, p.Verify(), ErrInvalidProof)
+	})
+
+	t.Run("wrong cross-safe head", func(t *testing.T) {
+		p := *proof
+		p.CrossSafe.Hash = common.Hash{0xcd}
+		require.ErrorIs(t, p.Verify(), ErrInvalidProof)
+		p = *proof
+		p.CrossSafeLogCount = 1
+		require.ErrorIs(t, p.Verify(), ErrInvalidProof)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		p := *proof
+		p.FirstBlock = 13
+		require.ErrorIs(t, p.Verify(), ErrInvalidProof)
+		p = *proof
+		p.LogCount = 1
+		require.ErrorIs(t, p.Verify(), ErrInvalidProof)
+	})
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ManagedProtocolVersion is the version of the managed-mode RPC protocol between the supervisor
+	// and the nodes it manages. Optional features are signaled with capabilities instead,
+	// the version only changes with changes that a supervisor of an older version cannot manage.
+	ManagedProtocolVersion uint64 = 1
+	// MinManagedProtocolVersion is the oldest version of the managed-mode RPC protocol that the supervisor can manage.
+	// Version 0 is the protocol of nodes that predate the protocol handshake.
+	MinManagedProtocolVersion uint64 = 0
+)
+
+// ManagedCapability is an optional feature of the managed-mode RPC protocol, that a managed node may support.
+type ManagedCapability string
+
+const (
+	// CapabilityEventsSubscription is the support of the "events" RPC subscription.
+	// Events of nodes without it are pulled with interop_pullEvent.
+	CapabilityEventsSubscription ManagedCapability = "events-subscription"
+	// CapabilityResetPreInterop is the support of interop_resetPreInterop.
+	CapabilityResetPreInterop ManagedCapability = "reset-pre-interop"
+	// CapabilityReplayDerivation is the support of interop_replayDerivation.
+	// Nodes only report it if they can replay derivation.
+	CapabilityReplayDerivation ManagedCapability = "replay-derivation"
+)
+
+// ManagedCapabilities are all the capabilities of the managed-mode RPC protocol.
+var ManagedCapabilities = []ManagedCapability{
+	CapabilityEventsSubscription,
+	CapabilityResetPreInterop,
+	CapabilityReplayDerivation,
+}
+
+// LegacyManagedProtocol is the protocol of nodes that predate the protocol handshake.
+// These nodes are assumed to have the capabilities that the supervisor relied on before the handshake,
+// so they are managed like before.
+var LegacyManagedProtocol = ManagedProtocol{
+	Version:      0,
+	Capabilities: []ManagedCapability{CapabilityEventsSubscription, CapabilityResetPreInterop},
+}
+
+// ManagedProtocol is the version of the managed-mode RPC protocol and the capabilities of a managed node,
+// as reported by the node when the supervisor connects to it.
+type ManagedProtocol struct {
+	Version      uint64              `json:"version"`
+	Capabilities []ManagedCapability `json:"capabilities"`
+	// DependencySetHash is the hash of the dependency set that the node is configured with, see depset.Hash.
+	// It is nil if the node does not report it, e.g. if the node predates it, or has no dependency set configured.
+	DependencySetHash *common.Hash `json:"dependencySetHash,omitempty"`
+}
+
+// Supports returns whether the node has the capability.
+func (p ManagedProtocol) Supports(c ManagedCapability) bool {
+	return slices.Contains(p.Capabilities, c)
+}
+
+// Check returns an ErrIncompatibleProtocol error if the supervisor cannot manage a node of the protocol.
+// Nodes of a newer version are managed with the features of the version of the supervisor.
+func (p ManagedProtocol) Check() error {
+	if p.Version < MinManagedProtocolVersion {
+		return fmt.Errorf("%w: node speaks version %d, but at least version %d is required",
+			ErrIncompatibleProtocol, p.Version, MinManagedProtocolVersion)
+	}
+	return nil
+}
+
+// CheckDependencySet returns an ErrDependencySetMismatch error if the node reported a different dependency set hash
+// than the given hash of the dependency set of the supervisor. Nodes that do not report a hash are not checked.
+func (p ManagedProtocol) CheckDependencySet(hash common.Hash) error {
+	if p.DependencySetHash != nil && *p.DependencySetHash != hash {
+		return fmt.Errorf("%w: node has dependency set %s, but the supervisor has %s",
+			ErrDependencySetMismatch, *p.DependencySetHash, hash)
+	}
+	return nil
+}
+
+func (p ManagedProtocol) String() string {
+	return fmt.Sprintf("v%d%v", p.Version, p.Capabilities)
+}
@@ -197,6 +197,28 @@ func (args ChecksumArgs) Query() ContainsQuery {
 	}
 }
 
+// ChecksumPreimage is the components that a message checksum is computed from, as indexed by the supervisor.
+// The payload hash of the initiating log is not indexed: the log hash commits to it, together with the origin
+// address of the log, see PayloadHashToLogHash.
+type ChecksumPreimage struct {
+	Checksum    MessageChecksum `json:"checksum"`
+	ChainID     eth.ChainID     `json:"chainID"`
+	BlockNumber uint64          `json:"blockNumber"`
+	LogIndex    uint32          `json:"logIndex"`
+	Timestamp   uint64          `json:"timestamp"`
+	LogHash     common.Hash     `json:"logHash"`
+}
+
+func (p ChecksumPreimage) ChecksumArgs() ChecksumArgs {
+	return ChecksumArgs{
+		BlockNumber: p.BlockNumber,
+		LogIndex:    p.LogIndex,
+		Timestamp:   p.Timestamp,
+		ChainID:     p.ChainID,
+		LogHash:     p.LogHash,
+	}
+}
+
 type Identifier struct {
 	Origin      common.Address
 	BlockNumber uint64
@@ -480,11 +502,102 @@ func (ids DerivedIDPair) String() string {
 	return fmt.Sprintf("idPair(source: %s, derived: %s)", ids.Source, ids.Derived)
 }
 
+// SuperRootRecord is a super root that the supervisor computed and stored,
+// once every chain in the dependency set was cross-safe at (or past) Timestamp.
+type SuperRootRecord struct {
+	Timestamp uint64      `json:"timestamp"`
+	SuperRoot eth.Bytes32 `json:"superRoot"`
+	// CrossSafeDerivedFrom is the L1 block that all chains were cross-safe derived from, at the time of recording.
+	CrossSafeDerivedFrom eth.BlockID `json:"crossSafeDerivedFrom"`
+}
+
+func (r SuperRootRecord) String() string {
+	return fmt.Sprintf("superRoot(timestamp: %d, root: %s, derivedFrom: %s)", r.Timestamp, r.SuperRoot, r.CrossSafeDerivedFrom)
+}
+
+// CrossSafeConstraint describes which dependency is holding back the cross-safe progress of a chain.
+// The cross-safe head of a chain cannot pass the local-safe timestamp of any of its dependencies,
+// so the dependency with the lowest local-safe timestamp is the binding constraint.
+type CrossSafeConstraint struct {
+	LocalSafe BlockSeal `json:"localSafe"`
+	CrossSafe BlockSeal `json:"crossSafe"`
+	// BindingDependency is the dependency with the lowest local-safe timestamp,
+	// if that is behind the local-safe timestamp of the chain itself.
+	// Nil if the chain is only bound by its own local-safe progress.
+	BindingDependency *eth.ChainID `json:"bindingDependency,omitempty"`
+	// Lag is the number of seconds that the binding dependency is behind the local-safe timestamp of the chain.
+	Lag uint64 `json:"lag"`
+	// DependencyLags is the number of seconds that each dependency is behind the local-safe timestamp of the chain.
+	// Dependencies that are not behind are omitted.
+	DependencyLags map[eth.ChainID]uint64 `json:"dependencyLags"`
+}
+
+// ChainProgress is the derivation progress of a chain, as exported to external SLO tooling.
+// Timestamps are zero when the corresponding head is not known yet.
+type ChainProgress struct {
+	LocalUnsafe uint64
+	CrossUnsafe uint64
+	LocalSafe   uint64
+	CrossSafe   uint64
+	Finalized   uint64
+	// L1Origin is the timestamp of the L1 block that the derivation of the chain has reached.
+	L1Origin uint64
+	// PendingMessages is the number of executing messages in local-unsafe blocks that are not cross-unsafe yet.
+	PendingMessages uint64
+}
+
+// SupervisorSnapshot is a view of all chains, as of a single point in time:
+// no database of any chain was written to while the view was read.
+type SupervisorSnapshot struct {
+	FinalizedL1 eth.BlockRef                  `json:"finalizedL1"`
+	Chains      map[eth.ChainID]ChainSnapshot `json:"chains"`
+}
+
+// ChainSnapshot is the view of a chain in a SupervisorSnapshot.
+// Heads that are not known yet are zero.
+type ChainSnapshot struct {
+	LocalUnsafe BlockSeal            `json:"localUnsafe"`
+	CrossUnsafe BlockSeal            `json:"crossUnsafe"`
+	LocalSafe   DerivedBlockSealPair `json:"localSafe"`
+	CrossSafe   DerivedBlockSealPair `json:"crossSafe"`
+	Finalized   BlockSeal            `json:"finalized"`
+	// PendingMessages is the number of executing messages in local-unsafe blocks that are not cross-unsafe yet.
+	PendingMessages uint64 `json:"pendingMessages"`
+}
+
+// LogLocation identifies a log in a sealed block of a chain.
+type LogLocation struct {
+	ChainID     eth.ChainID `json:"chainID"`
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	LogIndex    uint32      `json:"logIndex"`
+}
+
 type BlockReplacement struct {
 	Replacement eth.BlockRef `json:"replacement"`
 	Invalidated common.Hash  `json:"invalidated"`
 }
 
+// GossipBlockOutcome is the outcome of the processing of a block that a node received via p2p gossip.
+type GossipBlockOutcome string
+
+const (
+	GossipBlockValid   GossipBlockOutcome = "valid"
+	GossipBlockInvalid GossipBlockOutcome = "invalid"
+)
+
+// GossipBlock attributes an unsafe block, that a node received via p2p gossip,
+// to the peer that it was received from.
+type GossipBlock struct {
+	Block eth.BlockRef `json:"block"`
+	// Peer is the ID of the p2p peer that the block was received from.
+	Peer string `json:"peer"`
+	// ReceivedAt is the unix timestamp, in milliseconds, of the arrival of the block.
+	ReceivedAt hexutil.Uint64 `json:"receivedAt"`
+	// Outcome is the outcome of the processing of the block by the node.
+	Outcome GossipBlockOutcome `json:"outcome"`
+}
+
 // ManagedEvent is an event sent by the managed node to the supervisor,
 // to share an update. One of the fields will be non-null; different kinds of updates may be sent.
 type ManagedEvent struct {
@@ -494,6 +607,7 @@ type ManagedEvent struct {
 	ExhaustL1              *DerivedBlockRefPair `json:"exhaustL1,omitempty"`
 	ReplaceBlock           *BlockReplacement    `json:"replaceBlock,omitempty"`
 	DerivationOriginUpdate *eth.BlockRef        `json:"derivationOriginUpdate,omitempty"`
+	GossipBlock            *GossipBlock         `json:"gossipBlock,omitempty"`
 }
 
 // MessageChecksum represents a message checksum, as used for access-list checks.
@@ -307,6 +307,27 @@ func TestChecksumArgs(t *testing.T) {
 	})
 }
 
+func TestChecksumPreimage(t *testing.T) {
+	preimage := ChecksumPreimage{
+		Checksum:    testChecksum,
+		ChainID:     testChainID,
+		BlockNumber: testBlockNumber,
+		LogIndex:    testLogIndex,
+		Timestamp:   testTimestamp,
+		LogHash:     testLogHash,
+	}
+	t.Run("checksum", func(t *testing.T) {
+		require.Equal(t, testChecksum, preimage.ChecksumArgs().Checksum())
+	})
+	t.Run("json roundtrip", func(t *testing.T) {
+		data, err := json.Marshal(preimage)
+		require.NoError(t, err)
+		var out ChecksumPreimage
+		require.NoError(t, json.Unmarshal(data, &out))
+		require.Equal(t, preimage, out)
+	})
+}
+
 func TestIdentifier(t *testing.T) {
 	id := Identifier{
 		Origin:      testOrigin,
@@ -66,8 +66,8 @@ contract MIPS64 is ISemver {
     }
 
     /// @notice The semantic version of the MIPS64 contract.
-    /// @custom:semver 1.7.0
-    string public constant version = "1.7.0";
+    /// @custom:semver 1.8.0
+    string public constant version = "1.8.0";
 
     /// @notice The preimage oracle contract.
     IPreimageOracle internal immutable ORACLE;
@@ -92,8 +92,8 @@ contract MIPS64 is ISemver {
 
     /// @param _oracle The address of the preimage oracle contract.
     constructor(IPreimageOracle _oracle, uint256 _stateVersion) {
-        // Supports VersionMultiThreaded64_v4 (7) and VersionMultiThreaded64_v5 (8)
-        if (_stateVersion != 7 && _stateVersion != 8) {
+        // Supports VersionMultiThreaded64_v4 (7), VersionMultiThreaded64_v5 (8) and VersionMultiThreaded64_v6 (9)
+        if (_stateVersion != 7 && _stateVersion != 8 && _stateVersion != 9) {
             revert UnsupportedStateVersion();
         }
         ORACLE = _oracle;
@@ -264,6 +264,14 @@ contract MIPS64 is ISemver {
                 return handleRMWOps(state, thread, insn, opcode);
             }
 
+            // Handle rdhwr of the thread pointer of the musl runtime
+            if (
+                opcode == ins.OP_SPECIAL3 && fun == ins.FUN_RDHWR
+                    && st.featuresForVersion(STATE_VERSION).supportMuslRuntime
+            ) {
+                return handleRdhwr(thread, insn);
+            }
+
             // Exec the rest of the step logic
             st.CpuScalars memory cpu = getCpuScalars(thread);
             ins.CoreStepLogicParams memory coreStepArgs = ins.CoreStepLogicParams({
@@ -520,12 +528,21 @@ contract MIPS64 is ISemver {
                 v0 = sys.EBADF;
                 v1 = sys.SYS_ERROR_SIGNAL;
             } else if (syscall_no == sys.SYS_CLOCKGETTIME) {
-                if (a0 == sys.CLOCK_GETTIME_REALTIME_FLAG || a0 == sys.CLOCK_GETTIME_MONOTONIC_FLAG) {
+                if (
+                    a0 == sys.CLOCK_GETTIME_REALTIME_FLAG || a0 == sys.CLOCK_GETTIME_MONOTONIC_FLAG
+                        || (
+                            st.featuresForVersion(STATE_VERSION).supportExtendedClockGettime
+                                && (
+                                    a0 == sys.CLOCK_GETTIME_THREAD_CPUTIME_FLAG || a0 == sys.CLOCK_GETTIME_BOOTTIME_FLAG
+                                )
+                        )
+                ) {
                     v0 = 0;
                     v1 = 0;
                     uint64 secs = 0;
                     uint64 nsecs = 0;
-                    if (a0 == sys.CLOCK_GETTIME_MONOTONIC_FLAG) {
+                    // The boot time and the CPU time of the thread are the monotonic time
+                    if (a0 != sys.CLOCK_GETTIME_REALTIME_FLAG) {
                         secs = uint64(state.step / sys.HZ);
                         nsecs = uint64((state.step % sys.HZ) * (1_000_000_000 / sys.HZ));
                     }
@@ -578,7 +595,14 @@ contract MIPS64 is ISemver {
             } else if (syscall_no == sys.SYS_RTSIGPROCMASK) {
                 // ignored
             } else if (syscall_no == sys.SYS_SIGALTSTACK) {
-                // ignored
+                // a0 = ss, a1 = old_ss. The stack is never used, as signals are not delivered.
+                if (st.featuresForVersion(STATE_VERSION).supportMuslRuntime && a1 != 0) {
+                    // A thread has no signal stack
+                    uint64 flagsAddr = a1 + sys.SIGALTSTACK_FLAGS_OFFSET;
+                    storeSubWord(state, flagsAddr, 4, sys.SIGALTSTACK_DISABLE);
+                    handleMemoryUpdate(state, flagsAddr & arch.ADDRESS_MASK);
+                }
+                // Otherwise, ignored (noop)
             } else if (syscall_no == sys.SYS_RTSIGACTION) {
                 // ignored
             } else if (syscall_no == sys.SYS_PRLIMIT64) {
@@ -643,6 +667,15 @@ contract MIPS64 is ISemver {
                 } else {
                     v0 = sys.FD_EVENTFD;
                 }
+            } else if (
+                syscall_no == sys.SYS_SET_THREAD_AREA || syscall_no == sys.SYS_SET_TID_ADDRESS
+                    || syscall_no == sys.SYS_SET_ROBUST_LIST || syscall_no == sys.SYS_GET_ROBUST_LIST
+                    || syscall_no == sys.SYS_POLL || syscall_no == sys.SYS_MREMAP
+            ) {
+                if (!st.featuresForVersion(STATE_VERSION).supportMuslRuntime) {
+                    revert("MIPS64: unimplemented syscall");
+                }
+                (v0, v1) = syscallMuslRuntime(thread, syscall_no, a0, a1);
             } else {
                 revert("MIPS64: unimplemented syscall");
             }
@@ -703,6 +736,51 @@ contract MIPS64 is ISemver {
         }
     }
 
+    /// @notice Handles the syscalls of the musl runtime.
+    function syscallMuslRuntime(
+        ThreadState memory _thread,
+        uint64 _syscallNum,
+        uint64 _a0,
+        uint64 _a1
+    )
+        internal
+        pure
+        returns (uint64 v0_, uint64 v1_)
+    {
+        if (_syscallNum == sys.SYS_SET_THREAD_AREA) {
+            // _a0 = thread pointer
+            _thread.registers[sys.REG_THREAD_POINTER] = _a0;
+        } else if (_syscallNum == sys.SYS_SET_TID_ADDRESS) {
+            // The tid address is not cleared on exit, as threads of musl programs cannot be cloned.
+            v0_ = _thread.threadID;
+        } else if (_syscallNum == sys.SYS_SET_ROBUST_LIST) {
+            // _a0 = head, _a1 = len. The list is not walked on exit, as for set_tid_address.
+            if (_a1 != sys.ROBUST_LIST_HEAD_SIZE) {
+                v0_ = sys.EINVAL;
+                v1_ = sys.SYS_ERROR_SIGNAL;
+            }
+        } else if (_syscallNum == sys.SYS_GET_ROBUST_LIST || _syscallNum == sys.SYS_MREMAP) {
+            // Robust lists are never read back. mremap fails, so that musl's realloc falls back to a new mapping.
+            v0_ = sys.ENOSYS;
+            v1_ = sys.SYS_ERROR_SIGNAL;
+        }
+        // poll: no file descriptor has any event
+    }
+
+    /// @notice Handles rdhwr of the UserLocal hardware register, like the kernel does, by reading the thread pointer.
+    function handleRdhwr(ThreadState memory _thread, uint32 _insn) internal returns (bytes32 out_) {
+        unchecked {
+            if (((_insn >> 11) & 0x1F) != ins.HWR_USER_LOCAL) {
+                revert("MIPS64: invalid instruction");
+            }
+            st.CpuScalars memory cpu = getCpuScalars(_thread);
+            ins.handleRd(cpu, _thread.registers, (_insn >> 16) & 0x1F, _thread.registers[sys.REG_THREAD_POINTER], true);
+            setStateCpuScalars(_thread, cpu);
+            updateCurrentThreadRoot();
+            out_ = outputState();
+        }
+    }
+
     function syscallYield(State memory _state, ThreadState memory _thread) internal returns (bytes32 out_) {
         uint64 v0 = 0;
         uint64 v1 = 0;
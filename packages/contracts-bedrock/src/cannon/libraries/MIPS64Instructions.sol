@@ -14,6 +14,10 @@ library MIPS64Instructions {
     uint32 internal constant OP_LOAD_DOUBLE_LEFT = 0x1A;
     uint32 internal constant OP_LOAD_DOUBLE_RIGHT = 0x1B;
     uint32 internal constant REG_RA = 31;
+    uint32 internal constant OP_SPECIAL3 = 0x1F;
+    uint32 internal constant FUN_RDHWR = 0x3B;
+    /// @notice The hardware register of rdhwr that holds the thread pointer.
+    uint32 internal constant HWR_USER_LOCAL = 29;
     uint64 internal constant U64_MASK = 0xFFFFFFFFFFFFFFFF;
     uint32 internal constant U32_MASK = 0xFFffFFff;
 
@@ -118,7 +122,7 @@ library MIPS64Instructions {
             if (_args.opcode == 0x27 || _args.opcode == 0x1A || _args.opcode == 0x1B) {
                 rt = _args.registers[rtReg];
                 rdReg = rtReg;
-            } else if (_args.opcode == 0 || _args.opcode == 0x1c) {
+            } else if (_args.opcode == 0 || _args.opcode == 0x1c || _args.opcode == 0x1f) {
                 // R-type (stores rd)
                 rt = _args.registers[rtReg];
                 rdReg = uint64((_args.insn >> 11) & 0x1F);
@@ -279,8 +283,12 @@ library MIPS64Instructions {
                     uint32 shiftAmt = (insn >> 6) & 0x1F;
                     return signExtend((rt << shiftAmt) & U32_MASK, 32);
                 }
-                // srl
+                // srl, and rotr with rs=1
                 else if (fun == 0x02) {
+                    // rotr
+                    if (((insn >> 21) & 0x1F) == 1 && st.featuresForVersion(stateVersion).supportRotrSebSeh) {
+                        return signExtend(rotateRight32(rt, (insn >> 6) & 0x1F), 32);
+                    }
                     return signExtend((rt & U32_MASK) >> ((insn >> 6) & 0x1F), 32);
                 }
                 // sra
@@ -293,8 +301,12 @@ library MIPS64Instructions {
                     uint64 shiftAmt = rs & 0x1F;
                     return signExtend((rt << shiftAmt) & U32_MASK, 32);
                 }
-                // srlv
+                // srlv, and rotrv with sa=1
                 else if (fun == 0x6) {
+                    // rotrv
+                    if (((insn >> 6) & 0x1F) == 1 && st.featuresForVersion(stateVersion).supportRotrSebSeh) {
+                        return signExtend(rotateRight32(rt, rs & 0x1F), 32);
+                    }
                     return signExtend((rt & U32_MASK) >> (rs & 0x1F), 32);
                 }
                 // srav
@@ -452,8 +464,12 @@ library MIPS64Instructions {
                 else if (fun == 0x38) {
                     return rt << ((insn >> 6) & 0x1f);
                 }
-                // dsrl
+                // dsrl, and drotr with rs=1
                 else if (fun == 0x3A) {
+                    // drotr
+                    if (((insn >> 21) & 0x1F) == 1 && st.featuresForVersion(stateVersion).supportRotrSebSeh) {
+                        return rotateRight64(rt, (insn >> 6) & 0x1f);
+                    }
                     return rt >> ((insn >> 6) & 0x1f);
                 }
                 // dsra
@@ -464,8 +480,12 @@ library MIPS64Instructions {
                 else if (fun == 0x3c) {
                     return rt << (((insn >> 6) & 0x1f) + 32);
                 }
-                // dsrl32
+                // dsrl32, and drotr32 with rs=1
                 else if (fun == 0x3e) {
+                    // drotr32
+                    if (((insn >> 21) & 0x1F) == 1 && st.featuresForVersion(stateVersion).supportRotrSebSeh) {
+                        return rotateRight64(rt, ((insn >> 6) & 0x1f) + 32);
+                    }
                     return rt >> (((insn >> 6) & 0x1f) + 32);
                 }
                 // dsra32
@@ -506,6 +526,23 @@ library MIPS64Instructions {
                         return i;
                     }
                 }
+                // SPECIAL3
+                else if (opcode == 0x1F) {
+                    // seb, seh: bshfl with rs=0
+                    if (
+                        fun == 0x20 && ((insn >> 21) & 0x1F) == 0
+                            && st.featuresForVersion(stateVersion).supportRotrSebSeh
+                    ) {
+                        // seb
+                        if (((insn >> 6) & 0x1F) == 0x10) {
+                            return signExtend(rt & 0xFF, 8);
+                        }
+                        // seh
+                        else if (((insn >> 6) & 0x1F) == 0x18) {
+                            return signExtend(rt & 0xFFFF, 16);
+                        }
+                    }
+                }
                 // lui
                 else if (opcode == 0x0F) {
                     return signExtend(rt << 16, 32);
@@ -627,6 +664,20 @@ library MIPS64Instructions {
         }
     }
 
+    /// @notice Rotates the lower 32 bits of the value right, and clears the upper 32 bits.
+    function rotateRight32(uint64 _dat, uint64 _shamt) internal pure returns (uint64 out_) {
+        unchecked {
+            return ((_dat & U32_MASK) >> _shamt) | ((_dat << (32 - _shamt)) & U32_MASK);
+        }
+    }
+
+    /// @notice Rotates the value right.
+    function rotateRight64(uint64 _dat, uint64 _shamt) internal pure returns (uint64 out_) {
+        unchecked {
+            return (_dat >> _shamt) | (_dat << (64 - _shamt));
+        }
+    }
+
     /// @notice Extends the value leftwards with its most significant bit (sign extension).
     function signExtend(uint64 _dat, uint64 _idx) internal pure returns (uint64 out_) {
         unchecked {
@@ -17,6 +17,9 @@ library MIPS64State {
         bool supportDclzDclo;
         bool supportNoopMprotect;
         bool supportWorkingSysGetRandom;
+        bool supportExtendedClockGettime;
+        bool supportRotrSebSeh;
+        bool supportMuslRuntime;
     }
 
     function assertExitedIsValid(uint32 _exited) internal pure {
@@ -34,5 +37,10 @@ library MIPS64State {
         if (_version >= 8) {
             features_.supportWorkingSysGetRandom = true;
         }
+        if (_version >= 9) {
+            features_.supportExtendedClockGettime = true;
+            features_.supportRotrSebSeh = true;
+            features_.supportMuslRuntime = true;
+        }
     }
 }
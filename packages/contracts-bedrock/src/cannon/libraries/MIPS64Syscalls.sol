@@ -104,6 +104,13 @@ library MIPS64Syscalls {
     uint32 internal constant SYS_TIMERCREATE = 5216;
     uint32 internal constant SYS_TIMERSETTIME = 5217;
     uint32 internal constant SYS_TIMERDELETE = 5220;
+    // musl runtime syscalls
+    uint32 internal constant SYS_SET_THREAD_AREA = 5242;
+    uint32 internal constant SYS_SET_TID_ADDRESS = 5212;
+    uint32 internal constant SYS_SET_ROBUST_LIST = 5268;
+    uint32 internal constant SYS_GET_ROBUST_LIST = 5269;
+    uint32 internal constant SYS_POLL = 5007;
+    uint32 internal constant SYS_MREMAP = 5024;
 
     uint32 internal constant FD_STDIN = 0;
     uint32 internal constant FD_STDOUT = 1;
@@ -119,14 +126,27 @@ library MIPS64Syscalls {
     uint64 internal constant EINVAL = 0x16;
     uint64 internal constant EAGAIN = 0xb;
     uint64 internal constant ETIMEDOUT = 0x91;
+    uint64 internal constant ENOSYS = 0x59;
 
     uint64 internal constant FUTEX_WAIT_PRIVATE = 128;
     uint64 internal constant FUTEX_WAKE_PRIVATE = 129;
 
+    /// @notice The register that holds the thread pointer of set_thread_area and rdhwr. It is $k1, which is reserved
+    ///         for the kernel, so the layout of the thread state is unchanged.
+    uint64 internal constant REG_THREAD_POINTER = 27;
+    /// @notice The size of the struct robust_list_head of set_robust_list.
+    uint64 internal constant ROBUST_LIST_HEAD_SIZE = 24;
+    /// @notice The SS_DISABLE flag of a stack_t, for threads without a signal stack.
+    uint64 internal constant SIGALTSTACK_DISABLE = 2;
+    /// @notice The offset of ss_flags in a stack_t {ss_sp, ss_size, ss_flags}.
+    uint64 internal constant SIGALTSTACK_FLAGS_OFFSET = 16;
+
     uint64 internal constant SCHED_QUANTUM = 100_000;
     uint64 internal constant HZ = 10_000_000;
     uint64 internal constant CLOCK_GETTIME_REALTIME_FLAG = 0;
     uint64 internal constant CLOCK_GETTIME_MONOTONIC_FLAG = 1;
+    uint64 internal constant CLOCK_GETTIME_THREAD_CPUTIME_FLAG = 3;
+    uint64 internal constant CLOCK_GETTIME_BOOTTIME_FLAG = 7;
     /// @notice Start of the data segment.
     uint64 internal constant PROGRAM_BREAK = 0x00_00_40_00_00_00_00_00;
     uint64 internal constant HEAP_END = 0x00_00_60_00_00_00_00_00;
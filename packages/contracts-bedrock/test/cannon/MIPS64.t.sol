@@ -14,7 +14,7 @@ contract MIPS64_TestInit is Test {
     IPreimageOracle oracle;
 
     // Store some data about acceptable versions
-    uint256[2] validVersions = [7, 8];
+    uint256[3] validVersions = [7, 8, 9];
     mapping(uint256 => bool) public isValidVersion;
     uint256 maxValidVersion;
 